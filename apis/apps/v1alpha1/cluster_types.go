@@ -21,7 +21,9 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -100,6 +102,24 @@ type ClusterSpec struct {
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
+	// Specifies how the cluster works with the service mesh which injects the sidecar proxy into the pods, such as
+	// Istio and Linkerd. If specified, the ports of the pods are excluded from the interception of the sidecar proxy,
+	// the containers of the pods start after the sidecar proxy is ready, and the sidecar proxy is not injected into
+	// the jobs of the cluster, such as the postProvision and backup jobs, so they can complete.
+	//
+	// +optional
+	ServiceMesh *ServiceMeshCompatibility `json:"serviceMesh,omitempty"`
+
+	// Specifies whether to generate the NetworkPolicies to isolate the pods of the cluster from the network.
+	// If specified, a NetworkPolicy is generated for each component, which only allows the traffic:
+	//
+	// - from the clients selected by `clients`, to the service ports of the component.
+	// - from the pods of the same cluster, for the replication traffic between the replicas and the components.
+	// - from the pods of KubeBlocks and the backup jobs, for the probes and the management operations.
+	//
+	// +optional
+	NetworkPolicy *ClusterNetworkPolicy `json:"networkPolicy,omitempty"`
+
 	// !!!!! The following fields may be deprecated in subsequent versions, please DO NOT rely on them for new requirements.
 
 	// Describes how pods are distributed across node.
@@ -146,6 +166,31 @@ type ClusterSpec struct {
 	Backup *ClusterBackup `json:"backup,omitempty"`
 }
 
+// ServiceMeshCompatibility defines how a cluster works with the service mesh.
+type ServiceMeshCompatibility struct {
+	// Specifies the service mesh which injects the sidecar proxy into the pods.
+	//
+	// +kubebuilder:validation:Required
+	Provider ServiceMeshProvider `json:"provider"`
+
+	// Specifies whether to exclude the ports from the interception of the outbound traffic as well, so the replication
+	// and membership traffic between the replicas bypasses the mTLS of the mesh, which may conflict with the TLS
+	// or the protocols of the engine.
+	//
+	// +optional
+	DisableMTLS bool `json:"disableMTLS,omitempty"`
+}
+
+// ClusterNetworkPolicy defines the NetworkPolicies generated for the components of a cluster.
+type ClusterNetworkPolicy struct {
+	// Specifies the clients allowed to access the service ports of the components, which are selected by the labels
+	// of their namespaces and pods, or by the IP blocks.
+	// If not specified, the components can not be accessed by the clients outside the cluster.
+	//
+	// +optional
+	Clients []networkingv1.NetworkPolicyPeer `json:"clients,omitempty"`
+}
+
 type ClusterBackup struct {
 	// Specifies whether automated backup is enabled.
 	//
@@ -467,6 +512,20 @@ type ClusterComponentSpec struct {
 	// +optional
 	Services []ClusterComponentService `json:"services,omitempty"`
 
+	// Enables or disables the sidecars declared in the referenced ClusterComponentDefinition,
+	// the sidecars not listed here are injected according to their defaults.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Sidecars []ClusterComponentSidecar `json:"sidecars,omitempty"`
+
+	// Runs a connection pooler as a sidecar on each pod of the component.
+	// The pooler sidecar is declared in the referenced ClusterComponentDefinition and is injected whenever it's set here.
+	//
+	// +optional
+	ConnectionPooler *ClusterComponentConnectionPooler `json:"connectionPooler,omitempty"`
+
 	// Defines the strategy for switchover and failover when workloadType is Replication.
 	//
 	// +optional
@@ -487,6 +546,12 @@ type ClusterComponentSpec struct {
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
+	// Specifies the name of the PriorityClass of the component's pods.
+	// If not specified, the priorityClassName defined in the pod spec of the referenced definition will be used.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
 	// Defines the update strategy for the component.
 	// Not supported.
 	//
@@ -518,6 +583,66 @@ type ClusterComponentSpec struct {
 	//
 	// +optional
 	Instances []string `json:"instances,omitempty"`
+
+	// Specifies that the component stands for a database running outside of Kubernetes.
+	// If specified, no workload will be created for the component. Instead, KubeBlocks renders the connection
+	// credential Secret and an alias Service from the referenced ServiceDescriptor, and keeps probing the external
+	// endpoint to report the status of the component.
+	//
+	// +optional
+	External *ExternalComponent `json:"external,omitempty"`
+
+	// Specifies whether the pods of the component use the host's network namespace.
+	// It takes effect only if the referenced ComponentDefinition declares the `hostNetwork`, and the container ports
+	// declared there will be allocated automatically to avoid the conflicts with other components on the same node.
+	//
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// Specifies how to recover the pods of the component from the nodes which are NotReady.
+	// If not specified, the pods on NotReady nodes will not be recovered automatically.
+	//
+	// +optional
+	NodeFailureRecovery *NodeFailureRecovery `json:"nodeFailureRecovery,omitempty"`
+
+	// Specifies whether to retain the PVCs of the replicas removed when the component is scaled in.
+	// The replicas leave the membership by the memberLeave action before they are removed, and their PVCs are
+	// deleted by default. The retained PVCs will be reused if the component is scaled out again.
+	//
+	// +optional
+	VolumeClaimRetentionPolicy *VolumeClaimRetentionPolicy `json:"volumeClaimRetentionPolicy,omitempty"`
+
+	// Specifies how to scale the replicas of the component automatically based on metrics.
+	// Once specified, the replicas of the component are managed by the autoscaler and `replicas` is only used
+	// as the initial replicas, so the component can not be scaled by the HorizontalScaling OpsRequest anymore.
+	//
+	// +optional
+	Autoscaling *ComponentAutoscaling `json:"autoscaling,omitempty"`
+
+	// Specifies the time windows to scale the replicas of the component to, such as scaling the read replicas
+	// out on weekdays from 9:00 to 18:00. The component is scaled by the HorizontalScaling OpsRequest when a
+	// window begins and is scaled back to the replicas before the window when it ends.
+	// It can not be used together with `autoscaling`.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ScheduledScaling []ScheduledScalingWindow `json:"scheduledScaling,omitempty"`
+
+	// Specifies whether to publish a stable endpoint for each pod of the component, for the tools which need to
+	// address the members directly, such as the rebalancing utilities.
+	// The endpoints are published to the connection credential Secret of the cluster with the key
+	// 'podEndpoints.<component>', and to the env ConfigMap of the component as `KB_POD_ENDPOINTS`,
+	// as a comma-separated list ordered by the pod ordinals.
+	//
+	// +optional
+	PodEndpoints *PodEndpoints `json:"podEndpoints,omitempty"`
+
+	// Overrides the default security settings of the pods and containers of the component, which are defined
+	// in the ComponentDefinition and the operator. The unset fields fall back to the defaults.
+	//
+	// +optional
+	SecurityContext *SecurityContextDefaults `json:"securityContext,omitempty"`
 }
 
 type ComponentMessageMap map[string]string
@@ -548,6 +673,34 @@ type ClusterComponentStatus struct {
 	//
 	// +optional
 	MembersStatus []workloads.MemberStatus `json:"membersStatus,omitempty"`
+
+	// Records the external endpoints of the LoadBalancer and NodePort services exposed for the component.
+	//
+	// +optional
+	ExposedEndpoints []ExposedEndpoint `json:"exposedEndpoints,omitempty"`
+}
+
+// ExposedEndpoint describes the external address allocated to an exposed service.
+type ExposedEndpoint struct {
+	// Specifies the name of the service.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the type of the service.
+	//
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Lists the external addresses of the service, formatted as 'host:port'.
+	// For LoadBalancer services, the host is the ingress IP or hostname allocated by the provider.
+	// For NodePort services, the hosts are the external IPs (or the internal IPs if absent) of the nodes where the
+	// pods of the component are running, and the address is formatted as ':nodePort' if the nodes are unknown.
+	// The addresses recorded in the Cluster status are also published in the connection credential secret of the
+	// cluster with the key 'endpoint.<name>'.
+	//
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
 }
 
 // ClusterSwitchPolicy defines the switch policy for a cluster.
@@ -570,6 +723,34 @@ type ClusterComponentVolumeClaimTemplate struct {
 	//
 	// +optional
 	Spec PersistentVolumeClaimSpec `json:"spec,omitempty"`
+
+	// Specifies how to expand the volumes automatically when their space usage crosses the threshold.
+	// It takes effect only for the components declared in `spec.componentSpecs` of the cluster.
+	//
+	// +optional
+	AutoExpansion *VolumeAutoExpansion `json:"autoExpansion,omitempty"`
+}
+
+// VolumeAutoExpansion defines how to expand the volumes automatically based on their space usage.
+type VolumeAutoExpansion struct {
+	// Specifies the space usage percentage of the volumes to trigger the expansion.
+	// The volumes are expanded once the space usage of any replica crosses the threshold.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +kubebuilder:default=80
+	// +optional
+	UsageThreshold int32 `json:"usageThreshold,omitempty"`
+
+	// Specifies the size to add to the volumes for each expansion.
+	//
+	// +kubebuilder:validation:Required
+	Step resource.Quantity `json:"step"`
+
+	// Specifies the max size which the volumes can be expanded to.
+	//
+	// +kubebuilder:validation:Required
+	MaxSize resource.Quantity `json:"maxSize"`
 }
 
 func (r *ClusterComponentVolumeClaimTemplate) toVolumeClaimTemplate() corev1.PersistentVolumeClaimTemplate {
@@ -660,6 +841,45 @@ type Affinity struct {
 	// +kubebuilder:default=SharedNode
 	// +optional
 	Tenancy TenancyType `json:"tenancy,omitempty"`
+
+	// Indicates that pods of the DedicatedNode tenancy must be scheduled to the nodes dedicated to database pods,
+	// which are labeled and tainted with the key `kubeblocks.io/dedicated-node`.
+	// The component is not provisioned until such nodes exist. It takes effect only if the tenancy is DedicatedNode.
+	//
+	// +optional
+	DedicatedNodes bool `json:"dedicatedNodes,omitempty"`
+
+	// Specifies the topology spread constraints of pods within a component explicitly.
+	// If specified, they take the place of the constraints derived from `topologyKeys` and `podAntiAffinity`.
+	// The label selector of each constraint is generated automatically to select the pods of the component.
+	//
+	// +listType=map
+	// +listMapKey=topologyKey
+	// +optional
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// TopologySpreadConstraint specifies how to spread the pods of a component among the given topology.
+type TopologySpreadConstraint struct {
+	// The key of node labels, nodes with a label containing this key and identical values are considered
+	// to be in the same topology, e.g. `kubernetes.io/hostname` or `topology.kubernetes.io/zone`.
+	//
+	// +kubebuilder:validation:Required
+	TopologyKey string `json:"topologyKey"`
+
+	// Describes the degree to which pods may be unevenly distributed.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+
+	// Indicates how to deal with a pod if it doesn't satisfy the spread constraint.
+	//
+	// +kubebuilder:validation:Enum={DoNotSchedule,ScheduleAnyway}
+	// +kubebuilder:default=ScheduleAnyway
+	// +optional
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
 }
 
 type TLSConfig struct {
@@ -675,7 +895,7 @@ type TLSConfig struct {
 type Issuer struct {
 	// The issuer for TLS certificates.
 	//
-	// +kubebuilder:validation:Enum={KubeBlocks, UserProvided}
+	// +kubebuilder:validation:Enum={KubeBlocks, UserProvided, CertManager}
 	// +kubebuilder:default=KubeBlocks
 	// +kubebuilder:validation:Required
 	Name IssuerName `json:"name"`
@@ -685,6 +905,41 @@ type Issuer struct {
 	//
 	// +optional
 	SecretRef *TLSSecretRef `json:"secretRef,omitempty"`
+
+	// IssuerRef is the reference to the cert-manager Issuer or ClusterIssuer that signs the certificates.
+	// It is required when the issuer is set to CertManager.
+	//
+	// +optional
+	IssuerRef *CertManagerIssuerRef `json:"issuerRef,omitempty"`
+
+	// ClientCert indicates whether to request a client certificate from cert-manager as well,
+	// it will be mounted into pods alongside the server certificate.
+	// It only takes effect when the issuer is set to CertManager.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	ClientCert bool `json:"clientCert,omitempty"`
+}
+
+// CertManagerIssuerRef defines the reference to a cert-manager issuer.
+type CertManagerIssuerRef struct {
+	// Name of the issuer.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Kind of the issuer, Issuer or ClusterIssuer.
+	//
+	// +kubebuilder:validation:Enum={Issuer,ClusterIssuer}
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer, defaults to cert-manager.io.
+	//
+	// +kubebuilder:default=cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // TLSSecretRef defines Secret contains Tls certs
@@ -706,6 +961,7 @@ type TLSSecretRef struct {
 	Key string `json:"key"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.annotationProfile) || (has(self.serviceType) && self.serviceType == 'LoadBalancer')",message="annotationProfile is only supported by the LoadBalancer service"
 type ClusterComponentService struct {
 	// The name of the service.
 	//
@@ -736,6 +992,106 @@ type ClusterComponentService struct {
 	//
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Specifies the name of a predefined profile of the cloud provider specific annotations for the LoadBalancer
+	// service, so that the database can be exposed without knowing the annotation keys of the cloud provider:
+	//
+	// - `aws-nlb-internal`: an internal Network Load Balancer of AWS.
+	// - `aws-nlb-internet`: an internet-facing Network Load Balancer of AWS.
+	// - `gcp-internal`: an internal passthrough Network Load Balancer of GCP.
+	// - `aliyun-slb-intranet`: an intranet Server Load Balancer of Alibaba Cloud.
+	// - `aliyun-slb-internet`: an internet Server Load Balancer of Alibaba Cloud.
+	// - `azure-internal`: an internal Load Balancer of Azure.
+	// - `azure-internet`: a public Load Balancer of Azure.
+	//
+	// The annotations of the profile are merged with `annotations`, and the latter take precedence.
+	//
+	// +optional
+	AnnotationProfile LoadBalancerAnnotationProfile `json:"annotationProfile,omitempty"`
+
+	// Pins the node ports of the service when ServiceType is NodePort or LoadBalancer, keyed by the name of the service port.
+	//
+	// +optional
+	NodePorts map[string]int32 `json:"nodePorts,omitempty"`
+
+	// Specifies how to allocate the node ports which are not specified explicitly when ServiceType is NodePort or LoadBalancer.
+	//
+	// - `Random`: the node ports are allocated by Kubernetes randomly, which is the default.
+	// - `Deterministic`: the node ports are derived from the hash of the namespace, the service name and the port name
+	//   in the range 30000-32767, so the same node ports are allocated if the service is re-created.
+	//   The node ports need to be specified explicitly if they conflict with those of other services.
+	//
+	// +optional
+	NodePortAllocation NodePortAllocationPolicy `json:"nodePortAllocation,omitempty"`
+
+	// Specifies the IP family policy of the service, such as SingleStack, PreferDualStack and RequireDualStack,
+	// for the IPv6 and dual-stack Kubernetes clusters. If not specified, it is defaulted by Kubernetes.
+	// More info: https://kubernetes.io/docs/concepts/services-networking/dual-stack/#services.
+	//
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// Specifies the IP families, IPv4 and IPv6, of the service in order.
+	// If not specified, it is defaulted by Kubernetes according to `ipFamilyPolicy`.
+	//
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=atomic
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+}
+
+// ClusterComponentSidecar enables or disables a sidecar declared in the ClusterComponentDefinition.
+type ClusterComponentSidecar struct {
+	// The name of the sidecar declared in `ClusterDefinition.spec.componentDefs[*].sidecars`.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies whether the sidecar is injected into the pods of the component.
+	//
+	// +kubebuilder:validation:Required
+	Enabled bool `json:"enabled"`
+}
+
+// PoolMode defines when a server connection is released back to the pool by the connection pooler.
+//
+// +enum
+// +kubebuilder:validation:Enum={Session,Transaction,Statement}
+type PoolMode string
+
+const (
+	SessionPoolMode     PoolMode = "Session"
+	TransactionPoolMode PoolMode = "Transaction"
+	StatementPoolMode   PoolMode = "Statement"
+)
+
+// ClusterComponentConnectionPooler defines the connection pooler running as a sidecar of the component.
+// The pool settings are passed to the pooler container as the env vars `KB_POOLER_MODE`, `KB_POOLER_MAX_CLIENT_CONN`
+// and `KB_POOLER_DEFAULT_POOL_SIZE`, while the topology of the component is available as the same `KB_*_LEADER` and
+// `KB_*_FOLLOWERS` env vars injected into all containers.
+type ClusterComponentConnectionPooler struct {
+	// The name of the sidecar declared in `ClusterDefinition.spec.componentDefs[*].sidecars` which runs the pooler.
+	//
+	// +kubebuilder:validation:Required
+	Sidecar string `json:"sidecar"`
+
+	// Specifies when a server connection is released back to the pool.
+	//
+	// +kubebuilder:default=Session
+	// +optional
+	PoolMode PoolMode `json:"poolMode,omitempty"`
+
+	// The maximum number of client connections allowed by the pooler.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxClientConnections *int32 `json:"maxClientConnections,omitempty"`
+
+	// The number of server connections allowed per user and database pair.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DefaultPoolSize *int32 `json:"defaultPoolSize,omitempty"`
 }
 
 type ClassDefRef struct {
@@ -815,6 +1171,184 @@ type ServiceRef struct {
 	ServiceDescriptor string `json:"serviceDescriptor,omitempty"`
 }
 
+// NodeFailureRecovery defines how to recover the pods of a component from the failed nodes.
+type NodeFailureRecovery struct {
+	// Specifies how long a node should stay NotReady before the pods on it are force deleted to be rescheduled.
+	//
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:default=300
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Specifies whether to detach the volumes of the force deleted pods from the NotReady node,
+	// so that the volumes can be attached to the new node immediately.
+	//
+	// +optional
+	DetachVolumes bool `json:"detachVolumes,omitempty"`
+}
+
+// VolumeClaimRetentionPolicy defines the retention of the PVCs of a component.
+type VolumeClaimRetentionPolicy struct {
+	// Specifies what happens to the PVCs of the replicas removed when the component is scaled in.
+	//
+	// - `Delete`: the PVCs are deleted after the replicas are removed.
+	// - `Retain`: the PVCs are retained.
+	//
+	// +kubebuilder:default=Delete
+	// +optional
+	WhenScaled VolumeClaimRetentionPolicyType `json:"whenScaled,omitempty"`
+}
+
+// PodEndpoints defines how to publish the stable endpoints of the pods of a component.
+type PodEndpoints struct {
+	// Specifies how the endpoints of the pods are published.
+	//
+	// - `Headless`: publishes the FQDNs of the pods resolved by the headless Service of the component.
+	// - `Service`: creates a ClusterIP Service for each pod, named after the pod, and publishes the FQDNs of these Services.
+	//
+	// +kubebuilder:default=Headless
+	// +optional
+	Type PodEndpointsType `json:"type,omitempty"`
+}
+
+// ComponentAutoscaling defines how to scale the replicas of a component automatically.
+// A HorizontalPodAutoscaler is created for the component, which scales the component by its scale subresource.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas cannot be greater than maxReplicas"
+type ComponentAutoscaling struct {
+	// Specifies the lower limit of the replicas that the autoscaler can scale in to.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Specifies the upper limit of the replicas that the autoscaler can scale out to.
+	// It cannot be less than `minReplicas`.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Specifies the metrics used to calculate the desired replicas, such as the CPU utilization of the pods,
+	// or custom metrics like connections and replication lag exposed by the custom metrics API.
+	// The largest replicas calculated from the metrics is used.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	Metrics []autoscalingv2.MetricSpec `json:"metrics"`
+
+	// Specifies the number of seconds to wait after the last scaling before scaling out again.
+	// Scaling out a stateful component is expensive, as the data of the new replicas needs to be provisioned,
+	// so the component is scaled out by at most `scaleOutStep` replicas within the cool-down period.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +kubebuilder:default=600
+	// +optional
+	ScaleOutCooldownSeconds *int32 `json:"scaleOutCooldownSeconds,omitempty"`
+
+	// Specifies the number of seconds to wait after the last scaling before scaling in again.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +kubebuilder:default=300
+	// +optional
+	ScaleInCooldownSeconds *int32 `json:"scaleInCooldownSeconds,omitempty"`
+
+	// Specifies the max number of replicas to add within a scale-out cool-down period.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	ScaleOutStep *int32 `json:"scaleOutStep,omitempty"`
+
+	// Specifies the max number of replicas to remove within a scale-in cool-down period.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	ScaleInStep *int32 `json:"scaleInStep,omitempty"`
+}
+
+// Weekday defines the day of the week.
+// +enum
+// +kubebuilder:validation:Enum={Mon,Tue,Wed,Thu,Fri,Sat,Sun}
+type Weekday string
+
+const (
+	Monday    Weekday = "Mon"
+	Tuesday   Weekday = "Tue"
+	Wednesday Weekday = "Wed"
+	Thursday  Weekday = "Thu"
+	Friday    Weekday = "Fri"
+	Saturday  Weekday = "Sat"
+	Sunday    Weekday = "Sun"
+)
+
+// ScheduledScalingWindow defines a time window in which the component is scaled to the specified replicas.
+type ScheduledScalingWindow struct {
+	// Specifies the name of the window, which is unique in the component.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the days of the week on which the window begins. The window begins every day if not specified.
+	//
+	// +listType=set
+	// +optional
+	Days []Weekday `json:"days,omitempty"`
+
+	// Specifies the time of the day when the window begins, in the format of HH:MM.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// Specifies the time of the day when the window ends, in the format of HH:MM.
+	// The window ends on the next day if it is not later than `startTime`.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime"`
+
+	// Specifies the IANA time zone of `startTime` and `endTime`, such as "Asia/Shanghai". Defaults to UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Specifies the replicas of the component in the window.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32 `json:"replicas"`
+}
+
+// ExternalComponent defines a component whose database is running outside of Kubernetes.
+type ExternalComponent struct {
+	// Specifies the name of the ServiceDescriptor object which describes the endpoint, port and credential of the
+	// external database. The ServiceDescriptor must be in the same namespace as the cluster.
+	//
+	// +kubebuilder:validation:Required
+	ServiceDescriptor string `json:"serviceDescriptor"`
+
+	// Specifies how often (in seconds) to probe the external endpoint.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +optional
+	ProbePeriodSeconds int32 `json:"probePeriodSeconds,omitempty"`
+
+	// Specifies the number of seconds after which the probe of the external endpoint times out.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	ProbeTimeoutSeconds int32 `json:"probeTimeoutSeconds,omitempty"`
+}
+
 // +genclient
 // +k8s:openapi-gen=true
 // +kubebuilder:object:root=true
@@ -915,6 +1449,39 @@ func (r *Cluster) GetVolumeClaimNames(compName string, claimNames ...string) []s
 	return pvcNames
 }
 
+// totalReplicas returns the total replicas of all components and shards in the cluster.
+func (r ClusterSpec) totalReplicas() int32 {
+	var replicas int32
+	for _, comp := range r.ComponentSpecs {
+		replicas += comp.Replicas
+	}
+	for _, sharding := range r.ShardingSpecs {
+		replicas += sharding.Template.Replicas * sharding.Shards
+	}
+	return replicas
+}
+
+// totalStorage returns the total storage requested by the volume claim templates of all components and shards in the cluster.
+func (r ClusterSpec) totalStorage() resource.Quantity {
+	storage := resource.Quantity{}
+	compStorage := func(comp ClusterComponentSpec, shards int32) {
+		for _, vct := range comp.VolumeClaimTemplates {
+			request, ok := vct.Spec.Resources.Requests[corev1.ResourceStorage]
+			if !ok {
+				continue
+			}
+			storage.Add(*resource.NewQuantity(request.Value()*int64(comp.Replicas*shards), request.Format))
+		}
+	}
+	for _, comp := range r.ComponentSpecs {
+		compStorage(comp, 1)
+	}
+	for _, sharding := range r.ShardingSpecs {
+		compStorage(sharding.Template, sharding.Shards)
+	}
+	return storage
+}
+
 // GetComponentByName gets component by name.
 func (r ClusterSpec) GetComponentByName(componentName string) *ClusterComponentSpec {
 	for _, v := range r.ComponentSpecs {
@@ -1030,6 +1597,59 @@ func (r *ClusterComponentSpec) ToVolumeClaimTemplates() []corev1.PersistentVolum
 	return ts
 }
 
+// GetAnnotations returns the annotations of the service, which are merged from the annotation profile and r.Annotations.
+func (r ClusterComponentService) GetAnnotations() map[string]string {
+	profileAnnotations := r.AnnotationProfile.Annotations()
+	if len(profileAnnotations) == 0 {
+		return r.Annotations
+	}
+	annotations := make(map[string]string, len(profileAnnotations)+len(r.Annotations))
+	for k, v := range profileAnnotations {
+		annotations[k] = v
+	}
+	for k, v := range r.Annotations {
+		annotations[k] = v
+	}
+	return annotations
+}
+
+// Annotations returns the cloud provider specific annotations of the profile.
+func (p LoadBalancerAnnotationProfile) Annotations() map[string]string {
+	switch p {
+	case AWSNLBInternalProfile:
+		return map[string]string{
+			"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb",
+			"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+		}
+	case AWSNLBInternetProfile:
+		return map[string]string{
+			"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb",
+			"service.beta.kubernetes.io/aws-load-balancer-internal": "false",
+		}
+	case GCPInternalProfile:
+		return map[string]string{
+			"networking.gke.io/load-balancer-type": "Internal",
+		}
+	case AliyunSLBIntranetProfile:
+		return map[string]string{
+			"service.beta.kubernetes.io/alibaba-cloud-loadbalancer-address-type": "intranet",
+		}
+	case AliyunSLBInternetProfile:
+		return map[string]string{
+			"service.beta.kubernetes.io/alibaba-cloud-loadbalancer-address-type": "internet",
+		}
+	case AzureInternalProfile:
+		return map[string]string{
+			"service.beta.kubernetes.io/azure-load-balancer-internal": "true",
+		}
+	case AzureInternetProfile:
+		return map[string]string{
+			"service.beta.kubernetes.io/azure-load-balancer-internal": "false",
+		}
+	}
+	return nil
+}
+
 // GetClusterUpRunningPhases returns Cluster running or partially running phases.
 func GetClusterUpRunningPhases() []ClusterPhase {
 	return []ClusterPhase{
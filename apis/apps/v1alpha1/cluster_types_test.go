@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 
@@ -77,6 +78,29 @@ var _ = Describe("", func() {
 		Expect(r.getStorageClassName(preferSC)).Should(BeEquivalentTo(&scName))
 	})
 
+	It("test totalReplicas and totalStorage", func() {
+		vct := ClusterComponentVolumeClaimTemplate{
+			Name: "data",
+			Spec: PersistentVolumeClaimSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			},
+		}
+		spec := ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{
+				{Name: "comp1", Replicas: 3, VolumeClaimTemplates: []ClusterComponentVolumeClaimTemplate{vct}},
+				{Name: "comp2", Replicas: 1},
+			},
+			ShardingSpecs: []ShardingSpec{
+				{Name: "shard", Shards: 2, Template: ClusterComponentSpec{Replicas: 2, VolumeClaimTemplates: []ClusterComponentVolumeClaimTemplate{vct}}},
+			},
+		}
+		Expect(spec.totalReplicas()).Should(BeEquivalentTo(8))
+		storage := spec.totalStorage()
+		Expect(storage.Cmp(resource.MustParse("70Gi"))).Should(Equal(0))
+	})
+
 	It("test IsDeleting", func() {
 		r := Cluster{}
 		Expect(r.IsDeleting()).Should(Equal(false))
@@ -334,3 +358,27 @@ func TestGetComponentOrName(t *testing.T) {
 		t.Error("function GetComponentByName should return nil")
 	}
 }
+
+func TestClusterComponentServiceGetAnnotations(t *testing.T) {
+	svc := ClusterComponentService{
+		Name:        "vpc",
+		ServiceType: "LoadBalancer",
+		Annotations: map[string]string{"foo": "bar"},
+	}
+	if annotations := svc.GetAnnotations(); len(annotations) != 1 || annotations["foo"] != "bar" {
+		t.Errorf("the annotations without profile are not expected: %v", annotations)
+	}
+
+	svc.AnnotationProfile = AWSNLBInternalProfile
+	svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"] = "false"
+	annotations := svc.GetAnnotations()
+	if annotations["service.beta.kubernetes.io/aws-load-balancer-type"] != "nlb" {
+		t.Errorf("the annotations of the profile should be merged: %v", annotations)
+	}
+	if annotations["service.beta.kubernetes.io/aws-load-balancer-internal"] != "false" || annotations["foo"] != "bar" {
+		t.Errorf("the annotations of the service should take precedence: %v", annotations)
+	}
+	if len(svc.Annotations) != 2 {
+		t.Errorf("the annotations of the service should not be changed: %v", svc.Annotations)
+	}
+}
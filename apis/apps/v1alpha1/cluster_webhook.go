@@ -20,17 +20,26 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
+	"time"
 
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 // log is for logging in this package.
@@ -39,9 +48,39 @@ var clusterlog = logf.Log.WithName("cluster-resource")
 func (r *Cluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(&clusterDefaulter{}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-apps-kubeblocks-io-v1alpha1-cluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=clusters,verbs=create,versions=v1alpha1,name=mcluster.kb.io,admissionReviewVersions=v1
+
+// clusterDefaulter fills the default fields of the Cluster, the errors are returned to reject the request
+// instead of admitting a cluster whose defaults can't be decided.
+type clusterDefaulter struct{}
+
+var _ admission.CustomDefaulter = &clusterDefaulter{}
+
+func (d *clusterDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	cluster := obj.(*Cluster)
+	clusterlog.Info("default", "name", cluster.Name)
+	return cluster.defaultClusterVersionRef(ctx)
+}
+
+// defaultClusterVersionRef fills the default ClusterVersion of the ClusterDefinition if the clusterVersionRef is omitted.
+func (r *Cluster) defaultClusterVersionRef(ctx context.Context) error {
+	if webhookMgr == nil || len(r.Spec.ClusterDefRef) == 0 || len(r.Spec.ClusterVersionRef) > 0 {
+		return nil
+	}
+	defaultClusterVersion, err := getDefaultClusterVersion(ctx, webhookMgr.client, r.Spec.ClusterDefRef, "")
+	if err != nil {
+		return fmt.Errorf("failed to get the default ClusterVersion of ClusterDefinition %s: %s", r.Spec.ClusterDefRef, err.Error())
+	}
+	if defaultClusterVersion != nil {
+		r.Spec.ClusterVersionRef = defaultClusterVersion.Name
+	}
+	return nil
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // +kubebuilder:webhook:path=/validate-apps-kubeblocks-io-v1alpha1-cluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=clusters,verbs=create;update,versions=v1alpha1,name=vcluster.kb.io,admissionReviewVersions=v1
 
@@ -50,7 +89,14 @@ var _ webhook.Validator = &Cluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Cluster) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", r.Name)
-	return nil, r.validate()
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	warnings, err := r.validateClusterVersionDeprecation()
+	if err != nil {
+		return nil, err
+	}
+	return warnings, r.validateNamespaceQuota(nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -63,7 +109,13 @@ func (r *Cluster) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 	if err := r.validate(); err != nil {
 		return nil, err
 	}
-	return nil, r.validateVolumeClaimTemplates(lastCluster)
+	if err := r.validateVolumeClaimTemplates(lastCluster); err != nil {
+		return nil, err
+	}
+	if err := r.validateClusterVersionUpgrade(lastCluster); err != nil {
+		return nil, err
+	}
+	return nil, r.validateNamespaceQuota(lastCluster)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -75,6 +127,114 @@ func (r *Cluster) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// validateNamespaceQuota checks whether the number of clusters, the total replicas and the total requested storage
+// in the namespace exceed the quotas configured by the operator, the cluster itself is counted by its desired spec.
+// On update, only the quotas whose usage is increased by the cluster are checked, so a namespace already over quota
+// can still scale in or shrink its clusters.
+func (r *Cluster) validateNamespaceQuota(lastCluster *Cluster) error {
+	if webhookMgr == nil {
+		return nil
+	}
+	maxClusters := viper.GetInt(constant.CfgKeyClusterQuotaMaxClusters)
+	maxReplicas := viper.GetInt(constant.CfgKeyClusterQuotaMaxReplicas)
+	maxStorage, err := resource.ParseQuantity(viper.GetString(constant.CfgKeyClusterQuotaMaxStorage))
+	if err != nil {
+		maxStorage = resource.Quantity{}
+	}
+	if maxClusters <= 0 && maxReplicas <= 0 && maxStorage.IsZero() {
+		return nil
+	}
+
+	clusterList := &ClusterList{}
+	if err = webhookMgr.client.List(context.Background(), clusterList, client.InNamespace(r.Namespace)); err != nil {
+		return err
+	}
+	clusters, replicas, storage := 1, r.Spec.totalReplicas(), r.Spec.totalStorage()
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if cluster.Name == r.Name || !cluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+		clusters++
+		replicas += cluster.Spec.totalReplicas()
+		storage.Add(cluster.Spec.totalStorage())
+	}
+
+	quotaExceeded := func(format string, args ...any) error {
+		return apierrors.NewForbidden(schema.GroupResource{Group: GroupVersion.Group, Resource: "clusters"},
+			r.Name, fmt.Errorf("exceeded quota of namespace %s: %s", r.Namespace, fmt.Sprintf(format, args...)))
+	}
+	creating, replicasIncreased, storageIncreased := lastCluster == nil, true, true
+	if !creating {
+		currentStorage := r.Spec.totalStorage()
+		replicasIncreased = r.Spec.totalReplicas() > lastCluster.Spec.totalReplicas()
+		storageIncreased = currentStorage.Cmp(lastCluster.Spec.totalStorage()) > 0
+	}
+	if creating && maxClusters > 0 && clusters > maxClusters {
+		return quotaExceeded("clusters requested: %d, limited: %d", clusters, maxClusters)
+	}
+	if replicasIncreased && maxReplicas > 0 && int(replicas) > maxReplicas {
+		return quotaExceeded("replicas requested: %d, limited: %d", replicas, maxReplicas)
+	}
+	if storageIncreased && !maxStorage.IsZero() && storage.Cmp(maxStorage) > 0 {
+		return quotaExceeded("storage requested: %s, limited: %s", storage.String(), maxStorage.String())
+	}
+	return nil
+}
+
+// validateClusterVersionDeprecation warns or rejects the creation of cluster referring to a deprecated ClusterVersion.
+func (r *Cluster) validateClusterVersionDeprecation() (admission.Warnings, error) {
+	if webhookMgr == nil || len(r.Spec.ClusterVersionRef) == 0 {
+		return nil, nil
+	}
+	clusterVersion := &ClusterVersion{}
+	if err := webhookMgr.client.Get(context.Background(), types.NamespacedName{Name: r.Spec.ClusterVersionRef}, clusterVersion); err != nil {
+		// the existence of ClusterVersion is validated in validate()
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	message := clusterVersion.DeprecationMessage()
+	if len(message) == 0 {
+		return nil, nil
+	}
+	if viper.GetBool(constant.CfgKeyRejectDeprecatedClusterVersion) {
+		return nil, newInvalidError(ClusterKind, r.Name, "spec.clusterVersionRef", message)
+	}
+	return admission.Warnings{message}, nil
+}
+
+// validateClusterVersionUpgrade checks whether the cluster can be upgraded from the last ClusterVersion to the new one.
+func (r *Cluster) validateClusterVersionUpgrade(lastCluster *Cluster) error {
+	if webhookMgr == nil || len(lastCluster.Spec.ClusterVersionRef) == 0 || len(r.Spec.ClusterVersionRef) == 0 ||
+		lastCluster.Spec.ClusterVersionRef == r.Spec.ClusterVersionRef {
+		return nil
+	}
+	var (
+		ctx                = context.Background()
+		lastClusterVersion = &ClusterVersion{}
+		clusterVersion     = &ClusterVersion{}
+	)
+	if err := webhookMgr.client.Get(ctx, types.NamespacedName{Name: lastCluster.Spec.ClusterVersionRef}, lastClusterVersion); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := webhookMgr.client.Get(ctx, types.NamespacedName{Name: r.Spec.ClusterVersionRef}, clusterVersion); err != nil {
+		// the existence of the new ClusterVersion is validated in validate()
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := clusterVersion.ValidateUpgradeFrom(lastClusterVersion); err != nil {
+		return newInvalidError(ClusterKind, r.Name, "spec.clusterVersionRef", err.Error())
+	}
+	return nil
+}
+
 // validateVolumeClaimTemplates volumeClaimTemplates is forbidden modification except for storage size.
 func (r *Cluster) validateVolumeClaimTemplates(lastCluster *Cluster) error {
 	var allErrs field.ErrorList
@@ -181,9 +341,16 @@ func (r *Cluster) validateComponents(allErrs *field.ErrorList, clusterDef *Clust
 
 		componentNameMap[v.Name] = struct{}{}
 		r.validateComponentResources(allErrs, v.Resources, i)
+		r.validateComponentScheduledScaling(allErrs, v, i)
+		r.validateComponentExternal(allErrs, v, i)
+		if compDef, ok := componentMap[v.ComponentDefRef]; ok {
+			r.validateComponentSidecars(allErrs, v.Sidecars, compDef, i)
+			r.validateComponentConnectionPooler(allErrs, v, compDef, i)
+		}
 	}
 
 	r.validateComponentTLSSettings(allErrs)
+	r.validateComponentServiceNames(allErrs, clusterDef)
 
 	if len(invalidComponentDefs) > 0 {
 		*allErrs = append(*allErrs, field.NotFound(field.NewPath("spec.components[*].type"),
@@ -191,6 +358,60 @@ func (r *Cluster) validateComponents(allErrs *field.ErrorList, clusterDef *Clust
 	}
 }
 
+// validateComponentServiceNames validates the Service and workload names generated by the naming template of ClusterDefinition
+// are valid DNS-1035 labels and unique among the components.
+func (r *Cluster) validateComponentServiceNames(allErrs *field.ErrorList, clusterDef *ClusterDefinition) {
+	if clusterDef.Spec.NamingTemplate == nil {
+		return
+	}
+	svcNames := make(map[string]string)
+	for i, v := range r.Spec.ComponentSpecs {
+		path := field.NewPath(fmt.Sprintf("spec.components[%d].name", i))
+		svcName := clusterDef.Spec.NamingTemplate.GenerateComponentServiceName(r.Name, v.Name, v.ComponentDefRef, "")
+		for _, msg := range validation.IsDNS1035Label(svcName) {
+			*allErrs = append(*allErrs, field.Invalid(path, v.Name, fmt.Sprintf("generated service name %s is invalid: %s", svcName, msg)))
+		}
+		if other, ok := svcNames[svcName]; ok {
+			*allErrs = append(*allErrs, field.Duplicate(path, fmt.Sprintf("generated service name %s conflicts with component %s", svcName, other)))
+		}
+		svcNames[svcName] = v.Name
+		// the workload is named the same as the service, and its headless service is the longest name derived from it
+		if clusterDef.Spec.NamingTemplate.Workloads {
+			headlessSvcName := clusterDef.Spec.NamingTemplate.GenerateComponentHeadlessServiceName(r.Name, v.Name, v.ComponentDefRef)
+			for _, msg := range validation.IsDNS1035Label(headlessSvcName) {
+				*allErrs = append(*allErrs, field.Invalid(path, v.Name, fmt.Sprintf("generated headless service name %s is invalid: %s", headlessSvcName, msg)))
+			}
+		}
+	}
+}
+
+// validateComponentSidecars validates the sidecars enabled or disabled by the component are declared in the ClusterComponentDefinition.
+func (r *Cluster) validateComponentSidecars(allErrs *field.ErrorList, sidecars []ClusterComponentSidecar, compDef ClusterComponentDefinition, index int) {
+	for j, sidecar := range sidecars {
+		if !slices.ContainsFunc(compDef.Sidecars, func(s SidecarSpec) bool { return s.Name == sidecar.Name }) {
+			*allErrs = append(*allErrs, field.NotFound(field.NewPath(fmt.Sprintf("spec.components[%d].sidecars[%d].name", index, j)),
+				fmt.Sprintf("sidecar %s is not declared in ClusterDefinition.spec.componentDefs[%s].sidecars", sidecar.Name, compDef.Name)))
+		}
+	}
+}
+
+// validateComponentConnectionPooler validates the sidecar running the connection pooler is declared and not disabled.
+func (r *Cluster) validateComponentConnectionPooler(allErrs *field.ErrorList, compSpec ClusterComponentSpec, compDef ClusterComponentDefinition, index int) {
+	pooler := compSpec.ConnectionPooler
+	if pooler == nil {
+		return
+	}
+	path := field.NewPath(fmt.Sprintf("spec.components[%d].connectionPooler.sidecar", index))
+	if !slices.ContainsFunc(compDef.Sidecars, func(s SidecarSpec) bool { return s.Name == pooler.Sidecar }) {
+		*allErrs = append(*allErrs, field.NotFound(path,
+			fmt.Sprintf("sidecar %s is not declared in ClusterDefinition.spec.componentDefs[%s].sidecars", pooler.Sidecar, compDef.Name)))
+		return
+	}
+	if slices.ContainsFunc(compSpec.Sidecars, func(s ClusterComponentSidecar) bool { return s.Name == pooler.Sidecar && !s.Enabled }) {
+		*allErrs = append(*allErrs, field.Invalid(path, pooler.Sidecar, "the sidecar running the connection pooler can not be disabled"))
+	}
+}
+
 // validateComponentResources validate component resources
 func (r *Cluster) validateComponentResources(allErrs *field.ErrorList, resources corev1.ResourceRequirements, index int) {
 	if invalidValue, err := validateVerticalResourceList(resources.Requests); err != nil {
@@ -204,6 +425,43 @@ func (r *Cluster) validateComponentResources(allErrs *field.ErrorList, resources
 	}
 }
 
+// validateComponentScheduledScaling validates the scheduled scaling windows of the component.
+func (r *Cluster) validateComponentScheduledScaling(allErrs *field.ErrorList, compSpec ClusterComponentSpec, index int) {
+	if len(compSpec.ScheduledScaling) == 0 {
+		return
+	}
+	if compSpec.Autoscaling != nil {
+		*allErrs = append(*allErrs, field.Forbidden(field.NewPath(fmt.Sprintf("spec.components[%d].scheduledScaling", index)),
+			"scheduledScaling can not be used together with autoscaling"))
+	}
+	for j, window := range compSpec.ScheduledScaling {
+		if len(window.TimeZone) == 0 {
+			continue
+		}
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.components[%d].scheduledScaling[%d].timeZone", index, j)),
+				window.TimeZone, err.Error()))
+		}
+	}
+}
+
+// validateComponentExternal validates the port of the ServiceDescriptor referenced by the external component
+// is a valid port number, the ServiceDescriptor not found is left to the component controller.
+func (r *Cluster) validateComponentExternal(allErrs *field.ErrorList, compSpec ClusterComponentSpec, index int) {
+	if compSpec.External == nil {
+		return
+	}
+	sd := &ServiceDescriptor{}
+	sdKey := types.NamespacedName{Namespace: r.Namespace, Name: compSpec.External.ServiceDescriptor}
+	if err := webhookMgr.client.Get(context.Background(), sdKey, sd); err != nil || sd.Spec.Port == nil || sd.Spec.Port.ValueFrom != nil {
+		return
+	}
+	if port, err := strconv.Atoi(sd.Spec.Port.Value); err != nil || port <= 0 || port > 65535 {
+		*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.components[%d].external.serviceDescriptor", index)),
+			compSpec.External.ServiceDescriptor, fmt.Sprintf("the port %q of the ServiceDescriptor is not a valid port number", sd.Spec.Port.Value)))
+	}
+}
+
 func (r *Cluster) validateComponentTLSSettings(allErrs *field.ErrorList) {
 	for index, component := range r.Spec.ComponentSpecs {
 		if !component.TLS {
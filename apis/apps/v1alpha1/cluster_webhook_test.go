@@ -26,8 +26,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("cluster webhook", func() {
@@ -201,6 +205,214 @@ var _ = Describe("cluster webhook", func() {
 			Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
 		})
 	})
+
+	Context("namespace quota", func() {
+		BeforeEach(func() {
+			By("By creating a new clusterDefinition")
+			clusterDef, _ := createTestClusterDefinitionObj(clusterDefinitionName)
+			Expect(testCtx.CreateObj(ctx, clusterDef)).Should(Succeed())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterDefinitionName}, clusterDef)).Should(Succeed())
+
+			By("By creating a new clusterVersion")
+			clusterVersion := createTestClusterVersionObj(clusterDefinitionName, clusterVersionName)
+			Expect(testCtx.CreateObj(ctx, clusterVersion)).Should(Succeed())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterVersionName}, clusterVersion)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			viper.Set(constant.CfgKeyClusterQuotaMaxClusters, 0)
+			viper.Set(constant.CfgKeyClusterQuotaMaxReplicas, 0)
+			viper.Set(constant.CfgKeyClusterQuotaMaxStorage, "")
+		})
+
+		It("should reject the creation of clusters over the quota", func() {
+			viper.Set(constant.CfgKeyClusterQuotaMaxClusters, 1)
+			cluster, _ := createTestCluster(clusterDefinitionName, clusterVersionName, clusterName)
+			Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
+
+			another, _ := createTestCluster(clusterDefinitionName, clusterVersionName, clusterName+"-another")
+			err := testCtx.CreateObj(ctx, another)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("exceeded quota"))
+
+			By("By updating the existing cluster, expect succeed")
+			patch := client.MergeFrom(cluster.DeepCopy())
+			cluster.Spec.ComponentSpecs[0].Replicas = 2
+			Expect(k8sClient.Patch(ctx, cluster, patch)).Should(Succeed())
+		})
+
+		It("should reject only the updates increasing the usage over the quota", func() {
+			// the cluster requests 2 replicas and 1Gi storage
+			viper.Set(constant.CfgKeyClusterQuotaMaxReplicas, 3)
+			cluster, _ := createTestCluster(clusterDefinitionName, clusterVersionName, clusterName)
+			Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
+
+			By("By scaling out within the quota, expect succeed")
+			patch := client.MergeFrom(cluster.DeepCopy())
+			cluster.Spec.ComponentSpecs[0].Replicas = 2
+			Expect(k8sClient.Patch(ctx, cluster, patch)).Should(Succeed())
+
+			By("By scaling out over the quota, expect not succeed")
+			patch = client.MergeFrom(cluster.DeepCopy())
+			cluster.Spec.ComponentSpecs[0].Replicas = 3
+			err := k8sClient.Patch(ctx, cluster, patch)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("exceeded quota"))
+			cluster.Spec.ComponentSpecs[0].Replicas = 2
+
+			By("By lowering the quota, the namespace is over quota now")
+			viper.Set(constant.CfgKeyClusterQuotaMaxReplicas, 1)
+			viper.Set(constant.CfgKeyClusterQuotaMaxStorage, "512Mi")
+
+			By("By scaling in, expect succeed")
+			patch = client.MergeFrom(cluster.DeepCopy())
+			cluster.Spec.ComponentSpecs[0].Replicas = 1
+			Expect(k8sClient.Patch(ctx, cluster, patch)).Should(Succeed())
+
+			By("By updating the fields not related to the quota, expect succeed")
+			patch = client.MergeFrom(cluster.DeepCopy())
+			cluster.Spec.ComponentSpecs[0].Resources = corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			}
+			Expect(k8sClient.Patch(ctx, cluster, patch)).Should(Succeed())
+
+			By("By expanding the storage, expect not succeed")
+			patch = client.MergeFrom(cluster.DeepCopy())
+			cluster.Spec.ComponentSpecs[0].VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("2Gi")
+			err = k8sClient.Patch(ctx, cluster, patch)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("exceeded quota"))
+		})
+	})
+
+	Context("default clusterVersion", func() {
+		It("should fill the default clusterVersion of the clusterDefinition if omitted", func() {
+			By("By creating a new clusterDefinition")
+			clusterDef, _ := createTestClusterDefinitionObj(clusterDefinitionName)
+			Expect(testCtx.CreateObj(ctx, clusterDef)).Should(Succeed())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterDefinitionName}, clusterDef)).Should(Succeed())
+
+			By("By creating a default clusterVersion")
+			clusterVersion := createTestClusterVersionObj(clusterDefinitionName, clusterVersionName)
+			clusterVersion.Annotations = map[string]string{constant.DefaultClusterVersionAnnotationKey: "true"}
+			Expect(testCtx.CreateObj(ctx, clusterVersion)).Should(Succeed())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterVersionName}, clusterVersion)).Should(Succeed())
+
+			By("By creating a cluster without clusterVersionRef")
+			cluster, _ := createTestCluster(clusterDefinitionName, "", clusterName)
+			// wait until the default clusterVersion is visible to the webhook
+			Eventually(func(g Gomega) {
+				defaulted := cluster.DeepCopy()
+				g.Expect((&clusterDefaulter{}).Default(ctx, defaulted)).Should(Succeed())
+				g.Expect(defaulted.Spec.ClusterVersionRef).Should(Equal(clusterVersionName))
+			}).Should(Succeed())
+			Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).Should(Succeed())
+			Expect(cluster.Spec.ClusterVersionRef).Should(Equal(clusterVersionName))
+
+			By("By creating a cluster with clusterVersionRef, expect it kept")
+			another, _ := createTestCluster(clusterDefinitionName, clusterVersionName+"-another", clusterName+"-another")
+			Expect((&clusterDefaulter{}).Default(ctx, another)).Should(Succeed())
+			Expect(another.Spec.ClusterVersionRef).Should(Equal(clusterVersionName + "-another"))
+		})
+	})
+
+	Context("deprecated clusterVersion", func() {
+		BeforeEach(func() {
+			By("By creating a new clusterDefinition")
+			clusterDef, _ := createTestClusterDefinitionObj(clusterDefinitionName)
+			Expect(testCtx.CreateObj(ctx, clusterDef)).Should(Succeed())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterDefinitionName}, clusterDef)).Should(Succeed())
+
+			By("By creating a deprecated clusterVersion")
+			clusterVersion := createTestClusterVersionObj(clusterDefinitionName, clusterVersionName)
+			clusterVersion.Spec.Deprecated = true
+			clusterVersion.Spec.ReplacedBy = clusterVersionName + "-new"
+			Expect(testCtx.CreateObj(ctx, clusterVersion)).Should(Succeed())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterVersionName}, clusterVersion)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			viper.Set(constant.CfgKeyRejectDeprecatedClusterVersion, false)
+		})
+
+		It("should warn the creation of clusters referring to the deprecated clusterVersion", func() {
+			cluster, _ := createTestCluster(clusterDefinitionName, clusterVersionName, clusterName)
+			Eventually(func(g Gomega) {
+				warnings, err := cluster.ValidateCreate()
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(warnings).Should(ConsistOf(ContainSubstring("please use %s instead", clusterVersionName+"-new")))
+			}).Should(Succeed())
+			Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
+		})
+
+		It("should reject the creation of clusters referring to the deprecated clusterVersion if configured", func() {
+			viper.Set(constant.CfgKeyRejectDeprecatedClusterVersion, true)
+			cluster, _ := createTestCluster(clusterDefinitionName, clusterVersionName, clusterName)
+			Eventually(func(g Gomega) {
+				err := testCtx.CreateObj(ctx, cluster)
+				g.Expect(err).Should(HaveOccurred())
+				g.Expect(err.Error()).Should(ContainSubstring("ClusterVersion %s is deprecated", clusterVersionName))
+			}).Should(Succeed())
+
+			By("By updating the existing cluster, expect succeed")
+			viper.Set(constant.CfgKeyRejectDeprecatedClusterVersion, false)
+			Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
+			viper.Set(constant.CfgKeyRejectDeprecatedClusterVersion, true)
+			patch := client.MergeFrom(cluster.DeepCopy())
+			cluster.Spec.ComponentSpecs[0].Replicas = 2
+			Expect(k8sClient.Patch(ctx, cluster, patch)).Should(Succeed())
+		})
+	})
+
+	Context("external component validation", func() {
+		var sd *ServiceDescriptor
+
+		BeforeEach(func() {
+			By("By creating a new clusterDefinition")
+			clusterDef, _ := createTestClusterDefinitionObj(clusterDefinitionName)
+			Expect(testCtx.CreateObj(ctx, clusterDef)).Should(Succeed())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterDefinitionName}, clusterDef)).Should(Succeed())
+
+			By("By creating a new clusterVersion")
+			clusterVersion := createTestClusterVersionObj(clusterDefinitionName, clusterVersionName)
+			Expect(testCtx.CreateObj(ctx, clusterVersion)).Should(Succeed())
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Name: clusterVersionName}, clusterVersion)).Should(Succeed())
+
+			By("By creating a new serviceDescriptor")
+			sd = &ServiceDescriptor{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cluster-webhook-external-" + randomStr,
+					Namespace: testCtx.DefaultNamespace,
+				},
+				Spec: ServiceDescriptorSpec{
+					ServiceKind:    "mysql",
+					ServiceVersion: "8.0",
+					Endpoint:       &CredentialVar{Value: "mysql.example.com"},
+					Port:           &CredentialVar{Value: "mysql-port"},
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, sd)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, sd))).Should(Succeed())
+		})
+
+		It("should reject the external component whose ServiceDescriptor port is not a number", func() {
+			cluster, _ := createTestCluster(clusterDefinitionName, clusterVersionName, clusterName)
+			cluster.Spec.ComponentSpecs[1].External = &ExternalComponent{ServiceDescriptor: sd.Name}
+			err := testCtx.CreateObj(ctx, cluster)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not a valid port number"))
+
+			By("By fixing the port of the serviceDescriptor")
+			patch := client.MergeFrom(sd.DeepCopy())
+			sd.Spec.Port.Value = "3306"
+			Expect(k8sClient.Patch(ctx, sd, patch)).Should(Succeed())
+			Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
+		})
+	})
 })
 
 func createTestCluster(clusterDefinitionName, clusterVersionName, clusterName string) (*Cluster, error) {
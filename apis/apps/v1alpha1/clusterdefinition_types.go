@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 // ClusterDefinitionSpec defines the desired state of ClusterDefinition
@@ -63,9 +65,56 @@ type ClusterDefinitionSpec struct {
 	// - `$(SVC_PORT_{PORT-NAME})` is ServicePort's port value with specified port name, i.e, a servicePort JSON struct:
 	//    `{"name": "mysql", "targetPort": "mysqlContainerPort", "port": 3306}`, and `$(SVC_PORT_mysql)` in the
 	//    connection credential value is 3306.
+	// - `$(SVC_FQDN_{SERVICE-NAME})` is the FQDN of the additional service with specified name of the 1st component,
+	//    i.e., `$(SVC_FQDN_readonly)` is the FQDN of the service named `readonly` declared in the cluster component spec.
+	// - `$(NAMESPACE)` is the namespace of the cluster.
+	// - `$(CONN_CREDENTIAL).{KEY}` is the value of another key in the connection credential, it's useful to template
+	//    DSN strings for client libraries, i.e., `mysql://$(CONN_CREDENTIAL).username:$(CONN_CREDENTIAL).password@$(SVC_FQDN):$(SVC_PORT_mysql)`.
+	//
+	// The endpoint related values are regenerated when the services change, while the random and constant values are kept.
 	//
 	// +optional
 	ConnectionCredential map[string]string `json:"connectionCredential,omitempty"`
+
+	// Defines the naming convention of the Services generated for the cluster components,
+	// instead of the fixed `<cluster>-<component>[-<service>]` scheme.
+	// The workloads, their pods and headless Services follow the convention too if `workloads` is enabled.
+	//
+	// +optional
+	NamingTemplate *NamingTemplate `json:"namingTemplate,omitempty"`
+}
+
+// NamingTemplate defines the naming convention of the generated Services and workloads.
+// The generated name follows the pattern `[<prefix>-]<cluster>-<component short name>[-<service>][-<suffix>]`,
+// and must be a valid DNS-1035 label which is no more than 63 characters.
+type NamingTemplate struct {
+	// Specifies the prefix prepended to the generated names.
+	//
+	// +kubebuilder:validation:MaxLength=16
+	// +kubebuilder:validation:Pattern:=`^[a-z]([a-z0-9\-]*[a-z0-9])?$`
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Specifies the suffix appended to the generated names.
+	//
+	// +kubebuilder:validation:MaxLength=16
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// Maps the names of the component definitions (`spec.componentDefs[*].name`) to the short names,
+	// which are used in the generated names instead of the component names.
+	//
+	// +optional
+	ComponentShortNames map[string]string `json:"componentShortNames,omitempty"`
+
+	// Specifies whether the workloads follow the naming convention too, the pods are named `<workload>-<ordinal>`
+	// and the headless Services are named `<workload>-headless`.
+	// Since the pods and their volumes are renamed along with the workloads, the template can't be changed
+	// once it applies to the workloads, and it can't be applied to the workloads of an existing ClusterDefinition.
+	//
+	// +optional
+	Workloads bool `json:"workloads,omitempty"`
 }
 
 // SystemAccountSpec specifies information to create system accounts.
@@ -256,6 +305,44 @@ type MonitorConfig struct {
 	//
 	// +optional
 	Exporter *ExporterConfig `json:"exporterConfig,omitempty"`
+
+	// Specifies the templates of the Grafana dashboards and the Prometheus alert rules of the engine,
+	// which are provisioned for the components with the monitoring enabled.
+	//
+	// +optional
+	Templates *MonitorTemplates `json:"templates,omitempty"`
+}
+
+// MonitorTemplates refers to the ConfigMaps holding the Grafana dashboards and the Prometheus alert rules of the engine.
+// The placeholders `$(NAMESPACE)`, `$(CLUSTER_NAME)` and `$(COMP_NAME)` in them are replaced with the ones of the component,
+// e.g., `mysql_up{namespace="$(NAMESPACE)",app_kubernetes_io_instance="$(CLUSTER_NAME)"} == 0`.
+type MonitorTemplates struct {
+	// Specifies the name of the ConfigMap holding the Grafana dashboards, each key of which is a dashboard in JSON.
+	// The dashboards are provisioned into a ConfigMap of the component labeled with `grafana_dashboard: "1"`,
+	// to be loaded by the dashboard sidecar of Grafana.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	// +optional
+	DashboardsTemplateRef string `json:"dashboardsTemplateRef,omitempty"`
+
+	// Specifies the name of the ConfigMap holding the Prometheus alert rules, each key of which is the rule groups
+	// in YAML in the form of `groups: [...]`, e.g., the rules for the replication lag, the absent leader and the failed backups.
+	// The rules are provisioned as a PrometheusRule of the component if the Prometheus Operator is installed.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	// +optional
+	AlertRulesTemplateRef string `json:"alertRulesTemplateRef,omitempty"`
+
+	// Specifies the namespace of the referenced ConfigMaps.
+	// An empty namespace is equivalent to the "default" namespace.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	// +kubebuilder:default="default"
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type LogConfig struct {
@@ -434,6 +521,17 @@ type ClusterComponentDefinition struct {
 	// +optional
 	PodSpec *corev1.PodSpec `json:"podSpec,omitempty"`
 
+	// Defines the reusable sidecars of the component, such as the metrics exporter, the backup agent or the proxy,
+	// which are injected into the pods of the component.
+	// Each sidecar can be enabled or disabled by the cluster component with `Cluster.spec.componentSpecs[*].sidecars`.
+	//
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Sidecars []SidecarSpec `json:"sidecars,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
 	// Defines the service spec.
 	//
 	// +optional
@@ -517,6 +615,18 @@ type ClusterComponentDefinition struct {
 	// +optional
 	PostStartSpec *PostStartAction `json:"postStartSpec,omitempty"`
 
+	// Defines the command to be executed before the component is terminated, and the command will only be executed once.
+	// The deletion of the component proceeds only after the command is executed successfully.
+	//
+	// +optional
+	PreTerminateSpec *PreTerminateAction `json:"preTerminateSpec,omitempty"`
+
+	// Defines the engine-specific actions invoked by the switchover, failover and scaling of the component.
+	// The promote and demote actions are used to perform the switchover when the switchoverSpec is not defined.
+	//
+	// +optional
+	Actions *ClusterComponentActions `json:"actions,omitempty"`
+
 	// Defines settings to do volume protect.
 	//
 	// +optional
@@ -578,6 +688,51 @@ func (r *ClusterComponentDefinition) GetCommonStatefulSpec() (*StatefulSetSpec,
 	return nil, nil
 }
 
+// SidecarSpec defines a sidecar container which is injected into the pods of the component.
+type SidecarSpec struct {
+	// The name of the sidecar, which is also used as the name of the injected container.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies whether the sidecar is injected if the cluster component doesn't enable or disable it explicitly.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// The container of the sidecar, with its own image, resources and volume mounts.
+	// The name of the container is overridden by the name of the sidecar.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Required
+	Container corev1.Container `json:"container"`
+
+	// The volumes used by the sidecar only, which are added to the pods along with the sidecar.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+}
+
+// IsEnabled checks whether the sidecar is enabled for the cluster component,
+// the sidecar running the connection pooler of the component is always enabled.
+func (r *SidecarSpec) IsEnabled(compSpec *ClusterComponentSpec) bool {
+	if compSpec != nil {
+		if compSpec.ConnectionPooler != nil && compSpec.ConnectionPooler.Sidecar == r.Name {
+			return true
+		}
+		for _, sidecar := range compSpec.Sidecars {
+			if sidecar.Name == r.Name {
+				return sidecar.Enabled
+			}
+		}
+	}
+	return r.Enabled == nil || *r.Enabled
+}
+
 type ServiceSpec struct {
 	// The list of ports that are exposed by this service.
 	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#virtual-ips-and-service-proxies
@@ -991,6 +1146,67 @@ type PostStartAction struct {
 	ScriptSpecSelectors []ScriptSpecSelector `json:"scriptSpecSelectors,omitempty"`
 }
 
+// ClusterComponentActions defines the actions that the switchover, failover and scaling subsystems invoke,
+// forming an engine-agnostic contract between KubeBlocks and the database engine.
+//
+// Besides the environment variables defined in each action, the following variables are injected if applicable:
+//
+// - KB_LEADER_POD: The name of the current leader pod.
+// - KB_TARGET_POD: The name of the target pod of the action, e.g., the candidate to be promoted.
+type ClusterComponentActions struct {
+	// Defines the action to promote the target pod to be the new leader, it is used to perform the switchover
+	// with a specified candidate.
+	//
+	// +optional
+	Promote *Action `json:"promote,omitempty"`
+
+	// Defines the action to demote the current leader, a new leader will be elected among the others,
+	// it is used to perform the switchover without a specified candidate.
+	//
+	// +optional
+	Demote *Action `json:"demote,omitempty"`
+
+	// Defines the action to add a new member to the replication group when scaling out.
+	//
+	// +optional
+	MemberJoin *Action `json:"memberJoin,omitempty"`
+
+	// Defines the action to remove a member from the replication group when scaling in.
+	//
+	// +optional
+	MemberLeave *Action `json:"memberLeave,omitempty"`
+}
+
+type PreTerminateAction struct {
+	// Specifies the pre-terminate command to be executed.
+	//
+	// +kubebuilder:validation:Required
+	CmdExecutorConfig CmdExecutorConfig `json:"cmdExecutorConfig"`
+
+	// Used to select the script that need to be referenced.
+	// When defined, the scripts defined in scriptSpecs can be referenced within the CmdExecutorConfig.
+	//
+	// +optional
+	ScriptSpecSelectors []ScriptSpecSelector `json:"scriptSpecSelectors,omitempty"`
+
+	// Specifies the maximum duration in seconds the pre-terminate command is allowed to run,
+	// the command is considered failed once it's exceeded. No limit if not specified.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Defines the policy to be followed once the pre-terminate command fails or times out:
+	//
+	// - Fail: The deletion of the component is blocked until the command is executed successfully.
+	// - Ignore: The failure is recorded and reported, and the deletion of the component proceeds.
+	//
+	// +kubebuilder:validation:Enum={Ignore,Fail}
+	// +kubebuilder:default=Fail
+	// +optional
+	FailurePolicy FailurePolicyType `json:"failurePolicy,omitempty"`
+}
+
 type SwitchoverSpec struct {
 	// Represents the action of switching over to a specified candidate primary or leader instance.
 	//
@@ -1151,6 +1367,43 @@ func (r *ClusterDefinition) GetComponentDefByName(compDefName string) *ClusterCo
 	return nil
 }
 
+// GenerateComponentServiceName generates the name of the component Service following the naming template.
+func (r *NamingTemplate) GenerateComponentServiceName(clusterName, compName, compDefName, svcName string) string {
+	if r == nil {
+		return constant.GenerateComponentServiceName(clusterName, compName, svcName)
+	}
+	if shortName, ok := r.ComponentShortNames[compDefName]; ok && len(shortName) > 0 {
+		compName = shortName
+	}
+	name := constant.GenerateComponentServiceName(clusterName, compName, svcName)
+	if len(r.Prefix) > 0 {
+		name = fmt.Sprintf("%s-%s", r.Prefix, name)
+	}
+	if len(r.Suffix) > 0 {
+		name = fmt.Sprintf("%s-%s", name, r.Suffix)
+	}
+	return name
+}
+
+// GenerateComponentWorkloadName generates the name of the component workload, which follows the naming template
+// only if it applies to the workloads.
+func (r *NamingTemplate) GenerateComponentWorkloadName(clusterName, compName, compDefName string) string {
+	if r == nil || !r.Workloads {
+		return constant.GenerateRSMNamePattern(clusterName, compName)
+	}
+	return r.GenerateComponentServiceName(clusterName, compName, compDefName, "")
+}
+
+// GenerateComponentHeadlessServiceName generates the name of the headless Service of the component workload.
+func (r *NamingTemplate) GenerateComponentHeadlessServiceName(clusterName, compName, compDefName string) string {
+	return constant.GenerateRSMServiceNamePattern(r.GenerateComponentWorkloadName(clusterName, compName, compDefName))
+}
+
+// GenerateComponentPodName generates the name of the pod with the ordinal of the component workload.
+func (r *NamingTemplate) GenerateComponentPodName(clusterName, compName, compDefName string, ordinal int) string {
+	return fmt.Sprintf("%s-%d", r.GenerateComponentWorkloadName(clusterName, compName, compDefName), ordinal)
+}
+
 // FailurePolicyType specifies the type of failure policy.
 //
 // +enum
@@ -84,6 +84,49 @@ func TestGetComponentDefByName(t *testing.T) {
 	}
 }
 
+func TestGenerateComponentServiceName(t *testing.T) {
+	var template *NamingTemplate
+	if name := template.GenerateComponentServiceName("mycluster", "mysql", "replicasets", ""); name != "mycluster-mysql" {
+		t.Errorf("expected default name mycluster-mysql, got %s", name)
+	}
+	template = &NamingTemplate{
+		Prefix:              "prod",
+		Suffix:              "svc",
+		ComponentShortNames: map[string]string{"replicasets": "rs"},
+	}
+	if name := template.GenerateComponentServiceName("mycluster", "mysql", "replicasets", ""); name != "prod-mycluster-rs-svc" {
+		t.Errorf("expected name prod-mycluster-rs-svc, got %s", name)
+	}
+	if name := template.GenerateComponentServiceName("mycluster", "proxy", "proxy", "read"); name != "prod-mycluster-proxy-read-svc" {
+		t.Errorf("expected name prod-mycluster-proxy-read-svc, got %s", name)
+	}
+}
+
+func TestGenerateComponentWorkloadName(t *testing.T) {
+	var template *NamingTemplate
+	if name := template.GenerateComponentPodName("mycluster", "mysql", "replicasets", 0); name != "mycluster-mysql-0" {
+		t.Errorf("expected default pod name mycluster-mysql-0, got %s", name)
+	}
+	template = &NamingTemplate{
+		Prefix:              "prod",
+		ComponentShortNames: map[string]string{"replicasets": "rs"},
+	}
+	// the workloads are not affected unless enabled
+	if name := template.GenerateComponentWorkloadName("mycluster", "mysql", "replicasets"); name != "mycluster-mysql" {
+		t.Errorf("expected workload name mycluster-mysql, got %s", name)
+	}
+	template.Workloads = true
+	if name := template.GenerateComponentWorkloadName("mycluster", "mysql", "replicasets"); name != "prod-mycluster-rs" {
+		t.Errorf("expected workload name prod-mycluster-rs, got %s", name)
+	}
+	if name := template.GenerateComponentHeadlessServiceName("mycluster", "mysql", "replicasets"); name != "prod-mycluster-rs-headless" {
+		t.Errorf("expected headless service name prod-mycluster-rs-headless, got %s", name)
+	}
+	if name := template.GenerateComponentPodName("mycluster", "mysql", "replicasets", 1); name != "prod-mycluster-rs-1" {
+		t.Errorf("expected pod name prod-mycluster-rs-1, got %s", name)
+	}
+}
+
 var _ = Describe("", func() {
 
 	It("test GetTerminalPhases", func() {
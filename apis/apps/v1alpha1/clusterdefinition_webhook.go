@@ -18,12 +18,19 @@ package v1alpha1
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/pkg/errors"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -40,6 +47,9 @@ var (
 // default values are 60 seconds.
 const DefaultRoleProbeTimeoutAfterPodsReady int32 = 60
 
+// svcPortPlaceholderRegexp matches the `$(SVC_PORT_{PORT-NAME})` placeholders in spec.connectionCredential.
+var svcPortPlaceholderRegexp = regexp.MustCompile(`\$\(SVC_PORT_([^)]+)\)`)
+
 func (r *ClusterDefinition) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
@@ -85,13 +95,18 @@ var _ webhook.Validator = &ClusterDefinition{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterDefinition) ValidateCreate() (admission.Warnings, error) {
 	clusterdefinitionlog.Info("validate create", "name", r.Name)
-	return nil, r.validate()
+	return append(r.validateConnectionCredential(), r.validateNamedPorts()...), r.validate()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterDefinition) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	clusterdefinitionlog.Info("validate update", "name", r.Name)
-	return nil, r.validate()
+	if lastClusterDef, ok := old.(*ClusterDefinition); ok {
+		if err := r.validateNamingTemplateUpdate(lastClusterDef); err != nil {
+			return nil, err
+		}
+	}
+	return append(r.validateConnectionCredential(), r.validateNamedPorts()...), r.validate()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -113,6 +128,8 @@ func (r *ClusterDefinition) validate() error {
 
 	r.validateComponents(&allErrs)
 	r.validateLogFilePatternPrefix(&allErrs)
+	r.validateNamingTemplate(&allErrs)
+	r.validateSidecars(&allErrs)
 
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(
@@ -145,6 +162,142 @@ func (r *ClusterDefinition) validateLogFilePatternPrefix(allErrs *field.ErrorLis
 	}
 }
 
+// validateNamingTemplate validates spec.namingTemplate.componentShortNames refer to the defined components
+// and are valid DNS-1035 labels.
+func (r *ClusterDefinition) validateNamingTemplate(allErrs *field.ErrorList) {
+	if r.Spec.NamingTemplate == nil {
+		return
+	}
+	path := field.NewPath("spec.namingTemplate.componentShortNames")
+	compDefNames := maps.Keys(r.Spec.NamingTemplate.ComponentShortNames)
+	slices.Sort(compDefNames)
+	shortNames := make(map[string]string)
+	for _, compDefName := range compDefNames {
+		shortName := r.Spec.NamingTemplate.ComponentShortNames[compDefName]
+		if r.GetComponentDefByName(compDefName) == nil {
+			*allErrs = append(*allErrs, field.NotFound(path.Key(compDefName), compDefName))
+			continue
+		}
+		for _, msg := range validation.IsDNS1035Label(shortName) {
+			*allErrs = append(*allErrs, field.Invalid(path.Key(compDefName), shortName, msg))
+		}
+		if other, ok := shortNames[shortName]; ok {
+			*allErrs = append(*allErrs, field.Duplicate(path.Key(compDefName),
+				fmt.Sprintf("short name %s is used by component definition %s", shortName, other)))
+		}
+		shortNames[shortName] = compDefName
+	}
+}
+
+// validateNamingTemplateUpdate forbids changing spec.namingTemplate once it applies to the workloads, and applying it to
+// the workloads of an existing ClusterDefinition, since the pods and their volumes of the clusters would be renamed.
+func (r *ClusterDefinition) validateNamingTemplateUpdate(lastClusterDef *ClusterDefinition) error {
+	appliedToWorkloads := func(template *NamingTemplate) bool {
+		return template != nil && template.Workloads
+	}
+	if !appliedToWorkloads(r.Spec.NamingTemplate) && !appliedToWorkloads(lastClusterDef.Spec.NamingTemplate) {
+		return nil
+	}
+	if reflect.DeepEqual(r.Spec.NamingTemplate, lastClusterDef.Spec.NamingTemplate) {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: APIVersion, Kind: ClusterDefinitionKind}, r.Name,
+		field.ErrorList{field.Forbidden(field.NewPath("spec.namingTemplate"),
+			"the naming template applied to the workloads can't be changed, since the pods and volumes would be renamed")})
+}
+
+// validateNamedPorts checks the named ports referenced by spec.componentDefs[*].service and
+// spec.componentDefs[*].monitor are declared in the containers of the component definition.
+// The unresolved ports are returned as warnings rather than errors, since the ClusterVersion may declare them
+// by overriding or adding containers, the ports are verified against the merged containers in ClusterVersion.VerifyPorts.
+func (r *ClusterDefinition) validateNamedPorts() admission.Warnings {
+	var warnings admission.Warnings
+	for i, compDef := range r.Spec.ComponentDefs {
+		// the containers are provided by the ClusterVersion totally
+		if compDef.PodSpec == nil || len(compDef.PodSpec.Containers) == 0 {
+			continue
+		}
+		for _, err := range compDef.validateNamedPorts(field.NewPath("spec.componentDefs").Index(i), compDef.PodSpec.Containers) {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return warnings
+}
+
+// validateSidecars validates the names of spec.componentDefs[*].sidecars don't conflict with the containers of the component definition.
+func (r *ClusterDefinition) validateSidecars(allErrs *field.ErrorList) {
+	for i, compDef := range r.Spec.ComponentDefs {
+		if len(compDef.Sidecars) == 0 || compDef.PodSpec == nil {
+			continue
+		}
+		for j, sidecar := range compDef.Sidecars {
+			hasSameName := func(c corev1.Container) bool { return c.Name == sidecar.Name }
+			if slices.ContainsFunc(compDef.PodSpec.InitContainers, hasSameName) || slices.ContainsFunc(compDef.PodSpec.Containers, hasSameName) {
+				*allErrs = append(*allErrs, field.Duplicate(field.NewPath("spec.componentDefs").Index(i).Child("sidecars").Index(j).Child("name"),
+					fmt.Sprintf("sidecar %s conflicts with the container of the same name", sidecar.Name)))
+			}
+		}
+	}
+}
+
+// validateNamedPorts validates the named ports referenced by the service and the monitor exporter are declared in @containers.
+func (r *ClusterComponentDefinition) validateNamedPorts(path *field.Path, containers []corev1.Container) field.ErrorList {
+	var (
+		allErrs   field.ErrorList
+		portNames = make([]string, 0)
+	)
+	for _, c := range containers {
+		for _, p := range c.Ports {
+			if len(p.Name) > 0 {
+				portNames = append(portNames, p.Name)
+			}
+		}
+	}
+	validate := func(portPath *field.Path, port intstr.IntOrString) {
+		if port.Type != intstr.String || len(port.StrVal) == 0 || slices.Contains(portNames, port.StrVal) {
+			return
+		}
+		allErrs = append(allErrs, field.Invalid(portPath, port.StrVal,
+			fmt.Sprintf("the named port is not declared in the containers, available named ports: %v", portNames)))
+	}
+	if r.Service != nil {
+		for i, p := range r.Service.Ports {
+			validate(path.Child("service", "ports").Index(i).Child("targetPort"), p.TargetPort)
+		}
+	}
+	if r.Monitor != nil && r.Monitor.Exporter != nil {
+		validate(path.Child("monitor", "exporterConfig", "scrapePort"), r.Monitor.Exporter.ScrapePort)
+	}
+	return allErrs
+}
+
+// validateConnectionCredential checks the `$(SVC_PORT_{PORT-NAME})` placeholders in spec.connectionCredential refer to
+// the ports of the 1st component which provides the service, the unresolved placeholders are returned as warnings.
+func (r *ClusterDefinition) validateConnectionCredential() admission.Warnings {
+	var portNames []string
+	for _, compDef := range r.Spec.ComponentDefs {
+		if compDef.Service == nil {
+			continue
+		}
+		for _, p := range compDef.Service.Ports {
+			portNames = append(portNames, p.Name)
+		}
+		break
+	}
+	var warnings admission.Warnings
+	keys := maps.Keys(r.Spec.ConnectionCredential)
+	slices.Sort(keys)
+	for _, key := range keys {
+		for _, match := range svcPortPlaceholderRegexp.FindAllStringSubmatch(r.Spec.ConnectionCredential[key], -1) {
+			if !slices.Contains(portNames, match[1]) {
+				warnings = append(warnings, fmt.Sprintf("spec.connectionCredential[%s]: the service port %s referenced by %s is not defined, available service ports: %v",
+					key, match[1], match[0], portNames))
+			}
+		}
+	}
+	return warnings
+}
+
 // ValidateComponents validate spec.components is legal.
 func (r *ClusterDefinition) validateComponents(allErrs *field.ErrorList) {
 
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -84,6 +85,21 @@ var _ = Describe("clusterDefinition webhook", func() {
 			Expect(testCtx.CreateObj(ctx, clusterDef)).ShouldNot(Succeed())
 		})
 
+		It("Should forbid changing the naming template applied to the workloads", func() {
+			clusterDef, _ := createTestClusterDefinitionObj(clusterDefinitionName)
+			clusterDef.Spec.NamingTemplate = &NamingTemplate{Prefix: "prod", Workloads: true}
+			Expect(testCtx.CreateObj(ctx, clusterDef)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: clusterDefinitionName}, clusterDef)).Should(Succeed())
+
+			By("changing the prefix")
+			clusterDef.Spec.NamingTemplate.Prefix = "dev"
+			Expect(k8sClient.Update(ctx, clusterDef)).ShouldNot(Succeed())
+
+			By("not applying it to the workloads any more")
+			clusterDef.Spec.NamingTemplate = &NamingTemplate{Prefix: "prod"}
+			Expect(k8sClient.Update(ctx, clusterDef)).ShouldNot(Succeed())
+		})
+
 		It("Validate Cluster Definition System Accounts", func() {
 			By("By creating a new clusterDefinition")
 			clusterDef, _ := createTestClusterDefinitionObj3(clusterDefinitionName3)
@@ -213,6 +229,36 @@ var _ = Describe("clusterDefinition webhook", func() {
 			Expect(testCtx.CreateObj(ctx, clusterDef)).Should(Succeed())
 		})
 
+		It("Validate Cluster Definition Named Ports", func() {
+			clusterDef, err := createTestClusterDefinitionObj3(clusterDefinitionName3)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			// the ports may be declared by the ClusterVersion, which verifies them against the merged containers
+			By("By validating a clusterDefinition with undeclared service target port, should warn")
+			clusterDef.Spec.ComponentDefs[0].Service = &ServiceSpec{
+				Ports: []ServicePort{{Name: "mysql", Port: 3306, TargetPort: intstr.FromString("mysqld")}},
+			}
+			warnings, err := clusterDef.ValidateCreate()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(warnings).Should(ContainElement(ContainSubstring("mysqld")))
+
+			By("By validating a clusterDefinition with undeclared exporter scrape port, should warn")
+			clusterDef.Spec.ComponentDefs[0].Service.Ports[0].TargetPort = intstr.FromString("mysql")
+			clusterDef.Spec.ComponentDefs[0].Monitor = &MonitorConfig{
+				Exporter: &ExporterConfig{ScrapePort: intstr.FromString("metrics")},
+			}
+			warnings, err = clusterDef.ValidateCreate()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(warnings).Should(ContainElement(ContainSubstring("metrics")))
+
+			By("By creating a new clusterDefinition with declared ports, should succeed without warnings")
+			clusterDef.Spec.ComponentDefs[0].Monitor.Exporter.ScrapePort = intstr.FromInt(9104)
+			warnings, err = clusterDef.ValidateCreate()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(warnings).Should(BeEmpty())
+			Expect(testCtx.CreateObj(ctx, clusterDef)).Should(Succeed())
+		})
+
 		It("Should webhook validate configSpec", func() {
 			clusterDef, _ := createTestClusterDefinitionObj(clusterDefinitionName + "-cfg-test")
 			tests := []struct {
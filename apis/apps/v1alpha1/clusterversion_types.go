@@ -17,8 +17,16 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/rogpeppe/go-internal/semver"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 // ClusterVersionSpec defines the desired state of ClusterVersion
@@ -38,6 +46,56 @@ type ClusterVersionSpec struct {
 	// +listType=map
 	// +listMapKey=componentDefRef
 	ComponentVersions []ClusterComponentVersion `json:"componentVersions" patchStrategy:"merge,retainKeys" patchMergeKey:"componentDefRef"`
+
+	// Specifies the version of the database engine shipped by this ClusterVersion in semantic versioning, such as `8.0.33`.
+	// It is used to order the ClusterVersions of the same ClusterDefinition, and the upgrade to a lower version
+	// is regarded as a downgrade, which is rejected unless the source ClusterVersion is listed in `upgradableFrom`.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Specifies the names of the ClusterVersions from which the clusters can be upgraded to this ClusterVersion directly.
+	// If specified, the upgrade from any other ClusterVersion is rejected, which forces the clusters to
+	// upgrade through the required intermediate versions.
+	// If not specified, the upgrade from any ClusterVersion with a lower or equal version is allowed.
+	//
+	// +optional
+	UpgradableFrom []string `json:"upgradableFrom,omitempty"`
+
+	// Marks the ClusterVersion as deprecated.
+	// The creation of new Clusters referring to a deprecated ClusterVersion is warned or rejected according to
+	// the configuration of KubeBlocks, while the existing Clusters continue to be reconciled.
+	//
+	// +optional
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Specifies the name of the ClusterVersion which replaces this deprecated ClusterVersion.
+	//
+	// +optional
+	ReplacedBy string `json:"replacedBy,omitempty"`
+
+	// Specifies how the images used by the ClusterVersion are verified.
+	// The ClusterVersion is set to Unavailable if the verification fails, which prevents the clusters from
+	// rolling to untrusted images.
+	//
+	// +optional
+	ImageVerification *ImageVerification `json:"imageVerification,omitempty"`
+}
+
+// ImageVerification defines the verification of the images used by the ClusterVersion.
+// Both the images overridden in the ClusterVersion and the images inherited from the ClusterDefinition are verified.
+type ImageVerification struct {
+	// Requires all images to be pinned by digest, such as `docker.io/apecloud/mysql@sha256:<hex>`,
+	// so that the images can not be changed by re-tagging.
+	//
+	// +optional
+	RequireDigest bool `json:"requireDigest,omitempty"`
+
+	// Specifies the digests of the trusted images, such as `sha256:<hex>`.
+	// If specified, all images must be pinned by one of the digests.
+	//
+	// +optional
+	AllowedDigests []string `json:"allowedDigests,omitempty"`
 }
 
 // ClusterVersionStatus defines the observed state of ClusterVersion
@@ -103,6 +161,43 @@ type ClusterComponentVersion struct {
 	//
 	// +optional
 	SwitchoverSpec *SwitchoverShortSpec `json:"switchoverSpec,omitempty"`
+
+	// Defines the checks to be run before the component is upgraded to this version by an Upgrade OpsRequest,
+	// such as checking the disk space, incompatible settings and the replication health.
+	// The upgrade is aborted with the findings of the checks if any of them fails.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	UpgradePreChecks []UpgradePreCheck `json:"upgradePreChecks,omitempty"`
+}
+
+// UpgradePreCheck defines an engine-specific check which is run before the component is upgraded.
+type UpgradePreCheck struct {
+	// Specifies the name of the check.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the image to run the check.
+	//
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// Specifies the command to run the check. The check fails if the command exits with a non-zero code,
+	// and the message written to /dev/termination-log (or the tail of the log) is reported as the findings.
+	// The environment variables KB_CLUSTER_NAME, KB_COMP_NAME, KB_HOST, KB_USER, KB_PASSWD,
+	// KB_CLUSTER_VERSION and KB_TARGET_CLUSTER_VERSION are provided.
+	//
+	// +kubebuilder:validation:Required
+	Command []string `json:"command"`
+
+	// Specifies the arguments of the command.
+	//
+	// +optional
+	Args []string `json:"args,omitempty"`
 }
 
 // SystemAccountShortSpec represents a condensed version of the SystemAccountSpec.
@@ -125,6 +220,10 @@ type VersionsContext struct {
 	// Provides override values for ClusterDefinition.spec.componentDefs.podSpec.initContainers.
 	// Typically used in scenarios such as updating application container images.
 	//
+	// The init containers with the same name as those in the ClusterDefinition override their attributes in place,
+	// while the others, e.g., a schema migration required by a specific version, are appended after them
+	// in the declared order.
+	//
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +patchMergeKey=name
 	// +patchStrategy=merge
@@ -185,3 +284,178 @@ func (r ClusterVersionSpec) GetDefNameMappingComponents() map[string]*ClusterCom
 	}
 	return m
 }
+
+// ValidateUpgradeFrom checks whether the clusters referring to the source ClusterVersion can be upgraded to this ClusterVersion.
+func (r *ClusterVersion) ValidateUpgradeFrom(source *ClusterVersion) error {
+	if source.Name == r.Name || slices.Contains(r.Spec.UpgradableFrom, source.Name) {
+		return nil
+	}
+	if len(r.Spec.UpgradableFrom) > 0 {
+		return fmt.Errorf("ClusterVersion %s can only be upgraded from %v, please upgrade from %s through the intermediate versions first",
+			r.Name, r.Spec.UpgradableFrom, source.Name)
+	}
+	if len(r.Spec.Version) == 0 || len(source.Spec.Version) == 0 {
+		return nil
+	}
+	if semver.Compare(toSemver(r.Spec.Version), toSemver(source.Spec.Version)) < 0 {
+		return fmt.Errorf("downgrade from ClusterVersion %s(%s) to %s(%s) is not supported",
+			source.Name, source.Spec.Version, r.Name, r.Spec.Version)
+	}
+	return nil
+}
+
+// ValidateRestoreFrom checks whether the backups of the clusters referring to the source ClusterVersion can be
+// restored to the clusters referring to this ClusterVersion. The restoration is allowed only if both ClusterVersions
+// belong to the same ClusterDefinition, and the engine version is not downgraded or changed in major version.
+func (r *ClusterVersion) ValidateRestoreFrom(source *ClusterVersion) error {
+	if source.Name == r.Name {
+		return nil
+	}
+	if source.Spec.ClusterDefinitionRef != r.Spec.ClusterDefinitionRef {
+		return fmt.Errorf("can not restore the backup of ClusterDefinition %s to ClusterVersion %s of ClusterDefinition %s",
+			source.Spec.ClusterDefinitionRef, r.Name, r.Spec.ClusterDefinitionRef)
+	}
+	if len(r.Spec.Version) == 0 || len(source.Spec.Version) == 0 {
+		return nil
+	}
+	sourceVersion, targetVersion := toSemver(source.Spec.Version), toSemver(r.Spec.Version)
+	if semver.Major(sourceVersion) != semver.Major(targetVersion) {
+		return fmt.Errorf("restoring the backup of ClusterVersion %s(%s) to %s(%s) with a different major version is not supported",
+			source.Name, source.Spec.Version, r.Name, r.Spec.Version)
+	}
+	if semver.Compare(targetVersion, sourceVersion) < 0 {
+		return fmt.Errorf("restoring the backup of ClusterVersion %s(%s) to a lower version %s(%s) is not supported",
+			source.Name, source.Spec.Version, r.Name, r.Spec.Version)
+	}
+	return nil
+}
+
+// IsDefault tells whether the ClusterVersion is the default one of the referenced ClusterDefinition,
+// which is marked by the annotation `kubeblocks.io/is-default-cluster-version: "true"`.
+func (r *ClusterVersion) IsDefault() bool {
+	return r.Annotations[constant.DefaultClusterVersionAnnotationKey] == "true"
+}
+
+// DeprecationMessage returns the message which describes the deprecation of the ClusterVersion,
+// an empty string is returned if the ClusterVersion is not deprecated.
+func (r *ClusterVersion) DeprecationMessage() string {
+	if !r.Spec.Deprecated {
+		return ""
+	}
+	if len(r.Spec.ReplacedBy) > 0 {
+		return fmt.Sprintf("ClusterVersion %s is deprecated, please use %s instead", r.Name, r.Spec.ReplacedBy)
+	}
+	return fmt.Sprintf("ClusterVersion %s is deprecated", r.Name)
+}
+
+// VerifyImages verifies the images used by the ClusterVersion according to the image verification policy.
+func (r *ClusterVersion) VerifyImages(clusterDef *ClusterDefinition) error {
+	verification := r.Spec.ImageVerification
+	if verification == nil || (!verification.RequireDigest && len(verification.AllowedDigests) == 0) {
+		return nil
+	}
+	var errs []string
+	for _, image := range r.getImages(clusterDef) {
+		digest := getImageDigest(image)
+		switch {
+		case len(digest) == 0:
+			errs = append(errs, fmt.Sprintf("image %s is not pinned by digest", image))
+		case len(verification.AllowedDigests) > 0 && !slices.Contains(verification.AllowedDigests, digest):
+			errs = append(errs, fmt.Sprintf("digest of image %s is not allowed", image))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("image verification failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// getImages returns the images used by the ClusterVersion, the containers defined in the ClusterDefinition
+// are overridden by the ClusterVersion with the same name.
+func (r *ClusterVersion) getImages(clusterDef *ClusterDefinition) []string {
+	images := make([]string, 0)
+	addImages := func(defContainers, verContainers []corev1.Container) {
+		overridden := map[string]bool{}
+		for _, c := range verContainers {
+			overridden[c.Name] = true
+			if len(c.Image) > 0 {
+				images = append(images, c.Image)
+			}
+		}
+		for _, c := range defContainers {
+			if !overridden[c.Name] && len(c.Image) > 0 {
+				images = append(images, c.Image)
+			}
+		}
+	}
+	for _, compVer := range r.Spec.ComponentVersions {
+		var podSpec *corev1.PodSpec
+		if clusterDef != nil {
+			if compDef := clusterDef.GetComponentDefByName(compVer.ComponentDefRef); compDef != nil && compDef.PodSpec != nil {
+				podSpec = compDef.PodSpec
+			}
+		}
+		if podSpec == nil {
+			podSpec = &corev1.PodSpec{}
+		}
+		addImages(podSpec.InitContainers, compVer.VersionsCtx.InitContainers)
+		addImages(podSpec.Containers, compVer.VersionsCtx.Containers)
+	}
+	return images
+}
+
+// VerifyPorts verifies the named ports referenced by the services and the monitor exporters of the ClusterDefinition
+// are declared in the containers, which are merged from the ClusterDefinition and the ClusterVersion.
+func (r *ClusterVersion) VerifyPorts(clusterDef *ClusterDefinition) error {
+	if clusterDef == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for _, compVer := range r.Spec.ComponentVersions {
+		compDef := clusterDef.GetComponentDefByName(compVer.ComponentDefRef)
+		if compDef == nil {
+			continue
+		}
+		var containers []corev1.Container
+		if compDef.PodSpec != nil {
+			containers = append(containers, compDef.PodSpec.Containers...)
+		}
+		for _, c := range compVer.VersionsCtx.Containers {
+			idx := slices.IndexFunc(containers, func(container corev1.Container) bool {
+				return container.Name == c.Name
+			})
+			switch {
+			case idx < 0:
+				containers = append(containers, c)
+			case len(c.Ports) > 0:
+				containers[idx].Ports = c.Ports
+			}
+		}
+		if len(containers) == 0 {
+			continue
+		}
+		allErrs = append(allErrs, compDef.validateNamedPorts(field.NewPath("clusterDefinition.spec.componentDefs").Key(compDef.Name), containers)...)
+	}
+	if len(allErrs) > 0 {
+		return fmt.Errorf("port verification failed: %s", allErrs.ToAggregate().Error())
+	}
+	return nil
+}
+
+// getImageDigest returns the digest of the image reference, such as `sha256:<hex>`,
+// an empty string is returned if the image is not pinned by digest.
+func getImageDigest(image string) string {
+	idx := strings.LastIndex(image, "@")
+	if idx < 0 {
+		return ""
+	}
+	return image[idx+1:]
+}
+
+// toSemver converts the version to the canonical form with the 'v' prefix required by semver.
+func toSemver(version string) string {
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -133,6 +136,166 @@ spec:
 	g.Expect(noContainersComponents[0]).To(Equal("component2"))
 }
 
+func TestValidateUpgradeFrom(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newClusterVersion := func(name, version string, upgradableFrom ...string) *ClusterVersion {
+		cv := &ClusterVersion{}
+		cv.Name = name
+		cv.Spec.Version = version
+		cv.Spec.UpgradableFrom = upgradableFrom
+		return cv
+	}
+	v1 := newClusterVersion("mysql-8.0.30", "8.0.30")
+	v2 := newClusterVersion("mysql-8.0.33", "8.0.33", "mysql-8.0.30")
+	v3 := newClusterVersion("mysql-8.1.0", "8.1.0", "mysql-8.0.33")
+	v4 := newClusterVersion("mysql-8.2.0", "8.2.0")
+
+	g.Expect(v2.ValidateUpgradeFrom(v1)).Should(Succeed())
+	g.Expect(v3.ValidateUpgradeFrom(v2)).Should(Succeed())
+	g.Expect(v4.ValidateUpgradeFrom(v1)).Should(Succeed())
+	// skip the required intermediate version
+	g.Expect(v3.ValidateUpgradeFrom(v1)).ShouldNot(Succeed())
+	// downgrade
+	g.Expect(v1.ValidateUpgradeFrom(v4)).ShouldNot(Succeed())
+	g.Expect(newClusterVersion("mysql-8.0.30-fix", "8.0.30", "mysql-8.2.0").ValidateUpgradeFrom(v4)).Should(Succeed())
+}
+
+func TestValidateRestoreFrom(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	newClusterVersion := func(name, clusterDef, version string) *ClusterVersion {
+		cv := &ClusterVersion{}
+		cv.Name = name
+		cv.Spec.ClusterDefinitionRef = clusterDef
+		cv.Spec.Version = version
+		return cv
+	}
+	v1 := newClusterVersion("mysql-8.0.30", "mysql", "8.0.30")
+	v2 := newClusterVersion("mysql-8.0.33", "mysql", "8.0.33")
+	v3 := newClusterVersion("mysql-5.7.44", "mysql", "5.7.44")
+
+	g.Expect(v1.ValidateRestoreFrom(v1)).Should(Succeed())
+	g.Expect(v2.ValidateRestoreFrom(v1)).Should(Succeed())
+	g.Expect(newClusterVersion("mysql-latest", "mysql", "").ValidateRestoreFrom(v1)).Should(Succeed())
+	// downgrade
+	g.Expect(v1.ValidateRestoreFrom(v2)).ShouldNot(Succeed())
+	// different major version
+	g.Expect(v1.ValidateRestoreFrom(v3)).ShouldNot(Succeed())
+	// different cluster definition
+	g.Expect(newClusterVersion("pg-14.8.0", "postgresql", "14.8.0").ValidateRestoreFrom(v1)).ShouldNot(Succeed())
+}
+
+func TestDeprecationMessage(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cv := &ClusterVersion{}
+	cv.Name = "mysql-5.7"
+	g.Expect(cv.DeprecationMessage()).Should(BeEmpty())
+	cv.Spec.Deprecated = true
+	g.Expect(cv.DeprecationMessage()).Should(Equal("ClusterVersion mysql-5.7 is deprecated"))
+	cv.Spec.ReplacedBy = "mysql-8.0"
+	g.Expect(cv.DeprecationMessage()).Should(ContainSubstring("please use mysql-8.0 instead"))
+}
+
+func TestVerifyImages(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	digest := "sha256:" + strings.Repeat("a", 64)
+	clusterDef := &ClusterDefinition{
+		Spec: ClusterDefinitionSpec{
+			ComponentDefs: []ClusterComponentDefinition{{
+				Name: "mysql",
+				PodSpec: &corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "mysql", Image: "docker.io/apecloud/mysql:8.0.33"},
+						{Name: "exporter", Image: "docker.io/apecloud/exporter:0.1.0"},
+					},
+				},
+			}},
+		},
+	}
+	cv := &ClusterVersion{
+		Spec: ClusterVersionSpec{
+			ComponentVersions: []ClusterComponentVersion{{
+				ComponentDefRef: "mysql",
+				VersionsCtx: VersionsContext{
+					Containers: []corev1.Container{{Name: "mysql", Image: "docker.io/apecloud/mysql@" + digest}},
+				},
+			}},
+		},
+	}
+	// no verification policy
+	g.Expect(cv.VerifyImages(clusterDef)).Should(Succeed())
+
+	// the image inherited from the ClusterDefinition is not pinned by digest
+	cv.Spec.ImageVerification = &ImageVerification{RequireDigest: true}
+	err := cv.VerifyImages(clusterDef)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(err.Error()).Should(ContainSubstring("exporter:0.1.0 is not pinned by digest"))
+	g.Expect(err.Error()).ShouldNot(ContainSubstring("mysql:8.0.33"))
+
+	cv.Spec.ComponentVersions[0].VersionsCtx.Containers = append(cv.Spec.ComponentVersions[0].VersionsCtx.Containers,
+		corev1.Container{Name: "exporter", Image: "docker.io/apecloud/exporter@" + digest})
+	g.Expect(cv.VerifyImages(clusterDef)).Should(Succeed())
+
+	// the digest is not allowed
+	cv.Spec.ImageVerification.AllowedDigests = []string{"sha256:" + strings.Repeat("b", 64)}
+	g.Expect(cv.VerifyImages(clusterDef)).Should(HaveOccurred())
+	cv.Spec.ImageVerification.AllowedDigests = append(cv.Spec.ImageVerification.AllowedDigests, digest)
+	g.Expect(cv.VerifyImages(clusterDef)).Should(Succeed())
+}
+
+func TestVerifyPorts(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	clusterDef := &ClusterDefinition{
+		Spec: ClusterDefinitionSpec{
+			ComponentDefs: []ClusterComponentDefinition{{
+				Name: "mysql",
+				PodSpec: &corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "mysql",
+						Ports: []corev1.ContainerPort{{Name: "mysql", ContainerPort: 3306}},
+					}},
+				},
+				Service: &ServiceSpec{
+					Ports: []ServicePort{{Name: "mysql", Port: 3306, TargetPort: intstr.FromString("mysql")}},
+				},
+				Monitor: &MonitorConfig{
+					Exporter: &ExporterConfig{ScrapePort: intstr.FromString("metrics")},
+				},
+			}},
+		},
+	}
+	cv := &ClusterVersion{
+		Spec: ClusterVersionSpec{
+			ComponentVersions: []ClusterComponentVersion{{
+				ComponentDefRef: "mysql",
+				VersionsCtx: VersionsContext{
+					Containers: []corev1.Container{{Name: "mysql", Image: "docker.io/apecloud/mysql:8.0.33"}},
+				},
+			}},
+		},
+	}
+	// the exporter container is not declared
+	err := cv.VerifyPorts(clusterDef)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(err.Error()).Should(ContainSubstring("monitor.exporterConfig.scrapePort"))
+	g.Expect(err.Error()).ShouldNot(ContainSubstring("service.ports"))
+
+	// the exporter container is provided by the ClusterVersion
+	cv.Spec.ComponentVersions[0].VersionsCtx.Containers = append(cv.Spec.ComponentVersions[0].VersionsCtx.Containers,
+		corev1.Container{Name: "exporter", Ports: []corev1.ContainerPort{{Name: "metrics", ContainerPort: 9104}}})
+	g.Expect(cv.VerifyPorts(clusterDef)).Should(Succeed())
+
+	// the ports of the container are overridden by the ClusterVersion
+	cv.Spec.ComponentVersions[0].VersionsCtx.Containers[0].Ports = []corev1.ContainerPort{{Name: "mysqld", ContainerPort: 3306}}
+	err = cv.VerifyPorts(clusterDef)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(err.Error()).Should(ContainSubstring("service.ports[0].targetPort"))
+}
+
 var _ = Describe("", func() {
 
 	It("test GetTerminalPhases", func() {
@@ -20,21 +20,29 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
 
+	"github.com/rogpeppe/go-internal/semver"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 // log is for logging in this package.
 var clusterversionlog = logf.Log.WithName("clusterversion-resource")
 
+// imageDigestRegexp matches the image digest, such as sha256:<hex>.
+var imageDigestRegexp = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+
 func (r *ClusterVersion) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
@@ -49,7 +57,10 @@ var _ webhook.Validator = &ClusterVersion{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *ClusterVersion) ValidateCreate() (admission.Warnings, error) {
 	clusterversionlog.Info("validate create", "name", r.Name)
-	return nil, r.validate()
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return nil, r.validateDefault()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -57,10 +68,17 @@ func (r *ClusterVersion) ValidateUpdate(old runtime.Object) (admission.Warnings,
 	clusterversionlog.Info("validate update", "name", r.Name)
 	// determine whether r.spec content is modified
 	lastClusterVersion := old.(*ClusterVersion)
-	if !reflect.DeepEqual(lastClusterVersion.Spec, r.Spec) {
+	// the deprecation of ClusterVersion is allowed to be updated
+	lastSpec, spec := lastClusterVersion.Spec.DeepCopy(), r.Spec.DeepCopy()
+	lastSpec.Deprecated, lastSpec.ReplacedBy = false, ""
+	spec.Deprecated, spec.ReplacedBy = false, ""
+	if !reflect.DeepEqual(lastSpec, spec) {
 		return nil, newInvalidError(ClusterVersionKind, r.Name, "", "ClusterVersion.spec is immutable, you can not update it.")
 	}
-	return nil, nil
+	if err := r.validateReplacedBy(); err != nil {
+		return nil, newInvalidError(ClusterVersionKind, r.Name, "spec.replacedBy", err.Error())
+	}
+	return nil, r.validateDefault()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -99,6 +117,29 @@ func (r *ClusterVersion) validate() error {
 			allErrs = append(allErrs, field.NotFound(field.NewPath("spec.components[*].type"),
 				fmt.Sprintf("containers are not defined in ClusterDefinition.spec.components[*]: %v", noContainersComponents)))
 		}
+
+		if err := r.VerifyPorts(clusterDef); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec.componentVersions[*].versionsContext.containers"),
+				r.Spec.ClusterDefinitionRef, err.Error()))
+		}
+	}
+
+	if len(r.Spec.Version) > 0 && !semver.IsValid(toSemver(r.Spec.Version)) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec.version"),
+			r.Spec.Version, "version should follow semantic versioning"))
+	}
+
+	if err := r.validateReplacedBy(); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec.replacedBy"), r.Spec.ReplacedBy, err.Error()))
+	}
+
+	if r.Spec.ImageVerification != nil {
+		for i, digest := range r.Spec.ImageVerification.AllowedDigests {
+			if !imageDigestRegexp.MatchString(digest) {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec.imageVerification.allowedDigests").Index(i),
+					digest, "digest should be in the format of <algorithm>:<hex>, such as sha256:<hex>"))
+			}
+		}
 	}
 
 	if err := r.validateConfigTemplate(); err != nil {
@@ -113,6 +154,55 @@ func (r *ClusterVersion) validate() error {
 	return nil
 }
 
+// validateDefault validates there is at most one default ClusterVersion for each ClusterDefinition.
+func (r *ClusterVersion) validateDefault() error {
+	if webhookMgr == nil || !r.IsDefault() {
+		return nil
+	}
+	defaultClusterVersion, err := getDefaultClusterVersion(context.Background(), webhookMgr.client, r.Spec.ClusterDefinitionRef, r.Name)
+	if err != nil {
+		return err
+	}
+	if defaultClusterVersion != nil {
+		return newInvalidError(ClusterVersionKind, r.Name, fmt.Sprintf("metadata.annotations[%s]", constant.DefaultClusterVersionAnnotationKey),
+			fmt.Sprintf("ClusterVersion %s is already the default of ClusterDefinition %s", defaultClusterVersion.Name, r.Spec.ClusterDefinitionRef))
+	}
+	return nil
+}
+
+// getDefaultClusterVersion gets the default ClusterVersion of the ClusterDefinition, excluding the ClusterVersion named @excluded.
+func getDefaultClusterVersion(ctx context.Context, cli client.Client, clusterDefRef, excluded string) (*ClusterVersion, error) {
+	clusterVersionList := &ClusterVersionList{}
+	if err := cli.List(ctx, clusterVersionList); err != nil {
+		return nil, err
+	}
+	for i, cv := range clusterVersionList.Items {
+		if cv.Name != excluded && cv.Spec.ClusterDefinitionRef == clusterDefRef && cv.IsDefault() {
+			return &clusterVersionList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// validateReplacedBy validates the replacement of ClusterVersion refers to another ClusterVersion of the same ClusterDefinition.
+func (r *ClusterVersion) validateReplacedBy() error {
+	if webhookMgr == nil || len(r.Spec.ReplacedBy) == 0 {
+		return nil
+	}
+	if r.Spec.ReplacedBy == r.Name {
+		return fmt.Errorf("ClusterVersion can not be replaced by itself")
+	}
+	replacement := &ClusterVersion{}
+	if err := webhookMgr.client.Get(context.Background(), types.NamespacedName{Name: r.Spec.ReplacedBy}, replacement); err != nil {
+		return err
+	}
+	if replacement.Spec.ClusterDefinitionRef != r.Spec.ClusterDefinitionRef {
+		return fmt.Errorf("the replacement ClusterVersion %s refers to a different ClusterDefinition %s",
+			replacement.Name, replacement.Spec.ClusterDefinitionRef)
+	}
+	return nil
+}
+
 func (r *ClusterVersion) validateConfigTemplate() error {
 	for _, c := range r.Spec.ComponentVersions {
 		if len(c.ConfigSpecs) > 1 {
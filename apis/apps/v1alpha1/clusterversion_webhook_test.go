@@ -23,6 +23,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 var _ = Describe("clusterVersion webhook", func() {
@@ -99,6 +101,30 @@ var _ = Describe("clusterVersion webhook", func() {
 			clusterVersion.Spec.ClusterDefinitionRef = "test1"
 			Expect(k8sClient.Patch(ctx, clusterVersion, patch)).ShouldNot(Succeed())
 		})
+
+		It("Should allow only one default clusterVersion for a clusterDefinition", func() {
+			By("By creating a new clusterDefinition")
+			clusterDef, _ := createTestClusterDefinitionObj(clusterDefinitionName)
+			Expect(testCtx.CreateObj(ctx, clusterDef)).Should(Succeed())
+
+			By("By creating a default clusterVersion")
+			clusterVersion := createTestClusterVersionObj(clusterDefinitionName, clusterVersionName)
+			clusterVersion.Annotations = map[string]string{constant.DefaultClusterVersionAnnotationKey: "true"}
+			Expect(testCtx.CheckedCreateObj(ctx, clusterVersion)).Should(Succeed())
+
+			By("By testing create another default clusterVersion")
+			anotherClusterVersion := createTestClusterVersionObj(clusterDefinitionName, clusterVersionName+"-another")
+			anotherClusterVersion.Annotations = map[string]string{constant.DefaultClusterVersionAnnotationKey: "true"}
+			Expect(testCtx.CheckedCreateObj(ctx, anotherClusterVersion)).ShouldNot(Succeed())
+
+			By("By testing deprecate the clusterVersion")
+			anotherClusterVersion.Annotations = nil
+			Expect(testCtx.CheckedCreateObj(ctx, anotherClusterVersion)).Should(Succeed())
+			patch := client.MergeFrom(clusterVersion.DeepCopy())
+			clusterVersion.Spec.Deprecated = true
+			clusterVersion.Spec.ReplacedBy = anotherClusterVersion.Name
+			Expect(k8sClient.Patch(ctx, clusterVersion, patch)).Should(Succeed())
+		})
 	})
 })
 
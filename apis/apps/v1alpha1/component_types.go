@@ -94,6 +94,11 @@ type ComponentSpec struct {
 	// +optional
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 
+	// The name of the PriorityClass of the component's pods.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
 	// Specifies the scheduling constraints for the component's workload.
 	// If specified, it will override the cluster-wide affinity.
 	//
@@ -133,6 +138,42 @@ type ComponentSpec struct {
 	//
 	// +optional
 	Instances []string `json:"instances,omitempty"`
+
+	// Specifies that the component stands for a database running outside of Kubernetes.
+	// No workload will be created for an external component.
+	//
+	// +optional
+	External *ExternalComponent `json:"external,omitempty"`
+
+	// Specifies whether the pods of the component use the host's network namespace.
+	//
+	// +optional
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+
+	// Specifies how to recover the pods of the component from the nodes which are NotReady.
+	//
+	// +optional
+	NodeFailureRecovery *NodeFailureRecovery `json:"nodeFailureRecovery,omitempty"`
+
+	// Specifies whether to retain the PVCs of the replicas removed when the component is scaled in.
+	//
+	// +optional
+	VolumeClaimRetentionPolicy *VolumeClaimRetentionPolicy `json:"volumeClaimRetentionPolicy,omitempty"`
+
+	// Specifies how to scale the replicas of the component automatically based on metrics.
+	//
+	// +optional
+	Autoscaling *ComponentAutoscaling `json:"autoscaling,omitempty"`
+
+	// Specifies whether to publish a stable endpoint for each pod of the component.
+	//
+	// +optional
+	PodEndpoints *PodEndpoints `json:"podEndpoints,omitempty"`
+
+	// Overrides the default security settings of the pods and containers of the component.
+	//
+	// +optional
+	SecurityContext *SecurityContextDefaults `json:"securityContext,omitempty"`
 }
 
 // ComponentStatus represents the observed state of a Component within the cluster.
@@ -165,11 +206,23 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message ComponentMessageMap `json:"message,omitempty"`
+
+	// Records the current number of the replicas of the component, which is used by the scale subresource.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Records the label selector of the pods of the component in string form, which is used by the scale
+	// subresource to find the pods when the component is scaled by the autoscaler.
+	//
+	// +optional
+	Selector string `json:"selector,omitempty"`
 }
 
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
 // +kubebuilder:resource:categories={kubeblocks,all},shortName=cmp
 // +kubebuilder:printcolumn:name="COMPONENT-DEFINITION",type="string",JSONPath=".spec.compDef",description="component definition"
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="status phase"
@@ -251,6 +251,57 @@ type ComponentDefinitionSpec struct {
 	// +kubebuilder:default=0
 	// +optional
 	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// Defines how the rotated TLS certificates are picked up by the component.
+	// - HotReload: the engine reloads the certificates from the mounted files, which are refreshed by the kubelet, by itself.
+	// - RollingRestart: the pods are restarted one by one to load the new certificates.
+	// This field is immutable.
+	//
+	// +kubebuilder:default=RollingRestart
+	// +optional
+	TLSCertReloadPolicy TLSCertReloadPolicy `json:"tlsCertReloadPolicy,omitempty"`
+
+	// Defines the default security settings of the pods and containers of the component.
+	// They take precedence over the defaults of the operator, and can be overridden by the Cluster.
+	// The settings specified in the runtime explicitly are always retained.
+	//
+	// +optional
+	SecurityContext *SecurityContextDefaults `json:"securityContext,omitempty"`
+
+	// Defines the hook executed in the pods around the creation of volume snapshots when backing up the component,
+	// such as flushing and locking the tables, in order to make the snapshots application-consistent.
+	//
+	// +optional
+	SnapshotHook *SnapshotHook `json:"snapshotHook,omitempty"`
+}
+
+// SnapshotHook defines a long-running command that keeps the component quiesced in a single session
+// while the volume snapshots are taken, so that the session-scoped locks (e.g. FLUSH TABLES WITH READ LOCK)
+// are held until the snapshots are created.
+//
+// The command is executed in the pod with the following environment variables:
+//
+// - KB_SNAPSHOT_QUIESCED_FILE: the file the command should create after the component is quiesced.
+// - KB_SNAPSHOT_RESUME_FILE: the file created after the snapshots are taken, the command should wait for it,
+// resume the component in the same session, and then exit.
+type SnapshotHook struct {
+	// Specifies the container to execute the command in.
+	// Defaults to the first container of the pod.
+	//
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Specifies the command to execute.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Specifies the maximum duration the component may stay quiesced.
+	// The command is terminated when it times out, which releases the session-scoped locks, and the backup fails.
+	//
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
 }
 
 // ComponentDefinitionStatus defines the observed state of ComponentDefinition.
@@ -608,6 +659,31 @@ type LifecycleActionHandler struct {
 	CustomHandler *Action `json:"customHandler,omitempty"`
 }
 
+// PreTerminateActionHandler defines the PreTerminate action and the policy to be followed once it fails.
+type PreTerminateActionHandler struct {
+	LifecycleActionHandler `json:",inline"`
+
+	// Defines the policy to be followed once the action fails or times out after all retries:
+	//
+	// - Fail: The deletion of the Component is blocked until the action is executed successfully.
+	// - Ignore: The failure is recorded and reported, and the deletion of the Component proceeds.
+	//
+	// This field cannot be updated.
+	//
+	// +kubebuilder:validation:Enum={Ignore,Fail}
+	// +kubebuilder:default=Fail
+	// +optional
+	FailurePolicy FailurePolicyType `json:"failurePolicy,omitempty"`
+}
+
+// GetLifecycleActionHandler returns the handler of the PreTerminate action, or nil if it is not defined.
+func (r *PreTerminateActionHandler) GetLifecycleActionHandler() *LifecycleActionHandler {
+	if r == nil {
+		return nil
+	}
+	return &r.LifecycleActionHandler
+}
+
 // ComponentLifecycleActions defines a set of operational actions for interacting with component services and processes.
 type ComponentLifecycleActions struct {
 	// Specifies the actions and corresponding policy to be executed when a component is created.
@@ -632,7 +708,7 @@ type ComponentLifecycleActions struct {
 	// This field cannot be updated.
 	//
 	// +optional
-	PreTerminate *LifecycleActionHandler `json:"preTerminate,omitempty"`
+	PreTerminate *PreTerminateActionHandler `json:"preTerminate,omitempty"`
 
 	// RoleProbe defines the mechanism to probe the role of replicas periodically. The specified action will be
 	// executed by Lorry at the configured interval. If the execution is successful, the output will be used as
@@ -653,6 +729,9 @@ type ComponentLifecycleActions struct {
 	// - ROLE: the role of the replica. It must be one of the names defined in the roles.
 	// - ERROR: Any error message if the action fails.
 	//
+	// The custom handler can be an exec command, which may query the DB service with the client in a helper image,
+	// or an HTTP GET request to an endpoint served by the replica, the response body of which is used as the role.
+	//
 	// This field cannot be updated.
 	//
 	// +optional
@@ -673,6 +752,8 @@ type ComponentLifecycleActions struct {
 	// - KB_LEADER_POD_IP: The IP address of the original leader's Pod before switchover.
 	// - KB_LEADER_POD_NAME: The name of the original leader's Pod before switchover.
 	// - KB_LEADER_POD_FQDN: The FQDN of the original leader's Pod before switchover.
+	// - KB_LEADER_POD: The name of the original leader's Pod before switchover, the same as KB_LEADER_POD_NAME.
+	// - KB_TARGET_POD: The name of the new candidate replica's Pod, the same as KB_SWITCHOVER_CANDIDATE_NAME. It may be empty.
 	//
 	// The environment variables with the following prefixes are deprecated and will be removed in the future:
 	//
@@ -821,11 +902,39 @@ type ComponentLifecycleActions struct {
 	// +optional
 	Reconfigure *LifecycleActionHandler `json:"reconfigure,omitempty"`
 
-	// Defines the method to provision accounts.
+	// Defines the method to provision accounts, it's required to manage the users by the DatabaseUser.
+	// The following dedicated environment variables are provided to the action:
+	//
+	// - KB_ACCOUNT_OPERATION: The operation to perform, one of "create", "delete", "describe", "grantRole" and "revokeRole".
+	// - KB_ACCOUNT_NAME: The name of the account.
+	// - KB_ACCOUNT_PASSWORD: The password of the account, for the "create" operation only.
+	// - KB_ACCOUNT_ROLE: The role to grant or revoke, one of "superuser", "readwrite" and "readonly".
+	//
+	// The "describe" operation should print the account in JSON, e.g. {"userName": "foo", "roleName": "readonly"},
+	// or print nothing if the account does not exist.
+	//
+	// Note that only Action.Exec is currently supported.
 	// This field cannot be updated.
 	//
 	// +optional
 	AccountProvision *LifecycleActionHandler `json:"accountProvision,omitempty"`
+
+	// Defines the method to rotate the password of an account, it's required by the PasswordRotation OpsRequest.
+	// The following dedicated environment variables are provided to the action:
+	//
+	// - KB_ACCOUNT_NAME: The name of the account.
+	// - KB_ACCOUNT_PASSWORD: The new password of the account.
+	// - KB_ACCOUNT_OLD_PASSWORD: The old password of the account, it's empty when discarding the old password.
+	// - KB_ACCOUNT_RETAIN_OLD_PASSWORD: "true" if the old password should still be accepted until the action is
+	//   called again with "false" after the grace period, so the clients have time to switch to the new password.
+	//
+	// The action should be idempotent, it may be called again with the same passwords when the rotation is retried.
+	//
+	// Note that only Action.Exec is currently supported.
+	// This field cannot be updated.
+	//
+	// +optional
+	AccountPasswordRotation *LifecycleActionHandler `json:"accountPasswordRotation,omitempty"`
 }
 
 type ComponentSwitchover struct {
@@ -870,4 +979,18 @@ type RoleProbe struct {
 	//
 	// +optional
 	PeriodSeconds int32 `json:"periodSeconds,omitempty" protobuf:"varint,4,opt,name=periodSeconds"`
+
+	// Minimum consecutive successes for the probe to be considered successful after having failed.
+	// Defaults to 1. Minimum value is 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+
+	// Minimum consecutive failures for the probe to be considered failed after having succeeded.
+	// Defaults to 2. Minimum value is 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
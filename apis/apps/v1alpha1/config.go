@@ -103,3 +103,44 @@ type ConfigParams struct {
 	// +optional
 	Parameters map[string]*string `json:"parameters,omitempty"`
 }
+
+// ParameterDiffOperation defines how a parameter is changed.
+// +enum
+// +kubebuilder:validation:Enum={Added,Updated,Deleted}
+type ParameterDiffOperation string
+
+const (
+	ParameterAdded   ParameterDiffOperation = "Added"
+	ParameterUpdated ParameterDiffOperation = "Updated"
+	ParameterDeleted ParameterDiffOperation = "Deleted"
+)
+
+// ParameterDiff describes the change of a parameter in a configuration file.
+type ParameterDiff struct {
+	// Specifies the name of the configuration file.
+	//
+	// +kubebuilder:validation:Required
+	File string `json:"file"`
+
+	// Specifies the name of the parameter.
+	//
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+
+	// Specifies how the parameter is changed.
+	//
+	// +kubebuilder:validation:Required
+	Operation ParameterDiffOperation `json:"operation"`
+
+	// Represents the value before the change, it is not set if the parameter is added.
+	// The values of the sensitive parameters are redacted.
+	//
+	// +optional
+	OldValue *string `json:"oldValue,omitempty"`
+
+	// Represents the value after the change, it is not set if the parameter is deleted.
+	// The values of the sensitive parameters are redacted.
+	//
+	// +optional
+	NewValue *string `json:"newValue,omitempty"`
+}
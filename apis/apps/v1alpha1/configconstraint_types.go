@@ -102,6 +102,19 @@ type ConfigConstraintSpec struct {
 	// +optional
 	ImmutableParameters []string `json:"immutableParameters,omitempty"`
 
+	// Lists the parameters whose values are sensitive, such as passwords.
+	// Their values are redacted in the parameter diffs of the reconfiguring status.
+	//
+	// +listType=set
+	// +optional
+	SensitiveParameters []string `json:"sensitiveParameters,omitempty"`
+
+	// Defines the options of the canary reconfigure policy, which applies the changes to a single secondary replica first,
+	// and rolls to the rest of the replicas only after the canary replica passes the validation.
+	//
+	// +optional
+	CanaryOptions *CanaryOptions `json:"canaryOptions,omitempty"`
+
 	// Used to match labels on the pod to do a dynamic reload
 	// TODO (refactored to DynamicReloadSelector)
 	//
@@ -157,6 +170,23 @@ type CustomParametersValidation struct {
 	CUE string `json:"cue,omitempty"`
 }
 
+// CanaryOptions defines how to validate the canary replica of the canary reconfigure policy.
+type CanaryOptions struct {
+	// Specifies the duration in seconds for which the canary replica must keep healthy after the changes are applied,
+	// the reconfiguring halts if the canary replica is not healthy within the duration.
+	//
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ValidationWindowSeconds int32 `json:"validationWindowSeconds,omitempty"`
+
+	// Specifies an optional command executed in the container of the canary replica after the validation window,
+	// the reconfiguring halts if the command exits with a non-zero code.
+	//
+	// +optional
+	ProbeCommand []string `json:"probeCommand,omitempty"`
+}
+
 // Defines the options for reloading a service or application within the Kubernetes cluster.
 // Only one of its members may be specified at a time.
 
@@ -165,6 +165,11 @@ type ConfigurationItemDetailStatus struct {
 	//
 	// +optional
 	ReconcileDetail *ReconcileDetail `json:"reconcileDetail,omitempty"`
+
+	// Contains the parameter-level diffs of the update revision, sorted by the file and the key.
+	//
+	// +optional
+	ParameterDiffs []ParameterDiff `json:"parameterDiffs,omitempty"`
 }
 
 // Represents the observed state of a Configuration resource.
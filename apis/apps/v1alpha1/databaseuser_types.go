@@ -0,0 +1,146 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DatabaseUserSpec defines the desired state of DatabaseUser
+//
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.userName) == has(self.userName) && (!has(self.userName) || self.userName == oldSelf.userName)",message="forbidden to update spec.userName"
+type DatabaseUserSpec struct {
+	// Specifies the name of the Cluster where the user is created.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the Component in the Cluster where the user is created.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the name of the user in the database engine. Defaults to the name of the DatabaseUser object.
+	//
+	// +kubebuilder:validation:MaxLength=64
+	// +optional
+	UserName string `json:"userName,omitempty"`
+
+	// Specifies the privileges granted to the user.
+	//
+	// +kubebuilder:validation:Required
+	Role DatabaseUserRole `json:"role"`
+
+	// Specifies the policy to generate the password of the user. The generated password is stored in the Secret
+	// `<name>-credential`, alongside the user name.
+	//
+	// +optional
+	PasswordConfig PasswordConfig `json:"passwordConfig"`
+}
+
+// DatabaseUserRole defines the privileges granted to the database user.
+//
+// +enum
+// +kubebuilder:validation:Enum={superuser,readwrite,readonly}
+type DatabaseUserRole string
+
+const (
+	SuperUserDatabaseUserRole DatabaseUserRole = "superuser"
+	ReadWriteDatabaseUserRole DatabaseUserRole = "readwrite"
+	ReadOnlyDatabaseUserRole  DatabaseUserRole = "readonly"
+)
+
+// DatabaseUserStatus defines the observed state of DatabaseUser
+type DatabaseUserStatus struct {
+	// Refers to the most recent generation that has been observed for the DatabaseUser.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Indicates whether the user has been created in the database engine with the desired privileges.
+	//
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// Provides additional information about the current phase.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Indicates whether the user is created by the DatabaseUser. Only the users created by it are managed and
+	// dropped from the database engine on deletion, the existing users and the system accounts are never taken over.
+	//
+	// +optional
+	Owned bool `json:"owned,omitempty"`
+
+	// Records the privileges granted to the user in the database engine.
+	//
+	// +optional
+	Role DatabaseUserRole `json:"role,omitempty"`
+
+	// The name of the Secret which stores the credential of the user.
+	//
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=dbuser
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName",description="cluster name"
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName",description="component name"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.role",description="user role"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="status phase"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// DatabaseUser is the Schema for the databaseusers API, which manages a user of the database engine declaratively.
+// The user is created, updated and deleted in the engine by the account provision actions of the Component.
+type DatabaseUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DatabaseUserSpec   `json:"spec,omitempty"`
+	Status DatabaseUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DatabaseUserList contains a list of DatabaseUser
+type DatabaseUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatabaseUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DatabaseUser{}, &DatabaseUserList{})
+}
+
+// GetUserName returns the name of the user in the database engine.
+func (r *DatabaseUser) GetUserName() string {
+	if len(r.Spec.UserName) > 0 {
+		return r.Spec.UserName
+	}
+	return r.Name
+}
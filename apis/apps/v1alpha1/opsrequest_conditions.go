@@ -41,8 +41,12 @@ const (
 	ConditionTypeVersionUpgrading   = "VersionUpgrading"
 	ConditionTypeExpose             = "Exposing"
 	ConditionTypeDataScript         = "ExecuteDataScript"
+	ConditionTypeBenchmark          = "Benchmark"
+	ConditionTypePasswordRotation   = "PasswordRotation"
+	ConditionTypeUpgradePreCheck    = "UpgradePreCheck"
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypeApproved           = "Approved"
 
 	// condition and event reasons
 
@@ -54,6 +58,7 @@ const (
 	ReasonReconfigureNoChanged     = "ReconfigureNoChanged"
 	ReasonReconfigureSucceed       = "ReconfigureSucceed"
 	ReasonReconfigureRunning       = "ReconfigureRunning"
+	ReasonReconfigureRollback      = "ReconfigureRollback"
 	ReasonClusterPhaseMismatch     = "ClusterPhaseMismatch"
 	ReasonOpsTypeNotSupported      = "OpsTypeNotSupported"
 	ReasonValidateFailed           = "ValidateFailed"
@@ -63,6 +68,10 @@ const (
 	ReasonOpsCancelFailed          = "CancelFailed"
 	ReasonOpsCancelSucceed         = "CancelSucceed"
 	ReasonOpsCancelByController    = "CancelByController"
+	ReasonWaitForApproval          = "WaitForApproval"
+	ReasonOpsApproved              = "Approved"
+	ReasonOpsRejected              = "Rejected"
+	ReasonApprovalDenied           = "ApprovalDenied"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
@@ -81,6 +90,52 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewWaitForApprovalCondition waits for the approval of the OpsRequest.
+func NewWaitForApprovalCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproved,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonWaitForApproval,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("wait for the approval of the OpsRequest: %s in Cluster: %s",
+			ops.Name, ops.Spec.ClusterRef),
+	}
+}
+
+// NewApprovedCondition the OpsRequest has been approved.
+func NewApprovedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproved,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonOpsApproved,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("the OpsRequest: %s is approved by %s", ops.Name, ops.Spec.Approval.Approver),
+	}
+}
+
+// NewRejectedCondition the OpsRequest has been rejected.
+func NewRejectedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproved,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonOpsRejected,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("the OpsRequest: %s is rejected by %s", ops.Name, ops.Spec.Approval.Approver),
+	}
+}
+
+// NewApprovalDeniedCondition the approver is not allowed to approve the OpsRequest.
+func NewApprovalDeniedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproved,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonApprovalDenied,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`the approver "%s" is not allowed to approve the OpsRequest: %s, the "approve" verb on opsrequests is required`,
+			ops.Spec.Approval.Approver, ops.Name),
+	}
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -284,6 +339,20 @@ func NewDataScriptCondition(ops *OpsRequest) *metav1.Condition {
 	return newOpsCondition(ops, ConditionTypeDataScript, "DataScriptStarted", fmt.Sprintf("Start to execute data script in Cluster: %s", ops.Spec.ClusterRef))
 }
 
+// NewUpgradePreCheckPassedCondition creates a condition that the pre-checks of the upgrade are passed.
+func NewUpgradePreCheckPassedCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeUpgradePreCheck, "UpgradePreCheckPassed",
+		fmt.Sprintf("The pre-checks for upgrading Cluster %s to ClusterVersion %s are passed", ops.Spec.ClusterRef, ops.Spec.Upgrade.ClusterVersionRef))
+}
+
+func NewBenchmarkCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypeBenchmark, "BenchmarkStarted", fmt.Sprintf("Start to run benchmark in Cluster: %s", ops.Spec.ClusterRef))
+}
+
+func NewPasswordRotationCondition(ops *OpsRequest) *metav1.Condition {
+	return newOpsCondition(ops, ConditionTypePasswordRotation, "PasswordRotationStarted", fmt.Sprintf("Start to rotate account passwords in Cluster: %s", ops.Spec.ClusterRef))
+}
+
 func newOpsCondition(ops *OpsRequest, condType, reason, message string) *metav1.Condition {
 	return &metav1.Condition{
 		Type:               condType,
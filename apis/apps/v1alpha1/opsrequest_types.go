@@ -22,6 +22,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 )
 
 // TODO: @wangyelei could refactor to ops group
@@ -39,6 +41,22 @@ type OpsRequestSpec struct {
 	// +optional
 	Cancel bool `json:"cancel,omitempty"`
 
+	// Indicates whether the OpsRequest requires an approval before being executed,
+	// it is recommended for the destructive operations such as the in-place restore.
+	// If true, the OpsRequest will be kept in the PendingApproval phase until spec.approval is set.
+	// The approval is also required if the operator requires it for the type of the OpsRequest,
+	// or the cluster does by the annotation `apps.kubeblocks.io/ops-approval-required`.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.approvalRequired"
+	// +optional
+	ApprovalRequired bool `json:"approvalRequired,omitempty"`
+
+	// Approves the OpsRequest which requires an approval.
+	// The approver must be the user who sets it, and must be allowed to `approve` the opsrequests resource
+	// by the roles bound to the user directly, which is checked by the controller.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.approval"
+	// +optional
+	Approval *OpsApproval `json:"approval,omitempty"`
+
 	// Defines the operation type.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.type"
@@ -48,6 +66,10 @@ type OpsRequestSpec struct {
 	// +optional
 	TTLSecondsAfterSucceed int32 `json:"ttlSecondsAfterSucceed,omitempty"`
 
+	// OpsRequest will be deleted after TTLSecondsAfterFailed second when OpsRequest.status.phase is Failed or Cancelled.
+	// +optional
+	TTLSecondsAfterFailed int32 `json:"ttlSecondsAfterFailed,omitempty"`
+
 	// Specifies the cluster version by specifying clusterVersionRef.
 	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.upgrade"
@@ -80,6 +102,24 @@ type OpsRequestSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.restart"
 	RestartList []ComponentOps `json:"restart,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Stops the specified components. If not specified, all components of the cluster will be stopped.
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.stop"
+	StopList []ComponentOps `json:"stop,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Starts the specified components which are stopped. If not specified, all stopped components of the cluster will be started.
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.start"
+	StartList []ComponentOps `json:"start,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Switches over the specified components.
 	// +optional
 	// +patchMergeKey=componentName
@@ -133,6 +173,25 @@ type OpsRequestSpec struct {
 	// +optional
 	TTLSecondsBeforeAbort *int32 `json:"ttlSecondsBeforeAbort,omitempty"`
 
+	// Specifies the time to execute the OpsRequest, the OpsRequest will be kept in the Pending phase until then.
+	// If not specified, the OpsRequest will be executed immediately.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.scheduledAt"
+	// +optional
+	ScheduledAt *metav1.Time `json:"scheduledAt,omitempty"`
+
+	// Specifies the preconditions which must be met before executing the OpsRequest,
+	// in addition to the cluster phases required by the operation type.
+	// The OpsRequest will wait at most TTLSecondsBeforeAbort seconds for them to be met.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.preconditions"
+	// +optional
+	Preconditions *OpsPreconditions `json:"preconditions,omitempty"`
+
+	// Indicates whether to execute the OpsRequest forcibly, skipping the check of the cluster phase and spec.preconditions.
+	// A warning event will be recorded when the OpsRequest is forced to execute.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.force"
+	// +optional
+	Force bool `json:"force,omitempty"`
+
 	// Defines the script to be executed.
 	// +optional
 	ScriptSpec *ScriptSpec `json:"scriptSpec,omitempty"`
@@ -141,6 +200,20 @@ type OpsRequestSpec struct {
 	// +optional
 	BackupSpec *BackupSpec `json:"backupSpec,omitempty"`
 
+	// Defines the load test to be run against the cluster.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.benchmarkSpec"
+	// +optional
+	BenchmarkSpec *BenchmarkSpec `json:"benchmarkSpec,omitempty"`
+
+	// Defines the system accounts whose passwords to rotate.
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.passwordRotation"
+	PasswordRotationList []PasswordRotation `json:"passwordRotation,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Defines how to restore the cluster.
 	// Note that this restore operation will roll back cluster services.
 	// +optional
@@ -158,6 +231,34 @@ type ComponentOps struct {
 	ComponentName string `json:"componentName"`
 }
 
+// OpsApproval defines the approval of the OpsRequest.
+type OpsApproval struct {
+	// Specifies the name of the user who approves the OpsRequest.
+	// +kubebuilder:validation:Required
+	Approver string `json:"approver"`
+
+	// Rejects the OpsRequest instead of approving it, the rejected OpsRequest is cancelled without being executed.
+	// +optional
+	Rejected bool `json:"rejected,omitempty"`
+}
+
+// OpsPreconditions defines the preconditions which must be met before executing the OpsRequest.
+type OpsPreconditions struct {
+	// Requires the cluster to be in the Running phase.
+	// +optional
+	RequireClusterRunning bool `json:"requireClusterRunning,omitempty"`
+
+	// Requires no other OpsRequest of the cluster to be in progress.
+	// +optional
+	RequireNoOpsInProgress bool `json:"requireNoOpsInProgress,omitempty"`
+
+	// Specifies the minimum number of available replicas of each component operated by the OpsRequest.
+	// All components of the cluster are checked if the OpsRequest does not specify any component.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinAvailableReplicas *int32 `json:"minAvailableReplicas,omitempty"`
+}
+
 type Switchover struct {
 	ComponentOps `json:",inline"`
 
@@ -249,6 +350,29 @@ type HorizontalScaling struct {
 	//
 	// +optional
 	Instances []string `json:"instances,omitempty"`
+
+	// Specifies how to add the replicas in batches when scaling out. All new replicas are added at once if not specified.
+	// It helps to avoid the storage provisioning and data cloning of many replicas overwhelming the cluster.
+	//
+	// +optional
+	ScaleOutBatch *ScaleOutBatchPolicy `json:"scaleOutBatch,omitempty"`
+}
+
+// ScaleOutBatchPolicy defines how to add the replicas in batches.
+type ScaleOutBatchPolicy struct {
+	// Specifies the max number of the replicas to add in a batch, which are being created at the same time.
+	// The next batch begins only after all replicas of the current batch are available.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	BatchSize int32 `json:"batchSize"`
+
+	// Specifies the number of seconds to pause after a batch is completed before beginning the next batch.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	PauseSeconds int32 `json:"pauseSeconds,omitempty"`
 }
 
 // Reconfigure represents the variables required for updating a configuration.
@@ -264,6 +388,14 @@ type Reconfigure struct {
 	// +listMapKey=name
 	Configurations []ConfigurationItem `json:"configurations" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
 
+	// Specifies the maximum duration in seconds to wait for all pods to apply the new configuration.
+	// If the deadline is exceeded, the configuration is rolled back to the previous version and the OpsRequest fails.
+	// If not set, no automatic rollback is performed.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RollbackTimeoutSeconds *int32 `json:"rollbackTimeoutSeconds,omitempty"`
+
 	// Indicates the duration for which the parameter changes are valid.
 	// +optional
 	// TTL *int64 `json:"ttl,omitempty"`
@@ -280,6 +412,8 @@ type Reconfigure struct {
 	// Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.keys) != has(self.rollbackToRevision)",message="exactly one of keys and rollbackToRevision must be set"
+
 type ConfigurationItem struct {
 	// Specifies the name of the configuration template.
 	// +kubebuilder:validation:Required
@@ -292,13 +426,70 @@ type ConfigurationItem struct {
 	Policy *UpgradePolicy `json:"policy,omitempty"`
 
 	// Sets the parameters to be updated. It should contain at least one item. The keys are merged and retained during patch operations.
-	// +kubebuilder:validation:Required
+	// It is required unless rollbackToRevision is set.
 	// +kubebuilder:validation:MinItems=1
 	// +patchMergeKey=key
 	// +patchStrategy=merge,retainKeys
 	// +listType=map
 	// +listMapKey=key
-	Keys []ParameterConfig `json:"keys" patchStrategy:"merge,retainKeys" patchMergeKey:"key"`
+	// +optional
+	Keys []ParameterConfig `json:"keys,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"key"`
+
+	// Specifies the revision of the configuration to roll back to.
+	// The recent rendered versions of the configuration are kept in the ConfigMaps labeled with `config.kubeblocks.io/config-type: history`,
+	// the parameters and files that differ from the specified version are reset to the historical values,
+	// and applied with the same policy as a normal reconfiguring.
+	// +optional
+	RollbackToRevision string `json:"rollbackToRevision,omitempty"`
+
+	// Specifies how and when the pods are restarted if the updated parameters require a restart,
+	// that is, any of them is declared in the staticParameters of the ConfigConstraint, or the engine does not support reloading.
+	// It has no effect if all the updated parameters are reloaded dynamically.
+	// +optional
+	RestartPolicy *ReconfigureRestartPolicy `json:"restartPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.timing) || self.timing != 'MaintenanceWindow' || has(self.maintenanceWindow)",message="maintenanceWindow is required if the timing is MaintenanceWindow"
+
+type ReconfigureRestartPolicy struct {
+	// Specifies how the pods are restarted:
+	//
+	// - Rolling: restarts the pods one by one, the secondary replicas are restarted before the primary replica.
+	// - Simultaneous: restarts all the pods at once.
+	//
+	// If not set, the component is restarted following the update strategy of its workload.
+	//
+	// +optional
+	Strategy ReconfigureRestartStrategy `json:"strategy,omitempty"`
+
+	// Specifies when the pods are restarted:
+	//
+	// - Immediate: restarts the pods once the configuration is updated.
+	// - MaintenanceWindow: defers the restart until the maintenance window.
+	// - NextRestart: updates the configuration without restarting the pods,
+	//   the changes take effect on the next restart of the pods.
+	//
+	// +kubebuilder:default=Immediate
+	// +optional
+	Timing ReconfigureRestartTiming `json:"timing,omitempty"`
+
+	// Specifies the daily maintenance window, required if the timing is MaintenanceWindow.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindow defines a daily time window in UTC.
+type MaintenanceWindow struct {
+	// Specifies the start time of the window in the format of "HH:MM", in UTC.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// Specifies the duration of the window in minutes.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1440
+	DurationMinutes int32 `json:"durationMinutes"`
 }
 
 type CustomOpsSpec struct {
@@ -449,6 +640,31 @@ type OpsService struct {
 	// More info: https://kubernetes.io/docs/concepts/services-networking/service/#publishing-services-service-types.
 	// +optional
 	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Specifies how to allocate the node ports which are not specified explicitly when ServiceType is NodePort or LoadBalancer.
+	//
+	// - `Random`: the node ports are allocated by Kubernetes randomly, which is the default.
+	// - `Deterministic`: the node ports are derived from the hash of the namespace, the service name and the port name
+	//   in the range 30000-32767, so the same node ports are allocated if the service is re-created.
+	//   The node ports need to be specified explicitly if they conflict with those of other services.
+	//
+	// +optional
+	NodePortAllocation NodePortAllocationPolicy `json:"nodePortAllocation,omitempty"`
+
+	// Specifies the IP family policy of the service, such as SingleStack, PreferDualStack and RequireDualStack,
+	// for the IPv6 and dual-stack Kubernetes clusters. If not specified, it is defaulted by Kubernetes.
+	// More info: https://kubernetes.io/docs/concepts/services-networking/dual-stack/#services.
+	//
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// Specifies the IP families, IPv4 and IPv6, of the service in order.
+	// If not specified, it is defaulted by Kubernetes according to `ipFamilyPolicy`.
+	//
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=atomic
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
 }
 
 type RestoreFromSpec struct {
@@ -489,6 +705,7 @@ type PointInTimeRefSpec struct {
 
 // ScriptSpec is designed to execute specific operations such as creating a database or user.
 // It is not a general-purpose script executor and is applicable for engines like MySQL, PostgreSQL, Redis, MongoDB, etc.
+// The output of the script is captured into the ConfigMap named `<opsRequestName>-datascript-output` once the script is done.
 type ScriptSpec struct {
 	ComponentOps `json:",inline"`
 	// Specifies the image to be used for the exec command. By default, the image of kubeblocks-datascript is used.
@@ -563,6 +780,34 @@ type BackupSpec struct {
 	ParentBackupName string `json:"parentBackupName,omitempty"`
 }
 
+// OpsBackupStatus records the backup created by the OpsRequest and mirrors its progress,
+// so that the result of the backup can be checked from the OpsRequest directly.
+type OpsBackupStatus struct {
+	// Specifies the name of the Backup object created by the operation.
+	// +kubebuilder:validation:Required
+	BackupName string `json:"backupName"`
+
+	// Records the backup policy used to perform the backup.
+	// +optional
+	BackupPolicyName string `json:"backupPolicyName,omitempty"`
+
+	// Records the backup method of the backup policy used to perform the backup.
+	// +optional
+	BackupMethod string `json:"backupMethod,omitempty"`
+
+	// Indicates the current phase of the backup.
+	// +optional
+	Phase dpv1alpha1.BackupPhase `json:"phase,omitempty"`
+
+	// Records the total size of the data backed up.
+	// +optional
+	TotalSize string `json:"totalSize,omitempty"`
+
+	// Provides the reason if the backup failed.
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
 type RestoreSpec struct {
 	// Specifies the name of the backup.
 	// +kubebuilder:validation:Required
@@ -578,6 +823,119 @@ type RestoreSpec struct {
 	// +kubebuilder:validation:Enum=Serial;Parallel
 	// +kubebuilder:default=Parallel
 	VolumeRestorePolicy string `json:"volumeRestorePolicy,omitempty"`
+
+	// Indicates whether to restore the backup into the existing cluster specified by clusterRef.
+	// The cluster must be Running, its terminationPolicy must be Delete, and the backup must belong to it.
+	// Before the cluster is deleted and re-created from the backup, a backup of the current data is taken
+	// with the default backup policy, and its status is recorded in status.backupStatus.
+	// +optional
+	InPlace bool `json:"inPlace,omitempty"`
+
+	// Specifies the ClusterVersion of the restored cluster, which can be a newer ClusterVersion with the same
+	// major engine version as the backed up cluster. If not specified, the ClusterVersion of the backed up cluster is used.
+	// +optional
+	ClusterVersionRef string `json:"clusterVersionRef,omitempty"`
+
+	// Specifies the overrides of the components of the restored cluster, which allows the restored cluster
+	// to have a different topology from the backed up cluster, such as the replicas, resources and storage class.
+	// The compatibility between the backup and the restored cluster is validated before the restore jobs run.
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	ComponentOverrides []RestoreComponentOverride `json:"componentOverrides,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+}
+
+// RestoreComponentOverride defines the overrides of a component of the restored cluster.
+type RestoreComponentOverride struct {
+	ComponentOps `json:",inline"`
+
+	// Specifies the replicas of the component.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Specifies the resources of the component.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Specifies the overrides of the volume claim templates of the component.
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	VolumeClaimTemplates []RestoreVolumeClaimTemplate `json:"volumeClaimTemplates,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// RestoreVolumeClaimTemplate defines the overrides of a volume claim template of the restored component.
+type RestoreVolumeClaimTemplate struct {
+	// Specifies the name of the volume claim template.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the requested storage size of the volume, it should not be less than the backup size.
+	// +optional
+	Storage *resource.Quantity `json:"storage,omitempty"`
+
+	// Specifies the storage class of the volume.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// BenchmarkSpec defines the load test to be run against a component.
+type BenchmarkSpec struct {
+	ComponentOps `json:",inline"`
+
+	// Specifies the tool used to run the benchmark.
+	// sysbench runs the oltp_read_write workload against MySQL compatible engines,
+	// pgbench runs the TPC-B like workload against PostgreSQL compatible engines.
+	// +kubebuilder:validation:Required
+	Tool BenchmarkTool `json:"tool"`
+
+	// Specifies the image which contains the benchmark tool.
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// Specifies the database in which the test tables are created. The database must exist.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9_]+$`
+	Database string `json:"database"`
+
+	// Specifies the number of concurrent threads (clients) used by the benchmark.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=4
+	// +optional
+	Threads int32 `json:"threads,omitempty"`
+
+	// Specifies the duration of the load test in seconds, the time spent on preparing the data is not included.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=60
+	// +optional
+	DurationSeconds int32 `json:"durationSeconds,omitempty"`
+
+	// Specifies the secret which contains the credentials to connect to the database.
+	// Defaults to the connection credential of the cluster.
+	// +optional
+	Secret *ScriptSecret `json:"secret,omitempty"`
+}
+
+// PasswordRotation defines the system accounts of a component whose passwords to rotate.
+type PasswordRotation struct {
+	ComponentOps `json:",inline"`
+
+	// Specifies the names of the system accounts to rotate.
+	// If not specified, the passwords of all the system accounts of the component will be rotated.
+	// +optional
+	Accounts []string `json:"accounts,omitempty"`
+
+	// Specifies the period in seconds in which the old passwords are still accepted after the rotation,
+	// so that the clients have time to switch to the new passwords.
+	// The old passwords are discarded immediately if it's zero.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds int32 `json:"gracePeriodSeconds,omitempty"`
 }
 
 // ScriptSecret represents the secret that is used to execute the script.
@@ -656,6 +1014,24 @@ type OpsRequestStatus struct {
 	// +optional
 	ReconfiguringStatusAsComponent map[string]*ReconfiguringStatus `json:"reconfiguringStatusAsComponent,omitempty"`
 
+	// Represents the status of the backup created by the Backup operation, or the pre-restore backup
+	// created by the in-place Restore operation.
+	// +optional
+	BackupStatus *OpsBackupStatus `json:"backupStatus,omitempty"`
+
+	// Represents the summarized result of the Benchmark operation.
+	// +optional
+	BenchmarkResult *OpsBenchmarkResult `json:"benchmarkResult,omitempty"`
+
+	// Records the approval of the OpsRequest which requires an approval.
+	// +optional
+	Approval *OpsApprovalStatus `json:"approval,omitempty"`
+
+	// Represents the position and the blocking reason of the OpsRequest when it is pending in the queue of the cluster.
+	// OpsRequests which change the phase of the cluster are mutually exclusive and are processed one by one.
+	// +optional
+	QueueStatus *OpsQueueStatus `json:"queueStatus,omitempty"`
+
 	// Describes the detailed status of the OpsRequest.
 	// +optional
 	// +patchMergeKey=type
@@ -665,6 +1041,45 @@ type OpsRequestStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+type OpsBenchmarkResult struct {
+	// Represents the number of transactions per second.
+	// +optional
+	TPS string `json:"tps,omitempty"`
+
+	// Represents the average latency of the transactions, e.g. "4.86ms".
+	// +optional
+	LatencyAvg string `json:"latencyAvg,omitempty"`
+
+	// Represents the 95th percentile latency of the transactions, e.g. "8.43ms".
+	// +optional
+	LatencyP95 string `json:"latencyP95,omitempty"`
+}
+
+type OpsApprovalStatus struct {
+	// Specifies the name of the user who approved the OpsRequest.
+	// +optional
+	Approver string `json:"approver,omitempty"`
+
+	// Specifies the time when the OpsRequest was approved.
+	// +optional
+	ApprovalTimestamp metav1.Time `json:"approvalTimestamp,omitempty"`
+}
+
+type OpsQueueStatus struct {
+	// Specifies the position of the OpsRequest in the queue of the cluster, starting from 1.
+	// The OpsRequest at position 1 is being processed.
+	// +optional
+	Position int32 `json:"position,omitempty"`
+
+	// Specifies the name of the OpsRequest which blocks the current one.
+	// +optional
+	BlockedBy string `json:"blockedBy,omitempty"`
+
+	// Provides the reason why the OpsRequest is blocked.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="has(self.objectKey) || has(self.actionName)", message="either objectKey and actionName."
 
 type ProgressStatusDetail struct {
@@ -795,6 +1210,33 @@ type OpsRequestComponentStatus struct {
 	// +kubebuilder:validation:MaxLength=32768
 	// +optional
 	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
+
+	// Records the external endpoints allocated to the services exposed by the Expose operation.
+	// +optional
+	ExposedEndpoints []ExposedEndpoint `json:"exposedEndpoints,omitempty"`
+
+	// Records the progress of the batches when the component is scaled out in batches.
+	// +optional
+	ScaleOutBatch *ScaleOutBatchStatus `json:"scaleOutBatch,omitempty"`
+}
+
+// ScaleOutBatchStatus records the progress of the batches when scaling out the replicas in batches.
+type ScaleOutBatchStatus struct {
+	// Indicates the sequence number of the current batch, starting from 1.
+	// +optional
+	CurrentBatch int32 `json:"currentBatch,omitempty"`
+
+	// Indicates the total number of the batches.
+	// +optional
+	TotalBatches int32 `json:"totalBatches,omitempty"`
+
+	// Indicates the replicas of the component after the current batch is completed.
+	// +optional
+	BatchReplicas int32 `json:"batchReplicas,omitempty"`
+
+	// Indicates the time when the current batch is completed, the next batch begins after the pause seconds.
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
 }
 
 type PreCheckResult struct {
@@ -862,9 +1304,17 @@ type ConfigurationItemStatus struct {
 	// +optional
 	LastAppliedConfiguration map[string]string `json:"lastAppliedConfiguration,omitempty"`
 
+	// Stores the parameters of the configuration before the reconfiguring, used to roll back the changes.
+	// +optional
+	LastConfigFileParams map[string]ConfigParams `json:"lastConfigFileParams,omitempty"`
+
 	// Contains the updated parameters.
 	// +optional
 	UpdatedParameters UpdatedParameters `json:"updatedParameters"`
+
+	// Contains the parameter-level diffs with the old and new values, sorted by the file and the key.
+	// +optional
+	ParameterDiffs []ParameterDiff `json:"parameterDiffs,omitempty"`
 }
 
 type UpdatedParameters struct {
@@ -923,6 +1373,24 @@ func (r OpsRequestSpec) GetRestartComponentNameSet() ComponentNameSet {
 	return set
 }
 
+// GetStopComponentNameSet gets the component name map with stop operation.
+func (r OpsRequestSpec) GetStopComponentNameSet() ComponentNameSet {
+	set := make(ComponentNameSet)
+	for _, v := range r.StopList {
+		set[v.ComponentName] = struct{}{}
+	}
+	return set
+}
+
+// GetStartComponentNameSet gets the component name map with start operation.
+func (r OpsRequestSpec) GetStartComponentNameSet() ComponentNameSet {
+	set := make(ComponentNameSet)
+	for _, v := range r.StartList {
+		set[v.ComponentName] = struct{}{}
+	}
+	return set
+}
+
 // GetSwitchoverComponentNameSet gets the component name map with switchover operation.
 func (r OpsRequestSpec) GetSwitchoverComponentNameSet() ComponentNameSet {
 	set := make(ComponentNameSet)
@@ -984,6 +1452,22 @@ func (r OpsRequestSpec) GetDataScriptComponentNameSet() ComponentNameSet {
 	return set
 }
 
+// GetBenchmarkComponentNameSet gets the component name map with benchmark operation.
+func (r OpsRequestSpec) GetBenchmarkComponentNameSet() ComponentNameSet {
+	set := make(ComponentNameSet)
+	set[r.BenchmarkSpec.ComponentName] = struct{}{}
+	return set
+}
+
+// GetPasswordRotationComponentNameSet gets the component name map with password rotation operation.
+func (r OpsRequestSpec) GetPasswordRotationComponentNameSet() ComponentNameSet {
+	set := make(ComponentNameSet)
+	for _, v := range r.PasswordRotationList {
+		set[v.ComponentName] = struct{}{}
+	}
+	return set
+}
+
 // ToVolumeExpansionListToMap converts volumeExpansionList to map
 func (r OpsRequestSpec) ToVolumeExpansionListToMap() map[string]VolumeExpansion {
 	volumeExpansionMap := make(map[string]VolumeExpansion)
@@ -1037,6 +1521,10 @@ func (r *OpsRequest) GetComponentNameSet() ComponentNameSet {
 	switch r.Spec.Type {
 	case RestartType:
 		return r.Spec.GetRestartComponentNameSet()
+	case StopType:
+		return r.Spec.GetStopComponentNameSet()
+	case StartType:
+		return r.Spec.GetStartComponentNameSet()
 	case VerticalScalingType:
 		return r.Spec.GetVerticalScalingComponentNameSet()
 	case HorizontalScalingType:
@@ -1053,6 +1541,10 @@ func (r *OpsRequest) GetComponentNameSet() ComponentNameSet {
 		return r.Spec.GetSwitchoverComponentNameSet()
 	case DataScriptType:
 		return r.Spec.GetDataScriptComponentNameSet()
+	case BenchmarkType:
+		return r.Spec.GetBenchmarkComponentNameSet()
+	case PasswordRotationType:
+		return r.Spec.GetPasswordRotationComponentNameSet()
 	default:
 		return nil
 	}
@@ -17,7 +17,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 var componentName = "mysql"
@@ -198,6 +205,22 @@ func TestGetExposeComponentNameSet(t *testing.T) {
 	checkComponentMap(t, componentNameSet1, len(ops.Spec.ExposeList), componentName)
 }
 
+func TestGetStopAndStartComponentNameSet(t *testing.T) {
+	ops := &OpsRequest{}
+	ops.Spec.Type = StopType
+	if len(ops.GetComponentNameSet()) != 0 {
+		t.Error("expected all components in the scope of the cluster-wide stop")
+	}
+	ops.Spec.StopList = []ComponentOps{{ComponentName: componentName}}
+	checkComponentMap(t, ops.Spec.GetStopComponentNameSet(), len(ops.Spec.StopList), componentName)
+	checkComponentMap(t, ops.GetComponentNameSet(), len(ops.Spec.StopList), componentName)
+
+	ops.Spec.Type = StartType
+	ops.Spec.StartList = []ComponentOps{{ComponentName: componentName}}
+	checkComponentMap(t, ops.Spec.GetStartComponentNameSet(), len(ops.Spec.StartList), componentName)
+	checkComponentMap(t, ops.GetComponentNameSet(), len(ops.Spec.StartList), componentName)
+}
+
 func TestGetReconfiguringComponentNameSet(t *testing.T) {
 	ops := &OpsRequest{}
 	ops.Spec.Type = ReconfiguringType
@@ -217,3 +240,40 @@ func TestSetStatusAndMessage(t *testing.T) {
 		t.Error("set progressDetail status and message failed")
 	}
 }
+
+func TestValidateApproval(t *testing.T) {
+	ops := mockRestartOps()
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "alice"},
+		},
+	})
+	if err := ops.validateApproval(ctx, nil); err != nil {
+		t.Errorf("expected no error without approval, but got %v", err)
+	}
+	ops.Spec.Approval = &OpsApproval{Approver: "bob"}
+	if err := ops.validateApproval(ctx, ops.Spec.Approval.DeepCopy()); err != nil {
+		t.Errorf("expected no error when the approval is unchanged, but got %v", err)
+	}
+	if err := ops.validateApproval(ctx, nil); err == nil {
+		t.Error("expected error when the approver is not the requesting user")
+	}
+}
+
+func TestValidateRequestedByImmutable(t *testing.T) {
+	validator := &opsRequestValidator{}
+	oldOps := mockRestartOps()
+	oldOps.Annotations = map[string]string{constant.RequestedByAnnotationKey: "alice"}
+	ops := oldOps.DeepCopy()
+	if _, err := validator.ValidateUpdate(context.Background(), oldOps, ops); err != nil {
+		t.Errorf("expected no error when the actor is unchanged, but got %v", err)
+	}
+	ops.Annotations[constant.RequestedByAnnotationKey] = "bob"
+	if _, err := validator.ValidateUpdate(context.Background(), oldOps, ops); err == nil {
+		t.Error("expected error when the actor is changed")
+	}
+	delete(ops.Annotations, constant.RequestedByAnnotationKey)
+	if _, err := validator.ValidateUpdate(context.Background(), oldOps, ops); err == nil {
+		t.Error("expected error when the actor is removed")
+	}
+}
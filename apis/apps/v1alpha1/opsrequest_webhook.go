@@ -22,9 +22,11 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/exp/slices"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -55,9 +57,64 @@ var (
 func (r *OpsRequest) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(&opsRequestDefaulter{}).
+		WithValidator(&opsRequestValidator{}).
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-apps-kubeblocks-io-v1alpha1-opsrequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsrequests,verbs=create,versions=v1alpha1,name=mopsrequest.kb.io,admissionReviewVersions=v1
+
+// opsRequestDefaulter records the user who creates the OpsRequest, which is the actor of the audit trail.
+type opsRequestDefaulter struct{}
+
+var _ admission.CustomDefaulter = &opsRequestDefaulter{}
+
+func (d *opsRequestDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	ops := obj.(*OpsRequest)
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || len(req.UserInfo.Username) == 0 {
+		return nil
+	}
+	if ops.Annotations == nil {
+		ops.Annotations = map[string]string{}
+	}
+	ops.Annotations[constant.RequestedByAnnotationKey] = req.UserInfo.Username
+	return nil
+}
+
+// opsRequestValidator validates the OpsRequest with the admission request,
+// which is required to verify the user who approves the OpsRequest.
+type opsRequestValidator struct{}
+
+var _ admission.CustomValidator = &opsRequestValidator{}
+
+func (v *opsRequestValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ops := obj.(*OpsRequest)
+	warnings, err := ops.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, ops.validateApproval(ctx, nil)
+}
+
+func (v *opsRequestValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	ops := newObj.(*OpsRequest)
+	// the actor of the audit trail is recorded at creation, it can't be changed afterwards.
+	if oldActor, actor := oldObj.(*OpsRequest).Annotations[constant.RequestedByAnnotationKey],
+		ops.Annotations[constant.RequestedByAnnotationKey]; oldActor != actor {
+		return nil, fmt.Errorf("update OpsRequest: %s is forbidden, the annotation %s is immutable", ops.Name, constant.RequestedByAnnotationKey)
+	}
+	warnings, err := ops.ValidateUpdate(oldObj)
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, ops.validateApproval(ctx, oldObj.(*OpsRequest).Spec.Approval)
+}
+
+func (v *opsRequestValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return obj.(*OpsRequest).ValidateDelete()
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // +kubebuilder:webhook:path=/validate-apps-kubeblocks-io-v1alpha1-opsrequest,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsrequests,verbs=create;update,versions=v1alpha1,name=vopsrequest.kb.io,admissionReviewVersions=v1
 
@@ -84,10 +141,11 @@ func (r *OpsRequest) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		return nil, fmt.Errorf("update OpsRequest: %s is forbidden when status.Phase is %s", r.Name, r.Status.Phase)
 	}
 
-	// Keep the cancel consistent between the two opsRequest for comparing the diff.
+	// Keep the cancel and approval consistent between the two opsRequest for comparing the diff.
 	lastOpsRequest.Spec.Cancel = r.Spec.Cancel
+	lastOpsRequest.Spec.Approval = r.Spec.Approval
 	if !reflect.DeepEqual(lastOpsRequest.Spec, r.Spec) && r.Status.Phase != "" {
-		return nil, fmt.Errorf("update OpsRequest: %s is forbidden except for cancel when status.Phase is %s", r.Name, r.Status.Phase)
+		return nil, fmt.Errorf("update OpsRequest: %s is forbidden except for cancel and approval when status.Phase is %s", r.Name, r.Status.Phase)
 	}
 	return nil, r.validateEntry(false)
 }
@@ -106,6 +164,59 @@ func (r *OpsRequest) IsComplete(phases ...OpsPhase) bool {
 	return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsSucceedPhase, OpsFailedPhase}, phases[0])
 }
 
+// IsBeforeScheduledTime checks if opsRequest is waiting for the scheduled time.
+func (r *OpsRequest) IsBeforeScheduledTime() bool {
+	return r.Spec.ScheduledAt != nil && time.Now().Before(r.Spec.ScheduledAt.Time)
+}
+
+// validateApproval validates that the approver is the user who approves the OpsRequest,
+// and the user is allowed to approve the OpsRequest.
+func (r *OpsRequest) validateApproval(ctx context.Context, lastApproval *OpsApproval) error {
+	if r.Spec.Approval == nil || reflect.DeepEqual(r.Spec.Approval, lastApproval) {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	userInfo := req.UserInfo
+	if userInfo.Username != r.Spec.Approval.Approver {
+		return fmt.Errorf(`spec.approval.approver "%s" must be the user "%s" who approves the OpsRequest`,
+			r.Spec.Approval.Approver, userInfo.Username)
+	}
+	// fail closed if the approver can't be checked.
+	if webhookMgr == nil || webhookMgr.client == nil {
+		return fmt.Errorf("unable to check whether the user %s is allowed to approve the OpsRequest: %s", userInfo.Username, r.Name)
+	}
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: r.Namespace,
+				Verb:      "approve",
+				Group:     GroupVersion.Group,
+				Resource:  "opsrequests",
+				Name:      r.Name,
+			},
+		},
+	}
+	if err = webhookMgr.client.Create(ctx, sar); err != nil {
+		return err
+	}
+	if !sar.Status.Allowed {
+		return fmt.Errorf(`user "%s" is not allowed to approve the OpsRequest: %s, the "approve" verb on opsrequests is required`,
+			userInfo.Username, r.Name)
+	}
+	return nil
+}
+
 // validateClusterPhase validates whether the current cluster state supports the OpsRequest
 func (r *OpsRequest) validateClusterPhase(cluster *Cluster) error {
 	opsBehaviour := OpsRequestBehaviourMapper[r.Spec.Type]
@@ -129,6 +240,10 @@ func (r *OpsRequest) validateClusterPhase(cluster *Cluster) error {
 	if slices.Contains(opsBehaviour.FromClusterPhases, cluster.Status.Phase) {
 		return nil
 	}
+	// the cluster phase will be checked when the scheduled time comes.
+	if r.IsBeforeScheduledTime() {
+		return nil
+	}
 	// check if this opsRequest needs to verify cluster phase before opsRequest starts running.
 	needCheck := len(opsRecorder) == 0 || (opsRecorder[0].Name == r.Name && opsRecorder[0].InQueue)
 	if !needCheck {
@@ -174,7 +289,8 @@ func (r *OpsRequest) Validate(ctx context.Context,
 	k8sClient client.Client,
 	cluster *Cluster,
 	needCheckClusterPhase bool) error {
-	if needCheckClusterPhase {
+	// the cluster phase is not checked if the OpsRequest is forced to execute.
+	if needCheckClusterPhase && !r.Spec.Force {
 		if err := r.validateClusterPhase(cluster); err != nil {
 			return err
 		}
@@ -203,7 +319,7 @@ func (r *OpsRequest) validateOps(ctx context.Context,
 	// Check whether the corresponding attribute is legal according to the operation type
 	switch r.Spec.Type {
 	case UpgradeType:
-		return r.validateUpgrade(ctx, k8sClient)
+		return r.validateUpgrade(ctx, k8sClient, cluster)
 	case VerticalScalingType:
 		return r.validateVerticalScaling(cluster)
 	case HorizontalScalingType:
@@ -212,12 +328,20 @@ func (r *OpsRequest) validateOps(ctx context.Context,
 		return r.validateVolumeExpansion(ctx, k8sClient, cluster)
 	case RestartType:
 		return r.validateRestart(cluster)
+	case StopType:
+		return r.validateComponentOps(cluster, r.Spec.StopList)
+	case StartType:
+		return r.validateComponentOps(cluster, r.Spec.StartList)
 	case ReconfiguringType:
 		return r.validateReconfigure(ctx, k8sClient, cluster)
 	case SwitchoverType:
 		return r.validateSwitchover(ctx, k8sClient, cluster)
 	case DataScriptType:
 		return r.validateDataScript(ctx, k8sClient, cluster)
+	case BenchmarkType:
+		return r.validateBenchmark(cluster)
+	case PasswordRotationType:
+		return r.validatePasswordRotation(cluster)
 	case ExposeType:
 		return r.validateExpose(ctx, cluster)
 	}
@@ -269,9 +393,19 @@ func (r *OpsRequest) validateRestart(cluster *Cluster) error {
 	return r.checkComponentExistence(cluster, compNames)
 }
 
+// validateComponentOps validates the optional component list of the cluster-wide operations, e.g. spec.stop and spec.start.
+func (r *OpsRequest) validateComponentOps(cluster *Cluster, compOpsList []ComponentOps) error {
+	compNames := make([]string, len(compOpsList))
+	for i, v := range compOpsList {
+		compNames[i] = v.ComponentName
+	}
+	return r.checkComponentExistence(cluster, compNames)
+}
+
 // validateUpgrade validates spec.clusterOps.upgrade
 func (r *OpsRequest) validateUpgrade(ctx context.Context,
-	k8sClient client.Client) error {
+	k8sClient client.Client,
+	cluster *Cluster) error {
 	if r.Spec.Upgrade == nil {
 		return notEmptyError("spec.upgrade")
 	}
@@ -281,7 +415,18 @@ func (r *OpsRequest) validateUpgrade(ctx context.Context,
 	if err := k8sClient.Get(ctx, types.NamespacedName{Name: clusterVersionRef}, clusterVersion); err != nil {
 		return fmt.Errorf("get clusterVersion: %s failed, err: %s", clusterVersionRef, err.Error())
 	}
-	return nil
+	if len(cluster.Spec.ClusterVersionRef) == 0 {
+		return nil
+	}
+	sourceClusterVersion := &ClusterVersion{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: cluster.Spec.ClusterVersionRef}, sourceClusterVersion); err != nil {
+		// the source ClusterVersion may have been deleted, no upgrade path to check then.
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("get clusterVersion: %s failed, err: %s", cluster.Spec.ClusterVersionRef, err.Error())
+	}
+	return clusterVersion.ValidateUpgradeFrom(sourceClusterVersion)
 }
 
 // validateVerticalScaling validates api when spec.type is VerticalScaling
@@ -386,7 +531,15 @@ func (r *OpsRequest) validateHorizontalScaling(ctx context.Context, cli client.C
 	for i, v := range horizontalScalingList {
 		componentNames[i] = v.ComponentName
 	}
-	return r.checkComponentExistence(cluster, componentNames)
+	if err := r.checkComponentExistence(cluster, componentNames); err != nil {
+		return err
+	}
+	for _, compName := range componentNames {
+		if compSpec := cluster.Spec.GetComponentByName(compName); compSpec != nil && compSpec.Autoscaling != nil {
+			return fmt.Errorf(`the replicas of component "%s" are managed by the autoscaler and can not be scaled horizontally`, compName)
+		}
+	}
+	return nil
 }
 
 // validateVolumeExpansion validates volumeExpansion api when spec.type is VolumeExpansion
@@ -642,6 +795,28 @@ func (r *OpsRequest) validateDataScript(ctx context.Context, cli client.Client,
 	return nil
 }
 
+// validateBenchmark validates spec.benchmarkSpec.
+func (r *OpsRequest) validateBenchmark(cluster *Cluster) error {
+	benchmarkSpec := r.Spec.BenchmarkSpec
+	if benchmarkSpec == nil {
+		return notEmptyError("spec.benchmarkSpec")
+	}
+	return r.checkComponentExistence(cluster, []string{benchmarkSpec.ComponentName})
+}
+
+// validatePasswordRotation validates spec.passwordRotation.
+func (r *OpsRequest) validatePasswordRotation(cluster *Cluster) error {
+	passwordRotationList := r.Spec.PasswordRotationList
+	if len(passwordRotationList) == 0 {
+		return notEmptyError("spec.passwordRotation")
+	}
+	compNames := make([]string, len(passwordRotationList))
+	for i, v := range passwordRotationList {
+		compNames[i] = v.ComponentName
+	}
+	return r.checkComponentExistence(cluster, compNames)
+}
+
 // validateVerticalResourceList checks if k8s resourceList is legal
 func validateVerticalResourceList(resourceList map[corev1.ResourceName]resource.Quantity) (string, error) {
 	for k := range resourceList {
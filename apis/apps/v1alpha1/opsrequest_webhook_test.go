@@ -24,11 +24,13 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/sethvargo/go-password/password"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubectl/pkg/util/storage"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -382,6 +384,37 @@ var _ = Describe("OpsRequest webhook", func() {
 		opsRequest.Spec.HorizontalScalingList = []HorizontalScaling{hScalingList[0], hScalingList[2]}
 		Expect(testCtx.CreateObj(ctx, opsRequest).Error()).To(ContainSubstring(notFoundComponentsString("hs-not-exist")))
 
+		By("By testing horizontalScaling - the replicas of target component are managed by the autoscaler")
+		compIndex := 0
+		for i := range cluster.Spec.ComponentSpecs {
+			if cluster.Spec.ComponentSpecs[i].Name == componentName {
+				compIndex = i
+			}
+		}
+		clusterPatch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Spec.ComponentSpecs[compIndex].Autoscaling = &ComponentAutoscaling{
+			MaxReplicas: 3,
+			Metrics: []autoscalingv2.MetricSpec{
+				{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: pointer.Int32(80),
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Patch(ctx, cluster, clusterPatch)).Should(Succeed())
+		opsRequest = createTestOpsRequest(clusterName, opsRequestName, HorizontalScalingType)
+		opsRequest.Spec.HorizontalScalingList = []HorizontalScaling{hScalingList[2]}
+		Expect(testCtx.CreateObj(ctx, opsRequest).Error()).To(ContainSubstring("managed by the autoscaler"))
+		clusterPatch = client.MergeFrom(cluster.DeepCopy())
+		cluster.Spec.ComponentSpecs[compIndex].Autoscaling = nil
+		Expect(k8sClient.Patch(ctx, cluster, clusterPatch)).Should(Succeed())
+
 		By("By testing horizontalScaling. if api is legal, it will create successfully")
 		opsRequest = createTestOpsRequest(clusterName, opsRequestName, HorizontalScalingType)
 		opsRequest.Spec.HorizontalScalingList = []HorizontalScaling{hScalingList[2]}
@@ -772,6 +805,41 @@ var _ = Describe("OpsRequest webhook", func() {
 			Expect(testCtx.CheckedCreateObj(ctx, opsRequest)).Should(Succeed())
 		})
 	})
+
+	Context("When upgrading between clusterVersions", func() {
+		It("should forbid the downgrade", func() {
+			By("By create a clusterDefinition")
+			clusterDef, _ := createTestClusterDefinitionObj(clusterDefinitionName)
+			Expect(testCtx.CheckedCreateObj(ctx, clusterDef)).Should(Succeed())
+			By("By creating the clusterVersions of different versions")
+			clusterVersion := createTestClusterVersionObj(clusterDefinitionName, clusterVersionName)
+			clusterVersion.Spec.Version = "1.0.0"
+			Expect(testCtx.CheckedCreateObj(ctx, clusterVersion)).Should(Succeed())
+			upgradeClusterVersion := createTestClusterVersionObj(clusterDefinitionName, clusterVersionNameForUpgrade)
+			upgradeClusterVersion.Spec.Version = "2.0.0"
+			Expect(testCtx.CheckedCreateObj(ctx, upgradeClusterVersion)).Should(Succeed())
+
+			newUpgradeOps := func(target string) *OpsRequest {
+				opsRequest := createTestOpsRequest(clusterName, opsRequestName+"-upgrade", UpgradeType)
+				opsRequest.Spec.Upgrade = &Upgrade{ClusterVersionRef: target}
+				return opsRequest
+			}
+			newCluster := func(source string) *Cluster {
+				cluster := &Cluster{}
+				cluster.Spec.ClusterVersionRef = source
+				return cluster
+			}
+
+			By("By testing the upgrade")
+			Expect(newUpgradeOps(upgradeClusterVersion.Name).validateUpgrade(ctx, k8sClient, newCluster(clusterVersion.Name))).Should(Succeed())
+			By("By testing the downgrade")
+			Expect(newUpgradeOps(clusterVersion.Name).validateUpgrade(ctx, k8sClient, newCluster(upgradeClusterVersion.Name))).Should(HaveOccurred())
+			By("By testing when the source cluster version not exist")
+			Expect(newUpgradeOps(upgradeClusterVersion.Name).validateUpgrade(ctx, k8sClient, newCluster(clusterVersionName+"-not-exist"))).Should(Succeed())
+			By("By testing when the target cluster version not exist")
+			Expect(newUpgradeOps(clusterVersionName+"-not-exist").validateUpgrade(ctx, k8sClient, newCluster(clusterVersion.Name))).Should(HaveOccurred())
+		})
+	})
 })
 
 func createTestOpsRequest(clusterName, opsRequestName string, opsType OpsType) *OpsRequest {
@@ -77,6 +77,15 @@ type ComponentTemplateSpec struct {
 	//
 	// +optional
 	DefaultMode *int32 `json:"defaultMode,omitempty" protobuf:"varint,3,opt,name=defaultMode"`
+
+	// Specifies whether the template is rendered into a Secret instead of a ConfigMap, and mounted as a Secret volume.
+	// It is required if the template references the keys of Secrets by the `getSecretValue` function,
+	// so that the sensitive values, such as passwords and TLS materials, are not stored in ConfigMaps in plaintext.
+	//
+	// The config template rendered as a Secret is not reconfigurable.
+	//
+	// +optional
+	AsSecret bool `json:"asSecret,omitempty"`
 }
 
 type ConfigTemplateExtension struct {
@@ -140,16 +149,42 @@ type ComponentConfigSpec struct {
 	// +listType=set
 	// +optional
 	ReRenderResourceTypes []RerenderResourceType `json:"reRenderResourceTypes,omitempty"`
+
+	// Specifies how to handle the drift of the configuration, that is, the configuration files in the ConfigMap
+	// are modified directly and diverge from the rendered configuration.
+	//
+	// - Flag: sets the ConfigDrifted condition of the component, the modified configuration is kept.
+	// - Correct: restores the rendered configuration.
+	//
+	// If not set, the Flag policy is used.
+	//
+	// The drift is only detected on the ConfigMap. The files in the pods are projected from the ConfigMap
+	// read-only by the kubelet, so they follow the ConfigMap rather than drift on their own,
+	// and whether the processes have reloaded the files is not checked.
+	//
+	// +optional
+	DriftPolicy ConfigDriftPolicy `json:"driftPolicy,omitempty"`
 }
 
 // RerenderResourceType defines the resource requirements for a component.
 // +enum
-// +kubebuilder:validation:Enum={resources,replcias,tls}
+// +kubebuilder:validation:Enum={resources,replcias,tls,topology}
 type RerenderResourceType string
 
 const (
 	ComponentResourceType RerenderResourceType = "resources"
 	ComponentReplicasType RerenderResourceType = "replicas"
+	ComponentTopologyType RerenderResourceType = "topology"
+)
+
+// ConfigDriftPolicy defines how to handle the configuration which diverges from the rendered configuration.
+// +enum
+// +kubebuilder:validation:Enum={Flag,Correct}
+type ConfigDriftPolicy string
+
+const (
+	FlagConfigDriftPolicy    ConfigDriftPolicy = "Flag"
+	CorrectConfigDriftPolicy ConfigDriftPolicy = "Correct"
 )
 
 // MergedPolicy defines how to merge external imported templates into component templates.
@@ -244,6 +279,10 @@ const (
 	ConditionTypeReplicasReady       = "ReplicasReady"       // ConditionTypeReplicasReady all pods of components are ready
 	ConditionTypeReady               = "Ready"               // ConditionTypeReady all components are running
 	ConditionTypeSwitchoverPrefix    = "Switchover-"         // ConditionTypeSwitchoverPrefix component status condition of switchover
+	ConditionTypePostProvisioned     = "PostProvisioned"     // ConditionTypePostProvisioned component status condition of the postProvision action
+	ConditionTypePreTerminated       = "PreTerminated"       // ConditionTypePreTerminated component status condition of the preTerminate action
+	ConditionTypeConfigDrifted       = "ConfigDrifted"       // ConditionTypeConfigDrifted component status condition of the configuration drift
+	ConditionTypeVolumeAutoExpansion = "VolumeAutoExpansion" // ConditionTypeVolumeAutoExpansion cluster status condition of the volume auto expansion
 )
 
 // Phase represents the current status of the ClusterDefinition and ClusterVersion CR.
@@ -284,17 +323,18 @@ const (
 
 // OpsPhase defines opsRequest phase.
 // +enum
-// +kubebuilder:validation:Enum={Pending,Creating,Running,Cancelling,Cancelled,Failed,Succeed}
+// +kubebuilder:validation:Enum={PendingApproval,Pending,Creating,Running,Cancelling,Cancelled,Failed,Succeed}
 type OpsPhase string
 
 const (
-	OpsPendingPhase    OpsPhase = "Pending"
-	OpsCreatingPhase   OpsPhase = "Creating"
-	OpsRunningPhase    OpsPhase = "Running"
-	OpsCancellingPhase OpsPhase = "Cancelling"
-	OpsSucceedPhase    OpsPhase = "Succeed"
-	OpsCancelledPhase  OpsPhase = "Cancelled"
-	OpsFailedPhase     OpsPhase = "Failed"
+	OpsPendingApprovalPhase OpsPhase = "PendingApproval"
+	OpsPendingPhase         OpsPhase = "Pending"
+	OpsCreatingPhase        OpsPhase = "Creating"
+	OpsRunningPhase         OpsPhase = "Running"
+	OpsCancellingPhase      OpsPhase = "Cancelling"
+	OpsSucceedPhase         OpsPhase = "Succeed"
+	OpsCancelledPhase       OpsPhase = "Cancelled"
+	OpsFailedPhase          OpsPhase = "Failed"
 )
 
 // PodSelectionPolicy pod selection strategy.
@@ -330,7 +370,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,Custom,Benchmark,PasswordRotation}
 type OpsType string
 
 const (
@@ -347,7 +387,19 @@ const (
 	DataScriptType        OpsType = "DataScript" // DataScriptType the data script operation will execute the data script against the cluster.
 	BackupType            OpsType = "Backup"
 	RestoreType           OpsType = "Restore"
-	CustomType            OpsType = "Custom" // use opsDefinition
+	CustomType            OpsType = "Custom"           // use opsDefinition
+	BenchmarkType         OpsType = "Benchmark"        // BenchmarkType the benchmark operation will run a load test against the cluster.
+	PasswordRotationType  OpsType = "PasswordRotation" // PasswordRotationType the password rotation operation will rotate the passwords of the system accounts.
+)
+
+// BenchmarkTool defines the tool used to run the benchmark.
+// +enum
+// +kubebuilder:validation:Enum={sysbench,pgbench}
+type BenchmarkTool string
+
+const (
+	SysbenchTool BenchmarkTool = "sysbench"
+	PgbenchTool  BenchmarkTool = "pgbench"
 )
 
 // ComponentResourceKey defines the resource key of component, such as pod/pvc.
@@ -444,6 +496,20 @@ const (
 	WipeOut TerminationPolicyType = "WipeOut"
 )
 
+// VolumeClaimRetentionPolicyType defines whether to retain the PVCs of the removed replicas.
+//
+// +enum
+// +kubebuilder:validation:Enum={Retain,Delete}
+type VolumeClaimRetentionPolicyType string
+
+const (
+	// RetainVolumeClaimRetentionPolicyType retains the PVCs of the removed replicas.
+	RetainVolumeClaimRetentionPolicyType VolumeClaimRetentionPolicyType = "Retain"
+
+	// DeleteVolumeClaimRetentionPolicyType deletes the PVCs of the removed replicas.
+	DeleteVolumeClaimRetentionPolicyType VolumeClaimRetentionPolicyType = "Delete"
+)
+
 // HScaleDataClonePolicyType defines the data clone policy to be used during horizontal scaling.
 // This policy determines how data is handled when new nodes are added to the cluster.
 // The policy can be set to `None`, `CloneVolume`, or `Snapshot`.
@@ -491,7 +557,8 @@ const (
 	// SharedNode means multiple pods may share the same node.
 	SharedNode TenancyType = "SharedNode"
 
-	// DedicatedNode means each pod runs on their own dedicated node.
+	// DedicatedNode means each pod runs on their own dedicated node, which is labeled and tainted
+	// with the key `kubeblocks.io/dedicated-node` and not shared with other database pods.
 	DedicatedNode TenancyType = "DedicatedNode"
 )
 
@@ -669,7 +736,7 @@ const (
 
 // UpgradePolicy defines the policy of reconfiguring.
 // +enum
-// +kubebuilder:validation:Enum={simple,parallel,rolling,autoReload,operatorSyncUpdate,dynamicReloadBeginRestart}
+// +kubebuilder:validation:Enum={simple,parallel,rolling,canary,autoReload,operatorSyncUpdate,dynamicReloadBeginRestart}
 type UpgradePolicy string
 
 const (
@@ -677,11 +744,33 @@ const (
 	NormalPolicy                  UpgradePolicy = "simple"
 	RestartPolicy                 UpgradePolicy = "parallel"
 	RollingPolicy                 UpgradePolicy = "rolling"
+	CanaryPolicy                  UpgradePolicy = "canary"
 	AsyncDynamicReloadPolicy      UpgradePolicy = "autoReload"
 	SyncDynamicReloadPolicy       UpgradePolicy = "operatorSyncUpdate"
 	DynamicReloadAndRestartPolicy UpgradePolicy = "dynamicReloadBeginRestart"
 )
 
+// ReconfigureRestartStrategy defines how the pods are restarted if the updated parameters require a restart.
+// +enum
+// +kubebuilder:validation:Enum={Rolling,Simultaneous}
+type ReconfigureRestartStrategy string
+
+const (
+	RollingRestartStrategy      ReconfigureRestartStrategy = "Rolling"
+	SimultaneousRestartStrategy ReconfigureRestartStrategy = "Simultaneous"
+)
+
+// ReconfigureRestartTiming defines when the pods are restarted if the updated parameters require a restart.
+// +enum
+// +kubebuilder:validation:Enum={Immediate,MaintenanceWindow,NextRestart}
+type ReconfigureRestartTiming string
+
+const (
+	ImmediateRestartTiming         ReconfigureRestartTiming = "Immediate"
+	MaintenanceWindowRestartTiming ReconfigureRestartTiming = "MaintenanceWindow"
+	NextRestartTiming              ReconfigureRestartTiming = "NextRestart"
+)
+
 // CfgReloadType defines reload method.
 // +enum
 type CfgReloadType string
@@ -736,7 +825,7 @@ const (
 
 // IssuerName defines the name of the TLS certificates issuer.
 // +enum
-// +kubebuilder:validation:Enum={KubeBlocks,UserProvided}
+// +kubebuilder:validation:Enum={KubeBlocks,UserProvided,CertManager}
 type IssuerName string
 
 const (
@@ -745,6 +834,22 @@ const (
 
 	// IssuerUserProvided indicates that the user has provided their own CA-signed certificates.
 	IssuerUserProvided IssuerName = "UserProvided"
+
+	// IssuerCertManager indicates that the certificates are requested from cert-manager.
+	IssuerCertManager IssuerName = "CertManager"
+)
+
+// TLSCertReloadPolicy defines how the rotated TLS certificates are picked up by the component.
+// +enum
+// +kubebuilder:validation:Enum={HotReload,RollingRestart}
+type TLSCertReloadPolicy string
+
+const (
+	// HotReloadTLSCert indicates that the engine reloads the certificates without restarting.
+	HotReloadTLSCert TLSCertReloadPolicy = "HotReload"
+
+	// RollingRestartTLSCert indicates that the pods are restarted one by one to load the new certificates.
+	RollingRestartTLSCert TLSCertReloadPolicy = "RollingRestart"
 )
 
 // SwitchPolicyType defines the types of switch policies that can be applied to a cluster.
@@ -797,6 +902,58 @@ const (
 // +kubebuilder:validation:Enum={full,snapshot}
 type BaseBackupType string
 
+// NodePortAllocationPolicy defines how to allocate the node ports of the NodePort and LoadBalancer services.
+//
+// +enum
+// +kubebuilder:validation:Enum={Random,Deterministic}
+type NodePortAllocationPolicy string
+
+const (
+	RandomNodePortAllocation        NodePortAllocationPolicy = "Random"
+	DeterministicNodePortAllocation NodePortAllocationPolicy = "Deterministic"
+)
+
+// LoadBalancerAnnotationProfile defines the predefined profile of the cloud provider specific annotations for the LoadBalancer service.
+//
+// +enum
+// +kubebuilder:validation:Enum={aws-nlb-internal,aws-nlb-internet,gcp-internal,aliyun-slb-intranet,aliyun-slb-internet,azure-internal,azure-internet}
+type LoadBalancerAnnotationProfile string
+
+const (
+	AWSNLBInternalProfile    LoadBalancerAnnotationProfile = "aws-nlb-internal"
+	AWSNLBInternetProfile    LoadBalancerAnnotationProfile = "aws-nlb-internet"
+	GCPInternalProfile       LoadBalancerAnnotationProfile = "gcp-internal"
+	AliyunSLBIntranetProfile LoadBalancerAnnotationProfile = "aliyun-slb-intranet"
+	AliyunSLBInternetProfile LoadBalancerAnnotationProfile = "aliyun-slb-internet"
+	AzureInternalProfile     LoadBalancerAnnotationProfile = "azure-internal"
+	AzureInternetProfile     LoadBalancerAnnotationProfile = "azure-internet"
+)
+
+// PodEndpointsType defines how to publish the per-pod endpoints of a component.
+//
+// +enum
+// +kubebuilder:validation:Enum={Headless,Service}
+type PodEndpointsType string
+
+const (
+	// HeadlessPodEndpoints publishes the FQDNs of the pods resolved by the headless service of the component.
+	HeadlessPodEndpoints PodEndpointsType = "Headless"
+
+	// ServicePodEndpoints creates a ClusterIP service for each pod and publishes the FQDNs of these services.
+	ServicePodEndpoints PodEndpointsType = "Service"
+)
+
+// ServiceMeshProvider defines the service mesh which injects the sidecar proxy into the pods.
+//
+// +enum
+// +kubebuilder:validation:Enum={Istio,Linkerd}
+type ServiceMeshProvider string
+
+const (
+	IstioServiceMesh   ServiceMeshProvider = "Istio"
+	LinkerdServiceMesh ServiceMeshProvider = "Linkerd"
+)
+
 // BackupStatusUpdateStage defines the stage of backup status update.
 //
 // +enum
@@ -868,6 +1025,16 @@ type ClusterService struct {
 	//
 	// +optional
 	ComponentSelector string `json:"componentSelector,omitempty"`
+
+	// Specifies how to allocate the node ports which are not specified explicitly when ServiceType is NodePort or LoadBalancer.
+	//
+	// - `Random`: the node ports are allocated by Kubernetes randomly, which is the default.
+	// - `Deterministic`: the node ports are derived from the hash of the namespace, the service name and the port name
+	//   in the range 30000-32767, so the same node ports are allocated if the service is re-created.
+	//   The node ports need to be specified explicitly if they conflict with those of other services.
+	//
+	// +optional
+	NodePortAllocation NodePortAllocationPolicy `json:"nodePortAllocation,omitempty"`
 }
 
 type ComponentService struct {
@@ -1004,6 +1171,10 @@ type VarSource struct {
 	// Selects a defined var of a ServiceRef.
 	// +optional
 	ServiceRefVarRef *ServiceRefVarSelector `json:"serviceRefVarRef,omitempty"`
+
+	// Selects a defined var of a Component, such as the pods with a specified role.
+	// +optional
+	ComponentVarRef *ComponentVarSelector `json:"componentVarRef,omitempty"`
 }
 
 // VarOption defines whether a variable is required or optional.
@@ -1074,6 +1245,28 @@ type ServiceRefVars struct {
 	CredentialVars `json:",inline"`
 }
 
+// ComponentVars defines the vars can be referenced from a Component.
+type ComponentVars struct {
+	// Reference to the names of the pods with the specified role, separated by comma and sorted by name.
+	// +optional
+	PodNamesForRole *RoledVar `json:"podNamesForRole,omitempty"`
+
+	// Reference to the FQDNs of the pods with the specified role, separated by comma and sorted by name.
+	// The value follows the role changes of the pods, e.g., a proxy can use it to discover the upstream leader.
+	// +optional
+	PodFQDNsForRole *RoledVar `json:"podFQDNsForRole,omitempty"`
+}
+
+// RoledVar defines a var which is resolved from the pods with the specified role.
+type RoledVar struct {
+	// The role of the pods, which is matched case-insensitively.
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+
+	// +optional
+	Option *VarOption `json:"option,omitempty"`
+}
+
 // PodVarSelector selects a var from a Pod.
 type PodVarSelector struct {
 	// The pod to select from.
@@ -1133,6 +1326,14 @@ type ServiceRefVarSelector struct {
 	ServiceRefVars `json:",inline"`
 }
 
+// ComponentVarSelector selects a var from a Component.
+type ComponentVarSelector struct {
+	// The Component to select from.
+	ClusterObjectReference `json:",inline"`
+
+	ComponentVars `json:",inline"`
+}
+
 // ClusterObjectReference contains information to let you locate the referenced object inside the same cluster.
 type ClusterObjectReference struct {
 	// CompDef specifies the definition used by the component that the referent object resident in.
@@ -1147,3 +1348,50 @@ type ClusterObjectReference struct {
 	// +optional
 	Optional *bool `json:"optional,omitempty"`
 }
+
+// SecurityContextDefaults defines the security settings applied to the pods and containers of a component,
+// if they are not specified in the pod template explicitly.
+type SecurityContextDefaults struct {
+	// Indicates that the containers must run as a non-root user.
+	//
+	// +optional
+	RunAsNonRoot *bool `json:"runAsNonRoot,omitempty"`
+
+	// The UID to run the entrypoint of the containers.
+	//
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// The GID to run the entrypoint of the containers.
+	//
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+
+	// A special supplemental group that applies to all containers, the volumes mounted will be owned by it.
+	//
+	// +optional
+	FSGroup *int64 `json:"fsGroup,omitempty"`
+
+	// The seccomp profile applied to the pods.
+	//
+	// +optional
+	SeccompProfile *corev1.SeccompProfile `json:"seccompProfile,omitempty"`
+
+	// Whether the processes of the containers can gain more privileges than their parent processes.
+	// It's not applied to the privileged containers.
+	//
+	// +optional
+	AllowPrivilegeEscalation *bool `json:"allowPrivilegeEscalation,omitempty"`
+
+	// Whether to drop all the capabilities of the containers, except for those added explicitly.
+	// It's not applied to the privileged containers.
+	//
+	// +optional
+	DropAllCapabilities *bool `json:"dropAllCapabilities,omitempty"`
+
+	// Whether the containers have a read-only root filesystem.
+	// Enable it only if the engine writes to the mounted volumes only.
+	//
+	// +optional
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty"`
+}
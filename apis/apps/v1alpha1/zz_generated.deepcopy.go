@@ -26,7 +26,9 @@ package v1alpha1
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -109,6 +111,11 @@ func (in *Affinity) DeepCopyInto(out *Affinity) {
 			(*out)[key] = val
 		}
 	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]TopologySpreadConstraint, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Affinity.
@@ -331,6 +338,27 @@ func (in *BackupSpec) DeepCopy() *BackupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BenchmarkSpec) DeepCopyInto(out *BenchmarkSpec) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(ScriptSecret)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BenchmarkSpec.
+func (in *BenchmarkSpec) DeepCopy() *BenchmarkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BenchmarkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CPUConstraint) DeepCopyInto(out *CPUConstraint) {
 	*out = *in
@@ -368,6 +396,41 @@ func (in *CPUConstraint) DeepCopy() *CPUConstraint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryOptions) DeepCopyInto(out *CanaryOptions) {
+	*out = *in
+	if in.ProbeCommand != nil {
+		in, out := &in.ProbeCommand, &out.ProbeCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryOptions.
+func (in *CanaryOptions) DeepCopy() *CanaryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerRef) DeepCopyInto(out *CertManagerIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerRef.
+func (in *CertManagerIssuerRef) DeepCopy() *CertManagerIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassDefRef) DeepCopyInto(out *ClassDefRef) {
 	*out = *in
@@ -440,6 +503,66 @@ func (in *ClusterBackup) DeepCopy() *ClusterBackup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterComponentActions) DeepCopyInto(out *ClusterComponentActions) {
+	*out = *in
+	if in.Promote != nil {
+		in, out := &in.Promote, &out.Promote
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Demote != nil {
+		in, out := &in.Demote, &out.Demote
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemberJoin != nil {
+		in, out := &in.MemberJoin, &out.MemberJoin
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemberLeave != nil {
+		in, out := &in.MemberLeave, &out.MemberLeave
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentActions.
+func (in *ClusterComponentActions) DeepCopy() *ClusterComponentActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterComponentActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterComponentConnectionPooler) DeepCopyInto(out *ClusterComponentConnectionPooler) {
+	*out = *in
+	if in.MaxClientConnections != nil {
+		in, out := &in.MaxClientConnections, &out.MaxClientConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefaultPoolSize != nil {
+		in, out := &in.DefaultPoolSize, &out.DefaultPoolSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentConnectionPooler.
+func (in *ClusterComponentConnectionPooler) DeepCopy() *ClusterComponentConnectionPooler {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterComponentConnectionPooler)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterComponentDefinition) DeepCopyInto(out *ClusterComponentDefinition) {
 	*out = *in
@@ -477,6 +600,13 @@ func (in *ClusterComponentDefinition) DeepCopyInto(out *ClusterComponentDefiniti
 		*out = new(v1.PodSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]SidecarSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Service != nil {
 		in, out := &in.Service, &out.Service
 		*out = new(ServiceSpec)
@@ -539,6 +669,16 @@ func (in *ClusterComponentDefinition) DeepCopyInto(out *ClusterComponentDefiniti
 		*out = new(PostStartAction)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PreTerminateSpec != nil {
+		in, out := &in.PreTerminateSpec, &out.PreTerminateSpec
+		*out = new(PreTerminateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = new(ClusterComponentActions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VolumeProtectionSpec != nil {
 		in, out := &in.VolumeProtectionSpec, &out.VolumeProtectionSpec
 		*out = new(VolumeProtectionSpec)
@@ -580,6 +720,23 @@ func (in *ClusterComponentService) DeepCopyInto(out *ClusterComponentService) {
 			(*out)[key] = val
 		}
 	}
+	if in.NodePorts != nil {
+		in, out := &in.NodePorts, &out.NodePorts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentService.
@@ -592,6 +749,21 @@ func (in *ClusterComponentService) DeepCopy() *ClusterComponentService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterComponentSidecar) DeepCopyInto(out *ClusterComponentSidecar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentSidecar.
+func (in *ClusterComponentSidecar) DeepCopy() *ClusterComponentSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterComponentSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 	*out = *in
@@ -637,6 +809,16 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]ClusterComponentSidecar, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionPooler != nil {
+		in, out := &in.ConnectionPooler, &out.ConnectionPooler
+		*out = new(ClusterComponentConnectionPooler)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SwitchPolicy != nil {
 		in, out := &in.SwitchPolicy, &out.SwitchPolicy
 		*out = new(ClusterSwitchPolicy)
@@ -667,6 +849,43 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalComponent)
+		**out = **in
+	}
+	if in.NodeFailureRecovery != nil {
+		in, out := &in.NodeFailureRecovery, &out.NodeFailureRecovery
+		*out = new(NodeFailureRecovery)
+		**out = **in
+	}
+	if in.VolumeClaimRetentionPolicy != nil {
+		in, out := &in.VolumeClaimRetentionPolicy, &out.VolumeClaimRetentionPolicy
+		*out = new(VolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ComponentAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScheduledScaling != nil {
+		in, out := &in.ScheduledScaling, &out.ScheduledScaling
+		*out = make([]ScheduledScalingWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodEndpoints != nil {
+		in, out := &in.PodEndpoints, &out.PodEndpoints
+		*out = new(PodEndpoints)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(SecurityContextDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentSpec.
@@ -703,6 +922,13 @@ func (in *ClusterComponentStatus) DeepCopyInto(out *ClusterComponentStatus) {
 		*out = make([]workloadsv1alpha1.MemberStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExposedEndpoints != nil {
+		in, out := &in.ExposedEndpoints, &out.ExposedEndpoints
+		*out = make([]ExposedEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentStatus.
@@ -736,6 +962,13 @@ func (in *ClusterComponentVersion) DeepCopyInto(out *ClusterComponentVersion) {
 		*out = new(SwitchoverShortSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpgradePreChecks != nil {
+		in, out := &in.UpgradePreChecks, &out.UpgradePreChecks
+		*out = make([]UpgradePreCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentVersion.
@@ -752,6 +985,11 @@ func (in *ClusterComponentVersion) DeepCopy() *ClusterComponentVersion {
 func (in *ClusterComponentVolumeClaimTemplate) DeepCopyInto(out *ClusterComponentVolumeClaimTemplate) {
 	*out = *in
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.AutoExpansion != nil {
+		in, out := &in.AutoExpansion, &out.AutoExpansion
+		*out = new(VolumeAutoExpansion)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentVolumeClaimTemplate.
@@ -915,6 +1153,11 @@ func (in *ClusterDefinitionSpec) DeepCopyInto(out *ClusterDefinitionSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.NamingTemplate != nil {
+		in, out := &in.NamingTemplate, &out.NamingTemplate
+		*out = new(NamingTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefinitionSpec.
@@ -1009,6 +1252,28 @@ func (in *ClusterNetwork) DeepCopy() *ClusterNetwork {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkPolicy) DeepCopyInto(out *ClusterNetworkPolicy) {
+	*out = *in
+	if in.Clients != nil {
+		in, out := &in.Clients, &out.Clients
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkPolicy.
+func (in *ClusterNetworkPolicy) DeepCopy() *ClusterNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterObjectReference) DeepCopyInto(out *ClusterObjectReference) {
 	*out = *in
@@ -1120,6 +1385,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMeshCompatibility)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(ClusterNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
@@ -1279,6 +1554,16 @@ func (in *ClusterVersionSpec) DeepCopyInto(out *ClusterVersionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpgradableFrom != nil {
+		in, out := &in.UpgradableFrom, &out.UpgradableFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageVerification != nil {
+		in, out := &in.ImageVerification, &out.ImageVerification
+		*out = new(ImageVerification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionSpec.
@@ -1413,6 +1698,53 @@ func (in *Component) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscaling) DeepCopyInto(out *ComponentAutoscaling) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScaleOutCooldownSeconds != nil {
+		in, out := &in.ScaleOutCooldownSeconds, &out.ScaleOutCooldownSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleInCooldownSeconds != nil {
+		in, out := &in.ScaleInCooldownSeconds, &out.ScaleInCooldownSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleOutStep != nil {
+		in, out := &in.ScaleOutStep, &out.ScaleOutStep
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleInStep != nil {
+		in, out := &in.ScaleInStep, &out.ScaleInStep
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscaling.
+func (in *ComponentAutoscaling) DeepCopy() *ComponentAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentClass) DeepCopyInto(out *ComponentClass) {
 	*out = *in
@@ -1831,6 +2163,16 @@ func (in *ComponentDefinitionSpec) DeepCopyInto(out *ComponentDefinitionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(SecurityContextDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotHook != nil {
+		in, out := &in.SnapshotHook, &out.SnapshotHook
+		*out = new(SnapshotHook)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionSpec.
@@ -1868,7 +2210,7 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 	}
 	if in.PreTerminate != nil {
 		in, out := &in.PreTerminate, &out.PreTerminate
-		*out = new(LifecycleActionHandler)
+		*out = new(PreTerminateActionHandler)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.RoleProbe != nil {
@@ -1921,6 +2263,11 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 		*out = new(LifecycleActionHandler)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccountPasswordRotation != nil {
+		in, out := &in.AccountPasswordRotation, &out.AccountPasswordRotation
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentLifecycleActions.
@@ -2232,6 +2579,36 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalComponent)
+		**out = **in
+	}
+	if in.NodeFailureRecovery != nil {
+		in, out := &in.NodeFailureRecovery, &out.NodeFailureRecovery
+		*out = new(NodeFailureRecovery)
+		**out = **in
+	}
+	if in.VolumeClaimRetentionPolicy != nil {
+		in, out := &in.VolumeClaimRetentionPolicy, &out.VolumeClaimRetentionPolicy
+		*out = new(VolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ComponentAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodEndpoints != nil {
+		in, out := &in.PodEndpoints, &out.PodEndpoints
+		*out = new(PodEndpoints)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(SecurityContextDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
@@ -2338,6 +2715,48 @@ func (in *ComponentValueFrom) DeepCopy() *ComponentValueFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentVarSelector) DeepCopyInto(out *ComponentVarSelector) {
+	*out = *in
+	in.ClusterObjectReference.DeepCopyInto(&out.ClusterObjectReference)
+	in.ComponentVars.DeepCopyInto(&out.ComponentVars)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVarSelector.
+func (in *ComponentVarSelector) DeepCopy() *ComponentVarSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentVarSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentVars) DeepCopyInto(out *ComponentVars) {
+	*out = *in
+	if in.PodNamesForRole != nil {
+		in, out := &in.PodNamesForRole, &out.PodNamesForRole
+		*out = new(RoledVar)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodFQDNsForRole != nil {
+		in, out := &in.PodFQDNsForRole, &out.PodFQDNsForRole
+		*out = new(RoledVar)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVars.
+func (in *ComponentVars) DeepCopy() *ComponentVars {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentVars)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentVolume) DeepCopyInto(out *ComponentVolume) {
 	*out = *in
@@ -2467,6 +2886,16 @@ func (in *ConfigConstraintSpec) DeepCopyInto(out *ConfigConstraintSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SensitiveParameters != nil {
+		in, out := &in.SensitiveParameters, &out.SensitiveParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CanaryOptions != nil {
+		in, out := &in.CanaryOptions, &out.CanaryOptions
+		*out = new(CanaryOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Selector != nil {
 		in, out := &in.Selector, &out.Selector
 		*out = new(metav1.LabelSelector)
@@ -2613,7 +3042,12 @@ func (in *ConfigurationItem) DeepCopyInto(out *ConfigurationItem) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(ReconfigureRestartPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationItem.
 func (in *ConfigurationItem) DeepCopy() *ConfigurationItem {
@@ -2671,6 +3105,13 @@ func (in *ConfigurationItemDetailStatus) DeepCopyInto(out *ConfigurationItemDeta
 		*out = new(ReconcileDetail)
 		**out = **in
 	}
+	if in.ParameterDiffs != nil {
+		in, out := &in.ParameterDiffs, &out.ParameterDiffs
+		*out = make([]ParameterDiff, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationItemDetailStatus.
@@ -2693,7 +3134,21 @@ func (in *ConfigurationItemStatus) DeepCopyInto(out *ConfigurationItemStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.LastConfigFileParams != nil {
+		in, out := &in.LastConfigFileParams, &out.LastConfigFileParams
+		*out = make(map[string]ConfigParams, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	in.UpdatedParameters.DeepCopyInto(&out.UpdatedParameters)
+	if in.ParameterDiffs != nil {
+		in, out := &in.ParameterDiffs, &out.ParameterDiffs
+		*out = make([]ParameterDiff, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationItemStatus.
@@ -3069,6 +3524,96 @@ func (in *CustomParametersValidation) DeepCopy() *CustomParametersValidation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseUser) DeepCopyInto(out *DatabaseUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUser.
+func (in *DatabaseUser) DeepCopy() *DatabaseUser {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseUserList) DeepCopyInto(out *DatabaseUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatabaseUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUserList.
+func (in *DatabaseUserList) DeepCopy() *DatabaseUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseUserSpec) DeepCopyInto(out *DatabaseUserSpec) {
+	*out = *in
+	out.PasswordConfig = in.PasswordConfig
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUserSpec.
+func (in *DatabaseUserSpec) DeepCopy() *DatabaseUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseUserStatus) DeepCopyInto(out *DatabaseUserStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseUserStatus.
+func (in *DatabaseUserStatus) DeepCopy() *DatabaseUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DownwardAPIOption) DeepCopyInto(out *DownwardAPIOption) {
 	*out = *in
@@ -3211,6 +3756,41 @@ func (in *Expose) DeepCopy() *Expose {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposedEndpoint) DeepCopyInto(out *ExposedEndpoint) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposedEndpoint.
+func (in *ExposedEndpoint) DeepCopy() *ExposedEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposedEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalComponent) DeepCopyInto(out *ExternalComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalComponent.
+func (in *ExternalComponent) DeepCopy() *ExternalComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FormatterConfig) DeepCopyInto(out *FormatterConfig) {
 	*out = *in
@@ -3319,6 +3899,11 @@ func (in *HorizontalScaling) DeepCopyInto(out *HorizontalScaling) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ScaleOutBatch != nil {
+		in, out := &in.ScaleOutBatch, &out.ScaleOutBatch
+		*out = new(ScaleOutBatchPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalScaling.
@@ -3378,6 +3963,26 @@ func (in *HostNetworkContainerPort) DeepCopy() *HostNetworkContainerPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerification) DeepCopyInto(out *ImageVerification) {
+	*out = *in
+	if in.AllowedDigests != nil {
+		in, out := &in.AllowedDigests, &out.AllowedDigests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerification.
+func (in *ImageVerification) DeepCopy() *ImageVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IniConfig) DeepCopyInto(out *IniConfig) {
 	*out = *in
@@ -3401,6 +4006,11 @@ func (in *Issuer) DeepCopyInto(out *Issuer) {
 		*out = new(TLSSecretRef)
 		**out = **in
 	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Issuer.
@@ -3561,6 +4171,21 @@ func (in *LogConfig) DeepCopy() *LogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchExpressions) DeepCopyInto(out *MatchExpressions) {
 	*out = *in
@@ -3614,6 +4239,11 @@ func (in *MonitorConfig) DeepCopyInto(out *MonitorConfig) {
 		*out = new(ExporterConfig)
 		**out = **in
 	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = new(MonitorTemplates)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorConfig.
@@ -3626,6 +4256,21 @@ func (in *MonitorConfig) DeepCopy() *MonitorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitorTemplates) DeepCopyInto(out *MonitorTemplates) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorTemplates.
+func (in *MonitorTemplates) DeepCopy() *MonitorTemplates {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitorTemplates)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamedVar) DeepCopyInto(out *NamedVar) {
 	*out = *in
@@ -3646,6 +4291,43 @@ func (in *NamedVar) DeepCopy() *NamedVar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamingTemplate) DeepCopyInto(out *NamingTemplate) {
+	*out = *in
+	if in.ComponentShortNames != nil {
+		in, out := &in.ComponentShortNames, &out.ComponentShortNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamingTemplate.
+func (in *NamingTemplate) DeepCopy() *NamingTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NamingTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailureRecovery) DeepCopyInto(out *NodeFailureRecovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailureRecovery.
+func (in *NodeFailureRecovery) DeepCopy() *NodeFailureRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailureRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsAction) DeepCopyInto(out *OpsAction) {
 	*out = *in
@@ -3681,6 +4363,67 @@ func (in *OpsAction) DeepCopy() *OpsAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsApproval) DeepCopyInto(out *OpsApproval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsApproval.
+func (in *OpsApproval) DeepCopy() *OpsApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsApprovalStatus) DeepCopyInto(out *OpsApprovalStatus) {
+	*out = *in
+	in.ApprovalTimestamp.DeepCopyInto(&out.ApprovalTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsApprovalStatus.
+func (in *OpsApprovalStatus) DeepCopy() *OpsApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsBackupStatus) DeepCopyInto(out *OpsBackupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsBackupStatus.
+func (in *OpsBackupStatus) DeepCopy() *OpsBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsBenchmarkResult) DeepCopyInto(out *OpsBenchmarkResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsBenchmarkResult.
+func (in *OpsBenchmarkResult) DeepCopy() *OpsBenchmarkResult {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsBenchmarkResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsDefinition) DeepCopyInto(out *OpsDefinition) {
 	*out = *in
@@ -3841,6 +4584,41 @@ func (in *OpsExecAction) DeepCopy() *OpsExecAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsPreconditions) DeepCopyInto(out *OpsPreconditions) {
+	*out = *in
+	if in.MinAvailableReplicas != nil {
+		in, out := &in.MinAvailableReplicas, &out.MinAvailableReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsPreconditions.
+func (in *OpsPreconditions) DeepCopy() *OpsPreconditions {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsPreconditions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsQueueStatus) DeepCopyInto(out *OpsQueueStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsQueueStatus.
+func (in *OpsQueueStatus) DeepCopy() *OpsQueueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsQueueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRecorder) DeepCopyInto(out *OpsRecorder) {
 	*out = *in
@@ -3919,6 +4697,18 @@ func (in *OpsRequestComponentStatus) DeepCopyInto(out *OpsRequestComponentStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExposedEndpoints != nil {
+		in, out := &in.ExposedEndpoints, &out.ExposedEndpoints
+		*out = make([]ExposedEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScaleOutBatch != nil {
+		in, out := &in.ScaleOutBatch, &out.ScaleOutBatch
+		*out = new(ScaleOutBatchStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestComponentStatus.
@@ -3966,6 +4756,11 @@ func (in *OpsRequestList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 	*out = *in
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(OpsApproval)
+		**out = **in
+	}
 	if in.Upgrade != nil {
 		in, out := &in.Upgrade, &out.Upgrade
 		*out = new(Upgrade)
@@ -3990,6 +4785,16 @@ func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 		*out = make([]ComponentOps, len(*in))
 		copy(*out, *in)
 	}
+	if in.StopList != nil {
+		in, out := &in.StopList, &out.StopList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartList != nil {
+		in, out := &in.StartList, &out.StartList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
 	if in.SwitchoverList != nil {
 		in, out := &in.SwitchoverList, &out.SwitchoverList
 		*out = make([]Switchover, len(*in))
@@ -4031,6 +4836,15 @@ func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ScheduledAt != nil {
+		in, out := &in.ScheduledAt, &out.ScheduledAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Preconditions != nil {
+		in, out := &in.Preconditions, &out.Preconditions
+		*out = new(OpsPreconditions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ScriptSpec != nil {
 		in, out := &in.ScriptSpec, &out.ScriptSpec
 		*out = new(ScriptSpec)
@@ -4041,10 +4855,22 @@ func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 		*out = new(BackupSpec)
 		**out = **in
 	}
+	if in.BenchmarkSpec != nil {
+		in, out := &in.BenchmarkSpec, &out.BenchmarkSpec
+		*out = new(BenchmarkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordRotationList != nil {
+		in, out := &in.PasswordRotationList, &out.PasswordRotationList
+		*out = make([]PasswordRotation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.RestoreSpec != nil {
 		in, out := &in.RestoreSpec, &out.RestoreSpec
 		*out = new(RestoreSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.CustomSpec != nil {
 		in, out := &in.CustomSpec, &out.CustomSpec
@@ -4110,6 +4936,26 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.BackupStatus != nil {
+		in, out := &in.BackupStatus, &out.BackupStatus
+		*out = new(OpsBackupStatus)
+		**out = **in
+	}
+	if in.BenchmarkResult != nil {
+		in, out := &in.BenchmarkResult, &out.BenchmarkResult
+		*out = new(OpsBenchmarkResult)
+		**out = **in
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(OpsApprovalStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueueStatus != nil {
+		in, out := &in.QueueStatus, &out.QueueStatus
+		*out = new(OpsQueueStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -4191,6 +5037,16 @@ func (in *OpsService) DeepCopyInto(out *OpsService) {
 			(*out)[key] = val
 		}
 	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(v1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]v1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsService.
@@ -4276,6 +5132,31 @@ func (in *ParameterConfig) DeepCopy() *ParameterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterDiff) DeepCopyInto(out *ParameterDiff) {
+	*out = *in
+	if in.OldValue != nil {
+		in, out := &in.OldValue, &out.OldValue
+		*out = new(string)
+		**out = **in
+	}
+	if in.NewValue != nil {
+		in, out := &in.NewValue, &out.NewValue
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterDiff.
+func (in *ParameterDiff) DeepCopy() *ParameterDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParameterPair) DeepCopyInto(out *ParameterPair) {
 	*out = *in
@@ -4330,6 +5211,27 @@ func (in *PasswordConfig) DeepCopy() *PasswordConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotation) DeepCopyInto(out *PasswordRotation) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotation.
+func (in *PasswordRotation) DeepCopy() *PasswordRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Payload.
 func (in *Payload) DeepCopy() *Payload {
 	if in == nil {
@@ -4371,6 +5273,21 @@ func (in *PersistentVolumeClaimSpec) DeepCopy() *PersistentVolumeClaimSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodEndpoints) DeepCopyInto(out *PodEndpoints) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodEndpoints.
+func (in *PodEndpoints) DeepCopy() *PodEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(PodEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSelector) DeepCopyInto(out *PodSelector) {
 	*out = *in
@@ -4531,6 +5448,43 @@ func (in *PreConditionExec) DeepCopy() *PreConditionExec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreTerminateAction) DeepCopyInto(out *PreTerminateAction) {
+	*out = *in
+	in.CmdExecutorConfig.DeepCopyInto(&out.CmdExecutorConfig)
+	if in.ScriptSpecSelectors != nil {
+		in, out := &in.ScriptSpecSelectors, &out.ScriptSpecSelectors
+		*out = make([]ScriptSpecSelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreTerminateAction.
+func (in *PreTerminateAction) DeepCopy() *PreTerminateAction {
+	if in == nil {
+		return nil
+	}
+	out := new(PreTerminateAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreTerminateActionHandler) DeepCopyInto(out *PreTerminateActionHandler) {
+	*out = *in
+	in.LifecycleActionHandler.DeepCopyInto(&out.LifecycleActionHandler)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreTerminateActionHandler.
+func (in *PreTerminateActionHandler) DeepCopy() *PreTerminateActionHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(PreTerminateActionHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProgressStatusDetail) DeepCopyInto(out *ProgressStatusDetail) {
 	*out = *in
@@ -4673,30 +5627,55 @@ func (in *ReconcileDetail) DeepCopy() *ReconcileDetail {
 	if in == nil {
 		return nil
 	}
-	out := new(ReconcileDetail)
+	out := new(ReconcileDetail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reconfigure) DeepCopyInto(out *Reconfigure) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Configurations != nil {
+		in, out := &in.Configurations, &out.Configurations
+		*out = make([]ConfigurationItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RollbackTimeoutSeconds != nil {
+		in, out := &in.RollbackTimeoutSeconds, &out.RollbackTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reconfigure.
+func (in *Reconfigure) DeepCopy() *Reconfigure {
+	if in == nil {
+		return nil
+	}
+	out := new(Reconfigure)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Reconfigure) DeepCopyInto(out *Reconfigure) {
+func (in *ReconfigureRestartPolicy) DeepCopyInto(out *ReconfigureRestartPolicy) {
 	*out = *in
-	out.ComponentOps = in.ComponentOps
-	if in.Configurations != nil {
-		in, out := &in.Configurations, &out.Configurations
-		*out = make([]ConfigurationItem, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reconfigure.
-func (in *Reconfigure) DeepCopy() *Reconfigure {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconfigureRestartPolicy.
+func (in *ReconfigureRestartPolicy) DeepCopy() *ReconfigureRestartPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Reconfigure)
+	out := new(ReconfigureRestartPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4864,6 +5843,39 @@ func (in *ResourceMeta) DeepCopy() *ResourceMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreComponentOverride) DeepCopyInto(out *RestoreComponentOverride) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]RestoreVolumeClaimTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreComponentOverride.
+func (in *RestoreComponentOverride) DeepCopy() *RestoreComponentOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreComponentOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreFromSpec) DeepCopyInto(out *RestoreFromSpec) {
 	*out = *in
@@ -4892,6 +5904,13 @@ func (in *RestoreFromSpec) DeepCopy() *RestoreFromSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 	*out = *in
+	if in.ComponentOverrides != nil {
+		in, out := &in.ComponentOverrides, &out.ComponentOverrides
+		*out = make([]RestoreComponentOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSpec.
@@ -4904,6 +5923,31 @@ func (in *RestoreSpec) DeepCopy() *RestoreSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVolumeClaimTemplate) DeepCopyInto(out *RestoreVolumeClaimTemplate) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVolumeClaimTemplate.
+func (in *RestoreVolumeClaimTemplate) DeepCopy() *RestoreVolumeClaimTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVolumeClaimTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
 	*out = *in
@@ -4935,6 +5979,26 @@ func (in *RoleProbe) DeepCopy() *RoleProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoledVar) DeepCopyInto(out *RoledVar) {
+	*out = *in
+	if in.Option != nil {
+		in, out := &in.Option, &out.Option
+		*out = new(VarOption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoledVar.
+func (in *RoledVar) DeepCopy() *RoledVar {
+	if in == nil {
+		return nil
+	}
+	out := new(RoledVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rule) DeepCopyInto(out *Rule) {
 	*out = *in
@@ -4950,6 +6014,40 @@ func (in *Rule) DeepCopy() *Rule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOutBatchPolicy) DeepCopyInto(out *ScaleOutBatchPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOutBatchPolicy.
+func (in *ScaleOutBatchPolicy) DeepCopy() *ScaleOutBatchPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOutBatchPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleOutBatchStatus) DeepCopyInto(out *ScaleOutBatchStatus) {
+	*out = *in
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleOutBatchStatus.
+func (in *ScaleOutBatchStatus) DeepCopy() *ScaleOutBatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleOutBatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulePolicy) DeepCopyInto(out *SchedulePolicy) {
 	*out = *in
@@ -4970,6 +6068,26 @@ func (in *SchedulePolicy) DeepCopy() *SchedulePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledScalingWindow) DeepCopyInto(out *ScheduledScalingWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledScalingWindow.
+func (in *ScheduledScalingWindow) DeepCopy() *ScheduledScalingWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledScalingWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptConfig) DeepCopyInto(out *ScriptConfig) {
 	*out = *in
@@ -5097,6 +6215,61 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityContextDefaults) DeepCopyInto(out *SecurityContextDefaults) {
+	*out = *in
+	if in.RunAsNonRoot != nil {
+		in, out := &in.RunAsNonRoot, &out.RunAsNonRoot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SeccompProfile != nil {
+		in, out := &in.SeccompProfile, &out.SeccompProfile
+		*out = new(v1.SeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowPrivilegeEscalation != nil {
+		in, out := &in.AllowPrivilegeEscalation, &out.AllowPrivilegeEscalation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DropAllCapabilities != nil {
+		in, out := &in.DropAllCapabilities, &out.DropAllCapabilities
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadOnlyRootFilesystem != nil {
+		in, out := &in.ReadOnlyRootFilesystem, &out.ReadOnlyRootFilesystem
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityContextDefaults.
+func (in *SecurityContextDefaults) DeepCopy() *SecurityContextDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityContextDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -5224,6 +6397,21 @@ func (in *ServiceDescriptorStatus) DeepCopy() *ServiceDescriptorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMeshCompatibility) DeepCopyInto(out *ServiceMeshCompatibility) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMeshCompatibility.
+func (in *ServiceMeshCompatibility) DeepCopy() *ServiceMeshCompatibility {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMeshCompatibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServicePort) DeepCopyInto(out *ServicePort) {
 	*out = *in
@@ -5448,6 +6636,55 @@ func (in *ShellTrigger) DeepCopy() *ShellTrigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarSpec) DeepCopyInto(out *SidecarSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	in.Container.DeepCopyInto(&out.Container)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarSpec.
+func (in *SidecarSpec) DeepCopy() *SidecarSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotHook) DeepCopyInto(out *SnapshotHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotHook.
+func (in *SnapshotHook) DeepCopy() *SnapshotHook {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatefulSetSpec) DeepCopyInto(out *StatefulSetSpec) {
 	*out = *in
@@ -5824,6 +7061,21 @@ func (in *ToolsImageSpec) DeepCopy() *ToolsImageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpreadConstraint) DeepCopyInto(out *TopologySpreadConstraint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadConstraint.
+func (in *TopologySpreadConstraint) DeepCopy() *TopologySpreadConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpreadConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TypedObjectRef) DeepCopyInto(out *TypedObjectRef) {
 	*out = *in
@@ -5910,6 +7162,31 @@ func (in *Upgrade) DeepCopy() *Upgrade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePreCheck) DeepCopyInto(out *UpgradePreCheck) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePreCheck.
+func (in *UpgradePreCheck) DeepCopy() *UpgradePreCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePreCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserResourceRefs) DeepCopyInto(out *UserResourceRefs) {
 	*out = *in
@@ -6021,6 +7298,11 @@ func (in *VarSource) DeepCopyInto(out *VarSource) {
 		*out = new(ServiceRefVarSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ComponentVarRef != nil {
+		in, out := &in.ComponentVarRef, &out.ComponentVarRef
+		*out = new(ComponentVarSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VarSource.
@@ -6084,6 +7366,38 @@ func (in *VerticalScaling) DeepCopy() *VerticalScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAutoExpansion) DeepCopyInto(out *VolumeAutoExpansion) {
+	*out = *in
+	out.Step = in.Step.DeepCopy()
+	out.MaxSize = in.MaxSize.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAutoExpansion.
+func (in *VolumeAutoExpansion) DeepCopy() *VolumeAutoExpansion {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAutoExpansion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeClaimRetentionPolicy) DeepCopyInto(out *VolumeClaimRetentionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimRetentionPolicy.
+func (in *VolumeClaimRetentionPolicy) DeepCopy() *VolumeClaimRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeClaimRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeExpansion) DeepCopyInto(out *VolumeExpansion) {
 	*out = *in
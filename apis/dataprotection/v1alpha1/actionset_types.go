@@ -117,6 +117,13 @@ type BackupActionSpec struct {
 	//
 	// +optional
 	PreDeleteBackup *BaseJobActionSpec `json:"preDelete,omitempty"`
+
+	// Represents a custom action to verify whether the backup data can be restored,
+	// such as an engine-specific tool checking the integrity of the backup.
+	// It is executed periodically if the verification of the backup policy is enabled.
+	//
+	// +optional
+	VerifyBackup *BaseJobActionSpec `json:"verify,omitempty"`
 }
 
 // BackupDataActionSpec defines how to back up data.
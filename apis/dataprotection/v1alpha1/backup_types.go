@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -70,10 +71,31 @@ type BackupSpec struct {
 	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
 
 	// Determines the parent backup name for incremental or differential backup.
+	// It is required for incremental backup, the parent backup must be a completed
+	// full or incremental backup using the same backup policy.
+	// Deleting the parent backup also deletes the backups based on it, and an expired
+	// parent backup is retained until all the backups based on it are expired.
 	//
 	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.parentBackupName"
 	ParentBackupName string `json:"parentBackupName,omitempty"`
+
+	// Specifies the maximum bandwidth per second that the backup is allowed to use
+	// when transferring data to the backup repository, e.g. `100Mi` means 100 MiB/s.
+	// If the backup repository also defines a bandwidth limit, the smaller one takes effect.
+	// If not set, the bandwidth is unlimited.
+	//
+	// +optional
+	BandwidthLimit *resource.Quantity `json:"bandwidthLimit,omitempty"`
+
+	// Specifies whether the backup is protected from deletion, which works as a
+	// legal hold. A protected backup can not be deleted by users and is never
+	// removed by the garbage collection, even if it is expired or exceeds the
+	// retention of the backup policy. Its parent backups are retained as well.
+	// The backup can be deleted after the protection is lifted.
+	//
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
 }
 
 // BackupStatus defines the observed state of Backup.
@@ -120,6 +142,19 @@ type BackupStatus struct {
 	// +optional
 	TotalSize string `json:"totalSize,omitempty"`
 
+	// Records the size of the backup data after compression, which is reported by the backup tool.
+	// The size is represented as a string with capacity units in the format of "1Gi", "1Mi", "1Ki".
+	//
+	// +optional
+	CompressedSize string `json:"compressedSize,omitempty"`
+
+	// Records the average throughput of the backup operation, which is calculated
+	// by the total size and the duration of the backup.
+	// The throughput is represented as a string with capacity units per second in the format of "10Mi/s".
+	//
+	// +optional
+	Throughput string `json:"throughput,omitempty"`
+
 	// Any error that caused the backup operation to fail.
 	//
 	// +optional
@@ -168,6 +203,23 @@ type BackupStatus struct {
 	// +optional
 	EncryptionConfig *EncryptionConfig `json:"encryptionConfig,omitempty"`
 
+	// Records the compression config for this backup.
+	//
+	// +optional
+	CompressionConfig *CompressionConfig `json:"compressionConfig,omitempty"`
+
+	// Records the effective bandwidth limit per second for this backup, which is
+	// the smaller one of the bandwidth limits of the backup and the backup repository.
+	//
+	// +optional
+	BandwidthLimit *resource.Quantity `json:"bandwidthLimit,omitempty"`
+
+	// Records the pod settings of the backup and restore jobs for this backup,
+	// which are copied from the backup policy.
+	//
+	// +optional
+	JobPodTemplate *JobPodTemplate `json:"jobPodTemplate,omitempty"`
+
 	// Records the actions status for this backup.
 	//
 	// +optional
@@ -182,8 +234,53 @@ type BackupStatus struct {
 	//
 	// +optional
 	Extras []map[string]string `json:"extras,omitempty"`
+
+	// Records the latest verification result of the backup.
+	//
+	// +optional
+	Verification *BackupVerificationStatus `json:"verification,omitempty"`
+}
+
+// BackupVerificationStatus records the result of a backup verification.
+type BackupVerificationStatus struct {
+	// The phase of the verification.
+	//
+	// +optional
+	Phase BackupVerificationPhase `json:"phase,omitempty"`
+
+	// Records the time the verification was started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time the verification was completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// An error that caused the verification to fail.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
 }
 
+// BackupVerificationPhase describes the phase of a backup verification.
+// +enum
+// +kubebuilder:validation:Enum={Running,Passed,Failed}
+type BackupVerificationPhase string
+
+const (
+	// BackupVerificationPhaseRunning means the verification is running.
+	BackupVerificationPhaseRunning BackupVerificationPhase = "Running"
+
+	// BackupVerificationPhasePassed means the backup is verified to be restorable.
+	BackupVerificationPhasePassed BackupVerificationPhase = "Passed"
+
+	// BackupVerificationPhaseFailed means the verification failed, the backup may
+	// not be restorable.
+	BackupVerificationPhaseFailed BackupVerificationPhase = "Failed"
+)
+
 // BackupTimeRange records the time range of backed up data, for PITR, this is the
 // time range of recoverable data.
 type BackupTimeRange struct {
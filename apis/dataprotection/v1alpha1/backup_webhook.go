@@ -0,0 +1,63 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var backuplog = logf.Log.WithName("backup-resource")
+
+func (r *Backup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dataprotection-kubeblocks-io-v1alpha1-backup,mutating=false,failurePolicy=fail,sideEffects=None,groups=dataprotection.kubeblocks.io,resources=backups,verbs=delete,versions=v1alpha1,name=vbackup.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Backup{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *Backup) ValidateCreate() (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *Backup) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Backup) ValidateDelete() (admission.Warnings, error) {
+	backuplog.Info("validate delete", "name", r.Name)
+	if r.Spec.DeletionProtection {
+		return nil, fmt.Errorf("the deletion for backup %s is denied because its deletionProtection is enabled, "+
+			"disable the deletionProtection before deleting it", r.Name)
+	}
+	return nil, nil
+}
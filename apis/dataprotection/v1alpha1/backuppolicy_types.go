@@ -72,6 +72,112 @@ type BackupPolicySpec struct {
 	//
 	// +optional
 	EncryptionConfig *EncryptionConfig `json:"encryptionConfig,omitempty"`
+
+	// Specifies the parameters for compressing backup data, which are passed to
+	// the backup tool by the environment variables `DP_COMPRESSION_ALGORITHM` and
+	// `DP_COMPRESSION_LEVEL`.
+	// The backup tool decides how to compress the data if the field is not set.
+	//
+	// +optional
+	CompressionConfig *CompressionConfig `json:"compressionConfig,omitempty"`
+
+	// Specifies the retention of the backups created from this policy.
+	// The backups exceeding the retention will be deleted by the garbage collection
+	// controller, together with their data stored in the backup repository.
+	//
+	// +optional
+	Retention *BackupRetention `json:"retention,omitempty"`
+
+	// Specifies the verification of the backups created from this policy.
+	// If set, the latest completed backup of each backup method will be verified
+	// periodically by the verify action of its ActionSet, and the result will be
+	// recorded in the backup status.
+	//
+	// +optional
+	Verification *BackupVerification `json:"verification,omitempty"`
+
+	// Specifies the pod settings of the backup and restore jobs, which override
+	// the default settings of the jobs, so that the backup workloads can be
+	// scheduled onto dedicated nodes and comply with the security policies of
+	// the cluster.
+	//
+	// +optional
+	JobPodTemplate *JobPodTemplate `json:"jobPodTemplate,omitempty"`
+}
+
+// JobPodTemplate defines the pod settings of the backup and restore jobs.
+type JobPodTemplate struct {
+	// Specifies the node selector of the job pod. It is ignored if the job is
+	// required to run on the node of the target pod.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Specifies the tolerations of the job pod. It is ignored if the job is
+	// required to run on the node of the target pod.
+	//
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Specifies the resources of the job container.
+	// The resources specified in the runtimeSettings of the backup method take
+	// precedence over it.
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Specifies the service account of the job pod. The service account must
+	// have the permissions to get and patch the status of backups.
+	// If not set, the worker service account created by the controller is used.
+	//
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// Specifies the security context of the job pod.
+	//
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+
+	// Specifies the secrets for pulling the images of the job pod.
+	//
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// BackupVerification defines how to verify the backups created from a backup policy.
+type BackupVerification struct {
+	// Specifies the interval between two verifications of the backups with the same
+	// backup method. The format is the same as the retentionPeriod of the backup,
+	// for example, `7d`.
+	//
+	// +kubebuilder:default="7d"
+	// +optional
+	Interval RetentionPeriod `json:"interval,omitempty"`
+}
+
+// BackupRetention defines the retention of the backups created from a backup policy.
+// Continuous backups are not limited by the retention.
+type BackupRetention struct {
+	// Specifies the maximum number of completed backups to retain.
+	// The oldest backups exceeding the count will be deleted.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+
+	// Specifies the maximum age of the completed backups to retain, the age is
+	// counted from the start time of the backup.
+	// The format is the same as the retentionPeriod of the backup, for example, `30d`.
+	//
+	// +optional
+	MaxAge RetentionPeriod `json:"maxAge,omitempty"`
+
+	// Specifies whether to retain the latest completed full backup even though
+	// it exceeds the retention or is expired, so that there is always a full
+	// backup to restore from.
+	//
+	// +optional
+	KeepLastFull bool `json:"keepLastFull,omitempty"`
 }
 
 type BackupTarget struct {
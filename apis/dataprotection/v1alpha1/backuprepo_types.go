@@ -70,6 +70,14 @@ type BackupRepoSpec struct {
 	//
 	// +optional
 	Credential *corev1.SecretReference `json:"credential,omitempty"`
+
+	// Specifies the maximum bandwidth per second that each backup job is allowed
+	// to use when transferring data to this repository, e.g. `100Mi` means 100 MiB/s.
+	// It prevents backups from saturating the network or disk of busy clusters.
+	// If not set, the bandwidth is unlimited.
+	//
+	// +optional
+	BandwidthLimit *resource.Quantity `json:"bandwidthLimit,omitempty"`
 }
 
 // BackupRepoStatus defines the observed state of `BackupRepo`.
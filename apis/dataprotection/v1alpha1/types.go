@@ -250,3 +250,42 @@ type EncryptionConfig struct {
 	// +kubebuilder:validation:Required
 	PassPhraseSecretKeyRef *corev1.SecretKeySelector `json:"passPhraseSecretKeyRef"`
 }
+
+// CompressionAlgorithm defines the algorithm for compressing backup data.
+// +enum
+// +kubebuilder:validation:Enum={gzip,zstd,lz4,none}
+type CompressionAlgorithm string
+
+const (
+	CompressionAlgorithmGzip CompressionAlgorithm = "gzip"
+	CompressionAlgorithmZstd CompressionAlgorithm = "zstd"
+	CompressionAlgorithmLz4  CompressionAlgorithm = "lz4"
+	CompressionAlgorithmNone CompressionAlgorithm = "none"
+)
+
+// CompressionConfig defines the parameters for compressing backup data.
+// +kubebuilder:validation:XValidation:rule="!has(self.level) || self.algorithm != 'none'",message="level is not allowed when the compression is disabled"
+// +kubebuilder:validation:XValidation:rule="!has(self.level) || self.algorithm != 'gzip' || self.level <= 9",message="the level of gzip should be in the range of 1 to 9"
+// +kubebuilder:validation:XValidation:rule="!has(self.level) || self.algorithm != 'lz4' || self.level <= 12",message="the level of lz4 should be in the range of 1 to 12"
+type CompressionConfig struct {
+	// Specifies the compression algorithm. Currently supported algorithms are:
+	//
+	// - gzip
+	// - zstd
+	// - lz4
+	// - none: the backup data is not compressed.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=zstd
+	Algorithm CompressionAlgorithm `json:"algorithm"`
+
+	// Specifies the compression level, a higher level results in a better compression
+	// ratio at the cost of more CPU. The valid range depends on the algorithm,
+	// 1 to 9 for gzip, 1 to 22 for zstd and 1 to 12 for lz4.
+	// If not specified, the default level of the algorithm is used.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=22
+	// +optional
+	Level *int32 `json:"level,omitempty"`
+}
@@ -51,6 +51,7 @@ import (
 	dpcontrollers "github.com/apecloud/kubeblocks/controllers/dataprotection"
 	storagecontrollers "github.com/apecloud/kubeblocks/controllers/storage"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
@@ -107,6 +108,7 @@ func init() {
 	viper.SetDefault(dptypes.CfgKeyWorkerServiceAccountAnnotations, "{}")
 	viper.SetDefault(dptypes.CfgKeyWorkerClusterRoleName, "kubeblocks-dataprotection-worker-role")
 	viper.SetDefault(dptypes.CfgDataProtectionReconcileWorkers, runtime.NumCPU())
+	viper.SetDefault(constant.CfgKeyNotificationClusterWebhooksEnabled, false)
 }
 
 func main() {
@@ -180,6 +182,7 @@ func main() {
 	if len(managedNamespaces) > 0 {
 		setupLog.Info(fmt.Sprintf("managed namespaces: %s", managedNamespaces))
 	}
+	eventBroadcaster := intctrlutil.NewEventBroadcaster()
 	mgr, err := ctrl.NewManager(intctrlutil.GeKubeRestConfig(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
@@ -207,6 +210,9 @@ func main() {
 
 		CertDir:               viper.GetString("cert_dir"),
 		ClientDisableCacheFor: intctrlutil.GetUncachedObjects(),
+
+		// aggregate the repeated events, the broadcaster is stopped after the manager stops.
+		EventBroadcaster: eventBroadcaster, //nolint:staticcheck
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -327,7 +333,12 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	// the manager doesn't stop the broadcaster given, stop it to write the events held for aggregation.
+	if eventBroadcaster != nil {
+		eventBroadcaster.Shutdown()
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -381,5 +392,11 @@ func validateRequiredToParseConfigs() error {
 	if err := validateWorkerServiceAccountAnnotations(viper.GetString(dptypes.CfgKeyWorkerServiceAccountAnnotations)); err != nil {
 		return err
 	}
+	if _, err := secretstore.GetProvider(); err != nil {
+		return err
+	}
+	if _, err := intctrlutil.GetDataPlaneSecurityContext(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -36,6 +36,7 @@ import (
 	kzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
 	"github.com/apecloud/kubeblocks/pkg/lorry/grpcserver"
@@ -78,6 +79,12 @@ func main() {
 	}
 	ctrl.SetLogger(kzap.New(kopts...))
 
+	// Load the credentials delivered from the external secret manager
+	err = secretstore.LoadFileEnvs()
+	if err != nil {
+		panic(errors.Wrap(err, "load the credentials from files failed"))
+	}
+
 	// Initialize DB Manager
 	err = register.InitDBManager(configDir)
 	if err != nil {
@@ -20,10 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"runtime"
 	"strings"
 	"time"
 
@@ -33,6 +35,7 @@ import (
 	snapshotv1beta1 "github.com/kubernetes-csi/external-snapshotter/client/v3/apis/volumesnapshot/v1beta1"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	"github.com/spf13/pflag"
+	"go.uber.org/automaxprocs/maxprocs"
 	corev1 "k8s.io/api/core/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -56,8 +59,11 @@ import (
 	k8scorecontrollers "github.com/apecloud/kubeblocks/controllers/k8score"
 	workloadscontrollers "github.com/apecloud/kubeblocks/controllers/workloads"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/debug"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/metrics"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -71,6 +77,7 @@ const (
 
 	probeAddrFlagKey     flagName = "health-probe-bind-address"
 	metricsAddrFlagKey   flagName = "metrics-bind-address"
+	debugAddrFlagKey     flagName = "debug-bind-address"
 	leaderElectFlagKey   flagName = "leader-elect"
 	leaderElectIDFlagKey flagName = "leader-elect-id"
 
@@ -109,6 +116,8 @@ func init() {
 	viper.SetDefault(constant.CfgKeyCtrlrReconcileRetryDurationMS, 1000)
 	viper.SetDefault("CERT_DIR", "/tmp/k8s-webhook-server/serving-certs")
 	viper.SetDefault(constant.EnableRBACManager, true)
+	viper.SetDefault(constant.EnableLeastPrivilegeRBAC, false)
+	viper.SetDefault(constant.CfgKeySecretStorePathPrefix, "kubeblocks")
 	viper.SetDefault("VOLUMESNAPSHOT_API_BETA", false)
 	viper.SetDefault(constant.KBToolsImage, "apecloud/kubeblocks-tools:latest")
 	viper.SetDefault(constant.KBEnvLorryHTTPPort, 3501)
@@ -126,8 +135,14 @@ func init() {
 	viper.SetDefault(constant.KubernetesClusterDomainEnv, constant.DefaultDNSDomain)
 	viper.SetDefault(rsm.FeatureGateRSMCompatibilityMode, true)
 	viper.SetDefault(rsm.FeatureGateRSMToPod, true)
+	viper.SetDefault(rsm.FeatureGateRSMInPlacePodVerticalScaling, false)
 	viper.SetDefault(constant.FeatureGateEnableRuntimeMetrics, false)
-	viper.SetDefault(constant.CfgKBReconcileWorkers, 8)
+	viper.SetDefault(constant.FeatureGateEnableFleetMetrics, true)
+	// GOMAXPROCS follows the CPU quota of the container rather than the CPUs of the node.
+	_, _ = maxprocs.Set()
+	viper.SetDefault(constant.CfgKBReconcileWorkers, max(8, runtime.GOMAXPROCS(0)*2))
+	viper.SetDefault(constant.CfgKeyNotificationClusterWebhooksEnabled, false)
+	viper.SetDefault(constant.CfgKeyVolumeAutoExpansionEnabled, false)
 }
 
 type flagName string
@@ -143,6 +158,9 @@ func (r flagName) viperName() string {
 func setupFlags() {
 	flag.String(metricsAddrFlagKey.String(), ":8080", "The address the metric endpoint binds to.")
 	flag.String(probeAddrFlagKey.String(), ":8081", "The address the probe endpoint binds to.")
+	flag.String(debugAddrFlagKey.String(), "",
+		"The address the pprof and debug endpoints bind to, e.g., 127.0.0.1:6060, disabled if empty. "+
+			"The DEBUG_AUTH_TOKEN is required if it's not bound to localhost.")
 	flag.Bool(leaderElectFlagKey.String(), false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -230,6 +248,12 @@ func validateRequiredToParseConfigs() error {
 	if err := validateAffinity(viper.GetString(constant.CfgKeyDataPlaneAffinity)); err != nil {
 		return err
 	}
+	if _, err := secretstore.GetProvider(); err != nil {
+		return err
+	}
+	if _, err := intctrlutil.GetDataPlaneSecurityContext(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -272,6 +296,17 @@ func main() {
 	enableLeaderElectionID = viper.GetString(leaderElectIDFlagKey.viperName())
 	kubeContexts = viper.GetString(kubeContextsFlagKey.viperName())
 
+	// each shard elects its own leader in the sharding mode
+	if intctrlutil.ShardingEnabled() {
+		shardID, err := intctrlutil.ShardID()
+		if err != nil {
+			setupLog.Error(err, "unable to get the shard id")
+			os.Exit(1)
+		}
+		enableLeaderElectionID = fmt.Sprintf("%s-shard-%d", enableLeaderElectionID, shardID)
+	}
+
+	eventBroadcaster := intctrlutil.NewEventBroadcaster()
 	mgr, err := ctrl.NewManager(intctrlutil.GeKubeRestConfig(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
@@ -299,6 +334,9 @@ func main() {
 
 		CertDir:               viper.GetString("cert_dir"),
 		ClientDisableCacheFor: intctrlutil.GetUncachedObjects(),
+
+		// aggregate the repeated events, the broadcaster is stopped after the manager stops.
+		EventBroadcaster: eventBroadcaster, //nolint:staticcheck
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -322,6 +360,27 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := intctrlutil.SetupSharding(mgr.GetCache()); err != nil {
+		setupLog.Error(err, "unable to setup sharding")
+		os.Exit(1)
+	}
+
+	if err := intctrlutil.RegisterFieldIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to register field indexes")
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		setupLog.Error(err, "unable to setup tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			setupLog.Error(err, "failed to shutdown tracing")
+		}
+	}()
+
 	if viper.GetBool(appsFlagKey.viperName()) {
 		if err = (&appscontrollers.ClusterReconciler{
 			Client:   client,
@@ -377,6 +436,15 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&appscontrollers.DatabaseUserReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("database-user-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "DatabaseUser")
+			os.Exit(1)
+		}
+
 		if err = (&appscontrollers.OpsRequestReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
@@ -422,6 +490,27 @@ func main() {
 			os.Exit(1)
 		}
 
+		if viper.GetBool(constant.CfgKeyVolumeAutoExpansionEnabled) {
+			if err = (&appscontrollers.VolumeAutoExpansionReconciler{
+				Client:     mgr.GetClient(),
+				Scheme:     mgr.GetScheme(),
+				Recorder:   mgr.GetEventRecorderFor("volume-auto-expansion-controller"),
+				RestConfig: mgr.GetConfig(),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "VolumeAutoExpansion")
+				os.Exit(1)
+			}
+		}
+
+		if err = (&appscontrollers.ScheduledScalingReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("scheduled-scaling-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ScheduledScaling")
+			os.Exit(1)
+		}
+
 		if err = (&k8scorecontrollers.EventReconciler{
 			Client:   client,
 			Scheme:   mgr.GetScheme(),
@@ -525,6 +614,28 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ServiceDescriptor")
 			os.Exit(1)
 		}
+
+		if err = (&dpv1alpha1.Backup{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Backup")
+			os.Exit(1)
+		}
+
+		if err = configuration.SetupConfigMapWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ConfigMap")
+			os.Exit(1)
+		}
+	}
+
+	if debugAddr := viper.GetString(debugAddrFlagKey.viperName()); len(debugAddr) > 0 {
+		debugServer, err := debug.NewServer(debugAddr, viper.GetString(constant.CfgKeyDebugAuthToken), mgr.GetClient())
+		if err != nil {
+			setupLog.Error(err, "unable to create debug server")
+			os.Exit(1)
+		}
+		if err = mgr.Add(debugServer); err != nil {
+			setupLog.Error(err, "unable to add debug server")
+			os.Exit(1)
+		}
 	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -551,6 +662,8 @@ func main() {
 
 	setupLog.Info("golang runtime metrics.", "featureGate", constant.EnabledRuntimeMetrics())
 	metrics.RegisterRuntimeMetric(mgr)
+	setupLog.Info("fleet metrics.", "featureGate", constant.EnabledFleetMetrics())
+	metrics.RegisterFleetMetric(mgr)
 
 	setupLog.Info("starting manager")
 	if multiClusterMgr != nil {
@@ -559,7 +672,12 @@ func main() {
 			os.Exit(1)
 		}
 	}
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	// the manager doesn't stop the broadcaster given, stop it to write the events held for aggregation.
+	if eventBroadcaster != nil {
+		eventBroadcaster.Shutdown()
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
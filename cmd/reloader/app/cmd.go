@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -145,7 +146,7 @@ func startGRPCService(opt *VolumeWatcherOpts, ctx context.Context, handler cfgco
 		return err
 	}
 
-	tcpSpec := fmt.Sprintf("%s:%d", proxy.opt.PodIP, proxy.opt.GrpcPort)
+	tcpSpec := net.JoinHostPort(proxy.opt.PodIP, strconv.Itoa(proxy.opt.GrpcPort))
 
 	logger.Infof("starting reconfigure service: %s", tcpSpec)
 	listener, err := net.Listen("tcp", tcpSpec)
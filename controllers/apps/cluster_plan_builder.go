@@ -28,6 +28,7 @@ import (
 	snapshotv1beta1 "github.com/kubernetes-csi/external-snapshotter/client/v3/apis/volumesnapshot/v1beta1"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -45,7 +46,10 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/notification"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 )
 
 const (
@@ -81,14 +85,20 @@ type clusterPlanBuilder struct {
 	cli          client.Client
 	transCtx     *clusterTransformContext
 	transformers graph.TransformerChain
+	// statusWriter accumulates the cluster status changes of the reconciliation and writes them in a single patch
+	statusWriter *intctrlutil.StatusWriter[*appsv1alpha1.Cluster]
+	// statusVertex is the cluster vertex whose status changes are queued in statusWriter
+	statusVertex *model.ObjectVertex
 }
 
 // clusterPlan a graph.Plan implementation for Cluster reconciliation
 type clusterPlan struct {
-	dag      *graph.DAG
-	walkFunc graph.WalkFunc
-	cli      client.Client
-	transCtx *clusterTransformContext
+	dag          *graph.DAG
+	walkFunc     graph.WalkFunc
+	cli          client.Client
+	transCtx     *clusterTransformContext
+	statusWriter *intctrlutil.StatusWriter[*appsv1alpha1.Cluster]
+	writeStatus  func() error
 }
 
 var _ graph.TransformContext = &clusterTransformContext{}
@@ -169,15 +179,20 @@ func (c *clusterPlanBuilder) Build() (graph.Plan, error) {
 
 	// new a DAG and apply chain on it
 	dag := graph.NewDAG()
+	_, span := tracing.StartSpan(c.transCtx.Context, "cluster.Build")
 	err = c.transformers.ApplyTo(c.transCtx, dag)
+	tracing.EndSpan(span, err)
 	c.transCtx.Logger.V(1).Info(fmt.Sprintf("DAG: %s", dag))
 
 	// construct execution plan
+	c.statusWriter = intctrlutil.NewStatusWriter(c.cli, c.transCtx.OrigCluster)
 	plan := &clusterPlan{
-		dag:      dag,
-		walkFunc: c.defaultWalkFuncWithLogging,
-		cli:      c.cli,
-		transCtx: c.transCtx,
+		dag:          dag,
+		walkFunc:     c.defaultWalkFuncWithLogging,
+		cli:          c.cli,
+		transCtx:     c.transCtx,
+		statusWriter: c.statusWriter,
+		writeStatus:  c.writeClusterStatus,
 	}
 	return plan, err
 }
@@ -202,20 +217,24 @@ func (p *clusterPlan) Execute() error {
 		}
 		return getWeight(v1) <= getWeight(v2)
 	}
+	_, span := tracing.StartSpan(p.transCtx.Context, "cluster.Execute")
 	err := p.dag.WalkReverseTopoOrder(p.walkFunc, less)
+	tracing.EndSpan(span, err)
 	if err != nil {
-		if hErr := p.handlePlanExecutionError(err); hErr != nil {
-			return hErr
-		}
+		p.handlePlanExecutionError(err)
+	}
+	// all the cluster status changes are written here once, after the plan is executed
+	if wErr := p.writeStatus(); wErr != nil {
+		return wErr
 	}
 	return err
 }
 
-func (p *clusterPlan) handlePlanExecutionError(err error) error {
-	clusterCopy := p.transCtx.OrigCluster.DeepCopy()
+func (p *clusterPlan) handlePlanExecutionError(err error) {
 	condition := newFailedApplyResourcesCondition(err)
-	meta.SetStatusCondition(&clusterCopy.Status.Conditions, condition)
-	return p.cli.Status().Patch(p.transCtx.Context, clusterCopy, client.MergeFrom(p.transCtx.OrigCluster))
+	p.statusWriter.Add(func(cluster *appsv1alpha1.Cluster) {
+		meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	})
 }
 
 // Do the real works
@@ -308,6 +327,9 @@ func (c *clusterPlanBuilder) reconcileObject(node *model.ObjectVertex) error {
 }
 
 func (c *clusterPlanBuilder) reconcileCreateObject(ctx context.Context, node *model.ObjectVertex) error {
+	if job, ok := node.Obj.(*batchv1.Job); ok {
+		tracing.InjectJob(ctx, job)
+	}
 	err := c.cli.Create(ctx, node.Obj, clientOption(node))
 	if err != nil && !apierrors.IsAlreadyExists(err) {
 		return err
@@ -360,19 +382,86 @@ func (c *clusterPlanBuilder) reconcileDeleteObject(ctx context.Context, node *mo
 }
 
 func (c *clusterPlanBuilder) reconcileStatusObject(ctx context.Context, node *model.ObjectVertex) error {
-	patch := client.MergeFrom(node.OriObj)
-	if err := c.cli.Status().Patch(ctx, node.Obj, patch, clientOption(node)); err != nil {
+	newCluster, ok := node.Obj.(*appsv1alpha1.Cluster)
+	if !ok {
+		patch := client.MergeFrom(node.OriObj)
+		return c.cli.Status().Patch(ctx, node.Obj, patch, clientOption(node))
+	}
+	// the cluster status is written by writeClusterStatus after the plan is executed
+	oldCluster, _ := node.OriObj.(*appsv1alpha1.Cluster)
+	oldStatus, newStatus := oldCluster.Status.DeepCopy(), newCluster.Status.DeepCopy()
+	c.statusWriter.Add(func(cluster *appsv1alpha1.Cluster) {
+		applyClusterStatusChanges(&cluster.Status, oldStatus, newStatus)
+	})
+	c.statusVertex = node
+	return nil
+}
+
+// writeClusterStatus writes the cluster status changes accumulated in the reconciliation in a single patch.
+func (c *clusterPlanBuilder) writeClusterStatus() error {
+	if c.statusWriter.Pending() == 0 {
+		return nil
+	}
+	ctx, span := tracing.StartSpan(c.transCtx.Context, "cluster.WriteStatus")
+	_, err := c.statusWriter.Write(ctx)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		cluster := c.transCtx.OrigCluster
+		metrics.StatusPatchFailuresTotal.WithLabelValues("cluster", cluster.Namespace, cluster.Name, "").Inc()
 		return err
 	}
 	// handle condition and phase changing triggered events
-	if newCluster, ok := node.Obj.(*appsv1alpha1.Cluster); ok {
-		oldCluster, _ := node.OriObj.(*appsv1alpha1.Cluster)
+	if c.statusVertex != nil {
+		oldCluster, _ := c.statusVertex.OriObj.(*appsv1alpha1.Cluster)
+		newCluster, _ := c.statusVertex.Obj.(*appsv1alpha1.Cluster)
 		c.emitConditionUpdatingEvent(oldCluster.Status.Conditions, newCluster.Status.Conditions)
 		c.emitStatusUpdatingEvent(oldCluster.Status, newCluster.Status)
+		c.notifyStatusUpdating(oldCluster.Status, newCluster.Status)
 	}
 	return nil
 }
 
+// applyClusterStatusChanges applies the changes from oldStatus to newStatus on the latest status,
+// the fields not changed by the reconciliation are left as they are, since they may be updated by others.
+func applyClusterStatusChanges(status, oldStatus, newStatus *appsv1alpha1.ClusterStatus) {
+	if oldStatus.ObservedGeneration != newStatus.ObservedGeneration {
+		status.ObservedGeneration = newStatus.ObservedGeneration
+	}
+	if oldStatus.Phase != newStatus.Phase {
+		status.Phase = newStatus.Phase
+	}
+	if oldStatus.Message != newStatus.Message {
+		status.Message = newStatus.Message
+	}
+	if oldStatus.ClusterDefGeneration != newStatus.ClusterDefGeneration {
+		status.ClusterDefGeneration = newStatus.ClusterDefGeneration
+	}
+	for name, compStatus := range newStatus.Components {
+		if oldCompStatus, ok := oldStatus.Components[name]; ok && reflect.DeepEqual(oldCompStatus, compStatus) {
+			continue
+		}
+		if status.Components == nil {
+			status.Components = map[string]appsv1alpha1.ClusterComponentStatus{}
+		}
+		status.Components[name] = compStatus
+	}
+	for name := range oldStatus.Components {
+		if _, ok := newStatus.Components[name]; !ok {
+			delete(status.Components, name)
+		}
+	}
+	for _, cond := range newStatus.Conditions {
+		if oldCond := meta.FindStatusCondition(oldStatus.Conditions, cond.Type); oldCond == nil || !reflect.DeepEqual(*oldCond, cond) {
+			meta.SetStatusCondition(&status.Conditions, cond)
+		}
+	}
+	for _, cond := range oldStatus.Conditions {
+		if meta.FindStatusCondition(newStatus.Conditions, cond.Type) == nil {
+			meta.RemoveStatusCondition(&status.Conditions, cond.Type)
+		}
+	}
+}
+
 func (c *clusterPlanBuilder) reconcileNoopObject(ctx context.Context, node *model.ObjectVertex) error {
 	return nil
 }
@@ -394,6 +483,19 @@ func (c *clusterPlanBuilder) emitConditionUpdatingEvent(oldConditions, newCondit
 	}
 }
 
+// notifyStatusUpdating sends the notification when the cluster turns into Failed.
+func (c *clusterPlanBuilder) notifyStatusUpdating(oldStatus, newStatus appsv1alpha1.ClusterStatus) {
+	if newStatus.Phase != appsv1alpha1.FailedClusterPhase || oldStatus.Phase == newStatus.Phase {
+		return
+	}
+	cluster := c.transCtx.Cluster
+	message := fmt.Sprintf("the phase of cluster changes from %s to %s", oldStatus.Phase, newStatus.Phase)
+	if len(newStatus.Message) > 0 {
+		message = fmt.Sprintf("%s: %s", message, newStatus.Message)
+	}
+	notification.NotifyCluster(cluster, cluster, notification.EventClusterFailed, message)
+}
+
 func (c *clusterPlanBuilder) emitStatusUpdatingEvent(oldStatus, newStatus appsv1alpha1.ClusterStatus) {
 	cluster := c.transCtx.Cluster
 	newPhase := newStatus.Phase
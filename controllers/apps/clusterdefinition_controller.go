@@ -48,8 +48,6 @@ type ClusterDefinitionReconciler struct {
 	Recorder record.EventRecorder
 }
 
-var clusterDefUpdateHandlers = map[string]func(client client.Client, ctx context.Context, clusterDef *appsv1alpha1.ClusterDefinition) error{}
-
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
@@ -74,8 +72,11 @@ func (r *ClusterDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Re
 				"cannot be deleted because of existing referencing Cluster or ClusterVersion.")
 		}
 		if res, err := intctrlutil.ValidateReferenceCR(reqCtx, r.Client, dbClusterDef,
-			constant.ClusterDefLabelKey, recordEvent, &appsv1alpha1.ClusterList{},
-			&appsv1alpha1.ClusterVersionList{}); res != nil || err != nil {
+			constant.ClusterDefLabelKey, recordEvent, &appsv1alpha1.ClusterList{}); res != nil || err != nil {
+			return res, err
+		}
+		if res, err := intctrlutil.ValidateReferenceCRByIndex(reqCtx, r.Client, dbClusterDef, intctrlutil.ClusterDefRefField,
+			constant.ClusterDefLabelKey, recordEvent, &appsv1alpha1.ClusterVersionList{}); res != nil || err != nil {
 			return res, err
 		}
 		return nil, r.deleteExternalResources(reqCtx, dbClusterDef)
@@ -93,12 +94,6 @@ func (r *ClusterDefinitionReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return intctrlutil.RequeueAfter(time.Second, reqCtx.Log, err.Error())
 	}
 
-	for _, handler := range clusterDefUpdateHandlers {
-		if err := handler(r.Client, reqCtx.Ctx, dbClusterDef); err != nil {
-			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
-		}
-	}
-
 	statusPatch := client.MergeFrom(dbClusterDef.DeepCopy())
 	dbClusterDef.Status.ObservedGeneration = dbClusterDef.Generation
 	dbClusterDef.Status.Phase = appsv1alpha1.AvailablePhase
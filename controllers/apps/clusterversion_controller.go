@@ -28,13 +28,14 @@ import (
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	appsconfig "github.com/apecloud/kubeblocks/controllers/apps/configuration"
@@ -53,10 +54,6 @@ type ClusterVersionReconciler struct {
 	Recorder record.EventRecorder
 }
 
-func init() {
-	clusterDefUpdateHandlers["clusterVersion"] = clusterVersionUpdateHandler
-}
-
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
@@ -90,11 +87,6 @@ func (r *ClusterVersionReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return *res, err
 	}
 
-	if clusterVersion.Status.ObservedGeneration == clusterVersion.Generation &&
-		slices.Contains(clusterVersion.Status.GetTerminalPhases(), clusterVersion.Status.Phase) {
-		return intctrlutil.Reconciled()
-	}
-
 	clusterdefinition := &appsv1alpha1.ClusterDefinition{}
 	if err := r.Client.Get(reqCtx.Ctx, types.NamespacedName{
 		Name: clusterVersion.Spec.ClusterDefinitionRef,
@@ -111,6 +103,13 @@ func (r *ClusterVersionReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return intctrlutil.RequeueWithErrorAndRecordEvent(clusterVersion, r.Recorder, err, reqCtx.Log)
 	}
 
+	// the ClusterVersion is revalidated if the referenced ClusterDefinition has been updated
+	if clusterVersion.Status.ObservedGeneration == clusterVersion.Generation &&
+		clusterVersion.Status.ClusterDefGeneration == clusterdefinition.Generation &&
+		slices.Contains(clusterVersion.Status.GetTerminalPhases(), clusterVersion.Status.Phase) {
+		return intctrlutil.Reconciled()
+	}
+
 	patchStatus := func(phase appsv1alpha1.Phase, message string) error {
 		patch := client.MergeFrom(clusterVersion.DeepCopy())
 		clusterVersion.Status.Phase = phase
@@ -135,7 +134,7 @@ func (r *ClusterVersionReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return *res, err
 	}
 
-	if err = patchStatus(appsv1alpha1.AvailablePhase, ""); err != nil {
+	if err = patchStatus(appsv1alpha1.AvailablePhase, clusterVersion.DeprecationMessage()); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 	intctrlutil.RecordCreatedEvent(r.Recorder, clusterVersion)
@@ -146,9 +145,31 @@ func (r *ClusterVersionReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 func (r *ClusterVersionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&appsv1alpha1.ClusterVersion{}).
+		Watches(&appsv1alpha1.ClusterDefinition{}, handler.EnqueueRequestsFromMapFunc(r.clusterDefinitionEventHandler)).
 		Complete(r)
 }
 
+// clusterDefinitionEventHandler enqueues the ClusterVersions which refer to the changed ClusterDefinition.
+func (r *ClusterVersionReconciler) clusterDefinitionEventHandler(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterDef, ok := obj.(*appsv1alpha1.ClusterDefinition)
+	if !ok {
+		return []reconcile.Request{}
+	}
+	list := &appsv1alpha1.ClusterVersionList{}
+	if err := intctrlutil.ListByIndex(ctx, r.Client, list, intctrlutil.ClusterDefRefField, clusterDef.Name,
+		client.MatchingLabels{constant.ClusterDefLabelKey: clusterDef.Name}); err != nil {
+		return []reconcile.Request{}
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		if item.Status.ClusterDefGeneration == clusterDef.Generation {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name}})
+	}
+	return requests
+}
+
 func (r *ClusterVersionReconciler) patchClusterDefLabel(reqCtx intctrlutil.RequestCtx,
 	clusterVersion *appsv1alpha1.ClusterVersion) (*ctrl.Result, error) {
 	if v, ok := clusterVersion.ObjectMeta.Labels[constant.ClusterDefLabelKey]; !ok || v != clusterVersion.Spec.ClusterDefinitionRef {
@@ -185,6 +206,12 @@ func validateClusterVersion(clusterVersion *appsv1alpha1.ClusterVersion, cluster
 	} else if len(noContainersComponents) > 0 {
 		statusMsgs = append(statusMsgs, fmt.Sprintf("spec.componentSpecs[*].componentDefRef %v missing spec.componentSpecs[*].containers in ClusterDefinition.spec.componentDefs[*] and ClusterVersion.spec.componentVersions[*]", noContainersComponents))
 	}
+	if err := clusterVersion.VerifyImages(clusterDef); err != nil {
+		statusMsgs = append(statusMsgs, err.Error())
+	}
+	if err := clusterVersion.VerifyPorts(clusterDef); err != nil {
+		statusMsgs = append(statusMsgs, err.Error())
+	}
 	return strings.Join(statusMsgs, ";")
 }
 
@@ -196,34 +223,3 @@ func (r *ClusterVersionReconciler) deleteExternalResources(reqCtx intctrlutil.Re
 	// multiple times for same object.
 	return appsconfig.DeleteConfigMapFinalizer(r.Client, reqCtx, clusterVersion)
 }
-
-func clusterVersionUpdateHandler(cli client.Client, ctx context.Context, clusterDef *appsv1alpha1.ClusterDefinition) error {
-	labelSelector, err := labels.Parse(constant.ClusterDefLabelKey + "=" + clusterDef.GetName())
-	if err != nil {
-		return err
-	}
-	o := &client.ListOptions{LabelSelector: labelSelector}
-
-	list := &appsv1alpha1.ClusterVersionList{}
-	if err := cli.List(ctx, list, o); err != nil {
-		return err
-	}
-	for _, item := range list.Items {
-		if item.Status.ClusterDefGeneration != clusterDef.Generation {
-			patch := client.MergeFrom(item.DeepCopy())
-			if statusMsg := validateClusterVersion(&item, clusterDef); statusMsg != "" {
-				item.Status.Phase = appsv1alpha1.UnavailablePhase
-				item.Status.Message = statusMsg
-			} else {
-				item.Status.Phase = appsv1alpha1.AvailablePhase
-				item.Status.Message = ""
-				item.Status.ClusterDefGeneration = clusterDef.Generation
-			}
-			if err = cli.Status().Patch(ctx, &item, patch); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 )
 
@@ -67,7 +68,7 @@ var _ = Describe("test clusterVersion controller", func() {
 				})).Should(Succeed())
 
 			By("create a clusterDefinition obj")
-			testapps.NewClusterDefFactory(clusterDefName).
+			clusterDefObj := testapps.NewClusterDefFactory(clusterDefName).
 				AddComponentDef(testapps.StatefulMySQLComponent, statefulCompDefName).
 				Create(&testCtx).GetObject()
 
@@ -77,6 +78,63 @@ var _ = Describe("test clusterVersion controller", func() {
 				func(g Gomega, tmpCV *appsv1alpha1.ClusterVersion) {
 					g.Expect(tmpCV.Status.Phase).Should(Equal(appsv1alpha1.AvailablePhase))
 				})).Should(Succeed())
+
+			changeCompDefName := func(name string) int64 {
+				Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(clusterDefObj),
+					func(clusterDef *appsv1alpha1.ClusterDefinition) {
+						clusterDef.Spec.ComponentDefs[0].Name = name
+					})()).Should(Succeed())
+				Expect(testCtx.Cli.Get(testCtx.Ctx, client.ObjectKeyFromObject(clusterDefObj), clusterDefObj)).Should(Succeed())
+				return clusterDefObj.Generation
+			}
+
+			By("rename the componentDef of the clusterDefinition, expect the clusterVersion is revalidated as unavailable")
+			generation := changeCompDefName(statefulCompDefName + "-renamed")
+			Eventually(testapps.CheckObj(&testCtx,
+				client.ObjectKeyFromObject(clusterVersionObj),
+				func(g Gomega, tmpCV *appsv1alpha1.ClusterVersion) {
+					g.Expect(tmpCV.Status.ClusterDefGeneration).Should(Equal(generation))
+					g.Expect(tmpCV.Status.Phase).Should(Equal(appsv1alpha1.UnavailablePhase))
+					g.Expect(tmpCV.Status.Message).Should(ContainSubstring(statefulCompDefName))
+				})).Should(Succeed())
+
+			By("restore the componentDef of the clusterDefinition, expect the clusterVersion is revalidated as available")
+			generation = changeCompDefName(statefulCompDefName)
+			Eventually(testapps.CheckObj(&testCtx,
+				client.ObjectKeyFromObject(clusterVersionObj),
+				func(g Gomega, tmpCV *appsv1alpha1.ClusterVersion) {
+					g.Expect(tmpCV.Status.ClusterDefGeneration).Should(Equal(generation))
+					g.Expect(tmpCV.Status.Phase).Should(Equal(appsv1alpha1.AvailablePhase))
+				})).Should(Succeed())
+		})
+
+		It("should revalidate only the clusterVersions of the stale clusterDefinition generation", func() {
+			By("create a clusterDefinition and the clusterVersions")
+			clusterDefObj := testapps.NewClusterDefFactory(clusterDefName).
+				AddComponentDef(testapps.StatefulMySQLComponent, statefulCompDefName).
+				Create(&testCtx).GetObject()
+			clusterVersionObj := testapps.NewClusterVersionFactory(clusterVersionName, clusterDefName).
+				AddComponentVersion(statefulCompDefName).AddContainerShort("mysql", testapps.ApeCloudMySQLImage).
+				Create(&testCtx).GetObject()
+			testapps.NewClusterVersionFactory(clusterVersionName+"-other", clusterDefName+"-other").
+				AddComponentVersion(statefulCompDefName).AddContainerShort("mysql", testapps.ApeCloudMySQLImage).
+				Create(&testCtx)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(clusterVersionObj),
+				func(g Gomega, tmpCV *appsv1alpha1.ClusterVersion) {
+					g.Expect(tmpCV.Labels).Should(HaveKeyWithValue(constant.ClusterDefLabelKey, clusterDefName))
+					g.Expect(tmpCV.Status.Phase).Should(Equal(appsv1alpha1.AvailablePhase))
+				})).Should(Succeed())
+			Expect(testCtx.Cli.Get(testCtx.Ctx, client.ObjectKeyFromObject(clusterDefObj), clusterDefObj)).Should(Succeed())
+
+			r := &ClusterVersionReconciler{Client: k8sClient}
+			By("the clusterVersion validated against the current generation is not revalidated")
+			Expect(r.clusterDefinitionEventHandler(ctx, clusterDefObj)).Should(BeEmpty())
+
+			By("the clusterVersion validated against a stale generation is revalidated")
+			clusterDefObj.Generation++
+			requests := r.clusterDefinitionEventHandler(ctx, clusterDefObj)
+			Expect(requests).Should(HaveLen(1))
+			Expect(requests[0].Name).Should(Equal(clusterVersionName))
 		})
 	})
 
@@ -23,23 +23,30 @@ import (
 	"context"
 	"time"
 
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -67,6 +74,13 @@ type ComponentReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=services/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=core,resources=endpoints,verbs=get;list;watch;create;update;patch;delete
+
+// read-only access on nodes to validate the dedicated nodes and detect the NotReady nodes
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch;delete
+
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims/finalizers,verbs=update
@@ -76,8 +90,17 @@ type ComponentReconciler struct {
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers/finalizers,verbs=update
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies/finalizers,verbs=update
+
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
+
 // read + update access
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
 
@@ -92,6 +115,9 @@ type ComponentReconciler struct {
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings/status,verbs=get
 
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles/status,verbs=get
+
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings/status,verbs=get
 
@@ -101,6 +127,9 @@ type ComponentReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
 func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcileSpan(ctx, "component", req)
+	defer span.End()
+
 	reqCtx := intctrlutil.RequestCtx{
 		Ctx:      ctx,
 		Req:      req,
@@ -124,9 +153,26 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	}
 
-	plan, errBuild := planBuilder.
-		AddTransformer(
-			// handle component deletion first
+	planBuilder.AddTransformer(&componentChainSwitchTransformer{
+		// the external component has no workloads, it is reconciled by a dedicated transformer chain
+		external: graph.TransformerChain{
+			// handle component deletion
+			&componentDeletionTransformer{},
+			// handle finalizers and referenced definition labels
+			&componentMetaTransformer{},
+			// validate referenced componentDefinition objects, and build synthesized component
+			&componentLoadResourcesTransformer{Client: r.Client},
+			// do validation for the spec & definition consistency
+			&componentValidationTransformer{},
+			// handle the external component, which has no workloads
+			&componentExternalTransformer{},
+			// add our finalizer to all objects
+			&componentOwnershipTransformer{},
+		},
+		workload: graph.TransformerChain{
+			// execute the preTerminate lifecycle action before the component is deleted
+			&componentPreTerminateTransformer{Client: r.Client},
+			// handle component deletion
 			&componentDeletionTransformer{},
 			// handle finalizers and referenced definition labels
 			&componentMetaTransformer{},
@@ -158,13 +204,23 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentWorkloadTransformer{Client: r.Client},
 			// handle RBAC for component workloads
 			&componentRBACTransformer{},
+			// handle the autoscaler of the component
+			&componentAutoscalingTransformer{},
+			// handle the network policy of the component
+			&componentNetworkPolicyTransformer{},
+			// provision the dashboards and alert rules of the component
+			&componentMonitorTransformer{},
 			// add our finalizer to all objects
 			&componentOwnershipTransformer{},
+			// recover the pods from the NotReady nodes
+			&componentNodeFailureRecoveryTransformer{Client: r.Client},
 			// handle component postProvision lifecycle action
 			&componentPostProvisionTransformer{Client: r.Client},
 			// update component status
 			&componentStatusTransformer{Client: r.Client},
-		).Build()
+		},
+	})
+	plan, errBuild := planBuilder.Build()
 
 	// Execute stage
 	// errBuild not nil means build stage partial success or validation error
@@ -188,6 +244,7 @@ func (r *ComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&appsv1alpha1.Component{}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: viper.GetInt(constant.CfgKBReconcileWorkers),
+			RateLimiter:             intctrlutil.NewReconcileRateLimiter(),
 		}).
 		Watches(&workloads.ReplicatedStateMachine{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentResources)).
 		Owns(&corev1.Service{}).
@@ -197,12 +254,21 @@ func (r *ComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&dpv1alpha1.Restore{}).
 		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentResources)).
 		Owns(&batchv1.Job{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Watches(&appsv1alpha1.Configuration{}, handler.EnqueueRequestsFromMapFunc(r.configurationEventHandler)).
-		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentResources))
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentResources),
+			builder.WithPredicates(intctrlutil.NewPodChangedPredicate(constant.RoleLabelKey, constant.ReadyWithoutPrimaryKey,
+				constant.PrimaryAnnotationKey))).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.podToDependentComponents),
+			builder.WithPredicates(intctrlutil.PodReadyOrRoleChangedPredicate)).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.notReadyNodeToComponents),
+			builder.WithPredicates(intctrlutil.NodeReadyChangedPredicate))
 
 	if viper.GetBool(constant.EnableRBACManager) {
 		b.Owns(&rbacv1.ClusterRoleBinding{}).
 			Owns(&rbacv1.RoleBinding{}).
+			Owns(&rbacv1.Role{}).
 			Owns(&corev1.ServiceAccount{})
 	} else {
 		b.Watches(&rbacv1.ClusterRoleBinding{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentResources)).
@@ -210,7 +276,14 @@ func (r *ComponentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentResources))
 	}
 
-	return b.Complete(r)
+	c, err := b.Build(r)
+	if err != nil {
+		return err
+	}
+	// the monitor templates live out of the managed namespaces and the clusters, they are watched without the namespace
+	// and shard filters of the builder, and the components are filtered by monitorTemplateToComponents instead.
+	return c.Watch(source.Kind(mgr.GetCache(), &corev1.ConfigMap{}),
+		handler.EnqueueRequestsFromMapFunc(r.monitorTemplateToComponents))
 }
 
 func (r *ComponentReconciler) filterComponentResources(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -235,6 +308,148 @@ func (r *ComponentReconciler) filterComponentResources(ctx context.Context, obj
 	}
 }
 
+// notReadyNodeToComponents maps a NotReady node to the components which have pods on it,
+// so that the node failure recovery of the components starts counting without waiting for other events.
+func (r *ComponentReconciler) notReadyNodeToComponents(ctx context.Context, obj client.Object) []reconcile.Request {
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return nil
+	}
+	if _, notReady := nodeNotReadySince(node); !notReady {
+		return nil
+	}
+	podList := &corev1.PodList{}
+	if err := intctrlutil.ListByIndex(ctx, r.Client, podList, intctrlutil.PodNodeNameField, node.Name,
+		client.MatchingLabels{constant.AppManagedByLabelKey: constant.AppName}); err != nil {
+		return nil
+	}
+	keys := sets.New[types.NamespacedName]()
+	for i, pod := range podList.Items {
+		// the pods are still filtered by the node since the index falls back to the labels
+		if pod.Spec.NodeName != node.Name || !intctrlutil.InCurrentShard(&podList.Items[i]) {
+			continue
+		}
+		for _, req := range r.filterComponentResources(ctx, &podList.Items[i]) {
+			keys.Insert(req.NamespacedName)
+		}
+	}
+	requests := make([]reconcile.Request, 0, keys.Len())
+	for key := range keys {
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return requests
+}
+
+// podToDependentComponents enqueues the components of the same cluster which refer to the roles of the pods of the
+// component the pod belongs to through vars, so that the topology changes (e.g., switchover) are pushed to them in time.
+// It's only triggered by the readiness and role changes of the pods, the owner component is enqueued by filterComponentResources.
+func (r *ComponentReconciler) podToDependentComponents(ctx context.Context, obj client.Object) []reconcile.Request {
+	owners := r.filterComponentResources(ctx, obj)
+	if len(owners) == 0 {
+		return owners
+	}
+	var requests []reconcile.Request
+	comp := &appsv1alpha1.Component{}
+	if err := r.Client.Get(ctx, owners[0].NamespacedName, comp); err != nil || len(comp.Spec.CompDef) == 0 {
+		return requests
+	}
+	compList := &appsv1alpha1.ComponentList{}
+	if err := r.Client.List(ctx, compList, client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabels{constant.AppInstanceLabelKey: obj.GetLabels()[constant.AppInstanceLabelKey]}); err != nil {
+		return requests
+	}
+	for _, item := range compList.Items {
+		if item.Name == comp.Name || len(item.Spec.CompDef) == 0 {
+			continue
+		}
+		compDef := &appsv1alpha1.ComponentDefinition{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: item.Spec.CompDef}, compDef); err != nil {
+			continue
+		}
+		for _, v := range compDef.Spec.Vars {
+			if v.ValueFrom != nil && v.ValueFrom.ComponentVarRef != nil && v.ValueFrom.ComponentVarRef.CompDef == comp.Spec.CompDef {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: item.Namespace, Name: item.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// monitorTemplateToComponents maps a template ConfigMap of the Grafana dashboards or the Prometheus alert rules to
+// the components of this shard provisioned from it, so that the changes of the template are applied to them in time.
+func (r *ComponentReconciler) monitorTemplateToComponents(ctx context.Context, obj client.Object) []reconcile.Request {
+	refersTo := func(monitor *appsv1alpha1.MonitorConfig) bool {
+		if monitor == nil || monitor.Templates == nil {
+			return false
+		}
+		namespace := monitor.Templates.Namespace
+		if len(namespace) == 0 {
+			namespace = corev1.NamespaceDefault
+		}
+		return namespace == obj.GetNamespace() &&
+			(monitor.Templates.DashboardsTemplateRef == obj.GetName() || monitor.Templates.AlertRulesTemplateRef == obj.GetName())
+	}
+
+	keys := sets.New[types.NamespacedName]()
+	compDefList := &appsv1alpha1.ComponentDefinitionList{}
+	if err := r.Client.List(ctx, compDefList); err != nil {
+		return nil
+	}
+	for _, compDef := range compDefList.Items {
+		if !refersTo(compDef.Spec.Monitor) {
+			continue
+		}
+		compList := &appsv1alpha1.ComponentList{}
+		if err := r.Client.List(ctx, compList, client.MatchingLabels{constant.ComponentDefinitionLabelKey: compDef.Name}); err != nil {
+			return nil
+		}
+		for i, comp := range compList.Items {
+			if intctrlutil.InCurrentShard(&compList.Items[i]) {
+				keys.Insert(types.NamespacedName{Namespace: comp.Namespace, Name: comp.Name})
+			}
+		}
+	}
+
+	// the legacy components defined by the ClusterDefinition
+	clusterDefList := &appsv1alpha1.ClusterDefinitionList{}
+	if err := r.Client.List(ctx, clusterDefList); err != nil {
+		return nil
+	}
+	for _, clusterDef := range clusterDefList.Items {
+		compDefNames := sets.New[string]()
+		for _, compDef := range clusterDef.Spec.ComponentDefs {
+			if refersTo(compDef.Monitor) {
+				compDefNames.Insert(compDef.Name)
+			}
+		}
+		if compDefNames.Len() == 0 {
+			continue
+		}
+		clusterList := &appsv1alpha1.ClusterList{}
+		if err := r.Client.List(ctx, clusterList, client.MatchingLabels{constant.ClusterDefLabelKey: clusterDef.Name}); err != nil {
+			return nil
+		}
+		for i, cluster := range clusterList.Items {
+			if !intctrlutil.InCurrentShard(&clusterList.Items[i]) {
+				continue
+			}
+			for _, compSpec := range cluster.Spec.ComponentSpecs {
+				if compDefNames.Has(compSpec.ComponentDefRef) {
+					keys.Insert(types.NamespacedName{Namespace: cluster.Namespace,
+						Name: constant.GenerateClusterComponentName(cluster.Name, compSpec.Name)})
+				}
+			}
+		}
+	}
+
+	requests := make([]reconcile.Request, 0, keys.Len())
+	for key := range keys {
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return requests
+}
+
 func (r *ComponentReconciler) configurationEventHandler(_ context.Context, obj client.Object) []reconcile.Request {
 	cr, ok := obj.(*appsv1alpha1.Configuration)
 	if !ok {
@@ -142,6 +142,11 @@ func (d *baseDataClone) CloneData(realDataClone dataClone) ([]client.Object, err
 	}
 	// backup's ready, then start to check restore
 	for i := *d.stsObj.Spec.Replicas; i < d.component.Replicas; i++ {
+		if retained, err := d.isPVCRetained(i); err != nil {
+			return nil, err
+		} else if retained {
+			continue
+		}
 		restoreStatus, err := realDataClone.CheckRestoreStatus(i)
 		if err != nil {
 			return nil, err
@@ -175,6 +180,27 @@ func (d *baseDataClone) isPVCExists(pvcKey types.NamespacedName) (bool, error) {
 	return true, nil
 }
 
+// getComponentPVC gets the PVC of the component, it returns nil if the PVC does not exist or is being deleted,
+// and returns an error if the PVC exists but belongs to another cluster or component.
+func (d *baseDataClone) getComponentPVC(pvcKey types.NamespacedName) (*corev1.PersistentVolumeClaim, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := d.cli.Get(d.reqCtx.Ctx, pvcKey, pvc); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if !pvc.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	checkLabel := func(key, value string) bool {
+		v, ok := pvc.Labels[key]
+		return !ok || v == value
+	}
+	if !checkLabel(constant.AppInstanceLabelKey, d.cluster.Name) || !checkLabel(constant.KBAppComponentLabelKey, d.component.Name) {
+		return nil, fmt.Errorf("the PVC %s exists but does not belong to the component %s of the cluster %s",
+			pvcKey.Name, d.component.Name, d.cluster.Name)
+	}
+	return pvc, nil
+}
+
 func (d *baseDataClone) checkAllPVCsExist() (bool, error) {
 	for i := *d.stsObj.Spec.Replicas; i < d.component.Replicas; i++ {
 		for _, vct := range d.component.VolumeClaimTemplates {
@@ -183,11 +209,11 @@ func (d *baseDataClone) checkAllPVCsExist() (bool, error) {
 				Name:      fmt.Sprintf("%s-%s-%d", vct.Name, d.stsObj.Name, i),
 			}
 			// check pvc existence
-			pvcExists, err := d.isPVCExists(pvcKey)
+			pvc, err := d.getComponentPVC(pvcKey)
 			if err != nil {
 				return true, err
 			}
-			if !pvcExists {
+			if pvc == nil {
 				return false, nil
 			}
 		}
@@ -195,6 +221,25 @@ func (d *baseDataClone) checkAllPVCsExist() (bool, error) {
 	return true, nil
 }
 
+// isPVCRetained checks whether the PVC of the backup VCT for the replica is the one retained on the former
+// scale-in, whose data is reused instead of being cloned.
+func (d *baseDataClone) isPVCRetained(ordinal int32) (bool, error) {
+	vct := d.backupVCT()
+	if vct == nil {
+		return false, nil
+	}
+	pvcKey := types.NamespacedName{
+		Namespace: d.stsObj.Namespace,
+		Name:      fmt.Sprintf("%s-%s-%d", vct.Name, d.stsObj.Name, ordinal),
+	}
+	pvc, err := d.getComponentPVC(pvcKey)
+	if err != nil || pvc == nil {
+		return false, err
+	}
+	_, ok := pvc.Annotations[constant.RetainedPVCAnnotationKey]
+	return ok, nil
+}
+
 func (d *baseDataClone) allVCTs() []*corev1.PersistentVolumeClaimTemplate {
 	vcts := make([]*corev1.PersistentVolumeClaimTemplate, 0)
 	for i := range d.component.VolumeClaimTemplates {
@@ -300,6 +345,11 @@ func (d *backupDataClone) Succeed() (bool, error) {
 		return allPVCsExist, err
 	}
 	for i := *d.stsObj.Spec.Replicas; i < d.component.Replicas; i++ {
+		if retained, err := d.isPVCRetained(i); err != nil {
+			return false, err
+		} else if retained {
+			continue
+		}
 		restoreStatus, err := d.CheckRestoreStatus(i)
 		if err != nil {
 			return false, err
@@ -0,0 +1,206 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("retain PVCs on horizontal scaling test", func() {
+	const (
+		compName = "mysql"
+		vctName  = "data"
+	)
+
+	var (
+		clusterName string
+		cluster     *appsv1alpha1.Cluster
+		sts         *appsv1.StatefulSet
+		reqCtx      intctrlutil.RequestCtx
+	)
+
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, inNS, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		clusterName = "test-cluster-retain-" + testCtx.GetRandomStr()
+		cluster = &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: clusterName, UID: types.UID(clusterName)},
+		}
+		sts = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: pointer.Int32(3),
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+					{ObjectMeta: metav1.ObjectMeta{Name: vctName}},
+				},
+			},
+		}
+		reqCtx = intctrlutil.RequestCtx{Ctx: ctx, Log: logger, Recorder: clusterRecorder}
+	})
+
+	AfterEach(cleanEnv)
+
+	newSynthesizedComp := func(replicas int32, policy appsv1alpha1.VolumeClaimRetentionPolicyType) *component.SynthesizedComponent {
+		return &component.SynthesizedComponent{
+			Namespace:                  testCtx.DefaultNamespace,
+			ClusterName:                clusterName,
+			Name:                       compName,
+			Replicas:                   replicas,
+			VolumeClaimTemplates:       []corev1.PersistentVolumeClaimTemplate{{ObjectMeta: metav1.ObjectMeta{Name: vctName}}},
+			VolumeClaimRetentionPolicy: &appsv1alpha1.VolumeClaimRetentionPolicy{WhenScaled: policy},
+		}
+	}
+
+	pvcName := func(ordinal string) string {
+		return vctName + "-" + sts.Name + "-" + ordinal
+	}
+
+	newPVCFactory := func(ordinal, compName string) *testapps.MockPersistentVolumeClaimFactory {
+		return testapps.NewPersistentVolumeClaimFactory(testCtx.DefaultNamespace, pvcName(ordinal), clusterName, compName, vctName).
+			SetStorage("1Gi")
+	}
+
+	createRetainedPVC := func(ordinal string) {
+		newPVCFactory(ordinal, compName).
+			AddAnnotations(constant.RetainedPVCAnnotationKey, "true").
+			Create(&testCtx)
+	}
+
+	newDataCloneOf := func(synthesizedComp *component.SynthesizedComponent, hscalePolicy *appsv1alpha1.HorizontalScalePolicy) dataClone {
+		synthesizedComp.HorizontalScalePolicy = hscalePolicy
+		d, err := newDataClone(reqCtx, k8sClient, cluster, synthesizedComp, sts, sts,
+			types.NamespacedName{Namespace: testCtx.DefaultNamespace, Name: "backup"})
+		Expect(err).Should(Succeed())
+		return d
+	}
+
+	Context("scale-in", func() {
+		testScaleIn := func(policy appsv1alpha1.VolumeClaimRetentionPolicyType, check func(vertex *model.ObjectVertex)) {
+			newPVCFactory("2", compName).Create(&testCtx)
+			synthesizedComp := newSynthesizedComp(2, policy)
+			_, dag, _ := mockComponentTransformContext(synthesizedComp)
+			ops := &componentWorkloadOps{
+				cli:            k8sClient,
+				reqCtx:         reqCtx,
+				cluster:        cluster,
+				synthesizeComp: synthesizedComp,
+				dag:            dag,
+			}
+			Expect(ops.deletePVCs4ScaleIn(sts)).Should(Succeed())
+
+			var pvcVertices []*model.ObjectVertex
+			for _, v := range dag.Vertices() {
+				if vertex := v.(*model.ObjectVertex); vertex.Obj.GetName() == pvcName("2") {
+					pvcVertices = append(pvcVertices, vertex)
+				}
+			}
+			Expect(pvcVertices).Should(HaveLen(1))
+			check(pvcVertices[0])
+		}
+
+		It("retains the PVCs with the Retain policy", func() {
+			testScaleIn(appsv1alpha1.RetainVolumeClaimRetentionPolicyType, func(vertex *model.ObjectVertex) {
+				Expect(*vertex.Action).Should(Equal(model.UPDATE))
+				Expect(vertex.Obj.GetAnnotations()).Should(HaveKeyWithValue(constant.RetainedPVCAnnotationKey, "true"))
+			})
+		})
+
+		It("deletes the PVCs with the Delete policy", func() {
+			testScaleIn(appsv1alpha1.DeleteVolumeClaimRetentionPolicyType, func(vertex *model.ObjectVertex) {
+				Expect(*vertex.Action).Should(Equal(model.DELETE))
+			})
+		})
+	})
+
+	Context("scale-out", func() {
+		It("reuses the retained PVCs", func() {
+			createRetainedPVC("3")
+			createRetainedPVC("4")
+
+			succeed, err := newDataCloneOf(newSynthesizedComp(5, appsv1alpha1.RetainVolumeClaimRetentionPolicyType), nil).Succeed()
+			Expect(err).Should(Succeed())
+			Expect(succeed).Should(BeTrue())
+
+			By("the data clone is skipped for the retained PVCs")
+			succeed, err = newDataCloneOf(newSynthesizedComp(5, appsv1alpha1.RetainVolumeClaimRetentionPolicyType),
+				&appsv1alpha1.HorizontalScalePolicy{Type: appsv1alpha1.HScaleDataClonePolicyCloneVolume}).Succeed()
+			Expect(err).Should(Succeed())
+			Expect(succeed).Should(BeTrue())
+		})
+
+		It("clones the data for the PVCs not retained", func() {
+			createRetainedPVC("3")
+			newPVCFactory("4", compName).Create(&testCtx)
+
+			succeed, err := newDataCloneOf(newSynthesizedComp(5, appsv1alpha1.RetainVolumeClaimRetentionPolicyType),
+				&appsv1alpha1.HorizontalScalePolicy{Type: appsv1alpha1.HScaleDataClonePolicyCloneVolume}).Succeed()
+			Expect(err).Should(Succeed())
+			Expect(succeed).Should(BeFalse())
+		})
+
+		It("waits for the PVCs being deleted", func() {
+			pvc := newPVCFactory("3", compName).
+				AddFinalizers([]string{"kubernetes.io/pvc-protection"}).
+				Create(&testCtx).
+				GetObject()
+			testapps.DeleteObject(&testCtx, client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{})
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(pvc), func(g Gomega, pvc *corev1.PersistentVolumeClaim) {
+				g.Expect(pvc.DeletionTimestamp).ShouldNot(BeNil())
+			})).Should(Succeed())
+
+			succeed, err := newDataCloneOf(newSynthesizedComp(4, appsv1alpha1.RetainVolumeClaimRetentionPolicyType), nil).Succeed()
+			Expect(err).Should(Succeed())
+			Expect(succeed).Should(BeFalse())
+		})
+
+		It("refuses the PVCs of other components", func() {
+			newPVCFactory("3", "other").Create(&testCtx)
+
+			_, err := newDataCloneOf(newSynthesizedComp(4, appsv1alpha1.RetainVolumeClaimRetentionPolicyType), nil).Succeed()
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
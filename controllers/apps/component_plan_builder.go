@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -35,7 +36,9 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 )
 
 // componentTransformContext a graph.TransformContext implementation for Cluster reconciliation
@@ -117,7 +120,9 @@ func (c *componentPlanBuilder) AddParallelTransformer(transformer ...graph.Trans
 // Build runs all transformers to generate a plan
 func (c *componentPlanBuilder) Build() (graph.Plan, error) {
 	dag := graph.NewDAG()
+	_, span := tracing.StartSpan(c.transCtx.Context, "component.Build")
 	err := c.transformers.ApplyTo(c.transCtx, dag)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		c.transCtx.Logger.V(1).Info(fmt.Sprintf("build error: %s", err.Error()))
 	}
@@ -133,7 +138,9 @@ func (c *componentPlanBuilder) Build() (graph.Plan, error) {
 }
 
 func (p *componentPlan) Execute() error {
+	_, span := tracing.StartSpan(p.transCtx.Context, "component.Execute")
 	err := p.dag.WalkReverseTopoOrder(p.walkFunc, nil)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		p.transCtx.Logger.V(1).Info(fmt.Sprintf("execute error: %s", err.Error()))
 	}
@@ -199,6 +206,9 @@ func (c *componentPlanBuilder) defaultWalkFunc(v graph.Vertex) error {
 }
 
 func (c *componentPlanBuilder) reconcileCreateObject(ctx context.Context, vertex *model.ObjectVertex) error {
+	if job, ok := vertex.Obj.(*batchv1.Job); ok {
+		tracing.InjectJob(ctx, job)
+	}
 	err := c.cli.Create(ctx, vertex.Obj, clientOption(vertex))
 	if err != nil && !apierrors.IsAlreadyExists(err) {
 		return err
@@ -231,7 +241,11 @@ func (c *componentPlanBuilder) reconcileDeleteObject(ctx context.Context, vertex
 		}
 	}
 	if !model.IsObjectDeleting(vertex.Obj) {
-		err := c.cli.Delete(ctx, vertex.Obj, clientOption(vertex))
+		opts := []client.DeleteOption{clientOption(vertex)}
+		if opt, ok := vertex.ClientOpt.(client.DeleteOption); ok {
+			opts = append(opts, opt)
+		}
+		err := c.cli.Delete(ctx, vertex.Obj, opts...)
 		if err != nil && !apierrors.IsNotFound(err) {
 			return err
 		}
@@ -240,5 +254,12 @@ func (c *componentPlanBuilder) reconcileDeleteObject(ctx context.Context, vertex
 }
 
 func (c *componentPlanBuilder) reconcileStatusObject(ctx context.Context, vertex *model.ObjectVertex) error {
-	return c.cli.Status().Update(ctx, vertex.Obj, clientOption(vertex))
+	ctx, span := tracing.StartSpan(ctx, "component.WriteStatus")
+	err := c.cli.Status().Update(ctx, vertex.Obj, clientOption(vertex))
+	tracing.EndSpan(span, err)
+	if err != nil {
+		metrics.RecordStatusPatchFailure("component", vertex.Obj)
+		return err
+	}
+	return nil
 }
@@ -28,6 +28,7 @@ import (
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -248,6 +249,26 @@ func (r *ComponentDefinitionReconciler) validateServices(cli client.Client, rctx
 		}
 	}
 
+	containerPorts := make(map[string]bool)
+	for _, c := range cmpd.Spec.Runtime.Containers {
+		for _, p := range c.Ports {
+			containerPorts[p.Name] = true
+		}
+	}
+	for _, svc := range cmpd.Spec.Services {
+		for _, p := range svc.Spec.Ports {
+			if p.TargetPort.Type == intstr.String && len(p.TargetPort.StrVal) > 0 && !containerPorts[p.TargetPort.StrVal] {
+				return fmt.Errorf("the container port that service %s referenced is not defined: %s", svc.Name, p.TargetPort.StrVal)
+			}
+		}
+	}
+	if cmpd.Spec.Monitor != nil && cmpd.Spec.Monitor.Exporter != nil {
+		scrapePort := cmpd.Spec.Monitor.Exporter.ScrapePort
+		if scrapePort.Type == intstr.String && len(scrapePort.StrVal) > 0 && !containerPorts[scrapePort.StrVal] {
+			return fmt.Errorf("the container port that monitor exporter referenced is not defined: %s", scrapePort.StrVal)
+		}
+	}
+
 	roleNames := make(map[string]bool, 0)
 	for _, role := range cmpd.Spec.Roles {
 		roleNames[strings.ToLower(role.Name)] = true
@@ -345,7 +366,7 @@ func (r *ComponentDefinitionReconciler) validateLifecycleActionBuiltInHandlers(l
 		LifeCycleActionHandlers *appsv1alpha1.LifecycleActionHandler
 	}{
 		{lifecycleActions.PostProvision},
-		{lifecycleActions.PreTerminate},
+		{lifecycleActions.PreTerminate.GetLifecycleActionHandler()},
 		{lifecycleActions.MemberJoin},
 		{lifecycleActions.MemberLeave},
 		{lifecycleActions.Readonly},
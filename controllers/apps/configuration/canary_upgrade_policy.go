@@ -0,0 +1,168 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	podutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const defaultCanaryValidationWindowSeconds = 60
+
+// canaryUpgradePolicy restarts a single secondary replica with the new configuration first,
+// waits for it to keep healthy within the validation window and pass the optional probe command,
+// and then rolls the changes to the rest of the replicas.
+// The reconfiguring halts if the canary replica fails the validation.
+type canaryUpgradePolicy struct {
+}
+
+// canaryState records the progress of the canary replica in the pod annotation.
+type canaryState struct {
+	Version   string      `json:"version"`
+	StartTime metav1.Time `json:"startTime"`
+	Passed    bool        `json:"passed,omitempty"`
+}
+
+func init() {
+	RegisterPolicy(appsv1alpha1.CanaryPolicy, &canaryUpgradePolicy{})
+}
+
+func (c *canaryUpgradePolicy) Upgrade(params reconfigureParams) (ReturnedStatus, error) {
+	return performCanaryUpgrade(params, GetRSMRollingUpgradeFuncs())
+}
+
+func (c *canaryUpgradePolicy) GetPolicyName() string {
+	return string(appsv1alpha1.CanaryPolicy)
+}
+
+func performCanaryUpgrade(params reconfigureParams, funcs RollingUpgradeFuncs) (ReturnedStatus, error) {
+	pods, err := funcs.GetPodsFunc(params)
+	if err != nil {
+		return makeReturnedStatus(ESFailedAndRetry), err
+	}
+	if len(pods) == 0 || !canPerformUpgrade(pods, params) {
+		return makeReturnedStatus(ESRetry), nil
+	}
+
+	// the pods are sorted by the role priority, the last one is the first to be updated in rolling.
+	passed, status, err := validateCanaryPod(params, funcs, &pods[len(pods)-1])
+	if !passed {
+		return status, err
+	}
+	return performRollingUpgrade(params, funcs)
+}
+
+func validateCanaryPod(params reconfigureParams, funcs RollingUpgradeFuncs, pod *corev1.Pod) (bool, ReturnedStatus, error) {
+	var (
+		configKey     = params.getConfigKey()
+		configVersion = params.getTargetVersionHash()
+		canaryKey     = core.GenerateUniqKeyWithConfig(constant.CanaryReconfigureAnnotationKey, configKey)
+		options       = params.ConfigConstraint.CanaryOptions
+		window        = time.Duration(defaultCanaryValidationWindowSeconds) * time.Second
+		retryStatus   = makeReturnedStatus(ESRetry, withExpected(int32(params.getTargetReplicas())), withSucceed(0))
+	)
+	if options != nil {
+		window = time.Duration(options.ValidationWindowSeconds) * time.Second
+	}
+
+	state := canaryState{}
+	if value, ok := pod.Annotations[canaryKey]; ok {
+		if err := json.Unmarshal([]byte(value), &state); err != nil {
+			return false, makeReturnedStatus(ESFailedAndRetry), err
+		}
+	}
+	switch {
+	case state.Version != configVersion:
+		// start the canary
+		if err := funcs.RestartContainerFunc(pod, params.Ctx.Ctx, params.ContainerNames, params.ReconfigureClientFactory); err != nil {
+			return false, makeReturnedStatus(ESFailedAndRetry), err
+		}
+		params.Ctx.Recorder.Eventf(params.ConfigMap, corev1.EventTypeNormal, appsv1alpha1.ReasonReconfigureRunning,
+			"apply the configuration to the canary pod[%s], version: %s", pod.Name, configVersion)
+		return false, retryStatus, patchCanaryState(params, pod, canaryKey, configVersion,
+			canaryState{Version: configVersion, StartTime: metav1.Now()})
+	case state.Passed:
+		return true, retryStatus, nil
+	}
+
+	if !isReadySince(pod, state.StartTime.Time) {
+		if time.Since(state.StartTime.Time) > window {
+			return false, makeReturnedStatus(ESFailed),
+				core.MakeError("the canary pod[%s] is not ready in %s after the configuration is applied", pod.Name, window)
+		}
+		return false, retryStatus, nil
+	}
+	if !podutil.IsAvailable(pod, int32(window.Seconds())) {
+		return false, retryStatus, nil
+	}
+	if options != nil && len(options.ProbeCommand) > 0 {
+		if err := funcs.ExecProbeFunc(pod, params.Ctx.Ctx, canaryContainerName(params, pod), options.ProbeCommand); err != nil {
+			return false, makeReturnedStatus(ESFailed), err
+		}
+	}
+
+	state.Passed = true
+	params.Ctx.Recorder.Eventf(params.ConfigMap, corev1.EventTypeNormal, appsv1alpha1.ReasonReconfigureRunning,
+		"the canary pod[%s] passed the validation, roll the configuration to the rest pods", pod.Name)
+	return true, retryStatus, patchCanaryState(params, pod, canaryKey, configVersion, state)
+}
+
+func patchCanaryState(params reconfigureParams, pod *corev1.Pod, canaryKey, configVersion string, state canaryState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string, 1)
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string, 1)
+	}
+	pod.Labels[params.getConfigKey()] = configVersion
+	pod.Annotations[canaryKey] = string(b)
+	return params.Client.Patch(params.Ctx.Ctx, pod, patch)
+}
+
+// isReadySince checks whether the pod becomes ready after the given time.
+func isReadySince(pod *corev1.Pod, since time.Time) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue && !condition.LastTransitionTime.Time.Before(since)
+		}
+	}
+	return false
+}
+
+func canaryContainerName(params reconfigureParams, pod *corev1.Pod) string {
+	if len(params.ContainerNames) > 0 {
+		return params.ContainerNames[0]
+	}
+	return pod.Spec.Containers[0].Name
+}
@@ -0,0 +1,263 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	testutil "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+)
+
+var _ = Describe("Reconfigure CanaryPolicy", func() {
+
+	const configSpecName = "mysql-config"
+
+	var (
+		k8sMockClient *testutil.K8sClientMockHelper
+		canaryPolicy  = upgradePolicyMap[appsv1alpha1.CanaryPolicy]
+		configData    = map[string]string{"my.cnf": "max_connections=200"}
+		canaryKey     = core.GenerateUniqKeyWithConfig(constant.CanaryReconfigureAnnotationKey, configSpecName)
+	)
+
+	BeforeEach(func() {
+		k8sMockClient = testutil.NewK8sMockClient()
+	})
+
+	AfterEach(func() {
+		k8sMockClient.Finish()
+	})
+
+	newParams := func(options *appsv1alpha1.CanaryOptions) reconfigureParams {
+		params := newMockReconfigureParams("canaryPolicy", k8sMockClient.Client(),
+			withConfigSpec(configSpecName, configData),
+			withClusterComponent(3))
+		params.ConfigConstraint = &appsv1alpha1.ConfigConstraintSpec{CanaryOptions: options}
+		return params
+	}
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   defaultNamespace,
+				Name:        name,
+				Annotations: map[string]string{},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "mysql"}, {Name: "exporter"}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	readyPod := func(pod *corev1.Pod, transitionTime time.Time) *corev1.Pod {
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type:               corev1.PodReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(transitionTime),
+		}}
+		return pod
+	}
+
+	Context("canary reconfigure policy helpers", func() {
+		It("Should check the pod becomes ready after the canary starts", func() {
+			Expect(canaryPolicy.GetPolicyName()).Should(BeEquivalentTo("canary"))
+
+			now := time.Now()
+			Expect(isReadySince(newPod("mysql-mysql-0"), now)).Should(BeFalse())
+			notReady := readyPod(newPod("mysql-mysql-0"), now.Add(time.Second))
+			notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+			Expect(isReadySince(notReady, now)).Should(BeFalse())
+			// the pod has been ready before the canary starts, it is not restarted yet.
+			Expect(isReadySince(readyPod(newPod("mysql-mysql-0"), now.Add(-time.Minute)), now)).Should(BeFalse())
+			Expect(isReadySince(readyPod(newPod("mysql-mysql-0"), now.Add(time.Second)), now)).Should(BeTrue())
+		})
+
+		It("Should pick the container to probe", func() {
+			pod := newPod("mysql-mysql-0")
+			Expect(canaryContainerName(reconfigureParams{}, pod)).Should(Equal("mysql"))
+			Expect(canaryContainerName(reconfigureParams{ContainerNames: []string{"exporter"}}, pod)).Should(Equal("exporter"))
+		})
+	})
+
+	Context("canary reconfigure policy test", func() {
+		It("Should validate the canary pod", func() {
+			var (
+				now        = time.Now()
+				baseParams = newParams(nil)
+				version    = baseParams.getTargetVersionHash()
+			)
+			canaryPod := func(state *canaryState, readyTime *time.Time) *corev1.Pod {
+				pod := newPod("mysql-mysql-2")
+				if state != nil {
+					b, _ := json.Marshal(state)
+					pod.Annotations[canaryKey] = string(b)
+				}
+				if readyTime != nil {
+					readyPod(pod, *readyTime)
+				}
+				return pod
+			}
+			timeAgo := func(d time.Duration) *time.Time {
+				t := now.Add(-d)
+				return &t
+			}
+			started := func(d time.Duration) *canaryState {
+				return &canaryState{Version: version, StartTime: metav1.NewTime(now.Add(-d))}
+			}
+
+			tests := []struct {
+				name          string
+				pod           *corev1.Pod
+				probeCommand  []string
+				probeErr      error
+				expectPassed  bool
+				expectStatus  ExecStatus
+				expectErr     bool
+				expectRestart bool
+				expectProbe   bool
+				expectState   *canaryState
+			}{{
+				name:          "start the canary",
+				pod:           canaryPod(nil, timeAgo(time.Hour)),
+				expectStatus:  ESRetry,
+				expectRestart: true,
+				expectState:   &canaryState{Version: version},
+			}, {
+				name:          "restart the canary for the new version",
+				pod:           canaryPod(&canaryState{Version: "old", Passed: true}, timeAgo(time.Hour)),
+				expectStatus:  ESRetry,
+				expectRestart: true,
+				expectState:   &canaryState{Version: version},
+			}, {
+				name:         "wait for the canary to be ready",
+				pod:          canaryPod(started(10*time.Second), timeAgo(time.Hour)),
+				expectStatus: ESRetry,
+			}, {
+				name:         "the canary is not ready within the window",
+				pod:          canaryPod(started(10*time.Minute), timeAgo(time.Hour)),
+				expectStatus: ESFailed,
+				expectErr:    true,
+			}, {
+				name:         "wait for the canary to keep ready in the window",
+				pod:          canaryPod(started(time.Minute), timeAgo(10*time.Second)),
+				expectStatus: ESRetry,
+			}, {
+				name:         "the canary fails the probe",
+				pod:          canaryPod(started(10*time.Minute), timeAgo(5*time.Minute)),
+				probeCommand: []string{"check"},
+				probeErr:     core.MakeError("probe failed"),
+				expectStatus: ESFailed,
+				expectErr:    true,
+				expectProbe:  true,
+			}, {
+				name:         "the canary passes the validation",
+				pod:          canaryPod(started(10*time.Minute), timeAgo(5*time.Minute)),
+				probeCommand: []string{"check"},
+				expectPassed: true,
+				expectStatus: ESRetry,
+				expectProbe:  true,
+				expectState:  &canaryState{Version: version, Passed: true},
+			}, {
+				name:         "the canary has passed",
+				pod:          canaryPod(&canaryState{Version: version, Passed: true}, nil),
+				probeCommand: []string{"check"},
+				expectPassed: true,
+				expectStatus: ESRetry,
+			}}
+
+			// the canary state is patched to the pod only when the canary starts or passes
+			k8sMockClient.MockPatchMethod(testutil.WithSucceed(testutil.WithTimes(3)))
+			for _, tt := range tests {
+				By(tt.name)
+				var restarted, probed bool
+				funcs := RollingUpgradeFuncs{
+					RestartContainerFunc: func(pod *corev1.Pod, ctx context.Context, containerName []string, createConnFn createReconfigureClient) error {
+						restarted = true
+						return nil
+					},
+					ExecProbeFunc: func(pod *corev1.Pod, ctx context.Context, containerName string, command []string) error {
+						probed = true
+						Expect(containerName).Should(Equal("mysql"))
+						return tt.probeErr
+					},
+				}
+				params := newParams(&appsv1alpha1.CanaryOptions{ValidationWindowSeconds: 60, ProbeCommand: tt.probeCommand})
+				passed, status, err := validateCanaryPod(params, funcs, tt.pod)
+
+				Expect(passed).Should(Equal(tt.expectPassed))
+				Expect(status.Status).Should(Equal(tt.expectStatus))
+				if tt.expectErr {
+					Expect(err).ShouldNot(Succeed())
+				} else {
+					Expect(err).Should(Succeed())
+				}
+				Expect(restarted).Should(Equal(tt.expectRestart))
+				Expect(probed).Should(Equal(tt.expectProbe))
+				if tt.expectState == nil {
+					continue
+				}
+				state := canaryState{}
+				Expect(json.Unmarshal([]byte(tt.pod.Annotations[canaryKey]), &state)).Should(Succeed())
+				Expect(state.Version).Should(Equal(tt.expectState.Version))
+				Expect(state.Passed).Should(Equal(tt.expectState.Passed))
+				Expect(tt.pod.Labels[configSpecName]).Should(Equal(version))
+			}
+		})
+
+		It("Should halt the rolling before the canary passes", func() {
+			var pods []corev1.Pod
+			for _, name := range []string{"mysql-mysql-0", "mysql-mysql-1", "mysql-mysql-2"} {
+				pods = append(pods, *newPod(name))
+			}
+			var restarted []string
+			funcs := RollingUpgradeFuncs{
+				GetPodsFunc: func(params reconfigureParams) ([]corev1.Pod, error) {
+					return pods, nil
+				},
+				RestartContainerFunc: func(pod *corev1.Pod, ctx context.Context, containerName []string, createConnFn createReconfigureClient) error {
+					restarted = append(restarted, pod.Name)
+					return nil
+				},
+			}
+
+			// only the canary pod is restarted, the rest pods wait for the canary to pass the validation
+			k8sMockClient.MockPatchMethod(testutil.WithSucceed(testutil.WithTimes(1)))
+			params := newParams(nil)
+			for i := 0; i < 2; i++ {
+				status, err := performCanaryUpgrade(params, funcs)
+				Expect(err).Should(Succeed())
+				Expect(status.Status).Should(Equal(ESRetry))
+				Expect(status.SucceedCount).Should(BeEquivalentTo(0))
+			}
+			Expect(restarted).Should(Equal([]string{"mysql-mysql-2"}))
+		})
+	})
+})
@@ -181,8 +181,9 @@ func updateAppliedConfigs(cli client.Client, ctx intctrlutil.RequestCtx, config
 	}
 	config.ObjectMeta.Labels[constant.CMInsLastReconfigurePhaseKey] = newReconfigurePhase
 
-	// delete reconfigure-policy
+	// delete reconfigure-policy and restart-policy
 	delete(config.ObjectMeta.Annotations, constant.UpgradePolicyAnnotationKey)
+	delete(config.ObjectMeta.Annotations, constant.ReconfigureRestartPolicyAnnotationKey)
 	if err := cli.Patch(ctx.Ctx, config, patch); err != nil {
 		return false, err
 	}
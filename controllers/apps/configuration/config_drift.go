@@ -0,0 +1,150 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
+)
+
+const (
+	reasonConfigDrifted        = "ConfigDrifted"
+	reasonConfigDriftCorrected = "ConfigDriftCorrected"
+	reasonConfigConsistent     = "ConfigConsistent"
+)
+
+// reconcileConfigDrift detects the ConfigMaps which diverge from the rendered configuration, e.g. edited manually.
+// Depending on the drift policy of the config spec, the drifted ConfigMap is restored to the rendered configuration,
+// or the ConfigDrifted condition of the component is set.
+// The files mounted in the pods are not inspected, they are read-only projections of the ConfigMaps.
+func (r *ConfigurationReconciler) reconcileConfigDrift(taskCtx TaskContext) error {
+	var (
+		ctx           = taskCtx.reqCtx.Ctx
+		configuration = taskCtx.configuration
+		drifted       []string
+	)
+	for _, item := range configuration.Spec.ConfigItemDetails {
+		if item.ConfigSpec == nil {
+			continue
+		}
+		cm := &corev1.ConfigMap{}
+		cmKey := client.ObjectKey{
+			Namespace: configuration.Namespace,
+			Name:      core.GetComponentCfgName(configuration.Spec.ClusterRef, configuration.Spec.ComponentName, item.Name),
+		}
+		if err := r.Client.Get(ctx, cmKey, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		revision := cm.Annotations[constant.ConfigurationRevision]
+		if revision == "" {
+			continue
+		}
+		// the rendered configuration of the revision is kept as history.
+		rendered, err := configctrl.GetConfigHistory(ctx, r.Client, cm, revision)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		files := getDriftedFiles(cm.Data, rendered.Data)
+		if len(files) == 0 {
+			continue
+		}
+		if item.ConfigSpec.DriftPolicy != appsv1alpha1.CorrectConfigDriftPolicy {
+			drifted = append(drifted, fmt.Sprintf("%s[%s]", item.Name, strings.Join(files, ",")))
+			continue
+		}
+		patch := client.MergeFrom(cm.DeepCopy())
+		cm.Data = rendered.Data
+		if err = r.Client.Patch(ctx, cm, patch); err != nil {
+			return err
+		}
+		taskCtx.reqCtx.Recorder.Eventf(cm, corev1.EventTypeWarning, reasonConfigDriftCorrected,
+			"the drifted files %v are restored to the rendered configuration of revision %s", files, revision)
+	}
+	return r.updateConfigDriftedCondition(taskCtx, drifted)
+}
+
+func (r *ConfigurationReconciler) updateConfigDriftedCondition(taskCtx TaskContext, drifted []string) error {
+	configuration := taskCtx.configuration
+	comp := &appsv1alpha1.Component{}
+	compKey := client.ObjectKey{
+		Namespace: configuration.Namespace,
+		Name:      constant.GenerateClusterComponentName(configuration.Spec.ClusterRef, configuration.Spec.ComponentName),
+	}
+	if err := r.Client.Get(taskCtx.reqCtx.Ctx, compKey, comp); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	existing := meta.FindStatusCondition(comp.Status.Conditions, appsv1alpha1.ConditionTypeConfigDrifted)
+	condition := metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeConfigDrifted,
+		Status:  metav1.ConditionFalse,
+		Reason:  reasonConfigConsistent,
+		Message: "the configurations are consistent with the rendered configurations",
+	}
+	if len(drifted) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = reasonConfigDrifted
+		condition.Message = fmt.Sprintf("the configurations diverge from the rendered configurations: %s", strings.Join(drifted, "; "))
+	}
+	switch {
+	case existing == nil && len(drifted) == 0:
+		return nil
+	case existing != nil && existing.Status == condition.Status && existing.Message == condition.Message:
+		return nil
+	}
+	patch := client.MergeFrom(comp.DeepCopy())
+	meta.SetStatusCondition(&comp.Status.Conditions, condition)
+	return r.Client.Status().Patch(taskCtx.reqCtx.Ctx, comp, patch)
+}
+
+// getDriftedFiles returns the files which differ from the rendered configuration.
+func getDriftedFiles(current, rendered map[string]string) []string {
+	var files []string
+	for key, content := range current {
+		if renderedContent, ok := rendered[key]; !ok || renderedContent != content {
+			files = append(files, key)
+		}
+	}
+	for key := range rendered {
+		if _, ok := current[key]; !ok {
+			files = append(files, key)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
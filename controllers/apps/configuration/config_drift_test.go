@@ -0,0 +1,48 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDriftedFiles(t *testing.T) {
+	rendered := map[string]string{
+		"my.cnf":   "[mysqld]\nmax_connections=100\n",
+		"setup.sh": "echo setup",
+	}
+
+	assert.Empty(t, getDriftedFiles(map[string]string{
+		"my.cnf":   "[mysqld]\nmax_connections=100\n",
+		"setup.sh": "echo setup",
+	}, rendered))
+
+	assert.Equal(t, []string{"my.cnf"}, getDriftedFiles(map[string]string{
+		"my.cnf":   "[mysqld]\nmax_connections=200\n",
+		"setup.sh": "echo setup",
+	}, rendered))
+
+	assert.Equal(t, []string{"extra.cnf", "setup.sh"}, getDriftedFiles(map[string]string{
+		"my.cnf":    "[mysqld]\nmax_connections=100\n",
+		"extra.cnf": "[mysqld]\nport=3306\n",
+	}, rendered))
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// +kubebuilder:webhook:path=/validate--v1-configmap,mutating=false,failurePolicy=ignore,sideEffects=None,groups="",resources=configmaps,verbs=update,versions=v1,name=vconfigmap.kb.io,admissionReviewVersions=v1
+
+// ConfigMapValidator validates the configuration ConfigMaps edited by users against the ConfigConstraint,
+// so that the invalid parameters are rejected before they are synced to the pods.
+type ConfigMapValidator struct {
+	client.Client
+}
+
+var _ admission.CustomValidator = &ConfigMapValidator{}
+
+func SetupConfigMapWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&corev1.ConfigMap{}).
+		WithValidator(&ConfigMapValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+func (v *ConfigMapValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ConfigMapValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldCM, newCM := oldObj.(*corev1.ConfigMap), newObj.(*corev1.ConfigMap)
+	ccName := newCM.Labels[constant.CMConfigurationConstraintsNameLabelKey]
+	if ccName == "" || newCM.Labels[constant.CMConfigurationTypeLabelKey] != constant.ConfigInstanceType {
+		return nil, nil
+	}
+	cc := &appsv1alpha1.ConfigConstraint{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: ccName}, cc); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	var keys []string
+	if cmKeys := newCM.Labels[constant.CMConfigurationCMKeysLabelKey]; cmKeys != "" {
+		keys = strings.Split(cmKeys, ",")
+	}
+	if err := validateConfigMapParameters(&cc.Spec, oldCM.Data, newCM.Data, keys); err != nil {
+		return nil, apierrors.NewForbidden(corev1.Resource("configmaps"), newCM.Name, err)
+	}
+	return nil, nil
+}
+
+func (v *ConfigMapValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateConfigMapParameters checks the updated configuration files against the types, ranges and enums
+// defined in the schema of the ConfigConstraint, and checks that the immutable parameters are not updated.
+func validateConfigMapParameters(cc *appsv1alpha1.ConfigConstraintSpec, oldData, newData map[string]string, keys []string) error {
+	if cc.FormatterConfig == nil {
+		return nil
+	}
+	updatedData := make(map[string]string)
+	for key, content := range newData {
+		if oldContent, ok := oldData[key]; !ok || oldContent != content {
+			updatedData[key] = content
+		}
+	}
+	if len(updatedData) == 0 {
+		return nil
+	}
+	if err := validate.NewConfigValidator(cc, validate.WithKeySelector(keys)).Validate(updatedData); err != nil {
+		return err
+	}
+	configPatch, _, err := core.CreateConfigPatch(oldData, newData, cc.FormatterConfig.Format, keys, false)
+	if err != nil {
+		return err
+	}
+	return core.ValidateImmutableParameters(cc, configPatch)
+}
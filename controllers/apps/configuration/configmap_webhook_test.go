@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("ConfigMap webhook test", func() {
+	const cueSchema = `
+#Parameter: {
+	max_connections?: int & >=10 & <=1000
+	sql_mode?: string & "STRICT" | "TRADITIONAL"
+	lower_case_table_names?: int
+	...
+}
+configuration: #Parameter & {
+}
+`
+	var cc *appsv1alpha1.ConfigConstraintSpec
+
+	BeforeEach(func() {
+		cc = &appsv1alpha1.ConfigConstraintSpec{
+			ConfigurationSchema: &appsv1alpha1.CustomParametersValidation{CUE: cueSchema},
+			FormatterConfig:     &appsv1alpha1.FormatterConfig{Format: appsv1alpha1.Properties},
+			ImmutableParameters: []string{"lower_case_table_names"},
+		}
+	})
+
+	Context("validate the parameters of the configmap", func() {
+		oldData := map[string]string{
+			"my.cnf": "max_connections=100\nsql_mode=STRICT\nlower_case_table_names=0\n",
+		}
+
+		It("accepts the valid parameters", func() {
+			newData := map[string]string{
+				"my.cnf": "max_connections=200\nsql_mode=TRADITIONAL\nlower_case_table_names=0\n",
+			}
+			Expect(validateConfigMapParameters(cc, oldData, newData, []string{"my.cnf"})).Should(Succeed())
+		})
+
+		It("rejects the value out of range or not in the enums", func() {
+			newData := map[string]string{
+				"my.cnf": "max_connections=2000\nsql_mode=STRICT\nlower_case_table_names=0\n",
+			}
+			Expect(validateConfigMapParameters(cc, oldData, newData, []string{"my.cnf"})).ShouldNot(Succeed())
+			newData = map[string]string{
+				"my.cnf": "max_connections=100\nsql_mode=UNKNOWN\nlower_case_table_names=0\n",
+			}
+			Expect(validateConfigMapParameters(cc, oldData, newData, []string{"my.cnf"})).ShouldNot(Succeed())
+		})
+
+		It("rejects the update of the immutable parameters", func() {
+			newData := map[string]string{
+				"my.cnf": "max_connections=100\nsql_mode=STRICT\nlower_case_table_names=1\n",
+			}
+			Expect(validateConfigMapParameters(cc, oldData, newData, []string{"my.cnf"})).ShouldNot(Succeed())
+		})
+
+		It("ignores the files which are not selected", func() {
+			newData := map[string]string{
+				"my.cnf":   oldData["my.cnf"],
+				"extra.sh": "echo hello",
+			}
+			Expect(validateConfigMapParameters(cc, oldData, newData, []string{"my.cnf"})).Should(Succeed())
+		})
+	})
+})
@@ -22,7 +22,6 @@ package configuration
 import (
 	"context"
 	"fmt"
-	"math"
 	"strconv"
 	"time"
 
@@ -32,7 +31,6 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -40,7 +38,6 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
-	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 // ConfigurationReconciler reconciles a Configuration object
@@ -178,6 +175,9 @@ func (r *ConfigurationReconciler) runTasks(taskCtx TaskContext, tasks []Task) (e
 			continue
 		}
 	}
+	if err := r.reconcileConfigDrift(taskCtx); err != nil {
+		errs = append(errs, err)
+	}
 
 	configuration.Status.Message = ""
 	if len(errs) > 0 {
@@ -196,9 +196,7 @@ func (r *ConfigurationReconciler) runTasks(taskCtx TaskContext, tasks []Task) (e
 func (r *ConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&appsv1alpha1.Configuration{}).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: int(math.Ceil(viper.GetFloat64(constant.CfgKBReconcileWorkers) / 2)),
-		}).
+		WithOptions(intctrlutil.NewControllerOptions(constant.CfgKeyConfigurationReconcileWorkers, constant.CfgKBReconcileWorkers, 0.5)).
 		Owns(&corev1.ConfigMap{}).
 		Complete(r)
 }
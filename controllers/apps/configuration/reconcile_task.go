@@ -56,6 +56,11 @@ func NewTask(item appsv1alpha1.ConfigurationItemDetail, status *appsv1alpha1.Con
 			if configSpec == nil {
 				return core.MakeError("not found config spec: %s", item.Name)
 			}
+			// the template rendered as a Secret is rendered along with the component, and not reconfigured.
+			if configSpec.AsSecret {
+				status.Phase = appsv1alpha1.CFinishedPhase
+				return nil
+			}
 			if err := fetcher.ConfigMap(item.Name).Complete(); err != nil {
 				return err
 			}
@@ -94,8 +99,10 @@ func syncImpl(fetcher *Task,
 		PrepareForTemplate().
 		RerenderTemplate().
 		ApplyParameters().
+		DiffParameters().
 		UpdateConfigVersion(revision).
 		Sync().
+		SyncHistory(revision).
 		Complete()
 
 	if err != nil {
@@ -23,7 +23,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -31,7 +30,6 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -41,7 +39,6 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
-	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 // ReconfigureReconciler reconciles a ReconfigureRequest object
@@ -134,9 +131,7 @@ func (r *ReconfigureReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 func (r *ReconfigureReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&corev1.ConfigMap{}).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: int(math.Ceil(viper.GetFloat64(constant.CfgKBReconcileWorkers) / 4)),
-		}).
+		WithOptions(intctrlutil.NewControllerOptions(constant.CfgKeyReconfigureReconcileWorkers, constant.CfgKBReconcileWorkers, 0.25)).
 		WithEventFilter(predicate.NewPredicateFuncs(checkConfigurationObject)).
 		Complete(r)
 }
@@ -230,6 +225,16 @@ func (r *ReconfigureReconciler) performUpgrade(params reconfigureParams) (ctrl.R
 	if err != nil {
 		return intctrlutil.RequeueWithErrorAndRecordEvent(params.ConfigMap, r.Recorder, err, params.Ctx.Log)
 	}
+	restartPolicy, err := getRestartPolicy(params.ConfigMap)
+	if err != nil {
+		return intctrlutil.RequeueWithErrorAndRecordEvent(params.ConfigMap, r.Recorder, err, params.Ctx.Log)
+	}
+	policy = applyRestartStrategy(policy, restartPolicy)
+	if restartPolicy != nil && isRestartPolicy(policy.GetPolicyName()) {
+		if result, deferred, err := r.deferRestart(params, policy, restartPolicy); deferred {
+			return result, err
+		}
+	}
 
 	returnedStatus, err := policy.Upgrade(params)
 	if err != nil {
@@ -281,6 +286,40 @@ func (r *ReconfigureReconciler) performUpgrade(params reconfigureParams) (ctrl.R
 	}
 }
 
+// deferRestart defers the restart of the pods according to the timing of the restart policy.
+func (r *ReconfigureReconciler) deferRestart(params reconfigureParams, policy reconfigurePolicy, restartPolicy *appsv1alpha1.ReconfigureRestartPolicy) (ctrl.Result, bool, error) {
+	switch restartPolicy.Timing {
+	case appsv1alpha1.NextRestartTiming:
+		params.Ctx.Recorder.Eventf(params.ConfigMap,
+			corev1.EventTypeNormal,
+			appsv1alpha1.ReasonReconfigureSucceed,
+			"the configuration is updated without restarting, it takes effect on the next restart of the pods, request[%s]",
+			getOpsRequestID(params.ConfigMap))
+		result := reconciled(makeReturnedStatus(ESNone), policy.GetPolicyName(), appsv1alpha1.CFinishedPhase)
+		result.Message = "the restart is deferred to the next restart of the pods"
+		res, err := r.updateConfigCMStatus(params.Ctx, params.ConfigMap, policy.GetPolicyName(), &result)
+		return res, true, err
+	case appsv1alpha1.MaintenanceWindowRestartTiming:
+		inWindow, wait, err := inMaintenanceWindow(restartPolicy.MaintenanceWindow, time.Now())
+		if err != nil {
+			res, err := intctrlutil.RequeueWithErrorAndRecordEvent(params.ConfigMap, r.Recorder, err, params.Ctx.Log)
+			return res, true, err
+		}
+		if inWindow {
+			return ctrl.Result{}, false, nil
+		}
+		message := fmt.Sprintf("the restart is deferred to the maintenance window, which starts in %s", wait.Round(time.Second))
+		params.Ctx.Recorder.Event(params.ConfigMap, corev1.EventTypeNormal, appsv1alpha1.ReasonReconfigureRunning, message)
+		if res, err := updateConfigPhase(r.Client, params.Ctx, params.ConfigMap, appsv1alpha1.CPendingPhase, message); err != nil {
+			return res, true, err
+		}
+		res, err := intctrlutil.RequeueAfter(wait, params.Ctx.Log, message)
+		return res, true, err
+	default:
+		return ctrl.Result{}, false, nil
+	}
+}
+
 func getOpsRequestID(cm *corev1.ConfigMap) string {
 	if len(cm.Annotations) != 0 {
 		return cm.Annotations[constant.LastAppliedOpsCRAnnotationKey]
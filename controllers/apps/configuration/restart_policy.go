@@ -0,0 +1,102 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"encoding/json"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// getRestartPolicy gets the restart policy specified by the reconfigure request.
+func getRestartPolicy(cm *corev1.ConfigMap) (*appsv1alpha1.ReconfigureRestartPolicy, error) {
+	value, ok := cm.GetAnnotations()[constant.ReconfigureRestartPolicyAnnotationKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	restartPolicy := &appsv1alpha1.ReconfigureRestartPolicy{}
+	if err := json.Unmarshal([]byte(value), restartPolicy); err != nil {
+		return nil, core.WrapError(err, "failed to parse the restart policy: %s", value)
+	}
+	return restartPolicy, nil
+}
+
+func isRestartPolicy(policy string) bool {
+	switch appsv1alpha1.UpgradePolicy(policy) {
+	case appsv1alpha1.NormalPolicy,
+		appsv1alpha1.RestartPolicy,
+		appsv1alpha1.RollingPolicy,
+		appsv1alpha1.CanaryPolicy,
+		appsv1alpha1.DynamicReloadAndRestartPolicy:
+		return true
+	default:
+		return false
+	}
+}
+
+// applyRestartStrategy replaces the default restart policy with the policy of the restart strategy,
+// the policy specified explicitly or decided by the updated parameters is kept.
+func applyRestartStrategy(policy reconfigurePolicy, restartPolicy *appsv1alpha1.ReconfigureRestartPolicy) reconfigurePolicy {
+	if restartPolicy == nil || policy.GetPolicyName() != string(appsv1alpha1.NormalPolicy) {
+		return policy
+	}
+	switch restartPolicy.Strategy {
+	case appsv1alpha1.RollingRestartStrategy:
+		return upgradePolicyMap[appsv1alpha1.RollingPolicy]
+	case appsv1alpha1.SimultaneousRestartStrategy:
+		return upgradePolicyMap[appsv1alpha1.RestartPolicy]
+	default:
+		return policy
+	}
+}
+
+// inMaintenanceWindow checks whether the time is within the daily maintenance window,
+// and returns the duration until the next window starts if not.
+func inMaintenanceWindow(window *appsv1alpha1.MaintenanceWindow, now time.Time) (bool, time.Duration, error) {
+	if window == nil {
+		return false, 0, core.MakeError("the maintenance window is not specified")
+	}
+	startTime, err := time.Parse("15:04", window.StartTime)
+	if err != nil {
+		return false, 0, core.WrapError(err, "invalid start time of the maintenance window: %s", window.StartTime)
+	}
+	var (
+		day      = 24 * time.Hour
+		duration = time.Duration(window.DurationMinutes) * time.Minute
+	)
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), startTime.Hour(), startTime.Minute(), 0, 0, time.UTC)
+	if now.Before(start) {
+		// the window started yesterday may last until today.
+		if now.Before(start.Add(-day).Add(duration)) {
+			return true, 0, nil
+		}
+		return false, start.Sub(now), nil
+	}
+	if now.Before(start.Add(duration)) {
+		return true, 0, nil
+	}
+	return false, start.Add(day).Sub(now), nil
+}
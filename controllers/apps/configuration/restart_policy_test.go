@@ -0,0 +1,109 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 2, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		window   appsv1alpha1.MaintenanceWindow
+		now      time.Time
+		inWindow bool
+		wait     time.Duration
+	}{{
+		name:   "before the window",
+		window: appsv1alpha1.MaintenanceWindow{StartTime: "02:00", DurationMinutes: 60},
+		now:    at(1, 30),
+		wait:   30 * time.Minute,
+	}, {
+		name:     "within the window",
+		window:   appsv1alpha1.MaintenanceWindow{StartTime: "02:00", DurationMinutes: 60},
+		now:      at(2, 30),
+		inWindow: true,
+	}, {
+		name:   "after the window",
+		window: appsv1alpha1.MaintenanceWindow{StartTime: "02:00", DurationMinutes: 60},
+		now:    at(3, 0),
+		wait:   23 * time.Hour,
+	}, {
+		name:     "within the window started yesterday",
+		window:   appsv1alpha1.MaintenanceWindow{StartTime: "23:00", DurationMinutes: 120},
+		now:      at(0, 30),
+		inWindow: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inWindow, wait, err := inMaintenanceWindow(&tt.window, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.inWindow, inWindow)
+			assert.Equal(t, tt.wait, wait)
+		})
+	}
+
+	_, _, err := inMaintenanceWindow(&appsv1alpha1.MaintenanceWindow{StartTime: "2am", DurationMinutes: 60}, at(0, 0))
+	assert.Error(t, err)
+}
+
+func TestApplyRestartStrategy(t *testing.T) {
+	simple := upgradePolicyMap[appsv1alpha1.NormalPolicy]
+	autoReload := upgradePolicyMap[appsv1alpha1.AsyncDynamicReloadPolicy]
+
+	assert.Equal(t, simple, applyRestartStrategy(simple, nil))
+	assert.Equal(t, simple, applyRestartStrategy(simple, &appsv1alpha1.ReconfigureRestartPolicy{}))
+	assert.Equal(t, upgradePolicyMap[appsv1alpha1.RollingPolicy], applyRestartStrategy(simple,
+		&appsv1alpha1.ReconfigureRestartPolicy{Strategy: appsv1alpha1.RollingRestartStrategy}))
+	assert.Equal(t, upgradePolicyMap[appsv1alpha1.RestartPolicy], applyRestartStrategy(simple,
+		&appsv1alpha1.ReconfigureRestartPolicy{Strategy: appsv1alpha1.SimultaneousRestartStrategy}))
+	// the dynamic reloading is not affected by the restart strategy.
+	assert.Equal(t, autoReload, applyRestartStrategy(autoReload,
+		&appsv1alpha1.ReconfigureRestartPolicy{Strategy: appsv1alpha1.RollingRestartStrategy}))
+}
+
+func TestGetRestartPolicy(t *testing.T) {
+	cm := &corev1.ConfigMap{}
+	restartPolicy, err := getRestartPolicy(cm)
+	require.NoError(t, err)
+	assert.Nil(t, restartPolicy)
+
+	cm.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{
+		constant.ReconfigureRestartPolicyAnnotationKey: `{"strategy":"Rolling","timing":"NextRestart"}`,
+	}}
+	restartPolicy, err = getRestartPolicy(cm)
+	require.NoError(t, err)
+	assert.Equal(t, &appsv1alpha1.ReconfigureRestartPolicy{
+		Strategy: appsv1alpha1.RollingRestartStrategy,
+		Timing:   appsv1alpha1.NextRestartTiming,
+	}, restartPolicy)
+}
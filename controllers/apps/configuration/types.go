@@ -36,6 +36,7 @@ type RestartComponent func(client client.Client, ctx intctrlutil.RequestCtx, key
 
 type RestartContainerFunc func(pod *corev1.Pod, ctx context.Context, containerName []string, createConnFn createReconfigureClient) error
 type OnlineUpdatePodFunc func(pod *corev1.Pod, ctx context.Context, createClient createReconfigureClient, configSpec string, updatedParams map[string]string) error
+type ExecProbeFunc func(pod *corev1.Pod, ctx context.Context, containerName string, command []string) error
 
 // Node: Distinguish between implementation and interface.
 // RollingUpgradeFuncs defines the interface, rsm is an implementation of Stateful, Replication and Consensus, not the only solution.
@@ -45,6 +46,7 @@ type RollingUpgradeFuncs struct {
 	RestartContainerFunc RestartContainerFunc
 	OnlineUpdatePodFunc  OnlineUpdatePodFunc
 	RestartComponent     RestartComponent
+	ExecProbeFunc        ExecProbeFunc
 }
 
 func GetRSMRollingUpgradeFuncs() RollingUpgradeFuncs {
@@ -53,5 +55,6 @@ func GetRSMRollingUpgradeFuncs() RollingUpgradeFuncs {
 		RestartContainerFunc: commonStopContainerWithPod,
 		OnlineUpdatePodFunc:  commonOnlineUpdateWithPod,
 		RestartComponent:     restartComponent,
+		ExecProbeFunc:        execProbeWithPod,
 	}
 }
@@ -0,0 +1,346 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+// DatabaseUserReconciler reconciles a DatabaseUser object
+type DatabaseUserReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databaseusers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databaseusers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=databaseusers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentdefinitions,verbs=get;list;watch
+
+func (r *DatabaseUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("databaseUser", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	dbUser := &appsv1alpha1.DatabaseUser{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, dbUser); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	res, err := intctrlutil.HandleCRDeletion(reqCtx, r, dbUser, databaseUserFinalizerName, func() (*ctrl.Result, error) {
+		return r.deleteUser(reqCtx, dbUser)
+	})
+	if res != nil {
+		return *res, err
+	}
+
+	if dbUser.Status.ObservedGeneration == dbUser.Generation &&
+		dbUser.Status.Phase == appsv1alpha1.AvailablePhase {
+		return intctrlutil.Reconciled()
+	}
+
+	cluster, err := r.getCluster(reqCtx, dbUser)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if cluster == nil || cluster.Spec.GetComponentByName(dbUser.Spec.ComponentName) == nil {
+		if err = r.updateStatusUnavailable(reqCtx, dbUser,
+			fmt.Errorf("component %s of cluster %s not found", dbUser.Spec.ComponentName, dbUser.Spec.ClusterName)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(time.Second*10, reqCtx.Log, "")
+	}
+	if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+		return intctrlutil.RequeueAfter(time.Second*10, reqCtx.Log, "wait for the cluster to be running")
+	}
+
+	synthesizedComp, err := r.buildSynthesizedComponent(reqCtx, cluster, dbUser.Spec.ComponentName)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.checkUserManageable(synthesizedComp, dbUser); err != nil {
+		// the user is refused to be managed until the spec or the ComponentDefinition changes.
+		if patchErr := r.updateStatusUnavailable(reqCtx, dbUser, err); patchErr != nil {
+			return intctrlutil.CheckedRequeueWithError(patchErr, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	secret, err := r.ensureCredentialSecret(reqCtx, dbUser)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.provisionUser(reqCtx, cluster, synthesizedComp, dbUser, secret); err != nil {
+		if patchErr := r.updateStatusUnavailable(reqCtx, dbUser, err); patchErr != nil {
+			return intctrlutil.CheckedRequeueWithError(patchErr, reqCtx.Log, "")
+		}
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			return intctrlutil.Reconciled()
+		}
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "failed to provision the database user")
+	}
+
+	statusPatch := client.MergeFrom(dbUser.DeepCopy())
+	dbUser.Status.ObservedGeneration = dbUser.Generation
+	dbUser.Status.Phase = appsv1alpha1.AvailablePhase
+	dbUser.Status.Message = ""
+	dbUser.Status.Role = dbUser.Spec.Role
+	dbUser.Status.SecretName = secret.Name
+	if err = r.Client.Status().Patch(reqCtx.Ctx, dbUser, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	intctrlutil.RecordCreatedEvent(r.Recorder, dbUser)
+	return intctrlutil.Reconciled()
+}
+
+func (r *DatabaseUserReconciler) getCluster(reqCtx intctrlutil.RequestCtx, dbUser *appsv1alpha1.DatabaseUser) (*appsv1alpha1.Cluster, error) {
+	cluster := &appsv1alpha1.Cluster{}
+	clusterKey := types.NamespacedName{Namespace: dbUser.Namespace, Name: dbUser.Spec.ClusterName}
+	if err := r.Client.Get(reqCtx.Ctx, clusterKey, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// ensureCredentialSecret creates the secret to store the credential of the user if it does not exist,
+// the password is generated once and kept unchanged afterward.
+func (r *DatabaseUserReconciler) ensureCredentialSecret(reqCtx intctrlutil.RequestCtx, dbUser *appsv1alpha1.DatabaseUser) (*corev1.Secret, error) {
+	secretKey := types.NamespacedName{
+		Namespace: dbUser.Namespace,
+		Name:      constant.GenerateDatabaseUserSecretName(dbUser.Name),
+	}
+	secret := &corev1.Secret{}
+	if err := r.Client.Get(reqCtx.Ctx, secretKey, secret); err == nil {
+		return secret, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	secret = builder.NewSecretBuilder(secretKey.Namespace, secretKey.Name).
+		AddLabelsInMap(constant.GetComponentWellKnownLabels(dbUser.Spec.ClusterName, dbUser.Spec.ComponentName)).
+		PutData(constant.AccountNameForSecret, []byte(dbUser.GetUserName())).
+		PutData(constant.AccountPasswdForSecret, component.GenerateAccountPassword(dbUser.Spec.PasswordConfig)).
+		SetImmutable(true).
+		GetObject()
+	if err := intctrlutil.SetControllerReference(dbUser, secret); err != nil {
+		return nil, err
+	}
+	if err := secretstore.Seal(reqCtx.Ctx, secret, constant.AccountPasswdForSecret); err != nil {
+		return nil, err
+	}
+	if err := r.Client.Create(reqCtx.Ctx, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// checkUserManageable checks whether the user can be managed by the DatabaseUser, the users are managed by
+// the accountProvision action declared in the ComponentDefinition, and the system accounts are never managed.
+func (r *DatabaseUserReconciler) checkUserManageable(synthesizedComp *component.SynthesizedComponent, dbUser *appsv1alpha1.DatabaseUser) error {
+	lifecycleActions := synthesizedComp.LifecycleActions
+	if lifecycleActions == nil || lifecycleActions.AccountProvision == nil {
+		return fmt.Errorf("the accountProvision action is not declared by the ComponentDefinition of component %s", synthesizedComp.Name)
+	}
+	userName := dbUser.GetUserName()
+	for _, account := range synthesizedComp.SystemAccounts {
+		if account.Name == userName {
+			return fmt.Errorf("user %s is a system account of component %s, which can't be managed by the DatabaseUser", userName, synthesizedComp.Name)
+		}
+	}
+	return nil
+}
+
+// provisionUser creates the user in the database engine if it has not been created, or grants the desired role to it.
+// It refuses to take over the users which exist before, only the users created by the DatabaseUser are managed.
+func (r *DatabaseUserReconciler) provisionUser(reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster,
+	synthesizedComp *component.SynthesizedComponent, dbUser *appsv1alpha1.DatabaseUser, secret *corev1.Secret) error {
+	lorryCli, err := r.buildLorryClient(reqCtx, cluster, synthesizedComp)
+	if err != nil {
+		return err
+	}
+
+	userName := dbUser.GetUserName()
+	role := string(dbUser.Spec.Role)
+	if len(dbUser.Status.Role) == 0 {
+		user, err := lorryCli.DescribeUser(reqCtx.Ctx, userName)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			// the user may have been created while the status failed to update.
+			if dbUser.Status.Owned {
+				return lorryCli.GrantUserRole(reqCtx.Ctx, userName, role)
+			}
+			return intctrlutil.NewFatalError(fmt.Sprintf("user %s already exists, which is not created by the DatabaseUser", userName))
+		}
+		// mark the user as owned before creating it, so it's recognized if the status fails to update after that.
+		if !dbUser.Status.Owned {
+			statusPatch := client.MergeFrom(dbUser.DeepCopy())
+			dbUser.Status.Owned = true
+			if err = r.Client.Status().Patch(reqCtx.Ctx, dbUser, statusPatch); err != nil {
+				return err
+			}
+		}
+		data, err := secretstore.Unseal(reqCtx.Ctx, secret)
+		if err != nil {
+			return err
+		}
+		return lorryCli.CreateUser(reqCtx.Ctx, userName, string(data[constant.AccountPasswdForSecret]), role)
+	}
+	if dbUser.Status.Role == dbUser.Spec.Role {
+		return nil
+	}
+	if err = lorryCli.RevokeUserRole(reqCtx.Ctx, userName, string(dbUser.Status.Role)); err != nil {
+		return err
+	}
+	return lorryCli.GrantUserRole(reqCtx.Ctx, userName, role)
+}
+
+// deleteUser deletes the user created by the DatabaseUser from the database engine. It's skipped if the cluster
+// or the component has gone, and it waits for the cluster to be running if the cluster is stopped.
+func (r *DatabaseUserReconciler) deleteUser(reqCtx intctrlutil.RequestCtx, dbUser *appsv1alpha1.DatabaseUser) (*ctrl.Result, error) {
+	cluster, err := r.getCluster(reqCtx, dbUser)
+	if err != nil {
+		return nil, err
+	}
+	clusterGone := cluster == nil || !cluster.GetDeletionTimestamp().IsZero() ||
+		cluster.Spec.GetComponentByName(dbUser.Spec.ComponentName) == nil
+	if !clusterGone && dbUser.Status.Owned {
+		if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+			res, err := intctrlutil.RequeueAfter(time.Second*10, reqCtx.Log, "wait for the cluster to be running to delete the user")
+			return &res, err
+		}
+		synthesizedComp, err := r.buildSynthesizedComponent(reqCtx, cluster, dbUser.Spec.ComponentName)
+		if err != nil {
+			return nil, err
+		}
+		lorryCli, err := r.buildLorryClient(reqCtx, cluster, synthesizedComp)
+		if err != nil {
+			return nil, err
+		}
+		if err = lorryCli.DeleteUser(reqCtx.Ctx, dbUser.GetUserName()); err != nil {
+			return nil, err
+		}
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: dbUser.Namespace, Name: constant.GenerateDatabaseUserSecretName(dbUser.Name)}
+	if err = r.Client.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return nil, secretstore.Purge(reqCtx.Ctx, secret)
+}
+
+func (r *DatabaseUserReconciler) buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1alpha1.Cluster, compName string) (*component.SynthesizedComponent, error) {
+	compSpec := cluster.Spec.GetComponentByName(compName)
+	if compSpec == nil {
+		return nil, fmt.Errorf("component %s not found", compName)
+	}
+	return component.BuildSynthesizedComponentWrapper(reqCtx, r.Client, cluster, compSpec)
+}
+
+// buildLorryClient builds the lorry client of the writable pod if the component has roles, otherwise the first pod of the component.
+func (r *DatabaseUserReconciler) buildLorryClient(reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster,
+	synthesizedComp *component.SynthesizedComponent) (lorry.Client, error) {
+	var (
+		podList *corev1.PodList
+		err     error
+	)
+	roleName := ""
+	for _, role := range synthesizedComp.Roles {
+		if role.Serviceable && role.Writable {
+			roleName = role.Name
+		}
+	}
+	if len(roleName) > 0 {
+		podList, err = component.GetComponentPodListWithRole(reqCtx.Ctx, r.Client, *cluster, synthesizedComp.Name, roleName)
+	} else {
+		podList, err = component.GetComponentPodList(reqCtx.Ctx, r.Client, *cluster, synthesizedComp.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if podList == nil || len(podList.Items) == 0 {
+		return nil, fmt.Errorf("unable to find appropriate pods of component %s to manage the user", synthesizedComp.Name)
+	}
+	return lorry.NewClient(podList.Items[0])
+}
+
+func (r *DatabaseUserReconciler) updateStatusUnavailable(reqCtx intctrlutil.RequestCtx, dbUser *appsv1alpha1.DatabaseUser, err error) error {
+	statusPatch := client.MergeFrom(dbUser.DeepCopy())
+	dbUser.Status.Phase = appsv1alpha1.UnavailablePhase
+	dbUser.Status.ObservedGeneration = dbUser.Generation
+	dbUser.Status.Message = err.Error()
+	return r.Client.Status().Patch(reqCtx.Ctx, dbUser, statusPatch)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DatabaseUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.DatabaseUser{}).
+		Owns(&corev1.Secret{}).
+		Watches(&appsv1alpha1.ComponentDefinition{}, handler.EnqueueRequestsFromMapFunc(r.componentDefinitionToDatabaseUsers)).
+		Complete(r)
+}
+
+// componentDefinitionToDatabaseUsers enqueues the unavailable DatabaseUsers when a ComponentDefinition changes,
+// since they may be refused to be managed by the ComponentDefinition of their components.
+func (r *DatabaseUserReconciler) componentDefinitionToDatabaseUsers(ctx context.Context, _ client.Object) []reconcile.Request {
+	dbUsers := &appsv1alpha1.DatabaseUserList{}
+	if err := r.Client.List(ctx, dbUsers); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, dbUser := range dbUsers.Items {
+		if dbUser.Status.Phase == appsv1alpha1.UnavailablePhase {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&dbUser)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,283 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("DatabaseUser Controller", func() {
+	const compName = "mysql"
+
+	var (
+		clusterDefName string
+		compDefName    string
+		clusterName    string
+		clusterKey     types.NamespacedName
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+		lorry.UnsetMockClient()
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+
+		// resources should be released in following order
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.DatabaseUserSignature, true, inNS, ml)
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		if len(clusterName) > 0 {
+			testapps.ClearResources(&testCtx, generics.SecretSignature, inNS,
+				client.MatchingLabels{constant.AppInstanceLabelKey: clusterName})
+		}
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		randomStr := testCtx.GetRandomStr()
+		clusterDefName = "test-clusterdef-" + randomStr
+		compDefName = "test-compdef-" + randomStr
+		clusterName = "test-cluster-" + randomStr
+	})
+
+	AfterEach(func() {
+		cleanEnv()
+	})
+
+	newDatabaseUser := func(clusterName, userName string) *appsv1alpha1.DatabaseUser {
+		return &appsv1alpha1.DatabaseUser{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-dbuser-" + testCtx.GetRandomStr(),
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1alpha1.DatabaseUserSpec{
+				ClusterName:    clusterName,
+				ComponentName:  compName,
+				UserName:       userName,
+				Role:           appsv1alpha1.ReadOnlyDatabaseUserRole,
+				PasswordConfig: appsv1alpha1.PasswordConfig{Length: 16},
+			},
+		}
+	}
+
+	// createCluster creates a cluster whose component declares the accountProvision action and the system account root,
+	// and the leader pod of the component to manage the users.
+	createCluster := func() {
+		testapps.NewClusterDefFactory(clusterDefName).
+			AddComponentDef(testapps.StatefulMySQLComponent, "stateful").
+			Create(&testCtx)
+		testapps.NewComponentDefinitionFactory(compDefName).
+			SetDefaultSpec().
+			Create(&testCtx)
+		cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName, "").
+			AddComponentV2(compName, compDefName).
+			SetReplicas(1).
+			Create(&testCtx).
+			GetObject()
+		clusterKey = client.ObjectKeyFromObject(cluster)
+		testapps.NewPodFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, compName)+"-0").
+			AddLabelsInMap(constant.GetComponentWellKnownLabels(clusterName, compName)).
+			AddRoleLabel("leader").
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+			Create(&testCtx)
+	}
+
+	mockClusterStatusPhaseToRunning := func() {
+		Expect(testapps.GetAndChangeObjStatus(&testCtx, clusterKey, func(cluster *appsv1alpha1.Cluster) {
+			cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+			cluster.Status.SetComponentStatus(compName, appsv1alpha1.ClusterComponentStatus{
+				Phase: appsv1alpha1.RunningClusterCompPhase,
+			})
+		})()).Should(Succeed())
+	}
+
+	// mockDeleteUser counts the users deleted from the engine.
+	mockDeleteUser := func(recorder *lorry.MockClientMockRecorder, deleted *atomic.Int32) {
+		recorder.DeleteUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ string) error {
+			deleted.Add(1)
+			return nil
+		}).AnyTimes()
+	}
+
+	deleteDatabaseUser := func(dbUser *appsv1alpha1.DatabaseUser) {
+		testapps.DeleteObject(&testCtx, client.ObjectKeyFromObject(dbUser), &appsv1alpha1.DatabaseUser{})
+		Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(dbUser), &appsv1alpha1.DatabaseUser{}, false)).Should(Succeed())
+	}
+
+	Context("Test DatabaseUser", func() {
+		It("should be unavailable if the cluster does not exist", func() {
+			dbUser := newDatabaseUser("not-exist", "")
+			Expect(testCtx.CreateObj(testCtx.Ctx, dbUser)).Should(Succeed())
+
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(dbUser), func(g Gomega, user *appsv1alpha1.DatabaseUser) {
+				g.Expect(user.Finalizers).Should(ContainElement(databaseUserFinalizerName))
+				g.Expect(user.Status.Phase).Should(Equal(appsv1alpha1.UnavailablePhase))
+				g.Expect(user.Status.Message).ShouldNot(BeEmpty())
+			})).Should(Succeed())
+
+			By("delete the DatabaseUser")
+			deleteDatabaseUser(dbUser)
+		})
+
+		It("should skip deleting the user from the engine if the cluster has gone", func() {
+			var deleted atomic.Int32
+			mockLorryClient(func(recorder *lorry.MockClientMockRecorder) {
+				mockDeleteUser(recorder, &deleted)
+			})
+			dbUser := newDatabaseUser("not-exist", "")
+			Expect(testCtx.CreateObj(testCtx.Ctx, dbUser)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(dbUser), func(g Gomega, user *appsv1alpha1.DatabaseUser) {
+				g.Expect(user.Finalizers).Should(ContainElement(databaseUserFinalizerName))
+				g.Expect(user.Status.Phase).Should(Equal(appsv1alpha1.UnavailablePhase))
+			})).Should(Succeed())
+
+			By("mock the user has been created before the cluster is gone")
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(dbUser), func(user *appsv1alpha1.DatabaseUser) {
+				user.Status.Owned = true
+				user.Status.Role = appsv1alpha1.ReadOnlyDatabaseUserRole
+			})()).Should(Succeed())
+
+			deleteDatabaseUser(dbUser)
+			Expect(deleted.Load()).Should(BeZero())
+		})
+
+		It("should create the user and drop it from the engine on deletion", func() {
+			createCluster()
+			mockClusterStatusPhaseToRunning()
+
+			var (
+				password atomic.Value
+				deleted  atomic.Int32
+			)
+			dbUser := newDatabaseUser(clusterName, "")
+			mockLorryClient(func(recorder *lorry.MockClientMockRecorder) {
+				recorder.DescribeUser(gomock.Any(), dbUser.Name).Return(nil, nil).AnyTimes()
+				recorder.CreateUser(gomock.Any(), dbUser.Name, gomock.Any(), "readonly").
+					DoAndReturn(func(_ context.Context, _, passwd, _ string) error {
+						password.Store(passwd)
+						return nil
+					}).AnyTimes()
+				mockDeleteUser(recorder, &deleted)
+			})
+			Expect(testCtx.CreateObj(testCtx.Ctx, dbUser)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(dbUser), func(g Gomega, user *appsv1alpha1.DatabaseUser) {
+				g.Expect(user.Status.Phase).Should(Equal(appsv1alpha1.AvailablePhase))
+				g.Expect(user.Status.Owned).Should(BeTrue())
+				g.Expect(user.Status.Role).Should(Equal(appsv1alpha1.ReadOnlyDatabaseUserRole))
+			})).Should(Succeed())
+
+			By("check the password created in the engine is stored in the secret")
+			secretKey := types.NamespacedName{Namespace: dbUser.Namespace, Name: constant.GenerateDatabaseUserSecretName(dbUser.Name)}
+			Eventually(testapps.CheckObj(&testCtx, secretKey, func(g Gomega, secret *corev1.Secret) {
+				g.Expect(password.Load()).Should(HaveLen(16))
+				g.Expect(string(secret.Data[constant.AccountPasswdForSecret])).Should(Equal(password.Load()))
+			})).Should(Succeed())
+
+			By("the user created by the DatabaseUser is dropped from the engine on deletion")
+			deleteDatabaseUser(dbUser)
+			Expect(deleted.Load()).Should(BeNumerically(">", 0))
+		})
+
+		It("should refuse to manage the user existing before", func() {
+			createCluster()
+			mockClusterStatusPhaseToRunning()
+
+			var deleted atomic.Int32
+			dbUser := newDatabaseUser(clusterName, "")
+			mockLorryClient(func(recorder *lorry.MockClientMockRecorder) {
+				recorder.DescribeUser(gomock.Any(), dbUser.Name).Return(map[string]any{"userName": dbUser.Name}, nil).AnyTimes()
+				mockDeleteUser(recorder, &deleted)
+			})
+			Expect(testCtx.CreateObj(testCtx.Ctx, dbUser)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(dbUser), func(g Gomega, user *appsv1alpha1.DatabaseUser) {
+				g.Expect(user.Status.Phase).Should(Equal(appsv1alpha1.UnavailablePhase))
+				g.Expect(user.Status.Owned).Should(BeFalse())
+			})).Should(Succeed())
+
+			By("the existing user is kept in the engine on deletion")
+			deleteDatabaseUser(dbUser)
+			Expect(deleted.Load()).Should(BeZero())
+		})
+
+		It("should refuse to manage the system account", func() {
+			createCluster()
+			mockClusterStatusPhaseToRunning()
+
+			dbUser := newDatabaseUser(clusterName, "root")
+			Expect(testCtx.CreateObj(testCtx.Ctx, dbUser)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(dbUser), func(g Gomega, user *appsv1alpha1.DatabaseUser) {
+				g.Expect(user.Status.Phase).Should(Equal(appsv1alpha1.UnavailablePhase))
+				g.Expect(user.Status.Message).Should(ContainSubstring("system account"))
+			})).Should(Succeed())
+		})
+
+		It("should recognize the user created by the DatabaseUser", func() {
+			createCluster()
+
+			dbUser := newDatabaseUser(clusterName, "")
+			Expect(testCtx.CreateObj(testCtx.Ctx, dbUser)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(dbUser), func(g Gomega, user *appsv1alpha1.DatabaseUser) {
+				g.Expect(user.Finalizers).Should(ContainElement(databaseUserFinalizerName))
+			})).Should(Succeed())
+
+			By("mock the user has been created while the status failed to update")
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(dbUser), func(user *appsv1alpha1.DatabaseUser) {
+				user.Status.Owned = true
+			})()).Should(Succeed())
+			mockLorryClient(func(recorder *lorry.MockClientMockRecorder) {
+				recorder.DescribeUser(gomock.Any(), dbUser.Name).Return(map[string]any{"userName": dbUser.Name}, nil).AnyTimes()
+				recorder.GrantUserRole(gomock.Any(), dbUser.Name, "readonly").Return(nil).AnyTimes()
+			})
+			mockClusterStatusPhaseToRunning()
+			Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(dbUser), func(user *appsv1alpha1.DatabaseUser) {
+				if user.Annotations == nil {
+					user.Annotations = map[string]string{}
+				}
+				user.Annotations["test-trigger"] = "true"
+			})()).Should(Succeed())
+
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(dbUser), func(g Gomega, user *appsv1alpha1.DatabaseUser) {
+				g.Expect(user.Status.Phase).Should(Equal(appsv1alpha1.AvailablePhase))
+			})).Should(Succeed())
+		})
+	})
+})
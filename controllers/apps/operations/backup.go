@@ -21,6 +21,7 @@ package operations
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -63,6 +64,15 @@ func (b BackupOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clien
 	opsRequest := opsRes.OpsRequest
 	cluster := opsRes.Cluster
 
+	// the backup has been created by the previous reconciliation
+	backups := &dpv1alpha1.BackupList{}
+	if err := cli.List(reqCtx.Ctx, backups, client.InNamespace(cluster.Namespace), client.MatchingLabels(getBackupLabels(cluster.Name, opsRequest.Name))); err != nil {
+		return err
+	}
+	if len(backups.Items) > 0 {
+		return nil
+	}
+
 	// create backup
 	if backup, err := buildBackup(reqCtx, cli, opsRequest, cluster); err != nil {
 		return err
@@ -72,12 +82,13 @@ func (b BackupOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clien
 }
 
 // ReconcileAction implements the backup reconcile action.
-// It will check the backup status and update the OpsRequest status.
+// It will check the backup status and record it to the OpsRequest.status.backupStatus.
 // If the backup is completed, it will return OpsSuccess
 // If the backup is failed, it will return OpsFailed
 func (b BackupOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
 	opsRequest := opsRes.OpsRequest
 	cluster := opsRes.Cluster
+	opsDeepCopy := opsRequest.DeepCopy()
 
 	// get backup
 	backups := &dpv1alpha1.BackupList{}
@@ -88,12 +99,25 @@ func (b BackupOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 	if len(backups.Items) == 0 {
 		return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("backup not found")
 	}
+	// record backup status
+	backup := &backups.Items[0]
+	opsRequest.Status.BackupStatus = buildOpsBackupStatus(backup)
+	opsRequest.Status.Progress = "0/1"
+	if backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+		opsRequest.Status.Progress = "1/1"
+	}
+	if !reflect.DeepEqual(opsDeepCopy.Status, opsRequest.Status) {
+		if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy)); err != nil {
+			return appsv1alpha1.OpsRunningPhase, 0, err
+		}
+	}
+
 	// check backup status
-	phase := backups.Items[0].Status.Phase
-	if phase == dpv1alpha1.BackupPhaseCompleted {
+	switch backup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
 		return appsv1alpha1.OpsSucceedPhase, 0, nil
-	} else if phase == dpv1alpha1.BackupPhaseFailed {
-		return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("backup failed")
+	case dpv1alpha1.BackupPhaseFailed:
+		return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("backup %s failed: %s", backup.Name, backup.Status.FailureReason)
 	}
 	return appsv1alpha1.OpsRunningPhase, 0, nil
 }
@@ -179,6 +203,17 @@ func buildBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *a
 	return backup, nil
 }
 
+func buildOpsBackupStatus(backup *dpv1alpha1.Backup) *appsv1alpha1.OpsBackupStatus {
+	return &appsv1alpha1.OpsBackupStatus{
+		BackupName:       backup.Name,
+		BackupPolicyName: backup.Spec.BackupPolicyName,
+		BackupMethod:     backup.Spec.BackupMethod,
+		Phase:            backup.Status.Phase,
+		TotalSize:        backup.Status.TotalSize,
+		FailureReason:    backup.Status.FailureReason,
+	}
+}
+
 func getDefaultBackupPolicy(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster, backupPolicy string) (string, error) {
 	// if backupPolicy is not empty, return it directly
 	if backupPolicy != "" {
@@ -23,12 +23,17 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
 )
 
 var _ = Describe("Backup OpsRequest", func() {
@@ -94,6 +99,116 @@ var _ = Describe("Backup OpsRequest", func() {
 			Expect(err).ShouldNot(HaveOccurred())
 		})
 	})
+
+	Context("Test the backup ops handler", func() {
+		var (
+			backupClusterName string
+			opsName           string
+			backupPolicyName  string
+			opsRes            *OpsResource
+			reqCtx            intctrlutil.RequestCtx
+			handler           BackupOpsHandler
+		)
+
+		cleanBackups := func() {
+			inNS := client.InNamespace(testCtx.DefaultNamespace)
+			ml := client.HasLabels{testCtx.TestObjLabelKey}
+			testapps.ClearResources(&testCtx, generics.BackupPolicySignature, inNS, ml)
+			if len(backupClusterName) > 0 {
+				testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS,
+					client.MatchingLabels(getBackupLabels(backupClusterName, opsName)))
+			}
+		}
+
+		BeforeEach(func() {
+			cleanBackups()
+			backupClusterName = "backup-cluster-" + testCtx.GetRandomStr()
+			opsName = "backup-ops-" + testCtx.GetRandomStr()
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("create the default backup policy of the cluster")
+			backupPolicyName = "backup-policy-" + testCtx.GetRandomStr()
+			backupPolicy := testdp.NewBackupPolicyFactory(testCtx.DefaultNamespace, backupPolicyName).
+				AddLabels(constant.AppInstanceLabelKey, backupClusterName).
+				AddAnnotations(dptypes.DefaultBackupPolicyAnnotationKey, "true").
+				AddBackupMethod("snapshot", true, "").
+				SetTarget(constant.AppInstanceLabelKey, backupClusterName).
+				Create(&testCtx).
+				GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, backupPolicy, func() {
+				backupPolicy.Status.Phase = dpv1alpha1.AvailablePhase
+			})).Should(Succeed())
+
+			// the cluster is not read by the handler, it is not created.
+			cluster := &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: backupClusterName}}
+			opsRes = &OpsResource{
+				OpsRequest: createBackupOpsObj(backupClusterName, opsName),
+				Cluster:    cluster,
+				Recorder:   eventRecorder,
+			}
+		})
+
+		AfterEach(cleanBackups)
+
+		getBackup := func() *dpv1alpha1.Backup {
+			backups := &dpv1alpha1.BackupList{}
+			Expect(k8sClient.List(ctx, backups, client.InNamespace(testCtx.DefaultNamespace),
+				client.MatchingLabels(getBackupLabels(backupClusterName, opsName)))).Should(Succeed())
+			Expect(backups.Items).Should(HaveLen(1), "expect exactly one backup created by the ops")
+			return &backups.Items[0]
+		}
+
+		setBackupPhase := func(phase dpv1alpha1.BackupPhase) {
+			backup := getBackup()
+			Expect(testapps.ChangeObjStatus(&testCtx, backup, func() {
+				backup.Status.Phase = phase
+				backup.Status.FailureReason = "test"
+			})).Should(Succeed())
+		}
+
+		expectPhase := func(expected appsv1alpha1.OpsPhase, expectErr bool) {
+			phase, _, err := handler.ReconcileAction(reqCtx, k8sClient, opsRes)
+			Expect(phase).Should(Equal(expected))
+			if expectErr {
+				Expect(err).Should(HaveOccurred())
+			} else {
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+		}
+
+		It("should reuse the backup and record its status in the ops", func() {
+			By("the action is idempotent, the backup created by the previous reconciliation is reused")
+			for i := 0; i < 2; i++ {
+				Expect(handler.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			}
+			backup := getBackup()
+			Expect(backup.Spec.BackupPolicyName).Should(Equal(backupPolicyName))
+			Expect(backup.Spec.BackupMethod).Should(Equal("snapshot"))
+
+			By("the backup is running")
+			setBackupPhase(dpv1alpha1.BackupPhaseRunning)
+			expectPhase(appsv1alpha1.OpsRunningPhase, false)
+			status := opsRes.OpsRequest.Status
+			Expect(status.Progress).Should(Equal("0/1"))
+			Expect(status.BackupStatus).ShouldNot(BeNil())
+			Expect(status.BackupStatus.BackupName).Should(Equal(backup.Name))
+			Expect(status.BackupStatus.Phase).Should(Equal(dpv1alpha1.BackupPhaseRunning))
+
+			By("the backup is completed")
+			setBackupPhase(dpv1alpha1.BackupPhaseCompleted)
+			expectPhase(appsv1alpha1.OpsSucceedPhase, false)
+			Expect(opsRes.OpsRequest.Status.Progress).Should(Equal("1/1"))
+			Expect(opsRes.OpsRequest.Status.BackupStatus.Phase).Should(Equal(dpv1alpha1.BackupPhaseCompleted))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Progress).Should(Equal("1/1"))
+			})).Should(Succeed())
+
+			By("the backup is failed")
+			setBackupPhase(dpv1alpha1.BackupPhaseFailed)
+			expectPhase(appsv1alpha1.OpsFailedPhase, true)
+			Expect(opsRes.OpsRequest.Status.BackupStatus.FailureReason).Should(Equal("test"))
+		})
+	})
 })
 
 func createBackupOpsObj(clusterName, backupOpsName string) *appsv1alpha1.OpsRequest {
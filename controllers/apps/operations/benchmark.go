@@ -0,0 +1,278 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	componetutil "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ OpsHandler = BenchmarkOpsHandler{}
+
+const (
+	benchmarkContainerName = "benchmark"
+	benchmarkOutputFile    = "/tmp/benchmark-output"
+	pgbenchLogPrefix       = "/tmp/pgbench_log"
+)
+
+var (
+	sysbenchTPSRegexp        = regexp.MustCompile(`transactions:\s+\d+\s+\(([\d.]+) per sec\.\)`)
+	sysbenchLatencyAvgRegexp = regexp.MustCompile(`avg:\s+([\d.]+)`)
+	sysbenchLatencyP95Regexp = regexp.MustCompile(`95th percentile:\s+([\d.]+)`)
+	pgbenchTPSRegexp         = regexp.MustCompile(`tps = ([\d.]+)`)
+	pgbenchLatencyAvgRegexp  = regexp.MustCompile(`latency average = ([\d.]+) ms`)
+	pgbenchLatencyP95Regexp  = regexp.MustCompile(`latency 95th percentile = ([\d.]+) ms`)
+)
+
+// BenchmarkOpsHandler handles Benchmark operation, it runs a load test job against the component
+// and summarizes the result into the status of the OpsRequest.
+type BenchmarkOpsHandler struct {
+}
+
+func init() {
+	// ToClusterPhase is not defined, because 'benchmark' does not affect the cluster status.
+	benchmarkBehavior := OpsBehaviour{
+		FromClusterPhases: []appsv1alpha1.ClusterPhase{appsv1alpha1.RunningClusterPhase},
+		OpsHandler:        BenchmarkOpsHandler{},
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.BenchmarkType, benchmarkBehavior)
+}
+
+// Action implements OpsHandler.Action
+// It will create a job to prepare the test data, run the load test and clean up the test data.
+func (o BenchmarkOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error {
+	job, err := buildBenchmarkJob(reqCtx, cli, opsResource.Cluster, opsResource.OpsRequest)
+	if err != nil {
+		return err
+	}
+	return client.IgnoreAlreadyExists(cli.Create(reqCtx.Ctx, job))
+}
+
+// ReconcileAction implements OpsHandler.ReconcileAction
+// It will check the job status, and parse the result of the benchmark into the opsRequest status once the job is done.
+func (o BenchmarkOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	opsRequest := opsResource.OpsRequest
+	job := &batchv1.Job{}
+	if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: opsRequest.Namespace, Name: getBenchmarkJobName(opsRequest)}, job); err != nil {
+		return appsv1alpha1.OpsFailedPhase, 0, err
+	}
+
+	opsPhase := appsv1alpha1.OpsRunningPhase
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			opsPhase = appsv1alpha1.OpsSucceedPhase
+		case batchv1.JobFailed:
+			opsPhase = appsv1alpha1.OpsFailedPhase
+		}
+	}
+	if opsPhase == appsv1alpha1.OpsRunningPhase {
+		return opsPhase, 5 * time.Second, nil
+	}
+
+	output, _, err := getJobTerminationMessage(reqCtx.Ctx, cli, job, benchmarkContainerName)
+	if err != nil {
+		return appsv1alpha1.OpsRunningPhase, time.Second, err
+	}
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	opsRequest.Status.Progress = "1/1"
+	if opsPhase == appsv1alpha1.OpsSucceedPhase {
+		opsRequest.Status.BenchmarkResult = parseBenchmarkResult(opsRequest.Spec.BenchmarkSpec.Tool, output)
+	}
+	if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+		return opsPhase, time.Second, err
+	}
+	if opsPhase == appsv1alpha1.OpsFailedPhase {
+		return opsPhase, 0, fmt.Errorf("benchmark job %s failed: %s", job.Name, output)
+	}
+	return opsPhase, 0, nil
+}
+
+func (o BenchmarkOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewBenchmarkCondition(opsRes.OpsRequest), nil
+}
+
+func (o BenchmarkOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error {
+	return nil
+}
+
+func getBenchmarkJobName(ops *appsv1alpha1.OpsRequest) string {
+	jobName := fmt.Sprintf("%s-benchmark", ops.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return jobName
+}
+
+// getBenchmarkCommand returns the shell script which prepares the test data, runs the load test and cleans up the test data.
+// The output of the load test is written to the termination message of the container.
+func getBenchmarkCommand(spec *appsv1alpha1.BenchmarkSpec) (string, error) {
+	var prepare, run, cleanup string
+	switch spec.Tool {
+	case appsv1alpha1.SysbenchTool:
+		opts := fmt.Sprintf(`--db-driver=mysql --mysql-host="$KB_HOST" --mysql-user="$KB_USER" --mysql-password="$KB_PASSWD" --mysql-db=%s --tables=4 --table-size=10000 --threads=%d`,
+			spec.Database, spec.Threads)
+		prepare = fmt.Sprintf("sysbench oltp_read_write %s prepare", opts)
+		run = fmt.Sprintf("sysbench oltp_read_write %s --time=%d --report-interval=0 run", opts, spec.DurationSeconds)
+		cleanup = fmt.Sprintf("sysbench oltp_read_write %s cleanup", opts)
+	case appsv1alpha1.PgbenchTool:
+		opts := `-h "$KB_HOST" -U "$KB_USER"`
+		prepare = fmt.Sprintf("pgbench %s -i %s", opts, spec.Database)
+		// pgbench does not report the latency percentiles, so log the latency of each transaction
+		// and calculate the 95th percentile from the logs, which are in microseconds.
+		run = fmt.Sprintf(`{ rm -f %[5]s.*; pgbench %[1]s -c %[2]d -j %[2]d -T %[3]d -l --log-prefix=%[5]s %[4]s && `+
+			`cat %[5]s.* | awk '{print $3}' | sort -n | `+
+			`awk '{v[NR]=$1} END {if (NR > 0) printf "latency 95th percentile = %%.3f ms\n", v[int((NR*95+99)/100)]/1000}'; }`,
+			opts, spec.Threads, spec.DurationSeconds, spec.Database, pgbenchLogPrefix)
+		cleanup = fmt.Sprintf("pgbench %s -i -I d %s", opts, spec.Database)
+	default:
+		return "", fmt.Errorf("unsupported benchmark tool: %s", spec.Tool)
+	}
+	return fmt.Sprintf("set -e; %s; %s; %s || true; exit $rc",
+		prepare, captureOutputToTerminationLog(run, benchmarkOutputFile), cleanup), nil
+}
+
+func buildBenchmarkJob(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster, ops *appsv1alpha1.OpsRequest) (*batchv1.Job, error) {
+	spec := ops.Spec.BenchmarkSpec
+	component := cluster.Spec.GetComponentByName(spec.ComponentName)
+	if component == nil {
+		// we have checked component exists in validation, so this should not happen
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("component %s not found in cluster %s", spec.ComponentName, cluster.Name))
+	}
+	endpoint, err := getTargetService(reqCtx, cli, cluster, component)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	secretFrom := spec.Secret
+	if secretFrom == nil {
+		secretFrom = &appsv1alpha1.ScriptSecret{
+			Name:        constant.GenerateDefaultConnCredential(cluster.Name),
+			PasswordKey: "password",
+			UsernameKey: "username",
+		}
+	}
+	if err = cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: secretFrom.Name}, &corev1.Secret{}); err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	command, err := getBenchmarkCommand(spec)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key:                  key,
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretFrom.Name},
+				},
+			},
+		}
+	}
+	container := corev1.Container{
+		Name:            benchmarkContainerName,
+		Image:           spec.Image,
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		Command:         []string{"/bin/sh", "-c", command},
+		Env: []corev1.EnvVar{
+			{Name: "KB_HOST", Value: endpoint},
+			secretEnv("KB_USER", secretFrom.UsernameKey),
+			secretEnv("KB_PASSWD", secretFrom.PasswordKey),
+			// used by pgbench
+			secretEnv("PGPASSWORD", secretFrom.PasswordKey),
+		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getBenchmarkJobName(ops),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    cluster.Name,
+				constant.KBAppComponentLabelKey: component.Name,
+				constant.OpsRequestNameLabelKey: ops.Name,
+				constant.OpsRequestTypeLabelKey: string(appsv1alpha1.BenchmarkType),
+			},
+		},
+	}
+	// set backoff limit to 0, so that the load test will not be repeated
+	job.Spec.BackoffLimit = pointer.Int32(0)
+	job.Spec.Template.Annotations = intctrlutil.BuildClusterServiceMeshJobAnnotations(cluster)
+	job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	job.Spec.Template.Spec.Containers = []corev1.Container{container}
+	tolerations, err := componetutil.BuildTolerations(cluster, component)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	job.Spec.Template.Spec.Tolerations = tolerations
+	if err = intctrlutil.ApplyDataPlaneSecurityContext(&job.Spec.Template.Spec); err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	scheme, _ := appsv1alpha1.SchemeBuilder.Build()
+	if err = controllerutil.SetOwnerReference(ops, job, scheme); err != nil {
+		return nil, intctrlutil.NewFatalError(err.Error())
+	}
+	return job, nil
+}
+
+// parseBenchmarkResult summarizes the output of the benchmark tool.
+func parseBenchmarkResult(tool appsv1alpha1.BenchmarkTool, output string) *appsv1alpha1.OpsBenchmarkResult {
+	findValue := func(re *regexp.Regexp, suffix string) string {
+		matches := re.FindStringSubmatch(output)
+		if len(matches) < 2 {
+			return ""
+		}
+		return matches[1] + suffix
+	}
+	result := &appsv1alpha1.OpsBenchmarkResult{}
+	switch tool {
+	case appsv1alpha1.SysbenchTool:
+		result.TPS = findValue(sysbenchTPSRegexp, "")
+		result.LatencyAvg = findValue(sysbenchLatencyAvgRegexp, "ms")
+		result.LatencyP95 = findValue(sysbenchLatencyP95Regexp, "ms")
+	case appsv1alpha1.PgbenchTool:
+		result.TPS = findValue(pgbenchTPSRegexp, "")
+		result.LatencyAvg = findValue(pgbenchLatencyAvgRegexp, "ms")
+		result.LatencyP95 = findValue(pgbenchLatencyP95Regexp, "ms")
+	}
+	return result
+}
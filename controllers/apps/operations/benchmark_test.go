@@ -0,0 +1,227 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("BenchmarkOps", func() {
+
+	Context("Test Benchmark result", func() {
+		It("parse the output of sysbench", func() {
+			output := `SQL statistics:
+    queries performed:
+        read:                            172508
+        write:                           49288
+        other:                           24644
+        total:                           246440
+    transactions:                        12322  (205.31 per sec.)
+    queries:                             246440 (4106.22 per sec.)
+
+Latency (ms):
+         min:                                    2.11
+         avg:                                    4.86
+         max:                                   58.34
+         95th percentile:                        8.43
+         sum:                                59891.52
+`
+			result := parseBenchmarkResult(appsv1alpha1.SysbenchTool, output)
+			Expect(result.TPS).Should(Equal("205.31"))
+			Expect(result.LatencyAvg).Should(Equal("4.86ms"))
+			Expect(result.LatencyP95).Should(Equal("8.43ms"))
+		})
+
+		It("parse the output of pgbench", func() {
+			output := `transaction type: <builtin: TPC-B (sort of)>
+number of clients: 4
+number of threads: 4
+duration: 60 s
+number of transactions actually processed: 74071
+latency average = 3.240 ms
+initial connection time = 10.672 ms
+tps = 1234.567890 (without initial connection time)
+latency 95th percentile = 6.512 ms
+`
+			result := parseBenchmarkResult(appsv1alpha1.PgbenchTool, output)
+			Expect(result.TPS).Should(Equal("1234.567890"))
+			Expect(result.LatencyAvg).Should(Equal("3.240ms"))
+			Expect(result.LatencyP95).Should(Equal("6.512ms"))
+		})
+
+		It("build the command of the benchmark", func() {
+			spec := &appsv1alpha1.BenchmarkSpec{Tool: appsv1alpha1.PgbenchTool, Database: "postgres", Threads: 8, DurationSeconds: 30}
+			command, err := getBenchmarkCommand(spec)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(command).Should(ContainSubstring("-c 8 -j 8 -T 30 postgres"))
+			Expect(command).Should(ContainSubstring("/dev/termination-log"))
+
+			spec.Tool = "unknown"
+			_, err = getBenchmarkCommand(spec)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Test the benchmark ops handler", func() {
+		const compName = "mysql"
+
+		var (
+			clusterName string
+			opsRes      *OpsResource
+			reqCtx      intctrlutil.RequestCtx
+			handler     BenchmarkOpsHandler
+		)
+
+		jobKey := func() types.NamespacedName {
+			return types.NamespacedName{Namespace: testCtx.DefaultNamespace, Name: getBenchmarkJobName(opsRes.OpsRequest)}
+		}
+
+		cleanEnv := func() {
+			// must wait till resources deleted and no longer existed before the testcases start,
+			// otherwise if later it needs to create some new resource objects with the same name,
+			// in race conditions, it will find the existence of old objects, resulting failure to
+			// create the new objects.
+			By("clean resources")
+			if opsRes != nil {
+				testapps.DeleteObject(&testCtx, jobKey(), &batchv1.Job{})
+			}
+			inNS := client.InNamespace(testCtx.DefaultNamespace)
+			ml := client.HasLabels{testCtx.TestObjLabelKey}
+			testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+			testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+			testapps.ClearResources(&testCtx, generics.ServiceSignature, inNS, ml)
+			testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+		}
+
+		BeforeEach(func() {
+			cleanEnv()
+			clusterName = "benchmark-cluster-" + testCtx.GetRandomStr()
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("create the service and the connection credential of the cluster")
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace,
+					Name: constant.GenerateComponentServiceName(clusterName, compName, "")},
+				Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "mysql", Port: 3306}}},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, service)).Should(Succeed())
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace,
+				Name: constant.GenerateDefaultConnCredential(clusterName)}}
+			Expect(testCtx.CreateObj(testCtx.Ctx, secret)).Should(Succeed())
+
+			By("create the benchmark ops")
+			ops := testapps.NewOpsRequestObj("benchmark-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.BenchmarkType)
+			ops.Spec.BenchmarkSpec = &appsv1alpha1.BenchmarkSpec{
+				ComponentOps:    appsv1alpha1.ComponentOps{ComponentName: compName},
+				Tool:            appsv1alpha1.SysbenchTool,
+				Image:           "sysbench",
+				Database:        "test",
+				Threads:         4,
+				DurationSeconds: 60,
+			}
+			// the cluster is not read by the handler, it is not created.
+			cluster := &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: clusterName}}
+			cluster.Spec.ComponentSpecs = []appsv1alpha1.ClusterComponentSpec{{Name: compName}}
+			opsRes = &OpsResource{
+				OpsRequest: testapps.CreateOpsRequest(ctx, testCtx, ops),
+				Cluster:    cluster,
+				Recorder:   eventRecorder,
+			}
+		})
+
+		AfterEach(cleanEnv)
+
+		expectPhase := func(expected appsv1alpha1.OpsPhase, expectErr bool) {
+			phase, _, err := handler.ReconcileAction(reqCtx, k8sClient, opsRes)
+			Expect(phase).Should(Equal(expected))
+			if expectErr {
+				Expect(err).Should(HaveOccurred())
+			} else {
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+		}
+
+		setJobCondition := func(conditionType batchv1.JobConditionType) {
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, jobKey(), func(job *batchv1.Job) {
+				job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+			})()).Should(Succeed())
+		}
+
+		It("should run the benchmark job and record the result in the ops", func() {
+			By("the action is idempotent, the job created by the previous reconciliation is reused")
+			for i := 0; i < 2; i++ {
+				Expect(handler.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			}
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, jobKey(), job)).Should(Succeed())
+			command := job.Spec.Template.Spec.Containers[0].Command
+			Expect(command[len(command)-1]).Should(ContainSubstring("> "+benchmarkOutputFile+" 2>&1"),
+				"expect the output of the load test captured to the termination message")
+			Expect(command[len(command)-1]).Should(ContainSubstring("/dev/termination-log"))
+
+			By("the job is running")
+			expectPhase(appsv1alpha1.OpsRunningPhase, false)
+
+			By("the job is completed and the output is in the termination message")
+			pod := testapps.NewPodFactory(testCtx.DefaultNamespace, job.Name+"-"+testCtx.GetRandomStr()).
+				AddLabels("job-name", job.Name).
+				AddContainer(corev1.Container{Name: benchmarkContainerName, Image: "sysbench"}).
+				Create(&testCtx).
+				GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, pod, func() {
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name: benchmarkContainerName,
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Message: "transactions:                        12322  (205.31 per sec.)\n" +
+							"         avg:                                    4.86\n" +
+							"         95th percentile:                        8.43\n",
+					}},
+				}}
+			})).Should(Succeed())
+			setJobCondition(batchv1.JobComplete)
+			expectPhase(appsv1alpha1.OpsSucceedPhase, false)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Progress).Should(Equal("1/1"))
+				g.Expect(ops.Status.BenchmarkResult).ShouldNot(BeNil())
+				g.Expect(ops.Status.BenchmarkResult.TPS).Should(Equal("205.31"))
+				g.Expect(ops.Status.BenchmarkResult.LatencyAvg).Should(Equal("4.86ms"))
+				g.Expect(ops.Status.BenchmarkResult.LatencyP95).Should(Equal("8.43ms"))
+			})).Should(Succeed())
+
+			By("the job is failed")
+			setJobCondition(batchv1.JobFailed)
+			expectPhase(appsv1alpha1.OpsFailedPhase, true)
+		})
+	})
+})
@@ -25,6 +25,7 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -191,6 +192,10 @@ func (w *WorkloadAction) buildPodSpec(actionCtx ActionContext,
 			podSpec.ServiceAccountName = saKey.Name
 		}
 	}
+	// deliver the password from the external secret manager if it's sealed
+	if err := secretstore.InjectPodDelivery(w.Cluster.Namespace, &podSpec); err != nil {
+		return nil, err
+	}
 	return &podSpec, nil
 }
 
@@ -56,14 +56,18 @@ func buildComponentEnvs(reqCtx intctrlutil.RequestCtx,
 	opsDef *appsv1alpha1.OpsDefinition,
 	env *[]corev1.EnvVar,
 	comp *appsv1alpha1.ClusterComponentSpec) error {
+	namingTemplate, err := getNamingTemplate(reqCtx, cli, cluster, comp)
+	if err != nil {
+		return err
+	}
 	// inject built-in component env
-	fullCompName := constant.GenerateClusterComponentName(cluster.Name, comp.Name)
+	fullCompName := namingTemplate.GenerateComponentWorkloadName(cluster.Name, comp.Name, comp.ComponentDefRef)
 	*env = append(*env, []corev1.EnvVar{
 		{Name: constant.KBEnvClusterName, Value: cluster.Name},
 		{Name: constant.KBEnvCompName, Value: comp.Name},
 		{Name: constant.KBEnvClusterCompName, Value: fullCompName},
 		{Name: constant.KBEnvCompReplicas, Value: strconv.Itoa(int(comp.Replicas))},
-		{Name: kbEnvCompHeadlessSVCName, Value: constant.GenerateDefaultComponentHeadlessServiceName(cluster.Name, comp.Name)},
+		{Name: kbEnvCompHeadlessSVCName, Value: namingTemplate.GenerateComponentHeadlessServiceName(cluster.Name, comp.Name, comp.ComponentDefRef)},
 	}...)
 	if len(opsDef.Spec.ComponentDefinitionRefs) == 0 {
 		return nil
@@ -103,7 +107,8 @@ func buildComponentEnvs(reqCtx intctrlutil.RequestCtx,
 			if v.Name != compDefRef.ServiceName {
 				continue
 			}
-			*env = append(*env, corev1.EnvVar{Name: kbEnvCompSVCName, Value: constant.GenerateComponentServiceName(cluster.Name, comp.Name, v.ServiceName)})
+			*env = append(*env, corev1.EnvVar{Name: kbEnvCompSVCName,
+				Value: namingTemplate.GenerateComponentServiceName(cluster.Name, comp.Name, comp.ComponentDefRef, v.ServiceName)})
 			for _, port := range v.Spec.Ports {
 				portName := strings.ReplaceAll(port.Name, "-", "_")
 				*env = append(*env, corev1.EnvVar{Name: kbEnvCompSVCPortPrefix + strings.ToUpper(portName), Value: strconv.Itoa(int(port.Port))})
@@ -114,6 +119,19 @@ func buildComponentEnvs(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// getNamingTemplate returns the naming template of the ClusterDefinition which the component refers to, if any.
+func getNamingTemplate(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	cluster *appsv1alpha1.Cluster, comp *appsv1alpha1.ClusterComponentSpec) (*appsv1alpha1.NamingTemplate, error) {
+	if len(cluster.Spec.ClusterDefRef) == 0 || len(comp.ComponentDefRef) == 0 {
+		return nil, nil
+	}
+	clusterDef := &appsv1alpha1.ClusterDefinition{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: cluster.Spec.ClusterDefRef}, clusterDef); err != nil {
+		return nil, err
+	}
+	return clusterDef.Spec.NamingTemplate, nil
+}
+
 // BuildEnvVars builds the env vars by the vars of the targetPodTemplate.
 func buildEnvVars(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
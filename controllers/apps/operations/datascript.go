@@ -21,6 +21,7 @@ package operations
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -37,6 +38,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	componetutil "github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -44,6 +46,11 @@ import (
 
 var _ OpsHandler = DataScriptOpsHandler{}
 
+const (
+	dataScriptContainerName = "datascript"
+	dataScriptOutputFile    = "/tmp/datascript-output"
+)
+
 // DataScriptOpsHandler handles DataScript operation, it is more like a one-time command operation.
 type DataScriptOpsHandler struct {
 }
@@ -66,6 +73,16 @@ func (o DataScriptOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.C
 	cluster := opsResource.Cluster
 	spec := opsRequest.Spec.ScriptSpec
 
+	// the jobs may have been created before the operator leadership moved, resume them rather than running the script again
+	jobList := &batchv1.JobList{}
+	if err := cli.List(reqCtx.Ctx, jobList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(getDataScriptJobLabels(cluster.Name, spec.ComponentName, opsRequest.Name))); err != nil {
+		return err
+	}
+	if len(jobList.Items) > 0 {
+		return nil
+	}
+
 	// get component
 	component := cluster.Spec.GetComponentByName(spec.ComponentName)
 	if component == nil {
@@ -150,6 +167,13 @@ func (o DataScriptOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 		opsStatus = appsv1alpha1.OpsFailedPhase
 	}
 
+	// capture the output of the scripts once all jobs are done.
+	if opsStatus != appsv1alpha1.OpsRunningPhase {
+		if err := syncDataScriptOutput(reqCtx, cli, opsRequest, jobList.Items); err != nil {
+			return appsv1alpha1.OpsRunningPhase, time.Second, err
+		}
+	}
+
 	patch := client.MergeFrom(opsRequest.DeepCopy())
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", succeedCount, expectedCount)
 
@@ -216,11 +240,20 @@ func getScriptContent(reqCtx intctrlutil.RequestCtx, cli client.Client, spec *ap
 	return script, nil
 }
 
-func getTargetService(reqCtx intctrlutil.RequestCtx, cli client.Client, clusterObjectKey client.ObjectKey, componentName string) (string, error) {
+func getTargetService(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster, component *appsv1alpha1.ClusterComponentSpec) (string, error) {
+	// the service name follows the naming template of the ClusterDefinition if any
+	var namingTemplate *appsv1alpha1.NamingTemplate
+	if len(cluster.Spec.ClusterDefRef) > 0 {
+		clusterDef, err := getClusterDefByName(reqCtx.Ctx, cli, cluster.Spec.ClusterDefRef)
+		if err != nil {
+			return "", err
+		}
+		namingTemplate = clusterDef.Spec.NamingTemplate
+	}
 	// get svc
 	service := &corev1.Service{}
-	serviceName := fmt.Sprintf("%s-%s", clusterObjectKey.Name, componentName)
-	if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: clusterObjectKey.Namespace, Name: serviceName}, service); err != nil {
+	serviceName := namingTemplate.GenerateComponentServiceName(cluster.Name, component.Name, component.ComponentDefRef, "")
+	if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: serviceName}, service); err != nil {
 		return "", err
 	}
 	return serviceName, nil
@@ -305,11 +338,12 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 		}
 
 		container := corev1.Container{
-			Name:            "datascript",
-			Image:           containerImg,
-			ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
-			Command:         jobCmdTpl,
-			Env:             envs,
+			Name:                     dataScriptContainerName,
+			Image:                    containerImg,
+			ImagePullPolicy:          corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+			Command:                  captureDataScriptOutput(jobCmdTpl),
+			Env:                      envs,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 		}
 		randomStr, _ := password.Generate(4, 0, 0, true, false)
 		jobName := fmt.Sprintf("%s-%s-%s-%s", cluster.Name, "script", ops.Name, randomStr)
@@ -326,6 +360,7 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 		intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
 		// set backoff limit to 0, so that the job will not be restarted
 		job.Spec.BackoffLimit = pointer.Int32(0)
+		job.Spec.Template.Annotations = intctrlutil.BuildClusterServiceMeshJobAnnotations(cluster)
 		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
 		job.Spec.Template.Spec.Containers = []corev1.Container{container}
 
@@ -337,6 +372,14 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 			return nil, intctrlutil.NewFatalError(err.Error())
 		}
 		job.Spec.Template.Spec.Tolerations = tolerations
+		// apply the default security settings of the data plane
+		if err = intctrlutil.ApplyDataPlaneSecurityContext(&job.Spec.Template.Spec); err != nil {
+			return nil, intctrlutil.NewFatalError(err.Error())
+		}
+		// deliver the password from the external secret manager if it's sealed
+		if err = secretstore.InjectPodDelivery(cluster.Namespace, &job.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
 		// add owner reference
 		scheme, _ := appsv1alpha1.SchemeBuilder.Build()
 		if err := controllerutil.SetOwnerReference(ops, job, scheme); err != nil {
@@ -351,7 +394,7 @@ func buildDataScriptJobs(reqCtx intctrlutil.RequestCtx, cli client.Client, clust
 
 	jobs := make([]*batchv1.Job, 0)
 	if ops.Spec.ScriptSpec.Selector == nil {
-		if endpoint, err = getTargetService(reqCtx, cli, client.ObjectKeyFromObject(cluster), component.Name); err != nil {
+		if endpoint, err = getTargetService(reqCtx, cli, cluster, component); err != nil {
 			return nil, intctrlutil.NewFatalError(err.Error())
 		}
 		if job, err = buildJob(endpoint); err != nil {
@@ -398,3 +441,59 @@ func getDataScriptJobLabels(cluster, component, request string) map[string]strin
 		constant.OpsRequestTypeLabelKey: string(appsv1alpha1.DataScriptType),
 	}
 }
+
+// captureDataScriptOutput redirects the output of the shell command to the termination message of the container,
+// so that it can be collected without reading the logs of the pod.
+func captureDataScriptOutput(cmd []string) []string {
+	if len(cmd) < 3 || !strings.HasSuffix(cmd[0], "sh") {
+		return cmd
+	}
+	last := len(cmd) - 1
+	wrapped := append([]string{}, cmd...)
+	wrapped[last] = captureOutputToTerminationLog(cmd[last], dataScriptOutputFile) + "; exit $rc"
+	return wrapped
+}
+
+// syncDataScriptOutput collects the termination messages of the finished jobs into the output ConfigMap of the OpsRequest.
+func syncDataScriptOutput(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest, jobs []batchv1.Job) error {
+	data := map[string]string{}
+	for i := range jobs {
+		output, terminated, err := getJobTerminationMessage(reqCtx.Ctx, cli, &jobs[i], dataScriptContainerName)
+		if err != nil {
+			return err
+		}
+		if terminated {
+			data[jobs[i].Name] = output
+		}
+	}
+	cm := &corev1.ConfigMap{}
+	cmKey := types.NamespacedName{Namespace: opsRequest.Namespace, Name: fmt.Sprintf("%s-datascript-output", opsRequest.Name)}
+	if err := cli.Get(reqCtx.Ctx, cmKey, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cmKey.Name,
+				Namespace: cmKey.Namespace,
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    opsRequest.Spec.ClusterRef,
+					constant.OpsRequestNameLabelKey: opsRequest.Name,
+					constant.OpsRequestTypeLabelKey: string(appsv1alpha1.DataScriptType),
+				},
+			},
+			Data: data,
+		}
+		scheme, _ := appsv1alpha1.SchemeBuilder.Build()
+		if err = controllerutil.SetOwnerReference(opsRequest, cm, scheme); err != nil {
+			return err
+		}
+		return cli.Create(reqCtx.Ctx, cm)
+	}
+	if reflect.DeepEqual(cm.Data, data) {
+		return nil
+	}
+	patch := client.MergeFrom(cm.DeepCopy())
+	cm.Data = data
+	return cli.Patch(reqCtx.Ctx, cm, patch)
+}
@@ -288,6 +288,12 @@ var _ = Describe("DataScriptOps", func() {
 			job := jobs[0]
 			Expect(k8sClient.Create(testCtx.Ctx, job)).Should(Succeed())
 
+			By("mock the operator leadership moved, the new leader does the action again, should not create the job again")
+			jobLabels := client.MatchingLabels(getDataScriptJobLabels(clusterObj.Name, comp.Name, ops.Name))
+			Eventually(testapps.List(&testCtx, generics.JobSignature, client.InNamespace(ops.Namespace), jobLabels)).Should(HaveLen(1))
+			Expect(DataScriptOpsHandler{}.Action(reqCtx, k8sClient, opsResource)).Should(Succeed())
+			Consistently(testapps.List(&testCtx, generics.JobSignature, client.InNamespace(ops.Namespace), jobLabels)).Should(HaveLen(1))
+
 			By("reconcile the opsRequest phase")
 			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsResource)
 			Expect(err).Should(Succeed())
@@ -309,6 +315,10 @@ var _ = Describe("DataScriptOps", func() {
 			Expect(err).Should(Succeed())
 			Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
 
+			By("expect the output ConfigMap to be created")
+			Expect(k8sClient.Get(testCtx.Ctx, client.ObjectKey{Namespace: ops.Namespace, Name: ops.Name + "-datascript-output"},
+				&corev1.ConfigMap{})).Should(Succeed())
+
 			Expect(k8sClient.Delete(testCtx.Ctx, service)).Should(Succeed())
 			Expect(k8sClient.Delete(testCtx.Ctx, job)).Should(Succeed())
 			Expect(k8sClient.Delete(testCtx.Ctx, secret)).Should(Succeed())
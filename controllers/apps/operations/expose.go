@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -116,18 +117,20 @@ func (e ExposeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 		expectProgressCount int
 	)
 	for _, v := range opsRequest.Spec.ExposeList {
-		actualCount, expectCount, err := e.handleComponentServices(reqCtx, cli, opsResource, v)
+		actualCount, expectCount, endpoints, err := e.handleComponentServices(reqCtx, cli, opsResource, v)
 		if err != nil {
 			return "", 0, err
 		}
 		actualProgressCount += actualCount
 		expectProgressCount += expectCount
 
-		// update component status if completed
+		// report the allocated addresses and update component status if completed
+		p := opsRequest.Status.Components[v.ComponentName]
+		p.ExposedEndpoints = endpoints
 		if actualCount == expectCount {
-			p := opsRequest.Status.Components[v.ComponentName]
 			p.Phase = appsv1alpha1.RunningClusterCompPhase
 		}
+		opsRequest.Status.Components[v.ComponentName] = p
 	}
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", actualProgressCount, expectProgressCount)
 
@@ -145,12 +148,13 @@ func (e ExposeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 	return opsRequestPhase, 5 * time.Second, nil
 }
 
-func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, expose appsv1alpha1.Expose) (int, int, error) {
+func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource,
+	expose appsv1alpha1.Expose) (int, int, []appsv1alpha1.ExposedEndpoint, error) {
 	svcList := &corev1.ServiceList{}
 	if err := cli.List(reqCtx.Ctx, svcList, client.MatchingLabels{
 		constant.AppInstanceLabelKey: opsRes.Cluster.Name,
 	}, client.InNamespace(opsRes.Cluster.Namespace)); err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 
 	getSvcName := func(clusterName string, componentName string, name string) string {
@@ -178,7 +182,17 @@ func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx,
 	var (
 		expectCount = len(expose.Services)
 		actualCount int
+		endpoints   []appsv1alpha1.ExposedEndpoint
+		nodeHosts   []string
 	)
+	if len(expose.ComponentName) > 0 && slices.ContainsFunc(expose.Services, func(svc appsv1alpha1.OpsService) bool {
+		return svc.ServiceType == corev1.ServiceTypeNodePort
+	}) {
+		var err error
+		if nodeHosts, err = component.GetComponentNodeHosts(reqCtx.Ctx, cli, *opsRes.Cluster, expose.ComponentName); err != nil {
+			return 0, 0, nil, err
+		}
+	}
 
 	checkEnableExposeService := func() {
 		for _, item := range expose.Services {
@@ -186,6 +200,9 @@ func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx,
 			if !ok {
 				continue
 			}
+			if intctrlutil.IsExposedService(service.Spec.Type) {
+				endpoints = append(endpoints, intctrlutil.BuildExposedEndpoint(&service, nodeHosts))
+			}
 
 			if item.ServiceType == corev1.ServiceTypeLoadBalancer {
 				for _, ingress := range service.Status.LoadBalancer.Ingress {
@@ -218,7 +235,7 @@ func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx,
 		checkDisableExposeService()
 	}
 
-	return actualCount, expectCount, nil
+	return actualCount, expectCount, endpoints, nil
 }
 
 func (e ExposeOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
@@ -395,10 +412,13 @@ func (e ExposeOpsHandler) buildClusterServices(reqCtx intctrlutil.RequestCtx,
 				ServiceName: genServiceName,
 				Annotations: exposeService.Annotations,
 				Spec: corev1.ServiceSpec{
-					Type: exposeService.ServiceType,
+					Type:           exposeService.ServiceType,
+					IPFamilyPolicy: exposeService.IPFamilyPolicy,
+					IPFamilies:     exposeService.IPFamilies,
 				},
 			},
-			ComponentSelector: clusterCompSpecName,
+			ComponentSelector:  clusterCompSpecName,
+			NodePortAllocation: exposeService.NodePortAllocation,
 		}
 
 		// set service selector
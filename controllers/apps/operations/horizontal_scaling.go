@@ -20,8 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"reflect"
 	"time"
 
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -65,6 +68,16 @@ func (hs horizontalScalingOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli
 			continue
 		}
 		r := horizontalScaling.Replicas
+		// only the first batch is added here, the others are added after the previous batch is completed.
+		// the batch is based on the replicas saved before the action, the action may be done again by the new leader
+		// if the operator leadership moved before the opsRequest is patched to Running.
+		lastReplicas := component.Replicas
+		if replicas := getComponentLastReplicas(opsRes.OpsRequest, component.Name); replicas != nil {
+			lastReplicas = *replicas
+		}
+		if batch := horizontalScaling.ScaleOutBatch; batch != nil && r > lastReplicas+batch.BatchSize {
+			r = lastReplicas + batch.BatchSize
+		}
 		opsRes.Cluster.Spec.ComponentSpecs[index].Replicas = r
 		opsRes.Cluster.Spec.ComponentSpecs[index].Instances = horizontalScaling.Instances
 		opsRes.Cluster.Spec.ComponentSpecs[index].Nodes = horizontalScaling.Nodes
@@ -83,7 +96,108 @@ func (hs horizontalScalingOpsHandler) ReconcileAction(reqCtx intctrlutil.Request
 		compStatus *appsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
 		return handleComponentProgressForScalingReplicas(reqCtx, cli, opsRes, pgRes, compStatus, hs.getExpectReplicas)
 	}
-	return reconcileActionWithComponentOps(reqCtx, cli, opsRes, "", handleComponentProgress)
+	opsPhase, requeueAfter, err := reconcileActionWithComponentOps(reqCtx, cli, opsRes, "", handleComponentProgress)
+	if err != nil || opsPhase != appsv1alpha1.OpsRunningPhase || opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+		return opsPhase, requeueAfter, err
+	}
+	batchRequeueAfter, err := hs.reconcileScaleOutBatches(reqCtx, cli, opsRes)
+	if err != nil {
+		return opsPhase, 0, err
+	}
+	if batchRequeueAfter > 0 && (requeueAfter == 0 || batchRequeueAfter < requeueAfter) {
+		requeueAfter = batchRequeueAfter
+	}
+	return opsPhase, requeueAfter, nil
+}
+
+// reconcileScaleOutBatches adds the next batch of the replicas for the components scaled out in batches,
+// once the current batch is completed and the pause seconds have elapsed.
+func (hs horizontalScalingOpsHandler) reconcileScaleOutBatches(reqCtx intctrlutil.RequestCtx,
+	cli client.Client, opsRes *OpsResource) (time.Duration, error) {
+	var (
+		opsRequest     = opsRes.OpsRequest
+		cluster        = opsRes.Cluster
+		patch          = client.MergeFrom(opsRequest.DeepCopy())
+		oldStatus      = opsRequest.Status.DeepCopy()
+		requeueAfter   time.Duration
+		clusterChanged bool
+	)
+	for _, hScaling := range opsRequest.Spec.HorizontalScalingList {
+		batch := hScaling.ScaleOutBatch
+		lastReplicas := getComponentLastReplicas(opsRequest, hScaling.ComponentName)
+		if batch == nil || lastReplicas == nil || hScaling.Replicas <= *lastReplicas {
+			continue
+		}
+		index := slices.IndexFunc(cluster.Spec.ComponentSpecs, func(spec appsv1alpha1.ClusterComponentSpec) bool {
+			return spec.Name == hScaling.ComponentName
+		})
+		if index < 0 {
+			continue
+		}
+		compSpec := &cluster.Spec.ComponentSpecs[index]
+		compStatus := opsRequest.Status.Components[hScaling.ComponentName]
+		batchStatus := compStatus.ScaleOutBatch
+		if batchStatus == nil {
+			batchStatus = &appsv1alpha1.ScaleOutBatchStatus{
+				CurrentBatch:  1,
+				TotalBatches:  (hScaling.Replicas - *lastReplicas + batch.BatchSize - 1) / batch.BatchSize,
+				BatchReplicas: compSpec.Replicas,
+			}
+			compStatus.ScaleOutBatch = batchStatus
+		}
+		if compSpec.Replicas < hScaling.Replicas && hs.isScaleOutBatchCompleted(opsRes, hScaling.ComponentName, compStatus, compSpec.Replicas-*lastReplicas) {
+			if batchStatus.CompletionTimestamp == nil {
+				now := metav1.Now()
+				batchStatus.CompletionTimestamp = &now
+			}
+			pause := time.Duration(batch.PauseSeconds)*time.Second - time.Since(batchStatus.CompletionTimestamp.Time)
+			if pause > 0 {
+				if requeueAfter == 0 || pause < requeueAfter {
+					requeueAfter = pause
+				}
+			} else {
+				replicas := compSpec.Replicas + batch.BatchSize
+				if replicas > hScaling.Replicas {
+					replicas = hScaling.Replicas
+				}
+				compSpec.Replicas = replicas
+				clusterChanged = true
+				batchStatus.CurrentBatch += 1
+				batchStatus.BatchReplicas = replicas
+				batchStatus.CompletionTimestamp = nil
+				opsRes.Recorder.Eventf(opsRequest, corev1.EventTypeNormal, reasonScaleOutBatch,
+					"Start to scale out batch %d/%d of Component: %s, replicas: %d",
+					batchStatus.CurrentBatch, batchStatus.TotalBatches, hScaling.ComponentName, replicas)
+			}
+		}
+		opsRequest.Status.Components[hScaling.ComponentName] = compStatus
+	}
+	if clusterChanged {
+		if err := cli.Update(reqCtx.Ctx, cluster); err != nil {
+			return 0, err
+		}
+	}
+	if !reflect.DeepEqual(*oldStatus, opsRequest.Status) {
+		if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+			return 0, err
+		}
+	}
+	return requeueAfter, nil
+}
+
+// isScaleOutBatchCompleted checks whether all replicas added so far are available and the component is running.
+func (hs horizontalScalingOpsHandler) isScaleOutBatchCompleted(opsRes *OpsResource, compName string,
+	compStatus appsv1alpha1.OpsRequestComponentStatus, addedReplicas int32) bool {
+	if opsRes.Cluster.Status.Components[compName].Phase != appsv1alpha1.RunningClusterCompPhase {
+		return false
+	}
+	var succeedCount int32
+	for _, v := range compStatus.ProgressDetails {
+		if v.Status == appsv1alpha1.SucceedProgressStatus {
+			succeedCount += 1
+		}
+	}
+	return succeedCount >= addedReplicas
 }
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
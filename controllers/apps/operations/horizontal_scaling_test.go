@@ -30,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -62,6 +63,7 @@ var _ = Describe("HorizontalScaling OpsRequest", func() {
 		ml := client.HasLabels{testCtx.TestObjLabelKey}
 		// namespaced
 		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.RSMSignature, inNS, ml)
 		// default GracePeriod is 30s
 		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
 	}
@@ -152,6 +154,150 @@ var _ = Describe("HorizontalScaling OpsRequest", func() {
 			checkOpsRequestPhaseIsSucceed(reqCtx, opsRes)
 		})
 
+		It("test scaling out replicas in batches", func() {
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			By("init operations resources with CLusterDefinition/ClusterVersion/Hybrid components Cluster/consensus Pods")
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			_ = initConsensusPods(ctx, k8sClient, opsRes, clusterName)
+
+			By("create opsRequest for horizontal scaling of consensus component from 3 to 6 in batches of 2 replicas")
+			initClusterAnnotationAndPhaseForOps(opsRes)
+			ops := testapps.NewOpsRequestObj("horizontal-scaling-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.HorizontalScalingType)
+			ops.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{
+				{
+					ComponentOps:  appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					Replicas:      6,
+					ScaleOutBatch: &appsv1alpha1.ScaleOutBatchPolicy{BatchSize: 2},
+				},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			mockComponentIsOperating(opsRes.Cluster, appsv1alpha1.UpdatingClusterCompPhase, consensusComp)
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+
+			By("expect for the replicas of consensus component is 5 after the first batch begins")
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, tmpCluster *appsv1alpha1.Cluster) {
+				g.Expect(tmpCluster.Spec.GetComponentByName(consensusComp).Replicas).Should(BeEquivalentTo(5))
+			})).Should(Succeed())
+
+			By("mock the operator leadership moved before the opsRequest is Running, the new leader resumes from the persisted state")
+			takeoverOpsRes := &OpsResource{
+				OpsRequest: &appsv1alpha1.OpsRequest{},
+				Cluster:    &appsv1alpha1.Cluster{},
+				Recorder:   opsRes.Recorder,
+			}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), takeoverOpsRes.OpsRequest)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), takeoverOpsRes.Cluster)).Should(Succeed())
+			Expect(takeoverOpsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, takeoverOpsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, tmpCluster *appsv1alpha1.Cluster) {
+				g.Expect(tmpCluster.Spec.GetComponentByName(consensusComp).Replicas).Should(BeEquivalentTo(5))
+			})).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), opsRes.Cluster)).Should(Succeed())
+
+			By("mock the pods of the first batch are created")
+			for i := 3; i < 5; i++ {
+				podName := fmt.Sprintf("%s-%s-%d", clusterName, consensusComp, i)
+				testapps.MockConsensusComponentStsPod(&testCtx, nil, clusterName, consensusComp, podName, "follower", "Readonly")
+			}
+
+			By("expect for the second batch begins after the first batch is completed")
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			mockConsensusCompToRunning(opsRes)
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, tmpCluster *appsv1alpha1.Cluster) {
+				g.Expect(tmpCluster.Spec.GetComponentByName(consensusComp).Replicas).Should(BeEquivalentTo(6))
+			})).Should(Succeed())
+			batchStatus := opsRes.OpsRequest.Status.Components[consensusComp].ScaleOutBatch
+			Expect(batchStatus).ShouldNot(BeNil())
+			Expect(batchStatus.CurrentBatch).Should(BeEquivalentTo(2))
+			Expect(batchStatus.TotalBatches).Should(BeEquivalentTo(2))
+		})
+
+		It("test scaling out replicas with the memberJoin action", func() {
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _ := commonHScaleConsensusCompTest(reqCtx, 5)
+			By("mock the rsm of the component with the memberJoin action")
+			rsm := testapps.MockRSMComponent(&testCtx, clusterName, consensusComp)
+			Expect(testapps.ChangeObj(&testCtx, rsm, func(obj *workloads.ReplicatedStateMachine) {
+				obj.Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{
+					MemberJoinAction: &workloads.Action{Command: []string{"join"}},
+				}
+			})).Should(Succeed())
+
+			By("mock two pods are created")
+			var newPodNames []string
+			for i := 3; i < 5; i++ {
+				podName := constant.GeneratePodName(clusterName, consensusComp, i)
+				testapps.MockConsensusComponentStsPod(&testCtx, nil, clusterName, consensusComp, podName, "follower", "Readonly")
+				newPodNames = append(newPodNames, podName)
+			}
+
+			By("expect for opsRequest phase is Running before the new members join")
+			mockConsensusCompToRunning(opsRes)
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).ShouldNot(Equal(appsv1alpha1.OpsSucceedPhase))
+
+			By("mock the new members have joined")
+			Expect(testapps.ChangeObjStatus(&testCtx, rsm, func() {
+				for _, podName := range newPodNames {
+					rsm.Status.MembersStatus = append(rsm.Status.MembersStatus, workloads.MemberStatus{
+						PodName:     podName,
+						ReplicaRole: workloads.ReplicaRole{Name: "follower", AccessMode: workloads.ReadonlyMode},
+					})
+				}
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(rsm), func(g Gomega, obj *workloads.ReplicatedStateMachine) {
+				g.Expect(obj.Status.MembersStatus).Should(HaveLen(len(newPodNames)))
+			})).Should(Succeed())
+			checkOpsRequestPhaseIsSucceed(reqCtx, opsRes)
+		})
+
+		It("test scaling down replicas with the memberLeave action", func() {
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			By("mock the rsm of the component with the memberLeave action")
+			rsm := testapps.MockRSMComponent(&testCtx, clusterName, consensusComp)
+			Expect(testapps.ChangeObj(&testCtx, rsm, func(obj *workloads.ReplicatedStateMachine) {
+				obj.Spec.MembershipReconfiguration = &workloads.MembershipReconfiguration{
+					MemberLeaveAction: &workloads.Action{Command: []string{"leave"}},
+				}
+			})).Should(Succeed())
+			opsRes, podList := commonHScaleConsensusCompTest(reqCtx, 1)
+
+			By("expect for the departing pods are being decommissioned")
+			mockConsensusCompToRunning(opsRes)
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).ShouldNot(Equal(appsv1alpha1.OpsSucceedPhase))
+			progressDetails := opsRes.OpsRequest.Status.Components[consensusComp].ProgressDetails
+			Expect(progressDetails).Should(HaveLen(2))
+			for _, progressDetail := range progressDetails {
+				Expect(progressDetail.Status).Should(Equal(appsv1alpha1.ProcessingProgressStatus))
+				Expect(progressDetail.Message).Should(ContainSubstring("decommission"))
+			}
+
+			By("mock the departing pods are deleted")
+			for i := range podList {
+				pod := &podList[i]
+				if pod.Name == fmt.Sprintf("%s-%s-0", clusterName, consensusComp) {
+					continue
+				}
+				pod.Kind = constant.PodKind
+				testk8s.MockPodIsTerminating(ctx, testCtx, pod)
+				testk8s.RemovePodFinalizer(ctx, testCtx, pod)
+			}
+			checkOpsRequestPhaseIsSucceed(reqCtx, opsRes)
+		})
+
 		It("test canceling HScale opsRequest which scales down replicas of component", func() {
 			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
 			opsRes, podList := commonHScaleConsensusCompTest(reqCtx, 1)
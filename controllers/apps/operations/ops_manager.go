@@ -23,6 +23,7 @@ import (
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -73,13 +74,29 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		if opsRequest.Spec.Cancel {
 			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase)
 		}
+		// hold the OpsRequest in the Pending phase until the scheduled time.
+		if opsRequest.IsBeforeScheduledTime() {
+			return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Until(opsRequest.Spec.ScheduledAt.Time), reqCtx.Log, ""))
+		}
 		// validate entry condition for OpsRequest, check if the cluster is in the right phase
-		if err = validateOpsWaitingPhase(opsRes.Cluster, opsRequest, opsBehaviour); err != nil {
-			// check if the error is caused by WaitForClusterPhaseErr  error
-			if _, ok := err.(*WaitForClusterPhaseErr); ok {
-				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, ""))
+		// and the preconditions are met, unless the OpsRequest is forced to execute.
+		if !opsRequest.Spec.Force {
+			if err = validateOpsWaitingPhase(opsRes.Cluster, opsRequest, opsBehaviour); err != nil {
+				// check if the error is caused by WaitForClusterPhaseErr  error
+				if _, ok := err.(*WaitForClusterPhaseErr); ok {
+					return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, ""))
+				}
+				return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+			}
+			if err = validateOpsPreconditions(reqCtx.Ctx, cli, opsRes); err != nil {
+				if _, ok := err.(*PreconditionNotMetErr); !ok {
+					return nil, err
+				}
+				if isWithinWaitingTime(opsRequest) {
+					return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, ""))
+				}
+				return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 			}
-			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 		}
 		if opsBehaviour.ToClusterPhase != "" {
 			// if ToClusterPhase is not empty, enqueue OpsRequest to the cluster Annotation.
@@ -92,10 +109,16 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 			// only one operation can be running at a time if these operations are mutually exclusive(exist opsBehaviour.ToClusterPhase).
 			// other opsRequest should be reconciled.
 			if len(opsRecordeSlice) > 0 && opsRecordeSlice[0].Name != opsRequest.Name {
-				return intctrlutil.ResultToP(intctrlutil.Reconciled())
+				return &ctrl.Result{}, patchOpsQueueStatus(reqCtx.Ctx, cli, opsRes, opsRecordeSlice)
 			}
 		}
+		if opsRequest.Spec.Force {
+			opsRes.Recorder.Eventf(opsRequest, corev1.EventTypeWarning, reasonOpsForceExecution,
+				"OpsRequest %s is forced to execute, skipped checking the cluster phase and the preconditions", opsRequest.Name)
+		}
 		opsDeepCopy := opsRequest.DeepCopy()
+		// the opsRequest is no longer blocked by others.
+		opsRequest.Status.QueueStatus = nil
 		// save last configuration into status.lastConfiguration
 		if err = opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, cli, opsRes); err != nil {
 			return nil, err
@@ -112,6 +135,9 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeNeedWaiting) {
 			return intctrlutil.ResultToP(intctrlutil.Reconciled())
 		}
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRequeue) {
+			return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, err.Error()))
+		}
 		return nil, err
 	}
 	return nil, nil
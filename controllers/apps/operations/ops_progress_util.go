@@ -34,6 +34,7 @@ import (
 	"github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	rsmcore "github.com/apecloud/kubeblocks/pkg/controller/rsm"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -278,6 +279,10 @@ func handleProgressForPodsRollingUpdate(
 	for _, v := range podList.Items {
 		objectKey := getProgressObjectKey(v.Kind, v.Name)
 		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+		if podIsResizedInPlaceDuringOperation(opsStartTime, &v) {
+			completedCount += handleInPlaceResizeProgressDetail(opsRes, pgRes, compStatus, progressDetail, &v, minReadySeconds)
+			continue
+		}
 		if podProcessedSuccessful(workloadType, opsStartTime, &v, minReadySeconds, compStatus.Phase, pgRes.opsIsCompleted) {
 			completedCount += 1
 			handleSucceedProgressDetail(opsRes, pgRes, compStatus, progressDetail)
@@ -390,6 +395,34 @@ func handleFailedOrProcessingProgressDetail(opsRes *OpsResource,
 	return completedCount
 }
 
+// handleInPlaceResizeProgressDetail handles the progressDetail of the pod whose resources are resized in place.
+func handleInPlaceResizeProgressDetail(opsRes *OpsResource,
+	pgRes progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	progressDetail appsv1alpha1.ProgressStatusDetail,
+	pod *corev1.Pod,
+	minReadySeconds int32) int32 {
+	pgRes.opsMessageKey = fmt.Sprintf("%s in place", pgRes.opsMessageKey)
+	if len(pod.Status.Resize) == 0 && podIsAvailable(pgRes.clusterComponentDef.WorkloadType, pod, minReadySeconds) {
+		handleSucceedProgressDetail(opsRes, pgRes, compStatus, progressDetail)
+		return 1
+	}
+	return handleFailedOrProcessingProgressDetail(opsRes, pgRes, compStatus, progressDetail, pod)
+}
+
+// podIsResizedInPlaceDuringOperation checks if the resources of the pod are resized in place during the operation.
+func podIsResizedInPlaceDuringOperation(opsStartTime metav1.Time, pod *corev1.Pod) bool {
+	resizedAt, ok := pod.Annotations[constant.PodResizedInPlaceAnnotationKey]
+	if !ok || !pod.DeletionTimestamp.IsZero() {
+		return false
+	}
+	resizedTime, err := time.Parse(time.RFC3339, resizedAt)
+	if err != nil {
+		return false
+	}
+	return !resizedTime.Before(opsStartTime.Time.Truncate(time.Second))
+}
+
 // podIsPendingDuringOperation checks if pod is pending during the component's operation.
 func podIsPendingDuringOperation(opsStartTime metav1.Time, pod *corev1.Pod) bool {
 	return pod.CreationTimestamp.Before(&opsStartTime) && pod.DeletionTimestamp.IsZero()
@@ -509,31 +542,40 @@ func handleComponentProgressForScalingReplicas(reqCtx intctrlutil.RequestCtx,
 		expectProgressCount = dValue * -1
 	}
 	if isScaleOut {
-		completedCount, err = handleScaleOutProgress(reqCtx, cli, opsRes, pgRes, podList, compStatus)
+		completedCount, err = handleScaleOutProgress(reqCtx, cli, opsRes, pgRes, podList, compStatus, *lastComponentReplicas, *expectReplicas)
 		// if the workload type is Stateless, remove the progressDetails of the expired pods.
 		// because ReplicaSet may attempt to create a pod multiple times till it succeeds when scale out the replicas.
 		if pgRes.clusterComponentDef.WorkloadType == appsv1alpha1.Stateless {
 			compStatus.ProgressDetails = removeStatelessExpiredPods(podList, compStatus.ProgressDetails)
 		}
 	} else {
-		completedCount, err = handleScaleDownProgress(reqCtx, cli, opsRes, pgRes, podList, compStatus)
+		completedCount, err = handleScaleDownProgress(reqCtx, cli, opsRes, pgRes, podList, compStatus, *lastComponentReplicas, *expectReplicas)
 	}
 	return getFinalExpectCount(compStatus, expectProgressCount), completedCount, err
 }
 
 // handleScaleOutProgress handles the progressDetails of scaled out replicas.
+// If the data of the new replicas is cloned according to the horizontalScalePolicy,
+// the replicas are pending until their data are provisioned. And if the component
+// defines the memberJoin action, the replicas are counted as completed only after
+// they have joined the membership.
 func handleScaleOutProgress(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
 	pgRes progressResource,
 	podList *corev1.PodList,
-	compStatus *appsv1alpha1.OpsRequestComponentStatus) (int32, error) {
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	lastReplicas, expectReplicas int32) (int32, error) {
 	var componentName = pgRes.clusterComponent.Name
 	var workloadType = pgRes.clusterComponentDef.WorkloadType
 	minReadySeconds, err := intctrlcomp.GetComponentWorkloadMinReadySeconds(reqCtx.Ctx, cli, *opsRes.Cluster, workloadType, componentName)
 	if err != nil {
 		return 0, err
 	}
+	rsm, err := getComponentRSM(reqCtx, cli, opsRes.Cluster, componentName)
+	if err != nil {
+		return 0, err
+	}
 	var completedCount int32
 	for _, v := range podList.Items {
 		// only focus on the newly created pod when scaling out the replicas.
@@ -543,30 +585,115 @@ func handleScaleOutProgress(reqCtx intctrlutil.RequestCtx,
 		objectKey := getProgressObjectKey(v.Kind, v.Name)
 		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
 		pgRes.opsMessageKey = "create"
-		if podIsAvailable(workloadType, &v, minReadySeconds) {
+		if podIsAvailable(workloadType, &v, minReadySeconds) && memberHasJoined(rsm, v.Name) {
 			completedCount += 1
 			handleSucceedProgressDetail(opsRes, pgRes, compStatus, progressDetail)
 			continue
 		}
 		completedCount += handleFailedOrProcessingProgressDetail(opsRes, pgRes, compStatus, progressDetail, &v)
 	}
+	if rsm != nil {
+		handleDataProvisioningProgress(opsRes, pgRes, podList, compStatus, rsm.Name, lastReplicas, expectReplicas)
+	}
 	return completedCount, nil
 }
 
+// handleDataProvisioningProgress sets the pending progressDetails for the new replicas
+// whose pods are not created yet since their data are being provisioned.
+func handleDataProvisioningProgress(opsRes *OpsResource,
+	pgRes progressResource,
+	podList *corev1.PodList,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	rsmName string,
+	lastReplicas, expectReplicas int32) {
+	hScalePolicy := pgRes.clusterComponentDef.HorizontalScalePolicy
+	if hScalePolicy == nil || hScalePolicy.Type == appsv1alpha1.HScaleDataClonePolicyNone {
+		return
+	}
+	podKind := constant.PodKind
+	if len(podList.Items) > 0 {
+		podKind = podList.Items[0].Kind
+	}
+	for i := lastReplicas; i < expectReplicas; i++ {
+		podName := rsmcore.GetPodName(rsmName, int(i))
+		if slices.IndexFunc(podList.Items, func(pod corev1.Pod) bool { return pod.Name == podName }) >= 0 {
+			continue
+		}
+		objectKey := getProgressObjectKey(podKind, podName)
+		if progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey); progressDetail != nil &&
+			progressDetail.Status == appsv1alpha1.PendingProgressStatus {
+			continue
+		}
+		handlePendingProgressDetail(opsRes, compStatus, appsv1alpha1.ProgressStatusDetail{
+			ObjectKey: objectKey,
+			Message: fmt.Sprintf("Waiting for the data of %s to be provisioned in Component: %s",
+				objectKey, pgRes.clusterComponent.Name),
+		})
+	}
+}
+
+// getComponentRSM gets the rsm of the component, returns nil if it does not exist.
+// The rsm is looked up by the component labels, since its name may follow the naming template of the ClusterDefinition.
+func getComponentRSM(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	cluster *appsv1alpha1.Cluster, componentName string) (*v1alpha1.ReplicatedStateMachine, error) {
+	rsmList := &v1alpha1.ReplicatedStateMachineList{}
+	if err := intctrlcomp.GetObjectListByComponentName(reqCtx.Ctx, cli, *cluster, rsmList, componentName); err != nil {
+		return nil, err
+	}
+	if len(rsmList.Items) == 0 {
+		return nil, nil
+	}
+	return &rsmList.Items[0], nil
+}
+
+// memberHasJoined checks whether the pod has joined the membership of the rsm.
+// It always returns true if the rsm does not define the memberJoin action.
+func memberHasJoined(rsm *v1alpha1.ReplicatedStateMachine, podName string) bool {
+	if rsm == nil || rsm.Spec.MembershipReconfiguration == nil ||
+		rsm.Spec.MembershipReconfiguration.MemberJoinAction == nil {
+		return true
+	}
+	return slices.IndexFunc(rsm.Status.MembersStatus, func(status v1alpha1.MemberStatus) bool {
+		return status.PodName == podName
+	}) >= 0
+}
+
 // handleScaleDownProgress handles the progressDetails of scaled down replicas.
+// If the component defines the memberLeave action, the departing replicas are
+// decommissioned, i.e. leaving the membership, before they are deleted.
 func handleScaleDownProgress(
 	reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
 	pgRes progressResource,
 	podList *corev1.PodList,
-	compStatus *appsv1alpha1.OpsRequestComponentStatus) (completedCount int32, err error) {
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	lastReplicas, expectReplicas int32) (completedCount int32, err error) {
 	podMap := map[string]corev1.Pod{}
-	// record the deleting pod progressDetail
+	departingPods := map[string]struct{}{}
+	rsm, err := getComponentRSM(reqCtx, cli, opsRes.Cluster, pgRes.clusterComponent.Name)
+	if err != nil {
+		return 0, err
+	}
+	if rsm != nil && rsm.Spec.MembershipReconfiguration != nil &&
+		rsm.Spec.MembershipReconfiguration.MemberLeaveAction != nil {
+		for i := expectReplicas; i < lastReplicas; i++ {
+			departingPods[getProgressObjectKey(constant.PodKind, rsmcore.GetPodName(rsm.Name, int(i)))] = struct{}{}
+		}
+	}
+	// record the decommissioning and deleting pod progressDetail
 	for _, v := range podList.Items {
 		objectKey := getProgressObjectKey(constant.PodKind, v.Name)
 		podMap[objectKey] = v
 		if v.DeletionTimestamp.IsZero() {
+			if _, ok := departingPods[objectKey]; ok {
+				setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest,
+					&compStatus.ProgressDetails, appsv1alpha1.ProgressStatusDetail{
+						ObjectKey: objectKey,
+						Status:    appsv1alpha1.ProcessingProgressStatus,
+						Message:   fmt.Sprintf("Start to decommission pod: %s in Component: %s", objectKey, pgRes.clusterComponent.Name),
+					})
+			}
 			continue
 		}
 		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest,
@@ -607,6 +734,10 @@ func handleScaleDownProgress(
 				handleDeletionSuccessful(progressDetail.ObjectKey)
 				continue
 			}
+			// the departing pod is being decommissioned.
+			if _, ok := departingPods[progressDetail.ObjectKey]; ok && pod.DeletionTimestamp.IsZero() {
+				continue
+			}
 			// handle the re-created pods if these pods are failed before doing horizontal scaling.
 			pgRes.opsMessageKey = "re-create"
 			if podIsAvailable(workloadType, &pod, minReadySeconds) {
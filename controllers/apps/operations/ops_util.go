@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"golang.org/x/exp/slices"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,8 +37,11 @@ import (
 	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/audit"
 	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/notification"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 )
 
 const (
@@ -45,6 +49,15 @@ const (
 	componentFailedTimeout = 30 * time.Second
 
 	opsRequestQueueLimitSize = 10
+
+	// reasonOpsForceExecution the event reason indicates that the OpsRequest is forced to execute.
+	reasonOpsForceExecution = "ForceExecution"
+
+	// reasonScaleOutBatch the event reason indicates that a new batch of the replicas is being added.
+	reasonScaleOutBatch = "ScaleOutBatch"
+
+	// terminationLogMaxBytes the max bytes of the output kept in the termination message of the job container.
+	terminationLogMaxBytes = 4096
 )
 
 var _ error = &WaitForClusterPhaseErr{}
@@ -59,6 +72,17 @@ func (e *WaitForClusterPhaseErr) Error() string {
 	return fmt.Sprintf("wait for cluster %s to reach phase %v, current status is :%s", e.clusterName, e.expectedPhase, e.currentPhase)
 }
 
+var _ error = &PreconditionNotMetErr{}
+
+// PreconditionNotMetErr indicates that spec.preconditions of the OpsRequest are not met.
+type PreconditionNotMetErr struct {
+	message string
+}
+
+func (e *PreconditionNotMetErr) Error() string {
+	return fmt.Sprintf("the preconditions of the OpsRequest are not met: %s", e.message)
+}
+
 type handleStatusProgressWithComponent func(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
@@ -234,6 +258,10 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	opsRequest.Status.Phase = phase
 	if opsRequest.IsComplete(phase) {
 		opsRequest.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
+		if !opsRequest.Status.StartTimestamp.IsZero() {
+			metrics.OpsRequestDurationSeconds.WithLabelValues(opsRequest.Namespace, opsRequest.Spec.ClusterRef,
+				string(opsRequest.Spec.Type), string(phase)).Observe(time.Since(opsRequest.Status.StartTimestamp.Time).Seconds())
+		}
 		// when OpsRequest is completed, remove it from annotation
 		if err := DequeueOpsRequestInClusterAnnotation(ctx, cli, opsRes); err != nil {
 			return err
@@ -242,7 +270,56 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	if phase == appsv1alpha1.OpsCreatingPhase && opsRequest.Status.StartTimestamp.IsZero() {
 		opsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now()}
 	}
-	return cli.Status().Patch(ctx, opsRequest, patch)
+	if err := cli.Status().Patch(ctx, opsRequest, patch); err != nil {
+		return err
+	}
+	if opsRequestDeepCopy.Status.Phase != phase {
+		recordOpsRequestAudit(opsRes, phase)
+		notifyOpsRequestCompleted(opsRes, phase)
+	}
+	return nil
+}
+
+// notifyOpsRequestCompleted sends the notification when the OpsRequest completes.
+func notifyOpsRequestCompleted(opsRes *OpsResource, phase appsv1alpha1.OpsPhase) {
+	switch phase {
+	case appsv1alpha1.OpsSucceedPhase, appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+	default:
+		return
+	}
+	opsRequest := opsRes.OpsRequest
+	notification.NotifyCluster(opsRes.Cluster, opsRequest, notification.EventOpsCompleted,
+		fmt.Sprintf("%s OpsRequest %s completes with phase %s", opsRequest.Spec.Type, opsRequest.Name, phase))
+}
+
+// recordOpsRequestAudit records the audit entry when the OpsRequest starts to execute or completes.
+func recordOpsRequestAudit(opsRes *OpsResource, phase appsv1alpha1.OpsPhase) {
+	var result string
+	switch phase {
+	case appsv1alpha1.OpsCreatingPhase:
+		result = audit.ResultStarted
+	case appsv1alpha1.OpsSucceedPhase:
+		result = audit.ResultSucceeded
+	case appsv1alpha1.OpsFailedPhase:
+		result = audit.ResultFailed
+	case appsv1alpha1.OpsCancelledPhase:
+		result = audit.ResultCancelled
+	default:
+		return
+	}
+	opsRequest := opsRes.OpsRequest
+	actor := opsRequest.Annotations[constant.RequestedByAnnotationKey]
+	if len(actor) == 0 {
+		actor = "unknown"
+	}
+	audit.Record(opsRes.Recorder, opsRequest, audit.Event{
+		Actor:   actor,
+		Action:  string(opsRequest.Spec.Type),
+		Kind:    "OpsRequest",
+		Target:  opsRequest.Spec.ClusterRef,
+		Result:  result,
+		Message: fmt.Sprintf("phase: %s", phase),
+	})
 }
 
 // PatchOpsStatus patches OpsRequest.status
@@ -400,6 +477,28 @@ func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client
 	return opsRequestSlice, opsutil.UpdateClusterOpsAnnotations(ctx, cli, opsRes.Cluster, opsRequestSlice)
 }
 
+// patchOpsQueueStatus records the position and the blocking reason to status.queueStatus
+// when the OpsRequest is pending in the queue of the cluster.
+func patchOpsQueueStatus(ctx context.Context, cli client.Client, opsRes *OpsResource, opsRequestSlice []appsv1alpha1.OpsRecorder) error {
+	index, _ := GetOpsRecorderFromSlice(opsRequestSlice, opsRes.OpsRequest.Name)
+	if index <= 0 {
+		return nil
+	}
+	head := opsRequestSlice[0]
+	queueStatus := &appsv1alpha1.OpsQueueStatus{
+		Position:  int32(index + 1),
+		BlockedBy: head.Name,
+		Message: fmt.Sprintf(`Waiting for the %s OpsRequest "%s" to complete, they are mutually exclusive on the cluster`,
+			head.Type, head.Name),
+	}
+	if reflect.DeepEqual(opsRes.OpsRequest.Status.QueueStatus, queueStatus) {
+		return nil
+	}
+	patch := client.MergeFrom(opsRes.OpsRequest.DeepCopy())
+	opsRes.OpsRequest.Status.QueueStatus = queueStatus
+	return cli.Status().Patch(ctx, opsRes.OpsRequest, patch)
+}
+
 // isOpsRequestFailedPhase checks the OpsRequest phase is Failed
 func isOpsRequestFailedPhase(opsRequestPhase appsv1alpha1.OpsPhase) bool {
 	return opsRequestPhase == appsv1alpha1.OpsFailedPhase
@@ -475,7 +574,7 @@ func validateOpsWaitingPhase(cluster *appsv1alpha1.Cluster, ops *appsv1alpha1.Op
 	}
 	// check if entry-condition is met
 	// if the cluster is not in the expected phase, we should wait for it for up to TTLSecondsBeforeAbort seconds.
-	if ops.Spec.TTLSecondsBeforeAbort == nil || (time.Now().After(ops.GetCreationTimestamp().Add(time.Duration(*ops.Spec.TTLSecondsBeforeAbort) * time.Second))) {
+	if !isWithinWaitingTime(ops) {
 		return nil
 	}
 
@@ -485,3 +584,95 @@ func validateOpsWaitingPhase(cluster *appsv1alpha1.Cluster, ops *appsv1alpha1.Op
 		expectedPhase: opsBehaviour.FromClusterPhases,
 	}
 }
+
+// isWithinWaitingTime checks whether the OpsRequest can still wait for the entry-conditions to be met,
+// the waiting lasts TTLSecondsBeforeAbort seconds and starts from the scheduled time if it is specified.
+func isWithinWaitingTime(ops *appsv1alpha1.OpsRequest) bool {
+	if ops.Spec.TTLSecondsBeforeAbort == nil {
+		return false
+	}
+	waitingStartTime := ops.GetCreationTimestamp().Time
+	if ops.Spec.ScheduledAt != nil && ops.Spec.ScheduledAt.After(waitingStartTime) {
+		waitingStartTime = ops.Spec.ScheduledAt.Time
+	}
+	return !time.Now().After(waitingStartTime.Add(time.Duration(*ops.Spec.TTLSecondsBeforeAbort) * time.Second))
+}
+
+// validateOpsPreconditions validates whether spec.preconditions of the OpsRequest are met.
+func validateOpsPreconditions(ctx context.Context, cli client.Client, opsRes *OpsResource) error {
+	var (
+		ops           = opsRes.OpsRequest
+		cluster       = opsRes.Cluster
+		preconditions = ops.Spec.Preconditions
+	)
+	if preconditions == nil {
+		return nil
+	}
+	if preconditions.RequireClusterRunning && cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+		return &PreconditionNotMetErr{message: fmt.Sprintf("the cluster %s is %s rather than Running", cluster.Name, cluster.Status.Phase)}
+	}
+	if preconditions.RequireNoOpsInProgress {
+		opsList := &appsv1alpha1.OpsRequestList{}
+		if err := cli.List(ctx, opsList, client.InNamespace(ops.Namespace),
+			client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}); err != nil {
+			return err
+		}
+		for _, v := range opsList.Items {
+			if v.Name != ops.Name && slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsCreatingPhase,
+				appsv1alpha1.OpsRunningPhase, appsv1alpha1.OpsCancellingPhase}, v.Status.Phase) {
+				return &PreconditionNotMetErr{message: fmt.Sprintf(`the OpsRequest "%s" of the cluster is in progress`, v.Name)}
+			}
+		}
+	}
+	if preconditions.MinAvailableReplicas != nil {
+		compNameSet := ops.GetComponentNameSet()
+		for _, comp := range cluster.Spec.ComponentSpecs {
+			if len(compNameSet) > 0 {
+				if _, ok := compNameSet[comp.Name]; !ok {
+					continue
+				}
+			}
+			podList, err := intctrlcomp.GetComponentPodList(ctx, cli, *cluster, comp.Name)
+			if err != nil {
+				return err
+			}
+			var availableReplicas int32
+			for i := range podList.Items {
+				if intctrlutil.IsAvailable(&podList.Items[i], 0) {
+					availableReplicas++
+				}
+			}
+			if availableReplicas < *preconditions.MinAvailableReplicas {
+				return &PreconditionNotMetErr{message: fmt.Sprintf("the component %s has %d available replicas, less than %d",
+					comp.Name, availableReplicas, *preconditions.MinAvailableReplicas)}
+			}
+		}
+	}
+	return nil
+}
+
+// captureOutputToTerminationLog redirects the output of the shell script to the termination message of the container,
+// so that it can be collected without reading the logs of the pod. The exit code of the script is saved in $rc.
+func captureOutputToTerminationLog(script, outputFile string) string {
+	// disable the tracing in the subshell to avoid the credentials being captured.
+	return fmt.Sprintf("rc=0; (set +x; %s) > %s 2>&1 || rc=$?; cat %[2]s; tail -c %[3]d %[2]s > /dev/termination-log",
+		script, outputFile, terminationLogMaxBytes)
+}
+
+// getJobTerminationMessage gets the termination message of the container from the pods of the job,
+// returns false if the container has not terminated yet.
+func getJobTerminationMessage(ctx context.Context, cli client.Client, job *batchv1.Job, containerName string) (string, bool, error) {
+	podList := &corev1.PodList{}
+	if err := cli.List(ctx, podList, client.InNamespace(job.Namespace),
+		client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return "", false, err
+	}
+	for _, pod := range podList.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == containerName && status.State.Terminated != nil {
+				return status.State.Terminated.Message, true, nil
+			}
+		}
+	}
+	return "", false, nil
+}
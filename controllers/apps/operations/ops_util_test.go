@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -107,6 +108,53 @@ var _ = Describe("OpsUtil functions", func() {
 
 		})
 
+		It("Test scheduled opsRequest", func() {
+			By("init operations resources ")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+
+			By("expect the opsRequest is kept in Pending phase before the scheduled time")
+			ops := testapps.NewOpsRequestObj("scheduled-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: consensusComp}}
+			ops.Spec.ScheduledAt = &metav1.Time{Time: time.Now().Add(time.Hour)}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			res, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res.RequeueAfter).Should(BeNumerically(">", 0))
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingPhase))
+		})
+
+		It("Test opsRequest preconditions", func() {
+			By("init operations resources ")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+
+			newRestartOps := func(force bool) *appsv1alpha1.OpsRequest {
+				ops := testapps.NewOpsRequestObj("restart-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+					clusterName, appsv1alpha1.RestartType)
+				ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: consensusComp}}
+				ops.Spec.Preconditions = &appsv1alpha1.OpsPreconditions{MinAvailableReplicas: pointer.Int32(1)}
+				ops.Spec.Force = force
+				opsRequest := testapps.CreateOpsRequest(ctx, testCtx, ops)
+				opsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+				return opsRequest
+			}
+
+			By("expect the opsRequest to be Failed when the component has no available replicas")
+			opsRes.OpsRequest = newRestartOps(false)
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+
+			By("expect the forced opsRequest to skip the preconditions")
+			opsRes.OpsRequest = newRestartOps(true)
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+		})
+
 		It("Test opsRequest Queue functions", func() {
 			By("init operations resources ")
 			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
@@ -135,6 +183,11 @@ var _ = Describe("OpsUtil functions", func() {
 			Expect(opsSlice[0].InQueue).Should(BeFalse())
 			Expect(opsSlice[1].InQueue).Should(BeTrue())
 
+			By("expect the position and blocking reason recorded in the pending opsRequest")
+			Expect(ops2.Status.QueueStatus).ShouldNot(BeNil())
+			Expect(ops2.Status.QueueStatus.Position).Should(BeEquivalentTo(2))
+			Expect(ops2.Status.QueueStatus.BlockedBy).Should(Equal(ops1.Name))
+
 			By("test enqueueOpsRequestToClusterAnnotation function with Reentry")
 			opsBehaviour := opsManager.OpsMap[ops2.Spec.Type]
 			opsSlice, _ = enqueueOpsRequestToClusterAnnotation(ctx, k8sClient, opsRes, opsBehaviour)
@@ -0,0 +1,568 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const accountObjectKind = "Account"
+
+// passwordRotationOpsHandler handles the PasswordRotation operation, it rotates the passwords of the system accounts
+// by the accountPasswordRotation action of the component, and replaces the account secrets with the new passwords.
+// If the grace period is specified, the old passwords are discarded once the grace period is over.
+// The pods are restarted once the old password of the account used by lorry is no longer accepted.
+type passwordRotationOpsHandler struct{}
+
+var _ OpsHandler = passwordRotationOpsHandler{}
+
+func init() {
+	// ToClusterPhase is not defined, because 'password rotation' does not affect the cluster status.
+	passwordRotationBehaviour := OpsBehaviour{
+		FromClusterPhases: []appsv1alpha1.ClusterPhase{appsv1alpha1.RunningClusterPhase},
+		OpsHandler:        passwordRotationOpsHandler{},
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.PasswordRotationType, passwordRotationBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the password rotation request.
+func (r passwordRotationOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewPasswordRotationCondition(opsRes.OpsRequest), nil
+}
+
+// Action rotates the passwords of the accounts and keeps the old ones if the grace period is specified.
+// The rotated accounts are recorded in the progress details, so they will not be rotated again when retrying.
+func (r passwordRotationOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = make(map[string]appsv1alpha1.OpsRequestComponentStatus)
+	}
+	err := r.rotateComponentsPasswords(reqCtx, cli, opsRes)
+	// persist the rotated accounts even if some of them fail.
+	if patchErr := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); patchErr != nil && err == nil {
+		err = patchErr
+	}
+	return err
+}
+
+func (r passwordRotationOpsHandler) rotateComponentsPasswords(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	for _, rotation := range opsRequest.Spec.PasswordRotationList {
+		compSpec := opsRes.Cluster.Spec.GetComponentByName(rotation.ComponentName)
+		if compSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf("component %s not found", rotation.ComponentName))
+		}
+		synthesizedComp, err := component.BuildSynthesizedComponentWrapper(reqCtx, cli, opsRes.Cluster, compSpec)
+		if err != nil {
+			return err
+		}
+		actions := synthesizedComp.LifecycleActions
+		if actions == nil || actions.AccountPasswordRotation == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf("the accountPasswordRotation action of component %s is not defined", rotation.ComponentName))
+		}
+		accounts, err := getRotationAccounts(synthesizedComp, rotation)
+		if err != nil {
+			return intctrlutil.NewFatalError(err.Error())
+		}
+
+		compStatus := opsRequest.Status.Components[rotation.ComponentName]
+		compStatus.Phase = appsv1alpha1.UpdatingClusterCompPhase
+		var lorryCli lorry.Client
+		for _, account := range accounts {
+			objectKey := getProgressObjectKey(accountObjectKind, account.Name)
+			if findStatusProgressDetail(compStatus.ProgressDetails, objectKey) != nil {
+				continue
+			}
+			if lorryCli == nil {
+				if lorryCli, err = buildPasswordRotationLorryClient(reqCtx, cli, opsRes.Cluster, synthesizedComp); err != nil {
+					break
+				}
+			}
+			if err = rotateAccountPassword(reqCtx, cli, opsRequest, opsRes.Cluster, synthesizedComp, lorryCli, account, rotation.GracePeriodSeconds > 0); err != nil {
+				break
+			}
+			// the old password is still accepted during the grace period, lorry is restarted once it's discarded.
+			if rotation.GracePeriodSeconds == 0 {
+				if err = restartLorryIfServiceAccount(reqCtx, cli, opsRequest, opsRes.Cluster, synthesizedComp, account.Name); err != nil {
+					break
+				}
+			}
+			progressDetail := appsv1alpha1.ProgressStatusDetail{
+				ObjectKey: objectKey,
+				Status:    appsv1alpha1.SucceedProgressStatus,
+				Message:   fmt.Sprintf("The password of account %s in component %s is rotated", account.Name, rotation.ComponentName),
+			}
+			if rotation.GracePeriodSeconds > 0 {
+				progressDetail.Status = appsv1alpha1.ProcessingProgressStatus
+				progressDetail.Message = fmt.Sprintf("The password of account %s in component %s is rotated, the old password will be discarded in %d seconds",
+					account.Name, rotation.ComponentName, rotation.GracePeriodSeconds)
+			}
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+		}
+		opsRequest.Status.Components[rotation.ComponentName] = compStatus
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReconcileAction discards the old passwords once the grace period is over, and succeeds when all accounts are done.
+func (r passwordRotationOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = make(map[string]appsv1alpha1.OpsRequestComponentStatus)
+	}
+	var (
+		expectCount    int
+		completedCount int
+		requeueAfter   time.Duration
+		err            error
+	)
+	for _, rotation := range opsRequest.Spec.PasswordRotationList {
+		compStatus := opsRequest.Status.Components[rotation.ComponentName]
+		gracePeriod := time.Duration(rotation.GracePeriodSeconds) * time.Second
+		var (
+			synthesizedComp *component.SynthesizedComponent
+			lorryCli        lorry.Client
+		)
+		compCompletedCount := 0
+		for i := range compStatus.ProgressDetails {
+			progressDetail := compStatus.ProgressDetails[i]
+			if isCompletedProgressStatus(progressDetail.Status) {
+				compCompletedCount++
+				continue
+			}
+			if remaining := time.Until(progressDetail.StartTime.Add(gracePeriod)); remaining > 0 {
+				if requeueAfter == 0 || remaining < requeueAfter {
+					requeueAfter = remaining
+				}
+				continue
+			}
+			if lorryCli == nil {
+				if synthesizedComp, err = r.buildSynthesizedComponent(reqCtx, cli, opsRes.Cluster, rotation.ComponentName); err != nil {
+					break
+				}
+				if lorryCli, err = buildPasswordRotationLorryClient(reqCtx, cli, opsRes.Cluster, synthesizedComp); err != nil {
+					break
+				}
+			}
+			accountName := progressDetail.ObjectKey[len(accountObjectKind)+1:]
+			if err = discardOldAccountPassword(reqCtx, cli, opsRes.Cluster, rotation.ComponentName, lorryCli, accountName); err != nil {
+				break
+			}
+			if err = restartLorryIfServiceAccount(reqCtx, cli, opsRequest, opsRes.Cluster, synthesizedComp, accountName); err != nil {
+				break
+			}
+			progressDetail.Status = appsv1alpha1.SucceedProgressStatus
+			progressDetail.Message = fmt.Sprintf("The old password of account %s in component %s is discarded", accountName, rotation.ComponentName)
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+			compCompletedCount++
+		}
+		expectCount += len(compStatus.ProgressDetails)
+		completedCount += compCompletedCount
+		if compCompletedCount == len(compStatus.ProgressDetails) {
+			compStatus.Phase = appsv1alpha1.RunningClusterCompPhase
+		}
+		opsRequest.Status.Components[rotation.ComponentName] = compStatus
+		if err != nil {
+			break
+		}
+	}
+	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedCount, expectCount)
+	if patchErr := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); patchErr != nil && err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return appsv1alpha1.OpsRunningPhase, time.Second, err
+	}
+	if completedCount == expectCount {
+		return appsv1alpha1.OpsSucceedPhase, 0, nil
+	}
+	return appsv1alpha1.OpsRunningPhase, requeueAfter, nil
+}
+
+// SaveLastConfiguration this operation does not change Cluster.spec, empty implementation here.
+func (r passwordRotationOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+func (r passwordRotationOpsHandler) buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	cluster *appsv1alpha1.Cluster, compName string) (*component.SynthesizedComponent, error) {
+	compSpec := cluster.Spec.GetComponentByName(compName)
+	if compSpec == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("component %s not found", compName))
+	}
+	return component.BuildSynthesizedComponentWrapper(reqCtx, cli, cluster, compSpec)
+}
+
+// getRotationAccounts gets the system accounts to rotate, all the system accounts are returned if none is specified.
+func getRotationAccounts(synthesizedComp *component.SynthesizedComponent, rotation appsv1alpha1.PasswordRotation) ([]appsv1alpha1.SystemAccount, error) {
+	if len(rotation.Accounts) == 0 {
+		return synthesizedComp.SystemAccounts, nil
+	}
+	accounts := make([]appsv1alpha1.SystemAccount, 0, len(rotation.Accounts))
+	for _, name := range rotation.Accounts {
+		index := slices.IndexFunc(synthesizedComp.SystemAccounts, func(account appsv1alpha1.SystemAccount) bool {
+			return account.Name == name
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("system account %s not found in component %s", name, rotation.ComponentName)
+		}
+		accounts = append(accounts, synthesizedComp.SystemAccounts[index])
+	}
+	return accounts, nil
+}
+
+// buildPasswordRotationLorryClient builds the lorry client of the pod which the action is performed in,
+// it's the writable pod if the component has roles, otherwise the first pod of the component.
+func buildPasswordRotationLorryClient(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	cluster *appsv1alpha1.Cluster, synthesizedComp *component.SynthesizedComponent) (lorry.Client, error) {
+	var pod *corev1.Pod
+	if len(synthesizedComp.Roles) > 0 {
+		writablePod, err := getServiceableNWritablePod(reqCtx.Ctx, cli, *cluster, *synthesizedComp)
+		if err != nil {
+			return nil, err
+		}
+		pod = writablePod
+	} else {
+		podList, err := component.GetComponentPodList(reqCtx.Ctx, cli, *cluster, synthesizedComp.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(podList.Items) == 0 {
+			return nil, fmt.Errorf("no pod found in component %s to rotate the passwords", synthesizedComp.Name)
+		}
+		pod = &podList.Items[0]
+	}
+	return lorry.NewClient(*pod)
+}
+
+// rotateAccountPassword rotates the password of the account in phases, so the new password is never lost
+// even if the rotation is interrupted:
+//  1. the new password is saved as the pending one, it's reused when retrying;
+//  2. the password of the account is changed in the engine by the action, and the pending one is marked as applied;
+//  3. the pending password is promoted to the password of the account secret and the connection credential.
+//
+// The pending password of an unsealed account secret is kept in a separate secret, since the account secret is immutable
+// and replaced by deleting and creating it. The account secret lost between them is restored by the component controller
+// with the applied pending password. The action may be called again with the same passwords if the rotation is retried.
+func rotateAccountPassword(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest, cluster *appsv1alpha1.Cluster,
+	synthesizedComp *component.SynthesizedComponent, lorryCli lorry.Client, account appsv1alpha1.SystemAccount, retainOldPassword bool) error {
+	secret, data, err := getAccountSecret(reqCtx, cli, cluster, synthesizedComp.Name, account.Name)
+	if err != nil {
+		return err
+	}
+	// the password has been rotated by the OpsRequest before the progress is saved, e.g. the operator leadership moved,
+	// the connection credential and the pending password may be left behind.
+	if secret.Annotations[constant.PasswordRotatedByAnnotationKey] == opsRequest.Name {
+		return completeAccountPasswordRotation(reqCtx, cli, cluster, secret, account.Name, data[constant.AccountPasswdForSecret])
+	}
+	password, err := getOrSavePendingPassword(reqCtx, cli, secret, data, account)
+	if err != nil {
+		return err
+	}
+
+	oldPassword := data[constant.AccountPasswdForSecret]
+	if err = lorryCli.RotateAccountPassword(reqCtx.Ctx, account.Name, string(password), string(oldPassword), retainOldPassword); err != nil {
+		return err
+	}
+	if err = markPendingPasswordApplied(reqCtx, cli, secret); err != nil {
+		return err
+	}
+
+	values := map[string][]byte{
+		constant.AccountPasswdForSecret:     password,
+		constant.AccountNextPasswdForSecret: nil,
+	}
+	secret.Annotations = maps.Clone(secret.Annotations)
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constant.PasswordRotatedByAnnotationKey] = opsRequest.Name
+	if err = replaceAccountSecret(reqCtx, cli, secret, values); err != nil {
+		return err
+	}
+	return completeAccountPasswordRotation(reqCtx, cli, cluster, secret, account.Name, password)
+}
+
+// completeAccountPasswordRotation updates the connection credential with the rotated password and removes the pending one.
+func completeAccountPasswordRotation(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster,
+	secret *corev1.Secret, accountName string, password []byte) error {
+	if err := updateConnCredentialPassword(reqCtx, cli, cluster, accountName, password); err != nil {
+		return err
+	}
+	if secretstore.IsSealed(secret) {
+		// the pending password is removed from the external secret manager when it's promoted.
+		return nil
+	}
+	pendingSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: secret.Namespace,
+			Name:      constant.GeneratePendingAccountSecretName(secret.Name),
+		},
+	}
+	return client.IgnoreNotFound(cli.Delete(reqCtx.Ctx, pendingSecret))
+}
+
+// getOrSavePendingPassword gets the pending password of the account, a new one is generated and saved if there is none.
+// The pending password of a sealed account secret is kept in the external secret manager with the values of it,
+// otherwise it's kept in the pending secret, and the account secret is left untouched until the password is promoted.
+func getOrSavePendingPassword(reqCtx intctrlutil.RequestCtx, cli client.Client, secret *corev1.Secret,
+	data map[string][]byte, account appsv1alpha1.SystemAccount) ([]byte, error) {
+	// the seed is used to generate the same initial password, it's ignored to generate a new one.
+	config := account.PasswordGenerationPolicy
+	config.Seed = ""
+	if secretstore.IsSealed(secret) {
+		if password := data[constant.AccountNextPasswdForSecret]; len(password) > 0 {
+			return password, nil
+		}
+		password := component.GenerateAccountPassword(config)
+		if err := secretstore.UpdateSealed(reqCtx.Ctx, secret, map[string][]byte{constant.AccountNextPasswdForSecret: password}); err != nil {
+			return nil, err
+		}
+		return password, nil
+	}
+
+	pendingSecret := &corev1.Secret{}
+	pendingKey := types.NamespacedName{Namespace: secret.Namespace, Name: constant.GeneratePendingAccountSecretName(secret.Name)}
+	err := cli.Get(reqCtx.Ctx, pendingKey, pendingSecret)
+	switch {
+	case err == nil:
+		return pendingSecret.Data[constant.AccountPasswdForSecret], nil
+	case !apierrors.IsNotFound(err):
+		return nil, err
+	}
+	pendingSecret = buildPendingAccountSecret(secret, component.GenerateAccountPassword(config))
+	if err = cli.Create(reqCtx.Ctx, pendingSecret); err != nil {
+		return nil, err
+	}
+	return pendingSecret.Data[constant.AccountPasswdForSecret], nil
+}
+
+// buildPendingAccountSecret builds the secret which keeps the pending password of the account secret. It has the
+// well-known labels and the owners of the account secret, so it's removed with the component if left behind.
+func buildPendingAccountSecret(secret *corev1.Secret, password []byte) *corev1.Secret {
+	labels := maps.Clone(secret.Labels)
+	// the pending secret isn't an account secret.
+	delete(labels, constant.ClusterAccountLabelKey)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       secret.Namespace,
+			Name:            constant.GeneratePendingAccountSecretName(secret.Name),
+			Labels:          labels,
+			OwnerReferences: secret.OwnerReferences,
+		},
+		Type: secret.Type,
+		Data: map[string][]byte{
+			constant.AccountNameForSecret:   secret.Data[constant.AccountNameForSecret],
+			constant.AccountPasswdForSecret: password,
+		},
+	}
+}
+
+// markPendingPasswordApplied marks the pending password as changed in the engine, the account secret is restored
+// with it by the component controller if lost when being replaced.
+func markPendingPasswordApplied(reqCtx intctrlutil.RequestCtx, cli client.Client, secret *corev1.Secret) error {
+	if secretstore.IsSealed(secret) {
+		// the sealed account secret is updated in place, it's never lost.
+		return nil
+	}
+	pendingSecret := &corev1.Secret{}
+	pendingKey := types.NamespacedName{Namespace: secret.Namespace, Name: constant.GeneratePendingAccountSecretName(secret.Name)}
+	if err := cli.Get(reqCtx.Ctx, pendingKey, pendingSecret); err != nil {
+		return err
+	}
+	if pendingSecret.Annotations[constant.PendingPasswordAppliedAnnotationKey] == "true" {
+		return nil
+	}
+	patch := client.MergeFrom(pendingSecret.DeepCopy())
+	if pendingSecret.Annotations == nil {
+		pendingSecret.Annotations = map[string]string{}
+	}
+	pendingSecret.Annotations[constant.PendingPasswordAppliedAnnotationKey] = "true"
+	return cli.Patch(reqCtx.Ctx, pendingSecret, patch)
+}
+
+// discardOldAccountPassword calls the action with the current password of the account to discard the old one.
+func discardOldAccountPassword(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster,
+	compName string, lorryCli lorry.Client, accountName string) error {
+	_, data, err := getAccountSecret(reqCtx, cli, cluster, compName, accountName)
+	if err != nil {
+		return err
+	}
+	return lorryCli.RotateAccountPassword(reqCtx.Ctx, accountName, string(data[constant.AccountPasswdForSecret]), "", false)
+}
+
+// getAccountSecret gets the account secret and the values of it, which are kept in the external secret manager if sealed.
+func getAccountSecret(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster,
+	compName, accountName string) (*corev1.Secret, map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      constant.GenerateAccountSecretName(cluster.Name, compName, accountName),
+	}
+	if err := cli.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		return nil, nil, err
+	}
+	data, err := secretstore.Unseal(reqCtx.Ctx, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	return secret, data, nil
+}
+
+// replaceAccountSecret replaces the account secret with a new one which contains the new values,
+// since the account secret is immutable. The metadata of the secret is kept, and the keys with empty values are removed.
+// If it's interrupted after the account secret is deleted, the component controller restores it with the pending password.
+func replaceAccountSecret(reqCtx intctrlutil.RequestCtx, cli client.Client, secret *corev1.Secret, values map[string][]byte) error {
+	// the secret keeps the reference only if the values are stored in the external secret manager,
+	// and the metadata of an immutable secret can be updated in place.
+	if secretstore.IsSealed(secret) {
+		if err := secretstore.UpdateSealed(reqCtx.Ctx, secret, values); err != nil {
+			return err
+		}
+		return cli.Update(reqCtx.Ctx, buildRotatedAccountSecret(secret, nil))
+	}
+	newSecret := buildRotatedAccountSecret(secret, values)
+	if secret.Immutable == nil || !*secret.Immutable {
+		return cli.Update(reqCtx.Ctx, newSecret)
+	}
+	if len(secret.Finalizers) > 0 {
+		patch := client.MergeFrom(secret.DeepCopy())
+		secret.Finalizers = nil
+		if err := cli.Patch(reqCtx.Ctx, secret, patch); err != nil {
+			return err
+		}
+	}
+	if err := cli.Delete(reqCtx.Ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	newSecret.ResourceVersion = ""
+	return cli.Create(reqCtx.Ctx, newSecret)
+}
+
+func buildRotatedAccountSecret(secret *corev1.Secret, values map[string][]byte) *corev1.Secret {
+	newSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       secret.Namespace,
+			Name:            secret.Name,
+			Labels:          secret.Labels,
+			Annotations:     secret.Annotations,
+			OwnerReferences: secret.OwnerReferences,
+			Finalizers:      secret.Finalizers,
+			ResourceVersion: secret.ResourceVersion,
+		},
+		Immutable: secret.Immutable,
+		Type:      secret.Type,
+		Data:      make(map[string][]byte, len(secret.Data)),
+	}
+	for k, v := range secret.Data {
+		newSecret.Data[k] = v
+	}
+	for k, v := range values {
+		if len(v) == 0 {
+			delete(newSecret.Data, k)
+		} else {
+			newSecret.Data[k] = v
+		}
+	}
+	return newSecret
+}
+
+// restartLorryIfServiceAccount restarts the pods of the component if lorry connects to the engine with the account,
+// since lorry reads the password from the environment variables which are only resolved when the pods are started.
+func restartLorryIfServiceAccount(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest, cluster *appsv1alpha1.Cluster,
+	synthesizedComp *component.SynthesizedComponent, accountName string) error {
+	serviceAccount, err := getLorryServiceAccount(reqCtx, cli, cluster, synthesizedComp)
+	if err != nil || serviceAccount != accountName {
+		return err
+	}
+	rsmObj, err := component.GetComponentRSM(reqCtx.Ctx, cli, synthesizedComp)
+	if err != nil || rsmObj == nil {
+		return err
+	}
+	patch := client.MergeFrom(rsmObj.DeepCopy())
+	if rsmObj.Spec.Template.Annotations == nil {
+		rsmObj.Spec.Template.Annotations = map[string]string{}
+	}
+	// restart with the start time of the OpsRequest, the pods are not restarted again if it's done again by the new leader.
+	rsmObj.Spec.Template.Annotations[constant.RestartAnnotationKey] = opsRequest.Status.StartTimestamp.Format(time.RFC3339)
+	return cli.Patch(reqCtx.Ctx, rsmObj, patch)
+}
+
+// getLorryServiceAccount gets the account which lorry connects to the engine with, it's the init account of the component,
+// or the account of the connection credential if the component is defined by the cluster definition.
+func getLorryServiceAccount(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster,
+	synthesizedComp *component.SynthesizedComponent) (string, error) {
+	compSpec := cluster.Spec.GetComponentByName(synthesizedComp.Name)
+	if compSpec == nil || compSpec.ComponentDef != "" {
+		for _, account := range synthesizedComp.SystemAccounts {
+			if account.InitAccount {
+				return account.Name, nil
+			}
+		}
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: cluster.Namespace, Name: constant.GenerateDefaultConnCredential(cluster.Name)}
+	if err := cli.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	return string(secret.Data[constant.AccountNameForSecret]), nil
+}
+
+// updateConnCredentialPassword updates the password in the connection credential of the cluster if it refers to the account.
+func updateConnCredentialPassword(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster,
+	accountName string, password []byte) error {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: cluster.Namespace, Name: constant.GenerateDefaultConnCredential(cluster.Name)}
+	if err := cli.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if string(secret.Data[constant.AccountNameForSecret]) != accountName {
+		return nil
+	}
+	if secretstore.IsSealed(secret) {
+		return secretstore.UpdateSealed(reqCtx.Ctx, secret, map[string][]byte{constant.AccountPasswdForSecret: password})
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	secret.Data[constant.AccountPasswdForSecret] = password
+	return cli.Patch(reqCtx.Ctx, secret, patch)
+}
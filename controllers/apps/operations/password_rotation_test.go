@@ -0,0 +1,451 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+)
+
+// failAccountSecretClient fails to create the account secret, e.g., the operator is restarted after
+// the account secret is deleted to be replaced.
+type failAccountSecretClient struct {
+	client.Client
+	secretName string
+	fail       bool
+}
+
+func (c *failAccountSecretClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if secret, ok := obj.(*corev1.Secret); ok && c.fail && secret.Name == c.secretName {
+		return errors.New("failed to create the secret")
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("PasswordRotationOps", func() {
+	const (
+		clusterDefName = "test-clusterdef-password-rotation"
+		compDefName    = "test-compdef-password-rotation"
+		rotationComp   = "mysql"
+	)
+
+	var (
+		reqCtx            intctrlutil.RequestCtx
+		lorryCli          *lorry.MockClient
+		opsRes            *OpsResource
+		cluster           *appsv1alpha1.Cluster
+		accountSecretName string
+		rsmKey            client.ObjectKey
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.RSMSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PodSignature, true, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(func() {
+		lorry.UnsetMockClient()
+		cleanEnv()
+	})
+
+	// initResources creates a component which has the init account root, the connection credential of root,
+	// and the workload and the pod of the component, the password rotation action is mocked by lorry.
+	initResources := func() {
+		By("create the cluster with the init account root")
+		passwordConfig := appsv1alpha1.PasswordConfig{Length: 16, NumDigits: 4}
+		compDef := testapps.NewComponentDefinitionFactory(compDefName).
+			WithRandomName().
+			SetRuntime(nil).
+			Apply(func(compDef *appsv1alpha1.ComponentDefinition) {
+				compDef.Spec.SystemAccounts = []appsv1alpha1.SystemAccount{
+					{Name: "root", InitAccount: true, PasswordGenerationPolicy: passwordConfig},
+					{Name: "monitor", PasswordGenerationPolicy: passwordConfig},
+				}
+				compDef.Spec.LifecycleActions = &appsv1alpha1.ComponentLifecycleActions{
+					AccountPasswordRotation: &appsv1alpha1.LifecycleActionHandler{
+						CustomHandler: &appsv1alpha1.Action{Exec: &appsv1alpha1.ExecAction{Command: []string{"rotate"}}},
+					},
+				}
+			}).
+			Create(&testCtx).
+			GetObject()
+		clusterDef := testapps.NewClusterDefFactory(clusterDefName).
+			WithRandomName().
+			AddComponentDef(testapps.StatefulMySQLComponent, rotationComp).
+			Create(&testCtx).
+			GetObject()
+		cluster = testapps.NewClusterFactory(testCtx.DefaultNamespace, "", clusterDef.Name, "").
+			WithRandomName().
+			AddComponentV2(rotationComp, compDef.Name).
+			SetReplicas(1).
+			Create(&testCtx).
+			GetObject()
+
+		By("create the workload and the pod of the component")
+		container := corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}
+		rsmObj := testapps.NewRSMFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(cluster.Name, rotationComp),
+			cluster.Name, rotationComp).
+			AddContainer(container).
+			Create(&testCtx).
+			GetObject()
+		rsmKey = client.ObjectKeyFromObject(rsmObj)
+		testapps.NewPodFactory(testCtx.DefaultNamespace, fmt.Sprintf("%s-0", rsmObj.Name)).
+			AddLabelsInMap(constant.GetComponentWellKnownLabels(cluster.Name, rotationComp)).
+			AddContainer(container).
+			Create(&testCtx)
+
+		By("create the account secret and the connection credential of root")
+		accountSecretName = constant.GenerateAccountSecretName(cluster.Name, rotationComp, "root")
+		Expect(testCtx.CreateObj(testCtx.Ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: accountSecretName},
+			Immutable:  pointer.Bool(true),
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte("root"),
+				constant.AccountPasswdForSecret: []byte("old"),
+			},
+		})).Should(Succeed())
+		Expect(testCtx.CreateObj(testCtx.Ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: constant.GenerateDefaultConnCredential(cluster.Name)},
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte("root"),
+				constant.AccountPasswdForSecret: []byte("old"),
+			},
+		})).Should(Succeed())
+
+		lorryCli = lorry.NewMockClient(gomock.NewController(GinkgoT()))
+		lorry.SetMockClient(lorryCli, nil)
+		reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+	}
+
+	// createRotationOps creates the OpsRequest to rotate the password of root.
+	createRotationOps := func(gracePeriodSeconds int32) {
+		ops := testapps.NewOpsRequestObj("rotate-root-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+			cluster.Name, appsv1alpha1.PasswordRotationType)
+		ops.Spec.PasswordRotationList = []appsv1alpha1.PasswordRotation{{
+			ComponentOps:       appsv1alpha1.ComponentOps{ComponentName: rotationComp},
+			Accounts:           []string{"root"},
+			GracePeriodSeconds: gracePeriodSeconds,
+		}}
+		opsRes = &OpsResource{
+			OpsRequest: testapps.CreateOpsRequest(ctx, testCtx, ops),
+			Cluster:    cluster,
+			Recorder:   k8sManager.GetEventRecorderFor("opsrequest-controller"),
+		}
+	}
+
+	secretData := func(name string) map[string][]byte {
+		secret := &corev1.Secret{}
+		err := k8sClient.Get(ctx, client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: name}, secret)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		Expect(err).ShouldNot(HaveOccurred())
+		return secret.Data
+	}
+
+	accountSecretData := func() map[string][]byte {
+		return secretData(accountSecretName)
+	}
+
+	pendingSecretData := func() map[string][]byte {
+		return secretData(constant.GeneratePendingAccountSecretName(accountSecretName))
+	}
+
+	connCredentialPassword := func() string {
+		return string(secretData(constant.GenerateDefaultConnCredential(cluster.Name))[constant.AccountPasswdForSecret])
+	}
+
+	// restoreAccountSecret restores the account secret lost when being replaced as the component controller does.
+	restoreAccountSecret := func(cli client.Client) {
+		if accountSecretData() != nil {
+			return
+		}
+		password, err := component.GetAppliedPendingAccountPassword(ctx, cli, testCtx.DefaultNamespace, accountSecretName)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(password).ShouldNot(BeEmpty(), "expect the applied pending password kept to restore the account secret")
+		Expect(cli.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      accountSecretName,
+				Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+			},
+			Immutable: pointer.Bool(true),
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte("root"),
+				constant.AccountPasswdForSecret: password,
+			},
+		})).Should(Succeed())
+	}
+
+	restartAnnotation := func() (string, bool) {
+		rsmObj := &workloads.ReplicatedStateMachine{}
+		Expect(k8sClient.Get(ctx, rsmKey, rsmObj)).Should(Succeed())
+		value, ok := rsmObj.Spec.Template.Annotations[constant.RestartAnnotationKey]
+		return value, ok
+	}
+
+	restarted := func() bool {
+		_, ok := restartAnnotation()
+		return ok
+	}
+
+	reconcile := func() (appsv1alpha1.OpsPhase, time.Duration) {
+		phase, requeueAfter, err := passwordRotationOpsHandler{}.ReconcileAction(reqCtx, k8sClient, opsRes)
+		Expect(err).ShouldNot(HaveOccurred())
+		return phase, requeueAfter
+	}
+
+	Context("Test PasswordRotation helpers", func() {
+		It("get the accounts to rotate", func() {
+			synthesizedComp := &component.SynthesizedComponent{
+				SystemAccounts: []appsv1alpha1.SystemAccount{{Name: "root"}, {Name: "monitor"}},
+			}
+			rotation := appsv1alpha1.PasswordRotation{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "mysql"}}
+			accounts, err := getRotationAccounts(synthesizedComp, rotation)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(accounts).Should(HaveLen(2))
+
+			rotation.Accounts = []string{"monitor"}
+			accounts, err = getRotationAccounts(synthesizedComp, rotation)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(accounts).Should(HaveLen(1))
+			Expect(accounts[0].Name).Should(Equal("monitor"))
+
+			rotation.Accounts = []string{"unknown"}
+			_, err = getRotationAccounts(synthesizedComp, rotation)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("build the rotated account secret", func() {
+			immutable := true
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "default",
+					Name:       "mycluster-mysql-account-root",
+					Labels:     map[string]string{constant.ClusterAccountLabelKey: "root"},
+					Finalizers: []string{constant.DBClusterFinalizerName},
+				},
+				Immutable: &immutable,
+				Data: map[string][]byte{
+					constant.AccountNameForSecret:   []byte("root"),
+					constant.AccountPasswdForSecret: []byte("old"),
+				},
+			}
+			secret.Data[constant.AccountNextPasswdForSecret] = []byte("new")
+			newSecret := buildRotatedAccountSecret(secret, map[string][]byte{
+				constant.AccountPasswdForSecret:     []byte("new"),
+				constant.AccountNextPasswdForSecret: nil,
+			})
+			Expect(newSecret.Name).Should(Equal(secret.Name))
+			Expect(newSecret.Labels).Should(Equal(secret.Labels))
+			Expect(newSecret.Finalizers).Should(Equal(secret.Finalizers))
+			Expect(*newSecret.Immutable).Should(BeTrue())
+			Expect(newSecret.Data[constant.AccountNameForSecret]).Should(Equal([]byte("root")))
+			Expect(newSecret.Data[constant.AccountPasswdForSecret]).Should(Equal([]byte("new")))
+			Expect(newSecret.Data).ShouldNot(HaveKey(constant.AccountNextPasswdForSecret))
+			Expect(secret.Data[constant.AccountPasswdForSecret]).Should(Equal([]byte("old")))
+		})
+	})
+
+	Context("Test PasswordRotation action", func() {
+		BeforeEach(initResources)
+
+		It("rotates the password and restarts lorry", func() {
+			createRotationOps(0)
+			var password string
+			lorryCli.EXPECT().RotateAccountPassword(gomock.Any(), "root", gomock.Any(), "old", false).
+				DoAndReturn(func(_ context.Context, _, newPassword, _ string, _ bool) error {
+					password = newPassword
+					return nil
+				})
+
+			Expect(passwordRotationOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Expect(password).ShouldNot(BeEmpty())
+			Expect(string(accountSecretData()[constant.AccountPasswdForSecret])).Should(Equal(password))
+			Expect(pendingSecretData()).Should(BeNil(), "expect the pending password removed once promoted")
+			Expect(connCredentialPassword()).Should(Equal(password))
+			Expect(restarted()).Should(BeTrue(), "expect lorry restarted since it connects with the rotated account")
+
+			phase, _ := reconcile()
+			Expect(phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+		})
+
+		It("discards the old password once the grace period is over", func() {
+			createRotationOps(60)
+			var password string
+			lorryCli.EXPECT().RotateAccountPassword(gomock.Any(), "root", gomock.Any(), "old", true).
+				DoAndReturn(func(_ context.Context, _, newPassword, _ string, _ bool) error {
+					password = newPassword
+					return nil
+				})
+
+			Expect(passwordRotationOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Expect(restarted()).Should(BeFalse(), "expect lorry not restarted since the old password is still accepted")
+			phase, requeueAfter := reconcile()
+			Expect(phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+			Expect(requeueAfter).Should(BeNumerically(">", 0), "expect the ops requeued until the grace period is over")
+
+			By("the grace period is over, the old password is discarded and lorry is restarted")
+			compStatus := opsRes.OpsRequest.Status.Components[rotationComp]
+			compStatus.ProgressDetails[0].StartTime = metav1.NewTime(time.Now().Add(-time.Minute))
+			opsRes.OpsRequest.Status.Components[rotationComp] = compStatus
+			lorryCli.EXPECT().RotateAccountPassword(gomock.Any(), "root", password, "", false).Return(nil)
+			phase, _ = reconcile()
+			Expect(phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+			Expect(restarted()).Should(BeTrue(), "expect lorry restarted once the old password is discarded")
+		})
+
+		It("reuses the pending password if retried after the password is changed in the engine", func() {
+			createRotationOps(0)
+			// fail to create the account secret after it's deleted to replace, i.e., the account secret is lost.
+			cli := &failAccountSecretClient{Client: k8sClient, secretName: accountSecretName, fail: true}
+			var passwords []string
+			lorryCli.EXPECT().RotateAccountPassword(gomock.Any(), "root", gomock.Any(), gomock.Any(), false).
+				DoAndReturn(func(_ context.Context, _, newPassword, _ string, _ bool) error {
+					passwords = append(passwords, newPassword)
+					return nil
+				}).Times(2)
+
+			Expect(passwordRotationOpsHandler{}.Action(reqCtx, cli, opsRes)).ShouldNot(Succeed())
+			Expect(accountSecretData()).Should(BeNil(), "expect the account secret lost")
+			Expect(string(pendingSecretData()[constant.AccountPasswdForSecret])).Should(Equal(passwords[0]),
+				"expect the password changed in the engine kept as the pending one")
+			Expect(connCredentialPassword()).Should(Equal("old"), "expect the connection credential not updated before the password is promoted")
+			Expect(restarted()).Should(BeFalse(), "expect lorry not restarted before the password is promoted")
+
+			By("the account secret is restored with the pending password, which is reused when retrying")
+			cli.fail = false
+			restoreAccountSecret(k8sClient)
+			Expect(passwordRotationOpsHandler{}.Action(reqCtx, cli, opsRes)).Should(Succeed())
+			Expect(passwords[1]).Should(Equal(passwords[0]), "expect the pending password reused")
+			Expect(string(accountSecretData()[constant.AccountPasswdForSecret])).Should(Equal(passwords[0]))
+			Expect(pendingSecretData()).Should(BeNil(), "expect the pending password removed once promoted")
+			Expect(connCredentialPassword()).Should(Equal(passwords[0]))
+		})
+
+		It("updates the connection credential if retried after the account secret is replaced", func() {
+			createRotationOps(0)
+			By("the account secret has been replaced by the OpsRequest, but the connection credential isn't updated")
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: accountSecretName}, secret)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).Should(Succeed())
+			secret = buildRotatedAccountSecret(secret, map[string][]byte{constant.AccountPasswdForSecret: []byte("new")})
+			secret.ResourceVersion = ""
+			secret.Annotations = map[string]string{constant.PasswordRotatedByAnnotationKey: opsRes.OpsRequest.Name}
+			Expect(k8sClient.Create(ctx, secret)).Should(Succeed())
+
+			Expect(passwordRotationOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Expect(connCredentialPassword()).Should(Equal("new"))
+		})
+	})
+
+	Context("Test PasswordRotation takeover", func() {
+		It("rotates the password once if the operator leadership moves", func() {
+			for writes := 0; ; writes++ {
+				if writes > 0 {
+					// each takeover starts over with the fresh resources.
+					cleanEnv()
+				}
+				initResources()
+				createRotationOps(0)
+				startTime := metav1.NewTime(time.Now().Truncate(time.Second))
+				Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+					opsRes.OpsRequest.Status.StartTimestamp = startTime
+				})).Should(Succeed())
+				var passwords []string
+				lorryCli.EXPECT().RotateAccountPassword(gomock.Any(), "root", gomock.Any(), gomock.Any(), false).
+					DoAndReturn(func(_ context.Context, _, newPassword, _ string, _ bool) error {
+						passwords = append(passwords, newPassword)
+						return nil
+					}).AnyTimes()
+
+				// each leader reads the OpsRequest and the cluster from the persisted state.
+				lost, err := testk8s.RunTakeover(k8sClient, writes, func(cli client.Client) error {
+					restoreAccountSecret(cli)
+					ops := &appsv1alpha1.OpsRequest{}
+					if err := cli.Get(ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), ops); err != nil {
+						return err
+					}
+					cluster := &appsv1alpha1.Cluster{}
+					if err := cli.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster); err != nil {
+						return err
+					}
+					leaderOpsRes := &OpsResource{OpsRequest: ops, Cluster: cluster, Recorder: opsRes.Recorder}
+					return passwordRotationOpsHandler{}.Action(reqCtx, cli, leaderOpsRes)
+				})
+				Expect(err).ShouldNot(HaveOccurred(), "takeover after %d writes", writes)
+				Expect(passwords).ShouldNot(BeEmpty(), "takeover after %d writes: expect the password rotated", writes)
+				for _, password := range passwords[1:] {
+					Expect(password).Should(Equal(passwords[0]), "takeover after %d writes: expect the password rotated once", writes)
+				}
+				Expect(string(accountSecretData()[constant.AccountPasswdForSecret])).Should(Equal(passwords[0]),
+					"takeover after %d writes: expect the rotated password saved", writes)
+				Expect(connCredentialPassword()).Should(Equal(passwords[0]),
+					"takeover after %d writes: expect the connection credential updated", writes)
+				Expect(pendingSecretData()).Should(BeNil(), "takeover after %d writes: expect the pending password removed", writes)
+				restartedAt, _ := restartAnnotation()
+				Expect(restartedAt).Should(Equal(startTime.Format(time.RFC3339)),
+					"takeover after %d writes: expect lorry restarted once by the OpsRequest", writes)
+				if !lost {
+					break
+				}
+				lorry.UnsetMockClient()
+			}
+		})
+	})
+})
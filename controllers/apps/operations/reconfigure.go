@@ -30,6 +30,9 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	cfgutil "github.com/apecloud/kubeblocks/pkg/configuration/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -74,10 +77,12 @@ func handleReconfigureStatusProgress(result *appsv1alpha1.ReconcileDetail, opsSt
 	}
 }
 
-func handleNewReconfigureRequest(configPatch *core.ConfigPatchInfo, lastAppliedConfigs map[string]string) handleReconfigureOpsStatus {
+func handleNewReconfigureRequest(configPatch *core.ConfigPatchInfo, lastAppliedConfigs map[string]string, lastConfigFileParams map[string]appsv1alpha1.ConfigParams, parameterDiffs []appsv1alpha1.ParameterDiff) handleReconfigureOpsStatus {
 	return func(cmStatus *appsv1alpha1.ConfigurationItemStatus) (err error) {
 		cmStatus.Status = appsv1alpha1.ReasonReconfigurePersisted
 		cmStatus.LastAppliedConfiguration = lastAppliedConfigs
+		cmStatus.LastConfigFileParams = lastConfigFileParams
+		cmStatus.ParameterDiffs = parameterDiffs
 		if configPatch != nil {
 			cmStatus.UpdatedParameters = appsv1alpha1.UpdatedParameters{
 				AddedKeys:   i2sMap(configPatch.AddConfig),
@@ -126,6 +131,10 @@ func (r *reconfigureAction) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli c
 		case err != nil:
 			return "", 30 * time.Second, err
 		case phase == appsv1alpha1.OpsFailedPhase:
+			// persist the reconfiguring status before failing the ops, e.g. the rollback condition.
+			if err = PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, resource, opsDeepCopy, resource.OpsRequest.Status.Phase); err != nil {
+				return "", 30 * time.Second, err
+			}
 			return appsv1alpha1.OpsFailedPhase, 0, nil
 		case phase != appsv1alpha1.OpsSucceedPhase:
 			isFinished = false
@@ -161,6 +170,8 @@ func fromReconfigureOperations(request appsv1alpha1.OpsRequestSpec, reqCtx intct
 			opsRequest:          resource.OpsRequest,
 			configurationItem:   reconfigure.Configurations[0],
 			configurationStatus: initReconfigureStatus(resource.OpsRequest, reconfigure.ComponentName),
+
+			rollbackTimeoutSeconds: reconfigure.RollbackTimeoutSeconds,
 		})
 	}
 	return reconfigures
@@ -202,7 +213,18 @@ func (r *reconfigureAction) doSyncReconfigureStatus(params reconfigureParams) (a
 		return appsv1alpha1.OpsRunningPhase, nil
 	}
 
-	switch phase := reconfiguringPhase(resource, *item, itemStatus); phase {
+	phase, err := r.reconcileReconfiguringPhase(params, resource, *item, itemStatus)
+	if err != nil {
+		return phase, err
+	}
+	return phase, syncReconfigureProgressDetails(params, resource.ConfigMapObj, phase)
+}
+
+func (r *reconfigureAction) reconcileReconfiguringPhase(params reconfigureParams,
+	resource *configctrl.Fetcher,
+	item appsv1alpha1.ConfigurationItemDetail,
+	itemStatus *appsv1alpha1.ConfigurationItemDetailStatus) (appsv1alpha1.OpsPhase, error) {
+	switch phase := reconfiguringPhase(resource, item, itemStatus); phase {
 	case appsv1alpha1.CCreatingPhase, appsv1alpha1.CInitPhase:
 		return appsv1alpha1.OpsFailedPhase, core.MakeError("the configuration is creating or initializing, is not ready to reconfigure")
 	case appsv1alpha1.CFailedAndPausePhase:
@@ -212,11 +234,113 @@ func (r *reconfigureAction) doSyncReconfigureStatus(params reconfigureParams) (a
 		return appsv1alpha1.OpsSucceedPhase,
 			syncStatus(params.configurationStatus, params.resource, itemStatus, phase)
 	default:
+		if isReconfigureRollbackTimeout(params) {
+			return appsv1alpha1.OpsFailedPhase, r.rollbackReconfiguring(params, resource, itemStatus)
+		}
 		return appsv1alpha1.OpsRunningPhase,
 			syncStatus(params.configurationStatus, params.resource, itemStatus, phase)
 	}
 }
 
+// syncReconfigureProgressDetails records the progress of each pod to status.components[*].progressDetails,
+// a pod is reconfigured once it is labeled with the version of the new configuration.
+func syncReconfigureProgressDetails(params reconfigureParams, configMap *corev1.ConfigMap, phase appsv1alpha1.OpsPhase) error {
+	if configMap == nil {
+		return nil
+	}
+	opsRes := params.resource
+	podList, err := component.GetComponentPodList(params.reqCtx.Ctx, params.cli, *opsRes.Cluster, params.componentName)
+	if err != nil {
+		return err
+	}
+	versionHash, err := cfgutil.ComputeHash(configMap.Data)
+	if err != nil {
+		return err
+	}
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	compStatus := opsRequest.Status.Components[params.componentName]
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		objectKey := getProgressObjectKey(constant.PodKind, pod.Name)
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+		switch {
+		case phase == appsv1alpha1.OpsSucceedPhase || intctrlutil.IsMatchConfigVersion(pod, params.configurationItem.Name, versionHash):
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+				getProgressSucceedMessage("reconfigure", objectKey, params.componentName))
+		case phase == appsv1alpha1.OpsFailedPhase:
+			progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus,
+				getProgressFailedMessage("reconfigure", objectKey, params.componentName, getReconfigureFailedMessage(params)))
+		default:
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+				getProgressProcessingMessage("reconfigure", objectKey, params.componentName))
+		}
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+	}
+	opsRequest.Status.Components[params.componentName] = compStatus
+	return nil
+}
+
+func getReconfigureFailedMessage(params reconfigureParams) string {
+	if params.configurationStatus == nil || len(params.configurationStatus.ConfigurationStatus) == 0 {
+		return ""
+	}
+	return params.configurationStatus.ConfigurationStatus[0].Message
+}
+
+// isReconfigureRollbackTimeout checks whether the pods have failed to apply the new configuration within the rollback deadline.
+func isReconfigureRollbackTimeout(params reconfigureParams) bool {
+	startTime := params.opsRequest.Status.StartTimestamp
+	if params.rollbackTimeoutSeconds == nil || startTime.IsZero() {
+		return false
+	}
+	deadline := startTime.Add(time.Duration(*params.rollbackTimeoutSeconds) * time.Second)
+	return time.Now().After(deadline)
+}
+
+// rollbackReconfiguring restores the parameters of the configuration to the version before the reconfiguring.
+func (r *reconfigureAction) rollbackReconfiguring(params reconfigureParams,
+	resource *configctrl.Fetcher,
+	status *appsv1alpha1.ConfigurationItemDetailStatus) error {
+	var lastStatus *appsv1alpha1.ConfigurationItemStatus
+	for i, cmStatus := range params.configurationStatus.ConfigurationStatus {
+		if cmStatus.Name == status.Name {
+			lastStatus = &params.configurationStatus.ConfigurationStatus[i]
+			break
+		}
+	}
+	if lastStatus == nil {
+		return core.MakeError("not found the last configuration of config[%s] to roll back", status.Name)
+	}
+
+	configObj := resource.ConfigurationObj
+	rollbackObj := configObj.DeepCopy()
+	item := rollbackObj.Spec.GetConfigurationItem(status.Name)
+	if item == nil {
+		return core.MakeError("not found config item: %s", status.Name)
+	}
+	item.ConfigFileParams = lastStatus.LastConfigFileParams
+	if err := params.cli.Patch(params.reqCtx.Ctx, rollbackObj, client.MergeFrom(configObj)); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("the pods of component[%s] failed to apply the config[%s] in %d seconds, rolled back to the previous configuration",
+		params.componentName, status.Name, *params.rollbackTimeoutSeconds)
+	params.reqCtx.Recorder.Event(params.opsRequest, corev1.EventTypeWarning, appsv1alpha1.ReasonReconfigureRollback, msg)
+	lastStatus.Status = appsv1alpha1.ReasonReconfigureRollback
+	lastStatus.Message = msg
+	meta.SetStatusCondition(&params.configurationStatus.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ReasonReconfigureRollback,
+		Status:             metav1.ConditionTrue,
+		Reason:             appsv1alpha1.ReasonReconfigureRollback,
+		LastTransitionTime: metav1.Now(),
+		Message:            msg,
+	})
+	return nil
+}
+
 func (r *reconfigureAction) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, resource *OpsResource) error {
 	opsRequest := resource.OpsRequest.Spec
 	// Node: support multiple component
@@ -248,8 +372,10 @@ func (r *reconfigureAction) doReconfiguring(params reconfigureParams) error {
 		Validate().
 		ConfigMap(item.Name).
 		ConfigConstraints().
+		Rollback().
 		Merge().
 		UpdateOpsLabel().
+		UpdateRestartPolicy().
 		Sync().
 		Complete()
 
@@ -264,7 +390,7 @@ func (r *reconfigureAction) doReconfiguring(params reconfigureParams) error {
 
 	// merged successfully
 	if err := updateReconfigureStatusByCM(params.configurationStatus, opsPipeline.configSpec.Name,
-		handleNewReconfigureRequest(result.configPatch, result.lastAppliedConfigs)); err != nil {
+		handleNewReconfigureRequest(result.configPatch, result.lastAppliedConfigs, result.lastConfigFileParams, result.parameterDiffs)); err != nil {
 		return err
 	}
 	condition := constructReconfiguringConditions(result, params.resource, opsPipeline.configSpec)
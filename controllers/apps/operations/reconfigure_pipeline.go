@@ -20,11 +20,15 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"encoding/json"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -47,8 +51,11 @@ type pipeline struct {
 	updatedParameters []cfgcore.ParamPairs
 	mergedConfig      map[string]string
 	configPatch       *cfgcore.ConfigPatchInfo
+	parameterDiffs    []appsv1alpha1.ParameterDiff
 	isFileUpdated     bool
 
+	lastConfigFileParams map[string]appsv1alpha1.ConfigParams
+
 	updatedObject    *appsv1alpha1.Configuration
 	configConstraint *appsv1alpha1.ConfigConstraint
 	configSpec       *appsv1alpha1.ComponentConfigSpec
@@ -94,7 +101,7 @@ func (p *pipeline) Validate() *pipeline {
 
 func (p *pipeline) ConfigConstraints() *pipeline {
 	validateFn := func() (err error) {
-		if !hasFileUpdate(p.config) {
+		if !hasFileUpdate(p.config) && p.config.RollbackToRevision == "" {
 			p.isFailed = true
 			err = cfgcore.MakeError(
 				"current configSpec not support reconfigure, configSpec: %v",
@@ -120,6 +127,35 @@ func (p *pipeline) ConfigConstraints() *pipeline {
 	})
 }
 
+// Rollback converts the rollback to a history revision into the updates of the parameters and files
+// which differ from that revision, so that it is applied in the same way as a normal reconfiguring.
+func (p *pipeline) Rollback() *pipeline {
+	rollbackFn := func() error {
+		if p.config.RollbackToRevision == "" {
+			return nil
+		}
+		history, err := configctrl.GetConfigHistory(p.reqCtx.Ctx, p.cli, p.ConfigMapObj, p.config.RollbackToRevision)
+		if apierrors.IsNotFound(err) {
+			p.isFailed = true
+			return cfgcore.MakeError("not found the revision[%s] of config[%s]", p.config.RollbackToRevision, p.config.Name)
+		}
+		if err != nil {
+			return err
+		}
+		var formatter *appsv1alpha1.FormatterConfig
+		if p.configConstraint != nil {
+			formatter = p.configConstraint.Spec.FormatterConfig
+		}
+		p.config.Keys, err = generateRollbackKeys(p.ConfigMapObj.Data, history.Data, formatter, p.configSpec.Keys)
+		if err != nil {
+			p.isFailed = true
+		}
+		return err
+	}
+
+	return p.Wrap(rollbackFn)
+}
+
 func (p *pipeline) doMergeImpl(parameters appsv1alpha1.ConfigurationItem) error {
 	newConfigObj := p.ConfigurationObj.DeepCopy()
 
@@ -129,6 +165,8 @@ func (p *pipeline) doMergeImpl(parameters appsv1alpha1.ConfigurationItem) error
 	}
 
 	configSpec := p.configSpec
+	// keep the parameters before merging, used to roll back the changes
+	p.lastConfigFileParams = item.DeepCopy().ConfigFileParams
 	if item.ConfigFileParams == nil {
 		item.ConfigFileParams = make(map[string]appsv1alpha1.ConfigParams)
 	}
@@ -172,6 +210,15 @@ func (p *pipeline) createUpdatePatch(item *appsv1alpha1.ConfigurationItemDetail,
 		p.configConstraint.Spec.FormatterConfig.Format,
 		p.configSpec.Keys,
 		false)
+	if err != nil {
+		return err
+	}
+	p.parameterDiffs = cfgcore.GenerateParameterDiffs(p.configPatch,
+		p.configConstraint.Spec.FormatterConfig,
+		p.configConstraint.Spec.SensitiveParameters)
+	if err = cfgcore.ValidateImmutableParameters(&p.configConstraint.Spec, p.configPatch); err != nil {
+		p.isFailed = true
+	}
 	return err
 }
 
@@ -206,6 +253,34 @@ func (p *pipeline) UpdateOpsLabel() *pipeline {
 	return p.Wrap(updateFn)
 }
 
+// UpdateRestartPolicy passes the restart policy of the request to the ConfigMap, which is used by the reconfiguring of the ConfigMap.
+// It must be called before the Configuration is synced.
+func (p *pipeline) UpdateRestartPolicy() *pipeline {
+	updateFn := func() error {
+		cm := p.ConfigMapObj
+		_, exists := cm.Annotations[constant.ReconfigureRestartPolicyAnnotationKey]
+		if p.config.RestartPolicy == nil && !exists {
+			return nil
+		}
+		patch := client.MergeFrom(cm.DeepCopy())
+		if p.config.RestartPolicy == nil {
+			delete(cm.Annotations, constant.ReconfigureRestartPolicyAnnotationKey)
+		} else {
+			b, err := json.Marshal(p.config.RestartPolicy)
+			if err != nil {
+				return err
+			}
+			if cm.Annotations == nil {
+				cm.Annotations = make(map[string]string)
+			}
+			cm.Annotations[constant.ReconfigureRestartPolicyAnnotationKey] = string(b)
+		}
+		return p.cli.Patch(p.reqCtx.Ctx, cm, patch)
+	}
+
+	return p.Wrap(updateFn)
+}
+
 func (p *pipeline) Sync() *pipeline {
 	return p.Wrap(func() error {
 		return p.Client.Patch(p.reqCtx.Ctx, p.updatedObject, client.MergeFrom(p.ConfigurationObj))
@@ -219,6 +294,8 @@ func (p *pipeline) Complete() reconfiguringResult {
 
 	return makeReconfiguringResult(nil,
 		withReturned(p.mergedConfig, p.configPatch),
+		withLastConfigFileParams(p.lastConfigFileParams),
+		withParameterDiffs(p.parameterDiffs),
 		withNoFormatFilesUpdated(p.isFileUpdated),
 	)
 }
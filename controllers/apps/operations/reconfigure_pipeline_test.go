@@ -20,16 +20,22 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testutil "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
@@ -290,4 +296,120 @@ z2=y2
 		})
 	})
 
+	Context("reconfigure rollback test", func() {
+		It("Should roll back only after the deadline exceeded", func() {
+			opsRequest := testapps.NewOpsRequestObj("reconfigure-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.ReconfiguringType)
+			params := reconfigureParams{opsRequest: opsRequest}
+
+			By("no rollback timeout specified")
+			opsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			Expect(isReconfigureRollbackTimeout(params)).Should(BeFalse())
+
+			By("the deadline is not exceeded")
+			params.rollbackTimeoutSeconds = func() *int32 { v := int32(7200); return &v }()
+			Expect(isReconfigureRollbackTimeout(params)).Should(BeFalse())
+
+			By("the deadline is exceeded")
+			params.rollbackTimeoutSeconds = func() *int32 { v := int32(60); return &v }()
+			Expect(isReconfigureRollbackTimeout(params)).Should(BeTrue())
+
+			By("the ops is not started")
+			opsRequest.Status.StartTimestamp = metav1.Time{}
+			Expect(isReconfigureRollbackTimeout(params)).Should(BeFalse())
+		})
+
+		It("Should restore the last parameters of the configuration when rolling back", func() {
+			_, _, configObj := mockCfgTplObj(tpl)
+			newValue, lastValue := "y1", "y0"
+			configObj.Spec.GetConfigurationItem(tpl.Name).ConfigFileParams = map[string]appsv1alpha1.ConfigParams{
+				"my.cnf": {Parameters: map[string]*string{"x1": &newValue}},
+			}
+
+			var patched *appsv1alpha1.Configuration
+			k8sMockClient.MockPatchMethod(testutil.WithPatchReturned(func(obj client.Object, patch client.Patch) error {
+				if cfg, ok := obj.(*appsv1alpha1.Configuration); ok {
+					patched = cfg
+				}
+				return nil
+			}, testutil.WithAnyTimes()))
+
+			recorder := record.NewFakeRecorder(10)
+			opsRequest := testapps.NewOpsRequestObj("reconfigure-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.ReconfiguringType)
+			params := reconfigureParams{
+				reqCtx:        intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Recorder: recorder},
+				cli:           k8sMockClient.Client(),
+				componentName: componentName,
+				opsRequest:    opsRequest,
+				configurationStatus: &appsv1alpha1.ReconfiguringStatus{
+					ConfigurationStatus: []appsv1alpha1.ConfigurationItemStatus{{
+						Name:                 tpl.Name,
+						LastConfigFileParams: map[string]appsv1alpha1.ConfigParams{"my.cnf": {Parameters: map[string]*string{"x1": &lastValue}}},
+					}},
+				},
+				rollbackTimeoutSeconds: func() *int32 { v := int32(60); return &v }(),
+			}
+			fetcher := &configctrl.Fetcher{}
+			fetcher.ConfigurationObj = configObj
+			itemStatus := &appsv1alpha1.ConfigurationItemDetailStatus{Name: tpl.Name}
+
+			By("roll back the parameters")
+			Expect((&reconfigureAction{}).rollbackReconfiguring(params, fetcher, itemStatus)).Should(Succeed())
+			Expect(patched).ShouldNot(BeNil())
+			Expect(*patched.Spec.GetConfigurationItem(tpl.Name).ConfigFileParams["my.cnf"].Parameters["x1"]).Should(Equal(lastValue))
+			// the original object is not modified, which is the base of the merge patch
+			Expect(*configObj.Spec.GetConfigurationItem(tpl.Name).ConfigFileParams["my.cnf"].Parameters["x1"]).Should(Equal(newValue))
+
+			By("the rollback is recorded in the status and the event")
+			Expect(params.configurationStatus.ConfigurationStatus[0].Status).Should(Equal(appsv1alpha1.ReasonReconfigureRollback))
+			Expect(meta.IsStatusConditionTrue(params.configurationStatus.Conditions, appsv1alpha1.ReasonReconfigureRollback)).Should(BeTrue())
+			Expect(recorder.Events).Should(Receive(ContainSubstring(appsv1alpha1.ReasonReconfigureRollback)))
+
+			By("no last configuration to roll back")
+			itemStatus.Name = tpl2.Name
+			Expect((&reconfigureAction{}).rollbackReconfiguring(params, fetcher, itemStatus)).ShouldNot(Succeed())
+		})
+	})
+
+	Context("rollback to revision test", func() {
+		It("Should generate the keys to restore the history version", func() {
+			formatter := &appsv1alpha1.FormatterConfig{
+				Format: appsv1alpha1.Ini,
+				FormatterOptions: appsv1alpha1.FormatterOptions{
+					IniConfig: &appsv1alpha1.IniConfig{SectionName: "mysqld"},
+				},
+			}
+			current := map[string]string{
+				"my.cnf":   "[mysqld]\nmax_connections=200\nport=3306\nnew_param=3\n",
+				"setup.sh": "echo current",
+			}
+			history := map[string]string{
+				"my.cnf":   "[mysqld]\nmax_connections=100\nport=3306\n",
+				"setup.sh": "echo history",
+			}
+			keys, err := generateRollbackKeys(current, history, formatter, []string{"my.cnf"})
+			Expect(err).Should(Succeed())
+			Expect(keys).Should(HaveLen(2))
+
+			By("the formatted file is restored by the parameters")
+			Expect(keys[0].Key).Should(Equal("my.cnf"))
+			Expect(keys[0].FileContent).Should(BeEmpty())
+			Expect(keys[0].Parameters).Should(HaveLen(2))
+			Expect(keys[0].Parameters[0].Key).Should(Equal("max_connections"))
+			Expect(*keys[0].Parameters[0].Value).Should(Equal("100"))
+			Expect(keys[0].Parameters[1].Key).Should(Equal("new_param"))
+			Expect(keys[0].Parameters[1].Value).Should(BeNil())
+
+			By("the other file is restored by the content")
+			Expect(keys[1].Key).Should(Equal("setup.sh"))
+			Expect(keys[1].FileContent).Should(Equal("echo history"))
+
+			By("nothing to restore if the version is not changed")
+			keys, err = generateRollbackKeys(history, history, formatter, []string{"my.cnf"})
+			Expect(err).Should(Succeed())
+			Expect(keys).Should(BeEmpty())
+		})
+	})
+
 })
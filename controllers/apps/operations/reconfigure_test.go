@@ -278,12 +278,21 @@ var _ = Describe("Reconfigure OpsRequest", func() {
 					cm.Annotations[core.GenerateRevisionPhaseKey("1")] = string(b)
 				})).Should(Succeed())
 
+			By("mock the pods of the component")
+			pods := initConsensusPods(ctx, k8sClient, opsRes, clusterName)
+
 			By("Reconfigure operation success")
 			// Expect(reAction.Handle(eventContext, ops.Name, appsv1alpha1.OpsSucceedPhase, nil)).Should(Succeed())
 			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), opsRes.OpsRequest)).Should(Succeed())
 			_, _ = opsManager.Reconcile(reqCtx, k8sClient, opsRes)
 			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
 
+			By("expect the progress details of the pods to be Succeed")
+			progressDetails := opsRes.OpsRequest.Status.Components[consensusComp].ProgressDetails
+			Expect(progressDetails).Should(HaveLen(len(pods)))
+			for _, v := range progressDetails {
+				Expect(v.Status).Should(Equal(appsv1alpha1.SucceedProgressStatus))
+			}
 		})
 
 		It("Test Reconfigure OpsRequest with autoReload", func() {
@@ -22,6 +22,7 @@ package operations
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/spf13/cast"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,7 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -37,6 +39,8 @@ type reconfiguringResult struct {
 	noFormatFilesUpdated bool
 	configPatch          *core.ConfigPatchInfo
 	lastAppliedConfigs   map[string]string
+	lastConfigFileParams map[string]appsv1alpha1.ConfigParams
+	parameterDiffs       []appsv1alpha1.ParameterDiff
 	err                  error
 }
 
@@ -123,6 +127,18 @@ func withReturned(configs map[string]string, patch *core.ConfigPatchInfo) func(r
 	}
 }
 
+func withLastConfigFileParams(params map[string]appsv1alpha1.ConfigParams) func(result *reconfiguringResult) {
+	return func(result *reconfiguringResult) {
+		result.lastConfigFileParams = params
+	}
+}
+
+func withParameterDiffs(diffs []appsv1alpha1.ParameterDiff) func(result *reconfiguringResult) {
+	return func(result *reconfiguringResult) {
+		result.parameterDiffs = diffs
+	}
+}
+
 func withNoFormatFilesUpdated(changed bool) func(result *reconfiguringResult) {
 	return func(result *reconfiguringResult) {
 		result.noFormatFilesUpdated = changed
@@ -257,3 +273,41 @@ func hasFileUpdate(config appsv1alpha1.ConfigurationItem) bool {
 	}
 	return false
 }
+
+// generateRollbackKeys generates the keys to be updated to restore the configuration files to the target version.
+// The formatted files are restored by the parameters, and the others are restored by the file contents.
+func generateRollbackKeys(current, target map[string]string, formatter *appsv1alpha1.FormatterConfig, cmKeys []string) ([]appsv1alpha1.ParameterConfig, error) {
+	var (
+		keys   []appsv1alpha1.ParameterConfig
+		filter = validate.WithKeySelector(cmKeys)
+	)
+	for key, content := range target {
+		if content == current[key] || (formatter != nil && filter(key)) {
+			continue
+		}
+		keys = append(keys, appsv1alpha1.ParameterConfig{Key: key, FileContent: content})
+	}
+	if formatter != nil {
+		configPatch, _, err := core.CreateConfigPatch(current, target, formatter.Format, cmKeys, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, param := range core.GenerateVisualizedParamsList(configPatch, formatter, nil) {
+			if param.UpdateType != core.UpdatedType {
+				continue
+			}
+			parameters := make([]appsv1alpha1.ParameterPair, 0, len(param.Parameters))
+			for _, pair := range param.Parameters {
+				parameters = append(parameters, appsv1alpha1.ParameterPair{Key: pair.Key, Value: pair.Value})
+			}
+			sort.Slice(parameters, func(i, j int) bool {
+				return parameters[i].Key < parameters[j].Key
+			})
+			keys = append(keys, appsv1alpha1.ParameterConfig{Key: param.Key, Parameters: parameters})
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	})
+	return keys, nil
+}
@@ -44,8 +44,10 @@ func testUpdateConfigConfigmapResource(
 		Validate().
 		ConfigMap(config.Name).
 		ConfigConstraints().
+		Rollback().
 		Merge().
 		UpdateOpsLabel().
+		UpdateRestartPolicy().
 		Sync().
 		Complete()
 }
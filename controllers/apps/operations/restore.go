@@ -22,6 +22,8 @@ package operations
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -39,6 +41,11 @@ import (
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 )
 
+const (
+	// reasonInPlaceRestore the event reason indicates the progress of restoring a cluster in place.
+	reasonInPlaceRestore = "InPlaceRestore"
+)
+
 type RestoreOpsHandler struct{}
 
 var _ OpsHandler = RestoreOpsHandler{}
@@ -67,14 +74,29 @@ func (r RestoreOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 
 	opsRequest := opsRes.OpsRequest
 
-	// restore the cluster from the backup
-	if cluster, err = r.restoreClusterFromBackup(reqCtx, cli, opsRequest); err != nil {
-		return err
+	// the existing cluster should be backed up and deleted before restoring in place
+	if opsRequest.Spec.RestoreSpec.InPlace {
+		if cluster, err = r.prepareInPlaceRestore(reqCtx, cli, opsRes); err != nil {
+			return err
+		}
 	}
 
-	// create cluster
-	if err = cli.Create(reqCtx.Ctx, cluster); err != nil {
-		return err
+	if cluster == nil {
+		// restore the cluster from the backup
+		if cluster, err = r.restoreClusterFromBackup(reqCtx, cli, opsRequest); err != nil {
+			return err
+		}
+
+		// create cluster
+		if err = cli.Create(reqCtx.Ctx, cluster); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			// the cluster may have been created before the operator leadership moved
+			if cluster, err = r.getClusterRestoredByOps(reqCtx, cli, opsRequest); err != nil {
+				return err
+			}
+		}
 	}
 	opsRes.Cluster = cluster
 
@@ -131,6 +153,146 @@ func (r RestoreOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// prepareInPlaceRestore checks whether the existing cluster can be restored in place,
+// then takes a pre-restore backup of the cluster and deletes it after the backup is completed.
+// It returns a requeue error until the cluster is gone, and returns the cluster if it has been restored by the OpsRequest.
+func (r RestoreOpsHandler) prepareInPlaceRestore(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.Cluster, error) {
+	opsRequest := opsRes.OpsRequest
+	backupName := opsRequest.Spec.RestoreSpec.BackupName
+
+	preRestoreBackup, err := r.getPreRestoreBackup(reqCtx, cli, opsRequest)
+	if err != nil {
+		return nil, err
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRequest.Spec.ClusterRef, Namespace: opsRequest.Namespace}, cluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		// the cluster has been deleted after the pre-restore backup completed
+		if preRestoreBackup != nil && preRestoreBackup.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+			return nil, nil
+		}
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("cluster %s is not found, it can not be restored in place", opsRequest.Spec.ClusterRef))
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "wait for the cluster %s to be deleted", cluster.Name)
+	}
+	// the cluster has been re-created from the backup by this OpsRequest
+	if opsRecorders, _ := util.GetOpsRequestSliceFromCluster(cluster); preRestoreBackup != nil && len(opsRecorders) > 0 {
+		if index, _ := GetOpsRecorderFromSlice(opsRecorders, opsRequest.Name); index != -1 {
+			return cluster, nil
+		}
+	}
+
+	if preRestoreBackup == nil {
+		if err = r.validateInPlaceRestore(reqCtx, cli, opsRequest, cluster); err != nil {
+			return nil, err
+		}
+		if preRestoreBackup, err = r.createPreRestoreBackup(reqCtx, cli, opsRes, cluster); err != nil {
+			return nil, err
+		}
+	}
+	if err = r.syncPreRestoreBackupStatus(reqCtx, cli, opsRequest, preRestoreBackup); err != nil {
+		return nil, err
+	}
+
+	switch preRestoreBackup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
+		if err = cli.Delete(reqCtx.Ctx, cluster); err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		opsRes.Recorder.Eventf(opsRequest, corev1.EventTypeNormal, reasonInPlaceRestore,
+			"the pre-restore backup %s is completed, delete the cluster %s to restore it from backup %s", preRestoreBackup.Name, cluster.Name, backupName)
+		return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "wait for the cluster %s to be deleted", cluster.Name)
+	case dpv1alpha1.BackupPhaseFailed:
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("the pre-restore backup %s failed: %s", preRestoreBackup.Name, preRestoreBackup.Status.FailureReason))
+	default:
+		return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "wait for the pre-restore backup %s to be completed", preRestoreBackup.Name)
+	}
+}
+
+// validateInPlaceRestore checks whether it is safe to replace the data of the cluster with the backup.
+func (r RestoreOpsHandler) validateInPlaceRestore(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest, cluster *appsv1alpha1.Cluster) error {
+	backup := &dpv1alpha1.Backup{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRequest.Spec.RestoreSpec.BackupName, Namespace: opsRequest.Namespace}, backup); err != nil {
+		return err
+	}
+	if backup.Labels[constant.AppInstanceLabelKey] != cluster.Name {
+		return intctrlutil.NewFatalError(fmt.Sprintf("backup %s does not belong to cluster %s, it can not be restored in place", backup.Name, cluster.Name))
+	}
+	if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+		return intctrlutil.NewFatalError(fmt.Sprintf("cluster %s is %s, only running cluster can be restored in place", cluster.Name, cluster.Status.Phase))
+	}
+	// DoNotTerminate blocks the deletion, Halt keeps the PVCs which would take the place of the restored data,
+	// and WipeOut deletes the backups of the cluster.
+	if cluster.Spec.TerminationPolicy != appsv1alpha1.Delete {
+		return intctrlutil.NewFatalError(fmt.Sprintf("the terminationPolicy of cluster %s is %s, only cluster with %s terminationPolicy can be restored in place",
+			cluster.Name, cluster.Spec.TerminationPolicy, appsv1alpha1.Delete))
+	}
+	opsRecorders, err := util.GetOpsRequestSliceFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+	if len(opsRecorders) > 0 {
+		return intctrlutil.NewFatalError(fmt.Sprintf("cluster %s has running opsRequest %s, it can not be restored in place", cluster.Name, opsRecorders[0].Name))
+	}
+	return nil
+}
+
+// createPreRestoreBackup creates a backup of the cluster with the default backup policy before restoring in place.
+func (r RestoreOpsHandler) createPreRestoreBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, cluster *appsv1alpha1.Cluster) (*dpv1alpha1.Backup, error) {
+	opsRequest := opsRes.OpsRequest
+	backup, err := buildBackup(reqCtx, cli, &appsv1alpha1.OpsRequest{ObjectMeta: opsRequest.ObjectMeta}, cluster)
+	if err != nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("failed to build the pre-restore backup of cluster %s: %s", cluster.Name, err.Error()))
+	}
+	backup.Name = strings.Join([]string{"pre-restore", cluster.Name, time.Now().Format(backupTimeLayout)}, "-")
+	backup.Labels[constant.OpsRequestTypeLabelKey] = string(appsv1alpha1.RestoreType)
+	if err = cli.Create(reqCtx.Ctx, backup); err != nil {
+		return nil, err
+	}
+	opsRes.Recorder.Eventf(opsRequest, corev1.EventTypeNormal, reasonInPlaceRestore,
+		"create the pre-restore backup %s for cluster %s", backup.Name, cluster.Name)
+	return backup, nil
+}
+
+func (r RestoreOpsHandler) getPreRestoreBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) (*dpv1alpha1.Backup, error) {
+	backups := &dpv1alpha1.BackupList{}
+	labels := getBackupLabels(opsRequest.Spec.ClusterRef, opsRequest.Name)
+	labels[constant.OpsRequestTypeLabelKey] = string(appsv1alpha1.RestoreType)
+	if err := cli.List(reqCtx.Ctx, backups, client.InNamespace(opsRequest.Namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+	if len(backups.Items) == 0 {
+		return nil, nil
+	}
+	return &backups.Items[0], nil
+}
+
+func (r RestoreOpsHandler) syncPreRestoreBackupStatus(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest, backup *dpv1alpha1.Backup) error {
+	backupStatus := buildOpsBackupStatus(backup)
+	if reflect.DeepEqual(opsRequest.Status.BackupStatus, backupStatus) {
+		return nil
+	}
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	opsRequest.Status.BackupStatus = backupStatus
+	return cli.Status().Patch(reqCtx.Ctx, opsRequest, patch)
+}
+
+// getClusterRestoredByOps gets the existing cluster, which should be created from the backup by this OpsRequest.
+func (r RestoreOpsHandler) getClusterRestoredByOps(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) (*appsv1alpha1.Cluster, error) {
+	cluster := &appsv1alpha1.Cluster{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRequest.Spec.ClusterRef, Namespace: opsRequest.Namespace}, cluster); err != nil {
+		return nil, err
+	}
+	opsRecorders, _ := util.GetOpsRequestSliceFromCluster(cluster)
+	if index, _ := GetOpsRecorderFromSlice(opsRecorders, opsRequest.Name); index == -1 {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf("cluster %s already exists, it is not restored by the OpsRequest", cluster.Name))
+	}
+	return cluster, nil
+}
+
 func (r RestoreOpsHandler) restoreClusterFromBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) (*appsv1alpha1.Cluster, error) {
 	backupName := opsRequest.Spec.RestoreSpec.BackupName
 
@@ -183,6 +345,9 @@ func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, op
 		return nil, err
 	}
 	restoreSpec := opsRequest.Spec.RestoreSpec
+	if err := r.applyRestoreOverrides(cluster, restoreSpec); err != nil {
+		return nil, err
+	}
 	// set the restore annotation to cluster
 	restoreAnnotation, err := restore.GetRestoreFromBackupAnnotation(backup, cluster.Spec.ComponentSpecs, restoreSpec.VolumeRestorePolicy, restoreSpec.RestoreTimeStr, restoreSpec.EffectiveCommonComponentDef)
 	if err != nil {
@@ -213,3 +378,52 @@ func (r RestoreOpsHandler) getClusterObjFromBackup(backup *dpv1alpha1.Backup, op
 	cluster.Spec.Services = services
 	return cluster, nil
 }
+
+// applyRestoreOverrides applies the ClusterVersion and the component overrides to the cluster restored from backup,
+// the compatibility between the backup and the restored cluster is validated before the restore jobs run.
+func (r RestoreOpsHandler) applyRestoreOverrides(cluster *appsv1alpha1.Cluster, restoreSpec *appsv1alpha1.RestoreSpec) error {
+	if len(restoreSpec.ClusterVersionRef) > 0 {
+		cluster.Spec.ClusterVersionRef = restoreSpec.ClusterVersionRef
+	}
+	for _, override := range restoreSpec.ComponentOverrides {
+		var compSpec *appsv1alpha1.ClusterComponentSpec
+		for i := range cluster.Spec.ComponentSpecs {
+			if cluster.Spec.ComponentSpecs[i].Name == override.ComponentName {
+				compSpec = &cluster.Spec.ComponentSpecs[i]
+				break
+			}
+		}
+		if compSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found in the backed up cluster`, override.ComponentName))
+		}
+		if override.Replicas != nil {
+			compSpec.Replicas = *override.Replicas
+		}
+		if override.Resources != nil {
+			compSpec.Resources = *override.Resources
+		}
+		for _, vctOverride := range override.VolumeClaimTemplates {
+			var vct *appsv1alpha1.ClusterComponentVolumeClaimTemplate
+			for i := range compSpec.VolumeClaimTemplates {
+				if compSpec.VolumeClaimTemplates[i].Name == vctOverride.Name {
+					vct = &compSpec.VolumeClaimTemplates[i]
+					break
+				}
+			}
+			if vct == nil {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`volumeClaimTemplate "%s" not found in the component "%s" of the backed up cluster`,
+					vctOverride.Name, override.ComponentName))
+			}
+			if vctOverride.Storage != nil {
+				if vct.Spec.Resources.Requests == nil {
+					vct.Spec.Resources.Requests = corev1.ResourceList{}
+				}
+				vct.Spec.Resources.Requests[corev1.ResourceStorage] = *vctOverride.Storage
+			}
+			if vctOverride.StorageClassName != nil {
+				vct.Spec.StorageClassName = vctOverride.StorageClassName
+			}
+		}
+	}
+	return nil
+}
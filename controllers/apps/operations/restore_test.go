@@ -26,7 +26,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -37,6 +39,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
 )
 
 var _ = Describe("Restore OpsRequest", func() {
@@ -66,6 +69,7 @@ var _ = Describe("Restore OpsRequest", func() {
 		// namespaced
 		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResources(&testCtx, generics.BackupPolicySignature, inNS, ml)
 	}
 
 	BeforeEach(cleanEnv)
@@ -176,6 +180,286 @@ var _ = Describe("Restore OpsRequest", func() {
 			})).Should(Succeed())
 		})
 
+		It("test restore with a different topology", func() {
+			By("mock backup annotations and labels")
+			Expect(testapps.ChangeObj(&testCtx, backup, func(backup *dpv1alpha1.Backup) {
+				backup.Labels = map[string]string{
+					dptypes.BackupTypeLabelKey:      string(dpv1alpha1.BackupTypeFull),
+					constant.KBAppComponentLabelKey: statefulComp,
+				}
+				opsRes.Cluster.ResourceVersion = ""
+				clusterBytes, _ := json.Marshal(opsRes.Cluster)
+				backup.Annotations = map[string]string{
+					constant.ClusterSnapshotAnnotationKey: string(clusterBytes),
+				}
+			})).Should(Succeed())
+
+			By("create Restore OpsRequest with component overrides")
+			storage := resource.MustParse("2Gi")
+			ops := testapps.NewOpsRequestObj("restore-ops-"+randomStr, testCtx.DefaultNamespace,
+				restoreClusterName, appsv1alpha1.RestoreType)
+			ops.Spec.RestoreSpec = &appsv1alpha1.RestoreSpec{
+				BackupName: backupName,
+				ComponentOverrides: []appsv1alpha1.RestoreComponentOverride{
+					{
+						ComponentOps: appsv1alpha1.ComponentOps{ComponentName: statefulComp},
+						Replicas:     pointer.Int32(1),
+						VolumeClaimTemplates: []appsv1alpha1.RestoreVolumeClaimTemplate{
+							{
+								Name:             testapps.DataVolumeName,
+								Storage:          &storage,
+								StorageClassName: pointer.String("fast"),
+							},
+						},
+					},
+				},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("test restore action")
+			restoreHandler := RestoreOpsHandler{}
+			_ = restoreHandler.Action(reqCtx, k8sClient, opsRes)
+
+			By("the restored cluster should apply the overrides")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Name: restoreClusterName, Namespace: opsRes.OpsRequest.Namespace}, func(g Gomega, restoreCluster *appsv1alpha1.Cluster) {
+				compSpec := restoreCluster.Spec.GetComponentByName(statefulComp)
+				g.Expect(compSpec).ShouldNot(BeNil())
+				g.Expect(compSpec.Replicas).Should(BeEquivalentTo(1))
+				g.Expect(compSpec.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String()).Should(Equal("2Gi"))
+				g.Expect(*compSpec.VolumeClaimTemplates[0].Spec.StorageClassName).Should(Equal("fast"))
+			})).Should(Succeed())
+		})
+
+		It("test in-place restore with the backup of other cluster", func() {
+			By("create in-place Restore OpsRequest")
+			ops := testapps.NewOpsRequestObj("restore-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestoreType)
+			ops.Spec.RestoreSpec = &appsv1alpha1.RestoreSpec{
+				BackupName: backupName,
+				InPlace:    true,
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+
+			By("the in-place restore should be rejected before taking the pre-restore backup")
+			restoreHandler := RestoreOpsHandler{}
+			err := restoreHandler.Action(reqCtx, k8sClient, opsRes)
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+			Expect(err.Error()).Should(ContainSubstring("does not belong to cluster"))
+		})
+
+	})
+
+	Context("Test in-place restore", func() {
+		var (
+			inPlaceClusterName string
+			opsName            string
+			reqCtx             intctrlutil.RequestCtx
+			handler            RestoreOpsHandler
+		)
+
+		BeforeEach(func() {
+			inPlaceClusterName = "in-place-cluster-" + testCtx.GetRandomStr()
+			opsName = "restore-ops-" + testCtx.GetRandomStr()
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("create the completed backup of the cluster")
+			backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, backupName).
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				SetBackupMethod(testdp.VSBackupMethodName).
+				AddLabels(constant.AppInstanceLabelKey, inPlaceClusterName).
+				Create(&testCtx).GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, backup, func() {
+				backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+			})).Should(Succeed())
+
+			By("create the default backup policy of the cluster")
+			backupPolicy := testdp.NewBackupPolicyFactory(testCtx.DefaultNamespace, "backup-policy-"+testCtx.GetRandomStr()).
+				AddLabels(constant.AppInstanceLabelKey, inPlaceClusterName).
+				AddAnnotations(dptypes.DefaultBackupPolicyAnnotationKey, "true").
+				AddBackupMethod("snapshot", true, "").
+				SetTarget(constant.AppInstanceLabelKey, inPlaceClusterName).
+				Create(&testCtx).
+				GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, backupPolicy, func() {
+				backupPolicy.Status.Phase = dpv1alpha1.AvailablePhase
+			})).Should(Succeed())
+		})
+
+		createRunningCluster := func(terminationPolicy appsv1alpha1.TerminationPolicyType, annotations ...string) {
+			cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, inPlaceClusterName, clusterDefinitionName, clusterVersionName).
+				AddComponent(statefulComp, statefulComp).
+				SetTerminationPolicy(terminationPolicy).
+				AddAnnotations(annotations...).
+				Create(&testCtx).GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
+				cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+			})).Should(Succeed())
+		}
+
+		createInPlaceRestoreOps := func() *OpsResource {
+			ops := testapps.NewOpsRequestObj(opsName, testCtx.DefaultNamespace, inPlaceClusterName, appsv1alpha1.RestoreType)
+			ops.Spec.RestoreSpec = &appsv1alpha1.RestoreSpec{BackupName: backupName, InPlace: true}
+			return &OpsResource{OpsRequest: testapps.CreateOpsRequest(ctx, testCtx, ops), Recorder: eventRecorder}
+		}
+
+		expectRequeue := func(cluster *appsv1alpha1.Cluster, err error) {
+			Expect(cluster).Should(BeNil())
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRequeue)).Should(BeTrue(), "expect to requeue, but got error: %v", err)
+		}
+
+		listPreRestoreBackups := func() []dpv1alpha1.Backup {
+			backups := &dpv1alpha1.BackupList{}
+			Expect(k8sClient.List(ctx, backups, client.InNamespace(testCtx.DefaultNamespace),
+				client.MatchingLabels{constant.OpsRequestTypeLabelKey: string(appsv1alpha1.RestoreType)})).Should(Succeed())
+			return backups.Items
+		}
+
+		getPreRestoreBackup := func() *dpv1alpha1.Backup {
+			backups := listPreRestoreBackups()
+			Expect(backups).Should(HaveLen(1), "expect exactly one pre-restore backup")
+			return &backups[0]
+		}
+
+		clusterKey := func() client.ObjectKey {
+			return client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: inPlaceClusterName}
+		}
+
+		It("backs up, deletes and re-creates the cluster", func() {
+			createRunningCluster(appsv1alpha1.Delete)
+			opsRes := createInPlaceRestoreOps()
+
+			By("create the pre-restore backup")
+			cluster, err := handler.prepareInPlaceRestore(reqCtx, k8sClient, opsRes)
+			expectRequeue(cluster, err)
+			preRestoreBackup := getPreRestoreBackup()
+			Expect(opsRes.OpsRequest.Status.BackupStatus).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.BackupStatus.BackupName).Should(Equal(preRestoreBackup.Name))
+
+			By("resume while the pre-restore backup is running, no new backup is created and the cluster is kept")
+			cluster, err = handler.prepareInPlaceRestore(reqCtx, k8sClient, opsRes)
+			expectRequeue(cluster, err)
+			getPreRestoreBackup()
+			Eventually(testapps.CheckObjExists(&testCtx, clusterKey(), &appsv1alpha1.Cluster{}, true)).Should(Succeed())
+
+			By("delete the cluster after the pre-restore backup completed")
+			Expect(testapps.ChangeObjStatus(&testCtx, preRestoreBackup, func() {
+				preRestoreBackup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+			})).Should(Succeed())
+			cluster, err = handler.prepareInPlaceRestore(reqCtx, k8sClient, opsRes)
+			expectRequeue(cluster, err)
+			Eventually(testapps.CheckObjExists(&testCtx, clusterKey(), &appsv1alpha1.Cluster{}, false)).Should(Succeed())
+			Expect(opsRes.OpsRequest.Status.BackupStatus.Phase).Should(Equal(dpv1alpha1.BackupPhaseCompleted))
+
+			By("resume after the cluster deleted, it should be re-created from the backup")
+			cluster, err = handler.prepareInPlaceRestore(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(cluster).Should(BeNil())
+
+			By("resume after the cluster re-created by the ops, the cluster is returned")
+			recorders, _ := json.Marshal([]appsv1alpha1.OpsRecorder{{Name: opsName, Type: appsv1alpha1.RestoreType}})
+			createRunningCluster(appsv1alpha1.Delete, constant.OpsRequestAnnotationKey, string(recorders))
+			cluster, err = handler.prepareInPlaceRestore(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(cluster).ShouldNot(BeNil())
+			Expect(cluster.Name).Should(Equal(inPlaceClusterName))
+			getPreRestoreBackup()
+		})
+
+		It("fails if the pre-restore backup failed", func() {
+			createRunningCluster(appsv1alpha1.Delete)
+			opsRes := createInPlaceRestoreOps()
+			cluster, err := handler.prepareInPlaceRestore(reqCtx, k8sClient, opsRes)
+			expectRequeue(cluster, err)
+			preRestoreBackup := getPreRestoreBackup()
+			Expect(testapps.ChangeObjStatus(&testCtx, preRestoreBackup, func() {
+				preRestoreBackup.Status.Phase = dpv1alpha1.BackupPhaseFailed
+			})).Should(Succeed())
+
+			_, err = handler.prepareInPlaceRestore(reqCtx, k8sClient, opsRes)
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue(), "expect a fatal error, but got: %v", err)
+			Eventually(testapps.CheckObjExists(&testCtx, clusterKey(), &appsv1alpha1.Cluster{}, true)).Should(Succeed())
+		})
+
+		It("rejects the cluster which is unsafe to restore in place", func() {
+			createRunningCluster(appsv1alpha1.WipeOut)
+			opsRes := createInPlaceRestoreOps()
+			_, err := handler.prepareInPlaceRestore(reqCtx, k8sClient, opsRes)
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue(), "expect a fatal error, but got: %v", err)
+			Expect(listPreRestoreBackups()).Should(BeEmpty(), "expect no pre-restore backup created")
+		})
+	})
+
+	Context("Test restore takeover", func() {
+		var (
+			restoredClusterName string
+			opsName             string
+			reqCtx              intctrlutil.RequestCtx
+		)
+
+		BeforeEach(func() {
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("create the completed backup with the snapshot of the source cluster")
+			source := testapps.NewClusterFactory(testCtx.DefaultNamespace, "source-cluster-"+testCtx.GetRandomStr(),
+				clusterDefinitionName, clusterVersionName).
+				AddComponent(statefulComp, statefulComp).
+				SetReplicas(1).
+				AddLabels(testCtx.TestObjLabelKey, "true").
+				GetObject()
+			snapshot, _ := json.Marshal(source)
+			backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, backupName).
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				SetBackupMethod(testdp.VSBackupMethodName).
+				AddAnnotations(constant.ClusterSnapshotAnnotationKey, string(snapshot)).
+				Create(&testCtx).GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, backup, func() {
+				backup.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+			})).Should(Succeed())
+		})
+
+		createRestoreOps := func() {
+			restoredClusterName = "restored-cluster-" + testCtx.GetRandomStr()
+			opsName = "restore-ops-" + testCtx.GetRandomStr()
+			createRestoreOpsObj(restoredClusterName, opsName, backupName)
+		}
+
+		// each leader reads the OpsRequest from the persisted state.
+		restoreAction := func(cli client.Client) error {
+			ops := &appsv1alpha1.OpsRequest{}
+			if err := cli.Get(ctx, client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: opsName}, ops); err != nil {
+				return err
+			}
+			return RestoreOpsHandler{}.Action(reqCtx, cli, &OpsResource{OpsRequest: ops, Recorder: eventRecorder})
+		}
+
+		It("restores the cluster once if the operator leadership moves", func() {
+			for writes := 0; ; writes++ {
+				createRestoreOps()
+				lost, err := testk8s.RunTakeover(k8sClient, writes, restoreAction)
+				Expect(err).ShouldNot(HaveOccurred(), "takeover after %d writes", writes)
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: opsName},
+					func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+						g.Expect(ops.OwnerReferences).Should(HaveLen(1))
+						g.Expect(ops.OwnerReferences[0].Name).Should(Equal(restoredClusterName),
+							"takeover after %d writes: expect the OpsRequest owned by the restored cluster", writes)
+					})).Should(Succeed())
+				if !lost {
+					break
+				}
+			}
+		})
+
+		It("never takes over the existing cluster which is not restored by the OpsRequest", func() {
+			createRestoreOps()
+			testapps.NewClusterFactory(testCtx.DefaultNamespace, restoredClusterName, clusterDefinitionName, clusterVersionName).
+				AddComponent(statefulComp, statefulComp).
+				Create(&testCtx)
+			err := restoreAction(k8sClient)
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue(), "expect a fatal error, but got: %v", err)
+		})
 	})
 })
 
@@ -37,7 +37,8 @@ var _ OpsHandler = StartOpsHandler{}
 
 func init() {
 	stopBehaviour := OpsBehaviour{
-		FromClusterPhases: []appsv1alpha1.ClusterPhase{appsv1alpha1.StoppedClusterPhase},
+		// the cluster is running if only some components are stopped.
+		FromClusterPhases: append(appsv1alpha1.GetClusterUpRunningPhases(), appsv1alpha1.StoppedClusterPhase),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		OpsHandler:        StartOpsHandler{},
 	}
@@ -54,12 +55,17 @@ func (start StartOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCt
 // Action modifies Cluster.spec.components[*].replicas from the opsRequest
 func (start StartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	cluster := opsRes.Cluster
+	compNameSet := opsRes.OpsRequest.Spec.GetStartComponentNameSet()
 	componentReplicasMap, err := start.getComponentReplicasSnapshot(cluster.Annotations)
 	if err != nil {
 		return err
 	}
 	for i, v := range cluster.Spec.ComponentSpecs {
+		if !isComponentInScope(compNameSet, v.Name) {
+			continue
+		}
 		replicasOfSnapshot := componentReplicasMap[v.Name]
+		delete(componentReplicasMap, v.Name)
 		if replicasOfSnapshot == 0 {
 			continue
 		}
@@ -68,8 +74,16 @@ func (start StartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Cl
 			cluster.Spec.ComponentSpecs[i].Replicas = replicasOfSnapshot
 		}
 	}
-	// delete the replicas snapshot of components from the cluster.
-	delete(cluster.Annotations, constant.SnapShotForStartAnnotationKey)
+	// delete the replicas snapshot of the started components from the cluster.
+	if len(compNameSet) == 0 || len(componentReplicasMap) == 0 {
+		delete(cluster.Annotations, constant.SnapShotForStartAnnotationKey)
+	} else {
+		componentReplicasSnapshot, err := json.Marshal(componentReplicasMap)
+		if err != nil {
+			return err
+		}
+		cluster.Annotations[constant.SnapShotForStartAnnotationKey] = string(componentReplicasSnapshot)
+	}
 	return cli.Update(reqCtx.Ctx, cluster)
 }
 
@@ -103,6 +117,12 @@ func (start StartOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx
 	if err != nil {
 		return err
 	}
+	compNameSet := opsRequest.Spec.GetStartComponentNameSet()
+	for compName := range componentReplicasMap {
+		if !isComponentInScope(compNameSet, compName) {
+			delete(componentReplicasMap, compName)
+		}
+	}
 	if err = start.setOpsAnnotation(reqCtx, cli, opsRes, componentReplicasMap); err != nil {
 		return err
 	}
@@ -112,4 +112,74 @@ var _ = Describe("Start OpsRequest", func() {
 		})
 
 	})
+
+	Context("Test stopping and starting the individual components", func() {
+		var (
+			cluster *appsv1alpha1.Cluster
+			reqCtx  intctrlutil.RequestCtx
+		)
+
+		BeforeEach(func() {
+			reqCtx = intctrlutil.RequestCtx{Ctx: ctx}
+			cluster = testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefinitionName, clusterVersionName).
+				AddComponent(statefulComp, statefulComp).
+				SetReplicas(3).
+				AddComponent(statelessComp, statelessComp).
+				SetReplicas(2).
+				Create(&testCtx).GetObject()
+		})
+
+		newOpsRes := func(opsType appsv1alpha1.OpsType, compNames ...string) *OpsResource {
+			ops := testapps.NewOpsRequestObj("ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace, clusterName, opsType)
+			for _, compName := range compNames {
+				compOps := appsv1alpha1.ComponentOps{ComponentName: compName}
+				if opsType == appsv1alpha1.StopType {
+					ops.Spec.StopList = append(ops.Spec.StopList, compOps)
+				} else {
+					ops.Spec.StartList = append(ops.Spec.StartList, compOps)
+				}
+			}
+			latest := &appsv1alpha1.Cluster{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), latest)).Should(Succeed())
+			return &OpsResource{OpsRequest: ops, Cluster: latest, Recorder: eventRecorder}
+		}
+
+		expectState := func(opsRes *OpsResource, expectedReplicas, expectedSnapshot map[string]int32) {
+			for _, comp := range opsRes.Cluster.Spec.ComponentSpecs {
+				Expect(comp.Replicas).Should(Equal(expectedReplicas[comp.Name]), "replicas of component %s", comp.Name)
+			}
+			if len(expectedSnapshot) == 0 {
+				Expect(opsRes.Cluster.Annotations).ShouldNot(HaveKey(constant.SnapShotForStartAnnotationKey))
+				return
+			}
+			snapshot, err := StartOpsHandler{}.getComponentReplicasSnapshot(opsRes.Cluster.Annotations)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(snapshot).Should(Equal(expectedSnapshot))
+		}
+
+		It("keeps the replicas snapshot of the components out of the scope", func() {
+			By("stop the stateless component only, its last configuration is recorded")
+			opsRes := newOpsRes(appsv1alpha1.StopType, statelessComp)
+			Expect(StopOpsHandler{}.SaveLastConfiguration(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Expect(opsRes.OpsRequest.Status.LastConfiguration.Components).ShouldNot(HaveKey(statefulComp))
+			Expect(opsRes.OpsRequest.Status.LastConfiguration.Components).Should(HaveKey(statelessComp))
+			Expect(StopOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			expectState(opsRes, map[string]int32{statefulComp: 3, statelessComp: 0}, map[string]int32{statelessComp: 2})
+
+			By("stop the whole cluster, the snapshot of the stopped stateless component is kept")
+			opsRes = newOpsRes(appsv1alpha1.StopType)
+			Expect(StopOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			expectState(opsRes, map[string]int32{statefulComp: 0, statelessComp: 0}, map[string]int32{statefulComp: 3, statelessComp: 2})
+
+			By("start the stateless component only, the snapshot of the stateful component is kept")
+			opsRes = newOpsRes(appsv1alpha1.StartType, statelessComp)
+			Expect(StartOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			expectState(opsRes, map[string]int32{statefulComp: 0, statelessComp: 2}, map[string]int32{statefulComp: 3})
+
+			By("start the whole cluster, the snapshot is removed")
+			opsRes = newOpsRes(appsv1alpha1.StartType)
+			Expect(StartOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			expectState(opsRes, map[string]int32{statefulComp: 3, statelessComp: 2}, nil)
+		})
+	})
 })
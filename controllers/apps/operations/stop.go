@@ -54,14 +54,23 @@ func (stop StopOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx,
 // Action modifies Cluster.spec.components[*].replicas from the opsRequest
 func (stop StopOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	var (
-		expectReplicas       = int32(0)
-		componentReplicasMap = map[string]int32{}
-		cluster              = opsRes.Cluster
+		expectReplicas = int32(0)
+		cluster        = opsRes.Cluster
+		compNameSet    = opsRes.OpsRequest.Spec.GetStopComponentNameSet()
 	)
-	if _, ok := cluster.Annotations[constant.SnapShotForStartAnnotationKey]; ok {
-		return nil
+	// merge into the snapshot of the components which have been stopped before.
+	componentReplicasMap, err := StartOpsHandler{}.getComponentReplicasSnapshot(cluster.Annotations)
+	if err != nil {
+		return err
 	}
 	for i, v := range cluster.Spec.ComponentSpecs {
+		if !isComponentInScope(compNameSet, v.Name) {
+			continue
+		}
+		// the component has been stopped, keep the replicas of snapshot.
+		if v.Replicas == 0 {
+			continue
+		}
 		componentReplicasMap[v.Name] = v.Replicas
 		cluster.Spec.ComponentSpecs[i].Replicas = expectReplicas
 	}
@@ -102,8 +111,9 @@ func (stop StopOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 func (stop StopOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	opsRequest := opsRes.OpsRequest
 	lastComponentInfo := map[string]appsv1alpha1.LastComponentConfiguration{}
+	compNameSet := opsRequest.Spec.GetStopComponentNameSet()
 	for _, v := range opsRes.Cluster.Spec.ComponentSpecs {
-		if v.Replicas != 0 {
+		if v.Replicas != 0 && isComponentInScope(compNameSet, v.Name) {
 			podNames, err := getCompPodNamesBeforeScaleDownReplicas(reqCtx, cli, *opsRes.Cluster, v.Name)
 			if err != nil {
 				return err
@@ -120,3 +130,13 @@ func (stop StopOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	opsRequest.Status.LastConfiguration.Components = lastComponentInfo
 	return nil
 }
+
+// isComponentInScope checks whether the component is in the scope of the cluster-wide operation,
+// all components are in the scope if no component is specified.
+func isComponentInScope(compNameSet appsv1alpha1.ComponentNameSet, compName string) bool {
+	if len(compNameSet) == 0 {
+		return true
+	}
+	_, ok := compNameSet[compName]
+	return ok
+}
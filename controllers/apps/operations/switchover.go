@@ -125,6 +125,10 @@ func doSwitchoverComponents(reqCtx intctrlutil.RequestCtx, cli client.Client, op
 		opsRequest.Status.Components = make(map[string]appsv1alpha1.OpsRequestComponentStatus)
 	}
 	for _, switchover := range switchoverList {
+		// the component has been handled before the operator leadership moved, the job is checked by ReconcileAction.
+		if _, ok := opsRequest.Status.Components[switchover.ComponentName]; ok {
+			continue
+		}
 		compSpec := opsRes.Cluster.Spec.GetComponentByName(switchover.ComponentName)
 		synthesizedComp, err := component.BuildSynthesizedComponentWrapper(reqCtx, cli, opsRes.Cluster, compSpec)
 		if err != nil {
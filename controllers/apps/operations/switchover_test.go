@@ -241,5 +241,39 @@ var _ = Describe("", func() {
 			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("Test switchover action resumed by the new leader", func() {
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			clusterObj = testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName,
+				clusterDefObj.Name, clusterVersionObj.Name).WithRandomName().
+				AddComponent(consensusComp, consensusComp).
+				SetReplicas(2).
+				Create(&testCtx).GetObject()
+
+			By("the switchover job has been created by the previous leader, which saved the component status")
+			ops := testapps.NewOpsRequestObj("ops-switchover-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+				clusterObj.Name, appsv1alpha1.SwitchoverType)
+			ops.Spec.SwitchoverList = []appsv1alpha1.Switchover{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					InstanceName: fmt.Sprintf("%s-%s-%d", clusterObj.Name, consensusComp, 1),
+				},
+			}
+			ops = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			Expect(testapps.ChangeObjStatus(&testCtx, ops, func() {
+				ops.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{
+					consensusComp: {Phase: appsv1alpha1.UpdatingClusterCompPhase},
+				}
+			})).Should(Succeed())
+			opsRes := &OpsResource{
+				OpsRequest: ops,
+				Cluster:    clusterObj,
+				Recorder:   k8sManager.GetEventRecorderFor("opsrequest-controller"),
+			}
+
+			By("the component is not evaluated again by the new leader, whose primary may have been switched already")
+			Expect(switchoverOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Expect(ops.Status.Components[consensusComp].Phase).Should(Equal(appsv1alpha1.UpdatingClusterCompPhase))
+		})
 	})
 })
@@ -35,6 +35,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -45,7 +46,7 @@ const (
 	KBSwitchoverCandidateInstanceForAnyPod = "*"
 
 	KBJobTTLSecondsAfterFinished  = 5
-	KBSwitchoverJobLabelKey       = "kubeblocks.io/switchover-job"
+	KBSwitchoverJobLabelKey       = constant.SwitchoverJobLabelKey
 	KBSwitchoverJobLabelValue     = "kb-switchover-job"
 	KBSwitchoverJobNamePrefix     = "kb-switchover-job"
 	KBSwitchoverJobContainerName  = "kb-switchover-job-container"
@@ -55,6 +56,10 @@ const (
 	KBSwitchoverCandidateName = "KB_SWITCHOVER_CANDIDATE_NAME"
 	KBSwitchoverCandidateFqdn = "KB_SWITCHOVER_CANDIDATE_FQDN"
 
+	// KBLeaderPod and KBTargetPod are the engine-agnostic variables of the promote and demote actions.
+	KBLeaderPod = "KB_LEADER_POD"
+	KBTargetPod = "KB_TARGET_POD"
+
 	// KBSwitchoverReplicationPrimaryPodIP and the others Replication and Consensus switchover constants will be deprecated in the future, use KBSwitchoverLeaderPodIP instead.
 	KBSwitchoverReplicationPrimaryPodIP   = "KB_REPLICATION_PRIMARY_POD_IP"
 	KBSwitchoverReplicationPrimaryPodName = "KB_REPLICATION_PRIMARY_POD_NAME"
@@ -131,6 +136,7 @@ func createSwitchoverJob(reqCtx intctrlutil.RequestCtx,
 			}
 		}
 		// create the current generation switchoverJob
+		tracing.InjectJob(reqCtx.Ctx, switchoverJob)
 		if err := cli.Create(reqCtx.Ctx, switchoverJob); err != nil {
 			return err
 		}
@@ -265,8 +271,9 @@ func renderSwitchoverCmdJob(ctx context.Context,
 			Spec: batchv1.JobSpec{
 				Template: corev1.PodTemplateSpec{
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: cluster.Namespace,
-						Name:      jobName,
+						Namespace:   cluster.Namespace,
+						Name:        jobName,
+						Annotations: intctrlutil.BuildClusterServiceMeshJobAnnotations(cluster),
 					},
 					Spec: corev1.PodSpec{
 						Volumes:       volumes,
@@ -292,6 +299,9 @@ func renderSwitchoverCmdJob(ctx context.Context,
 		if len(cluster.Spec.Tolerations) > 0 {
 			job.Spec.Template.Spec.Tolerations = cluster.Spec.Tolerations
 		}
+		if err := intctrlutil.ApplyDataPlaneSecurityContext(&job.Spec.Template.Spec); err != nil {
+			return nil, err
+		}
 		return job, nil
 	}
 
@@ -342,6 +352,10 @@ func buildSwitchoverCandidateEnv(
 			Name:  KBSwitchoverCandidateFqdn,
 			Value: fmt.Sprintf("%s.%s", switchover.InstanceName, svcName),
 		},
+		{
+			Name:  KBTargetPod,
+			Value: switchover.InstanceName,
+		},
 	}
 }
 
@@ -430,6 +444,10 @@ func buildSwitchoverWorkloadEnvs(ctx context.Context,
 			Name:  KBSwitchoverLeaderPodFqdn,
 			Value: fmt.Sprintf("%s.%s", pod.Name, svcName),
 		},
+		{
+			Name:  KBLeaderPod,
+			Value: pod.Name,
+		},
 	}...)
 
 	// TODO(xingran): backward compatibility for the old env based on workloadType, it will be removed in the future
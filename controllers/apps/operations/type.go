@@ -75,6 +75,8 @@ type reconfigureParams struct {
 	opsRequest          *appsv1alpha1.OpsRequest
 	configurationItem   appsv1alpha1.ConfigurationItem
 	configurationStatus *appsv1alpha1.ReconfiguringStatus
+
+	rollbackTimeoutSeconds *int32
 }
 
 type OpsResource struct {
@@ -54,7 +54,11 @@ func (u upgradeOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx,
 }
 
 // Action modifies Cluster.spec.clusterVersionRef with opsRequest.spec.upgrade.clusterVersionRef
+// after the pre-checks declared in the target ClusterVersion are passed.
 func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if err := reconcileUpgradePreChecks(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
 	opsRes.Cluster.Spec.ClusterVersionRef = opsRes.OpsRequest.Spec.Upgrade.ClusterVersionRef
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
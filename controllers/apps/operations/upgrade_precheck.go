@@ -0,0 +1,228 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	componetutil "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// reconcileUpgradePreChecks runs the pre-checks declared in the target ClusterVersion for the components to be upgraded,
+// each component has a job which runs its checks as containers.
+// It returns a requeue error until all jobs are finished, and a fatal error with the findings if any check fails.
+func reconcileUpgradePreChecks(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if meta.IsStatusConditionTrue(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypeUpgradePreCheck) {
+		return nil
+	}
+	targetClusterVersion := &appsv1alpha1.ClusterVersion{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRequest.Spec.Upgrade.ClusterVersionRef}, targetClusterVersion); err != nil {
+		return err
+	}
+	preChecksMap := map[string][]appsv1alpha1.UpgradePreCheck{}
+	for _, v := range targetClusterVersion.Spec.ComponentVersions {
+		preChecksMap[v.ComponentDefRef] = v.UpgradePreChecks
+	}
+
+	var (
+		findings []string
+		finished = true
+	)
+	for i := range opsRes.Cluster.Spec.ComponentSpecs {
+		compSpec := &opsRes.Cluster.Spec.ComponentSpecs[i]
+		preChecks := preChecksMap[compSpec.ComponentDefRef]
+		if _, ok := opsRequest.Status.Components[compSpec.Name]; !ok || len(preChecks) == 0 {
+			continue
+		}
+		job, err := createUpgradePreCheckJobIfNotExist(reqCtx, cli, opsRes, compSpec, preChecks)
+		if err != nil {
+			return err
+		}
+		switch {
+		case isJobConditionTrue(job, batchv1.JobComplete):
+			continue
+		case isJobConditionTrue(job, batchv1.JobFailed):
+			compFindings, err := getUpgradePreCheckFindings(reqCtx, cli, job, compSpec.Name)
+			if err != nil {
+				return err
+			}
+			findings = append(findings, compFindings...)
+		default:
+			finished = false
+		}
+	}
+	if len(findings) > 0 {
+		return intctrlutil.NewFatalError(fmt.Sprintf("upgrade pre-checks failed: %s", strings.Join(findings, "; ")))
+	}
+	if !finished {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "wait for the upgrade pre-checks to finish")
+	}
+	meta.SetStatusCondition(&opsRequest.Status.Conditions, *appsv1alpha1.NewUpgradePreCheckPassedCondition(opsRequest))
+	return nil
+}
+
+func createUpgradePreCheckJobIfNotExist(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1alpha1.ClusterComponentSpec,
+	preChecks []appsv1alpha1.UpgradePreCheck) (*batchv1.Job, error) {
+	opsRequest := opsRes.OpsRequest
+	cluster := opsRes.Cluster
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: getUpgradePreCheckJobName(opsRequest.Name, compSpec.Name)}
+	job := &batchv1.Job{}
+	exist, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, cli, key, job)
+	if err != nil || exist {
+		return job, err
+	}
+
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					Key:                  key,
+					LocalObjectReference: corev1.LocalObjectReference{Name: constant.GenerateDefaultConnCredential(cluster.Name)},
+					Optional:             pointer.Bool(true),
+				},
+			},
+		}
+	}
+	clusterDef, err := getClusterDefByName(reqCtx.Ctx, cli, cluster.Spec.ClusterDefRef)
+	if err != nil {
+		return nil, err
+	}
+	envs := []corev1.EnvVar{
+		{Name: "KB_CLUSTER_NAME", Value: cluster.Name},
+		{Name: "KB_COMP_NAME", Value: compSpec.Name},
+		{Name: "KB_HOST", Value: clusterDef.Spec.NamingTemplate.GenerateComponentServiceName(cluster.Name, compSpec.Name, compSpec.ComponentDefRef, "")},
+		secretEnv("KB_USER", "username"),
+		secretEnv("KB_PASSWD", "password"),
+		{Name: "KB_CLUSTER_VERSION", Value: cluster.Spec.ClusterVersionRef},
+		{Name: "KB_TARGET_CLUSTER_VERSION", Value: opsRequest.Spec.Upgrade.ClusterVersionRef},
+	}
+	containers := make([]corev1.Container, 0, len(preChecks))
+	for _, preCheck := range preChecks {
+		container := corev1.Container{
+			Name:                     preCheck.Name,
+			Image:                    preCheck.Image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Command:                  preCheck.Command,
+			Args:                     preCheck.Args,
+			Env:                      envs,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		}
+		intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+		containers = append(containers, container)
+	}
+	tolerations, err := componetutil.BuildTolerations(cluster, compSpec)
+	if err != nil {
+		return nil, err
+	}
+	job = &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    cluster.Name,
+				constant.KBAppComponentLabelKey: compSpec.Name,
+				constant.OpsRequestNameLabelKey: opsRequest.Name,
+				constant.OpsRequestTypeLabelKey: string(appsv1alpha1.UpgradeType),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			// set backoff limit to 0, the findings of the failed checks are reported directly.
+			BackoffLimit: pointer.Int32(0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: intctrlutil.BuildClusterServiceMeshJobAnnotations(cluster),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    containers,
+					Tolerations:   tolerations,
+				},
+			},
+		},
+	}
+	if err = intctrlutil.ApplyDataPlaneSecurityContext(&job.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	// set the controller reference, so that the OpsRequest will be reconciled when the job is finished.
+	if err = intctrlutil.SetControllerReference(opsRequest, job); err != nil {
+		return nil, err
+	}
+	if err = cli.Create(reqCtx.Ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// getUpgradePreCheckFindings gets the termination messages of the failed checks.
+func getUpgradePreCheckFindings(reqCtx intctrlutil.RequestCtx, cli client.Client, job *batchv1.Job, compName string) ([]string, error) {
+	podList := &corev1.PodList{}
+	if err := cli.List(reqCtx.Ctx, podList, client.InNamespace(job.Namespace),
+		client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return nil, err
+	}
+	var findings []string
+	for _, pod := range podList.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			findings = append(findings, fmt.Sprintf("component %s check %s: %s", compName, status.Name, strings.TrimSpace(terminated.Message)))
+		}
+	}
+	if len(findings) == 0 {
+		findings = append(findings, fmt.Sprintf("component %s: job %s failed", compName, job.Name))
+	}
+	return findings, nil
+}
+
+func getUpgradePreCheckJobName(opsName, compName string) string {
+	jobName := fmt.Sprintf("%s-precheck-%s", opsName, compName)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return jobName
+}
+
+func isJobConditionTrue(job *batchv1.Job, condType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == condType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
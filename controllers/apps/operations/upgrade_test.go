@@ -23,6 +23,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -55,6 +57,7 @@ var _ = Describe("Upgrade OpsRequest", func() {
 		ml := client.HasLabels{testCtx.TestObjLabelKey}
 		// namespaced
 		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.JobSignature, inNS, ml)
 	}
 
 	BeforeEach(cleanEnv)
@@ -96,5 +99,52 @@ var _ = Describe("Upgrade OpsRequest", func() {
 			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("Test upgrade OpsRequest with pre-checks", func() {
+			By("init operations resources ")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, _, clusterObject := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+
+			By("create Upgrade Ops with the pre-checks declared in the target ClusterVersion")
+			newClusterVersionName := "clusterversion-upgrade-" + randomStr
+			_ = testapps.NewClusterVersionFactory(newClusterVersionName, clusterDefinitionName).
+				AddComponentVersion(consensusComp).AddContainerShort(testapps.DefaultMySQLContainerName, mysqlImageForUpdate).
+				AddUpgradePreCheck("disk-space", mysqlImageForUpdate, "/bin/sh", "-c", "df -h").
+				Create(&testCtx).GetObject()
+			ops := testapps.NewOpsRequestObj("upgrade-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterObject.Name, appsv1alpha1.UpgradeType)
+			ops.Spec.Upgrade = &appsv1alpha1.Upgrade{ClusterVersionRef: newClusterVersionName}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+
+			By("expect the upgrade to wait for the pre-check job")
+			res, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).ShouldNot(BeNil())
+			Expect(res.RequeueAfter).ShouldNot(BeZero())
+			jobKey := client.ObjectKey{Name: getUpgradePreCheckJobName(ops.Name, consensusComp), Namespace: testCtx.DefaultNamespace}
+			Eventually(testapps.CheckObjExists(&testCtx, jobKey, &batchv1.Job{}, true)).Should(Succeed())
+			Expect(opsRes.Cluster.Spec.ClusterVersionRef).Should(Equal(clusterVersionName))
+
+			By("mock the pre-check job failed and expect the upgrade is aborted")
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, jobKey, job)).Should(Succeed())
+			Expect(testapps.ChangeObjStatus(&testCtx, job, func() {
+				job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+					Type:   batchv1.JobFailed,
+					Status: corev1.ConditionTrue,
+				})
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, jobKey, func(g Gomega, job *batchv1.Job) {
+				g.Expect(job.Status.Conditions).ShouldNot(BeEmpty())
+			})).Should(Succeed())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			Expect(opsRes.Cluster.Spec.ClusterVersionRef).Should(Equal(clusterVersionName))
+		})
 	})
 })
@@ -21,11 +21,12 @@ package apps
 
 import (
 	"context"
-	"math"
 	"reflect"
+	"strings"
 	"time"
 
 	"golang.org/x/exp/slices"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -35,7 +36,6 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -47,6 +47,7 @@ import (
 	"github.com/apecloud/kubeblocks/controllers/apps/operations"
 	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -61,12 +62,16 @@ type OpsRequestReconciler struct {
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/finalizers,verbs=update
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
 func (r *OpsRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcileSpan(ctx, "opsrequest", req)
+	defer span.End()
+
 	reqCtx := intctrlutil.RequestCtx{
 		Ctx:      ctx,
 		Req:      req,
@@ -88,10 +93,8 @@ func (r *OpsRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 func (r *OpsRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&appsv1alpha1.OpsRequest{}).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: int(math.Ceil(viper.GetFloat64(constant.CfgKBReconcileWorkers) / 2)),
-		}).
-		Watches(&appsv1alpha1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.parseFirstOpsRequest)).
+		WithOptions(intctrlutil.NewControllerOptions(constant.CfgKeyOpsRequestReconcileWorkers, constant.CfgKBReconcileWorkers, 0.5)).
+		Watches(&appsv1alpha1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.parseQueuedOpsRequests)).
 		Watches(&workloadsv1alpha1.ReplicatedStateMachine{}, handler.EnqueueRequestsFromMapFunc(r.parseFirstOpsRequestForRSM)).
 		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.parseBackupOpsRequest)).
 		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestsFromMapFunc(r.parseVolumeExpansionOpsRequest)).
@@ -162,12 +165,20 @@ func (r *OpsRequestReconciler) fetchCluster(reqCtx intctrlutil.RequestCtx, opsRe
 func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	switch opsRes.OpsRequest.Status.Phase {
 	case "":
-		// update status.phase to pending
-		if err := operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsPendingPhase,
-			appsv1alpha1.NewWaitForProcessingCondition(opsRes.OpsRequest)); err != nil {
-			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		// the opsRequest waits for the approval if required
+		if isOpsApprovalRequired(opsRes) && opsRes.OpsRequest.Spec.Approval == nil {
+			if err := operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsPendingApprovalPhase,
+				appsv1alpha1.NewWaitForApprovalCondition(opsRes.OpsRequest)); err != nil {
+				return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+			}
+			return intctrlutil.ResultToP(intctrlutil.Reconciled())
 		}
-		return intctrlutil.ResultToP(intctrlutil.Reconciled())
+		return r.handleApprovedOpsRequest(reqCtx, opsRes)
+	case appsv1alpha1.OpsPendingApprovalPhase:
+		if opsRes.OpsRequest.Spec.Approval == nil {
+			return intctrlutil.ResultToP(intctrlutil.Reconciled())
+		}
+		return r.handleApprovedOpsRequest(reqCtx, opsRes)
 	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsCreatingPhase:
 		return r.doOpsRequestAction(reqCtx, opsRes)
 	case appsv1alpha1.OpsRunningPhase, appsv1alpha1.OpsCancellingPhase:
@@ -175,11 +186,94 @@ func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.Reques
 	case appsv1alpha1.OpsSucceedPhase:
 		return r.handleSucceedOpsRequest(reqCtx, opsRes.OpsRequest)
 	case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase:
+		return r.reclaimOpsRequestAfterTTL(reqCtx, opsRes.OpsRequest, opsRes.OpsRequest.Spec.TTLSecondsAfterFailed)
+	}
+	return intctrlutil.ResultToP(intctrlutil.Reconciled())
+}
+
+// isOpsApprovalRequired checks whether the OpsRequest requires an approval, which is required by the OpsRequest itself,
+// by the operator for its type, or by the cluster for its type.
+func isOpsApprovalRequired(opsRes *operations.OpsResource) bool {
+	if opsRes.OpsRequest.Spec.ApprovalRequired {
+		return true
+	}
+	containsOpsType := func(types string) bool {
+		for _, t := range strings.Split(types, ",") {
+			if strings.TrimSpace(t) == string(opsRes.OpsRequest.Spec.Type) {
+				return true
+			}
+		}
+		return false
+	}
+	if containsOpsType(viper.GetString(constant.CfgKeyOpsApprovalRequiredTypes)) {
+		return true
+	}
+	return opsRes.Cluster != nil && containsOpsType(opsRes.Cluster.Annotations[constant.OpsApprovalRequiredAnnotationKey])
+}
+
+// handleApprovedOpsRequest records the approval if exists and updates status.phase to Pending,
+// the rejected opsRequest is cancelled without being executed.
+func (r *OpsRequestReconciler) handleApprovedOpsRequest(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
+	opsRequest := opsRes.OpsRequest
+	opsDeepCopy := opsRequest.DeepCopy()
+	if opsRequest.Spec.Approval != nil {
+		allowed, err := r.verifyApprover(reqCtx, opsRequest)
+		if err != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		}
+		if !allowed {
+			// the OpsRequest keeps waiting for an approval by the allowed user.
+			if err = operations.PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, r.Client, opsRes, opsDeepCopy,
+				appsv1alpha1.OpsPendingApprovalPhase, appsv1alpha1.NewApprovalDeniedCondition(opsRequest)); err != nil {
+				return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+			}
+			return intctrlutil.ResultToP(intctrlutil.Reconciled())
+		}
+	}
+	if opsRequest.Spec.Approval != nil && opsRequest.Spec.Approval.Rejected {
+		if err := operations.PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, r.Client, opsRes, opsDeepCopy,
+			appsv1alpha1.OpsCancelledPhase, appsv1alpha1.NewRejectedCondition(opsRequest)); err != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
 	}
+	conditions := []*metav1.Condition{appsv1alpha1.NewWaitForProcessingCondition(opsRequest)}
+	if opsRequest.Spec.Approval != nil {
+		opsRequest.Status.Approval = &appsv1alpha1.OpsApprovalStatus{
+			Approver:          opsRequest.Spec.Approval.Approver,
+			ApprovalTimestamp: metav1.Now(),
+		}
+		conditions = append(conditions, appsv1alpha1.NewApprovedCondition(opsRequest))
+	}
+	if err := operations.PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, r.Client, opsRes, opsDeepCopy,
+		appsv1alpha1.OpsPendingPhase, conditions...); err != nil {
+		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	}
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
 }
 
+// verifyApprover checks whether the approver is allowed to approve the OpsRequest by a SubjectAccessReview.
+// The approval is checked against the requesting user by the webhook, but the webhook may be disabled,
+// so the controller doesn't trust it and checks the approver again.
+func (r *OpsRequestReconciler) verifyApprover(reqCtx intctrlutil.RequestCtx, opsRequest *appsv1alpha1.OpsRequest) (bool, error) {
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User: opsRequest.Spec.Approval.Approver,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: opsRequest.Namespace,
+				Verb:      "approve",
+				Group:     appsv1alpha1.GroupVersion.Group,
+				Resource:  "opsrequests",
+				Name:      opsRequest.Name,
+			},
+		},
+	}
+	if err := r.Client.Create(reqCtx.Ctx, sar); err != nil {
+		return false, err
+	}
+	return sar.Status.Allowed, nil
+}
+
 // handleCancelSignal handles the cancel signal for opsRequest.
 func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	opsRequest := opsRes.OpsRequest
@@ -189,6 +283,14 @@ func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx,
 	if opsRequest.IsComplete() || opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 		return nil, nil
 	}
+	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingApprovalPhase {
+		// nothing is executed before the approval, so it's cancelled directly even if the type does not support cancel.
+		if err := operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsCancelledPhase,
+			appsv1alpha1.NewCancelSucceedCondition(opsRequest.Name)); err != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		}
+		return intctrlutil.ResultToP(intctrlutil.Reconciled())
+	}
 	opsBehaviour := operations.GetOpsManager().OpsMap[opsRequest.Spec.Type]
 	if opsBehaviour.CancelFunc == nil {
 		r.Recorder.Eventf(opsRequest, corev1.EventTypeWarning, reasonOpsCancelActionNotSupported,
@@ -208,19 +310,24 @@ func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx,
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
 }
 
-// handleSucceedOpsRequest the opsRequest will be deleted after one hour when status.phase is Succeed
+// handleSucceedOpsRequest the opsRequest will be deleted after spec.ttlSecondsAfterSucceed seconds when status.phase is Succeed
 func (r *OpsRequestReconciler) handleSucceedOpsRequest(reqCtx intctrlutil.RequestCtx, opsRequest *appsv1alpha1.OpsRequest) (*ctrl.Result, error) {
 	if err := r.deleteExternalJobs(reqCtx.Ctx, opsRequest); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
-	if opsRequest.Status.CompletionTimestamp.IsZero() || opsRequest.Spec.TTLSecondsAfterSucceed == 0 {
+	return r.reclaimOpsRequestAfterTTL(reqCtx, opsRequest, opsRequest.Spec.TTLSecondsAfterSucceed)
+}
+
+// reclaimOpsRequestAfterTTL deletes the completed opsRequest after ttlSeconds since its completion,
+// the opsRequest is kept if ttlSeconds is 0.
+func (r *OpsRequestReconciler) reclaimOpsRequestAfterTTL(reqCtx intctrlutil.RequestCtx, opsRequest *appsv1alpha1.OpsRequest, ttlSeconds int32) (*ctrl.Result, error) {
+	if opsRequest.Status.CompletionTimestamp.IsZero() || ttlSeconds == 0 {
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
 	}
-	deadline := opsRequest.Status.CompletionTimestamp.Add(time.Duration(opsRequest.Spec.TTLSecondsAfterSucceed) * time.Second)
+	deadline := opsRequest.Status.CompletionTimestamp.Add(time.Duration(ttlSeconds) * time.Second)
 	if time.Now().Before(deadline) {
 		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Until(deadline), reqCtx.Log, ""))
 	}
-	// the opsRequest will be deleted after spec.ttlSecondsAfterSucceed seconds when status.phase is Succeed
 	if err := r.Client.Delete(reqCtx.Ctx, opsRequest); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
@@ -340,9 +447,24 @@ func (r *OpsRequestReconciler) getFirstRequestsFromCluster(cluster *appsv1alpha1
 	return requests
 }
 
-func (r *OpsRequestReconciler) parseFirstOpsRequest(ctx context.Context, object client.Object) []reconcile.Request {
+// parseQueuedOpsRequests enqueues all OpsRequests in the queue of the cluster,
+// so that the pending ones can refresh their positions in the queue.
+func (r *OpsRequestReconciler) parseQueuedOpsRequests(ctx context.Context, object client.Object) []reconcile.Request {
 	cluster := object.(*appsv1alpha1.Cluster)
-	return r.getFirstRequestsFromCluster(cluster)
+	opsRequestSlice, err := opsutil.GetOpsRequestSliceFromCluster(cluster)
+	if err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(opsRequestSlice))
+	for _, v := range opsRequestSlice {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: cluster.Namespace,
+				Name:      v.Name,
+			},
+		})
+	}
+	return requests
 }
 
 func (r *OpsRequestReconciler) parseFirstOpsRequestForRSM(ctx context.Context, object client.Object) []reconcile.Request {
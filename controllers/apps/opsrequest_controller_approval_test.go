@@ -0,0 +1,242 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("OpsRequest approval", func() {
+	const (
+		clusterDefName = "test-clusterdef-approval"
+		compName       = "mysql"
+	)
+
+	var (
+		randomStr   string
+		clusterName string
+		approver    string
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+		viper.Set(constant.CfgKeyOpsApprovalRequiredTypes, "")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS, ml)
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+		if len(approver) > 0 {
+			testapps.DeleteObject(&testCtx, types.NamespacedName{Name: approver}, &rbacv1.ClusterRoleBinding{})
+			testapps.DeleteObject(&testCtx, types.NamespacedName{Name: approver}, &rbacv1.ClusterRole{})
+		}
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		randomStr = testCtx.GetRandomStr()
+		clusterName = "test-cluster-" + randomStr
+		approver = "test-approver-" + randomStr
+
+		By("allow only the approver to approve the OpsRequests")
+		Expect(testCtx.CreateObj(testCtx.Ctx, &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: approver},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{appsv1alpha1.GroupVersion.Group},
+				Resources: []string{"opsrequests"},
+				Verbs:     []string{"approve"},
+			}},
+		})).Should(Succeed())
+		Expect(testCtx.CreateObj(testCtx.Ctx, &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: approver},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: approver},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: approver}},
+		})).Should(Succeed())
+		Eventually(func(g Gomega) {
+			sar := &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					User: approver,
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: testCtx.DefaultNamespace,
+						Verb:      "approve",
+						Group:     appsv1alpha1.GroupVersion.Group,
+						Resource:  "opsrequests",
+					},
+				},
+			}
+			g.Expect(k8sClient.Create(ctx, sar)).Should(Succeed())
+			g.Expect(sar.Status.Allowed).Should(BeTrue())
+		}).Should(Succeed())
+	})
+
+	AfterEach(cleanEnv)
+
+	createCluster := func(annotations map[string]string) {
+		testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName, "").
+			AddComponent(compName, compName).
+			AddAnnotationsInMap(annotations).
+			Create(&testCtx)
+	}
+
+	createOpsRequest := func(opsType appsv1alpha1.OpsType, changeFn func(ops *appsv1alpha1.OpsRequest)) *appsv1alpha1.OpsRequest {
+		ops := testapps.NewOpsRequestObj("test-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace, clusterName, opsType)
+		switch opsType {
+		case appsv1alpha1.RestartType:
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: compName}}
+		case appsv1alpha1.HorizontalScalingType:
+			ops.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{
+				{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: compName}, Replicas: 3},
+			}
+		}
+		if changeFn != nil {
+			changeFn(ops)
+		}
+		return testapps.CreateOpsRequest(ctx, testCtx, ops)
+	}
+
+	haveConditionReason := func(reason string) OmegaMatcher {
+		return ContainElement(HaveField("Reason", reason))
+	}
+
+	expectPendingApproval := func(ops *appsv1alpha1.OpsRequest) {
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+			g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingApprovalPhase))
+			g.Expect(ops.Status.Conditions).Should(haveConditionReason(appsv1alpha1.ReasonWaitForApproval))
+		})).Should(Succeed())
+	}
+
+	approve := func(ops *appsv1alpha1.OpsRequest, approval appsv1alpha1.OpsApproval) {
+		Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(ops), func(ops *appsv1alpha1.OpsRequest) {
+			ops.Spec.Approval = &approval
+		})()).Should(Succeed())
+	}
+
+	Context("when the approval is not required", func() {
+		It("should handle the OpsRequest without waiting for the approval", func() {
+			createCluster(nil)
+			ops := createOpsRequest(appsv1alpha1.RestartType, nil)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).ShouldNot(BeElementOf(appsv1alpha1.OpsPhase(""), appsv1alpha1.OpsPendingApprovalPhase))
+				g.Expect(ops.Status.Conditions).ShouldNot(haveConditionReason(appsv1alpha1.ReasonWaitForApproval))
+			})).Should(Succeed())
+		})
+	})
+
+	Context("when the approval is required", func() {
+		It("should wait for the approval required by the OpsRequest", func() {
+			createCluster(nil)
+			ops := createOpsRequest(appsv1alpha1.RestartType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.ApprovalRequired = true
+			})
+			expectPendingApproval(ops)
+		})
+
+		It("should wait for the approval required by the operator", func() {
+			viper.Set(constant.CfgKeyOpsApprovalRequiredTypes, "Stop, Restart")
+			createCluster(nil)
+			ops := createOpsRequest(appsv1alpha1.RestartType, nil)
+			expectPendingApproval(ops)
+		})
+
+		It("should wait for the approval required by the cluster", func() {
+			createCluster(map[string]string{constant.OpsApprovalRequiredAnnotationKey: "Restart"})
+			ops := createOpsRequest(appsv1alpha1.RestartType, nil)
+			expectPendingApproval(ops)
+		})
+
+		It("should handle the OpsRequest approved", func() {
+			createCluster(nil)
+			ops := createOpsRequest(appsv1alpha1.RestartType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.ApprovalRequired = true
+			})
+			expectPendingApproval(ops)
+
+			approve(ops, appsv1alpha1.OpsApproval{Approver: approver})
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).ShouldNot(Equal(appsv1alpha1.OpsPendingApprovalPhase))
+				g.Expect(ops.Status.Conditions).Should(haveConditionReason(appsv1alpha1.ReasonOpsApproved))
+				g.Expect(ops.Status.Approval).ShouldNot(BeNil())
+				g.Expect(ops.Status.Approval.Approver).Should(Equal(approver))
+			})).Should(Succeed())
+		})
+
+		It("should keep waiting if approved by the user not allowed", func() {
+			createCluster(nil)
+			ops := createOpsRequest(appsv1alpha1.RestartType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.ApprovalRequired = true
+			})
+			expectPendingApproval(ops)
+
+			approve(ops, appsv1alpha1.OpsApproval{Approver: "guest-" + randomStr})
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingApprovalPhase))
+				g.Expect(ops.Status.Conditions).Should(haveConditionReason(appsv1alpha1.ReasonApprovalDenied))
+			})).Should(Succeed())
+		})
+
+		It("should cancel the OpsRequest rejected", func() {
+			createCluster(nil)
+			ops := createOpsRequest(appsv1alpha1.RestartType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.ApprovalRequired = true
+			})
+			expectPendingApproval(ops)
+
+			approve(ops, appsv1alpha1.OpsApproval{Approver: approver, Rejected: true})
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsCancelledPhase))
+				g.Expect(ops.Status.Conditions).Should(haveConditionReason(appsv1alpha1.ReasonOpsRejected))
+			})).Should(Succeed())
+		})
+
+		It("should cancel the OpsRequest waiting for the approval directly", func() {
+			createCluster(nil)
+			ops := createOpsRequest(appsv1alpha1.HorizontalScalingType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.ApprovalRequired = true
+			})
+			expectPendingApproval(ops)
+
+			Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(ops), func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.Cancel = true
+			})()).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsCancelledPhase))
+				g.Expect(ops.Status.Conditions).Should(haveConditionReason(appsv1alpha1.ReasonOpsCancelSucceed))
+			})).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("OpsRequest TTL", func() {
+	const (
+		clusterDefName = "test-clusterdef-ttl"
+		compName       = "mysql"
+	)
+
+	var clusterName string
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS, ml)
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		clusterName = "test-cluster-" + testCtx.GetRandomStr()
+		testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName, "").
+			AddComponent(compName, compName).
+			Create(&testCtx)
+	})
+
+	AfterEach(cleanEnv)
+
+	// mockCompletedOpsRequest creates an OpsRequest and mocks it completed in the phase for the duration,
+	// the OpsRequest requires an approval to be kept from being executed by the controller.
+	mockCompletedOpsRequest := func(phase appsv1alpha1.OpsPhase, ttlSecondsAfterFailed int32, completed time.Duration) *appsv1alpha1.OpsRequest {
+		ops := testapps.NewOpsRequestObj("test-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace, clusterName, appsv1alpha1.RestartType)
+		ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: compName}}
+		ops.Spec.ApprovalRequired = true
+		ops.Spec.TTLSecondsAfterFailed = ttlSecondsAfterFailed
+		ops = testapps.CreateOpsRequest(ctx, testCtx, ops)
+		Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops))).Should(Equal(appsv1alpha1.OpsPendingApprovalPhase))
+
+		Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(ops), func(ops *appsv1alpha1.OpsRequest) {
+			ops.Status.Phase = phase
+			ops.Status.CompletionTimestamp = metav1.NewTime(time.Now().Add(-completed))
+		})()).Should(Succeed())
+		return ops
+	}
+
+	expectKept := func(ops *appsv1alpha1.OpsRequest) {
+		Consistently(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(ops), &appsv1alpha1.OpsRequest{}, true)).Should(Succeed())
+	}
+
+	expectReclaimed := func(ops *appsv1alpha1.OpsRequest) {
+		Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(ops), &appsv1alpha1.OpsRequest{}, false)).Should(Succeed())
+	}
+
+	expectRequeuedBeforeTTLExpired := func(ops *appsv1alpha1.OpsRequest) {
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+			r := &OpsRequestReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: clusterRecorder}
+			res, err := r.handleOpsRequestByPhase(intctrlutil.RequestCtx{Ctx: ctx, Log: logger}, &operations.OpsResource{OpsRequest: ops})
+			g.Expect(err).Should(Succeed())
+			g.Expect(res).ShouldNot(BeNil())
+			g.Expect(res.RequeueAfter).Should(BeNumerically(">", 0))
+		})).Should(Succeed())
+		expectKept(ops)
+	}
+
+	Context("when the OpsRequest failed", func() {
+		It("should keep the OpsRequest without the ttl", func() {
+			expectKept(mockCompletedOpsRequest(appsv1alpha1.OpsFailedPhase, 0, time.Hour))
+		})
+
+		It("should keep the OpsRequest before the ttl expired", func() {
+			expectRequeuedBeforeTTLExpired(mockCompletedOpsRequest(appsv1alpha1.OpsFailedPhase, 3600, time.Minute))
+		})
+
+		It("should reclaim the OpsRequest after the ttl expired", func() {
+			expectReclaimed(mockCompletedOpsRequest(appsv1alpha1.OpsFailedPhase, 60, time.Hour))
+		})
+	})
+
+	Context("when the OpsRequest is cancelled", func() {
+		It("should keep the OpsRequest before the ttl expired", func() {
+			expectRequeuedBeforeTTLExpired(mockCompletedOpsRequest(appsv1alpha1.OpsCancelledPhase, 3600, time.Minute))
+		})
+
+		It("should reclaim the OpsRequest after the ttl expired", func() {
+			expectReclaimed(mockCompletedOpsRequest(appsv1alpha1.OpsCancelledPhase, 60, time.Hour))
+		})
+	})
+})
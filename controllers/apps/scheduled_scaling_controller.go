@@ -0,0 +1,348 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// scheduledScalingCheckInterval the interval to check whether the scheduled scaling windows begin or end.
+	scheduledScalingCheckInterval = time.Minute
+
+	// reasonScheduledScaling the event reason indicates that the components are scaled by the scheduled scaling windows.
+	reasonScheduledScaling = "ScheduledScaling"
+)
+
+// scheduledScalingState records the active window of the component and the replicas before the window.
+type scheduledScalingState struct {
+	Window   string `json:"window"`
+	Replicas int32  `json:"replicas"`
+}
+
+// scheduledScalingOps records the HorizontalScaling OpsRequest planned by the windows. It's persisted with the states
+// of the windows before the OpsRequest is created, so the scaling is neither lost nor done twice if interrupted.
+type scheduledScalingOps struct {
+	Name                  string                           `json:"name"`
+	HorizontalScalingList []appsv1alpha1.HorizontalScaling `json:"horizontalScaling"`
+	Message               string                           `json:"message,omitempty"`
+}
+
+// ScheduledScalingReconciler scales the cluster components by the HorizontalScaling OpsRequest
+// when their scheduled scaling windows begin or end.
+type ScheduledScalingReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	now func() time.Time
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create
+
+// Reconcile checks the scheduled scaling windows of the components, scales the components to the replicas of
+// the window when it begins, and scales them back to the replicas before the window when it ends.
+func (r *ScheduledScalingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("cluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !cluster.GetDeletionTimestamp().IsZero() {
+		return intctrlutil.Reconciled()
+	}
+	// create the OpsRequest planned but not created yet, e.g., the operator is restarted after the states are persisted.
+	plannedOps, err := getScheduledScalingOps(cluster)
+	if err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	if plannedOps != nil {
+		if err = r.createScheduledScalingOps(reqCtx.Ctx, cluster, plannedOps); err != nil {
+			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(scheduledScalingCheckInterval, reqCtx.Log, "")
+	}
+	states, err := getScheduledScalingStates(cluster)
+	if err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(states) == 0 && !slices.ContainsFunc(cluster.Spec.ComponentSpecs, func(spec appsv1alpha1.ClusterComponentSpec) bool {
+		return len(spec.ScheduledScaling) > 0
+	}) {
+		return intctrlutil.Reconciled()
+	}
+	// scale the components only if the cluster is running and no other operation is in progress.
+	if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+		return intctrlutil.RequeueAfter(scheduledScalingCheckInterval, reqCtx.Log, "cluster is not running")
+	}
+	opsRecorders, err := opsutil.GetOpsRequestSliceFromCluster(cluster)
+	if err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(opsRecorders) > 0 {
+		return intctrlutil.RequeueAfter(scheduledScalingCheckInterval, reqCtx.Log, "cluster has running OpsRequests")
+	}
+
+	now := time.Now()
+	if r.now != nil {
+		now = r.now()
+	}
+	hScalingList, messages := r.planScheduledScaling(cluster, states, now)
+	if len(hScalingList) > 0 {
+		plannedOps = &scheduledScalingOps{
+			Name:                  fmt.Sprintf("%s-scheduled-scaling-%s", cluster.Name, rand.String(5)),
+			HorizontalScalingList: hScalingList,
+			Message:               strings.Join(messages, "; "),
+		}
+	}
+	// persist the states with the planned OpsRequest first, then create it.
+	if err = r.patchScheduledScalingStates(reqCtx.Ctx, cluster, states, plannedOps); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	if plannedOps != nil {
+		if err = r.createScheduledScalingOps(reqCtx.Ctx, cluster, plannedOps); err != nil {
+			return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	return intctrlutil.RequeueAfter(scheduledScalingCheckInterval, reqCtx.Log, "")
+}
+
+// createScheduledScalingOps creates the planned OpsRequest with the name persisted, and removes it from the cluster
+// once created.
+func (r *ScheduledScalingReconciler) createScheduledScalingOps(ctx context.Context,
+	cluster *appsv1alpha1.Cluster, plannedOps *scheduledScalingOps) error {
+	ops := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      plannedOps.Name,
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    cluster.Name,
+				constant.AppManagedByLabelKey:   constant.AppName,
+				constant.OpsRequestTypeLabelKey: string(appsv1alpha1.HorizontalScalingType),
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterRef:            cluster.Name,
+			Type:                  appsv1alpha1.HorizontalScalingType,
+			HorizontalScalingList: plannedOps.HorizontalScalingList,
+		},
+	}
+	err := r.Client.Create(ctx, ops)
+	switch {
+	case err == nil:
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, reasonScheduledScaling,
+			"OpsRequest %s is created: %s", ops.Name, plannedOps.Message)
+	case !apierrors.IsAlreadyExists(err):
+		return err
+	}
+	patch := client.MergeFrom(cluster.DeepCopy())
+	delete(cluster.Annotations, constant.ScheduledScalingOpsAnnotationKey)
+	return r.Client.Patch(ctx, cluster, patch)
+}
+
+// planScheduledScaling updates the states of the components by the windows at the time, and returns the components
+// to scale and the messages describing them.
+func (r *ScheduledScalingReconciler) planScheduledScaling(cluster *appsv1alpha1.Cluster,
+	states map[string]scheduledScalingState, now time.Time) ([]appsv1alpha1.HorizontalScaling, []string) {
+	var (
+		hScalingList []appsv1alpha1.HorizontalScaling
+		messages     []string
+		compNames    = map[string]bool{}
+	)
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		compNames[compSpec.Name] = true
+		// the stopped component is not scaled.
+		if compSpec.Replicas == 0 {
+			continue
+		}
+		var (
+			state, inWindow = states[compSpec.Name]
+			window          = getActiveScheduledScalingWindow(compSpec.ScheduledScaling, now)
+			replicas        int32
+			message         string
+		)
+		switch {
+		case window != nil && !inWindow:
+			states[compSpec.Name] = scheduledScalingState{Window: window.Name, Replicas: compSpec.Replicas}
+			replicas = window.Replicas
+			message = fmt.Sprintf("window %s of component %s begins", window.Name, compSpec.Name)
+		case window != nil && state.Window != window.Name:
+			state.Window = window.Name
+			states[compSpec.Name] = state
+			replicas = window.Replicas
+			message = fmt.Sprintf("window %s of component %s begins", window.Name, compSpec.Name)
+		case window == nil && inWindow:
+			delete(states, compSpec.Name)
+			replicas = state.Replicas
+			message = fmt.Sprintf("window %s of component %s ends", state.Window, compSpec.Name)
+		default:
+			// the replicas may be changed by others in the window, keep them unchanged.
+			continue
+		}
+		if replicas == compSpec.Replicas {
+			continue
+		}
+		hScalingList = append(hScalingList, appsv1alpha1.HorizontalScaling{
+			ComponentOps: appsv1alpha1.ComponentOps{ComponentName: compSpec.Name},
+			Replicas:     replicas,
+		})
+		messages = append(messages, fmt.Sprintf("%s, scale replicas from %d to %d", message, compSpec.Replicas, replicas))
+	}
+	// clean up the states of the deleted components.
+	for compName := range states {
+		if !compNames[compName] {
+			delete(states, compName)
+		}
+	}
+	return hScalingList, messages
+}
+
+func (r *ScheduledScalingReconciler) patchScheduledScalingStates(ctx context.Context,
+	cluster *appsv1alpha1.Cluster, states map[string]scheduledScalingState, plannedOps *scheduledScalingOps) error {
+	patch := client.MergeFrom(cluster.DeepCopy())
+	annotations := map[string]string{}
+	for k, v := range cluster.Annotations {
+		annotations[k] = v
+	}
+	delete(annotations, constant.ScheduledScalingAnnotationKey)
+	if len(states) > 0 {
+		data, err := json.Marshal(states)
+		if err != nil {
+			return err
+		}
+		annotations[constant.ScheduledScalingAnnotationKey] = string(data)
+	}
+	if plannedOps != nil {
+		data, err := json.Marshal(plannedOps)
+		if err != nil {
+			return err
+		}
+		annotations[constant.ScheduledScalingOpsAnnotationKey] = string(data)
+	}
+	if maps.Equal(annotations, cluster.Annotations) {
+		return nil
+	}
+	cluster.Annotations = annotations
+	return r.Client.Patch(ctx, cluster, patch)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScheduledScalingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		Named("scheduled-scaling").
+		For(&appsv1alpha1.Cluster{}).
+		Complete(r)
+}
+
+func getScheduledScalingOps(cluster *appsv1alpha1.Cluster) (*scheduledScalingOps, error) {
+	data, ok := cluster.Annotations[constant.ScheduledScalingOpsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	plannedOps := &scheduledScalingOps{}
+	if err := json.Unmarshal([]byte(data), plannedOps); err != nil {
+		return nil, err
+	}
+	return plannedOps, nil
+}
+
+func getScheduledScalingStates(cluster *appsv1alpha1.Cluster) (map[string]scheduledScalingState, error) {
+	states := map[string]scheduledScalingState{}
+	data, ok := cluster.Annotations[constant.ScheduledScalingAnnotationKey]
+	if !ok {
+		return states, nil
+	}
+	if err := json.Unmarshal([]byte(data), &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// getActiveScheduledScalingWindow returns the first window which is active at the time.
+func getActiveScheduledScalingWindow(windows []appsv1alpha1.ScheduledScalingWindow, now time.Time) *appsv1alpha1.ScheduledScalingWindow {
+	for i := range windows {
+		if isScheduledScalingWindowActive(windows[i], now) {
+			return &windows[i]
+		}
+	}
+	return nil
+}
+
+// isScheduledScalingWindowActive checks whether the window is active at the time.
+func isScheduledScalingWindowActive(window appsv1alpha1.ScheduledScalingWindow, now time.Time) bool {
+	loc := time.UTC
+	if len(window.TimeZone) > 0 {
+		var err error
+		if loc, err = time.LoadLocation(window.TimeZone); err != nil {
+			return false
+		}
+	}
+	start, err1 := parseMinuteOfDay(window.StartTime)
+	end, err2 := parseMinuteOfDay(window.EndTime)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	t := now.In(loc)
+	minute := t.Hour()*60 + t.Minute()
+	beginsOn := func(day time.Time) bool {
+		return len(window.Days) == 0 || slices.Contains(window.Days, appsv1alpha1.Weekday(day.Weekday().String()[:3]))
+	}
+	if start < end {
+		return beginsOn(t) && minute >= start && minute < end
+	}
+	// the window ends on the next day.
+	return (beginsOn(t) && minute >= start) || (beginsOn(t.AddDate(0, 0, -1)) && minute < end)
+}
+
+func parseMinuteOfDay(hhmm string) (int, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+// failOnceOpsRequestClient fails to create the OpsRequest once, e.g., the operator is restarted after
+// the scheduled scaling states are persisted.
+type failOnceOpsRequestClient struct {
+	client.Client
+	failed bool
+}
+
+func (c *failOnceOpsRequestClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*appsv1alpha1.OpsRequest); ok && !c.failed {
+		c.failed = true
+		return errors.New("failed to create the OpsRequest")
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+var _ = Describe("scheduled scaling controller", func() {
+	const (
+		clusterDefName = "test-clusterdef-scheduled-scaling"
+		compName       = "read"
+	)
+
+	officeHours := appsv1alpha1.ScheduledScalingWindow{Name: "office-hours", StartTime: "09:00", EndTime: "18:00", Replicas: 5}
+
+	Context("scheduled scaling window", func() {
+		It("should be active between the start and the end time of the days", func() {
+			window := officeHours
+			window.Days = []appsv1alpha1.Weekday{appsv1alpha1.Monday, appsv1alpha1.Tuesday, appsv1alpha1.Wednesday,
+				appsv1alpha1.Thursday, appsv1alpha1.Friday}
+			// 2024-01-01 is Monday
+			Expect(isScheduledScalingWindowActive(window, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))).Should(BeTrue())
+			Expect(isScheduledScalingWindowActive(window, time.Date(2024, 1, 1, 17, 59, 0, 0, time.UTC))).Should(BeTrue())
+			Expect(isScheduledScalingWindowActive(window, time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC))).Should(BeFalse())
+			Expect(isScheduledScalingWindowActive(window, time.Date(2024, 1, 1, 8, 59, 0, 0, time.UTC))).Should(BeFalse())
+			Expect(isScheduledScalingWindowActive(window, time.Date(2024, 1, 6, 10, 0, 0, 0, time.UTC))).Should(BeFalse())
+
+			By("the time is in the time zone of the window")
+			window.TimeZone = "Asia/Shanghai"
+			Expect(isScheduledScalingWindowActive(window, time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC))).Should(BeTrue())
+			Expect(isScheduledScalingWindowActive(window, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC))).Should(BeFalse())
+		})
+
+		It("should be active until the end time of the next day if it ends on the next day", func() {
+			overnight := appsv1alpha1.ScheduledScalingWindow{
+				Name:      "nightly",
+				Days:      []appsv1alpha1.Weekday{appsv1alpha1.Friday},
+				StartTime: "22:00",
+				EndTime:   "02:00",
+				Replicas:  3,
+			}
+			Expect(isScheduledScalingWindowActive(overnight, time.Date(2024, 1, 5, 23, 0, 0, 0, time.UTC))).Should(BeTrue())
+			Expect(isScheduledScalingWindowActive(overnight, time.Date(2024, 1, 6, 1, 0, 0, 0, time.UTC))).Should(BeTrue())
+			Expect(isScheduledScalingWindowActive(overnight, time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC))).Should(BeFalse())
+			Expect(isScheduledScalingWindowActive(overnight, time.Date(2024, 1, 5, 1, 0, 0, 0, time.UTC))).Should(BeFalse())
+		})
+	})
+
+	Context("plan scheduled scaling", func() {
+		It("should scale the component in the window and scale it back after the window", func() {
+			r := &ScheduledScalingReconciler{}
+			cluster := &appsv1alpha1.Cluster{
+				Spec: appsv1alpha1.ClusterSpec{
+					ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+						{Name: compName, Replicas: 2, ScheduledScaling: []appsv1alpha1.ScheduledScalingWindow{officeHours}},
+					},
+				},
+			}
+			inWindow := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+			outOfWindow := time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC)
+
+			By("the window begins")
+			states := map[string]scheduledScalingState{}
+			hScalingList, _ := r.planScheduledScaling(cluster, states, inWindow)
+			Expect(hScalingList).Should(HaveLen(1))
+			Expect(hScalingList[0].Replicas).Should(Equal(int32(5)))
+			Expect(states).Should(HaveKeyWithValue(compName, scheduledScalingState{Window: "office-hours", Replicas: 2}))
+
+			By("the window is active and the component has been scaled")
+			cluster.Spec.ComponentSpecs[0].Replicas = 5
+			hScalingList, _ = r.planScheduledScaling(cluster, states, inWindow)
+			Expect(hScalingList).Should(BeEmpty())
+
+			By("the window ends")
+			hScalingList, _ = r.planScheduledScaling(cluster, states, outOfWindow)
+			Expect(hScalingList).Should(HaveLen(1))
+			Expect(hScalingList[0].Replicas).Should(Equal(int32(2)))
+			Expect(states).Should(BeEmpty())
+
+			By("the stopped component is not scaled")
+			cluster.Spec.ComponentSpecs[0].Replicas = 0
+			hScalingList, _ = r.planScheduledScaling(cluster, states, inWindow)
+			Expect(hScalingList).Should(BeEmpty())
+			Expect(states).Should(BeEmpty())
+		})
+	})
+
+	Context("reconcile scheduled scaling", func() {
+		var clusterName string
+
+		cleanEnv := func() {
+			// must wait till resources deleted and no longer existed before the testcases start,
+			// otherwise if later it needs to create some new resource objects with the same name,
+			// in race conditions, it will find the existence of old objects, resulting failure to
+			// create the new objects.
+			By("clean resources")
+			inNS := client.InNamespace(testCtx.DefaultNamespace)
+			if len(clusterName) > 0 {
+				testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS,
+					client.MatchingLabels{constant.AppInstanceLabelKey: clusterName})
+			}
+			testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+		}
+
+		BeforeEach(func() {
+			cleanEnv()
+			clusterName = "test-cluster-" + testCtx.GetRandomStr()
+		})
+
+		AfterEach(cleanEnv)
+
+		It("should create the OpsRequest planned after the states are persisted", func() {
+			cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName, "").
+				AddComponent(compName, compName).
+				SetReplicas(2).
+				Apply(func(cluster *appsv1alpha1.Cluster) {
+					cluster.Spec.ComponentSpecs[0].ScheduledScaling = []appsv1alpha1.ScheduledScalingWindow{officeHours}
+				}).
+				Create(&testCtx).
+				GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
+				cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+			})).Should(Succeed())
+
+			r := &ScheduledScalingReconciler{
+				Client:   &failOnceOpsRequestClient{Client: k8sClient},
+				Recorder: clusterRecorder,
+				now: func() time.Time {
+					return time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+				},
+			}
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cluster)}
+
+			By("the states are persisted with the planned OpsRequest even if it fails to be created")
+			_, _ = r.Reconcile(ctx, req)
+			var plannedOps *scheduledScalingOps
+			Eventually(testapps.CheckObj(&testCtx, req.NamespacedName, func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Annotations).Should(HaveKey(constant.ScheduledScalingAnnotationKey))
+				ops, err := getScheduledScalingOps(cluster)
+				g.Expect(err).Should(Succeed())
+				g.Expect(ops).ShouldNot(BeNil())
+				plannedOps = ops
+			})).Should(Succeed())
+
+			By("the planned OpsRequest is created once, the window is not planned again")
+			for i := 0; i < 2; i++ {
+				Eventually(func() error {
+					_, err := r.Reconcile(ctx, req)
+					return err
+				}).Should(Succeed())
+			}
+			Eventually(func(g Gomega) {
+				opsList := &appsv1alpha1.OpsRequestList{}
+				g.Expect(k8sClient.List(ctx, opsList, client.InNamespace(testCtx.DefaultNamespace),
+					client.MatchingLabels{constant.AppInstanceLabelKey: clusterName})).Should(Succeed())
+				g.Expect(opsList.Items).Should(HaveLen(1))
+				g.Expect(opsList.Items[0].Name).Should(Equal(plannedOps.Name))
+				g.Expect(opsList.Items[0].Spec.HorizontalScalingList[0].Replicas).Should(Equal(int32(5)))
+			}).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, req.NamespacedName, func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Annotations).ShouldNot(HaveKey(constant.ScheduledScalingOpsAnnotationKey))
+			})).Should(Succeed())
+		})
+	})
+})
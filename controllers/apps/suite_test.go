@@ -144,6 +144,7 @@ var _ = BeforeSuite(func() {
 		ClientDisableCacheFor: intctrlutil.GetUncachedObjects(),
 	})
 	Expect(err).ToNot(HaveOccurred())
+	Expect(intctrlutil.RegisterFieldIndexes(ctx, k8sManager.GetFieldIndexer())).Should(Succeed())
 
 	viper.SetDefault("CERT_DIR", "/tmp/k8s-webhook-server/serving-certs")
 	viper.SetDefault(constant.KBToolsImage, "apecloud/kubeblocks-tools:latest")
@@ -199,6 +200,13 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&DatabaseUserReconciler{
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("database-user-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&OpsRequestReconciler{
 		Client:   k8sManager.GetClient(),
 		Scheme:   k8sManager.GetScheme(),
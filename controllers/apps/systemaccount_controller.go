@@ -269,7 +269,7 @@ func (r *SystemAccountReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				continue
 			}
 
-			isReady, svcEP, headlessEP, err := r.isComponentReady(reqCtx, cluster.Name, compName)
+			isReady, svcEP, headlessEP, err := r.isComponentReady(reqCtx, clusterdefinition.Spec.NamingTemplate, cluster.Name, compName, compType)
 			if err != nil {
 				return intctrlutil.RequeueAfter(requeueDuration, reqCtx.Log, "failed to get service")
 			}
@@ -370,12 +370,13 @@ func (r *SystemAccountReconciler) createByReferringToExisting(reqCtx intctrlutil
 	return nil
 }
 
-func (r *SystemAccountReconciler) isComponentReady(reqCtx intctrlutil.RequestCtx, clusterName string, compName string) (bool, *corev1.Endpoints, *corev1.Endpoints, error) {
+func (r *SystemAccountReconciler) isComponentReady(reqCtx intctrlutil.RequestCtx, namingTemplate *appsv1alpha1.NamingTemplate,
+	clusterName, compName, compDefName string) (bool, *corev1.Endpoints, *corev1.Endpoints, error) {
 	svcEP := &corev1.Endpoints{}
-	serviceName := clusterName + "-" + compName
+	serviceName := namingTemplate.GenerateComponentServiceName(clusterName, compName, compDefName, "")
 
 	headlessEP := &corev1.Endpoints{}
-	headlessSvcName := serviceName + "-headless"
+	headlessSvcName := namingTemplate.GenerateComponentHeadlessServiceName(clusterName, compName, compDefName)
 
 	svcErr := r.Client.Get(reqCtx.Ctx, types.NamespacedName{Namespace: reqCtx.Req.Namespace, Name: serviceName}, svcEP)
 	if svcErr != nil {
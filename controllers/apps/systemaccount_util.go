@@ -334,7 +334,7 @@ func calibrateJobMetaAndSpec(job *batchv1.Job, cluster *appsv1alpha1.Cluster, co
 	}
 	job.Spec.Template.Spec.Tolerations = tolerations
 
-	return nil
+	return intctrlutil.ApplyDataPlaneSecurityContext(&job.Spec.Template.Spec)
 }
 
 // completeExecConfig overrides the image of execConfig if version is not nil.
@@ -186,7 +186,10 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1alpha1.Component) *apps
 	compObjCopy.Spec.ClassDefRef = compProto.Spec.ClassDefRef
 	compObjCopy.Spec.Resources = compProto.Spec.Resources
 	compObjCopy.Spec.ServiceRefs = compProto.Spec.ServiceRefs
-	compObjCopy.Spec.Replicas = compProto.Spec.Replicas
+	// the replicas of the autoscaled component are managed by the autoscaler, except for stopping and starting it.
+	if compProto.Spec.Autoscaling == nil || compProto.Spec.Replicas == 0 || oldCompObj.Spec.Replicas == 0 {
+		compObjCopy.Spec.Replicas = compProto.Spec.Replicas
+	}
 	compObjCopy.Spec.Configs = compProto.Spec.Configs
 	compObjCopy.Spec.EnabledLogs = compProto.Spec.EnabledLogs
 	compObjCopy.Spec.VolumeClaimTemplates = compProto.Spec.VolumeClaimTemplates
@@ -196,6 +199,10 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1alpha1.Component) *apps
 	compObjCopy.Spec.TLSConfig = compProto.Spec.TLSConfig
 	compObjCopy.Spec.Nodes = compProto.Spec.Nodes
 	compObjCopy.Spec.Instances = compProto.Spec.Instances
+	compObjCopy.Spec.VolumeClaimRetentionPolicy = compProto.Spec.VolumeClaimRetentionPolicy
+	compObjCopy.Spec.Autoscaling = compProto.Spec.Autoscaling
+	compObjCopy.Spec.PodEndpoints = compProto.Spec.PodEndpoints
+	compObjCopy.Spec.SecurityContext = compProto.Spec.SecurityContext
 
 	if reflect.DeepEqual(oldCompObj.Annotations, compObjCopy.Annotations) &&
 		reflect.DeepEqual(oldCompObj.Labels, compObjCopy.Labels) &&
@@ -29,9 +29,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 // clusterComponentStatusTransformer transforms all cluster components' status.
@@ -70,11 +72,66 @@ func (t *clusterComponentStatusTransformer) reconcileComponentsStatus(transCtx *
 			}
 			return err
 		}
-		cluster.Status.Components[compSpec.Name] = t.buildClusterCompStatus(transCtx, comp, compSpec.Name)
+		status := t.buildClusterCompStatus(transCtx, comp, compSpec.Name)
+		endpoints, err := t.buildExposedEndpoints(transCtx, compSpec)
+		if err != nil {
+			return err
+		}
+		status.ExposedEndpoints = endpoints
+		cluster.Status.Components[compSpec.Name] = status
 	}
 	return nil
 }
 
+// buildExposedEndpoints mirrors the external addresses of the LoadBalancer and NodePort cluster services
+// which select the component, including the legacy services defined in the component spec.
+func (t *clusterComponentStatusTransformer) buildExposedEndpoints(transCtx *clusterTransformContext,
+	compSpec *appsv1alpha1.ClusterComponentSpec) ([]appsv1alpha1.ExposedEndpoint, error) {
+	var (
+		cluster      = transCtx.Cluster
+		endpoints    []appsv1alpha1.ExposedEndpoint
+		nodeHosts    []string
+		svcNames     [][]string
+		hasNodePorts bool
+	)
+	for _, clusterSvc := range cluster.Spec.Services {
+		if clusterSvc.ComponentSelector != compSpec.Name || !intctrlutil.IsExposedService(clusterSvc.Spec.Type) {
+			continue
+		}
+		svcNames = append(svcNames, []string{constant.GenerateClusterServiceName(cluster.Name, clusterSvc.ServiceName)})
+		hasNodePorts = hasNodePorts || clusterSvc.Spec.Type == corev1.ServiceTypeNodePort
+	}
+	for _, item := range compSpec.Services {
+		if !intctrlutil.IsExposedService(item.ServiceType) {
+			continue
+		}
+		// the legacy service may be created with the name of the component service before
+		svcNames = append(svcNames, []string{constant.GenerateClusterServiceName(cluster.Name, item.Name),
+			transCtx.ClusterDef.Spec.NamingTemplate.GenerateComponentServiceName(cluster.Name, compSpec.Name, compSpec.ComponentDefRef, item.Name)})
+		hasNodePorts = hasNodePorts || item.ServiceType == corev1.ServiceTypeNodePort
+	}
+	if hasNodePorts {
+		var err error
+		if nodeHosts, err = component.GetComponentNodeHosts(transCtx.Context, transCtx.Client, *cluster, compSpec.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, names := range svcNames {
+		for _, name := range names {
+			svc := &corev1.Service{}
+			if err := transCtx.Client.Get(transCtx.Context, types.NamespacedName{Namespace: cluster.Namespace, Name: name}, svc); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			endpoints = append(endpoints, intctrlutil.BuildExposedEndpoint(svc, nodeHosts))
+			break
+		}
+	}
+	return endpoints, nil
+}
+
 // buildClusterCompStatus builds cluster component status from specified component object.
 func (t *clusterComponentStatusTransformer) buildClusterCompStatus(transCtx *clusterTransformContext,
 	comp *appsv1alpha1.Component, compName string) appsv1alpha1.ClusterComponentStatus {
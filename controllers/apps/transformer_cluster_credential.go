@@ -20,16 +20,28 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"reflect"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apecloud/kubeblocks/pkg/common"
-
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
+)
+
+const (
+	// exposedEndpointKeyPrefix is the key prefix of the exposed endpoints in the connection credential secret.
+	exposedEndpointKeyPrefix = "endpoint."
+	// podEndpointsKeyPrefix is the key prefix of the per-pod endpoints of the components in the connection credential secret.
+	podEndpointsKeyPrefix = "podEndpoints."
 )
 
 // clusterCredentialTransformer creates the default cluster connection credential secret
@@ -73,16 +85,99 @@ func (t *clusterConnCredentialTransformer) buildClusterConnCredential(transCtx *
 	if secret == nil {
 		return nil
 	}
-	err := transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(secret), &corev1.Secret{})
+	existing := &corev1.Secret{}
+	err := transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(secret), existing)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
-	if apierrors.IsNotFound(err) {
+	notFound := apierrors.IsNotFound(err)
+	endpoints, err := t.buildEndpoints(transCtx)
+	if err != nil {
+		return err
+	}
+	if notFound {
+		if len(secret.StringData) > 0 {
+			for k, v := range endpoints {
+				secret.StringData[k] = v
+			}
+		}
+		// keep the password in the external secret manager if configured, only the reference is stored in the secret.
+		if err = secretstore.Seal(transCtx.Context, secret, constant.AccountPasswdForSecret); err != nil {
+			return err
+		}
 		graphCli.Create(dag, secret)
+		return nil
+	}
+	if len(secret.StringData) == 0 {
+		return nil
+	}
+
+	// regenerate the endpoints if the services have changed, and keep the random values generated before
+	secret = factory.RebuildConnCredential(transCtx.ClusterDef, transCtx.Cluster, synthesizedComponent, existing)
+	data := make(map[string][]byte, len(existing.Data))
+	for k, v := range existing.Data {
+		// the endpoints are rebuilt below, remove the ones of the services which are not exposed anymore
+		// and the components which do not publish the pod endpoints anymore
+		if _, ok := transCtx.ClusterDef.Spec.ConnectionCredential[k]; !ok &&
+			(strings.HasPrefix(k, exposedEndpointKeyPrefix) || strings.HasPrefix(k, podEndpointsKeyPrefix)) {
+			continue
+		}
+		data[k] = v
+	}
+	for k, v := range secret.StringData {
+		data[k] = []byte(v)
+	}
+	for k, v := range endpoints {
+		data[k] = []byte(v)
+	}
+	if secretstore.IsSealed(existing) {
+		// the password is kept in the external secret manager, the one generated by the rebuilding is discarded.
+		delete(data, constant.AccountPasswdForSecret)
+	}
+	if !reflect.DeepEqual(existing.Data, data) {
+		existingCopy := existing.DeepCopy()
+		existingCopy.Data = data
+		graphCli.Update(dag, existing, existingCopy)
 	}
 	return nil
 }
 
+// buildEndpoints builds the endpoints published in the connection credential secret, including:
+// - the external addresses of the exposed services recorded in the cluster status, for the clients outside the Kubernetes cluster.
+// - the per-pod endpoints of the components, for the tools which need to address the members directly.
+func (t *clusterConnCredentialTransformer) buildEndpoints(transCtx *clusterTransformContext) (map[string]string, error) {
+	data := map[string]string{}
+	for _, compStatus := range transCtx.Cluster.Status.Components {
+		for _, endpoint := range compStatus.ExposedEndpoints {
+			if len(endpoint.Addresses) > 0 {
+				data[exposedEndpointKeyPrefix+endpoint.Name] = strings.Join(endpoint.Addresses, ",")
+			}
+		}
+	}
+	for _, compSpec := range transCtx.ComponentSpecs {
+		if compSpec.PodEndpoints == nil {
+			continue
+		}
+		synthesizedComp := &component.SynthesizedComponent{
+			Namespace:          transCtx.Cluster.Namespace,
+			ClusterName:        transCtx.Cluster.Name,
+			Name:               compSpec.Name,
+			ClusterCompDefName: compSpec.ComponentDefRef,
+			NamingTemplate:     transCtx.ClusterDef.Spec.NamingTemplate,
+			Replicas:           compSpec.Replicas,
+			PodEndpoints:       compSpec.PodEndpoints,
+		}
+		rsmObj, err := component.GetComponentRSM(transCtx.Context, transCtx.Client, synthesizedComp)
+		if err != nil {
+			return nil, err
+		}
+		if endpoints := component.BuildPodEndpoints(synthesizedComp, rsmObj); len(endpoints) > 0 {
+			data[podEndpointsKeyPrefix+compSpec.Name] = strings.Join(endpoints, ",")
+		}
+	}
+	return data, nil
+}
+
 func (t *clusterConnCredentialTransformer) buildSynthesizedComponent(transCtx *clusterTransformContext) *component.SynthesizedComponent {
 	for _, compDef := range transCtx.ClusterDef.Spec.ComponentDefs {
 		if compDef.Service == nil {
@@ -92,10 +187,19 @@ func (t *clusterConnCredentialTransformer) buildSynthesizedComponent(transCtx *c
 			if compDef.Name != compSpec.ComponentDefRef {
 				continue
 			}
-			return &component.SynthesizedComponent{
-				Name:     compSpec.Name,
-				Services: []corev1.Service{{Spec: compDef.Service.ToSVCSpec()}},
+			synthesizedComp := &component.SynthesizedComponent{
+				Name:               compSpec.Name,
+				ClusterCompDefName: compDef.Name,
+				NamingTemplate:     transCtx.ClusterDef.Spec.NamingTemplate,
+				Services:           []corev1.Service{{Spec: compDef.Service.ToSVCSpec()}},
+			}
+			for _, item := range compSpec.Services {
+				synthesizedComp.Services = append(synthesizedComp.Services, corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: item.Name},
+					Spec:       compDef.Service.ToSVCSpec(),
+				})
 			}
+			return synthesizedComp
 		}
 	}
 	return nil
@@ -26,8 +26,10 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,7 +43,9 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 )
 
 // clusterDeletionTransformer handles cluster deletion
@@ -163,6 +167,12 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 	delObjs = append(delObjs, toDeleteObjs(nonNamespacedObjs)...)
 
 	for _, o := range delObjs {
+		// purge the sensitive values kept in the external secret manager along with the secrets
+		if secret, ok := o.(*corev1.Secret); ok {
+			if err = secretstore.Purge(transCtx.Context, secret); err != nil {
+				return err
+			}
+		}
 		if !rsm.IsOwnedByRsm(o) {
 			graphCli.Delete(dag, o)
 		}
@@ -185,6 +195,7 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 			return newRequeueError(time.Second*1, "release host ports failed")
 		}
 	}
+	metrics.DeleteClusterMetrics(cluster.Namespace, cluster.Name)
 
 	// fast return, that is stopping the plan.Build() stage and jump to plan.Execute() directly
 	return graph.ErrPrematureStop
@@ -209,9 +220,12 @@ func kindsForHalt() ([]client.ObjectList, []client.ObjectList) {
 		&workloads.ReplicatedStateMachineList{},
 		&appsv1.StatefulSetList{}, // be compatible with 0.6 workloads.
 		&policyv1.PodDisruptionBudgetList{},
+		&autoscalingv2.HorizontalPodAutoscalerList{},
+		&networkingv1.NetworkPolicyList{},
 		&corev1.ServiceList{},
 		&corev1.ServiceAccountList{},
 		&rbacv1.RoleBindingList{},
+		&rbacv1.RoleList{},
 		&dpv1alpha1.BackupPolicyList{},
 		&dpv1alpha1.BackupScheduleList{},
 		&dpv1alpha1.RestoreList{},
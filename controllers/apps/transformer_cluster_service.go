@@ -36,6 +36,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 // clusterServiceTransformer handles cluster services.
@@ -129,19 +130,30 @@ func (t *clusterServiceTransformer) convertLegacyClusterCompSpecServices(transCt
 		defaultLegacyServicePorts := clusterCompDef.Service.ToSVCPorts()
 
 		for _, item := range compSpec.Services {
+			ports := make([]corev1.ServicePort, 0, len(defaultLegacyServicePorts))
+			for _, port := range defaultLegacyServicePorts {
+				if nodePort, ok := item.NodePorts[port.Name]; ok && intctrlutil.IsExposedService(item.ServiceType) {
+					port.NodePort = nodePort
+				}
+				ports = append(ports, port)
+			}
 			legacyService := &appsv1alpha1.ClusterService{
 				Service: appsv1alpha1.Service{
 					Name:        constant.GenerateClusterServiceName(cluster.Name, item.Name),
 					ServiceName: constant.GenerateClusterServiceName(cluster.Name, item.Name),
-					Annotations: item.Annotations,
+					Annotations: item.GetAnnotations(),
 					Spec: corev1.ServiceSpec{
-						Ports: defaultLegacyServicePorts,
-						Type:  item.ServiceType,
+						Ports:          ports,
+						Type:           item.ServiceType,
+						IPFamilyPolicy: item.IPFamilyPolicy,
+						IPFamilies:     item.IPFamilies,
 					},
 				},
-				ComponentSelector: compSpec.Name,
+				ComponentSelector:  compSpec.Name,
+				NodePortAllocation: item.NodePortAllocation,
 			}
-			legacyServiceName := constant.GenerateComponentServiceName(cluster.Name, compSpec.Name, item.Name)
+			legacyServiceName := transCtx.ClusterDef.Spec.NamingTemplate.GenerateComponentServiceName(cluster.Name,
+				compSpec.Name, compSpec.ComponentDefRef, item.Name)
 			legacyServiceExist, err := checkLegacyServiceExist(transCtx, legacyServiceName, cluster.Namespace)
 			if err != nil {
 				return nil, err
@@ -177,7 +189,7 @@ func (t *clusterServiceTransformer) buildService(transCtx *clusterTransformConte
 	builder := builder.NewServiceBuilder(namespace, serviceName).
 		AddLabelsInMap(constant.GetClusterWellKnownLabels(clusterName)).
 		AddAnnotationsInMap(genSvc.Annotations).
-		SetSpec(&genSvc.Spec).
+		SetSpec(genSvc.Spec.DeepCopy()).
 		AddSelectorsInMap(t.builtinSelector(cluster)).
 		Optimize4ExternalTraffic()
 
@@ -201,7 +213,13 @@ func (t *clusterServiceTransformer) buildService(transCtx *clusterTransformConte
 		builder.AddSelector(constant.RoleLabelKey, genSvc.RoleSelector)
 	}
 
-	return builder.GetObject(), nil
+	svc := builder.GetObject()
+	if genSvc.NodePortAllocation == appsv1alpha1.DeterministicNodePortAllocation {
+		if err := intctrlutil.AllocateDeterministicNodePorts(transCtx.Context, transCtx.Client, svc); err != nil {
+			return nil, err
+		}
+	}
+	return svc, nil
 }
 
 func (t *clusterServiceTransformer) genMultiServiceIfNeed(transCtx *clusterTransformContext,
@@ -337,15 +355,18 @@ func resolveServiceDefaultFields(obj, objCopy *corev1.ServiceSpec) {
 			objCopy.Ports[i].TargetPort = obj.Ports[i].TargetPort
 		}
 	}
-	if len(objCopy.ClusterIP) == 0 {
-		objCopy.ClusterIP = obj.ClusterIP
-	}
-	if len(objCopy.ClusterIPs) == 0 {
-		objCopy.ClusterIPs = obj.ClusterIPs
-	}
 	if len(objCopy.Type) == 0 {
 		objCopy.Type = obj.Type
 	}
+	// the cluster IPs are released if the service is changed to ExternalName, which has none of them.
+	if objCopy.Type != corev1.ServiceTypeExternalName {
+		if len(objCopy.ClusterIP) == 0 {
+			objCopy.ClusterIP = obj.ClusterIP
+		}
+		if len(objCopy.ClusterIPs) == 0 {
+			objCopy.ClusterIPs = obj.ClusterIPs
+		}
+	}
 	if len(objCopy.SessionAffinity) == 0 {
 		objCopy.SessionAffinity = obj.SessionAffinity
 	}
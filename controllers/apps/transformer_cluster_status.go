@@ -92,12 +92,14 @@ func (t *clusterStatusTransformer) reconcileClusterPhase(cluster *appsv1alpha1.C
 		if !isPhaseIn(phase, appsv1alpha1.CreatingClusterCompPhase) {
 			isAllComponentCreating = false
 		}
-		if !isPhaseIn(phase, appsv1alpha1.RunningClusterCompPhase) {
+		// the components stopped separately don't affect the running of the cluster.
+		if !isPhaseIn(phase, appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase) {
 			isAllComponentRunning = false
 		}
 		if !isPhaseIn(phase, appsv1alpha1.CreatingClusterCompPhase,
 			appsv1alpha1.RunningClusterCompPhase,
-			appsv1alpha1.UpdatingClusterCompPhase) {
+			appsv1alpha1.UpdatingClusterCompPhase,
+			appsv1alpha1.StoppedClusterCompPhase) {
 			isAllComponentWorking = false
 		}
 		if isPhaseIn(phase, appsv1alpha1.StoppingClusterCompPhase) {
@@ -115,6 +117,10 @@ func (t *clusterStatusTransformer) reconcileClusterPhase(cluster *appsv1alpha1.C
 	}
 
 	switch {
+	case isAllComponentStopped:
+		if cluster.Status.Phase != appsv1alpha1.StoppedClusterPhase {
+			t.syncClusterPhaseToStopped(cluster)
+		}
 	case isAllComponentRunning:
 		if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
 			t.syncClusterPhaseToRunning(cluster)
@@ -123,10 +129,6 @@ func (t *clusterStatusTransformer) reconcileClusterPhase(cluster *appsv1alpha1.C
 		cluster.Status.Phase = appsv1alpha1.CreatingClusterPhase
 	case isAllComponentWorking:
 		cluster.Status.Phase = appsv1alpha1.UpdatingClusterPhase
-	case isAllComponentStopped:
-		if cluster.Status.Phase != appsv1alpha1.StoppedClusterPhase {
-			t.syncClusterPhaseToStopped(cluster)
-		}
 	case hasComponentStopping:
 		cluster.Status.Phase = appsv1alpha1.StoppingClusterPhase
 	case isAllComponentFailed:
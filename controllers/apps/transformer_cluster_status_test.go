@@ -0,0 +1,72 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("cluster status transformer", func() {
+	Context("reconcile the cluster phase with stopped components", func() {
+		newCluster := func(phases ...appsv1alpha1.ClusterComponentPhase) *appsv1alpha1.Cluster {
+			cluster := &appsv1alpha1.Cluster{}
+			cluster.Name = "mycluster"
+			cluster.Status.Components = map[string]appsv1alpha1.ClusterComponentStatus{}
+			for i, phase := range phases {
+				cluster.Status.Components[string(rune('a'+i))] = appsv1alpha1.ClusterComponentStatus{Phase: phase}
+			}
+			return cluster
+		}
+
+		reconcileClusterPhase := func(phases ...appsv1alpha1.ClusterComponentPhase) appsv1alpha1.ClusterPhase {
+			cluster := newCluster(phases...)
+			(&clusterStatusTransformer{}).reconcileClusterPhase(cluster)
+			return cluster.Status.Phase
+		}
+
+		It("should be running if some components are stopped", func() {
+			Expect(reconcileClusterPhase(appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase)).
+				Should(Equal(appsv1alpha1.RunningClusterPhase))
+		})
+
+		It("should be stopped if all components are stopped", func() {
+			Expect(reconcileClusterPhase(appsv1alpha1.StoppedClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase)).
+				Should(Equal(appsv1alpha1.StoppedClusterPhase))
+		})
+
+		It("should be stopping if a component is stopping", func() {
+			Expect(reconcileClusterPhase(appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.StoppingClusterCompPhase)).
+				Should(Equal(appsv1alpha1.StoppingClusterPhase))
+		})
+
+		It("should be updating if a component is starting", func() {
+			Expect(reconcileClusterPhase(appsv1alpha1.UpdatingClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase)).
+				Should(Equal(appsv1alpha1.UpdatingClusterPhase))
+		})
+
+		It("should be abnormal if a component is failed", func() {
+			Expect(reconcileClusterPhase(appsv1alpha1.FailedClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase)).
+				Should(Equal(appsv1alpha1.AbnormalClusterPhase))
+		})
+	})
+})
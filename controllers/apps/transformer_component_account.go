@@ -21,7 +21,6 @@ package apps
 
 import (
 	"fmt"
-	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -35,6 +34,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -101,9 +101,25 @@ func (t *componentAccountTransformer) buildAccountSecret(ctx *componentTransform
 			return nil, err
 		}
 	default:
-		password = t.buildPassword(ctx, account)
+		// the account secret may be lost when it's replaced by the password rotation, restore it with the password
+		// which has been changed in the engine.
+		secretName := constant.GenerateAccountSecretName(synthesizeComp.ClusterName, synthesizeComp.Name, account.Name)
+		pending, err := component.GetAppliedPendingAccountPassword(ctx.Context, ctx.Client, synthesizeComp.Namespace, secretName)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) > 0 {
+			password = pending
+		} else {
+			password = t.buildPassword(ctx, account)
+		}
 	}
-	return t.buildAccountSecretWithPassword(synthesizeComp, account, password), nil
+	secret := t.buildAccountSecretWithPassword(synthesizeComp, account, password)
+	// keep the password in the external secret manager if configured, only the reference is stored in the secret.
+	if err := secretstore.Seal(ctx.Context, secret, constant.AccountPasswdForSecret); err != nil {
+		return nil, err
+	}
+	return secret, nil
 }
 
 func (t *componentAccountTransformer) getPasswordFromSecret(ctx graph.TransformContext, account appsv1alpha1.SystemAccount) ([]byte, error) {
@@ -136,15 +152,7 @@ func (t *componentAccountTransformer) buildPassword(ctx *componentTransformConte
 }
 
 func (t *componentAccountTransformer) generatePassword(account appsv1alpha1.SystemAccount) []byte {
-	config := account.PasswordGenerationPolicy
-	passwd, _ := common.GeneratePassword((int)(config.Length), (int)(config.NumDigits), (int)(config.NumSymbols), false, config.Seed)
-	switch config.LetterCase {
-	case appsv1alpha1.UpperCases:
-		passwd = strings.ToUpper(passwd)
-	case appsv1alpha1.LowerCases:
-		passwd = strings.ToLower(passwd)
-	}
-	return []byte(passwd)
+	return component.GenerateAccountPassword(account.PasswordGenerationPolicy)
 }
 
 func (t *componentAccountTransformer) buildAccountSecretWithPassword(synthesizeComp *component.SynthesizedComponent,
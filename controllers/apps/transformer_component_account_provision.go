@@ -35,6 +35,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	"github.com/apecloud/kubeblocks/pkg/controllerutil"
 	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 	lorryModel "github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
@@ -211,7 +212,11 @@ func (t *componentAccountProvisionTransformer) provisionAccount(transCtx *compon
 		return err
 	}
 
-	username, password := secret.Data[constant.AccountNameForSecret], secret.Data[constant.AccountPasswdForSecret]
+	data, err := secretstore.Unseal(transCtx.Context, secret)
+	if err != nil {
+		return err
+	}
+	username, password := data[constant.AccountNameForSecret], data[constant.AccountPasswdForSecret]
 	if len(username) == 0 || len(password) == 0 {
 		return nil
 	}
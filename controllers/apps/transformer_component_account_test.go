@@ -0,0 +1,176 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const testSecretStoreProvider = "test-account"
+
+type testSecretStore struct {
+	store map[string]map[string][]byte
+}
+
+func (s *testSecretStore) Name() string {
+	return testSecretStoreProvider
+}
+
+func (s *testSecretStore) Ref(key string) string {
+	return key
+}
+
+func (s *testSecretStore) Put(_ context.Context, key string, values map[string][]byte) (string, error) {
+	s.store[key] = values
+	return s.Ref(key), nil
+}
+
+func (s *testSecretStore) Get(_ context.Context, ref string) (map[string][]byte, error) {
+	return s.store[ref], nil
+}
+
+func (s *testSecretStore) Delete(_ context.Context, ref string) error {
+	delete(s.store, ref)
+	return nil
+}
+
+var _ = Describe("component account transformer test", func() {
+	const (
+		compDefName = "test-compdef"
+		compName    = "mysql"
+	)
+
+	var (
+		clusterName string
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		clusterName = "test-cluster-account-" + testCtx.GetRandomStr()
+	})
+
+	AfterEach(cleanEnv)
+
+	newSynthesizedComp := func(account appsv1alpha1.SystemAccount) *component.SynthesizedComponent {
+		account.Name = "root"
+		account.PasswordGenerationPolicy = appsv1alpha1.PasswordConfig{
+			Length:    16,
+			NumDigits: 4,
+		}
+		return &component.SynthesizedComponent{
+			Namespace:      testCtx.DefaultNamespace,
+			ClusterName:    clusterName,
+			Name:           compName,
+			CompDefName:    compDefName,
+			SystemAccounts: []appsv1alpha1.SystemAccount{account},
+		}
+	}
+
+	Context("with the secret store", func() {
+		BeforeEach(func() {
+			store := &testSecretStore{store: map[string]map[string][]byte{}}
+			secretstore.RegisterProvider(testSecretStoreProvider, func() (secretstore.Provider, error) {
+				return store, nil
+			})
+			viper.Set(constant.CfgKeySecretStoreProvider, testSecretStoreProvider)
+		})
+
+		AfterEach(func() {
+			viper.Set(constant.CfgKeySecretStoreProvider, "")
+		})
+
+		It("should keep only the reference of the password in the secret", func() {
+			synthesizedComp := newSynthesizedComp(appsv1alpha1.SystemAccount{InitAccount: true})
+			transCtx, dag, graphCli := mockComponentTransformContext(synthesizedComp)
+			transCtx.Cluster = &appsv1alpha1.Cluster{}
+			Expect((&componentAccountTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+
+			secrets := graphCli.FindAll(dag, &corev1.Secret{})
+			Expect(secrets).Should(HaveLen(1))
+			secret := secrets[0].(*corev1.Secret)
+			Expect(secretstore.IsSealed(secret)).Should(BeTrue())
+			// only the reference is stored in the secret.
+			Expect(secret.Data).ShouldNot(HaveKey(constant.AccountPasswdForSecret))
+			values, err := secretstore.Unseal(transCtx.Context, secret)
+			Expect(err).Should(Succeed())
+			Expect(values[constant.AccountPasswdForSecret]).Should(HaveLen(16))
+
+			By("the pods refer to the password in the secret, which is delivered from the secret manager by the init container")
+			rsm, err := component.BuildRSMFrom(synthesizedComp, nil)
+			Expect(err).Should(Succeed())
+			Expect(rsm.Spec.Credential).ShouldNot(BeNil())
+			selector := rsm.Spec.Credential.Password.ValueFrom.SecretKeyRef
+			Expect(selector.Name).Should(Equal(secret.Name))
+			Expect(selector.Key).Should(Equal(constant.AccountPasswdForSecret))
+		})
+	})
+
+	It("should restore the rotated password if the account secret is lost when being replaced", func() {
+		synthesizedComp := newSynthesizedComp(appsv1alpha1.SystemAccount{})
+
+		By("the account secret is lost when being replaced by the password rotation, after the password is changed in the engine")
+		secretName := constant.GenerateAccountSecretName(synthesizedComp.ClusterName, synthesizedComp.Name, "root")
+		pendingSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   synthesizedComp.Namespace,
+				Name:        constant.GeneratePendingAccountSecretName(secretName),
+				Annotations: map[string]string{constant.PendingPasswordAppliedAnnotationKey: "true"},
+			},
+			Data: map[string][]byte{constant.AccountPasswdForSecret: []byte("rotated")},
+		}
+		Expect(testCtx.CreateObj(testCtx.Ctx, pendingSecret)).Should(Succeed())
+
+		transCtx, dag, graphCli := mockComponentTransformContext(synthesizedComp)
+		transCtx.Cluster = &appsv1alpha1.Cluster{}
+		Expect((&componentAccountTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+
+		secrets := graphCli.FindAll(dag, &corev1.Secret{})
+		Expect(secrets).Should(HaveLen(1))
+		secret := secrets[0].(*corev1.Secret)
+		Expect(secret.Name).Should(Equal(secretName))
+		Expect(string(secret.Data[constant.AccountPasswdForSecret])).Should(Equal("rotated"))
+	})
+})
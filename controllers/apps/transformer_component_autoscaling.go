@@ -0,0 +1,142 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"reflect"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	defaultScaleOutCooldownSeconds = 600
+	defaultScaleInCooldownSeconds  = 300
+	defaultAutoscalingStep         = 1
+)
+
+// componentAutoscalingTransformer handles the HorizontalPodAutoscaler of the component,
+// which scales the replicas of the component by the scale subresource of the Component.
+type componentAutoscalingTransformer struct{}
+
+var _ graph.Transformer = &componentAutoscalingTransformer{}
+
+func (t *componentAutoscalingTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizedComp := transCtx.SynthesizeComponent
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	key := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      synthesizedComp.FullCompName,
+	}
+	obj := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := transCtx.Client.Get(transCtx.Context, key, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		obj = nil
+	}
+
+	// the autoscaler is removed when the component is stopped, otherwise it scales the component out to the
+	// min replicas again, and it is restored when the component is started.
+	if synthesizedComp.Autoscaling == nil || synthesizedComp.Replicas == 0 {
+		if obj != nil && model.IsOwnerOf(transCtx.ComponentOrig, obj) {
+			graphCli.Delete(dag, obj)
+		}
+		return nil
+	}
+
+	hpa := buildHorizontalPodAutoscaler(synthesizedComp)
+	if obj == nil {
+		graphCli.Create(dag, hpa)
+		return nil
+	}
+	objCopy := obj.DeepCopy()
+	objCopy.Labels = hpa.Labels
+	objCopy.Spec = hpa.Spec
+	if !reflect.DeepEqual(obj, objCopy) {
+		graphCli.Update(dag, obj, objCopy)
+	}
+	return nil
+}
+
+func buildHorizontalPodAutoscaler(synthesizedComp *component.SynthesizedComponent) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := synthesizedComp.Autoscaling
+	orDefault := func(v *int32, d int32) *int32 {
+		if v != nil {
+			return v
+		}
+		return &d
+	}
+	scalingRules := func(cooldownSeconds, step *int32, defaultCooldownSeconds int32) *autoscalingv2.HPAScalingRules {
+		cooldown := orDefault(cooldownSeconds, defaultCooldownSeconds)
+		periodSeconds := *cooldown
+		// the period of the scaling policy must be in (0, 1800]
+		if periodSeconds < 1 {
+			periodSeconds = 1
+		}
+		if periodSeconds > 1800 {
+			periodSeconds = 1800
+		}
+		selectPolicy := autoscalingv2.MinChangePolicySelect
+		return &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: cooldown,
+			SelectPolicy:               &selectPolicy,
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{
+					Type:          autoscalingv2.PodsScalingPolicy,
+					Value:         *orDefault(step, defaultAutoscalingStep),
+					PeriodSeconds: periodSeconds,
+				},
+			},
+		}
+	}
+	minReplicas := orDefault(autoscaling.MinReplicas, 1)
+	return builder.NewHorizontalPodAutoscalerBuilder(synthesizedComp.Namespace, synthesizedComp.FullCompName).
+		AddLabelsInMap(constant.GetComponentWellKnownLabels(synthesizedComp.ClusterName, synthesizedComp.Name)).
+		SetScaleTargetRef(autoscalingv2.CrossVersionObjectReference{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       constant.ComponentKind,
+			Name:       synthesizedComp.FullCompName,
+		}).
+		SetMinReplicas(minReplicas).
+		SetMaxReplicas(autoscaling.MaxReplicas).
+		SetMetrics(autoscaling.Metrics).
+		SetBehavior(&autoscalingv2.HorizontalPodAutoscalerBehavior{
+			// scaling out a stateful component is expensive, the new replicas need to provision their data
+			// and join the membership, so that the replicas are added step by step with a long cool-down.
+			ScaleUp:   scalingRules(autoscaling.ScaleOutCooldownSeconds, autoscaling.ScaleOutStep, defaultScaleOutCooldownSeconds),
+			ScaleDown: scalingRules(autoscaling.ScaleInCooldownSeconds, autoscaling.ScaleInStep, defaultScaleInCooldownSeconds),
+		}).
+		GetObject()
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("component autoscaling transformer test", func() {
+	const (
+		compName = "mysql"
+	)
+
+	var (
+		clusterName string
+	)
+
+	hpaKey := func() types.NamespacedName {
+		return types.NamespacedName{
+			Namespace: testCtx.DefaultNamespace,
+			Name:      constant.GenerateClusterComponentName(clusterName, compName),
+		}
+	}
+
+	cleanEnv := func() {
+		By("clean resources")
+		if len(clusterName) > 0 {
+			testapps.DeleteObject(&testCtx, hpaKey(), &autoscalingv2.HorizontalPodAutoscaler{})
+		}
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		clusterName = "test-cluster-autoscaling-" + testCtx.GetRandomStr()
+	})
+
+	AfterEach(cleanEnv)
+
+	newSynthesizedComp := func(replicas int32) *component.SynthesizedComponent {
+		return &component.SynthesizedComponent{
+			Namespace:    testCtx.DefaultNamespace,
+			ClusterName:  clusterName,
+			Name:         compName,
+			FullCompName: constant.GenerateClusterComponentName(clusterName, compName),
+			Replicas:     replicas,
+			Autoscaling: &appsv1alpha1.ComponentAutoscaling{
+				MinReplicas: pointer.Int32(2),
+				MaxReplicas: 5,
+				Metrics: []autoscalingv2.MetricSpec{{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: pointer.Int32(80),
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	// createHPA creates the autoscaler owned by the component, it doesn't have the test label, which would be
+	// updated by the transformer.
+	createHPA := func(synthesizedComp *component.SynthesizedComponent) {
+		hpa := buildHorizontalPodAutoscaler(synthesizedComp)
+		hpa.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       constant.ComponentKind,
+			Name:       synthesizedComp.FullCompName,
+			UID:        types.UID(testCtx.GetRandomStr()),
+		}}
+		Expect(k8sClient.Create(ctx, hpa)).Should(Succeed())
+		Eventually(testapps.CheckObjExists(&testCtx, hpaKey(), &autoscalingv2.HorizontalPodAutoscaler{}, true)).Should(Succeed())
+	}
+
+	transform := func(synthesizedComp *component.SynthesizedComponent) *graph.DAG {
+		transCtx, dag, _ := mockComponentTransformContext(synthesizedComp)
+		Expect((&componentAutoscalingTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		return dag
+	}
+
+	hpaAction := func(dag *graph.DAG) *model.Action {
+		for _, v := range dag.Vertices() {
+			if vertex, ok := v.(*model.ObjectVertex); ok {
+				if _, ok := vertex.Obj.(*autoscalingv2.HorizontalPodAutoscaler); ok {
+					return vertex.Action
+				}
+			}
+		}
+		return nil
+	}
+
+	It("creates the autoscaler", func() {
+		action := hpaAction(transform(newSynthesizedComp(3)))
+		Expect(action).ShouldNot(BeNil())
+		Expect(*action).Should(Equal(model.CREATE))
+	})
+
+	It("keeps the autoscaler unchanged", func() {
+		synthesizedComp := newSynthesizedComp(3)
+		createHPA(synthesizedComp)
+		Expect(hpaAction(transform(synthesizedComp))).Should(BeNil())
+	})
+
+	It("removes the autoscaler when the component is stopped", func() {
+		synthesizedComp := newSynthesizedComp(0)
+		createHPA(synthesizedComp)
+		action := hpaAction(transform(synthesizedComp))
+		Expect(action).ShouldNot(BeNil())
+		Expect(*action).Should(Equal(model.DELETE))
+
+		By("the autoscaler is not created while the component is stopped")
+		testapps.DeleteObject(&testCtx, hpaKey(), &autoscalingv2.HorizontalPodAutoscaler{})
+		Eventually(testapps.CheckObjExists(&testCtx, hpaKey(), &autoscalingv2.HorizontalPodAutoscaler{}, false)).Should(Succeed())
+		Expect(hpaAction(transform(synthesizedComp))).Should(BeNil())
+	})
+
+	It("restores the autoscaler when the component is started", func() {
+		action := hpaAction(transform(newSynthesizedComp(2)))
+		Expect(action).ShouldNot(BeNil())
+		Expect(*action).Should(Equal(model.CREATE))
+	})
+
+	It("removes the autoscaler when the autoscaling is disabled", func() {
+		synthesizedComp := newSynthesizedComp(3)
+		createHPA(synthesizedComp)
+		synthesizedComp.Autoscaling = nil
+		action := hpaAction(transform(synthesizedComp))
+		Expect(action).ShouldNot(BeNil())
+		Expect(*action).Should(Equal(model.DELETE))
+	})
+})
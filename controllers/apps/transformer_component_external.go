@@ -0,0 +1,329 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	defaultExternalProbePeriodSeconds  = 30
+	defaultExternalProbeTimeoutSeconds = 5
+
+	// externalProbeResultTTL is how long a probe result is kept after it was last asked for.
+	externalProbeResultTTL = time.Hour
+)
+
+// probeExternalEndpoint checks whether the external endpoint is reachable, it can be overridden in tests.
+var probeExternalEndpoint = func(endpoint, port string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(endpoint, port), timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// externalProber probes the external endpoints in background, so the reconciliation never waits on the network.
+var externalProber = &externalEndpointProber{results: map[string]*externalProbeResult{}}
+
+type externalProbeResult struct {
+	err       error
+	probed    bool
+	probedAt  time.Time
+	probing   bool
+	requestAt time.Time
+}
+
+type externalEndpointProber struct {
+	mu      sync.Mutex
+	results map[string]*externalProbeResult
+}
+
+// result returns the latest probe result of the endpoint, and starts a new probe if there is none running
+// and the latest result is older than the period. The probed flag is false until the first probe completes.
+func (p *externalEndpointProber) result(endpoint, port string, timeout, period time.Duration) (probed bool, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for key, r := range p.results {
+		if !r.probing && now.Sub(r.requestAt) > externalProbeResultTTL {
+			delete(p.results, key)
+		}
+	}
+
+	key := net.JoinHostPort(endpoint, port)
+	r, ok := p.results[key]
+	if !ok {
+		r = &externalProbeResult{}
+		p.results[key] = r
+	}
+	r.requestAt = now
+	if !r.probing && (!r.probed || now.Sub(r.probedAt) >= period) {
+		r.probing = true
+		go func() {
+			probeErr := probeExternalEndpoint(endpoint, port, timeout)
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			r.err, r.probed, r.probedAt, r.probing = probeErr, true, time.Now(), false
+		}()
+	}
+	return r.probed, r.err
+}
+
+// componentChainSwitchTransformer runs the external chain for the component which stands for a database running
+// outside of Kubernetes, and the workload chain for the others.
+type componentChainSwitchTransformer struct {
+	external graph.TransformerChain
+	workload graph.TransformerChain
+}
+
+var _ graph.Transformer = &componentChainSwitchTransformer{}
+
+func (t *componentChainSwitchTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if transCtx.Component.Spec.External != nil {
+		return t.external.ApplyTo(ctx, dag)
+	}
+	return t.workload.ApplyTo(ctx, dag)
+}
+
+// componentExternalTransformer handles the component which stands for a database running outside of Kubernetes.
+// It renders the connection credential and the alias service from the referenced ServiceDescriptor,
+// and reports the component status by the result of probing the external endpoint in background.
+// The external component has no workloads, it is reconciled by a dedicated transformer chain.
+type componentExternalTransformer struct{}
+
+var _ graph.Transformer = &componentExternalTransformer{}
+
+func (t *componentExternalTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizedComp := transCtx.SynthesizeComponent
+	if synthesizedComp.External == nil {
+		return nil
+	}
+
+	sd, err := t.resolveServiceDescriptor(transCtx, synthesizedComp)
+	if err != nil {
+		return err
+	}
+
+	svc, endpoints, err := t.buildAliasService(synthesizedComp, sd)
+	if err != nil {
+		return err
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	if err = createOrUpdateService(ctx, dag, graphCli, svc, nil); err != nil {
+		return err
+	}
+	if err = t.createOrUpdateAliasEndpoints(transCtx, dag, graphCli, svc, endpoints); err != nil {
+		return err
+	}
+	if err = t.createOrUpdateConnCredential(transCtx, dag, graphCli, synthesizedComp, sd); err != nil {
+		return err
+	}
+
+	t.reconcileStatus(transCtx, synthesizedComp, sd)
+	graphCli.Status(dag, transCtx.ComponentOrig, transCtx.Component)
+
+	// delay the requeue to let the following transformers run.
+	return intctrlutil.NewDelayedRequeueError(externalProbePeriod(synthesizedComp.External), "requeue to probe the external component")
+}
+
+func (t *componentExternalTransformer) resolveServiceDescriptor(transCtx *componentTransformContext,
+	synthesizedComp *component.SynthesizedComponent) (*appsv1alpha1.ServiceDescriptor, error) {
+	sd := &appsv1alpha1.ServiceDescriptor{}
+	sdKey := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      synthesizedComp.External.ServiceDescriptor,
+	}
+	if err := transCtx.Client.Get(transCtx.Context, sdKey, sd); err != nil {
+		return nil, err
+	}
+	if sd.Status.Phase != appsv1alpha1.AvailablePhase {
+		return nil, newRequeueError(requeueDuration, fmt.Sprintf("referenced ServiceDescriptor %s is not available", sdKey.Name))
+	}
+	if sd.Spec.Endpoint == nil || sd.Spec.Port == nil {
+		return nil, fmt.Errorf("the endpoint and port of referenced ServiceDescriptor %s are required for external component", sdKey.Name)
+	}
+	if err := component.ResolveServiceDescriptorCredentialVars(transCtx.Context, transCtx.Client, sd); err != nil {
+		return nil, err
+	}
+	return sd, nil
+}
+
+// buildAliasService builds the service aliasing the external endpoint. The ExternalName service is valid
+// only for the host names, so the IP endpoint is aliased by the service without selectors and the Endpoints
+// of the same name, which is returned as well.
+func (t *componentExternalTransformer) buildAliasService(synthesizedComp *component.SynthesizedComponent,
+	sd *appsv1alpha1.ServiceDescriptor) (*corev1.Service, *corev1.Endpoints, error) {
+	port, err := strconv.ParseInt(sd.Spec.Port.Value, 10, 32)
+	if err != nil || port <= 0 || port > 65535 {
+		return nil, nil, fmt.Errorf("the port %q of referenced ServiceDescriptor %s is not a valid port number", sd.Spec.Port.Value, sd.Name)
+	}
+	svcName := component.ServiceName(synthesizedComp, synthesizedComp.Name, "")
+	labels := constant.GetComponentWellKnownLabels(synthesizedComp.ClusterName, synthesizedComp.Name)
+	svcBuilder := builder.NewServiceBuilder(synthesizedComp.Namespace, svcName).
+		AddLabelsInMap(labels).
+		AddPorts(corev1.ServicePort{
+			Name:     "default",
+			Protocol: corev1.ProtocolTCP,
+			Port:     int32(port),
+		})
+	if net.ParseIP(sd.Spec.Endpoint.Value) == nil {
+		return svcBuilder.SetExternalName(sd.Spec.Endpoint.Value).GetObject(), nil, nil
+	}
+	endpoints := builder.NewEndpointsBuilder(synthesizedComp.Namespace, svcName).
+		AddLabelsInMap(labels).
+		AddSubsets(corev1.EndpointSubset{
+			Addresses: []corev1.EndpointAddress{{IP: sd.Spec.Endpoint.Value}},
+			Ports: []corev1.EndpointPort{{
+				Name:     "default",
+				Protocol: corev1.ProtocolTCP,
+				Port:     int32(port),
+			}},
+		}).
+		GetObject()
+	return svcBuilder.SetType(corev1.ServiceTypeClusterIP).GetObject(), endpoints, nil
+}
+
+// createOrUpdateAliasEndpoints reconciles the Endpoints of the alias service, the Endpoints left by the IP endpoint
+// is deleted if the external endpoint is changed to a host name.
+func (t *componentExternalTransformer) createOrUpdateAliasEndpoints(transCtx *componentTransformContext, dag *graph.DAG,
+	graphCli model.GraphClient, svc *corev1.Service, endpoints *corev1.Endpoints) error {
+	obj := &corev1.Endpoints{}
+	err := transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(svc), obj)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	switch {
+	case endpoints == nil:
+		if err == nil && model.IsOwnerOf(transCtx.ComponentOrig, obj) {
+			graphCli.Delete(dag, obj)
+		}
+	case err != nil: // not-found
+		graphCli.Create(dag, endpoints)
+	case !reflect.DeepEqual(obj.Subsets, endpoints.Subsets):
+		objCopy := obj.DeepCopy()
+		objCopy.Subsets = endpoints.Subsets
+		graphCli.Update(dag, obj, objCopy)
+	}
+	return nil
+}
+
+func (t *componentExternalTransformer) createOrUpdateConnCredential(transCtx *componentTransformContext, dag *graph.DAG,
+	graphCli model.GraphClient, synthesizedComp *component.SynthesizedComponent, sd *appsv1alpha1.ServiceDescriptor) error {
+	data := map[string][]byte{
+		constant.ServiceDescriptorEndpointKey: []byte(component.ServiceName(synthesizedComp, synthesizedComp.Name, "")),
+		constant.ServiceDescriptorPortKey:     []byte(sd.Spec.Port.Value),
+	}
+	if sd.Spec.Auth != nil {
+		if sd.Spec.Auth.Username != nil {
+			data[constant.ServiceDescriptorUsernameKey] = []byte(sd.Spec.Auth.Username.Value)
+		}
+		if sd.Spec.Auth.Password != nil {
+			data[constant.ServiceDescriptorPasswordKey] = []byte(sd.Spec.Auth.Password.Value)
+		}
+	}
+
+	secretKey := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      constant.GenerateComponentConnCredential(synthesizedComp.ClusterName, synthesizedComp.Name),
+	}
+	secret := &corev1.Secret{}
+	err := transCtx.Client.Get(transCtx.Context, secretKey, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err != nil { // not-found
+		obj := builder.NewSecretBuilder(secretKey.Namespace, secretKey.Name).
+			AddLabelsInMap(constant.GetComponentWellKnownLabels(synthesizedComp.ClusterName, synthesizedComp.Name)).
+			SetData(data).
+			GetObject()
+		graphCli.Create(dag, obj)
+	} else if !reflect.DeepEqual(secret.Data, data) {
+		secretCopy := secret.DeepCopy()
+		secretCopy.Data = data
+		graphCli.Update(dag, secret, secretCopy)
+	}
+	return nil
+}
+
+func (t *componentExternalTransformer) reconcileStatus(transCtx *componentTransformContext,
+	synthesizedComp *component.SynthesizedComponent, sd *appsv1alpha1.ServiceDescriptor) {
+	comp := transCtx.Component
+	comp.Status.ObservedGeneration = comp.Generation
+
+	timeout := time.Duration(defaultExternalProbeTimeoutSeconds) * time.Second
+	if synthesizedComp.External.ProbeTimeoutSeconds > 0 {
+		timeout = time.Duration(synthesizedComp.External.ProbeTimeoutSeconds) * time.Second
+	}
+	probed, err := externalProber.result(sd.Spec.Endpoint.Value, sd.Spec.Port.Value, timeout, externalProbePeriod(synthesizedComp.External))
+	if !probed {
+		// the first probe is still running, keep the status unchanged until it completes.
+		if comp.Status.Phase == "" {
+			comp.Status.Phase = appsv1alpha1.CreatingClusterCompPhase
+		}
+		return
+	}
+
+	phase := appsv1alpha1.RunningClusterCompPhase
+	message := appsv1alpha1.ComponentMessageMap{}
+	if err != nil {
+		phase = appsv1alpha1.FailedClusterCompPhase
+		message.SetObjectMessage("ServiceDescriptor", sd.Name, fmt.Sprintf("external endpoint is unreachable: %s", err.Error()))
+	}
+
+	if comp.Status.Phase != phase {
+		transCtx.EventRecorder.Eventf(comp, corev1.EventTypeNormal, componentPhaseTransition,
+			fmt.Sprintf("external component is %s", phase))
+	}
+	comp.Status.Phase = phase
+	comp.Status.Message = message
+}
+
+func externalProbePeriod(external *appsv1alpha1.ExternalComponent) time.Duration {
+	if external.ProbePeriodSeconds > 0 {
+		return time.Duration(external.ProbePeriodSeconds) * time.Second
+	}
+	return time.Duration(defaultExternalProbePeriodSeconds) * time.Second
+}
@@ -0,0 +1,239 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("component external transformer test", func() {
+	const (
+		clusterName            = "test-cluster"
+		compName               = "mysql"
+		serviceDescriptorName  = "external-mysql"
+		externalEndpoint       = "mysql.example.com"
+		externalEndpointIP     = "10.0.0.1"
+		externalPort           = "3306"
+		serviceDescriptorKind  = "mysql"
+		serviceDescriptorVer   = "8.0.33"
+		externalProbeTimeout   = 5 * time.Second
+		externalProbeInterval  = 10 * time.Millisecond
+		externalProbeResultTTL = time.Hour
+	)
+
+	var (
+		probeErr        error
+		origProbe       func(endpoint, port string, timeout time.Duration) error
+		origProber      *externalEndpointProber
+		synthesizedComp *component.SynthesizedComponent
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ServiceDescriptorSignature, true, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.EndpointsSignature, inNS, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		probeErr = nil
+		origProbe, origProber = probeExternalEndpoint, externalProber
+		probeExternalEndpoint = func(endpoint, port string, timeout time.Duration) error {
+			return probeErr
+		}
+		externalProber = &externalEndpointProber{results: map[string]*externalProbeResult{}}
+
+		synthesizedComp = &component.SynthesizedComponent{
+			Namespace:   testCtx.DefaultNamespace,
+			ClusterName: clusterName,
+			Name:        compName,
+			External:    &appsv1alpha1.ExternalComponent{ServiceDescriptor: serviceDescriptorName, ProbePeriodSeconds: 1},
+		}
+	})
+
+	AfterEach(func() {
+		probeExternalEndpoint, externalProber = origProbe, origProber
+		cleanEnv()
+	})
+
+	createServiceDescriptor := func(endpoint, port string) {
+		sd := testapps.NewServiceDescriptorFactory(testCtx.DefaultNamespace, serviceDescriptorName).
+			SetServiceKind(serviceDescriptorKind).
+			SetServiceVersion(serviceDescriptorVer).
+			SetEndpoint(appsv1alpha1.CredentialVar{Value: endpoint}).
+			SetPort(appsv1alpha1.CredentialVar{Value: port}).
+			Create(&testCtx).
+			GetObject()
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(sd),
+			func(g Gomega, sd *appsv1alpha1.ServiceDescriptor) {
+				g.Expect(sd.Status.Phase).Should(Equal(appsv1alpha1.AvailablePhase))
+			})).Should(Succeed())
+	}
+
+	// waitProbed triggers the probe by transforming and waits until the probe completes.
+	waitProbed := func() {
+		Eventually(func() bool {
+			probed, _ := externalProber.result(externalEndpoint, externalPort, time.Second, externalProbeResultTTL)
+			return probed
+		}, externalProbeTimeout, externalProbeInterval).Should(BeTrue())
+	}
+
+	Context("external component", func() {
+		It("renders the alias service and credential, and reports the probe result", func() {
+			createServiceDescriptor(externalEndpoint, externalPort)
+			transCtx, dag, graphCli := mockComponentTransformContext(synthesizedComp)
+
+			err := (&componentExternalTransformer{}).Transform(transCtx, dag)
+			Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+			Expect(transCtx.Component.Status.Phase).Should(Equal(appsv1alpha1.CreatingClusterCompPhase))
+
+			services := graphCli.FindAll(dag, &corev1.Service{})
+			Expect(services).Should(HaveLen(1))
+			svc := services[0].(*corev1.Service)
+			Expect(svc.Spec.ExternalName).Should(Equal(externalEndpoint))
+			Expect(svc.Spec.Ports[0].Port).Should(BeEquivalentTo(3306))
+			Expect(graphCli.FindAll(dag, &corev1.Secret{})).Should(HaveLen(1))
+
+			By("the component is running once the external endpoint is probed")
+			waitProbed()
+			transCtx, dag, _ = mockComponentTransformContext(synthesizedComp)
+			_ = (&componentExternalTransformer{}).Transform(transCtx, dag)
+			Expect(transCtx.Component.Status.Phase).Should(Equal(appsv1alpha1.RunningClusterCompPhase))
+		})
+
+		It("reports failed if the external endpoint is unreachable", func() {
+			createServiceDescriptor(externalEndpoint, externalPort)
+			probeErr = errors.New("connection refused")
+
+			waitProbed()
+			transCtx, dag, _ := mockComponentTransformContext(synthesizedComp)
+			_ = (&componentExternalTransformer{}).Transform(transCtx, dag)
+			Expect(transCtx.Component.Status.Phase).Should(Equal(appsv1alpha1.FailedClusterCompPhase))
+			Expect(transCtx.Component.Status.Message["ServiceDescriptor/"+serviceDescriptorName]).Should(ContainSubstring("connection refused"))
+		})
+
+		It("aliases the IP endpoint by the service without selectors and the Endpoints", func() {
+			createServiceDescriptor(externalEndpointIP, externalPort)
+			transCtx, dag, graphCli := mockComponentTransformContext(synthesizedComp)
+			_ = (&componentExternalTransformer{}).Transform(transCtx, dag)
+
+			services := graphCli.FindAll(dag, &corev1.Service{})
+			Expect(services).Should(HaveLen(1))
+			svc := services[0].(*corev1.Service)
+			Expect(svc.Spec.Type).Should(Equal(corev1.ServiceTypeClusterIP))
+			Expect(svc.Spec.ExternalName).Should(BeEmpty())
+			Expect(svc.Spec.Selector).Should(BeEmpty())
+			endpoints := graphCli.FindAll(dag, &corev1.Endpoints{})
+			Expect(endpoints).Should(HaveLen(1))
+			ep := endpoints[0].(*corev1.Endpoints)
+			Expect(ep.Name).Should(Equal(svc.Name))
+			Expect(ep.Subsets[0].Addresses[0].IP).Should(Equal(externalEndpointIP))
+			Expect(ep.Subsets[0].Ports[0].Port).Should(BeEquivalentTo(3306))
+		})
+
+		It("deletes the Endpoints if the endpoint is changed to a host name", func() {
+			createServiceDescriptor(externalEndpoint, externalPort)
+			ep := &corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      component.ServiceName(synthesizedComp, synthesizedComp.Name, ""),
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: appsv1alpha1.GroupVersion.String(),
+						Kind:       constant.ComponentKind,
+						Name:       constant.GenerateClusterComponentName(clusterName, compName),
+						UID:        types.UID("mock-uid"),
+					}},
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, ep)).Should(Succeed())
+			transCtx, dag, graphCli := mockComponentTransformContext(synthesizedComp)
+			_ = (&componentExternalTransformer{}).Transform(transCtx, dag)
+
+			endpoints := graphCli.FindAll(dag, &corev1.Endpoints{})
+			Expect(endpoints).Should(HaveLen(1))
+			Expect(graphCli.IsAction(dag, endpoints[0], model.ActionDeletePtr())).Should(BeTrue())
+		})
+
+		It("rejects the port which is not a number", func() {
+			createServiceDescriptor(externalEndpoint, "mysql")
+			transCtx, dag, _ := mockComponentTransformContext(synthesizedComp)
+			err := (&componentExternalTransformer{}).Transform(transCtx, dag)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not a valid port number"))
+		})
+	})
+
+	Context("component chain switch", func() {
+		It("runs the external chain for the external component only", func() {
+			var ran string
+			transformer := &componentChainSwitchTransformer{
+				external: graph.TransformerChain{transformerFunc(func(graph.TransformContext, *graph.DAG) error {
+					ran = "external"
+					return nil
+				})},
+				workload: graph.TransformerChain{transformerFunc(func(graph.TransformContext, *graph.DAG) error {
+					ran = "workload"
+					return nil
+				})},
+			}
+			synthesizedComp.External = nil
+
+			transCtx, dag, _ := mockComponentTransformContext(synthesizedComp)
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(ran).Should(Equal("workload"))
+
+			transCtx.Component.Spec.External = &appsv1alpha1.ExternalComponent{ServiceDescriptor: serviceDescriptorName}
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(ran).Should(Equal("external"))
+		})
+	})
+})
+
+type transformerFunc func(ctx graph.TransformContext, dag *graph.DAG) error
+
+func (f transformerFunc) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	return f(ctx, dag)
+}
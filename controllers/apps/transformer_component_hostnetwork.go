@@ -47,6 +47,9 @@ func isHostNetworkEnabled(transCtx *componentTransformContext) bool {
 	if synthesizedComp.PodSpec.HostNetwork {
 		return true
 	}
+	if transCtx.Component.Spec.HostNetwork {
+		return true
+	}
 	// TODO: use component.annotations
 	cluster := transCtx.Cluster
 	if cluster.Annotations == nil {
@@ -91,6 +94,9 @@ func allocateHostPortsWithFunc(pm *intctrlutil.PortManager, synthesizedComp *com
 				if err != nil {
 					return nil, err
 				}
+				if ports[c.Name] == nil {
+					ports[c.Name] = map[string]int32{}
+				}
 				ports[c.Name][p.Name] = port
 			} else {
 				if err := pm.UsePort(portKey, p.ContainerPort); err != nil {
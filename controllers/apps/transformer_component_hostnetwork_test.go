@@ -0,0 +1,92 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("component host network transformer test", func() {
+	var (
+		clusterName   = "test-cluster-hostnetwork-" + testCtx.GetRandomStr()
+		includeRanges string
+	)
+
+	BeforeEach(func() {
+		includeRanges = viper.GetString(constant.CfgHostPortIncludeRanges)
+		viper.Set(constant.CfgHostPortIncludeRanges, "30000-30100")
+		Expect(intctrlutil.InitHostPortManager(k8sClient)).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(intctrlutil.GetPortManager().ReleaseByPrefix(clusterName)).Should(Succeed())
+		viper.Set(constant.CfgHostPortIncludeRanges, includeRanges)
+		Expect(intctrlutil.InitHostPortManager(k8sClient)).Should(Succeed())
+	})
+
+	It("allocates the host ports for the ports declared in the host network", func() {
+		synthesizedComp := &component.SynthesizedComponent{
+			Namespace:   testCtx.DefaultNamespace,
+			ClusterName: clusterName,
+			Name:        "mysql",
+			Monitor:     &component.MonitorConfig{},
+			HostNetwork: &appsv1alpha1.HostNetwork{
+				ContainerPorts: []appsv1alpha1.HostNetworkContainerPort{
+					{Container: "mysql", Ports: []string{"mysql", "admin"}},
+				},
+			},
+			PodSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "mysql",
+						Ports: []corev1.ContainerPort{
+							{Name: "mysql", ContainerPort: 3306},
+							{Name: "admin", ContainerPort: 33062},
+							{Name: "exporter", ContainerPort: 9104},
+						},
+					},
+				},
+			},
+		}
+		transCtx, dag, _ := mockComponentTransformContext(synthesizedComp)
+		transCtx.Component.Spec.HostNetwork = true
+
+		Expect((&componentHostNetworkTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+		podSpec := synthesizedComp.PodSpec
+		Expect(podSpec.HostNetwork).Should(BeTrue())
+		ports := podSpec.Containers[0].Ports
+		for _, p := range ports[:2] {
+			Expect(p.ContainerPort).Should(BeNumerically(">=", 30000), p.Name)
+			Expect(p.ContainerPort).Should(BeNumerically("<=", 30100), p.Name)
+		}
+		Expect(ports[0].ContainerPort).ShouldNot(Equal(ports[1].ContainerPort))
+		By("the port not declared in the host network is kept")
+		Expect(ports[2].ContainerPort).Should(Equal(int32(9104)))
+	})
+})
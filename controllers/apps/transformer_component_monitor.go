@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// componentMonitorTransformer provisions the Grafana dashboards and the Prometheus alert rules of the component
+// from the templates of the engine, if the monitoring of the component is enabled.
+type componentMonitorTransformer struct{}
+
+var _ graph.Transformer = &componentMonitorTransformer{}
+
+func (t *componentMonitorTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizedComp := transCtx.SynthesizeComponent
+	templates := &appsv1alpha1.MonitorTemplates{}
+	if synthesizedComp.Monitor != nil && synthesizedComp.Monitor.Enable && synthesizedComp.Monitor.Templates != nil {
+		templates = synthesizedComp.Monitor.Templates
+	}
+	if err := t.reconcileDashboards(transCtx, dag, templates); err != nil {
+		return err
+	}
+	return t.reconcileAlertRules(transCtx, dag, templates)
+}
+
+func (t *componentMonitorTransformer) reconcileDashboards(transCtx *componentTransformContext,
+	dag *graph.DAG, templates *appsv1alpha1.MonitorTemplates) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	obj := &corev1.ConfigMap{}
+	key := types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: component.GrafanaDashboardsName(synthesizedComp)}
+	if err := transCtx.Client.Get(transCtx.Context, key, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		obj = nil
+	}
+
+	if len(templates.DashboardsTemplateRef) == 0 {
+		if obj != nil && model.IsOwnerOf(transCtx.ComponentOrig, obj) {
+			graphCli.Delete(dag, obj)
+		}
+		return nil
+	}
+
+	tpl, err := getMonitorTemplate(transCtx.Context, transCtx.Client, templates.Namespace, templates.DashboardsTemplateRef)
+	if err != nil {
+		return err
+	}
+	dashboards := component.BuildGrafanaDashboards(synthesizedComp, tpl)
+	if obj == nil {
+		graphCli.Create(dag, dashboards)
+		return nil
+	}
+	objCopy := obj.DeepCopy()
+	objCopy.Labels = dashboards.Labels
+	objCopy.Data = dashboards.Data
+	if !reflect.DeepEqual(obj, objCopy) {
+		graphCli.Update(dag, obj, objCopy)
+	}
+	return nil
+}
+
+func (t *componentMonitorTransformer) reconcileAlertRules(transCtx *componentTransformContext,
+	dag *graph.DAG, templates *appsv1alpha1.MonitorTemplates) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(component.PrometheusRuleGVK)
+	key := types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: component.PrometheusRuleName(synthesizedComp)}
+	if err := transCtx.Client.Get(transCtx.Context, key, obj); err != nil {
+		// the Prometheus Operator is optional, the alert rules are not provisioned if it's not installed.
+		if meta.IsNoMatchError(err) {
+			if len(templates.AlertRulesTemplateRef) > 0 {
+				transCtx.Logger.V(1).Info("the Prometheus Operator is not installed, skip provisioning the alert rules")
+			}
+			return nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		obj = nil
+	}
+
+	if len(templates.AlertRulesTemplateRef) == 0 {
+		if obj != nil && model.IsOwnerOf(transCtx.ComponentOrig, obj) {
+			graphCli.Delete(dag, obj)
+		}
+		return nil
+	}
+
+	tpl, err := getMonitorTemplate(transCtx.Context, transCtx.Client, templates.Namespace, templates.AlertRulesTemplateRef)
+	if err != nil {
+		return err
+	}
+	rule, err := component.BuildPrometheusRule(synthesizedComp, tpl)
+	if err != nil {
+		return err
+	}
+	if obj == nil {
+		graphCli.Create(dag, rule)
+		return nil
+	}
+	// compare the specs in JSON, the numbers are decoded into different types from the template and the server.
+	spec, _ := json.Marshal(rule.Object["spec"])
+	objSpec, _ := json.Marshal(obj.Object["spec"])
+	if string(spec) != string(objSpec) || !reflect.DeepEqual(obj.GetLabels(), rule.GetLabels()) {
+		objCopy := obj.DeepCopy()
+		objCopy.SetLabels(rule.GetLabels())
+		objCopy.Object["spec"] = rule.Object["spec"]
+		graphCli.Update(dag, obj, objCopy)
+	}
+	return nil
+}
+
+func getMonitorTemplate(ctx context.Context, cli client.Reader, namespace, name string) (*corev1.ConfigMap, error) {
+	if len(namespace) == 0 {
+		namespace = corev1.NamespaceDefault
+	}
+	tpl := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, tpl); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
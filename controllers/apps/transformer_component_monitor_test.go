@@ -0,0 +1,256 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("component monitor transformer test", func() {
+	const (
+		compName = "mysql"
+	)
+
+	var (
+		clusterName     string
+		tplName         string
+		synthesizedComp *component.SynthesizedComponent
+	)
+
+	dashboardsKey := func() types.NamespacedName {
+		return types.NamespacedName{Namespace: testCtx.DefaultNamespace, Name: component.GrafanaDashboardsName(synthesizedComp)}
+	}
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentSignature, true, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.ConfigMapSignature, inNS, ml)
+		if synthesizedComp != nil {
+			testapps.DeleteObject(&testCtx, dashboardsKey(), &corev1.ConfigMap{})
+		}
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		clusterName = "test-cluster-monitor-" + testCtx.GetRandomStr()
+		tplName = "test-monitor-tpl-" + testCtx.GetRandomStr()
+	})
+
+	AfterEach(cleanEnv)
+
+	Context("provision the dashboards", func() {
+		newSynthesizedComp := func(enable bool) *component.SynthesizedComponent {
+			return &component.SynthesizedComponent{
+				Namespace:    testCtx.DefaultNamespace,
+				ClusterName:  clusterName,
+				Name:         compName,
+				FullCompName: constant.GenerateClusterComponentName(clusterName, compName),
+				Monitor: &component.MonitorConfig{
+					Enable: enable,
+					Templates: &appsv1alpha1.MonitorTemplates{
+						Namespace:             testCtx.DefaultNamespace,
+						DashboardsTemplateRef: tplName,
+					},
+				},
+			}
+		}
+
+		createTemplate := func(dashboard string) *corev1.ConfigMap {
+			tpl := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: tplName},
+				Data:       map[string]string{"mysql.json": dashboard},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, tpl)).Should(Succeed())
+			return tpl
+		}
+
+		// createDashboards creates the dashboards owned by the component, it doesn't have the test label, which
+		// would be updated by the transformer.
+		createDashboards := func(tpl *corev1.ConfigMap) {
+			dashboards := component.BuildGrafanaDashboards(synthesizedComp, tpl)
+			dashboards.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: appsv1alpha1.GroupVersion.String(),
+				Kind:       constant.ComponentKind,
+				Name:       synthesizedComp.FullCompName,
+				UID:        types.UID(testCtx.GetRandomStr()),
+			}}
+			Expect(k8sClient.Create(ctx, dashboards)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, dashboardsKey(), &corev1.ConfigMap{}, true)).Should(Succeed())
+		}
+
+		transform := func() (*graph.DAG, model.GraphClient, error) {
+			transCtx, dag, graphCli := mockComponentTransformContext(synthesizedComp)
+			// the PrometheusRule CRD is not installed in the test environment, as if the Prometheus Operator
+			// is not installed.
+			err := (&componentMonitorTransformer{}).Transform(transCtx, dag)
+			return dag, graphCli, err
+		}
+
+		dashboardsOf := func(dag *graph.DAG, graphCli model.GraphClient) *corev1.ConfigMap {
+			objs := graphCli.FindAll(dag, &corev1.ConfigMap{})
+			if len(objs) != 1 {
+				return nil
+			}
+			return objs[0].(*corev1.ConfigMap)
+		}
+
+		It("provisions the dashboards from the template", func() {
+			synthesizedComp = newSynthesizedComp(true)
+			createTemplate(`{"title": "$(CLUSTER_NAME)"}`)
+
+			dag, graphCli, err := transform()
+			Expect(err).Should(Succeed())
+			dashboards := dashboardsOf(dag, graphCli)
+			Expect(dashboards).ShouldNot(BeNil())
+			Expect(graphCli.IsAction(dag, dashboards, model.ActionCreatePtr())).Should(BeTrue())
+			Expect(dashboards.Data["mysql.json"]).Should(Equal(fmt.Sprintf(`{"title": "%s"}`, clusterName)))
+		})
+
+		It("applies the changes of the template", func() {
+			synthesizedComp = newSynthesizedComp(true)
+			tpl := createTemplate(`{"title": "$(CLUSTER_NAME)"}`)
+			createDashboards(tpl)
+			Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(tpl), func(tpl *corev1.ConfigMap) {
+				tpl.Data["mysql.json"] = `{"title": "$(COMP_NAME)"}`
+			})()).Should(Succeed())
+
+			dag, graphCli, err := transform()
+			Expect(err).Should(Succeed())
+			dashboards := dashboardsOf(dag, graphCli)
+			Expect(dashboards).ShouldNot(BeNil())
+			Expect(graphCli.IsAction(dag, dashboards, model.ActionUpdatePtr())).Should(BeTrue())
+			Expect(dashboards.Data["mysql.json"]).Should(Equal(`{"title": "mysql"}`))
+		})
+
+		It("does nothing if the template is not changed", func() {
+			synthesizedComp = newSynthesizedComp(true)
+			createDashboards(createTemplate(`{"title": "$(CLUSTER_NAME)"}`))
+
+			dag, graphCli, err := transform()
+			Expect(err).Should(Succeed())
+			Expect(dashboardsOf(dag, graphCli)).Should(BeNil())
+		})
+
+		It("deletes the dashboards if the monitoring is disabled", func() {
+			synthesizedComp = newSynthesizedComp(false)
+			createDashboards(createTemplate(`{"title": "$(CLUSTER_NAME)"}`))
+
+			dag, graphCli, err := transform()
+			Expect(err).Should(Succeed())
+			dashboards := dashboardsOf(dag, graphCli)
+			Expect(dashboards).ShouldNot(BeNil())
+			Expect(graphCli.IsAction(dag, dashboards, model.ActionDeletePtr())).Should(BeTrue())
+		})
+
+		It("fails if the template is not found", func() {
+			synthesizedComp = newSynthesizedComp(true)
+
+			_, _, err := transform()
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("map the monitor templates to the components", func() {
+		It("enqueues the components referring to the template", func() {
+			monitor := &appsv1alpha1.MonitorConfig{
+				BuiltIn: true,
+				Templates: &appsv1alpha1.MonitorTemplates{
+					Namespace:             testCtx.DefaultNamespace,
+					AlertRulesTemplateRef: tplName,
+				},
+			}
+
+			By("create the components referring to the template by the component definition")
+			compDefName := "test-compdef-monitor-" + testCtx.GetRandomStr()
+			testapps.NewComponentDefinitionFactory(compDefName).
+				SetDefaultSpec().
+				Apply(func(compDef *appsv1alpha1.ComponentDefinition) {
+					compDef.Spec.Monitor = monitor
+				}).
+				Create(&testCtx)
+			compKey := types.NamespacedName{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+			}
+			testapps.NewComponentFactory(compKey.Namespace, compKey.Name, compDefName).
+				AddLabels(constant.ComponentDefinitionLabelKey, compDefName).
+				Create(&testCtx)
+			testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, "redis"), "redis").
+				AddLabels(constant.ComponentDefinitionLabelKey, "redis").
+				Create(&testCtx)
+
+			By("create the legacy cluster referring to the template by the cluster definition")
+			clusterDefName := "test-clusterdef-monitor-" + testCtx.GetRandomStr()
+			testapps.NewClusterDefFactory(clusterDefName).
+				AddComponentDef(testapps.StatefulMySQLComponent, "mysql").
+				AddComponentDef(testapps.StatelessNginxComponent, "proxy").
+				Apply(func(clusterDef *appsv1alpha1.ClusterDefinition) {
+					clusterDef.Spec.ComponentDefs[0].Monitor = monitor
+				}).
+				Create(&testCtx)
+			legacyName := "test-legacy-monitor-" + testCtx.GetRandomStr()
+			testapps.NewClusterFactory(testCtx.DefaultNamespace, legacyName, clusterDefName, "").
+				AddLabels(constant.ClusterDefLabelKey, clusterDefName).
+				AddComponent("mysql", "mysql").
+				AddComponent("proxy", "proxy").
+				Create(&testCtx)
+
+			r := &ComponentReconciler{Client: k8sClient}
+			tpl := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: tplName}}
+			requests := r.monitorTemplateToComponents(ctx, tpl)
+			keys := make([]types.NamespacedName, 0, len(requests))
+			for _, req := range requests {
+				keys = append(keys, req.NamespacedName)
+			}
+			Expect(keys).Should(ConsistOf(compKey, types.NamespacedName{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateClusterComponentName(legacyName, "mysql"),
+			}))
+
+			By("the ConfigMaps not referred as the templates")
+			other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: "test-other-" + tplName}}
+			Expect(r.monitorTemplateToComponents(ctx, other)).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,168 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// componentNetworkPolicyTransformer handles the NetworkPolicy of the component, which isolates the pods of the component
+// from the network if the cluster enables the network policy.
+type componentNetworkPolicyTransformer struct{}
+
+var _ graph.Transformer = &componentNetworkPolicyTransformer{}
+
+func (t *componentNetworkPolicyTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizedComp := transCtx.SynthesizeComponent
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	key := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      synthesizedComp.FullCompName,
+	}
+	obj := &networkingv1.NetworkPolicy{}
+	if err := transCtx.Client.Get(transCtx.Context, key, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		obj = nil
+	}
+
+	if transCtx.Cluster.Spec.NetworkPolicy == nil {
+		if obj != nil && model.IsOwnerOf(transCtx.ComponentOrig, obj) {
+			graphCli.Delete(dag, obj)
+		}
+		return nil
+	}
+
+	policy := buildNetworkPolicy(transCtx.Cluster.Spec.NetworkPolicy, synthesizedComp)
+	if obj == nil {
+		graphCli.Create(dag, policy)
+		return nil
+	}
+	objCopy := obj.DeepCopy()
+	objCopy.Labels = policy.Labels
+	objCopy.Spec = policy.Spec
+	if !reflect.DeepEqual(obj, objCopy) {
+		graphCli.Update(dag, obj, objCopy)
+	}
+	return nil
+}
+
+func buildNetworkPolicy(networkPolicy *appsv1alpha1.ClusterNetworkPolicy, synthesizedComp *component.SynthesizedComponent) *networkingv1.NetworkPolicy {
+	labels := constant.GetComponentWellKnownLabels(synthesizedComp.ClusterName, synthesizedComp.Name)
+	policyBuilder := builder.NewNetworkPolicyBuilder(synthesizedComp.Namespace, synthesizedComp.FullCompName).
+		AddLabelsInMap(labels).
+		SetPodSelector(metav1.LabelSelector{MatchLabels: labels}).
+		SetPolicyTypes(networkingv1.PolicyTypeIngress)
+
+	// the clients can only access the service ports of the component
+	if ports := networkPolicyServicePorts(synthesizedComp); len(networkPolicy.Clients) > 0 && len(ports) > 0 {
+		policyBuilder.AddIngressRules(networkingv1.NetworkPolicyIngressRule{
+			From:  networkPolicy.Clients,
+			Ports: ports,
+		})
+	}
+
+	// the replication traffic between the replicas and the components of the same cluster, and the lifecycle action jobs
+	policyBuilder.AddIngressRules(networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{
+			{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{constant.AppInstanceLabelKey: synthesizedComp.ClusterName},
+				},
+			},
+		},
+	})
+
+	// the backup jobs in the same namespace, and the probes and management operations from KubeBlocks
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			PodSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: dptypes.BackupNameLabelKey, Operator: metav1.LabelSelectorOpExists},
+				},
+			},
+		},
+	}
+	if ns := viper.GetString(constant.CfgKeyCtrlrMgrNS); len(ns) > 0 {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: ns},
+			},
+		})
+	}
+	policyBuilder.AddIngressRules(networkingv1.NetworkPolicyIngressRule{From: peers})
+	return policyBuilder.GetObject()
+}
+
+// networkPolicyServicePorts returns the target ports of the services of the component, which are the ports of the pods
+// the clients access.
+func networkPolicyServicePorts(synthesizedComp *component.SynthesizedComponent) []networkingv1.NetworkPolicyPort {
+	ports := make([]networkingv1.NetworkPolicyPort, 0)
+	exists := make(map[string]bool)
+	addPort := func(port corev1.ServicePort) {
+		target := port.TargetPort
+		if target.Type == intstr.Int && target.IntVal == 0 || target.Type == intstr.String && len(target.StrVal) == 0 {
+			target = intstr.FromInt(int(port.Port))
+		}
+		if exists[target.String()] {
+			return
+		}
+		exists[target.String()] = true
+		protocol := port.Protocol
+		if len(protocol) == 0 {
+			protocol = corev1.ProtocolTCP
+		}
+		ports = append(ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &target})
+	}
+	for _, svc := range synthesizedComp.ComponentServices {
+		for _, port := range svc.Spec.Ports {
+			addPort(port)
+		}
+	}
+	for _, svc := range synthesizedComp.Services {
+		for _, port := range svc.Spec.Ports {
+			addPort(port)
+		}
+	}
+	return ports
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("component network policy transformer test", func() {
+	var ctrlrMgrNS string
+
+	BeforeEach(func() {
+		ctrlrMgrNS = viper.GetString(constant.CfgKeyCtrlrMgrNS)
+		viper.Set(constant.CfgKeyCtrlrMgrNS, "kb-system")
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeyCtrlrMgrNS, ctrlrMgrNS)
+	})
+
+	It("builds the network policy of the component", func() {
+		synthesizedComp := &component.SynthesizedComponent{
+			Namespace:    testCtx.DefaultNamespace,
+			ClusterName:  "mycluster",
+			Name:         "mysql",
+			FullCompName: "mycluster-mysql",
+			ComponentServices: []appsv1alpha1.ComponentService{
+				{
+					Service: appsv1alpha1.Service{
+						Spec: corev1.ServiceSpec{
+							Ports: []corev1.ServicePort{
+								{Name: "mysql", Port: 3306, TargetPort: intstr.FromString("mysql")},
+								{Name: "admin", Port: 33062},
+							},
+						},
+					},
+				},
+				{
+					Service: appsv1alpha1.Service{
+						Spec: corev1.ServiceSpec{
+							Ports: []corev1.ServicePort{{Name: "mysql", Port: 3306, TargetPort: intstr.FromString("mysql")}},
+						},
+					},
+				},
+			},
+		}
+		clients := []networkingv1.NetworkPolicyPeer{
+			{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}},
+		}
+		policy := buildNetworkPolicy(&appsv1alpha1.ClusterNetworkPolicy{Clients: clients}, synthesizedComp)
+
+		Expect(policy.Name).Should(Equal("mycluster-mysql"))
+		Expect(policy.Spec.PodSelector.MatchLabels).Should(Equal(constant.GetComponentWellKnownLabels("mycluster", "mysql")))
+		Expect(policy.Spec.PolicyTypes).Should(Equal([]networkingv1.PolicyType{networkingv1.PolicyTypeIngress}))
+		Expect(policy.Spec.Ingress).Should(HaveLen(3))
+
+		By("the clients are allowed to access the ports of the services")
+		clientRule := policy.Spec.Ingress[0]
+		Expect(clientRule.From).Should(Equal(clients))
+		Expect(clientRule.Ports).Should(HaveLen(2))
+		Expect(clientRule.Ports[0].Port.String()).Should(Equal("mysql"))
+		Expect(clientRule.Ports[1].Port.String()).Should(Equal("33062"))
+		Expect(*clientRule.Ports[1].Protocol).Should(Equal(corev1.ProtocolTCP))
+
+		By("the pods of the cluster are allowed to access all the ports")
+		clusterRule := policy.Spec.Ingress[1]
+		Expect(clusterRule.From[0].PodSelector.MatchLabels).Should(Equal(map[string]string{constant.AppInstanceLabelKey: "mycluster"}))
+		Expect(clusterRule.Ports).Should(BeEmpty())
+
+		By("the backup pods and KubeBlocks are allowed")
+		kbRule := policy.Spec.Ingress[2]
+		Expect(kbRule.From).Should(HaveLen(2))
+		Expect(kbRule.From[0].PodSelector.MatchExpressions[0].Key).Should(Equal(dptypes.BackupNameLabelKey))
+		Expect(kbRule.From[1].NamespaceSelector.MatchLabels).Should(Equal(map[string]string{corev1.LabelMetadataName: "kb-system"}))
+
+		By("no clients allowed")
+		policy = buildNetworkPolicy(&appsv1alpha1.ClusterNetworkPolicy{}, synthesizedComp)
+		Expect(policy.Spec.Ingress).Should(HaveLen(2))
+	})
+})
@@ -0,0 +1,205 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/audit"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	defaultNodeFailureRecoveryTimeoutSeconds = 300
+
+	// podRecoveredFromNodeFailure the event reason indicates that the pod is force deleted from the NotReady node.
+	podRecoveredFromNodeFailure = "PodRecoveredFromNodeFailure"
+)
+
+// componentNodeFailureRecoveryTransformer force deletes the pods of the component which sit on the nodes being NotReady
+// longer than the timeout, so that the workload can reschedule them to the healthy nodes.
+// The deletions are planned in the DAG, and the component is enqueued by the node watch when a node becomes NotReady.
+type componentNodeFailureRecoveryTransformer struct {
+	client.Client
+}
+
+var _ graph.Transformer = &componentNodeFailureRecoveryTransformer{}
+
+func (t *componentNodeFailureRecoveryTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizedComp := transCtx.SynthesizeComponent
+	if synthesizedComp.NodeFailureRecovery == nil {
+		return nil
+	}
+	timeout := time.Duration(defaultNodeFailureRecoveryTimeoutSeconds) * time.Second
+	if synthesizedComp.NodeFailureRecovery.TimeoutSeconds > 0 {
+		timeout = time.Duration(synthesizedComp.NodeFailureRecovery.TimeoutSeconds) * time.Second
+	}
+
+	pods, err := component.ListPodOwnedByComponent(transCtx.Context, t.Client, synthesizedComp.Namespace,
+		constant.GetComponentWellKnownLabels(synthesizedComp.ClusterName, synthesizedComp.Name))
+	if err != nil {
+		return err
+	}
+	// the pods force deleted last time may not be observed by the cache yet, wait for them rather than deleting again
+	expectationKey := intctrlutil.ComponentExpectationKey(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	observed := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		observed = append(observed, *pod)
+	}
+	intctrlutil.PodExpectations.ObservePods(expectationKey, observed)
+	if !intctrlutil.PodExpectations.Satisfied(expectationKey) {
+		return nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	var requeueAfter time.Duration
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		node := &corev1.Node{}
+		if err = t.Client.Get(transCtx.Context, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			// the pods on a deleted node will be garbage collected by Kubernetes
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		notReadySince, notReady := nodeNotReadySince(node)
+		if !notReady {
+			continue
+		}
+		if elapsed := time.Since(notReadySince); elapsed < timeout {
+			if remaining := timeout - elapsed; requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			continue
+		}
+		entry := audit.Event{
+			Actor:   audit.ControllerActor(),
+			Action:  audit.ActionForcePodDelete,
+			Kind:    "Component",
+			Target:  pod.Name,
+			Result:  audit.ResultSucceeded,
+			Message: fmt.Sprintf("node %s has been NotReady for more than %s", node.Name, timeout),
+		}
+		if err = t.recoverPod(transCtx, dag, graphCli, expectationKey, pod); err != nil {
+			entry.Result = audit.ResultFailed
+			entry.Message = err.Error()
+			audit.Record(transCtx.EventRecorder, transCtx.Component, entry)
+			return err
+		}
+		audit.Record(transCtx.EventRecorder, transCtx.Component, entry)
+		transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeWarning, podRecoveredFromNodeFailure,
+			fmt.Sprintf("pod %s is force deleted since node %s has been NotReady for more than %s", pod.Name, node.Name, timeout))
+	}
+	if requeueAfter > 0 {
+		return intctrlutil.NewDelayedRequeueError(requeueAfter, "requeue to recover pods from NotReady nodes")
+	}
+	return nil
+}
+
+// recoverPod plans to detach the volumes of the pod from the node if required, and to force delete the pod after that.
+// The expected deletion expires if the plan fails to execute, so that the pod is recovered again.
+func (t *componentNodeFailureRecoveryTransformer) recoverPod(transCtx *componentTransformContext, dag *graph.DAG,
+	graphCli model.GraphClient, expectationKey string, pod *corev1.Pod) error {
+	var volumeAttachments []client.Object
+	if transCtx.SynthesizeComponent.NodeFailureRecovery.DetachVolumes {
+		var err error
+		if volumeAttachments, err = t.volumeAttachments(transCtx, pod); err != nil {
+			return err
+		}
+	}
+	intctrlutil.PodExpectations.ExpectDeletions(expectationKey, pod.UID)
+	graphCli.Delete(dag, pod, model.WithClientOption(client.GracePeriodSeconds(0)))
+	for _, va := range volumeAttachments {
+		graphCli.Delete(dag, va)
+	}
+	graphCli.DependOn(dag, pod, volumeAttachments...)
+	return nil
+}
+
+// volumeAttachments returns the VolumeAttachments of the pod's persistent volumes on the node.
+func (t *componentNodeFailureRecoveryTransformer) volumeAttachments(transCtx *componentTransformContext, pod *corev1.Pod) ([]client.Object, error) {
+	pvNames := map[string]bool{}
+	for _, vol := range pod.Spec.Volumes {
+		if vol.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		pvcKey := types.NamespacedName{Namespace: pod.Namespace, Name: vol.PersistentVolumeClaim.ClaimName}
+		if err := t.Client.Get(transCtx.Context, pvcKey, pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if pvc.Spec.VolumeName != "" {
+			pvNames[pvc.Spec.VolumeName] = true
+		}
+	}
+	if len(pvNames) == 0 {
+		return nil, nil
+	}
+
+	vaList := &storagev1.VolumeAttachmentList{}
+	if err := t.Client.List(transCtx.Context, vaList); err != nil {
+		return nil, err
+	}
+	var volumeAttachments []client.Object
+	for i, va := range vaList.Items {
+		if va.Spec.NodeName != pod.Spec.NodeName || va.Spec.Source.PersistentVolumeName == nil {
+			continue
+		}
+		if pvNames[*va.Spec.Source.PersistentVolumeName] {
+			volumeAttachments = append(volumeAttachments, &vaList.Items[i])
+		}
+	}
+	return volumeAttachments, nil
+}
+
+// nodeNotReadySince returns the time since when the node is NotReady.
+func nodeNotReadySince(node *corev1.Node) (time.Time, bool) {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			if cond.Status == corev1.ConditionTrue {
+				return time.Time{}, false
+			}
+			return cond.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,203 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("component node failure recovery transformer test", func() {
+	const (
+		compName = "mysql"
+		vctName  = "data"
+	)
+
+	var (
+		clusterName     string
+		nodeName        string
+		pvName          string
+		vaNames         []string
+		synthesizedComp *component.SynthesizedComponent
+		expectationKey  string
+		pod             *corev1.Pod
+	)
+
+	cleanAll := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		testapps.ClearResources(&testCtx, generics.PersistentVolumeClaimSignature, inNS, ml)
+		if nodeName != "" {
+			testapps.DeleteObject(&testCtx, types.NamespacedName{Name: nodeName}, &corev1.Node{})
+		}
+		for _, name := range vaNames {
+			testapps.DeleteObject(&testCtx, types.NamespacedName{Name: name}, &storagev1.VolumeAttachment{})
+		}
+		vaNames = nil
+		if expectationKey != "" {
+			intctrlutil.PodExpectations.Delete(expectationKey)
+		}
+	}
+
+	BeforeEach(func() {
+		cleanAll()
+
+		clusterName = "test-cluster-recovery-" + testCtx.GetRandomStr()
+		nodeName = "test-node-" + testCtx.GetRandomStr()
+		pvName = "test-pv-" + testCtx.GetRandomStr()
+		synthesizedComp = &component.SynthesizedComponent{
+			Namespace:   testCtx.DefaultNamespace,
+			ClusterName: clusterName,
+			Name:        compName,
+			NodeFailureRecovery: &appsv1alpha1.NodeFailureRecovery{
+				TimeoutSeconds: 60,
+				DetachVolumes:  true,
+			},
+		}
+		expectationKey = intctrlutil.ComponentExpectationKey(testCtx.DefaultNamespace, clusterName, compName)
+
+		By("create the pod with a volume on the node")
+		pvcName := vctName + "-" + constant.GenerateClusterComponentName(clusterName, compName) + "-0"
+		testapps.NewPersistentVolumeClaimFactory(testCtx.DefaultNamespace, pvcName, clusterName, compName, vctName).
+			SetStorage("1Gi").
+			SetVolumeName(pvName).
+			Create(&testCtx)
+		pod = testapps.NewPodFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, compName)+"-0").
+			AddLabelsInMap(constant.GetComponentWellKnownLabels(clusterName, compName)).
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+			AddVolume(corev1.Volume{
+				Name: vctName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+				},
+			}).
+			AddNodeName(nodeName).
+			Create(&testCtx).
+			GetObject()
+	})
+
+	AfterEach(cleanAll)
+
+	createNode := func(notReadyFor time.Duration) {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		Expect(testCtx.CreateObj(testCtx.Ctx, node)).Should(Succeed())
+		Expect(testapps.ChangeObjStatus(&testCtx, node, func() {
+			node.Status.Conditions = []corev1.NodeCondition{{
+				Type:               corev1.NodeReady,
+				Status:             corev1.ConditionUnknown,
+				LastHeartbeatTime:  metav1.NewTime(time.Now().Add(-notReadyFor)),
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-notReadyFor)),
+			}}
+		})).Should(Succeed())
+	}
+
+	createVolumeAttachment := func(node, pv string) string {
+		va := &storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-va-" + testCtx.GetRandomStr()},
+			Spec: storagev1.VolumeAttachmentSpec{
+				Attacher: "csi.example.com",
+				NodeName: node,
+				Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: pointer.String(pv)},
+			},
+		}
+		Expect(testCtx.CreateObj(testCtx.Ctx, va)).Should(Succeed())
+		vaNames = append(vaNames, va.Name)
+		return va.Name
+	}
+
+	transform := func() (*graph.DAG, error) {
+		transCtx, dag, _ := mockComponentTransformContext(synthesizedComp)
+		err := (&componentNodeFailureRecoveryTransformer{Client: k8sClient}).Transform(transCtx, dag)
+		return dag, err
+	}
+
+	deleteVertices := func(dag *graph.DAG) map[string]*model.ObjectVertex {
+		vertices := map[string]*model.ObjectVertex{}
+		for _, v := range dag.Vertices() {
+			if vertex, ok := v.(*model.ObjectVertex); ok && vertex.Action != nil && *vertex.Action == model.DELETE {
+				vertices[vertex.Obj.GetName()] = vertex
+			}
+		}
+		return vertices
+	}
+
+	It("waits for the node being NotReady longer than the timeout", func() {
+		createNode(30 * time.Second)
+		createVolumeAttachment(nodeName, pvName)
+
+		dag, err := transform()
+		Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+		Expect(deleteVertices(dag)).Should(BeEmpty())
+	})
+
+	It("plans to force delete the pod after detaching its volumes", func() {
+		createNode(2 * time.Minute)
+		vaName := createVolumeAttachment(nodeName, pvName)
+		createVolumeAttachment("test-node-"+testCtx.GetRandomStr(), pvName)
+		createVolumeAttachment(nodeName, "test-pv-"+testCtx.GetRandomStr())
+
+		dag, err := transform()
+		Expect(err).Should(Succeed())
+
+		vertices := deleteVertices(dag)
+		Expect(vertices).Should(HaveLen(2))
+		podVertex, vaVertex := vertices[pod.Name], vertices[vaName]
+		Expect(podVertex).ShouldNot(BeNil())
+		Expect(vaVertex).ShouldNot(BeNil())
+		Expect(podVertex.ClientOpt).Should(Equal(client.GracePeriodSeconds(0)))
+
+		By("the plan is executed in the reverse topological order, the volume is detached before the pod is deleted")
+		var order []string
+		Expect(dag.WalkReverseTopoOrder(func(v graph.Vertex) error {
+			if vertex := v.(*model.ObjectVertex); vertex == podVertex || vertex == vaVertex {
+				order = append(order, vertex.Obj.GetName())
+			}
+			return nil
+		}, nil)).Should(Succeed())
+		Expect(order).Should(Equal([]string{vaName, pod.Name}))
+
+		By("the pod is not deleted again before the deletion is observed")
+		_, deletions := intctrlutil.PodExpectations.Pending(expectationKey)
+		Expect(deletions).Should(Equal([]types.UID{pod.UID}))
+		dag, err = transform()
+		Expect(err).Should(Succeed())
+		Expect(deleteVertices(dag)).Should(BeEmpty())
+	})
+})
@@ -22,12 +22,15 @@ package apps
 import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -53,6 +56,19 @@ func (f *componentOwnershipTransformer) Transform(ctx graph.TransformContext, da
 		if _, ok := object.(*corev1.PersistentVolume); ok {
 			continue
 		}
+		// the cert-manager and Prometheus Operator CRDs are optional and can't be listed when the component is deleting,
+		// so that only the owner reference is set for certificates and alert rules and they are collected by GC.
+		// the Endpoints of the external components are the same, since the Endpoints of all the services carry the labels
+		// of the services and they shouldn't be listed and deleted with the cluster.
+		u, isUnstructured := object.(*unstructured.Unstructured)
+		_, isEndpoints := object.(*corev1.Endpoints)
+		if isEndpoints || isUnstructured &&
+			(u.GroupVersionKind() == plan.CertManagerCertificateGVK || u.GroupVersionKind() == component.PrometheusRuleGVK) {
+			if err := controllerutil.SetControllerReference(comp, object, rscheme); err != nil {
+				return err
+			}
+			continue
+		}
 		// if err := intctrlutil.SetOwnership(comp, object, rscheme, constant.DBComponentFinalizerName); err != nil {
 		if err := intctrlutil.SetOwnership(comp, object, rscheme, constant.DBClusterFinalizerName); err != nil {
 			if _, ok := err.(*controllerutil.AlreadyOwnedError); ok {
@@ -23,7 +23,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 )
@@ -50,8 +52,31 @@ func (c *componentRelatedParametersTransformer) Transform(ctx graph.TransformCon
 	if err != nil {
 		return err
 	}
+	if configuration.EnableTopologyTrigger(&configNew.Spec) {
+		topologyUpdated, err := c.updateTopologyPayload(ctx, configNew, synthesizedComp)
+		if err != nil {
+			return err
+		}
+		updated = updated || topologyUpdated
+	}
 	if !updated {
 		return nil
 	}
 	return c.Patch(ctx.GetContext(), configNew, client.MergeFrom(config.DeepCopy()))
 }
+
+// updateTopologyPayload updates the topology payload of the configuration from the members status of the workload,
+// so that the configurations are re-rendered when the topology changes, e.g. a switchover.
+func (c *componentRelatedParametersTransformer) updateTopologyPayload(ctx graph.TransformContext,
+	config *appsv1alpha1.Configuration, synthesizedComp *component.SynthesizedComponent) (bool, error) {
+	rsm := &workloads.ReplicatedStateMachine{}
+	rsmKey := client.ObjectKey{
+		Namespace: synthesizedComp.Namespace,
+		Name:      component.WorkloadName(synthesizedComp, synthesizedComp.Name),
+	}
+	if err := c.Get(ctx.GetContext(), rsmKey, rsm); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	topology := configuration.BuildComponentTopology(synthesizedComp, rsm.Status.MembersStatus)
+	return configuration.UpdateConfigTopologyPayload(&config.Spec, topology)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+const (
+	// preTerminateFailed the event reason indicates that the preTerminate action of the component is failed.
+	preTerminateFailed = "PreTerminateFailed"
+
+	preTerminateFailedRequeueDuration = 30 * time.Second
+)
+
+// componentPreTerminateTransformer handles component preTerminate lifecycle action,
+// the deletion of the component is blocked until the action is executed successfully, unless its failure policy is Ignore.
+type componentPreTerminateTransformer struct {
+	client.Client
+}
+
+var _ graph.Transformer = &componentPreTerminateTransformer{}
+
+func (t *componentPreTerminateTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if transCtx.Component.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	// the synthesized component is required to render the action, if it can't be built (e.g., the cluster or definition has gone),
+	// there is no way to execute the action and the deletion should not be blocked.
+	if err := (&componentLoadResourcesTransformer{Client: t.Client}).Transform(ctx, dag); err != nil {
+		transCtx.Logger.Info(fmt.Sprintf("skip the preTerminate action since the component can not be loaded: %s", err.Error()))
+		return nil
+	}
+
+	comp := transCtx.Component
+	done, err := component.ReconcileCompPreTerminate(transCtx.Context, t.Client, transCtx.Cluster, comp, transCtx.SynthesizeComponent)
+	if err != nil {
+		transCtx.EventRecorder.Eventf(comp, corev1.EventTypeWarning, preTerminateFailed,
+			fmt.Sprintf("the preTerminate action of component %s failed: %s", comp.Name, err.Error()))
+	}
+	if done {
+		// the failure of the action is ignored by its failure policy
+		return nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	graphCli.Status(dag, transCtx.ComponentOrig, comp)
+	if err != nil {
+		return newRequeueError(preTerminateFailedRequeueDuration, "requeue to wait for the preTerminate action to be fixed")
+	}
+	return newRequeueError(requeueDuration, "requeue to wait for the preTerminate action finished")
+}
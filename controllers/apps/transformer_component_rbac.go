@@ -21,6 +21,7 @@ package apps
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,9 +31,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -59,6 +62,10 @@ func (t *componentRBACTransformer) Transform(ctx graph.TransformContext, dag *gr
 
 	graphCli, _ := transCtx.Client.(model.GraphClient)
 
+	if component.IsLeastPrivilegeRBACEnabled() {
+		return transformLeastPrivilegeRBAC(transCtx, graphCli, dag)
+	}
+
 	serviceAccount, needCRB, err := buildServiceAccount(transCtx)
 	if err != nil {
 		return err
@@ -100,6 +107,193 @@ func (t *componentRBACTransformer) Transform(ctx graph.TransformContext, dag *gr
 	return nil
 }
 
+var (
+	// lorryPolicyRules are the permissions required by lorry, e.g., to report the role of the pod and
+	// to update the status of the ops requests.
+	lorryPolicyRules = []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{corev1.GroupName},
+			Resources: []string{"events"},
+			Verbs:     []string{"create"},
+		},
+		{
+			APIGroups: []string{corev1.GroupName},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list", "patch"},
+		},
+		{
+			APIGroups: []string{corev1.GroupName},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"create", "get", "list", "patch", "update", "delete"},
+		},
+		{
+			APIGroups: []string{appsv1alpha1.GroupVersion.Group},
+			Resources: []string{"clusters"},
+			Verbs:     []string{"get", "list"},
+		},
+		{
+			APIGroups: []string{appsv1alpha1.GroupVersion.Group},
+			Resources: []string{"clusters/status"},
+			Verbs:     []string{"get"},
+		},
+		{
+			APIGroups: []string{appsv1alpha1.GroupVersion.Group},
+			Resources: []string{"opsrequests/status"},
+			Verbs:     []string{"get", "patch"},
+		},
+	}
+
+	// backupPolicyRules are the permissions required by the backup tools running in the pods.
+	backupPolicyRules = []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{dpv1alpha1.GroupVersion.Group},
+			Resources: []string{"backups"},
+			Verbs:     []string{"create"},
+		},
+		{
+			APIGroups: []string{dpv1alpha1.GroupVersion.Group},
+			Resources: []string{"backups/status"},
+			Verbs:     []string{"get", "update", "patch"},
+		},
+	}
+)
+
+// transformLeastPrivilegeRBAC generates a dedicated ServiceAccount, Role and RoleBinding for each component that grant
+// exactly the permissions required by the agents of its pods, and a ClusterRoleBinding if volume protection is enabled.
+// The legacy bindings of the aggregated cluster pod role are deleted, since the pods no longer use the shared ServiceAccount.
+func transformLeastPrivilegeRBAC(transCtx *componentTransformContext, graphCli model.GraphClient, dag *graph.DAG) error {
+	var (
+		cluster         = transCtx.Cluster
+		synthesizedComp = transCtx.SynthesizeComponent
+		saName          = synthesizedComp.ServiceAccountName
+	)
+	if saName == "" {
+		transCtx.Logger.V(1).Info("no service account is required by the component")
+		return nil
+	}
+
+	rules, err := buildComponentPolicyRules(transCtx)
+	if err != nil {
+		return err
+	}
+
+	var serviceAccount *corev1.ServiceAccount
+	if !isServiceAccountExist(transCtx, saName) {
+		serviceAccount = factory.BuildServiceAccount(cluster, saName)
+		graphCli.Create(dag, serviceAccount)
+	}
+
+	role := factory.BuildComponentRole(synthesizedComp, rules)
+	roleObj := &rbacv1.Role{}
+	if err = transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(role), roleObj); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		graphCli.Create(dag, role)
+	} else if !reflect.DeepEqual(roleObj.Rules, role.Rules) {
+		roleObjCopy := roleObj.DeepCopy()
+		roleObjCopy.Rules = role.Rules
+		graphCli.Update(dag, roleObj, roleObjCopy)
+	}
+
+	roleBinding := factory.BuildComponentRoleBinding(synthesizedComp, role.Name, saName)
+	if err = createBindingIfNotExist(transCtx, graphCli, dag, roleBinding, &rbacv1.RoleBinding{}, serviceAccount); err != nil {
+		return err
+	}
+
+	// volume protection requires the permissions of the cluster role to query the volume stats of the nodes.
+	if isVolumeProtectionEnabled(transCtx.CompDef) {
+		crb := factory.BuildClusterRoleBinding(cluster, saName)
+		if err = createBindingIfNotExist(transCtx, graphCli, dag, crb, &rbacv1.ClusterRoleBinding{}, serviceAccount); err != nil {
+			return err
+		}
+	}
+
+	if err = deleteLegacyBindings(transCtx, graphCli, dag); err != nil {
+		return err
+	}
+
+	if serviceAccount != nil {
+		rsmList := graphCli.FindAll(dag, &workloads.ReplicatedStateMachine{})
+		for _, rsm := range rsmList {
+			// serviceAccount must be created before workload
+			graphCli.DependOn(dag, rsm, serviceAccount)
+		}
+	}
+	return nil
+}
+
+func createBindingIfNotExist(transCtx *componentTransformContext, graphCli model.GraphClient, dag *graph.DAG,
+	binding, existing client.Object, serviceAccount *corev1.ServiceAccount) error {
+	if err := transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(binding), existing); err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+	graphCli.Create(dag, binding)
+	if serviceAccount != nil {
+		// serviceAccount must be created before the bindings
+		graphCli.DependOn(dag, binding, serviceAccount)
+	}
+	return nil
+}
+
+// deleteLegacyBindings deletes the RoleBinding and ClusterRoleBinding which were created for the cluster
+// before the least-privilege RBAC is enabled, they bind the ServiceAccount shared by all the components to
+// the aggregated cluster pod role.
+func deleteLegacyBindings(transCtx *componentTransformContext, graphCli model.GraphClient, dag *graph.DAG) error {
+	var (
+		cluster  = transCtx.Cluster
+		saName   = transCtx.SynthesizeComponent.ServiceAccountName
+		isLegacy = func(obj client.Object, roleRef rbacv1.RoleRef, roleName string) bool {
+			return obj.GetLabels()[constant.AppInstanceLabelKey] == cluster.Name && roleRef.Name == roleName
+		}
+	)
+	legacyNames := []string{constant.GenerateDefaultServiceAccountName(cluster.Name)}
+	if transCtx.Component.Spec.ServiceAccountName != "" {
+		// the legacy RoleBinding was named after the ServiceAccount specified by the user.
+		legacyNames = append(legacyNames, saName)
+	}
+	for _, name := range legacyNames {
+		key := types.NamespacedName{Namespace: cluster.Namespace, Name: name}
+		rb := &rbacv1.RoleBinding{}
+		if err := transCtx.Client.Get(transCtx.Context, key, rb); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		} else if isLegacy(rb, rb.RoleRef, constant.RBACRoleName) {
+			graphCli.Delete(dag, rb)
+		}
+	}
+
+	crb := &rbacv1.ClusterRoleBinding{}
+	key := types.NamespacedName{Name: constant.GenerateDefaultServiceAccountName(cluster.Name)}
+	if err := transCtx.Client.Get(transCtx.Context, key, crb); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if isLegacy(crb, crb.RoleRef, constant.RBACClusterRoleName) && crb.Name != saName {
+		graphCli.Delete(dag, crb)
+	}
+	return nil
+}
+
+// buildComponentPolicyRules builds the policy rules required by the component, which consist of the rules
+// required by lorry, the backup tools if data protection is enabled, and the rules declared in the component definition.
+func buildComponentPolicyRules(transCtx *componentTransformContext) ([]rbacv1.PolicyRule, error) {
+	var (
+		cluster = transCtx.Cluster
+		comp    = transCtx.Component
+	)
+	backupPolicyTPL, err := getDefaultBackupPolicyTemplate(transCtx, cluster.Spec.ClusterDefRef)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]rbacv1.PolicyRule, 0)
+	rules = append(rules, lorryPolicyRules...)
+	if isDataProtectionEnabled(backupPolicyTPL, cluster, comp) {
+		rules = append(rules, backupPolicyRules...)
+	}
+	return append(rules, transCtx.SynthesizeComponent.PolicyRules...), nil
+}
+
 func isProbesEnabled(compDef *appsv1alpha1.ComponentDefinition) bool {
 	// TODO(component): lorry
 	return compDef.Spec.LifecycleActions != nil && compDef.Spec.LifecycleActions.RoleProbe != nil
@@ -22,8 +22,9 @@ package apps
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -176,6 +177,117 @@ var _ = Describe("object rbac transformer test.", func() {
 			}
 			Expect(dag.Equals(dagExpected, model.DefaultLess)).Should(BeTrue())
 		})
+
+		It("create serviceaccount, role and rolebinding if least privilege rbac enabled", func() {
+			viper.Set(constant.EnableLeastPrivilegeRBAC, true)
+			defer viper.Set(constant.EnableLeastPrivilegeRBAC, false)
+			disableVolumeProtection()
+
+			Eventually(testapps.CheckObjExists(&testCtx, saKey,
+				&corev1.ServiceAccount{}, false)).Should(Succeed())
+			Expect(transformer.Transform(transCtx, dag)).Should(BeNil())
+
+			synthesizedComp := transCtx.(*componentTransformContext).SynthesizeComponent
+			rules, err := buildComponentPolicyRules(transCtx.(*componentTransformContext))
+			Expect(err).Should(Succeed())
+			Expect(rules).Should(ContainElements(lorryPolicyRules))
+
+			serviceAccount := factory.BuildServiceAccount(cluster, serviceAccountName)
+			role := factory.BuildComponentRole(synthesizedComp, rules)
+			roleBinding := factory.BuildComponentRoleBinding(synthesizedComp, role.Name, serviceAccount.Name)
+			Expect(roleBinding.RoleRef.Kind).Should(Equal("Role"))
+
+			dagExpected := mockDAG(graphCli, cluster)
+			graphCli.Create(dagExpected, serviceAccount)
+			graphCli.Create(dagExpected, role)
+			graphCli.Create(dagExpected, roleBinding)
+			graphCli.DependOn(dagExpected, roleBinding, serviceAccount)
+			rsmList := graphCli.FindAll(dagExpected, &workloads.ReplicatedStateMachine{})
+			for i := range rsmList {
+				graphCli.DependOn(dagExpected, rsmList[i], serviceAccount)
+			}
+			Expect(dag.Equals(dagExpected, model.DefaultLess)).Should(BeTrue())
+		})
+	})
+
+	Context("least privilege rbac with the dedicated service account", func() {
+		var (
+			legacyName      string
+			synthesizedComp *component.SynthesizedComponent
+		)
+
+		BeforeEach(func() {
+			viper.Set(constant.EnableRBACManager, true)
+			viper.Set(constant.EnableLeastPrivilegeRBAC, true)
+
+			name := "test-cluster-rbac-" + testCtx.GetRandomStr()
+			legacyName = constant.GenerateDefaultServiceAccountName(name)
+			synthesizedComp = &component.SynthesizedComponent{
+				Namespace:          testCtx.DefaultNamespace,
+				ClusterName:        name,
+				Name:               compName,
+				ServiceAccountName: constant.GenerateComponentServiceAccountName(name, compName),
+			}
+		})
+
+		AfterEach(func() {
+			viper.Set(constant.EnableRBACManager, false)
+			viper.Set(constant.EnableLeastPrivilegeRBAC, false)
+			testapps.DeleteObject(&testCtx, types.NamespacedName{Namespace: testCtx.DefaultNamespace, Name: legacyName}, &rbacv1.RoleBinding{})
+			testapps.DeleteObject(&testCtx, types.NamespacedName{Name: legacyName}, &rbacv1.ClusterRoleBinding{})
+		})
+
+		It("deletes the legacy bindings and keeps the volume protection binding", func() {
+			By("create the legacy bindings of the shared service account")
+			legacyLabels := map[string]string{constant.AppInstanceLabelKey: synthesizedComp.ClusterName}
+			legacyRB := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: legacyName, Labels: legacyLabels},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: constant.RBACRoleName},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, legacyRB)).Should(Succeed())
+			legacyCRB := &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: legacyName, Labels: legacyLabels},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: constant.RBACClusterRoleName},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, legacyCRB)).Should(Succeed())
+
+			transCtx, dag, graphCli := mockComponentTransformContext(synthesizedComp)
+			transCtx.Cluster = &appsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: synthesizedComp.ClusterName},
+			}
+			transCtx.CompDef = &appsv1alpha1.ComponentDefinition{}
+			transCtx.CompDef.Spec.Volumes = []appsv1alpha1.ComponentVolume{{Name: "data", HighWatermark: 85}}
+			Expect((&componentRBACTransformer{}).Transform(transCtx, dag)).Should(Succeed())
+
+			serviceAccounts := graphCli.FindAll(dag, &corev1.ServiceAccount{})
+			Expect(serviceAccounts).Should(HaveLen(1))
+			Expect(serviceAccounts[0].GetName()).Should(Equal(synthesizedComp.ServiceAccountName))
+			Expect(graphCli.FindAll(dag, &rbacv1.Role{})).Should(HaveLen(1))
+
+			for _, obj := range graphCli.FindAll(dag, &rbacv1.RoleBinding{}) {
+				rb := obj.(*rbacv1.RoleBinding)
+				if rb.Name == legacyName {
+					Expect(graphCli.IsAction(dag, rb, model.ActionDeletePtr())).Should(BeTrue(), "the legacy role binding should be deleted")
+					continue
+				}
+				Expect(graphCli.IsAction(dag, rb, model.ActionCreatePtr())).Should(BeTrue())
+				Expect(rb.RoleRef.Kind).Should(Equal("Role"))
+				Expect(rb.Subjects[0].Name).Should(Equal(synthesizedComp.ServiceAccountName))
+			}
+			crbs := graphCli.FindAll(dag, &rbacv1.ClusterRoleBinding{})
+			Expect(crbs).Should(HaveLen(2))
+			for _, obj := range crbs {
+				crb := obj.(*rbacv1.ClusterRoleBinding)
+				if crb.Name == legacyName {
+					Expect(graphCli.IsAction(dag, crb, model.ActionDeletePtr())).Should(BeTrue(), "the legacy cluster role binding should be deleted")
+					continue
+				}
+				// the cluster role binding required by volume protection is kept for the dedicated service account.
+				Expect(graphCli.IsAction(dag, crb, model.ActionCreatePtr())).Should(BeTrue())
+				Expect(crb.RoleRef.Name).Should(Equal(constant.RBACClusterRoleName))
+				Expect(crb.Subjects[0].Name).Should(Equal(synthesizedComp.ServiceAccountName))
+			}
+		})
 	})
 })
 
@@ -186,3 +298,23 @@ func mockDAG(graphCli model.GraphClient, cluster *appsv1alpha1.Cluster) *graph.D
 	graphCli.Create(d, rsm)
 	return d
 }
+
+// mockComponentTransformContext builds the transform context of the synthesized component, and the DAG rooted at
+// the component, which is not created in the API server.
+func mockComponentTransformContext(synthesizedComp *component.SynthesizedComponent) (*componentTransformContext, *graph.DAG, model.GraphClient) {
+	graphCli := model.NewGraphClient(k8sClient)
+	compName := constant.GenerateClusterComponentName(synthesizedComp.ClusterName, synthesizedComp.Name)
+	comp := testapps.NewComponentFactory(synthesizedComp.Namespace, compName, synthesizedComp.CompDefName).GetObject()
+	transCtx := &componentTransformContext{
+		Context:             ctx,
+		Client:              graphCli,
+		EventRecorder:       clusterRecorder,
+		Logger:              logger,
+		Component:           comp,
+		ComponentOrig:       comp.DeepCopy(),
+		SynthesizeComponent: synthesizedComp,
+	}
+	dag := graph.NewDAG()
+	graphCli.Root(dag, transCtx.ComponentOrig, transCtx.Component, model.ActionStatusPtr())
+	return transCtx, dag, graphCli
+}
@@ -25,6 +25,8 @@ import (
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -86,9 +88,78 @@ func (t *componentServiceTransformer) Transform(ctx graph.TransformContext, dag
 			}
 		}
 	}
+	return t.reconcilePodEndpointServices(transCtx, dag, graphCli)
+}
+
+// reconcilePodEndpointServices creates a ClusterIP service named after each pod if the pod endpoints are published
+// by services, and deletes the services of the pods that no longer exist.
+func (t *componentServiceTransformer) reconcilePodEndpointServices(transCtx *componentTransformContext,
+	dag *graph.DAG, graphCli model.GraphClient) error {
+	synthesizeComp := transCtx.SynthesizeComponent
+	labels := constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name)
+
+	podNames := sets.New[string]()
+	if synthesizeComp.PodEndpoints != nil && synthesizeComp.PodEndpoints.Type == appsv1alpha1.ServicePodEndpoints {
+		rsmObj, err := component.GetComponentRSM(transCtx.Context, transCtx.Client, synthesizeComp)
+		if err != nil {
+			return err
+		}
+		ports := t.podEndpointServicePorts(synthesizeComp)
+		for _, podName := range component.PodNamesOfWorkload(synthesizeComp, rsmObj) {
+			podNames.Insert(podName)
+			svc := builder.NewServiceBuilder(synthesizeComp.Namespace, podName).
+				AddLabelsInMap(labels).
+				AddSelectorsInMap(t.builtinSelector(transCtx.Component)).
+				AddSelector(constant.StatefulSetPodNameLabelKey, podName).
+				AddPorts(ports...).
+				SetPublishNotReadyAddresses(true).
+				GetObject()
+			if err := createOrUpdateService(transCtx, dag, graphCli, svc, transCtx.ComponentOrig); err != nil {
+				return err
+			}
+		}
+	}
+
+	svcList := &corev1.ServiceList{}
+	if err := transCtx.Client.List(transCtx.Context, svcList, client.InNamespace(synthesizeComp.Namespace), client.MatchingLabels(labels)); err != nil {
+		return err
+	}
+	for i, svc := range svcList.Items {
+		// the per-pod service is the one that is named after the pod it selects
+		if svc.Spec.Selector[constant.StatefulSetPodNameLabelKey] != svc.Name || podNames.Has(svc.Name) {
+			continue
+		}
+		if !model.IsOwnerOf(transCtx.ComponentOrig, &svcList.Items[i]) {
+			continue
+		}
+		graphCli.Delete(dag, &svcList.Items[i])
+	}
 	return nil
 }
 
+func (t *componentServiceTransformer) podEndpointServicePorts(synthesizeComp *component.SynthesizedComponent) []corev1.ServicePort {
+	ports := make([]corev1.ServicePort, 0)
+	if synthesizeComp.PodSpec == nil {
+		return ports
+	}
+	exists := make(map[int32]bool)
+	for _, container := range synthesizeComp.PodSpec.Containers {
+		for _, port := range container.Ports {
+			if exists[port.ContainerPort] {
+				continue
+			}
+			exists[port.ContainerPort] = true
+			ports = append(ports, corev1.ServicePort{
+				Name:       port.Name,
+				Protocol:   port.Protocol,
+				Port:       port.ContainerPort,
+				TargetPort: intstr.FromInt(int(port.ContainerPort)),
+			})
+		}
+	}
+	return ports
+}
+
 func (t *componentServiceTransformer) genMultiServicesIfNeed(cluster *appsv1alpha1.Cluster,
 	synthesizeComp *component.SynthesizedComponent, compService *appsv1alpha1.ComponentService) ([]*appsv1alpha1.ComponentService, error) {
 	if !compService.GeneratePodOrdinalService {
@@ -108,7 +179,7 @@ func (t *componentServiceTransformer) genMultiServicesIfNeed(cluster *appsv1alph
 			svc.Spec.Selector = make(map[string]string)
 		}
 		// TODO(xingran): use StatefulSet's podName as default selector to select unique pod
-		svc.Spec.Selector[constant.StatefulSetPodNameLabelKey] = constant.GeneratePodName(cluster.Name, synthesizeComp.Name, int(i))
+		svc.Spec.Selector[constant.StatefulSetPodNameLabelKey] = component.PodName(synthesizeComp, synthesizeComp.Name, int(i))
 		podOrdinalServices = append(podOrdinalServices, svc)
 	}
 
@@ -204,7 +275,7 @@ func (t *componentServiceTransformer) buildService(comp *appsv1alpha1.Component,
 		compName    = synthesizeComp.Name
 	)
 
-	serviceFullName := constant.GenerateComponentServiceName(synthesizeComp.ClusterName, synthesizeComp.Name, service.ServiceName)
+	serviceFullName := component.ServiceName(synthesizeComp, synthesizeComp.Name, service.ServiceName)
 	labels := constant.GetComponentWellKnownLabels(clusterName, compName)
 	builder := builder.NewServiceBuilder(namespace, serviceFullName).
 		AddLabelsInMap(labels).
@@ -248,7 +319,7 @@ func (t *componentServiceTransformer) checkRoleSelector(synthesizeComp *componen
 }
 
 func (t *componentServiceTransformer) skipDefaultHeadlessSvc(synthesizeComp *component.SynthesizedComponent, service *appsv1alpha1.ComponentService) bool {
-	svcName := constant.GenerateComponentServiceName(synthesizeComp.ClusterName, synthesizeComp.Name, service.ServiceName)
-	defaultHeadlessSvcName := constant.GenerateDefaultComponentHeadlessServiceName(synthesizeComp.ClusterName, synthesizeComp.Name)
+	svcName := component.ServiceName(synthesizeComp, synthesizeComp.Name, service.ServiceName)
+	defaultHeadlessSvcName := component.HeadlessServiceName(synthesizeComp, synthesizeComp.Name)
 	return svcName == defaultHeadlessSvcName
 }
@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/kubectl/pkg/util/podutils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -119,6 +120,10 @@ func (r *componentStatusHandler) reconcileComponentStatus() error {
 		return nil
 	}
 
+	// expose the replicas and selector for the scale subresource
+	r.comp.Status.Replicas = r.runningRSM.Status.Replicas
+	r.comp.Status.Selector = labels.SelectorFromSet(constant.GetComponentWellKnownLabels(r.cluster.Name, r.synthesizeComp.Name)).String()
+
 	// check if the rsm is deleting
 	isDeleting := func() bool {
 		return !r.runningRSM.DeletionTimestamp.IsZero()
@@ -312,6 +317,10 @@ func (r *componentStatusHandler) isAllConfigSynced() (bool, error) {
 		return false, err
 	}
 	for _, configSpec := range r.synthesizeComp.ConfigTemplates {
+		// the template rendered as a Secret is not reconfigured.
+		if configSpec.AsSecret {
+			continue
+		}
 		item := configuration.Spec.GetConfigurationItem(configSpec.Name)
 		status := configuration.Status.GetItemStatus(configSpec.Name)
 		// for creating phase
@@ -22,9 +22,15 @@ package apps
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -38,6 +44,11 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
+const (
+	tlsCertRotated  = "TLSCertRotated"
+	tlsCertExpiring = "TLSCertExpiring"
+)
+
 // componentTLSTransformer handles component configuration render
 type componentTLSTransformer struct {
 	client.Client
@@ -59,11 +70,17 @@ func (t *componentTLSTransformer) Transform(ctx graph.TransformContext, dag *gra
 		return err
 	}
 
+	// rotate or track the expiry of the tls cert
+	requeueErr := reconcileTLSCertExpiry(transCtx, dag)
+	if requeueErr != nil && !intctrlutil.IsDelayedRequeueError(requeueErr) {
+		return requeueErr
+	}
+
 	if err := checkAndTriggerReRender(transCtx.Context, *synthesizedComp, t.Client); err != nil {
 		return err
 	}
 
-	return nil
+	return requeueErr
 }
 
 // a hack way to notify the configuration controller to re-render config
@@ -146,14 +163,186 @@ func buildTLSCert(ctx context.Context, cli client.Reader, synthesizedComp compon
 			return err
 		}
 	case appsv1alpha1.IssuerKubeBlocks:
-		secret, err := plan.ComposeTLSSecret(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
-		if err != nil {
+		// the secret is created and rotated when reconciling the expiry of the tls cert
+	case appsv1alpha1.IssuerCertManager:
+		if tls.Issuer.IssuerRef == nil {
+			return fmt.Errorf("issuer.issuerRef shouldn't be nil when issuer is CertManager")
+		}
+		dnsNames := certManagerDNSNames(synthesizedComp)
+		serverCert := plan.ComposeCertManagerCertificate(synthesizedComp.Namespace, synthesizedComp.ClusterName,
+			synthesizedComp.FullCompName, plan.GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+			tls.Issuer.IssuerRef, dnsNames, []string{"digital signature", "key encipherment", "server auth"})
+		if err := createOrUpdateCertificate(ctx, cli, dag, serverCert); err != nil {
 			return err
 		}
-		graphCli, _ := cli.(model.GraphClient)
+		if tls.Issuer.ClientCert {
+			clientCert := plan.ComposeCertManagerCertificate(synthesizedComp.Namespace, synthesizedComp.ClusterName,
+				synthesizedComp.FullCompName+"-client", plan.GenerateTLSClientSecretName(synthesizedComp.ClusterName, synthesizedComp.Name),
+				tls.Issuer.IssuerRef, dnsNames[:1], []string{"digital signature", "key encipherment", "client auth"})
+			if err := createOrUpdateCertificate(ctx, cli, dag, clientCert); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// tlsCertExpiringWarned records the expiry of the certificates which the expiring warning has been emitted for,
+// keyed by the component UID and the secret name, so the warning is emitted only once for each certificate.
+var tlsCertExpiringWarned sync.Map
+
+// reconcileTLSCertExpiry rotates the certificates signed by KubeBlocks before they expire, and tracks the expiry
+// of the certificates provided by others. The hash of the certificates in use is recorded to the synthesized
+// component, so the pods will be restarted to load the new certificates once they are rotated. The component
+// is requeued to check the certificates again when they enter the rotation window.
+func reconcileTLSCertExpiry(transCtx *componentTransformContext, dag *graph.DAG) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	tls := synthesizedComp.TLSConfig
+	if tls == nil || !tls.Enable || tls.Issuer == nil {
+		return nil
+	}
+
+	secretName := plan.GenerateTLSSecretName(synthesizedComp.ClusterName, synthesizedComp.Name)
+	certKey := constant.CertName
+	if tls.Issuer.Name == appsv1alpha1.IssuerUserProvided {
+		secretName = tls.Issuer.SecretRef.Name
+		certKey = tls.Issuer.SecretRef.Cert
+	}
+	secret := &corev1.Secret{}
+	if err := transCtx.Client.Get(transCtx.Context, types.NamespacedName{Namespace: synthesizedComp.Namespace, Name: secretName}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		secret = nil
+	}
+
+	var notAfter time.Time
+	if tls.Issuer.Name == appsv1alpha1.IssuerKubeBlocks {
+		var err error
+		if notAfter, err = rotateTLSCert(transCtx, dag, secret); err != nil {
+			return err
+		}
+	} else {
+		// the certificates haven't been issued by cert-manager yet.
+		if secret == nil {
+			return nil
+		}
+		cert := tlsSecretValue(secret, certKey)
+		synthesizedComp.TLSCertHash = plan.ComputeTLSCertHash(cert)
+		notAfter, _ = plan.GetTLSCertNotAfter(cert)
+	}
+
+	if err := checkTLSCertRotated(transCtx); err != nil {
+		return err
+	}
+	if notAfter.IsZero() {
+		return nil
+	}
+	warnedKey := fmt.Sprintf("%s/%s", transCtx.Component.UID, secretName)
+	if !plan.NeedRotateTLSCert(notAfter) {
+		tlsCertExpiringWarned.Delete(warnedKey)
+		return intctrlutil.NewDelayedRequeueError(time.Until(notAfter)-plan.TLSCertRotationWindow,
+			"requeue to check the expiry of the TLS certificates")
+	}
+	if warned, ok := tlsCertExpiringWarned.Load(warnedKey); !ok || !warned.(time.Time).Equal(notAfter) {
+		transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeWarning, tlsCertExpiring,
+			"the TLS certificates in secret %s will expire at %s, please renew them", secretName, notAfter.Format(time.RFC3339))
+		tlsCertExpiringWarned.Store(warnedKey, notAfter)
+	}
+	return nil
+}
+
+// checkTLSCertRotated checks whether the certificates are rotated since the workload was updated last time,
+// by comparing with the hash of the certificates recorded in the workload.
+func checkTLSCertRotated(transCtx *componentTransformContext) error {
+	synthesizedComp := transCtx.SynthesizeComponent
+	rsmObj, err := component.GetComponentRSM(transCtx.Context, transCtx.Client, synthesizedComp)
+	if err != nil || rsmObj == nil {
+		return err
+	}
+	// the workloads created before the hash is recorded are not restarted.
+	lastCertHash := rsmObj.Annotations[constant.TLSCertHashAnnotationKey]
+	synthesizedComp.TLSCertRotated = len(lastCertHash) > 0 && lastCertHash != synthesizedComp.TLSCertHash
+	return nil
+}
+
+// rotateTLSCert creates the certificates signed by KubeBlocks, and re-signs them if they are about to expire.
+// The expiry of the certificates in use is returned.
+func rotateTLSCert(transCtx *componentTransformContext, dag *graph.DAG, obj *corev1.Secret) (time.Time, error) {
+	synthesizedComp := transCtx.SynthesizeComponent
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+
+	if obj != nil {
+		cert := tlsSecretValue(obj, constant.CertName)
+		notAfter, err := plan.GetTLSCertNotAfter(cert)
+		if err == nil && !plan.NeedRotateTLSCert(notAfter) {
+			synthesizedComp.TLSCertHash = plan.ComputeTLSCertHash(cert)
+			return notAfter, nil
+		}
+	}
+
+	secret, err := plan.ComposeTLSSecret(synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cert := []byte(secret.StringData[constant.CertName])
+	synthesizedComp.TLSCertHash = plan.ComputeTLSCertHash(cert)
+	notAfter, _ := plan.GetTLSCertNotAfter(cert)
+	if obj == nil {
 		graphCli.Create(dag, secret)
+		return notAfter, nil
 	}
 
+	objCopy := obj.DeepCopy()
+	objCopy.Data = nil
+	objCopy.StringData = secret.StringData
+	graphCli.Update(dag, obj, objCopy)
+	transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeNormal, tlsCertRotated,
+		"the TLS certificates in secret %s are rotated, which will expire at %s", obj.Name, notAfter.Format(time.RFC3339))
+	return notAfter, nil
+}
+
+func tlsSecretValue(secret *corev1.Secret, key string) []byte {
+	if v, ok := secret.Data[key]; ok {
+		return v
+	}
+	return []byte(secret.StringData[key])
+}
+
+// certManagerDNSNames returns the DNS names of the component services that the certificates are issued for.
+func certManagerDNSNames(synthesizedComp component.SynthesizedComponent) []string {
+	svcName := component.ServiceName(&synthesizedComp, synthesizedComp.Name, "")
+	headlessSvcName := component.HeadlessServiceName(&synthesizedComp, synthesizedComp.Name)
+	dnsNames := make([]string, 0)
+	for _, name := range []string{svcName, headlessSvcName, "*." + headlessSvcName} {
+		dnsNames = append(dnsNames, name,
+			fmt.Sprintf("%s.%s", name, synthesizedComp.Namespace),
+			fmt.Sprintf("%s.%s.svc", name, synthesizedComp.Namespace))
+	}
+	return dnsNames
+}
+
+func createOrUpdateCertificate(ctx context.Context, cli client.Reader, dag *graph.DAG, cert *unstructured.Unstructured) error {
+	graphCli, _ := cli.(model.GraphClient)
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(cert.GroupVersionKind())
+	if err := cli.Get(ctx, client.ObjectKeyFromObject(cert), obj); err != nil {
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("cert-manager is required when issuer is CertManager: %s", err.Error())
+		}
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		graphCli.Create(dag, cert)
+		return nil
+	}
+	objCopy := obj.DeepCopy()
+	objCopy.SetLabels(cert.GetLabels())
+	objCopy.Object["spec"] = cert.Object["spec"]
+	if !reflect.DeepEqual(obj, objCopy) {
+		graphCli.Update(dag, obj, objCopy)
+	}
 	return nil
 }
 
@@ -170,6 +359,10 @@ func updateTLSVolumeAndVolumeMount(podSpec *corev1.PodSpec, clusterName string,
 		return err
 	}
 	volumes = append(volumes, *volume)
+	clientCert := tls.Issuer.Name == appsv1alpha1.IssuerCertManager && tls.Issuer.ClientCert
+	if clientCert {
+		volumes = append(volumes, composeTLSClientVolume(clusterName, synthesizeComp))
+	}
 	podSpec.Volumes = volumes
 
 	// update volumeMount
@@ -177,6 +370,13 @@ func updateTLSVolumeAndVolumeMount(podSpec *corev1.PodSpec, clusterName string,
 		volumeMounts := container.VolumeMounts
 		volumeMount := composeTLSVolumeMount()
 		volumeMounts = append(volumeMounts, volumeMount)
+		if clientCert {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      constant.ClientVolumeName,
+				MountPath: constant.ClientMountPath,
+				ReadOnly:  true,
+			})
+		}
 		podSpec.Containers[index].VolumeMounts = volumeMounts
 	}
 
@@ -194,10 +394,14 @@ func composeTLSVolume(clusterName string, synthesizeComp component.SynthesizedCo
 	if tls.Issuer.Name == appsv1alpha1.IssuerUserProvided && tls.Issuer.SecretRef == nil {
 		return nil, fmt.Errorf("secret ref shouldn't be nil when issuer is UserProvided")
 	}
+	if tls.Issuer.Name == appsv1alpha1.IssuerCertManager && tls.Issuer.IssuerRef == nil {
+		return nil, fmt.Errorf("issuer ref shouldn't be nil when issuer is CertManager")
+	}
 
 	var secretName, ca, cert, key string
 	switch tls.Issuer.Name {
-	case appsv1alpha1.IssuerKubeBlocks:
+	case appsv1alpha1.IssuerKubeBlocks, appsv1alpha1.IssuerCertManager:
+		// cert-manager stores the issued certificates with the same keys as the KubeBlocks issuer
 		secretName = plan.GenerateTLSSecretName(clusterName, synthesizeComp.Name)
 		ca = constant.CAName
 		cert = constant.CertName
@@ -228,6 +432,26 @@ func composeTLSVolume(clusterName string, synthesizeComp component.SynthesizedCo
 	return &volume, nil
 }
 
+// composeTLSClientVolume composes the volume of the client certificates requested from cert-manager.
+func composeTLSClientVolume(clusterName string, synthesizeComp component.SynthesizedComponent) corev1.Volume {
+	mode := int32(0600)
+	return corev1.Volume{
+		Name: constant.ClientVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: plan.GenerateTLSClientSecretName(clusterName, synthesizeComp.Name),
+				Items: []corev1.KeyToPath{
+					{Key: constant.CAName, Path: constant.CAName},
+					{Key: constant.CertName, Path: constant.CertName},
+					{Key: constant.KeyName, Path: constant.KeyName},
+				},
+				Optional:    func() *bool { o := false; return &o }(),
+				DefaultMode: &mode,
+			},
+		},
+	}
+}
+
 func composeTLSVolumeMount() corev1.VolumeMount {
 	return corev1.VolumeMount{
 		Name:      constant.VolumeName,
@@ -21,21 +21,35 @@ package apps
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/plan"
 	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
@@ -336,3 +350,151 @@ var _ = Describe("TLS self-signed cert function", func() {
 		})
 	})
 })
+
+var _ = Describe("TLS certificates expiry", func() {
+	const (
+		compName = "mysql"
+	)
+
+	var (
+		clusterName string
+		secretName  string
+	)
+
+	cleanEnv := func() {
+		// must wait until resources deleted and no longer exist before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.RSMSignature, true, inNS, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		clusterName = "test-cluster-tls-" + testCtx.GetRandomStr()
+		secretName = "test-tls-" + testCtx.GetRandomStr()
+	})
+
+	AfterEach(cleanEnv)
+
+	newCert := func(notAfter time.Time) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).Should(Succeed())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: constant.GenerateClusterComponentName(clusterName, compName)},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).Should(Succeed())
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+
+	newSynthesizedComp := func() *component.SynthesizedComponent {
+		return &component.SynthesizedComponent{
+			Namespace:   testCtx.DefaultNamespace,
+			ClusterName: clusterName,
+			Name:        compName,
+			TLSConfig: &appsv1alpha1.TLSConfig{
+				Enable: true,
+				Issuer: &appsv1alpha1.Issuer{
+					Name:      appsv1alpha1.IssuerUserProvided,
+					SecretRef: &appsv1alpha1.TLSSecretRef{Name: secretName, CA: "ca.crt", Cert: "tls.crt", Key: "tls.key"},
+				},
+			},
+		}
+	}
+
+	createSecret := func(cert []byte) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: secretName},
+			Data:       map[string][]byte{"tls.crt": cert},
+		}
+		Expect(testCtx.CreateObj(testCtx.Ctx, secret)).Should(Succeed())
+	}
+
+	createRSM := func(synthesizedComp *component.SynthesizedComponent, certHash string) {
+		factory := testapps.NewRSMFactory(testCtx.DefaultNamespace, component.WorkloadName(synthesizedComp, compName), clusterName, compName).
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage})
+		if len(certHash) > 0 {
+			factory.AddAnnotations(constant.TLSCertHashAnnotationKey, certHash)
+		}
+		rsmObj := factory.Create(&testCtx).GetObject()
+		Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(rsmObj),
+			&workloads.ReplicatedStateMachine{}, true)).Should(Succeed())
+	}
+
+	// mockTransformContext records the events by a fake recorder to check the warnings.
+	mockTransformContext := func(synthesizedComp *component.SynthesizedComponent) (*componentTransformContext, *graph.DAG, *record.FakeRecorder) {
+		transCtx, dag, _ := mockComponentTransformContext(synthesizedComp)
+		recorder := record.NewFakeRecorder(100)
+		transCtx.EventRecorder = recorder
+		return transCtx, dag, recorder
+	}
+
+	It("requeues to check the certificates when they enter the rotation window", func() {
+		createSecret(newCert(time.Now().Add(plan.TLSCertRotationWindow + time.Hour)))
+		transCtx, dag, recorder := mockTransformContext(newSynthesizedComp())
+
+		err := reconcileTLSCertExpiry(transCtx, dag)
+		Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+		requeueAfter := err.(intctrlutil.DelayedRequeueError).RequeueAfter()
+		Expect(requeueAfter).Should(BeNumerically(">", 0))
+		Expect(requeueAfter).Should(BeNumerically("<=", time.Hour))
+		Expect(recorder.Events).Should(BeEmpty())
+	})
+
+	It("warns only once when the certificates are expiring", func() {
+		createSecret(newCert(time.Now().Add(24 * time.Hour)))
+		transCtx, dag, recorder := mockTransformContext(newSynthesizedComp())
+		transCtx.Component.UID = types.UID(testCtx.GetRandomStr())
+
+		for i := 0; i < 3; i++ {
+			Expect(reconcileTLSCertExpiry(transCtx, dag)).Should(Succeed())
+		}
+		Expect(recorder.Events).Should(HaveLen(1))
+	})
+
+	Context("restart the pods only when the certificates are rotated", func() {
+		var (
+			certHash string
+		)
+
+		BeforeEach(func() {
+			cert := newCert(time.Now().Add(24 * time.Hour))
+			certHash = plan.ComputeTLSCertHash(cert)
+			createSecret(cert)
+		})
+
+		checkRotated := func(rotated bool) {
+			transCtx, dag, _ := mockTransformContext(newSynthesizedComp())
+			Expect(reconcileTLSCertExpiry(transCtx, dag)).Should(Succeed())
+			Expect(transCtx.SynthesizeComponent.TLSCertHash).Should(Equal(certHash))
+			Expect(transCtx.SynthesizeComponent.TLSCertRotated).Should(Equal(rotated))
+		}
+
+		It("workload not created", func() {
+			checkRotated(false)
+		})
+
+		It("hash not recorded", func() {
+			createRSM(newSynthesizedComp(), "")
+			checkRotated(false)
+		})
+
+		It("hash unchanged", func() {
+			createRSM(newSynthesizedComp(), certHash)
+			checkRotated(false)
+		})
+
+		It("hash changed", func() {
+			createRSM(newSynthesizedComp(), "another")
+			checkRotated(true)
+		})
+	})
+})
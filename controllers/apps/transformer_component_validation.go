@@ -20,9 +20,15 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 )
 
@@ -46,6 +52,9 @@ func (t *componentValidationTransformer) Transform(ctx graph.TransformContext, d
 	if err = validateCompReplicas(comp, transCtx.CompDef); err != nil {
 		return newRequeueError(requeueDuration, err.Error())
 	}
+	if err = validateDedicatedNodes(transCtx.Context, transCtx.Client, comp); err != nil {
+		return newRequeueError(requeueDuration, err.Error())
+	}
 	return nil
 }
 
@@ -89,6 +98,26 @@ func validateCompReplicas(comp *appsv1alpha1.Component, compDef *appsv1alpha1.Co
 	return replicasOutOfLimitError(replicas, *replicasLimit)
 }
 
+// validateDedicatedNodes checks whether there are nodes dedicated to database pods if they are required.
+func validateDedicatedNodes(ctx context.Context, cli client.Reader, comp *appsv1alpha1.Component) error {
+	if !component.RequireDedicatedNodes(comp.Spec.Affinity) {
+		return nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := cli.List(ctx, nodes, client.HasLabels{constant.DedicatedNodeLabelKey}); err != nil {
+		return err
+	}
+	for _, node := range nodes.Items {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == constant.DedicatedNodeLabelKey && taint.Effect == corev1.TaintEffectNoSchedule {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("no dedicated nodes found for the DedicatedNode tenancy with dedicatedNodes required, the nodes should be labeled and tainted with key %s",
+		constant.DedicatedNodeLabelKey)
+}
+
 func replicasOutOfLimitError(replicas int32, replicasLimit appsv1alpha1.ReplicasLimit) error {
 	return fmt.Errorf("replicas %d out-of-limit [%d, %d]", replicas, replicasLimit.MinReplicas, replicasLimit.MaxReplicas)
 }
@@ -22,6 +22,7 @@ package apps
 import (
 	"context"
 	"reflect"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -77,6 +78,13 @@ func (t *componentVarsTransformer) Transform(ctx graph.TransformContext, dag *gr
 
 	// pass all direct value env vars through CM
 	envVars2, envData := buildEnvVarsNData(synthesizedComp, envVars, legacy)
+	rsmObj, err := component.GetComponentRSM(transCtx.Context, transCtx.Client, synthesizedComp)
+	if err != nil {
+		return err
+	}
+	if endpoints := component.BuildPodEndpoints(synthesizedComp, rsmObj); len(endpoints) > 0 {
+		envData[constant.KBEnvPodEndpoints] = strings.Join(endpoints, ",")
+	}
 	setTemplateNEnvVars(synthesizedComp, templateVars, envVars2, legacy)
 
 	return createOrUpdateEnvConfigMap(ctx, dag, envData)
@@ -96,7 +104,7 @@ func generatedComponent4LegacyCluster(transCtx *componentTransformContext) (bool
 	rsmObj := &workloads.ReplicatedStateMachine{}
 	rsmKey := types.NamespacedName{
 		Namespace: synthesizedComp.Namespace,
-		Name:      constant.GenerateRSMNamePattern(synthesizedComp.ClusterName, synthesizedComp.Name),
+		Name:      component.WorkloadName(synthesizedComp, synthesizedComp.Name),
 	}
 	if err = transCtx.Client.Get(transCtx.Context, rsmKey, rsmObj); err != nil {
 		return false, client.IgnoreNotFound(err)
@@ -140,7 +140,7 @@ func (t *componentWorkloadTransformer) runningRSMObject(ctx graph.TransformConte
 	synthesizeComp *component.SynthesizedComponent) (*workloads.ReplicatedStateMachine, error) {
 	rsmKey := types.NamespacedName{
 		Namespace: synthesizeComp.Namespace,
-		Name:      constant.GenerateRSMNamePattern(synthesizeComp.ClusterName, synthesizeComp.Name),
+		Name:      component.WorkloadName(synthesizeComp, synthesizeComp.Name),
 	}
 	rsm := &workloads.ReplicatedStateMachine{}
 	if err := ctx.GetClient().Get(ctx.GetContext(), rsmKey, rsm); err != nil {
@@ -286,7 +286,7 @@ func copyAndMergeRSM(oldRsm, newRsm *workloads.ReplicatedStateMachine, synthesiz
 			if svc.GeneratePodOrdinalService {
 				continue
 			}
-			serviceName := constant.GenerateComponentServiceName(synthesizeComp.ClusterName, synthesizeComp.Name, svc.ServiceName)
+			serviceName := component.ServiceName(synthesizeComp, synthesizeComp.Name, svc.ServiceName)
 			if defaultServiceName == serviceName {
 				return rsmObj.Spec.Service
 			}
@@ -601,6 +601,11 @@ func (r *componentWorkloadOps) leaveMember4ScaleIn(stsObj *apps.StatefulSet) err
 }
 
 func (r *componentWorkloadOps) deletePVCs4ScaleIn(stsObj *apps.StatefulSet) error {
+	retain := false
+	if policy := r.synthesizeComp.VolumeClaimRetentionPolicy; policy != nil &&
+		policy.WhenScaled == appsv1alpha1.RetainVolumeClaimRetentionPolicyType {
+		retain = true
+	}
 	graphCli := model.NewGraphClient(r.cli)
 	for i := r.synthesizeComp.Replicas; i < *stsObj.Spec.Replicas; i++ {
 		for _, vct := range stsObj.Spec.VolumeClaimTemplates {
@@ -612,6 +617,16 @@ func (r *componentWorkloadOps) deletePVCs4ScaleIn(stsObj *apps.StatefulSet) erro
 			if err := r.cli.Get(r.reqCtx.Ctx, pvcKey, &pvc); err != nil {
 				return err
 			}
+			if retain {
+				// mark the PVCs as retained, the data clone is skipped for them when the component is scaled out again.
+				pvcCopy := pvc.DeepCopy()
+				if pvcCopy.Annotations == nil {
+					pvcCopy.Annotations = map[string]string{}
+				}
+				pvcCopy.Annotations[constant.RetainedPVCAnnotationKey] = "true"
+				graphCli.Update(r.dag, &pvc, pvcCopy)
+				continue
+			}
 			// Since there are no order guarantee between updating STS and deleting PVCs, if there is any error occurred
 			// after updating STS and before deleting PVCs, the PVCs intended to scale-in will be leaked.
 			// For simplicity, the updating dependency is added between them to guarantee that the PVCs to scale-in
@@ -0,0 +1,295 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	statsv1alpha1 "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// volumeAutoExpansionCheckInterval the interval to check the space usage of the volumes.
+	volumeAutoExpansionCheckInterval = time.Minute
+
+	// reasonVolumeAutoExpanded the event reason indicates that the volumes are expanded automatically.
+	reasonVolumeAutoExpanded = "VolumeAutoExpanded"
+	// reasonVolumeReachedMaxSize the event reason indicates that the volumes can not be expanded anymore.
+	reasonVolumeReachedMaxSize = "VolumeReachedMaxSize"
+)
+
+// volumeStatsGetter gets the stats summary of the node from kubelet.
+type volumeStatsGetter func(ctx context.Context, nodeName string) (*statsv1alpha1.Summary, error)
+
+// VolumeAutoExpansionReconciler expands the volumes of the cluster components automatically
+// by the VolumeExpansion OpsRequest when their space usage crosses the threshold.
+type VolumeAutoExpansionReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Recorder   record.EventRecorder
+	RestConfig *rest.Config
+
+	getStatsSummary volumeStatsGetter
+
+	// reachedMaxSize records the max size of the volumes which have reached it, the warning is emitted
+	// only once until the space usage drops below the threshold or the max size is changed.
+	reachedMaxSize sync.Map
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create
+
+// Reconcile checks the space usage of the volumes which are configured to be expanded automatically,
+// and expands them by the VolumeExpansion OpsRequest.
+func (r *VolumeAutoExpansionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("cluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !cluster.GetDeletionTimestamp().IsZero() || !hasVolumeAutoExpansion(cluster) {
+		return intctrlutil.Reconciled()
+	}
+	// expand the volumes only if the cluster is running and no other operation is in progress,
+	// which means the last expansion has been done.
+	if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
+		return intctrlutil.RequeueAfter(volumeAutoExpansionCheckInterval, reqCtx.Log, "cluster is not running")
+	}
+	opsRecorders, err := opsutil.GetOpsRequestSliceFromCluster(cluster)
+	if err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(opsRecorders) > 0 {
+		return intctrlutil.RequeueAfter(volumeAutoExpansionCheckInterval, reqCtx.Log, "cluster has running OpsRequests")
+	}
+
+	var (
+		volumeExpansionList []appsv1alpha1.VolumeExpansion
+		messages            []string
+		summaries           = map[string]*statsv1alpha1.Summary{}
+	)
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		var vcts []appsv1alpha1.OpsRequestVolumeClaimTemplate
+		for _, vct := range compSpec.VolumeClaimTemplates {
+			if vct.AutoExpansion == nil {
+				continue
+			}
+			usage, err := r.getVolumeUsage(reqCtx, cluster, compSpec.Name, vct.Name, summaries)
+			if err != nil {
+				return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+			}
+			key := fmt.Sprintf("%s/%s/%s", cluster.UID, compSpec.Name, vct.Name)
+			if usage < vct.AutoExpansion.UsageThreshold {
+				r.reachedMaxSize.Delete(key)
+				continue
+			}
+			current := vct.Spec.Resources.Requests.Storage()
+			target := calcAutoExpansionSize(*current, vct.AutoExpansion.Step, vct.AutoExpansion.MaxSize)
+			if target.Cmp(*current) <= 0 {
+				if maxSize, ok := r.reachedMaxSize.Load(key); !ok || maxSize != vct.AutoExpansion.MaxSize.String() {
+					r.reachedMaxSize.Store(key, vct.AutoExpansion.MaxSize.String())
+					r.Recorder.Eventf(cluster, corev1.EventTypeWarning, reasonVolumeReachedMaxSize,
+						"the space usage of volume %s in component %s is %d%%, but it has reached the max size %s",
+						vct.Name, compSpec.Name, usage, vct.AutoExpansion.MaxSize.String())
+				}
+				continue
+			}
+			vcts = append(vcts, appsv1alpha1.OpsRequestVolumeClaimTemplate{Name: vct.Name, Storage: target})
+			messages = append(messages, fmt.Sprintf("volume %s in component %s is expanded from %s to %s as the space usage is %d%%",
+				vct.Name, compSpec.Name, current.String(), target.String(), usage))
+		}
+		if len(vcts) > 0 {
+			volumeExpansionList = append(volumeExpansionList, appsv1alpha1.VolumeExpansion{
+				ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: compSpec.Name},
+				VolumeClaimTemplates: vcts,
+			})
+		}
+	}
+	if len(volumeExpansionList) == 0 {
+		return intctrlutil.RequeueAfter(volumeAutoExpansionCheckInterval, reqCtx.Log, "")
+	}
+
+	ops := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: cluster.Name + "-volume-auto-expansion-",
+			Namespace:    cluster.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    cluster.Name,
+				constant.AppManagedByLabelKey:   constant.AppName,
+				constant.OpsRequestTypeLabelKey: string(appsv1alpha1.VolumeExpansionType),
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterRef:          cluster.Name,
+			Type:                appsv1alpha1.VolumeExpansionType,
+			VolumeExpansionList: volumeExpansionList,
+		},
+	}
+	if err = r.Client.Create(reqCtx.Ctx, ops); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	message := fmt.Sprintf("OpsRequest %s is created: %s", ops.Name, joinVolumeAutoExpansionMessages(messages))
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, reasonVolumeAutoExpanded, message)
+	// the cluster status is also written by the cluster controller, only the condition is applied on the latest one
+	condition := metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeVolumeAutoExpansion,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cluster.Generation,
+		Reason:             reasonVolumeAutoExpanded,
+		Message:            message,
+	}
+	statusWriter := intctrlutil.NewStatusWriter(r.Client, cluster)
+	statusWriter.Add(func(cluster *appsv1alpha1.Cluster) {
+		meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	})
+	if _, err := statusWriter.Write(reqCtx.Ctx); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(volumeAutoExpansionCheckInterval, reqCtx.Log, "")
+}
+
+// getVolumeUsage gets the max space usage percentage of the volume among the pods of the component.
+func (r *VolumeAutoExpansionReconciler) getVolumeUsage(reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster,
+	compName, volumeName string, summaries map[string]*statsv1alpha1.Summary) (int32, error) {
+	podList, err := intctrlcomp.GetComponentPodList(reqCtx.Ctx, r.Client, *cluster, compName)
+	if err != nil {
+		return 0, err
+	}
+	var maxUsage int32
+	for _, pod := range podList.Items {
+		if len(pod.Spec.NodeName) == 0 {
+			continue
+		}
+		summary, ok := summaries[pod.Spec.NodeName]
+		if !ok {
+			if summary, err = r.getStatsSummary(reqCtx.Ctx, pod.Spec.NodeName); err != nil {
+				// the kubelet may be unavailable temporarily, skip the pods on it
+				reqCtx.Log.Error(err, "get stats summary from kubelet failed", "node", pod.Spec.NodeName)
+			}
+			summaries[pod.Spec.NodeName] = summary
+		}
+		if usage := getPodVolumeUsage(summary, &pod, volumeName); usage > maxUsage {
+			maxUsage = usage
+		}
+	}
+	return maxUsage, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VolumeAutoExpansionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.getStatsSummary == nil {
+		cli, err := corev1client.NewForConfig(r.RestConfig)
+		if err != nil {
+			return err
+		}
+		r.getStatsSummary = func(ctx context.Context, nodeName string) (*statsv1alpha1.Summary, error) {
+			data, err := cli.RESTClient().Get().Resource("nodes").Name(nodeName).
+				SubResource("proxy").Suffix("stats/summary").DoRaw(ctx)
+			if err != nil {
+				return nil, err
+			}
+			summary := &statsv1alpha1.Summary{}
+			if err = json.Unmarshal(data, summary); err != nil {
+				return nil, err
+			}
+			return summary, nil
+		}
+	}
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		Named("volume-auto-expansion").
+		For(&appsv1alpha1.Cluster{}).
+		Complete(r)
+}
+
+func hasVolumeAutoExpansion(cluster *appsv1alpha1.Cluster) bool {
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		for _, vct := range compSpec.VolumeClaimTemplates {
+			if vct.AutoExpansion != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getPodVolumeUsage gets the space usage percentage of the volume of the pod from the stats summary.
+func getPodVolumeUsage(summary *statsv1alpha1.Summary, pod *corev1.Pod, volumeName string) int32 {
+	if summary == nil {
+		return 0
+	}
+	for _, podStats := range summary.Pods {
+		if podStats.PodRef.Namespace != pod.Namespace || podStats.PodRef.Name != pod.Name {
+			continue
+		}
+		for _, stats := range podStats.VolumeStats {
+			if stats.Name != volumeName || stats.CapacityBytes == nil || stats.UsedBytes == nil || *stats.CapacityBytes == 0 {
+				continue
+			}
+			return int32(*stats.UsedBytes * 100 / *stats.CapacityBytes)
+		}
+	}
+	return 0
+}
+
+// calcAutoExpansionSize calculates the size to expand the volume to, which is bounded by the max size.
+func calcAutoExpansionSize(current, step, maxSize resource.Quantity) resource.Quantity {
+	target := current.DeepCopy()
+	target.Add(step)
+	if target.Cmp(maxSize) > 0 {
+		target = maxSize.DeepCopy()
+	}
+	return target
+}
+
+func joinVolumeAutoExpansionMessages(messages []string) string {
+	msg := messages[0]
+	for _, m := range messages[1:] {
+		msg = fmt.Sprintf("%s; %s", msg, m)
+	}
+	return msg
+}
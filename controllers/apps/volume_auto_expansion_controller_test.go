@@ -0,0 +1,257 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	statsv1alpha1 "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("volume auto expansion controller", func() {
+	const (
+		clusterDefName = "test-clusterdef-auto-expansion"
+		compName       = "mysql"
+		vctName        = "data"
+		nodeName       = "node-0"
+	)
+
+	Context("calculate the size and the usage", func() {
+		It("should expand the volume by the step up to the max size", func() {
+			calc := func(current, step, maxSize string) string {
+				size := calcAutoExpansionSize(resource.MustParse(current), resource.MustParse(step), resource.MustParse(maxSize))
+				return size.String()
+			}
+			Expect(calc("10Gi", "5Gi", "20Gi")).Should(Equal("15Gi"))
+			Expect(calc("10Gi", "5Gi", "12Gi")).Should(Equal("12Gi"))
+			Expect(calc("12Gi", "5Gi", "12Gi")).Should(Equal("12Gi"))
+		})
+
+		It("should get the space usage of the pod volume from the stats summary", func() {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: "mysql-0"}}
+			summary := &statsv1alpha1.Summary{
+				Pods: []statsv1alpha1.PodStats{
+					{
+						PodRef: statsv1alpha1.PodReference{Namespace: testCtx.DefaultNamespace, Name: "mysql-0"},
+						VolumeStats: []statsv1alpha1.VolumeStats{
+							{
+								Name: vctName,
+								FsStats: statsv1alpha1.FsStats{
+									CapacityBytes: pointer.Uint64(100),
+									UsedBytes:     pointer.Uint64(85),
+								},
+							},
+							{
+								Name: "log",
+								FsStats: statsv1alpha1.FsStats{
+									CapacityBytes: pointer.Uint64(0),
+									UsedBytes:     pointer.Uint64(0),
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(getPodVolumeUsage(summary, pod, vctName)).Should(Equal(int32(85)))
+			Expect(getPodVolumeUsage(summary, pod, "log")).Should(Equal(int32(0)))
+			Expect(getPodVolumeUsage(summary, pod, "not-exist")).Should(Equal(int32(0)))
+			Expect(getPodVolumeUsage(nil, pod, vctName)).Should(Equal(int32(0)))
+		})
+	})
+
+	Context("reconcile the volume auto expansion", func() {
+		var (
+			clusterName string
+			clusterKey  client.ObjectKey
+			usedBytes   uint64
+		)
+
+		cleanEnv := func() {
+			// must wait till resources deleted and no longer existed before the testcases start,
+			// otherwise if later it needs to create some new resource objects with the same name,
+			// in race conditions, it will find the existence of old objects, resulting failure to
+			// create the new objects.
+			By("clean resources")
+			inNS := client.InNamespace(testCtx.DefaultNamespace)
+			ml := client.HasLabels{testCtx.TestObjLabelKey}
+			if len(clusterName) > 0 {
+				testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS,
+					client.MatchingLabels{constant.AppInstanceLabelKey: clusterName})
+			}
+			testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+			testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		}
+
+		BeforeEach(func() {
+			cleanEnv()
+			clusterName = "test-cluster-" + testCtx.GetRandomStr()
+			clusterKey = client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: clusterName}
+		})
+
+		AfterEach(cleanEnv)
+
+		// createCluster creates the running cluster, which refers to a cluster definition not existing, so that it
+		// is not reconciled by the cluster controller.
+		createCluster := func(storage string, annotations ...string) {
+			cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName, "").
+				AddComponent(compName, compName).
+				AddVolumeClaimTemplate(vctName, testapps.NewPVCSpec(storage)).
+				AddAnnotations(annotations...).
+				Apply(func(cluster *appsv1alpha1.Cluster) {
+					cluster.Spec.ComponentSpecs[0].VolumeClaimTemplates[0].AutoExpansion = &appsv1alpha1.VolumeAutoExpansion{
+						UsageThreshold: 80,
+						Step:           resource.MustParse("5Gi"),
+						MaxSize:        resource.MustParse("20Gi"),
+					}
+				}).
+				Create(&testCtx).
+				GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
+				cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+			})).Should(Succeed())
+
+			By("create the pod of the component on the node")
+			testapps.NewPodFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, compName)+"-0").
+				AddLabelsInMap(constant.GetComponentWellKnownLabels(clusterName, compName)).
+				AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+				AddNodeName(nodeName).
+				Create(&testCtx)
+		}
+
+		newReconciler := func() (*VolumeAutoExpansionReconciler, *record.FakeRecorder) {
+			recorder := record.NewFakeRecorder(10)
+			return &VolumeAutoExpansionReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				getStatsSummary: func(ctx context.Context, nodeName string) (*statsv1alpha1.Summary, error) {
+					return &statsv1alpha1.Summary{
+						Pods: []statsv1alpha1.PodStats{{
+							PodRef: statsv1alpha1.PodReference{
+								Namespace: testCtx.DefaultNamespace,
+								Name:      constant.GenerateClusterComponentName(clusterName, compName) + "-0",
+							},
+							VolumeStats: []statsv1alpha1.VolumeStats{{
+								Name: vctName,
+								FsStats: statsv1alpha1.FsStats{
+									CapacityBytes: pointer.Uint64(100),
+									UsedBytes:     pointer.Uint64(usedBytes),
+								},
+							}},
+						}},
+					}, nil
+				},
+			}, recorder
+		}
+
+		reconcile := func(r *VolumeAutoExpansionReconciler) {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: clusterKey})
+			Expect(err).Should(Succeed())
+		}
+
+		listOpsRequests := func() []appsv1alpha1.OpsRequest {
+			opsList := &appsv1alpha1.OpsRequestList{}
+			Expect(k8sClient.List(ctx, opsList, client.InNamespace(testCtx.DefaultNamespace),
+				client.MatchingLabels{constant.AppInstanceLabelKey: clusterName})).Should(Succeed())
+			return opsList.Items
+		}
+
+		events := func(recorder *record.FakeRecorder) []string {
+			var result []string
+			for len(recorder.Events) > 0 {
+				result = append(result, <-recorder.Events)
+			}
+			return result
+		}
+
+		It("creates a VolumeExpansion OpsRequest when the usage crosses the threshold", func() {
+			usedBytes = 85
+			createCluster("10Gi")
+			r, recorder := newReconciler()
+			reconcile(r)
+
+			opsList := listOpsRequests()
+			Expect(opsList).Should(HaveLen(1))
+			Expect(opsList[0].Spec.Type).Should(Equal(appsv1alpha1.VolumeExpansionType))
+			Expect(opsList[0].Spec.ClusterRef).Should(Equal(clusterName))
+			Expect(opsList[0].Spec.VolumeExpansionList).Should(HaveLen(1))
+			Expect(opsList[0].Spec.VolumeExpansionList[0].ComponentName).Should(Equal(compName))
+			Expect(opsList[0].Spec.VolumeExpansionList[0].VolumeClaimTemplates).Should(HaveLen(1))
+			Expect(opsList[0].Spec.VolumeExpansionList[0].VolumeClaimTemplates[0].Name).Should(Equal(vctName))
+			Expect(opsList[0].Spec.VolumeExpansionList[0].VolumeClaimTemplates[0].Storage.String()).Should(Equal("15Gi"))
+			Expect(events(recorder)).Should(HaveLen(1))
+
+			By("the cluster is not updated directly")
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().String()).Should(Equal("10Gi"))
+			})).Should(Succeed())
+		})
+
+		It("does nothing when the usage is below the threshold", func() {
+			usedBytes = 50
+			createCluster("10Gi")
+			r, recorder := newReconciler()
+			reconcile(r)
+			Expect(listOpsRequests()).Should(BeEmpty())
+			Expect(events(recorder)).Should(BeEmpty())
+		})
+
+		It("waits for the running OpsRequests", func() {
+			usedBytes = 85
+			createCluster("10Gi", constant.OpsRequestAnnotationKey, `[{"name":"`+clusterName+`-restart","type":"Restart"}]`)
+			r, _ := newReconciler()
+			reconcile(r)
+			Expect(listOpsRequests()).Should(BeEmpty())
+		})
+
+		It("warns only once when the volume reaches the max size", func() {
+			usedBytes = 85
+			createCluster("20Gi")
+			r, recorder := newReconciler()
+			for i := 0; i < 3; i++ {
+				reconcile(r)
+			}
+			Expect(listOpsRequests()).Should(BeEmpty())
+			Expect(events(recorder)).Should(HaveLen(1))
+
+			By("warns again after the usage drops and crosses the threshold again")
+			usedBytes = 50
+			reconcile(r)
+			usedBytes = 85
+			reconcile(r)
+			Expect(events(recorder)).Should(HaveLen(1))
+		})
+	})
+})
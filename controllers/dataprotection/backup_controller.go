@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/client/v3/apis/volumesnapshot/v1beta1"
@@ -31,6 +32,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -40,7 +42,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -49,12 +50,14 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/notification"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
 	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -80,6 +83,8 @@ type BackupReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components;componentdefinitions,verbs=get;list;watch
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the backup closer to the desired state.
 func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -103,6 +108,12 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	// reference workloads, data and volume snapshots will be deleted by controller
 	// later when the backup status.phase is deleting.
 	if !backup.GetDeletionTimestamp().IsZero() && backup.Status.Phase != dpv1alpha1.BackupPhaseDeleting {
+		// the backup is held by the finalizer until the deletion protection is lifted.
+		if protected, err := r.isDeletionProtected(reqCtx, backup); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		} else if protected {
+			return intctrlutil.Reconciled()
+		}
 		patch := client.MergeFrom(backup.DeepCopy())
 		backup.Status.Phase = dpv1alpha1.BackupPhaseDeleting
 		if err := r.Client.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
@@ -133,9 +144,7 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 func (r *BackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	b := intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&dpv1alpha1.Backup{}).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: viper.GetInt(dptypes.CfgDataProtectionReconcileWorkers),
-		}).
+		WithOptions(intctrlutil.NewControllerOptions(dptypes.CfgBackupReconcileWorkers, dptypes.CfgDataProtectionReconcileWorkers, 1)).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&batchv1.Job{}).
 		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.filterBackupPods)).
@@ -195,7 +204,11 @@ func (r *BackupReconciler) deleteBackupFiles(reqCtx intctrlutil.RequestCtx, back
 		// remove backup finalizers to delete it
 		patch := client.MergeFrom(backup.DeepCopy())
 		controllerutil.RemoveFinalizer(backup, dptypes.DataProtectionFinalizerName)
-		return r.Patch(reqCtx.Ctx, backup, patch)
+		if err := r.Patch(reqCtx.Ctx, backup, patch); err != nil {
+			return err
+		}
+		r.deleteBackupMetrics(reqCtx, backup)
+		return nil
 	}
 
 	deleter := &dpbackup.Deleter{
@@ -246,6 +259,11 @@ func (r *BackupReconciler) handleDeletingPhase(reqCtx intctrlutil.RequestCtx, ba
 		return intctrlutil.Reconciled()
 	}
 
+	// the dependent backups can not be restored without this backup, delete them too.
+	if err := r.deleteDependentBackups(reqCtx, backup); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	}
+
 	if err := r.deleteVolumeSnapshots(reqCtx, backup); err != nil {
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	}
@@ -256,6 +274,49 @@ func (r *BackupReconciler) handleDeletingPhase(reqCtx intctrlutil.RequestCtx, ba
 	return intctrlutil.Reconciled()
 }
 
+// isDeletionProtected checks whether the backup or any backup based on it is
+// protected from deletion, deleting the backup will invalidate its dependents.
+func (r *BackupReconciler) isDeletionProtected(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (bool, error) {
+	if backup.Spec.DeletionProtection {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "DeletionProtected",
+			"the backup is protected from deletion, disable the deletionProtection to delete it")
+		return true, nil
+	}
+	dependents, err := dpbackup.GetDependentBackups(reqCtx.Ctx, r.Client, backup)
+	if err != nil {
+		return false, err
+	}
+	for _, dependent := range dependents {
+		if dependent.Spec.DeletionProtection {
+			r.Recorder.Eventf(backup, corev1.EventTypeWarning, "DeletionProtected",
+				"the dependent backup %s is protected from deletion, disable its deletionProtection to delete the backup", dependent.Name)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteDependentBackups deletes the backups based on the specified backup, such as
+// the incremental backups whose parent is the backup.
+func (r *BackupReconciler) deleteDependentBackups(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) error {
+	dependents, err := dpbackup.GetDependentBackups(reqCtx.Ctx, r.Client, backup)
+	if err != nil {
+		return err
+	}
+	for _, dependent := range dependents {
+		if !dependent.DeletionTimestamp.IsZero() {
+			continue
+		}
+		reqCtx.Log.Info("delete the dependent backup", "dependent", dependent.Name)
+		if err = intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, dependent); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(dependent, corev1.EventTypeNormal, "ParentBackupDeleted",
+			"the parent backup %s is deleted, the backup is no longer restorable", backup.Name)
+	}
+	return nil
+}
+
 func (r *BackupReconciler) handleNewPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
@@ -326,6 +387,16 @@ func (r *BackupReconciler) prepareBackupRequest(
 		request.ActionSet = actionSet
 	}
 
+	// incremental backup is based on its parent backup, check the parent backup
+	// is available before backing up.
+	if request.ActionSet != nil && request.ActionSet.Spec.BackupType == dpv1alpha1.BackupTypeIncremental {
+		parentBackup, err := r.getParentBackup(reqCtx, backup)
+		if err != nil {
+			return nil, err
+		}
+		request.ParentBackup = parentBackup
+	}
+
 	// check encryption config
 	if backupPolicy.Spec.EncryptionConfig != nil {
 		secretKeyRef := backupPolicy.Spec.EncryptionConfig.PassPhraseSecretKeyRef
@@ -355,6 +426,12 @@ func (r *BackupReconciler) prepareBackupRequest(
 	}
 	request.TargetPods = targetPods
 
+	if snapshotVolumes {
+		if request.SnapshotHook, err = r.getSnapshotHook(reqCtx, targetPods[0]); err != nil {
+			return nil, err
+		}
+	}
+
 	saName := backupPolicy.Spec.Target.ServiceAccountName
 	if saName == "" {
 		saName, err = EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace)
@@ -367,6 +444,71 @@ func (r *BackupReconciler) prepareBackupRequest(
 	return request, nil
 }
 
+// getSnapshotHook gets the snapshot hook declared in the ComponentDefinition of
+// the component the target pod belongs to.
+func (r *BackupReconciler) getSnapshotHook(reqCtx intctrlutil.RequestCtx,
+	targetPod *corev1.Pod) (*appsv1alpha1.SnapshotHook, error) {
+	clusterName := targetPod.Labels[constant.AppInstanceLabelKey]
+	compName := targetPod.Labels[constant.KBAppComponentLabelKey]
+	if clusterName == "" || compName == "" {
+		return nil, nil
+	}
+	comp := &appsv1alpha1.Component{}
+	compKey := client.ObjectKey{
+		Namespace: targetPod.Namespace,
+		Name:      constant.GenerateClusterComponentName(clusterName, compName),
+	}
+	if err := r.Client.Get(reqCtx.Ctx, compKey, comp); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if comp.Spec.CompDef == "" {
+		return nil, nil
+	}
+	compDef := &appsv1alpha1.ComponentDefinition{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: comp.Spec.CompDef}, compDef); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return compDef.Spec.SnapshotHook, nil
+}
+
+// getParentBackup gets the parent backup of the incremental backup and checks
+// whether it can be used as the base of the backup.
+func (r *BackupReconciler) getParentBackup(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (*dpv1alpha1.Backup, error) {
+	if backup.Spec.ParentBackupName == "" {
+		return nil, fmt.Errorf("spec.parentBackupName is required for incremental backup")
+	}
+	parentBackup := &dpv1alpha1.Backup{}
+	parentKey := client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.ParentBackupName}
+	if err := r.Client.Get(reqCtx.Ctx, parentKey, parentBackup); err != nil {
+		return nil, fmt.Errorf("failed to get parent backup %s: %w", parentKey.Name, err)
+	}
+	if parentBackup.Spec.BackupPolicyName != backup.Spec.BackupPolicyName {
+		return nil, fmt.Errorf("parent backup %s uses backup policy %s, expected %s",
+			parentBackup.Name, parentBackup.Spec.BackupPolicyName, backup.Spec.BackupPolicyName)
+	}
+	switch parentType := parentBackup.Labels[dptypes.BackupTypeLabelKey]; parentType {
+	case string(dpv1alpha1.BackupTypeFull), string(dpv1alpha1.BackupTypeIncremental):
+	default:
+		return nil, fmt.Errorf("parent backup %s is a %s backup, only full or incremental backup can be the parent",
+			parentBackup.Name, parentType)
+	}
+	if !parentBackup.DeletionTimestamp.IsZero() {
+		return nil, fmt.Errorf("parent backup %s is being deleted", parentBackup.Name)
+	}
+	switch parentBackup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
+		return parentBackup, nil
+	case dpv1alpha1.BackupPhaseFailed, dpv1alpha1.BackupPhaseDeleting:
+		return nil, fmt.Errorf("parent backup %s is %s", parentBackup.Name, parentBackup.Status.Phase)
+	default:
+		// wait for the parent backup to complete.
+		return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue,
+			"parent backup %s is not completed yet", parentBackup.Name)
+	}
+}
+
 func (r *BackupReconciler) patchBackupStatus(
 	original *dpv1alpha1.Backup,
 	request *dpbackup.Request) error {
@@ -386,6 +528,11 @@ func (r *BackupReconciler) patchBackupStatus(
 	if request.BackupPolicy.Spec.EncryptionConfig != nil {
 		request.Status.EncryptionConfig = request.BackupPolicy.Spec.EncryptionConfig
 	}
+	if request.BackupPolicy.Spec.CompressionConfig != nil {
+		request.Status.CompressionConfig = request.BackupPolicy.Spec.CompressionConfig
+	}
+	request.Status.BandwidthLimit = dpbackup.GetBandwidthLimit(request.Backup, request.BackupRepo)
+	request.Status.JobPodTemplate = request.BackupPolicy.Spec.JobPodTemplate
 	// init action status
 	actions, err := request.BuildActions()
 	if err != nil {
@@ -441,13 +588,20 @@ func (r *BackupReconciler) handleRunningPhase(
 		RestClientConfig: r.RestConfig,
 	}
 
+	handleFailed := func(err error) (ctrl.Result, error) {
+		if !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRequeue) {
+			r.resumeSnapshotHooks(reqCtx, actionCtx, request, actions)
+		}
+		return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
+	}
+
 	// check all actions status, if any action failed, update backup status to failed
 	// if all actions completed, update backup status to completed, otherwise,
 	// continue to handle following actions.
 	for i, act := range actions {
 		status, err := act.Execute(actionCtx)
 		if err != nil {
-			return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
+			return handleFailed(err)
 		}
 		request.Status.Actions[i] = mergeActionStatus(&request.Status.Actions[i], status)
 
@@ -456,8 +610,7 @@ func (r *BackupReconciler) handleRunningPhase(
 			updateBackupStatusByActionStatus(&request.Status)
 			continue
 		case dpv1alpha1.ActionPhaseFailed:
-			return r.updateStatusIfFailed(reqCtx, backup, request.Backup,
-				fmt.Errorf("action %s failed, %s", act.GetName(), status.FailureReason))
+			return handleFailed(fmt.Errorf("action %s failed, %s", act.GetName(), status.FailureReason))
 		case dpv1alpha1.ActionPhaseRunning:
 			// update status
 			if err = r.Client.Status().Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(backup)); err != nil {
@@ -474,6 +627,7 @@ func (r *BackupReconciler) handleRunningPhase(
 		// round the duration to a multiple of seconds.
 		duration := request.Status.CompletionTimestamp.Sub(request.Status.StartTimestamp.Time).Round(time.Second)
 		request.Status.Duration = &metav1.Duration{Duration: duration}
+		request.Status.Throughput = dpbackup.CalculateThroughput(request.Status.TotalSize, duration)
 	}
 	if request.Spec.RetentionPeriod != "" {
 		// set expiration time
@@ -491,9 +645,42 @@ func (r *BackupReconciler) handleRunningPhase(
 	if err = r.Client.Status().Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(backup)); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
+	recordBackupMetrics(request.Backup)
 	return intctrlutil.Reconciled()
 }
 
+// resumeSnapshotHooks signals the snapshot hooks to resume the target application
+// if the backup fails after the hooks have been started, to make sure the writes of
+// the target application are not blocked by the hooks until they time out.
+func (r *BackupReconciler) resumeSnapshotHooks(reqCtx intctrlutil.RequestCtx,
+	actionCtx action.ActionContext,
+	request *dpbackup.Request,
+	actions []action.Action) {
+	hookStarted := false
+	for i, act := range actions {
+		if i >= len(request.Status.Actions) {
+			return
+		}
+		phase := request.Status.Actions[i].Phase
+		switch {
+		case strings.HasPrefix(act.GetName(), dpbackup.SnapshotHookJobNamePrefix):
+			if phase != "" && phase != dpv1alpha1.ActionPhaseNew {
+				hookStarted = true
+			}
+		case strings.HasPrefix(act.GetName(), dpbackup.SnapshotResumeJobNamePrefix):
+			if !hookStarted || phase == dpv1alpha1.ActionPhaseCompleted {
+				continue
+			}
+			status, err := act.Execute(actionCtx)
+			if err != nil {
+				reqCtx.Log.Error(err, "failed to resume the snapshot hook", "action", act.GetName())
+				continue
+			}
+			request.Status.Actions[i] = mergeActionStatus(&request.Status.Actions[i], status)
+		}
+	}
+}
+
 // checkIsCompletedDuringRunning when continuous schedule is disabled or cluster has been deleted,
 // backup phase should be Completed.
 func (r *BackupReconciler) checkIsCompletedDuringRunning(reqCtx intctrlutil.RequestCtx,
@@ -544,7 +731,7 @@ func (r *BackupReconciler) checkIsCompletedDuringRunning(reqCtx intctrlutil.Requ
 }
 
 // handleCompletedPhase handles the backup object in completed phase.
-// It will delete the reference workloads.
+// It will delete the reference workloads and verify the backup if required.
 func (r *BackupReconciler) handleCompletedPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
@@ -552,7 +739,7 @@ func (r *BackupReconciler) handleCompletedPhase(
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 
-	return intctrlutil.Reconciled()
+	return r.verifyBackup(reqCtx, backup)
 }
 
 func (r *BackupReconciler) updateStatusIfFailed(
@@ -574,6 +761,10 @@ func (r *BackupReconciler) updateStatusIfFailed(
 	if errUpdate := r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); errUpdate != nil {
 		return intctrlutil.CheckedRequeueWithError(errUpdate, reqCtx.Log, "")
 	}
+	if original.Status.Phase != dpv1alpha1.BackupPhaseFailed {
+		notification.Notify(reqCtx.Ctx, r.Client, backup, notification.EventBackupFailed,
+			fmt.Sprintf("backup %s failed: %s", backup.Name, backup.Status.FailureReason))
+	}
 	return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 }
 
@@ -664,6 +855,11 @@ func PatchBackupObjectMeta(
 
 	// set annotations
 	request.Annotations[dptypes.BackupTargetPodLabelKey] = targetPod.Name
+	// the jobs which run without the target pod, e.g. verifying and deleting the backup, don't inject the sidecar proxy
+	// of the service mesh either.
+	if provider, ok := targetPod.Annotations[constant.ServiceMeshAnnotationKey]; ok {
+		request.Annotations[constant.ServiceMeshAnnotationKey] = provider
+	}
 
 	// set finalizer
 	controllerutil.AddFinalizer(request.Backup, dptypes.DataProtectionFinalizerName)
@@ -694,6 +890,53 @@ func updateBackupStatusByActionStatus(backupStatus *dpv1alpha1.BackupStatus) {
 	}
 }
 
+// recordBackupMetrics records the size, duration and throughput of the completed backup.
+func recordBackupMetrics(backup *dpv1alpha1.Backup) {
+	labels := []string{
+		backup.Namespace,
+		backup.Labels[constant.AppInstanceLabelKey],
+		backup.Labels[constant.KBAppComponentLabelKey],
+		backup.Labels[dptypes.BackupTypeLabelKey],
+	}
+	var durationSeconds float64
+	if backup.Status.Duration != nil {
+		durationSeconds = backup.Status.Duration.Seconds()
+		metrics.BackupDurationSeconds.WithLabelValues(labels...).Set(durationSeconds)
+	}
+	// the total size is unknown for some backups, do not record it as zero.
+	size, err := resource.ParseQuantity(backup.Status.TotalSize)
+	if backup.Status.TotalSize == "" || err != nil {
+		return
+	}
+	totalBytes := float64(size.Value())
+	metrics.BackupTotalBytes.WithLabelValues(labels...).Set(totalBytes)
+	if durationSeconds >= 1 {
+		metrics.BackupThroughputBytes.WithLabelValues(labels...).Set(totalBytes / durationSeconds)
+	}
+}
+
+// deleteBackupMetrics deletes the series of the deleted backup if no other backups of its component
+// and backup type are left, the series of the latest completed backup are kept otherwise.
+func (r *BackupReconciler) deleteBackupMetrics(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) {
+	labels := map[string]string{
+		constant.AppInstanceLabelKey:    backup.Labels[constant.AppInstanceLabelKey],
+		constant.KBAppComponentLabelKey: backup.Labels[constant.KBAppComponentLabelKey],
+		dptypes.BackupTypeLabelKey:      backup.Labels[dptypes.BackupTypeLabelKey],
+	}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.List(reqCtx.Ctx, backupList, client.InNamespace(backup.Namespace), client.MatchingLabels(labels)); err != nil {
+		reqCtx.Log.Error(err, "failed to list the backups to delete the metrics")
+		return
+	}
+	for _, item := range backupList.Items {
+		if item.Name != backup.Name {
+			return
+		}
+	}
+	metrics.DeleteBackupMetrics(backup.Namespace, labels[constant.AppInstanceLabelKey],
+		labels[constant.KBAppComponentLabelKey], labels[dptypes.BackupTypeLabelKey])
+}
+
 // setConnectionPasswordAnnotation sets the encrypted password of the connection credential to the backup's annotations
 func setConnectionPasswordAnnotation(request *dpbackup.Request) error {
 	encryptPassword := func() (string, error) {
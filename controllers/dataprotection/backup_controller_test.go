@@ -30,8 +30,10 @@ import (
 	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -64,6 +66,7 @@ var _ = Describe("Backup Controller test", func() {
 
 		// namespaced
 		testapps.ClearResources(&testCtx, generics.ClusterSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.ComponentSignature, inNS, ml)
 		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
 
@@ -83,6 +86,7 @@ var _ = Describe("Backup Controller test", func() {
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupRepoSignature, true, ml)
 		testapps.ClearResources(&testCtx, generics.StorageProviderSignature, ml)
 		testapps.ClearResources(&testCtx, generics.VolumeSnapshotClassSignature, ml)
+		testapps.ClearResources(&testCtx, generics.ComponentDefinitionSignature, ml)
 	}
 
 	var clusterInfo *testdp.BackupClusterInfo
@@ -419,6 +423,39 @@ var _ = Describe("Backup Controller test", func() {
 			})
 		})
 
+		Context("creates a backup with compression", func() {
+			It("should run the backup with compression envs", func() {
+				By("set compressionConfig")
+				Expect(testapps.ChangeObj(&testCtx, backupPolicy, func(bp *dpv1alpha1.BackupPolicy) {
+					bp.Spec.CompressionConfig = &dpv1alpha1.CompressionConfig{
+						Algorithm: dpv1alpha1.CompressionAlgorithmZstd,
+						Level:     pointer.Int32(3),
+					}
+				})).Should(Succeed())
+
+				By("create a backup")
+				backup := testdp.NewFakeBackup(&testCtx, nil)
+
+				By("check the backup")
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseRunning))
+					g.Expect(fetched.Status.CompressionConfig).ShouldNot(BeNil())
+				})).Should(Succeed())
+
+				By("check the backup job")
+				jobKey := client.ObjectKey{
+					Name:      dpbackup.GenerateBackupJobName(backup, dpbackup.BackupDataJobNamePrefix+"-0"),
+					Namespace: backup.Namespace,
+				}
+				Eventually(testapps.CheckObj(&testCtx, jobKey, func(g Gomega, job *batchv1.Job) {
+					g.Expect(job.Spec.Template.Spec.Containers[0].Env).Should(ContainElements(
+						corev1.EnvVar{Name: dptypes.DPCompressionAlgorithm, Value: string(dpv1alpha1.CompressionAlgorithmZstd)},
+						corev1.EnvVar{Name: dptypes.DPCompressionLevel, Value: "3"},
+					))
+				})).Should(Succeed())
+			})
+		})
+
 		Context("deletes a backup", func() {
 			var (
 				backupKey types.NamespacedName
@@ -487,6 +524,161 @@ var _ = Describe("Backup Controller test", func() {
 
 				// TODO: add delete backup test case with the pvc not exists
 			})
+
+			It("should hold the protected backup until the protection is lifted", func() {
+				By("enabling the deletion protection")
+				Expect(testapps.ChangeObj(&testCtx, backup, func(b *dpv1alpha1.Backup) {
+					b.Spec.DeletionProtection = true
+				})).Should(Succeed())
+
+				By("deleting the backup object")
+				testapps.DeleteObject(&testCtx, backupKey, &dpv1alpha1.Backup{})
+
+				By("checking the backup, it should not be deleting")
+				jobKey := dpbackup.BuildDeleteBackupFilesJobKey(backup, false)
+				Consistently(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.DeletionTimestamp.IsZero()).Should(BeFalse())
+					g.Expect(fetched.Status.Phase).ShouldNot(Equal(dpv1alpha1.BackupPhaseDeleting))
+				})).Should(Succeed())
+				Eventually(testapps.CheckObjExists(&testCtx, jobKey, &batchv1.Job{}, false)).Should(Succeed())
+
+				By("lifting the deletion protection")
+				Expect(testapps.GetAndChangeObj(&testCtx, backupKey, func(b *dpv1alpha1.Backup) {
+					b.Spec.DeletionProtection = false
+				})()).Should(Succeed())
+
+				By("checking the backup, it should be deleting")
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseDeleting))
+				})).Should(Succeed())
+				Eventually(testapps.CheckObjExists(&testCtx, jobKey, &batchv1.Job{}, true)).Should(Succeed())
+			})
+		})
+
+		Context("creates an incremental backup", func() {
+			var (
+				parentBackup    *dpv1alpha1.Backup
+				parentBackupKey types.NamespacedName
+			)
+
+			getJobKey := func(backup *dpv1alpha1.Backup) client.ObjectKey {
+				return client.ObjectKey{
+					Name:      dpbackup.GenerateBackupJobName(backup, dpbackup.BackupDataJobNamePrefix+"-0"),
+					Namespace: backup.Namespace,
+				}
+			}
+
+			createIncrementalBackup := func(name, parentBackupName string) *dpv1alpha1.Backup {
+				return testdp.NewBackupFactory(testCtx.DefaultNamespace, name).
+					SetBackupPolicyName(testdp.BackupPolicyName).
+					SetBackupMethod(testdp.IncrementalBackupMethodName).
+					SetParentBackupName(parentBackupName).
+					Create(&testCtx).GetObject()
+			}
+
+			BeforeEach(func() {
+				By("creating an incremental actionSet")
+				testapps.CreateCustomizedObj(&testCtx, "backup/actionset.yaml", &dpv1alpha1.ActionSet{},
+					testapps.WithName(testdp.IncrementalActionSetName), func(as *dpv1alpha1.ActionSet) {
+						as.Spec.BackupType = dpv1alpha1.BackupTypeIncremental
+					})
+
+				By("adding an incremental backup method to the backupPolicy")
+				Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(backupPolicy), func(bp *dpv1alpha1.BackupPolicy) {
+					method := dputils.GetBackupMethodByName(testdp.BackupMethodName, bp).DeepCopy()
+					method.Name = testdp.IncrementalBackupMethodName
+					method.ActionSetName = testdp.IncrementalActionSetName
+					bp.Spec.BackupMethods = append(bp.Spec.BackupMethods, *method)
+				})).Should(Succeed())
+
+				By("creating a full backup as the parent backup")
+				parentBackup = testdp.NewFakeBackup(&testCtx, nil)
+				parentBackupKey = client.ObjectKeyFromObject(parentBackup)
+				testdp.PatchK8sJobStatus(&testCtx, getJobKey(parentBackup), batchv1.JobComplete)
+				Eventually(testapps.CheckObj(&testCtx, parentBackupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+				})).Should(Succeed())
+			})
+
+			It("should fail without parent backup", func() {
+				backup := createIncrementalBackup("incremental-backup", "")
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseFailed))
+				})).Should(Succeed())
+			})
+
+			It("should back up based on the parent backup and be deleted with it", func() {
+				backup := createIncrementalBackup("incremental-backup", parentBackup.Name)
+				backupKey := client.ObjectKeyFromObject(backup)
+
+				By("check backup job has the parent backup envs")
+				Eventually(testapps.CheckObj(&testCtx, getJobKey(backup), func(g Gomega, fetched *batchv1.Job) {
+					g.Expect(fetched.Spec.Template.Spec.Containers[0].Env).Should(ContainElements(
+						corev1.EnvVar{Name: dptypes.DPParentBackupName, Value: parentBackup.Name},
+						corev1.EnvVar{Name: dptypes.DPParentBackupBasePath,
+							Value: dpbackup.BuildBackupPath(parentBackup, backupPolicy.Spec.PathPrefix)},
+					))
+				})).Should(Succeed())
+
+				testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+					g.Expect(fetched.Labels[dptypes.BackupTypeLabelKey]).To(Equal(string(dpv1alpha1.BackupTypeIncremental)))
+				})).Should(Succeed())
+
+				By("deleting the parent backup, the incremental backup should be deleted too")
+				testapps.DeleteObject(&testCtx, parentBackupKey, &dpv1alpha1.Backup{})
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.DeletionTimestamp.IsZero()).Should(BeFalse())
+				})).Should(Succeed())
+			})
+		})
+
+		Context("verifies a completed backup", func() {
+			BeforeEach(func() {
+				By("adding a verify action to the actionSet")
+				Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKey{Name: testdp.ActionSetName}, func(as *dpv1alpha1.ActionSet) {
+					as.Spec.Backup.VerifyBackup = &dpv1alpha1.BaseJobActionSpec{
+						Image:   as.Spec.Backup.BackupData.Image,
+						Command: []string{"sh", "-c", "datasafed list /"},
+					}
+				})).Should(Succeed())
+
+				By("enabling the verification of the backupPolicy")
+				Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(backupPolicy), func(bp *dpv1alpha1.BackupPolicy) {
+					bp.Spec.Verification = &dpv1alpha1.BackupVerification{Interval: "7d"}
+				})).Should(Succeed())
+			})
+
+			It("should run the verify job and record the result", func() {
+				backup := testdp.NewFakeBackup(&testCtx, nil)
+				backupKey := client.ObjectKeyFromObject(backup)
+				testdp.PatchK8sJobStatus(&testCtx, client.ObjectKey{
+					Name:      dpbackup.GenerateBackupJobName(backup, dpbackup.BackupDataJobNamePrefix+"-0"),
+					Namespace: backup.Namespace,
+				}, batchv1.JobComplete)
+
+				By("check the verification is running")
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+					g.Expect(fetched.Status.Verification).ShouldNot(BeNil())
+					g.Expect(fetched.Status.Verification.Phase).To(Equal(dpv1alpha1.BackupVerificationPhaseRunning))
+				})).Should(Succeed())
+
+				verifyJobKey := dpbackup.BuildVerifyBackupJobKey(backup)
+				Eventually(testapps.CheckObj(&testCtx, verifyJobKey, func(g Gomega, fetched *batchv1.Job) {
+					g.Expect(fetched.Spec.Template.Spec.Containers[0].Env).Should(ContainElement(
+						corev1.EnvVar{Name: dptypes.DPBackupName, Value: backup.Name}))
+				})).Should(Succeed())
+
+				By("the verification should pass after the verify job completes")
+				testdp.PatchK8sJobStatus(&testCtx, verifyJobKey, batchv1.JobComplete)
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Verification.Phase).To(Equal(dpv1alpha1.BackupVerificationPhasePassed))
+					g.Expect(fetched.Status.Verification.CompletionTimestamp).ShouldNot(BeNil())
+				})).Should(Succeed())
+				Eventually(testapps.CheckObjExists(&testCtx, verifyJobKey, &batchv1.Job{}, false)).Should(Succeed())
+			})
 		})
 
 		Context("creates a snapshot backup", func() {
@@ -539,6 +731,86 @@ var _ = Describe("Backup Controller test", func() {
 			})
 		})
 
+		Context("creates a snapshot backup with snapshot hook", func() {
+			var (
+				backupKey types.NamespacedName
+				backup    *dpv1alpha1.Backup
+			)
+
+			getHookJobKey := func(prefix string) client.ObjectKey {
+				return client.ObjectKey{
+					Name:      dpbackup.GenerateBackupJobName(backup, prefix+"-0"),
+					Namespace: viper.GetString(constant.CfgKeyCtrlrMgrNS),
+				}
+			}
+
+			BeforeEach(func() {
+				// mock VolumeSnapshotClass for volume snapshot
+				testk8s.CreateVolumeSnapshotClass(&testCtx, testutil.DefaultCSIDriver)
+
+				By("declaring the snapshot hook in the component definition of the target component")
+				compDef := testapps.NewComponentDefinitionFactory(testdp.ComponentName).
+					SetDefaultSpec().
+					SetSnapshotHook(&appsv1alpha1.SnapshotHook{
+						Command: []string{"/scripts/flush-tables-with-read-lock.sh"},
+						Timeout: metav1.Duration{Duration: time.Minute},
+					}).
+					Create(&testCtx).
+					GetObject()
+				testapps.NewComponentFactory(testCtx.DefaultNamespace,
+					constant.GenerateClusterComponentName(testdp.ClusterName, testdp.ComponentName), compDef.Name).
+					Create(&testCtx)
+
+				By("create a backup from backupPolicy " + testdp.BackupPolicyName)
+				backup = testdp.NewFakeBackup(&testCtx, func(backup *dpv1alpha1.Backup) {
+					backup.Spec.BackupMethod = testdp.VSBackupMethodName
+				})
+				backupKey = client.ObjectKeyFromObject(backup)
+
+				By("checking the snapshot hook job is created without retries and with the timeout")
+				Eventually(testapps.CheckObj(&testCtx, getHookJobKey(dpbackup.SnapshotHookJobNamePrefix), func(g Gomega, fetched *batchv1.Job) {
+					g.Expect(*fetched.Spec.BackoffLimit).Should(BeEquivalentTo(0))
+					g.Expect(fetched.Spec.ActiveDeadlineSeconds).ShouldNot(BeNil())
+					g.Expect(*fetched.Spec.ActiveDeadlineSeconds).Should(BeEquivalentTo(60))
+				})).Should(Succeed())
+			})
+
+			It("should take the snapshot while the snapshot hook is holding the session", func() {
+				vsKey := client.ObjectKey{
+					Name:      dputils.GetBackupVolumeSnapshotName(backup.Name, "data"),
+					Namespace: backup.Namespace,
+				}
+				By("waiting for the target pod to be quiesced")
+				Eventually(testapps.CheckObjExists(&testCtx, getHookJobKey("dp-snapshotquiesced"), &batchv1.Job{}, true)).Should(Succeed())
+				Consistently(testapps.CheckObjExists(&testCtx, vsKey, &vsv1.VolumeSnapshot{}, false)).Should(Succeed())
+
+				By("taking the snapshot while the hook job is still running")
+				testdp.PatchK8sJobStatus(&testCtx, getHookJobKey("dp-snapshotquiesced"), batchv1.JobComplete)
+				Eventually(testapps.CheckObjExists(&testCtx, vsKey, &vsv1.VolumeSnapshot{}, true)).Should(Succeed())
+				testdp.PatchVolumeSnapshotStatus(&testCtx, vsKey, true)
+
+				By("resuming the target pod and waiting for the hook to exit")
+				testdp.PatchK8sJobStatus(&testCtx, getHookJobKey(dpbackup.SnapshotResumeJobNamePrefix), batchv1.JobComplete)
+				Consistently(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseRunning))
+				})).Should(Succeed())
+				testdp.PatchK8sJobStatus(&testCtx, getHookJobKey(dpbackup.SnapshotHookJobNamePrefix), batchv1.JobComplete)
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+				})).Should(Succeed())
+			})
+
+			It("should resume the target pod if the snapshot hook fails", func() {
+				testdp.PatchK8sJobStatus(&testCtx, getHookJobKey(dpbackup.SnapshotHookJobNamePrefix), batchv1.JobFailed)
+
+				Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseFailed))
+				})).Should(Succeed())
+				Eventually(testapps.CheckObjExists(&testCtx, getHookJobKey(dpbackup.SnapshotResumeJobNamePrefix),
+					&batchv1.Job{}, true)).Should(Succeed())
+			})
+		})
+
 		Context("creates a snapshot backup on error", func() {
 			var backupKey types.NamespacedName
 
@@ -717,6 +989,37 @@ var _ = Describe("Backup Controller test", func() {
 					g.Expect(backup.Status.PersistentVolumeClaimName).Should(BeEquivalentTo(repoPVCName2))
 				})).Should(Succeed())
 			})
+
+			It("should limit the bandwidth by the smaller one of the backup and the backup repo", func() {
+				By("setting the bandwidth limit of the backup repo")
+				Expect(testapps.ChangeObj(&testCtx, repo, func(repo *dpv1alpha1.BackupRepo) {
+					limit := resource.MustParse("50Mi")
+					repo.Spec.BandwidthLimit = &limit
+				})).Should(Succeed())
+
+				By("creating backup policy and backup")
+				_ = testdp.NewFakeBackupPolicy(&testCtx, nil)
+				backup := testdp.NewFakeBackup(&testCtx, func(backup *dpv1alpha1.Backup) {
+					limit := resource.MustParse("100Mi")
+					backup.Spec.BandwidthLimit = &limit
+				})
+
+				By("checking backup, it should use the bandwidth limit of the backup repo")
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, backup *dpv1alpha1.Backup) {
+					g.Expect(backup.Status.BandwidthLimit).ShouldNot(BeNil())
+					g.Expect(backup.Status.BandwidthLimit.String()).Should(Equal("50Mi"))
+				})).Should(Succeed())
+
+				By("checking the backup job, it should have the bandwidth limit env")
+				jobKey := client.ObjectKey{
+					Name:      dpbackup.GenerateBackupJobName(backup, dpbackup.BackupDataJobNamePrefix+"-0"),
+					Namespace: backup.Namespace,
+				}
+				Eventually(testapps.CheckObj(&testCtx, jobKey, func(g Gomega, job *batchv1.Job) {
+					g.Expect(job.Spec.Template.Spec.Containers[0].Env).Should(ContainElement(
+						corev1.EnvVar{Name: dptypes.DPBandwidthLimit, Value: "52428800"}))
+				})).Should(Succeed())
+			})
 		})
 
 		Context("default backup repo", func() {
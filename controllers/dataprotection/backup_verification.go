@@ -0,0 +1,206 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
+)
+
+// verifyBackup verifies the completed backup periodically if the verification of
+// its backup policy is enabled. Only the latest completed backup of each backup
+// method will be verified, and the result is recorded in the backup status.
+func (r *BackupReconciler) verifyBackup(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	policyKey := client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.BackupPolicyName}
+	if err := r.Client.Get(reqCtx.Ctx, policyKey, backupPolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return intctrlutil.Reconciled()
+		}
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	verification := backupPolicy.Spec.Verification
+	running := backup.Status.Verification != nil &&
+		backup.Status.Verification.Phase == dpv1alpha1.BackupVerificationPhaseRunning
+	if verification == nil && !running {
+		return intctrlutil.Reconciled()
+	}
+
+	actionSet, err := r.getVerifyActionSet(reqCtx, backup)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if actionSet == nil {
+		return intctrlutil.Reconciled()
+	}
+	if running {
+		return r.checkVerification(reqCtx, backup, actionSet)
+	}
+
+	latestBackup, lastVerifiedTime, err := r.getLatestBackupToVerify(reqCtx, backup)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if latestBackup == nil || latestBackup.Name != backup.Name {
+		return intctrlutil.Reconciled()
+	}
+	interval, err := verification.Interval.ToDuration()
+	if err != nil {
+		r.Recorder.Eventf(backup, corev1.EventTypeWarning, "VerifyBackupFailed",
+			"failed to parse verification interval %s of backup policy %s: %s", verification.Interval, backupPolicy.Name, err.Error())
+		return intctrlutil.Reconciled()
+	}
+	now := r.clock.Now()
+	if lastVerifiedTime != nil {
+		// without an interval, the backup is verified only once.
+		if interval == 0 && backup.Status.Verification != nil {
+			return intctrlutil.Reconciled()
+		}
+		if next := lastVerifiedTime.Add(interval); now.Before(next) {
+			return intctrlutil.RequeueAfter(next.Sub(now), reqCtx.Log, "wait for the next verification")
+		}
+	}
+
+	// mark the verification running, the verification job will be created in
+	// the next reconciliation.
+	patch := client.MergeFrom(backup.DeepCopy())
+	backup.Status.Verification = &dpv1alpha1.BackupVerificationStatus{
+		Phase:          dpv1alpha1.BackupVerificationPhaseRunning,
+		StartTimestamp: &metav1.Time{Time: now.UTC()},
+	}
+	if err = r.Client.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	r.Recorder.Event(backup, corev1.EventTypeNormal, "VerifyBackup", "start to verify the backup")
+	return intctrlutil.Reconciled()
+}
+
+// checkVerification creates the verification job if it does not exist, and
+// records the result to the backup status after the job finished.
+func (r *BackupReconciler) checkVerification(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup,
+	actionSet *dpv1alpha1.ActionSet) (ctrl.Result, error) {
+	verifier := &dpbackup.Verifier{
+		RequestCtx: reqCtx,
+		Client:     r.Client,
+		Scheme:     r.Scheme,
+	}
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace)
+	if err != nil {
+		return RecorderEventAndRequeue(reqCtx, r.Recorder, backup, err)
+	}
+	verifier.WorkerServiceAccount = saName
+
+	finished, verifyErr := verifier.VerifyBackup(backup, actionSet)
+	if !finished {
+		if verifyErr != nil {
+			return RecorderEventAndRequeue(reqCtx, r.Recorder, backup, verifyErr)
+		}
+		// wait for the verification job finished
+		return intctrlutil.Reconciled()
+	}
+
+	now := r.clock.Now()
+	patch := client.MergeFrom(backup.DeepCopy())
+	backup.Status.Verification.CompletionTimestamp = &metav1.Time{Time: now.UTC()}
+	passed := float64(1)
+	if verifyErr != nil {
+		passed = 0
+		backup.Status.Verification.Phase = dpv1alpha1.BackupVerificationPhaseFailed
+		backup.Status.Verification.FailureReason = verifyErr.Error()
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "VerifyBackupFailed", verifyErr.Error())
+	} else {
+		backup.Status.Verification.Phase = dpv1alpha1.BackupVerificationPhasePassed
+		backup.Status.Verification.FailureReason = ""
+		r.Recorder.Event(backup, corev1.EventTypeNormal, "VerifyBackupPassed", "the backup is verified")
+	}
+	if err = r.Client.Status().Patch(reqCtx.Ctx, backup, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	labels := []string{backup.Namespace, backup.Spec.BackupPolicyName, backup.Spec.BackupMethod}
+	metrics.BackupVerificationPassed.WithLabelValues(labels...).Set(passed)
+	metrics.BackupVerificationTimestamp.WithLabelValues(labels...).Set(float64(now.Unix()))
+
+	if err = verifier.DeleteVerifyBackupJob(backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// getVerifyActionSet gets the actionSet of the backup if it defines the verify action.
+func (r *BackupReconciler) getVerifyActionSet(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (*dpv1alpha1.ActionSet, error) {
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod == nil || backupMethod.ActionSetName == "" || backup.Status.BackupRepoName == "" {
+		return nil, nil
+	}
+	actionSet, err := dputils.GetActionSetByName(reqCtx, r.Client, backupMethod.ActionSetName)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if actionSet.Spec.BackupType == dpv1alpha1.BackupTypeContinuous ||
+		actionSet.Spec.Backup == nil || actionSet.Spec.Backup.VerifyBackup == nil {
+		return nil, nil
+	}
+	return actionSet, nil
+}
+
+// getLatestBackupToVerify gets the latest completed backup with the same backup
+// policy and backup method as the specified backup, and the latest time these
+// backups were verified.
+func (r *BackupReconciler) getLatestBackupToVerify(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (*dpv1alpha1.Backup, *metav1.Time, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(backup.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backup.Spec.BackupPolicyName}); err != nil {
+		return nil, nil, err
+	}
+	var (
+		latestBackup     *dpv1alpha1.Backup
+		lastVerifiedTime *metav1.Time
+	)
+	for i := range backupList.Items {
+		item := &backupList.Items[i]
+		if item.Spec.BackupMethod != backup.Spec.BackupMethod ||
+			item.Status.Phase != dpv1alpha1.BackupPhaseCompleted ||
+			!item.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if latestBackup == nil || getBackupStartTime(item).After(getBackupStartTime(latestBackup)) ||
+			(getBackupStartTime(item).Equal(getBackupStartTime(latestBackup)) && item.Name > latestBackup.Name) {
+			latestBackup = item
+		}
+		if v := item.Status.Verification; v != nil && v.StartTimestamp != nil &&
+			(lastVerifiedTime == nil || lastVerifiedTime.Before(v.StartTimestamp)) {
+			lastVerifiedTime = v.StartTimestamp
+		}
+	}
+	return latestBackup, lastVerifiedTime, nil
+}
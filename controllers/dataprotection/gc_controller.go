@@ -21,6 +21,9 @@ package dataprotection
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"sort"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -35,6 +38,7 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -70,6 +74,7 @@ func (r *GCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // delete expired backups.
@@ -96,26 +101,158 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 		return intctrlutil.Reconciled()
 	}
 
+	// backup is protected from deletion, skip
+	if backup.Spec.DeletionProtection {
+		reqCtx.Log.V(1).Info("backup is protected from deletion, skipping")
+		return intctrlutil.Reconciled()
+	}
+
 	reqCtx.Log.V(1).Info("gc reconcile", "backup", req.String(),
 		"phase", backup.Status.Phase, "expiration", backup.Status.Expiration)
 	reqCtx.Log = reqCtx.Log.WithValues("expiration", backup.Status.Expiration)
 
 	now := r.clock.Now()
-	if backup.Status.Expiration == nil || backup.Status.Expiration.After(now) {
+	reason, err := r.checkExpired(reqCtx, backup, now)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if reason == "" {
 		reqCtx.Log.V(1).Info("backup is not expired yet, skipping")
 		return intctrlutil.Reconciled()
 	}
 
-	reqCtx.Log.Info("backup has expired, delete it", "backup", req.String())
+	// deleting the backup will invalidate the backups based on it, retain it
+	// until all the dependent backups are expired.
+	if retained, err := r.hasUnexpiredDependents(reqCtx, backup, now); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	} else if retained {
+		reqCtx.Log.V(1).Info("backup has unexpired dependent backups, skipping")
+		return intctrlutil.Reconciled()
+	}
+
+	reqCtx.Log.Info("backup has expired, delete it", "backup", req.String(), "reason", reason)
 	if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, backup); err != nil {
 		reqCtx.Log.Error(err, "failed to delete backup")
 		r.Recorder.Event(backup, corev1.EventTypeWarning, "RemoveExpiredBackupsFailed", err.Error())
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
+	r.Recorder.Eventf(backup, corev1.EventTypeNormal, "RemoveExpiredBackup", "delete the backup, %s", reason)
 
 	return intctrlutil.Reconciled()
 }
 
+// checkExpired checks whether the backup is expired by its expiration time or
+// the retention of its backup policy, and returns the reason if it is expired.
+func (r *GCReconciler) checkExpired(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup, now time.Time) (string, error) {
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	if err := r.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.BackupPolicyName}, backupPolicy); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		backupPolicy = nil
+	}
+	var retention *dpv1alpha1.BackupRetention
+	if backupPolicy != nil {
+		retention = backupPolicy.Spec.Retention
+	}
+
+	// the completed backups of the same backup policy, sorted from the newest to
+	// the oldest, continuous backups are not limited by the retention.
+	var policyBackups []*dpv1alpha1.Backup
+	if retention != nil {
+		backupList := &dpv1alpha1.BackupList{}
+		if err := r.List(reqCtx.Ctx, backupList, client.InNamespace(backup.Namespace)); err != nil {
+			return "", err
+		}
+		for i, item := range backupList.Items {
+			if item.Spec.BackupPolicyName != backup.Spec.BackupPolicyName ||
+				item.Status.Phase != dpv1alpha1.BackupPhaseCompleted ||
+				item.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) ||
+				!item.DeletionTimestamp.IsZero() {
+				continue
+			}
+			policyBackups = append(policyBackups, &backupList.Items[i])
+		}
+		sort.SliceStable(policyBackups, func(i, j int) bool {
+			startI, startJ := getBackupStartTime(policyBackups[i]), getBackupStartTime(policyBackups[j])
+			if startI.Equal(startJ) {
+				return policyBackups[i].Name > policyBackups[j].Name
+			}
+			return startI.After(startJ)
+		})
+	}
+	index := slices.IndexFunc(policyBackups, func(b *dpv1alpha1.Backup) bool {
+		return b.Name == backup.Name
+	})
+
+	reason, err := func() (string, error) {
+		if backup.Status.Expiration != nil && !backup.Status.Expiration.After(now) {
+			return fmt.Sprintf("it is expired at %s", backup.Status.Expiration.UTC().Format(time.RFC3339)), nil
+		}
+		if index < 0 {
+			return "", nil
+		}
+		if retention.MaxCount != nil && index >= int(*retention.MaxCount) {
+			return fmt.Sprintf("it exceeds the max count %d of backup policy %s",
+				*retention.MaxCount, backupPolicy.Name), nil
+		}
+		maxAge, err := retention.MaxAge.ToDuration()
+		if err != nil {
+			return "", fmt.Errorf("failed to parse max age %s of backup policy %s: %w", retention.MaxAge, backupPolicy.Name, err)
+		}
+		if maxAge > 0 && now.Sub(getBackupStartTime(backup)) > maxAge {
+			return fmt.Sprintf("it exceeds the max age %s of backup policy %s", retention.MaxAge, backupPolicy.Name), nil
+		}
+		return "", nil
+	}()
+	if err != nil || reason == "" {
+		return "", err
+	}
+
+	// retain the latest completed full backup if required.
+	if index >= 0 && retention.KeepLastFull && backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeFull) {
+		latestFull := slices.IndexFunc(policyBackups, func(b *dpv1alpha1.Backup) bool {
+			return b.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeFull)
+		})
+		if latestFull == index {
+			reqCtx.Log.V(1).Info("retain the latest full backup", "reason", reason)
+			return "", nil
+		}
+	}
+	return reason, nil
+}
+
+// hasUnexpiredDependents checks whether any backup based on the specified backup,
+// directly or indirectly, is not expired yet or is protected from deletion.
+func (r *GCReconciler) hasUnexpiredDependents(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup, now time.Time) (bool, error) {
+	dependents, err := dpbackup.GetDependentBackups(reqCtx.Ctx, r.Client, backup)
+	if err != nil {
+		return false, err
+	}
+	for _, dependent := range dependents {
+		if !dependent.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if dependent.Spec.DeletionProtection {
+			return true, nil
+		}
+		if reason, err := r.checkExpired(reqCtx, dependent, now); err != nil || reason == "" {
+			return err == nil, err
+		}
+		if retained, err := r.hasUnexpiredDependents(reqCtx, dependent, now); err != nil || retained {
+			return retained, err
+		}
+	}
+	return false, nil
+}
+
+func getBackupStartTime(backup *dpv1alpha1.Backup) time.Time {
+	if backup.Status.StartTimestamp != nil {
+		return backup.Status.StartTimestamp.Time
+	}
+	return backup.CreationTimestamp.Time
+}
+
 func getGCFrequency() time.Duration {
 	gcFrequencySeconds := viper.GetInt(dptypes.CfgKeyGCFrequencySeconds)
 	if gcFrequencySeconds > 0 {
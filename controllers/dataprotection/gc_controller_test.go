@@ -27,6 +27,7 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
@@ -172,5 +173,132 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 			Eventually(testapps.CheckObjExists(&testCtx, backup1Key, &dpv1alpha1.Backup{}, true)).Should(Succeed())
 			Eventually(testapps.CheckObjExists(&testCtx, expiredKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 		})
+
+		It("retain expired backups which are protected from deletion", func() {
+			By("create a protected backup")
+			backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, backupNamePrefix+"protected").
+				WithRandomName().
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				SetBackupMethod(testdp.BackupMethodName).
+				Apply(func(backup *dpv1alpha1.Backup) {
+					backup.Spec.DeletionProtection = true
+				}).
+				Create(&testCtx).GetObject()
+			backupKey := client.ObjectKeyFromObject(backup)
+
+			By("waiting backup completed")
+			testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+			Eventually(testapps.CheckObj(&testCtx, backupKey,
+				func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+				})).Should(Succeed())
+
+			By("mock backup status to expire")
+			expiration := metav1.Time{Time: fakeClock.Now().Add(-time.Hour * 24)}
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, backupKey, func(fetched *dpv1alpha1.Backup) {
+				fetched.Status.Expiration = &expiration
+				fetched.Status.StartTimestamp = &expiration
+			})()).Should(Succeed())
+
+			By("retain the protected backup")
+			Consistently(testapps.CheckObjExists(&testCtx, backupKey, &dpv1alpha1.Backup{}, true)).Should(Succeed())
+
+			By("lift the deletion protection, the backup should be deleted")
+			Expect(testapps.GetAndChangeObj(&testCtx, backupKey, func(fetched *dpv1alpha1.Backup) {
+				fetched.Spec.DeletionProtection = false
+			})()).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, backupKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
+		})
+
+		Context("with the retention of backup policy", func() {
+			var backups []*dpv1alpha1.Backup
+
+			setRetention := func(retention *dpv1alpha1.BackupRetention) {
+				Eventually(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(backupPolicy), func(bp *dpv1alpha1.BackupPolicy) {
+					bp.Spec.Retention = retention
+				})).Should(Succeed())
+			}
+
+			BeforeEach(func() {
+				By("create completed backups started at different time")
+				backups = nil
+				for i := 0; i < 2; i++ {
+					backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, backupNamePrefix).
+						WithRandomName().
+						SetBackupPolicyName(testdp.BackupPolicyName).
+						SetBackupMethod(testdp.BackupMethodName).
+						Create(&testCtx).GetObject()
+					testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+					Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup),
+						func(g Gomega, fetched *dpv1alpha1.Backup) {
+							g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+						})).Should(Succeed())
+					startTime := metav1.Time{Time: fakeClock.Now().Add(time.Duration(i-3) * time.Hour * 24)}
+					testdp.PatchBackupStatus(&testCtx, client.ObjectKeyFromObject(backup), dpv1alpha1.BackupStatus{
+						Phase:               dpv1alpha1.BackupPhaseCompleted,
+						StartTimestamp:      &startTime,
+						CompletionTimestamp: &startTime,
+					})
+					backups = append(backups, backup)
+				}
+			})
+
+			It("delete the backups exceeding the max count", func() {
+				setRetention(&dpv1alpha1.BackupRetention{MaxCount: pointer.Int32(1)})
+				Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(backups[0]), &dpv1alpha1.Backup{}, false)).Should(Succeed())
+				Consistently(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(backups[1]), &dpv1alpha1.Backup{}, true)).Should(Succeed())
+			})
+
+			It("delete the backups exceeding the max age except the latest full backup", func() {
+				setRetention(&dpv1alpha1.BackupRetention{MaxAge: "1d", KeepLastFull: true})
+				Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(backups[0]), &dpv1alpha1.Backup{}, false)).Should(Succeed())
+				Consistently(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(backups[1]), &dpv1alpha1.Backup{}, true)).Should(Succeed())
+			})
+		})
+
+		It("retain expired backups which have unexpired dependent backups", func() {
+			createBackup := func(name, parentBackupName string) *dpv1alpha1.Backup {
+				return testdp.NewBackupFactory(testCtx.DefaultNamespace, name).
+					WithRandomName().
+					SetBackupPolicyName(testdp.BackupPolicyName).
+					SetBackupMethod(testdp.BackupMethodName).
+					SetParentBackupName(parentBackupName).
+					Create(&testCtx).GetObject()
+			}
+
+			setBackupExpiration := func(backup *dpv1alpha1.Backup, expiration time.Time) {
+				startTime := metav1.Time{Time: expiration.Add(-time.Hour)}
+				testdp.PatchBackupStatus(&testCtx, client.ObjectKeyFromObject(backup), dpv1alpha1.BackupStatus{
+					Phase:               dpv1alpha1.BackupPhaseCompleted,
+					Expiration:          &metav1.Time{Time: expiration},
+					StartTimestamp:      &startTime,
+					CompletionTimestamp: &startTime,
+				})
+			}
+
+			By("create a base backup and a backup based on it")
+			baseBackup := createBackup(backupNamePrefix+"base", "")
+			dependentBackup := createBackup(backupNamePrefix+"dependent", baseBackup.Name)
+			for _, backup := range []*dpv1alpha1.Backup{baseBackup, dependentBackup} {
+				testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup),
+					func(g Gomega, fetched *dpv1alpha1.Backup) {
+						g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+					})).Should(Succeed())
+			}
+
+			By("mock the base backup to expire and the dependent backup not to expire")
+			setBackupExpiration(dependentBackup, fakeClock.Now().Add(time.Hour*24))
+			setBackupExpiration(baseBackup, fakeClock.Now().Add(-time.Hour*24))
+
+			By("retain the expired base backup")
+			baseKey := client.ObjectKeyFromObject(baseBackup)
+			Consistently(testapps.CheckObjExists(&testCtx, baseKey, &dpv1alpha1.Backup{}, true)).Should(Succeed())
+
+			By("mock the dependent backup to expire, both backups should be deleted")
+			setBackupExpiration(dependentBackup, fakeClock.Now().Add(-time.Hour))
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(dependentBackup), &dpv1alpha1.Backup{}, false)).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, baseKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
+		})
 	})
 })
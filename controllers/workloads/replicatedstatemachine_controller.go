@@ -27,17 +27,23 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlhandler "sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/handler"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/rsm"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -61,6 +67,10 @@ type ReplicatedStateMachineReconciler struct {
 // +kubebuilder:rbac:groups=apps,resources=statefulsets/status,verbs=get
 // +kubebuilder:rbac:groups=apps,resources=statefulsets/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 // TODO(user): Modify the Reconcile function to compare the state specified by
@@ -71,6 +81,9 @@ type ReplicatedStateMachineReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.1/pkg/reconcile
 func (r *ReplicatedStateMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcileSpan(ctx, "rsm", req)
+	defer span.End()
+
 	reqCtx := intctrlutil.RequestCtx{
 		Ctx:      ctx,
 		Req:      req,
@@ -125,6 +138,8 @@ func (r *ReplicatedStateMachineReconciler) Reconcile(ctx context.Context, req ct
 			&rsm.UpdateStrategyTransformer{},
 			// handle member reconfiguration
 			&rsm.MemberReconfigurationTransformer{},
+			// switchover proactively if the leader's node is cordoned
+			&rsm.NodeDrainTransformer{},
 			// always safe to put your transformer below
 		).
 		Build()
@@ -152,6 +167,9 @@ func (r *ReplicatedStateMachineReconciler) SetupWithManager(mgr ctrl.Manager) er
 		Reader:  r.Client,
 		Scheme:  *r.Scheme,
 	}
+	// only the changes of roles, readiness, revisions and specs of the pods matter to the RSM.
+	podPredicate := intctrlutil.NewPodChangedPredicate(constant.RoleLabelKey, rsm.RSMAccessModeLabelKey,
+		constant.ReadyWithoutPrimaryKey, appsv1.ControllerRevisionHashLabelKey)
 
 	if viper.GetBool(rsm.FeatureGateRSMCompatibilityMode) {
 		nameLabels := []string{constant.AppInstanceLabelKey, constant.KBAppComponentLabelKey}
@@ -165,11 +183,14 @@ func (r *ReplicatedStateMachineReconciler) SetupWithManager(mgr ctrl.Manager) er
 			For(&workloads.ReplicatedStateMachine{}).
 			WithOptions(controller.Options{
 				MaxConcurrentReconciles: viper.GetInt(constant.CfgKBReconcileWorkers),
+				RateLimiter:             intctrlutil.NewReconcileRateLimiter(),
 			}).
 			Watches(&appsv1.StatefulSet{}, stsHandler).
 			Watches(&batchv1.Job{}, jobHandler).
-			Watches(&corev1.Pod{}, podHandler).
-			Owns(&corev1.Pod{}).
+			Watches(&corev1.Pod{}, podHandler, builder.WithPredicates(podPredicate)).
+			Watches(&corev1.Node{}, ctrlhandler.EnqueueRequestsFromMapFunc(r.cordonedNodeToRSMs),
+				builder.WithPredicates(intctrlutil.NodeSchedulableChangedPredicate)).
+			Owns(&corev1.Pod{}, builder.WithPredicates(podPredicate)).
 			Complete(r)
 	}
 
@@ -182,9 +203,12 @@ func (r *ReplicatedStateMachineReconciler) SetupWithManager(mgr ctrl.Manager) er
 			For(&workloads.ReplicatedStateMachine{}).
 			WithOptions(controller.Options{
 				MaxConcurrentReconciles: viper.GetInt(constant.CfgKBReconcileWorkers),
+				RateLimiter:             intctrlutil.NewReconcileRateLimiter(),
 			}).
 			Watches(&batchv1.Job{}, jobHandler).
-			Watches(&corev1.Pod{}, podHandler).
+			Watches(&corev1.Pod{}, podHandler, builder.WithPredicates(podPredicate)).
+			Watches(&corev1.Node{}, ctrlhandler.EnqueueRequestsFromMapFunc(r.cordonedNodeToRSMs),
+				builder.WithPredicates(intctrlutil.NodeSchedulableChangedPredicate)).
 			Complete(r)
 	}
 
@@ -195,9 +219,40 @@ func (r *ReplicatedStateMachineReconciler) SetupWithManager(mgr ctrl.Manager) er
 		For(&workloads.ReplicatedStateMachine{}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: viper.GetInt(constant.CfgKBReconcileWorkers),
+			RateLimiter:             intctrlutil.NewReconcileRateLimiter(),
 		}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&batchv1.Job{}).
-		Watches(&corev1.Pod{}, podHandler).
+		Watches(&corev1.Pod{}, podHandler, builder.WithPredicates(podPredicate)).
+		Watches(&corev1.Node{}, ctrlhandler.EnqueueRequestsFromMapFunc(r.cordonedNodeToRSMs),
+			builder.WithPredicates(intctrlutil.NodeSchedulableChangedPredicate)).
 		Complete(r)
 }
+
+// cordonedNodeToRSMs maps a cordoned node to the RSMs which have role-labeled pods on it.
+func (r *ReplicatedStateMachineReconciler) cordonedNodeToRSMs(ctx context.Context, obj client.Object) []reconcile.Request {
+	node, ok := obj.(*corev1.Node)
+	if !ok || !node.Spec.Unschedulable {
+		return nil
+	}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(ctx, podList, client.HasLabels{constant.RoleLabelKey}); err != nil {
+		return nil
+	}
+	keys := sets.New[types.NamespacedName]()
+	for i, pod := range podList.Items {
+		if pod.Spec.NodeName != node.Name || !intctrlutil.InCurrentShard(&podList.Items[i]) {
+			continue
+		}
+		for _, owner := range pod.OwnerReferences {
+			if owner.Kind == "StatefulSet" || owner.Kind == workloads.ReplicatedStateMachineKind {
+				keys.Insert(types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name})
+			}
+		}
+	}
+	var requests []reconcile.Request
+	for key := range keys {
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return requests
+}
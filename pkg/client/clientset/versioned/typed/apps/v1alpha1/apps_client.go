@@ -37,6 +37,7 @@ type AppsV1alpha1Interface interface {
 	ComponentDefinitionsGetter
 	ComponentResourceConstraintsGetter
 	ConfigConstraintsGetter
+	DatabaseUsersGetter
 	OpsDefinitionsGetter
 	OpsRequestsGetter
 	ServiceDescriptorsGetter
@@ -83,6 +84,10 @@ func (c *AppsV1alpha1Client) ConfigConstraints() ConfigConstraintInterface {
 	return newConfigConstraints(c)
 }
 
+func (c *AppsV1alpha1Client) DatabaseUsers(namespace string) DatabaseUserInterface {
+	return newDatabaseUsers(c, namespace)
+}
+
 func (c *AppsV1alpha1Client) OpsDefinitions() OpsDefinitionInterface {
 	return newOpsDefinitions(c)
 }
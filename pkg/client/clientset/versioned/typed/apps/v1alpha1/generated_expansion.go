@@ -36,6 +36,8 @@ type ComponentResourceConstraintExpansion interface{}
 
 type ConfigConstraintExpansion interface{}
 
+type DatabaseUserExpansion interface{}
+
 type OpsDefinitionExpansion interface{}
 
 type OpsRequestExpansion interface{}
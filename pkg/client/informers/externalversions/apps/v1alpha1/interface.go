@@ -42,6 +42,8 @@ type Interface interface {
 	ComponentResourceConstraints() ComponentResourceConstraintInformer
 	// ConfigConstraints returns a ConfigConstraintInformer.
 	ConfigConstraints() ConfigConstraintInformer
+	// DatabaseUsers returns a DatabaseUserInformer.
+	DatabaseUsers() DatabaseUserInformer
 	// OpsDefinitions returns a OpsDefinitionInformer.
 	OpsDefinitions() OpsDefinitionInformer
 	// OpsRequests returns a OpsRequestInformer.
@@ -106,6 +108,11 @@ func (v *version) ConfigConstraints() ConfigConstraintInformer {
 	return &configConstraintInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// DatabaseUsers returns a DatabaseUserInformer.
+func (v *version) DatabaseUsers() DatabaseUserInformer {
+	return &databaseUserInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // OpsDefinitions returns a OpsDefinitionInformer.
 func (v *version) OpsDefinitions() OpsDefinitionInformer {
 	return &opsDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -62,6 +62,14 @@ type ComponentResourceConstraintListerExpansion interface{}
 // ConfigConstraintLister.
 type ConfigConstraintListerExpansion interface{}
 
+// DatabaseUserListerExpansion allows custom methods to be added to
+// DatabaseUserLister.
+type DatabaseUserListerExpansion interface{}
+
+// DatabaseUserNamespaceListerExpansion allows custom methods to be added to
+// DatabaseUserNamespaceLister.
+type DatabaseUserNamespaceListerExpansion interface{}
+
 // OpsDefinitionListerExpansion allows custom methods to be added to
 // OpsDefinitionLister.
 type OpsDefinitionListerExpansion interface{}
@@ -22,6 +22,7 @@ package core
 import (
 	"encoding/json"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/StudioSol/set"
@@ -318,6 +319,72 @@ func GenerateVisualizedParamsList(configPatch *ConfigPatchInfo, formatConfig *ap
 	return r
 }
 
+const redactedParameterValue = "******"
+
+// GenerateParameterDiffs generates the parameter-level diffs with the old and new values from the config patch,
+// the values of the sensitive parameters are redacted.
+func GenerateParameterDiffs(configPatch *ConfigPatchInfo, formatConfig *appsv1alpha1.FormatterConfig, sensitiveParameters []string) []appsv1alpha1.ParameterDiff {
+	if configPatch == nil || !configPatch.IsModify {
+		return nil
+	}
+
+	var (
+		trimPrefix = NestedPrefixField(formatConfig)
+		sensitive  = set.NewLinkedHashSetString(sensitiveParameters...)
+		diffs      = make([]appsv1alpha1.ParameterDiff, 0)
+	)
+	redact := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		return util.ToPointer(redactedParameterValue)
+	}
+	for _, param := range GenerateVisualizedParamsList(configPatch, formatConfig, nil) {
+		lastValues := lastParameterValues(configPatch.LastVersion, param.Key, trimPrefix)
+		for _, pair := range param.Parameters {
+			diff := appsv1alpha1.ParameterDiff{File: param.Key, Key: pair.Key}
+			switch {
+			case param.UpdateType == AddedType:
+				diff.Operation, diff.NewValue = appsv1alpha1.ParameterAdded, pair.Value
+			case param.UpdateType == DeletedType:
+				diff.Operation, diff.OldValue = appsv1alpha1.ParameterDeleted, pair.Value
+			case pair.Value == nil:
+				diff.Operation, diff.OldValue = appsv1alpha1.ParameterDeleted, lastValues[pair.Key]
+			case lastValues[pair.Key] == nil:
+				diff.Operation, diff.NewValue = appsv1alpha1.ParameterAdded, pair.Value
+			default:
+				diff.Operation, diff.OldValue, diff.NewValue = appsv1alpha1.ParameterUpdated, lastValues[pair.Key], pair.Value
+			}
+			if sensitive.InArray(pair.Key) {
+				diff.OldValue, diff.NewValue = redact(diff.OldValue), redact(diff.NewValue)
+			}
+			diffs = append(diffs, diff)
+		}
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].File != diffs[j].File {
+			return diffs[i].File < diffs[j].File
+		}
+		return diffs[i].Key < diffs[j].Key
+	})
+	return diffs
+}
+
+func lastParameterValues(lastVersion *cfgWrapper, file string, trimPrefix string) map[string]*string {
+	values := make(map[string]*string)
+	if lastVersion == nil {
+		return values
+	}
+	configObject, ok := lastVersion.indexer[file]
+	if !ok {
+		return values
+	}
+	for _, pair := range checkAndFlattenMap(configObject.GetAllParameters(), trimPrefix) {
+		values[pair.Key] = pair.Value
+	}
+	return values
+}
+
 func generateUpdateParam(updatedParams map[string][]byte, trimPrefix string, sets *set.LinkedHashSetString) []VisualizedParam {
 	r := make([]VisualizedParam, 0, len(updatedParams))
 
@@ -301,3 +301,44 @@ func sortParams(param []VisualizedParam) {
 		})
 	}
 }
+
+func TestGenerateParameterDiffs(t *testing.T) {
+	formatConfig := &appsv1alpha1.FormatterConfig{
+		Format: appsv1alpha1.Ini,
+		FormatterOptions: appsv1alpha1.FormatterOptions{IniConfig: &appsv1alpha1.IniConfig{
+			SectionName: "mysqld",
+		}},
+	}
+	configPatch, _, err := CreateConfigPatch(map[string]string{
+		"my.cnf": "[mysqld]\nmax_connections=100\nread_buffer_size=1024\npassword=old\n",
+	}, map[string]string{
+		"my.cnf": "[mysqld]\nmax_connections=200\nsort_buffer_size=2048\npassword=new\n",
+	}, appsv1alpha1.Ini, nil, false)
+	require.Nil(t, err)
+
+	require.Equal(t, []appsv1alpha1.ParameterDiff{{
+		File:      "my.cnf",
+		Key:       "max_connections",
+		Operation: appsv1alpha1.ParameterUpdated,
+		OldValue:  util.ToPointer("100"),
+		NewValue:  util.ToPointer("200"),
+	}, {
+		File:      "my.cnf",
+		Key:       "password",
+		Operation: appsv1alpha1.ParameterUpdated,
+		OldValue:  util.ToPointer(redactedParameterValue),
+		NewValue:  util.ToPointer(redactedParameterValue),
+	}, {
+		File:      "my.cnf",
+		Key:       "read_buffer_size",
+		Operation: appsv1alpha1.ParameterDeleted,
+		OldValue:  util.ToPointer("1024"),
+	}, {
+		File:      "my.cnf",
+		Key:       "sort_buffer_size",
+		Operation: appsv1alpha1.ParameterAdded,
+		NewValue:  util.ToPointer("2048"),
+	}}, GenerateParameterDiffs(configPatch, formatConfig, []string{"password"}))
+
+	require.Nil(t, GenerateParameterDiffs(&ConfigPatchInfo{IsModify: false}, formatConfig, nil))
+}
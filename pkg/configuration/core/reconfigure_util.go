@@ -134,6 +134,23 @@ func IsUpdateDynamicParameters(cc *appsv1alpha1.ConfigConstraintSpec, cfg *Confi
 	return false, nil
 }
 
+// ValidateImmutableParameters verifies that none of the immutable parameters in the ConfigConstraint are updated
+func ValidateImmutableParameters(cc *appsv1alpha1.ConfigConstraintSpec, cfg *ConfigPatchInfo) error {
+	if cfg == nil || len(cc.ImmutableParameters) == 0 || len(cfg.UpdateConfig) == 0 {
+		return nil
+	}
+
+	updatedParams, err := getUpdateParameterList(cfg, NestedPrefixField(cc.FormatterConfig))
+	if err != nil {
+		return err
+	}
+	immutableParams := util.Union(util.NewSet(cc.ImmutableParameters...), util.NewSet(updatedParams...))
+	if immutableParams.Length() > 0 {
+		return MakeError("immutable parameters cannot be updated: %v", immutableParams.AsSlice())
+	}
+	return nil
+}
+
 // IsDynamicParameter checks if the parameter supports hot update
 func IsDynamicParameter(paramName string, cc *appsv1alpha1.ConfigConstraintSpec) bool {
 	if len(cc.DynamicParameters) != 0 {
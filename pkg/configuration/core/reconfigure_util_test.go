@@ -211,6 +211,41 @@ func TestIsUpdateDynamicParameters(t *testing.T) {
 	}
 }
 
+func TestValidateImmutableParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		ccSpec  *appsv1alpha1.ConfigConstraintSpec
+		diff    *ConfigPatchInfo
+		wantErr bool
+	}{{
+		name:    "no-immutable-parameters",
+		ccSpec:  &appsv1alpha1.ConfigConstraintSpec{},
+		diff:    newCfgDiffMeta(`{"param1":"b"}`, nil, nil),
+		wantErr: false,
+	}, {
+		name: "immutable-parameters-not-updated",
+		ccSpec: &appsv1alpha1.ConfigConstraintSpec{
+			ImmutableParameters: []string{"param1", "param2"},
+		},
+		diff:    newCfgDiffMeta(`{"param3":"b"}`, nil, nil),
+		wantErr: false,
+	}, {
+		name: "immutable-parameters-updated",
+		ccSpec: &appsv1alpha1.ConfigConstraintSpec{
+			ImmutableParameters: []string{"param1", "param2"},
+		},
+		diff:    newCfgDiffMeta(`{"param2":"b", "param3": 20}`, nil, nil),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateImmutableParameters(tt.ccSpec, tt.diff); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateImmutableParameters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestIsSchedulableConfigResource(t *testing.T) {
 	tests := []struct {
 		name   string
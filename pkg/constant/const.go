@@ -38,18 +38,85 @@ const (
 	CfgAddonJobImgPullPolicy = "ADDON_JOB_IMAGE_PULL_POLICY"
 
 	// data plane config key
-	CfgKeyDataPlaneTolerations = "DATA_PLANE_TOLERATIONS"
-	CfgKeyDataPlaneAffinity    = "DATA_PLANE_AFFINITY"
+	CfgKeyDataPlaneTolerations     = "DATA_PLANE_TOLERATIONS"
+	CfgKeyDataPlaneAffinity        = "DATA_PLANE_AFFINITY"
+	CfgKeyDataPlaneSecurityContext = "DATA_PLANE_SECURITY_CONTEXT"
+
+	// external secret store config keys
+	CfgKeySecretStoreProvider   = "SECRET_STORE_PROVIDER"
+	CfgKeySecretStorePathPrefix = "SECRET_STORE_PATH_PREFIX"
+	CfgKeySecretStoreVaultAddr  = "SECRET_STORE_VAULT_ADDR"
+	CfgKeySecretStoreVaultToken = "SECRET_STORE_VAULT_TOKEN"
+	CfgKeySecretStoreVaultMount = "SECRET_STORE_VAULT_MOUNT"
+	CfgKeySecretStoreVaultRole  = "SECRET_STORE_VAULT_ROLE"
+	CfgKeySecretStoreAWSRegion  = "SECRET_STORE_AWS_REGION"
+
+	// whether to expand the volumes automatically, which reads the volume stats of the kubelets by the nodes/proxy
+	CfgKeyVolumeAutoExpansionEnabled = "VOLUME_AUTO_EXPANSION_ENABLED"
+
+	// the node port range of Kubernetes, e.g. "30000-32767", which is the same as the --service-node-port-range of kube-apiserver
+	CfgKeyServiceNodePortRange = "SERVICE_NODE_PORT_RANGE"
+
+	// the external webhook to receive the audit entries
+	CfgKeyAuditWebhookURL = "AUDIT_WEBHOOK_URL"
+
+	// the notifications of the significant transitions of the clusters, e.g., cluster failed and backup failed
+	CfgKeyNotificationWebhookURL = "NOTIFICATION_WEBHOOK_URL"
+	// the template of the payload, which is compatible with the incoming webhooks of Slack by default
+	CfgKeyNotificationTemplate = "NOTIFICATION_TEMPLATE"
+	// the comma-separated events to notify, all the events if empty
+	CfgKeyNotificationEvents = "NOTIFICATION_EVENTS"
+	// whether to send the notifications to the webhooks configured by the annotations of the clusters, false by default
+	CfgKeyNotificationClusterWebhooksEnabled = "NOTIFICATION_CLUSTER_WEBHOOKS_ENABLED"
+	// the comma-separated hosts allowed for the webhooks of the clusters, e.g. "hooks.slack.com,*.example.com"
+	CfgKeyNotificationClusterWebhookHosts = "NOTIFICATION_CLUSTER_WEBHOOK_HOSTS"
+
+	// the tracing config keys, the tracing is enabled if the OTLP endpoint is set
+	CfgKeyTracingOTLPEndpoint = "TRACING_OTLP_ENDPOINT"
+	CfgKeyTracingOTLPInsecure = "TRACING_OTLP_INSECURE"
+	CfgKeyTracingSampleRatio  = "TRACING_SAMPLE_RATIO"
+
+	// the bearer token required by the debug endpoints if they are not bound to localhost
+	CfgKeyDebugAuthToken = "DEBUG_AUTH_TOKEN"
+
+	// the window to aggregate the repeated events in, e.g., 10s, disabled if 0
+	CfgKeyEventAggregationWindow = "EVENT_AGGREGATION_WINDOW"
+
+	// the sharding config keys, the clusters are spread over SHARDING_TOTAL operator replicas,
+	// and the replica reconciles the clusters of the shard SHARD_ID only
+	CfgKeyShardingTotal = "SHARDING_TOTAL"
+	CfgKeyShardID       = "SHARD_ID"
 
 	// storage config keys
 	CfgKeyDefaultStorageClass = "DEFAULT_STORAGE_CLASS"
 
+	// cluster quota config keys, the quotas are applied to each namespace and zero or empty means unlimited
+	CfgKeyClusterQuotaMaxClusters = "CLUSTER_QUOTA_MAX_CLUSTERS"
+	CfgKeyClusterQuotaMaxReplicas = "CLUSTER_QUOTA_MAX_REPLICAS"
+	CfgKeyClusterQuotaMaxStorage  = "CLUSTER_QUOTA_MAX_STORAGE"
+
+	// reject the creation of clusters referring to deprecated ClusterVersions, otherwise only warnings are returned
+	CfgKeyRejectDeprecatedClusterVersion = "REJECT_DEPRECATED_CLUSTER_VERSION"
+
+	// the OpsRequest types which always require an approval before being executed, joined by commas
+	CfgKeyOpsApprovalRequiredTypes = "OPS_APPROVAL_REQUIRED_TYPES"
+
 	// customized encryption key for encrypting the password of connection credential.
 	CfgKeyDPEncryptionKey = "DP_ENCRYPTION_KEY"
 
 	CfgKBReconcileWorkers = "KUBEBLOCKS_RECONCILE_WORKERS"
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
+
+	// the max concurrent reconciles of the controllers, default to a share of KUBEBLOCKS_RECONCILE_WORKERS if not set.
+	CfgKeyClusterReconcileWorkers       = "CLUSTER_RECONCILE_WORKERS"
+	CfgKeyOpsRequestReconcileWorkers    = "OPSREQUEST_RECONCILE_WORKERS"
+	CfgKeyConfigurationReconcileWorkers = "CONFIGURATION_RECONCILE_WORKERS"
+	CfgKeyReconfigureReconcileWorkers   = "RECONFIGURE_RECONCILE_WORKERS"
+
+	// the min and max backoff of requeuing the failed reconciliations, e.g., 5ms and 1000s.
+	CfgKeyReconcileBaseBackoff = "RECONCILE_BASE_BACKOFF"
+	CfgKeyReconcileMaxBackoff  = "RECONCILE_MAX_BACKOFF"
 )
 
 const (
@@ -98,6 +165,7 @@ const (
 	RoleLabelKey                             = "kubeblocks.io/role"              // RoleLabelKey consensusSet and replicationSet role label key
 	ReadyWithoutPrimaryKey                   = "kubeblocks.io/ready-without-primary"
 	VolumeTypeLabelKey                       = "kubeblocks.io/volume-type"
+	DedicatedNodeLabelKey                    = "kubeblocks.io/dedicated-node" // DedicatedNodeLabelKey marks the nodes dedicated to database pods, it is used as the taint key as well
 	ClusterAccountLabelKey                   = "account.kubeblocks.io/name"
 	KBAppClusterUIDLabelKey                  = "apps.kubeblocks.io/cluster-uid"
 	KBAppComponentLabelKey                   = "apps.kubeblocks.io/component-name"
@@ -105,6 +173,7 @@ const (
 	KBAppComponentDefRefLabelKey             = "apps.kubeblocks.io/component-def-ref" // refer clusterDefinition.Spec.ComponentDefs[*].Name before KubeBlocks Version 0.8.0 or refer ComponentDefinition.Name after KubeBlocks Version 0.8.0
 	KBAppClusterDefTypeLabelKey              = "apps.kubeblocks.io/cluster-type"      // refer clusterDefinition.Spec.Type (deprecated)
 	KBManagedByKey                           = "apps.kubeblocks.io/managed-by"        // KBManagedByKey marks resources that auto created
+	ShardLabelKey                            = "apps.kubeblocks.io/shard"             // ShardLabelKey pins the clusters in the labeled namespace to the operator shard
 	PVCNameLabelKey                          = "apps.kubeblocks.io/pvc-name"
 	VolumeClaimTemplateNameLabelKey          = "apps.kubeblocks.io/vct-name"
 	VolumeClaimTemplateNameLabelKeyForLegacy = "vct.kubeblocks.io/name" // Deprecated: only compatible with version 0.5, will be removed in 0.7
@@ -130,6 +199,7 @@ const (
 	ServiceDescriptorNameLabelKey            = "servicedescriptor.kubeblocks.io/name"
 	RestoreForHScaleLabelKey                 = "apps.kubeblocks.io/restore-for-hscale"
 	ResourceConstraintProviderLabelKey       = "resourceconstraint.kubeblocks.io/provider"
+	SwitchoverJobLabelKey                    = "kubeblocks.io/switchover-job" // SwitchoverJobLabelKey marks the jobs executing the switchover of the OpsRequests
 
 	// StatefulSetPodNameLabelKey is used to mark the pod name of the StatefulSet
 	StatefulSetPodNameLabelKey = "statefulset.kubernetes.io/pod-name"
@@ -153,12 +223,25 @@ const (
 	LastAppliedConfigAnnotationKey              = "config.kubeblocks.io/last-applied-configuration"
 	LastAppliedOpsCRAnnotationKey               = "config.kubeblocks.io/last-applied-ops-name"
 	UpgradePolicyAnnotationKey                  = "config.kubeblocks.io/reconfigure-policy"
+	ReconfigureRestartPolicyAnnotationKey       = "config.kubeblocks.io/restart-policy"
 	KBParameterUpdateSourceAnnotationKey        = "config.kubeblocks.io/reconfigure-source"
 	UpgradeRestartAnnotationKey                 = "config.kubeblocks.io/restart"
+	CanaryReconfigureAnnotationKey              = "config.kubeblocks.io/canary"
 	ConfigAppliedVersionAnnotationKey           = "config.kubeblocks.io/config-applied-version"
 	KubeBlocksGenerationKey                     = "kubeblocks.io/generation"
 	ExtraEnvAnnotationKey                       = "kubeblocks.io/extra-env"
 	LastRoleSnapshotVersionAnnotationKey        = "apps.kubeblocks.io/last-role-snapshot-version"
+	PodResizedInPlaceAnnotationKey              = "workloads.kubeblocks.io/resized-in-place" // PodResizedInPlaceAnnotationKey records the time when the resources of the pod are resized in place.
+	ScheduledScalingAnnotationKey               = "apps.kubeblocks.io/scheduled-scaling"     // ScheduledScalingAnnotationKey records the active scheduled scaling windows and the replicas before them.
+	ScheduledScalingOpsAnnotationKey            = "apps.kubeblocks.io/scheduled-scaling-ops" // ScheduledScalingOpsAnnotationKey records the OpsRequest planned by the scheduled scaling windows but not created yet.
+	ServiceMeshAnnotationKey                    = "apps.kubeblocks.io/service-mesh"          // ServiceMeshAnnotationKey marks the pods working with the sidecar proxy of the service mesh.
+	TLSCertHashAnnotationKey                    = "apps.kubeblocks.io/tls-cert-hash"         // TLSCertHashAnnotationKey records the hash of the TLS certificates the pods are started with.
+	SecretStoreProviderAnnotationKey            = "apps.kubeblocks.io/secret-store-provider" // SecretStoreProviderAnnotationKey records the external secret manager that keeps the sensitive values of the secret.
+	RequestedByAnnotationKey                    = "apps.kubeblocks.io/requested-by"          // RequestedByAnnotationKey records the user who creates the OpsRequest, it's immutable.
+	OpsApprovalRequiredAnnotationKey            = "apps.kubeblocks.io/ops-approval-required" // OpsApprovalRequiredAnnotationKey specifies the OpsRequest types which require an approval for the cluster, joined by commas.
+	RetainedPVCAnnotationKey                    = "apps.kubeblocks.io/retained-pvc"          // RetainedPVCAnnotationKey marks the PVCs retained on scale-in, which are reused when the component is scaled out again.
+	PasswordRotatedByAnnotationKey              = "apps.kubeblocks.io/password-rotated-by"   // PasswordRotatedByAnnotationKey records the OpsRequest which rotated the password of the account secret last.
+	PendingPasswordAppliedAnnotationKey         = "apps.kubeblocks.io/password-applied"      // PendingPasswordAppliedAnnotationKey marks the pending password secret whose password has been changed in the engine.
 
 	// kubeblocks.io well-known finalizers
 	DBClusterFinalizerName         = "cluster.kubeblocks.io/finalizer"
@@ -224,6 +307,7 @@ const (
 	ServiceKind               = "Service"
 	ConfigMapKind             = "ConfigMap"
 	DaemonSetKind             = "DaemonSet"
+	ComponentKind             = "Component"
 )
 
 const (
@@ -264,6 +348,7 @@ const (
 	PodMinReadySecondsEnv = "POD_MIN_READY_SECONDS"
 	ConfigTemplateType    = "tpl"
 	ConfigInstanceType    = "instance"
+	ConfigHistoryType     = "history"
 
 	ReconfigureManagerSource  = "manager"
 	ReconfigureUserSource     = "ops"
@@ -286,6 +371,10 @@ const (
 const (
 	AccountNameForSecret   = "username"
 	AccountPasswdForSecret = "password"
+	// AccountNextPasswdForSecret is the key of the pending password which is being rotated to.
+	AccountNextPasswdForSecret = "nextPassword"
+	// SecretStoreRefKey is the key of the reference to the sensitive values kept in the external secret manager.
+	SecretStoreRefKey = "secretStoreRef"
 )
 
 const (
@@ -55,6 +55,7 @@ const (
 	KBEnvPodIPs           = "KB_POD_IPS"
 	KBEnvPodFQDN          = "KB_POD_FQDN"
 	KBEnvPodOrdinal       = "KB_POD_ORDINAL"
+	KBEnvPodEndpoints     = "KB_POD_ENDPOINTS"
 	KBEnvPodIPDeprecated  = "KB_PODIP"
 	KBEnvPodIPsDeprecated = "KB_PODIPS"
 )
@@ -71,6 +72,13 @@ const (
 	KBEnvServiceAccountName = "KB_SA_NAME"
 )
 
+// Connection pooler
+const (
+	KBEnvPoolerMode            = "KB_POOLER_MODE"
+	KBEnvPoolerMaxClientConn   = "KB_POOLER_MAX_CLIENT_CONN"
+	KBEnvPoolerDefaultPoolSize = "KB_POOLER_DEFAULT_POOL_SIZE"
+)
+
 // TLS
 const (
 	KBEnvTLSCertPath = "KB_TLS_CERT_PATH"
@@ -22,6 +22,10 @@ package constant
 const (
 	EnableRBACManager = "EnableRBACManager"
 
+	// EnableLeastPrivilegeRBAC enables generating a dedicated Role for each component, which grants the pods
+	// exactly the permissions required by their agents, instead of binding the aggregated cluster pod role.
+	EnableLeastPrivilegeRBAC = "ENABLE_LEAST_PRIVILEGE_RBAC"
+
 	ManagedNamespacesFlag = "managed-namespaces"
 )
 
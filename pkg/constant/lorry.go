@@ -37,6 +37,9 @@ const (
 	ReadWriteAction     = "readwrite"
 	PostProvisionAction = "postProvision"
 	PreTerminateAction  = "preTerminate"
+
+	AccountProvisionAction        = "accountProvision"
+	AccountPasswordRotationAction = "accountPasswordRotation"
 )
 
 // action envs
@@ -26,3 +26,9 @@ const FeatureGateEnableRuntimeMetrics = "ENABLED_RUNTIME_METRICS"
 func EnabledRuntimeMetrics() bool {
 	return viper.GetBool(FeatureGateEnableRuntimeMetrics)
 }
+
+const FeatureGateEnableFleetMetrics = "ENABLED_FLEET_METRICS"
+
+func EnabledFleetMetrics() bool {
+	return viper.GetBool(FeatureGateEnableFleetMetrics)
+}
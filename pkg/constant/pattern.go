@@ -33,6 +33,17 @@ func GenerateAccountSecretName(clusterName, compName, name string) string {
 	return fmt.Sprintf("%s-%s-account-%s", clusterName, compName, name)
 }
 
+// GeneratePendingAccountSecretName generates the name of the secret which keeps the pending password of the account secret
+// when the password is being rotated.
+func GeneratePendingAccountSecretName(accountSecretName string) string {
+	return fmt.Sprintf("%s-pending", accountSecretName)
+}
+
+// GenerateDatabaseUserSecretName generates the secret name to store the credential of the DatabaseUser.
+func GenerateDatabaseUserSecretName(name string) string {
+	return fmt.Sprintf("%s-credential", name)
+}
+
 // GenerateClusterServiceName generates the service name for cluster.
 func GenerateClusterServiceName(clusterName, svcName string) string {
 	if len(svcName) > 0 {
@@ -81,6 +92,11 @@ func GenerateDefaultConnCredential(clusterName string) string {
 	return fmt.Sprintf("%s-conn-credential", clusterName)
 }
 
+// GenerateComponentConnCredential generates the connection credential name for component.
+func GenerateComponentConnCredential(clusterName, compName string) string {
+	return fmt.Sprintf("%s-%s-conn-credential", clusterName, compName)
+}
+
 // GenerateClusterComponentEnvPattern generates cluster and component pattern
 func GenerateClusterComponentEnvPattern(clusterName, compName string) string {
 	return fmt.Sprintf("%s-%s-env", clusterName, compName)
@@ -91,6 +107,16 @@ func GenerateDefaultServiceAccountName(name string) string {
 	return fmt.Sprintf("%s-%s", KBLowerPrefix, name)
 }
 
+// GenerateComponentRoleName generates the name of the least-privilege role (and role binding) for a component.
+func GenerateComponentRoleName(clusterName, compName string) string {
+	return fmt.Sprintf("%s-%s-%s", KBLowerPrefix, clusterName, compName)
+}
+
+// GenerateComponentServiceAccountName generates the name of the dedicated service account for a component.
+func GenerateComponentServiceAccountName(clusterName, compName string) string {
+	return fmt.Sprintf("%s-%s-%s", KBLowerPrefix, clusterName, compName)
+}
+
 // GenerateRSMNamePattern generates rsm name pattern
 func GenerateRSMNamePattern(clusterName, compName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, compName)
@@ -24,4 +24,5 @@ const (
 	ComponentResourcePayload = "component-resource"
 	ReplicasPayload          = "replicas"
 	BinaryVersionPayload     = "binary-version"
+	TopologyPayload          = "topology"
 )
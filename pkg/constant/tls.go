@@ -25,4 +25,7 @@ const (
 	CertName   = "tls.crt"
 	KeyName    = "tls.key"
 	MountPath  = "/etc/pki/tls"
+
+	ClientVolumeName = "tls-client"
+	ClientMountPath  = "/etc/pki/tls-client"
 )
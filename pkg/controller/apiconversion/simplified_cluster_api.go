@@ -118,22 +118,13 @@ func fillSimplifiedClusterAPI(cluster *appsv1alpha1.Cluster, clusterCompDef *app
 			}
 			switch getCloudProvider() {
 			case cloudProviderAWS:
-				svc.Annotations = map[string]string{
-					"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb",
-					"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
-				}
+				svc.AnnotationProfile = appsv1alpha1.AWSNLBInternalProfile
 			case cloudProviderGCP:
-				svc.Annotations = map[string]string{
-					"networking.gke.io/load-balancer-type": "Internal",
-				}
+				svc.AnnotationProfile = appsv1alpha1.GCPInternalProfile
 			case cloudProviderAliyun:
-				svc.Annotations = map[string]string{
-					"service.beta.kubernetes.io/alibaba-cloud-loadbalancer-address-type": "intranet",
-				}
+				svc.AnnotationProfile = appsv1alpha1.AliyunSLBIntranetProfile
 			case cloudProviderAzure:
-				svc.Annotations = map[string]string{
-					"service.beta.kubernetes.io/azure-load-balancer-internal": "true",
-				}
+				svc.AnnotationProfile = appsv1alpha1.AzureInternalProfile
 			}
 			clusterCompSpec.Services = append(clusterCompSpec.Services, svc)
 		}
@@ -144,18 +135,11 @@ func fillSimplifiedClusterAPI(cluster *appsv1alpha1.Cluster, clusterCompDef *app
 			}
 			switch getCloudProvider() {
 			case cloudProviderAWS:
-				svc.Annotations = map[string]string{
-					"service.beta.kubernetes.io/aws-load-balancer-type":     "nlb",
-					"service.beta.kubernetes.io/aws-load-balancer-internal": "false",
-				}
+				svc.AnnotationProfile = appsv1alpha1.AWSNLBInternetProfile
 			case cloudProviderAliyun:
-				svc.Annotations = map[string]string{
-					"service.beta.kubernetes.io/alibaba-cloud-loadbalancer-address-type": "internet",
-				}
+				svc.AnnotationProfile = appsv1alpha1.AliyunSLBInternetProfile
 			case cloudProviderAzure:
-				svc.Annotations = map[string]string{
-					"service.beta.kubernetes.io/azure-load-balancer-internal": "false",
-				}
+				svc.AnnotationProfile = appsv1alpha1.AzureInternetProfile
 			}
 			clusterCompSpec.Services = append(clusterCompSpec.Services, svc)
 		}
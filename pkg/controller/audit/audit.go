@@ -0,0 +1,129 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/sink"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// the results of the audited actions
+	ResultStarted   = "Started"
+	ResultSucceeded = "Succeeded"
+	ResultFailed    = "Failed"
+	ResultCancelled = "Cancelled"
+
+	// the actions initiated by the controllers
+	ActionSwitchover     = "Switchover"
+	ActionFailover       = "Failover"
+	ActionForcePodDelete = "ForcePodDelete"
+
+	// the annotation keys of the audit events
+	annotationKeyActor     = "audit.kubeblocks.io/actor"
+	annotationKeyAction    = "audit.kubeblocks.io/action"
+	annotationKeyResult    = "audit.kubeblocks.io/result"
+	annotationKeyTimestamp = "audit.kubeblocks.io/timestamp"
+
+	// the reason prefix of the audit events, e.g., AuditSwitchover
+	reasonPrefix = "Audit"
+)
+
+var (
+	log = logf.Log.WithName("audit")
+	// sinks post the audit entries to the webhook.
+	sinks = sink.NewPool("audit", 0)
+)
+
+// Event is an audit entry of the cluster-mutating operations, which records who did what to which object, when and the result.
+type Event struct {
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Target    string    `json:"target,omitempty"`
+	Result    string    `json:"result"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ControllerActor returns the identity of KubeBlocks, which is the actor of the actions initiated by the controllers.
+func ControllerActor() string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", viper.GetString(constant.CfgKeyCtrlrMgrNS), viper.GetString(constant.KBServiceAccountName))
+}
+
+// Record records the audit entry of the object as a Kubernetes Event with the reason 'Audit<Action>', the details of the entry
+// are put into the annotations of the event. The entry is also sent to the external webhook sink if configured.
+func Record(recorder record.EventRecorder, obj client.Object, entry Event) {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	if len(entry.Kind) == 0 {
+		entry.Kind = obj.GetObjectKind().GroupVersionKind().Kind
+	}
+	entry.Namespace = obj.GetNamespace()
+	entry.Name = obj.GetName()
+
+	if recorder != nil {
+		annotations := map[string]string{
+			annotationKeyActor:     entry.Actor,
+			annotationKeyAction:    entry.Action,
+			annotationKeyResult:    entry.Result,
+			annotationKeyTimestamp: entry.Timestamp.UTC().Format(time.RFC3339),
+		}
+		eventType := corev1.EventTypeNormal
+		if entry.Result == ResultFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		recorder.AnnotatedEventf(obj, annotations, eventType, reasonPrefix+entry.Action, "%s", entry.String())
+	}
+	if url := viper.GetString(constant.CfgKeyAuditWebhookURL); len(url) > 0 {
+		payload, err := json.Marshal(entry)
+		if err != nil {
+			log.Error(err, "failed to marshal the audit entry", "entry", entry.String())
+			return
+		}
+		sinks.Send(url, payload)
+	}
+}
+
+func (e Event) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s by %s: %s", e.Action, e.Actor, e.Result)
+	if len(e.Target) > 0 {
+		fmt.Fprintf(&b, ", target: %s", e.Target)
+	}
+	if len(e.Message) > 0 {
+		fmt.Fprintf(&b, ", %s", e.Message)
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("audit", func() {
+	var (
+		pod *corev1.Pod
+	)
+
+	BeforeEach(func() {
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeyAuditWebhookURL, "")
+	})
+
+	It("records the entry as event", func() {
+		recorder := record.NewFakeRecorder(1)
+		Record(recorder, pod, Event{
+			Actor:  "alice",
+			Action: ActionForcePodDelete,
+			Result: ResultFailed,
+		})
+		Expect(recorder.Events).Should(HaveLen(1))
+		event := <-recorder.Events
+		Expect(event).Should(HavePrefix(corev1.EventTypeWarning + " AuditForcePodDelete"))
+		Expect(event).Should(ContainSubstring("ForcePodDelete by alice: Failed"))
+	})
+
+	It("sends the entry to webhook", func() {
+		received := make(chan Event, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).Should(Equal(http.MethodPost))
+			entry := Event{}
+			Expect(json.NewDecoder(r.Body).Decode(&entry)).Should(Succeed())
+			received <- entry
+		}))
+		defer server.Close()
+		viper.Set(constant.CfgKeyAuditWebhookURL, server.URL)
+
+		Record(nil, pod, Event{
+			Actor:  "alice",
+			Action: "Restart",
+			Result: ResultStarted,
+		})
+		var entry Event
+		Eventually(received).Should(Receive(&entry))
+		Expect(entry.Actor).Should(Equal("alice"))
+		Expect(entry.Namespace).Should(Equal(pod.Namespace))
+		Expect(entry.Name).Should(Equal(pod.Name))
+		Expect(entry.Result).Should(Equal(ResultStarted))
+		Expect(entry.Timestamp.IsZero()).Should(BeFalse())
+	})
+
+	It("builds the controller actor", func() {
+		viper.Set(constant.CfgKeyCtrlrMgrNS, "kb-system")
+		viper.Set(constant.KBServiceAccountName, "kubeblocks")
+		Expect(strings.HasSuffix(ControllerActor(), ":kb-system:kubeblocks")).Should(BeTrue())
+	})
+})
@@ -102,6 +102,46 @@ func (builder *ComponentBuilder) SetInstances(instances []string) *ComponentBuil
 	return builder
 }
 
+func (builder *ComponentBuilder) SetExternal(external *appsv1alpha1.ExternalComponent) *ComponentBuilder {
+	builder.get().Spec.External = external
+	return builder
+}
+
+func (builder *ComponentBuilder) SetHostNetwork(hostNetwork bool) *ComponentBuilder {
+	builder.get().Spec.HostNetwork = hostNetwork
+	return builder
+}
+
+func (builder *ComponentBuilder) SetPriorityClassName(priorityClassName string) *ComponentBuilder {
+	builder.get().Spec.PriorityClassName = priorityClassName
+	return builder
+}
+
+func (builder *ComponentBuilder) SetVolumeClaimRetentionPolicy(policy *appsv1alpha1.VolumeClaimRetentionPolicy) *ComponentBuilder {
+	builder.get().Spec.VolumeClaimRetentionPolicy = policy
+	return builder
+}
+
+func (builder *ComponentBuilder) SetAutoscaling(autoscaling *appsv1alpha1.ComponentAutoscaling) *ComponentBuilder {
+	builder.get().Spec.Autoscaling = autoscaling
+	return builder
+}
+
+func (builder *ComponentBuilder) SetSecurityContext(securityContext *appsv1alpha1.SecurityContextDefaults) *ComponentBuilder {
+	builder.get().Spec.SecurityContext = securityContext
+	return builder
+}
+
+func (builder *ComponentBuilder) SetPodEndpoints(endpoints *appsv1alpha1.PodEndpoints) *ComponentBuilder {
+	builder.get().Spec.PodEndpoints = endpoints
+	return builder
+}
+
+func (builder *ComponentBuilder) SetNodeFailureRecovery(recovery *appsv1alpha1.NodeFailureRecovery) *ComponentBuilder {
+	builder.get().Spec.NodeFailureRecovery = recovery
+	return builder
+}
+
 func (builder *ComponentBuilder) SetTLSConfig(enable bool, issuer *appsv1alpha1.Issuer) *ComponentBuilder {
 	if enable {
 		builder.get().Spec.TLSConfig = &appsv1alpha1.TLSConfig{
@@ -0,0 +1,38 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+
+package builder
+
+import corev1 "k8s.io/api/core/v1"
+
+type EndpointsBuilder struct {
+	BaseBuilder[corev1.Endpoints, *corev1.Endpoints, EndpointsBuilder]
+}
+
+func NewEndpointsBuilder(namespace, name string) *EndpointsBuilder {
+	builder := &EndpointsBuilder{}
+	builder.init(namespace, name, &corev1.Endpoints{}, builder)
+	return builder
+}
+
+func (builder *EndpointsBuilder) AddSubsets(subsets ...corev1.EndpointSubset) *EndpointsBuilder {
+	builder.get().Subsets = append(builder.get().Subsets, subsets...)
+	return builder
+}
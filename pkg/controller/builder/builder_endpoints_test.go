@@ -0,0 +1,49 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+
+package builder
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("endpoints builder", func() {
+	It("should work well", func() {
+		const (
+			name = "foo"
+			ns   = "default"
+		)
+		subset := corev1.EndpointSubset{
+			Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+			Ports:     []corev1.EndpointPort{{Name: "default", Port: 3306, Protocol: corev1.ProtocolTCP}},
+		}
+		ep := NewEndpointsBuilder(ns, name).
+			AddSubsets(subset).
+			GetObject()
+
+		Expect(ep.Name).Should(Equal(name))
+		Expect(ep.Namespace).Should(Equal(ns))
+		Expect(ep.Subsets).Should(HaveLen(1))
+		Expect(ep.Subsets[0]).Should(Equal(subset))
+	})
+})
@@ -0,0 +1,57 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import autoscalingv2 "k8s.io/api/autoscaling/v2"
+
+type HorizontalPodAutoscalerBuilder struct {
+	BaseBuilder[autoscalingv2.HorizontalPodAutoscaler, *autoscalingv2.HorizontalPodAutoscaler, HorizontalPodAutoscalerBuilder]
+}
+
+func NewHorizontalPodAutoscalerBuilder(namespace, name string) *HorizontalPodAutoscalerBuilder {
+	builder := &HorizontalPodAutoscalerBuilder{}
+	builder.init(namespace, name, &autoscalingv2.HorizontalPodAutoscaler{}, builder)
+	return builder
+}
+
+func (builder *HorizontalPodAutoscalerBuilder) SetScaleTargetRef(ref autoscalingv2.CrossVersionObjectReference) *HorizontalPodAutoscalerBuilder {
+	builder.get().Spec.ScaleTargetRef = ref
+	return builder
+}
+
+func (builder *HorizontalPodAutoscalerBuilder) SetMinReplicas(minReplicas *int32) *HorizontalPodAutoscalerBuilder {
+	builder.get().Spec.MinReplicas = minReplicas
+	return builder
+}
+
+func (builder *HorizontalPodAutoscalerBuilder) SetMaxReplicas(maxReplicas int32) *HorizontalPodAutoscalerBuilder {
+	builder.get().Spec.MaxReplicas = maxReplicas
+	return builder
+}
+
+func (builder *HorizontalPodAutoscalerBuilder) SetMetrics(metrics []autoscalingv2.MetricSpec) *HorizontalPodAutoscalerBuilder {
+	builder.get().Spec.Metrics = metrics
+	return builder
+}
+
+func (builder *HorizontalPodAutoscalerBuilder) SetBehavior(behavior *autoscalingv2.HorizontalPodAutoscalerBehavior) *HorizontalPodAutoscalerBuilder {
+	builder.get().Spec.Behavior = behavior
+	return builder
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("horizontal pod autoscaler builder", func() {
+	It("should work well", func() {
+		const (
+			name = "foo"
+			ns   = "default"
+		)
+		ref := autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps.kubeblocks.io/v1alpha1",
+			Kind:       "Component",
+			Name:       "foo-bar",
+		}
+		metrics := []autoscalingv2.MetricSpec{
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: pointer.Int32(80),
+					},
+				},
+			},
+		}
+		behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+			ScaleUp: &autoscalingv2.HPAScalingRules{
+				StabilizationWindowSeconds: pointer.Int32(600),
+			},
+		}
+		hpa := NewHorizontalPodAutoscalerBuilder(ns, name).
+			SetScaleTargetRef(ref).
+			SetMinReplicas(pointer.Int32(1)).
+			SetMaxReplicas(3).
+			SetMetrics(metrics).
+			SetBehavior(behavior).
+			GetObject()
+
+		Expect(hpa.Name).Should(Equal(name))
+		Expect(hpa.Namespace).Should(Equal(ns))
+		Expect(hpa.Spec.ScaleTargetRef).Should(Equal(ref))
+		Expect(*hpa.Spec.MinReplicas).Should(Equal(int32(1)))
+		Expect(hpa.Spec.MaxReplicas).Should(Equal(int32(3)))
+		Expect(hpa.Spec.Metrics).Should(Equal(metrics))
+		Expect(hpa.Spec.Behavior).Should(Equal(behavior))
+	})
+})
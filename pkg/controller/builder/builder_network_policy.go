@@ -0,0 +1,50 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NetworkPolicyBuilder struct {
+	BaseBuilder[networkingv1.NetworkPolicy, *networkingv1.NetworkPolicy, NetworkPolicyBuilder]
+}
+
+func NewNetworkPolicyBuilder(namespace, name string) *NetworkPolicyBuilder {
+	builder := &NetworkPolicyBuilder{}
+	builder.init(namespace, name, &networkingv1.NetworkPolicy{}, builder)
+	return builder
+}
+
+func (builder *NetworkPolicyBuilder) SetPodSelector(selector metav1.LabelSelector) *NetworkPolicyBuilder {
+	builder.get().Spec.PodSelector = selector
+	return builder
+}
+
+func (builder *NetworkPolicyBuilder) SetPolicyTypes(policyTypes ...networkingv1.PolicyType) *NetworkPolicyBuilder {
+	builder.get().Spec.PolicyTypes = policyTypes
+	return builder
+}
+
+func (builder *NetworkPolicyBuilder) AddIngressRules(rules ...networkingv1.NetworkPolicyIngressRule) *NetworkPolicyBuilder {
+	builder.get().Spec.Ingress = append(builder.get().Spec.Ingress, rules...)
+	return builder
+}
@@ -0,0 +1,50 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("role builder", func() {
+	It("should work well", func() {
+		const (
+			name = "foo"
+			ns   = "default"
+		)
+		rule := rbacv1.PolicyRule{
+			APIGroups: []string{corev1.GroupName},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list", "patch"},
+		}
+		role := NewRoleBuilder(ns, name).
+			AddPolicyRules(rule).
+			GetObject()
+
+		Expect(role.Name).Should(Equal(name))
+		Expect(role.Namespace).Should(Equal(ns))
+		Expect(role.Rules).Should(HaveLen(1))
+		Expect(role.Rules[0]).Should(Equal(rule))
+	})
+})
@@ -102,6 +102,12 @@ func (builder *ServiceBuilder) SetType(serviceType corev1.ServiceType) *ServiceB
 	return builder
 }
 
+func (builder *ServiceBuilder) SetExternalName(externalName string) *ServiceBuilder {
+	builder.get().Spec.Type = corev1.ServiceTypeExternalName
+	builder.get().Spec.ExternalName = externalName
+	return builder
+}
+
 func (builder *ServiceBuilder) SetSpec(spec *corev1.ServiceSpec) *ServiceBuilder {
 	if spec != nil {
 		builder.get().Spec = *spec
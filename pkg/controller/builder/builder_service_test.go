@@ -91,4 +91,18 @@ var _ = Describe("service builder", func() {
 			Expect(hasPort(containerPort, svc.Spec.Ports)).Should(BeTrue())
 		}
 	})
+
+	It("should build external name service", func() {
+		const (
+			name         = "foo"
+			ns           = "default"
+			externalName = "db.example.com"
+		)
+		svc := NewServiceBuilder(ns, name).
+			SetExternalName(externalName).
+			GetObject()
+
+		Expect(svc.Spec.Type).Should(Equal(corev1.ServiceTypeExternalName))
+		Expect(svc.Spec.ExternalName).Should(Equal(externalName))
+	})
 })
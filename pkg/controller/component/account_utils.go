@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// GenerateAccountPassword generates a password of the system account according to the password generation policy.
+func GenerateAccountPassword(config appsv1alpha1.PasswordConfig) []byte {
+	passwd, _ := common.GeneratePassword((int)(config.Length), (int)(config.NumDigits), (int)(config.NumSymbols), false, config.Seed)
+	switch config.LetterCase {
+	case appsv1alpha1.UpperCases:
+		passwd = strings.ToUpper(passwd)
+	case appsv1alpha1.LowerCases:
+		passwd = strings.ToLower(passwd)
+	}
+	return []byte(passwd)
+}
+
+// GetAppliedPendingAccountPassword gets the pending password kept by the password rotation for the account secret,
+// if it has been changed in the engine. It's used to restore the account secret lost when being replaced.
+func GetAppliedPendingAccountPassword(ctx context.Context, cli client.Reader, namespace, accountSecretName string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{
+		Namespace: namespace,
+		Name:      constant.GeneratePendingAccountSecretName(accountSecretName),
+	}
+	if err := cli.Get(ctx, secretKey, secret); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if secret.Annotations[constant.PendingPasswordAppliedAnnotationKey] != "true" {
+		return nil, nil
+	}
+	return secret.Data[constant.AccountPasswdForSecret], nil
+}
@@ -59,12 +59,59 @@ func BuildTolerations(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.Clus
 	return append(tolerations, dpTolerations...), nil
 }
 
+// RequireDedicatedNodes checks whether the pods must be scheduled to the nodes dedicated to database pods,
+// it's opted in explicitly for the DedicatedNode tenancy.
+func RequireDedicatedNodes(compAffinity *appsv1alpha1.Affinity) bool {
+	return compAffinity != nil && compAffinity.Tenancy == appsv1alpha1.DedicatedNode && compAffinity.DedicatedNodes
+}
+
+// BuildDedicatedNodeTolerations builds the tolerations for the taint of dedicated nodes if they are required.
+func BuildDedicatedNodeTolerations(compAffinity *appsv1alpha1.Affinity) []corev1.Toleration {
+	if !RequireDedicatedNodes(compAffinity) {
+		return nil
+	}
+	return []corev1.Toleration{
+		{
+			Key:      constant.DedicatedNodeLabelKey,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	}
+}
+
 func BuildPodTopologySpreadConstraints(clusterName, compName string, compAffinity *appsv1alpha1.Affinity) []corev1.TopologySpreadConstraint {
 	if compAffinity == nil {
 		return nil
 	}
 
 	var topologySpreadConstraints []corev1.TopologySpreadConstraint
+	labelSelector := func() *metav1.LabelSelector {
+		return &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				constant.AppInstanceLabelKey:    clusterName,
+				constant.KBAppComponentLabelKey: compName,
+			},
+		}
+	}
+
+	if len(compAffinity.TopologySpreadConstraints) > 0 {
+		for _, c := range compAffinity.TopologySpreadConstraints {
+			constraint := corev1.TopologySpreadConstraint{
+				MaxSkew:           c.MaxSkew,
+				WhenUnsatisfiable: c.WhenUnsatisfiable,
+				TopologyKey:       c.TopologyKey,
+				LabelSelector:     labelSelector(),
+			}
+			if constraint.MaxSkew <= 0 {
+				constraint.MaxSkew = 1
+			}
+			if constraint.WhenUnsatisfiable == "" {
+				constraint.WhenUnsatisfiable = corev1.ScheduleAnyway
+			}
+			topologySpreadConstraints = append(topologySpreadConstraints, constraint)
+		}
+		return topologySpreadConstraints
+	}
 
 	var whenUnsatisfiable corev1.UnsatisfiableConstraintAction
 	if compAffinity.PodAntiAffinity == appsv1alpha1.Required {
@@ -77,12 +124,7 @@ func BuildPodTopologySpreadConstraints(clusterName, compName string, compAffinit
 			MaxSkew:           1,
 			WhenUnsatisfiable: whenUnsatisfiable,
 			TopologyKey:       topologyKey,
-			LabelSelector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					constant.AppInstanceLabelKey:    clusterName,
-					constant.KBAppComponentLabelKey: compName,
-				},
-			},
+			LabelSelector:     labelSelector(),
 		})
 	}
 	return topologySpreadConstraints
@@ -116,6 +158,13 @@ func buildNewAffinity(clusterName, compName string, compAffinity *appsv1alpha1.A
 			Values:   values,
 		})
 	}
+	// pods of the dedicated-node tenancy can be scheduled to the dedicated nodes only if required
+	if RequireDedicatedNodes(compAffinity) {
+		matchExpressions = append(matchExpressions, corev1.NodeSelectorRequirement{
+			Key:      constant.DedicatedNodeLabelKey,
+			Operator: corev1.NodeSelectorOpExists,
+		})
+	}
 	if len(matchExpressions) > 0 {
 		nodeSelectorTerm := corev1.NodeSelectorTerm{
 			MatchExpressions: matchExpressions,
@@ -149,6 +149,30 @@ var _ = Describe("affinity utils", func() {
 		})
 	})
 
+	Context("with explicit TopologySpreadConstraints", func() {
+		BeforeEach(func() {
+			buildObjs(appsv1alpha1.Required)
+			Expect(component).ShouldNot(BeNil())
+		})
+
+		It("should override the TopologySpreadConstraints derived from topology keys", func() {
+			const zoneKey = "topology.kubernetes.io/zone"
+			affinity := clusterObj.Spec.Affinity.DeepCopy()
+			affinity.TopologySpreadConstraints = []appsv1alpha1.TopologySpreadConstraint{
+				{TopologyKey: zoneKey, MaxSkew: 2, WhenUnsatisfiable: corev1.DoNotSchedule},
+				{TopologyKey: topologyKey},
+			}
+			topologySpreadConstraints := BuildPodTopologySpreadConstraints(clusterObj.Name, component.Name, affinity)
+			Expect(topologySpreadConstraints).Should(HaveLen(2))
+			Expect(topologySpreadConstraints[0].TopologyKey).Should(Equal(zoneKey))
+			Expect(topologySpreadConstraints[0].MaxSkew).Should(BeEquivalentTo(2))
+			Expect(topologySpreadConstraints[0].WhenUnsatisfiable).Should(Equal(corev1.DoNotSchedule))
+			Expect(topologySpreadConstraints[1].MaxSkew).Should(BeEquivalentTo(1))
+			Expect(topologySpreadConstraints[1].WhenUnsatisfiable).Should(Equal(corev1.ScheduleAnyway))
+			Expect(topologySpreadConstraints[1].LabelSelector.MatchLabels[constant.KBAppComponentLabelKey]).Should(Equal(component.Name))
+		})
+	})
+
 	Context("with tolerations", func() {
 		BeforeEach(func() {
 			buildObjs(appsv1alpha1.Required)
@@ -83,6 +83,7 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, clusterCompSpec *appsv1alpha1
 		SetResources(clusterCompSpec.Resources).
 		SetMonitor(clusterCompSpec.Monitor).
 		SetServiceAccountName(clusterCompSpec.ServiceAccountName).
+		SetPriorityClassName(clusterCompSpec.PriorityClassName).
 		SetVolumeClaimTemplates(clusterCompSpec.VolumeClaimTemplates).
 		SetEnabledLogs(clusterCompSpec.EnabledLogs).
 		SetServiceRefs(clusterCompSpec.ServiceRefs).
@@ -90,7 +91,14 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, clusterCompSpec *appsv1alpha1
 		SetTLSConfig(clusterCompSpec.TLS, clusterCompSpec.Issuer).
 		SetNodes(clusterCompSpec.Nodes).
 		SetInstances(clusterCompSpec.Instances).
-		SetTransformPolicy(clusterCompSpec.RsmTransformPolicy)
+		SetTransformPolicy(clusterCompSpec.RsmTransformPolicy).
+		SetExternal(clusterCompSpec.External).
+		SetHostNetwork(clusterCompSpec.HostNetwork).
+		SetNodeFailureRecovery(clusterCompSpec.NodeFailureRecovery).
+		SetVolumeClaimRetentionPolicy(clusterCompSpec.VolumeClaimRetentionPolicy).
+		SetAutoscaling(clusterCompSpec.Autoscaling).
+		SetPodEndpoints(clusterCompSpec.PodEndpoints).
+		SetSecurityContext(clusterCompSpec.SecurityContext)
 	if customLabels != nil {
 		compBuilder.AddLabelsInMap(customLabels)
 	}
@@ -569,19 +569,28 @@ func (c *compDefLifecycleActionsConvertor) convert(args ...any) (any, error) {
 
 	if clusterCompDef.SwitchoverSpec != nil {
 		lifecycleActions.Switchover = c.convertSwitchover(clusterCompDef.SwitchoverSpec, clusterCompVer)
+	} else if clusterCompDef.Actions != nil && (clusterCompDef.Actions.Promote != nil || clusterCompDef.Actions.Demote != nil) {
+		lifecycleActions.Switchover = &appsv1alpha1.ComponentSwitchover{
+			WithCandidate:    clusterCompDef.Actions.Promote.DeepCopy(),
+			WithoutCandidate: clusterCompDef.Actions.Demote.DeepCopy(),
+		}
 	}
 
 	if clusterCompDef.PostStartSpec != nil {
 		lifecycleActions.PostProvision = c.convertPostProvision(clusterCompDef.PostStartSpec)
 	}
 
-	lifecycleActions.PreTerminate = nil
-	lifecycleActions.MemberJoin = nil
-	lifecycleActions.MemberLeave = nil
+	if clusterCompDef.PreTerminateSpec != nil {
+		lifecycleActions.PreTerminate = c.convertPreTerminate(clusterCompDef.PreTerminateSpec)
+	}
+
+	if clusterCompDef.Actions != nil {
+		lifecycleActions.MemberJoin = c.convertCustomAction(clusterCompDef.Actions.MemberJoin)
+		lifecycleActions.MemberLeave = c.convertCustomAction(clusterCompDef.Actions.MemberLeave)
+	}
+
 	lifecycleActions.Readonly = nil
 	lifecycleActions.Readwrite = nil
-	lifecycleActions.DataPopulate = nil
-	lifecycleActions.DataAssemble = nil
 	lifecycleActions.Reconfigure = nil
 	lifecycleActions.AccountProvision = nil
 
@@ -623,17 +632,23 @@ func (c *compDefLifecycleActionsConvertor) convertRoleProbe(clusterCompDef *apps
 	// if RSMSpec has role probe CustomHandler, use it first.
 	if clusterCompDef.RSMSpec != nil && clusterCompDef.RSMSpec.RoleProbe != nil && len(clusterCompDef.RSMSpec.RoleProbe.CustomHandler) > 0 {
 		// TODO(xingran): RSMSpec.RoleProbe.CustomHandler support multiple images and commands, but ComponentDefinition.LifeCycleAction.RoleProbe only support one image and command now.
+		rsmRoleProbe := clusterCompDef.RSMSpec.RoleProbe
 		return &appsv1alpha1.RoleProbe{
 			LifecycleActionHandler: appsv1alpha1.LifecycleActionHandler{
 				BuiltinHandler: &builtinHandler,
 				CustomHandler: &appsv1alpha1.Action{
-					Image: clusterCompDef.RSMSpec.RoleProbe.CustomHandler[0].Image,
+					Image: rsmRoleProbe.CustomHandler[0].Image,
 					Exec: &appsv1alpha1.ExecAction{
-						Command: clusterCompDef.RSMSpec.RoleProbe.CustomHandler[0].Command,
-						Args:    clusterCompDef.RSMSpec.RoleProbe.CustomHandler[0].Args,
+						Command: rsmRoleProbe.CustomHandler[0].Command,
+						Args:    rsmRoleProbe.CustomHandler[0].Args,
 					},
 				},
 			},
+			InitialDelaySeconds: rsmRoleProbe.InitialDelaySeconds,
+			TimeoutSeconds:      rsmRoleProbe.TimeoutSeconds,
+			PeriodSeconds:       rsmRoleProbe.PeriodSeconds,
+			SuccessThreshold:    rsmRoleProbe.SuccessThreshold,
+			FailureThreshold:    rsmRoleProbe.FailureThreshold,
 		}
 	}
 
@@ -643,8 +658,9 @@ func (c *compDefLifecycleActionsConvertor) convertRoleProbe(clusterCompDef *apps
 
 	clusterCompDefRoleProbe := clusterCompDef.Probes.RoleProbe
 	roleProbe := &appsv1alpha1.RoleProbe{
-		TimeoutSeconds: clusterCompDefRoleProbe.TimeoutSeconds,
-		PeriodSeconds:  clusterCompDefRoleProbe.PeriodSeconds,
+		TimeoutSeconds:   clusterCompDefRoleProbe.TimeoutSeconds,
+		PeriodSeconds:    clusterCompDefRoleProbe.PeriodSeconds,
+		FailureThreshold: clusterCompDefRoleProbe.FailureThreshold,
 	}
 
 	roleProbe.BuiltinHandler = &builtinHandler
@@ -684,6 +700,36 @@ func (c *compDefLifecycleActionsConvertor) convertPostProvision(postStart *appsv
 	}
 }
 
+func (c *compDefLifecycleActionsConvertor) convertPreTerminate(preTerminate *appsv1alpha1.PreTerminateAction) *appsv1alpha1.PreTerminateActionHandler {
+	if preTerminate == nil {
+		return nil
+	}
+
+	return &appsv1alpha1.PreTerminateActionHandler{
+		LifecycleActionHandler: appsv1alpha1.LifecycleActionHandler{
+			CustomHandler: &appsv1alpha1.Action{
+				Image: preTerminate.CmdExecutorConfig.Image,
+				Exec: &appsv1alpha1.ExecAction{
+					Command: preTerminate.CmdExecutorConfig.Command,
+					Args:    preTerminate.CmdExecutorConfig.Args,
+				},
+				Env:            preTerminate.CmdExecutorConfig.Env,
+				TimeoutSeconds: preTerminate.TimeoutSeconds,
+			},
+		},
+		FailurePolicy: preTerminate.FailurePolicy,
+	}
+}
+
+func (c *compDefLifecycleActionsConvertor) convertCustomAction(action *appsv1alpha1.Action) *appsv1alpha1.LifecycleActionHandler {
+	if action == nil {
+		return nil
+	}
+	return &appsv1alpha1.LifecycleActionHandler{
+		CustomHandler: action.DeepCopy(),
+	}
+}
+
 func (c *compDefLifecycleActionsConvertor) convertSwitchover(switchover *appsv1alpha1.SwitchoverSpec,
 	clusterCompVer *appsv1alpha1.ClusterComponentVersion) *appsv1alpha1.ComponentSwitchover {
 	spec := *switchover
@@ -944,6 +944,49 @@ var _ = Describe("Component Definition Convertor", func() {
 				Expect(*actions.PostProvision.CustomHandler.PreCondition).Should(BeEquivalentTo(appsv1alpha1.ComponentReadyPreConditionType))
 			})
 
+			It("actions", func() {
+				clusterCompDef.Probes.RoleProbe = nil
+				convertor := &compDefLifecycleActionsConvertor{}
+				newAction := func(cmd string) *appsv1alpha1.Action {
+					return &appsv1alpha1.Action{
+						Image: commandExecutorEnvItem.Image,
+						Exec: &appsv1alpha1.ExecAction{
+							Command: []string{cmd},
+						},
+					}
+				}
+				clusterCompDef.Actions = &appsv1alpha1.ClusterComponentActions{
+					Promote:     newAction("promote"),
+					Demote:      newAction("demote"),
+					MemberJoin:  newAction("member-join"),
+					MemberLeave: newAction("member-leave"),
+				}
+
+				res, err := convertor.convert(clusterCompDef)
+				Expect(err).Should(Succeed())
+				actions := res.(*appsv1alpha1.ComponentLifecycleActions)
+				Expect(actions.Switchover).ShouldNot(BeNil())
+				Expect(actions.Switchover.WithCandidate).Should(BeEquivalentTo(clusterCompDef.Actions.Promote))
+				Expect(actions.Switchover.WithoutCandidate).Should(BeEquivalentTo(clusterCompDef.Actions.Demote))
+				Expect(actions.MemberJoin.CustomHandler).Should(BeEquivalentTo(clusterCompDef.Actions.MemberJoin))
+				Expect(actions.MemberLeave.CustomHandler).Should(BeEquivalentTo(clusterCompDef.Actions.MemberLeave))
+
+				By("the switchoverSpec takes precedence over the promote and demote actions")
+				clusterCompDef.SwitchoverSpec = &appsv1alpha1.SwitchoverSpec{
+					WithCandidate: &appsv1alpha1.SwitchoverAction{
+						CmdExecutorConfig: &appsv1alpha1.CmdExecutorConfig{
+							CommandExecutorEnvItem: *commandExecutorEnvItem,
+							CommandExecutorItem:    *commandExecutorItem,
+						},
+					},
+				}
+				res, err = convertor.convert(clusterCompDef)
+				Expect(err).Should(Succeed())
+				actions = res.(*appsv1alpha1.ComponentLifecycleActions)
+				Expect(actions.Switchover.WithCandidate.Exec.Command).Should(BeEquivalentTo(commandExecutorItem.Command))
+				Expect(actions.Switchover.WithoutCandidate).Should(BeNil())
+			})
+
 			It("role probe", func() {
 				convertor := &compDefLifecycleActionsConvertor{}
 				res, err := convertor.convert(clusterCompDef)
@@ -959,8 +1002,9 @@ var _ = Describe("Component Definition Convertor", func() {
 					LifecycleActionHandler: appsv1alpha1.LifecycleActionHandler{
 						BuiltinHandler: wesqlBuiltinHandler(),
 					},
-					TimeoutSeconds: clusterCompDef.Probes.RoleProbe.TimeoutSeconds,
-					PeriodSeconds:  clusterCompDef.Probes.RoleProbe.PeriodSeconds,
+					TimeoutSeconds:   clusterCompDef.Probes.RoleProbe.TimeoutSeconds,
+					PeriodSeconds:    clusterCompDef.Probes.RoleProbe.PeriodSeconds,
+					FailureThreshold: clusterCompDef.Probes.RoleProbe.FailureThreshold,
 				}
 				Expect(actions.RoleProbe).ShouldNot(BeNil())
 				Expect(*actions.RoleProbe).Should(BeEquivalentTo(*expectedRoleProbe))
@@ -983,6 +1027,8 @@ var _ = Describe("Component Definition Convertor", func() {
 								Args:    mockArgs,
 							},
 						},
+						PeriodSeconds:    5,
+						FailureThreshold: 4,
 					},
 				}
 				res, err := convertor.convert(clusterCompDef)
@@ -995,6 +1041,8 @@ var _ = Describe("Component Definition Convertor", func() {
 				Expect(actions.RoleProbe.CustomHandler.Image).Should(BeEquivalentTo("mock-rsm-role-probe-image"))
 				Expect(actions.RoleProbe.CustomHandler.Exec.Command).Should(BeEquivalentTo(mockCommand))
 				Expect(actions.RoleProbe.CustomHandler.Exec.Args).Should(BeEquivalentTo(mockArgs))
+				Expect(actions.RoleProbe.PeriodSeconds).Should(BeEquivalentTo(5))
+				Expect(actions.RoleProbe.FailureThreshold).Should(BeEquivalentTo(4))
 			})
 		})
 
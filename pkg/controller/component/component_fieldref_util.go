@@ -71,7 +71,7 @@ func buildComponentRef(clusterDef *appsv1alpha1.ClusterDefinition,
 						return err
 					}
 				case appsv1alpha1.FromServiceRef:
-					if env.Value, err = resolveServiceRef(cluster.Name, referredComponents, referredComponentDef); err != nil {
+					if env.Value, err = resolveServiceRef(clusterDef.Spec.NamingTemplate, cluster.Name, referredComponents, referredComponentDef); err != nil {
 						return err
 					}
 				case appsv1alpha1.FromHeadlessServiceRef:
@@ -82,7 +82,7 @@ func buildComponentRef(clusterDef *appsv1alpha1.ClusterDefinition,
 							return fmt.Errorf(errMsg)
 						}
 					}
-					env.Value = resolveHeadlessServiceFieldRef(refEnv.ValueFrom, cluster, referredComponents)
+					env.Value = resolveHeadlessServiceFieldRef(clusterDef.Spec.NamingTemplate, refEnv.ValueFrom, cluster, referredComponents, referredComponentDef.Name)
 				}
 			}
 
@@ -120,18 +120,19 @@ func resolveFieldRef(valueFrom *appsv1alpha1.ComponentValueFrom, components []ap
 	}
 }
 
-func resolveServiceRef(clusterName string, components []appsv1alpha1.ClusterComponentSpec, componentDef *appsv1alpha1.ClusterComponentDefinition) (string, error) {
+func resolveServiceRef(namingTemplate *appsv1alpha1.NamingTemplate, clusterName string,
+	components []appsv1alpha1.ClusterComponentSpec, componentDef *appsv1alpha1.ClusterComponentDefinition) (string, error) {
 	if componentDef.Service == nil {
 		return "", fmt.Errorf("componentDef %s does not have service", componentDef.Name)
 	}
 	if len(components) != 1 {
 		return "", fmt.Errorf("expect one component but got %d for componentDef %s", len(components), componentDef.Name)
 	}
-	return fmt.Sprintf("%s-%s", clusterName, components[0].Name), nil
+	return namingTemplate.GenerateComponentServiceName(clusterName, components[0].Name, componentDef.Name, ""), nil
 }
 
-func resolveHeadlessServiceFieldRef(valueFrom *appsv1alpha1.ComponentValueFrom,
-	cluster *appsv1alpha1.Cluster, components []appsv1alpha1.ClusterComponentSpec) string {
+func resolveHeadlessServiceFieldRef(namingTemplate *appsv1alpha1.NamingTemplate, valueFrom *appsv1alpha1.ComponentValueFrom,
+	cluster *appsv1alpha1.Cluster, components []appsv1alpha1.ClusterComponentSpec, compDefName string) string {
 
 	preDefineVars := []string{"POD_NAME", "POD_FQDN", "POD_ORDINAL"}
 
@@ -147,10 +148,10 @@ func resolveHeadlessServiceFieldRef(valueFrom *appsv1alpha1.ComponentValueFrom,
 	hosts := make([]string, 0)
 	for _, comp := range components {
 		for i := int32(0); i < comp.Replicas; i++ {
-			qualifiedName := fmt.Sprintf("%s-%s", cluster.Name, comp.Name)
 			podOrdinal := strconv.Itoa(int(i))
-			podName := fmt.Sprintf("%s-%s", qualifiedName, podOrdinal)
-			podFQDN := fmt.Sprintf("%s.%s-headless.%s.svc", podName, qualifiedName, cluster.Namespace)
+			podName := namingTemplate.GenerateComponentPodName(cluster.Name, comp.Name, compDefName, int(i))
+			podFQDN := fmt.Sprintf("%s.%s.%s.svc", podName,
+				namingTemplate.GenerateComponentHeadlessServiceName(cluster.Name, comp.Name, compDefName), cluster.Namespace)
 
 			valuesToReplace := []string{podName, podFQDN, podOrdinal}
 
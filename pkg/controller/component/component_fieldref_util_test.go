@@ -110,7 +110,7 @@ var _ = Describe("ComponentRef Fields Tests", func() {
 			Expect(len(components)).To(Equal(1))
 
 			By("lookup service name, should fail")
-			_, err := resolveServiceRef(cluster.Name, components, componentDef)
+			_, err := resolveServiceRef(nil, cluster.Name, components, componentDef)
 			if componentDef.Service != nil {
 				Expect(err).To(BeNil())
 			} else {
@@ -136,7 +136,7 @@ var _ = Describe("ComponentRef Fields Tests", func() {
 			Expect(len(components)).To(Equal(2))
 
 			By("lookup service name, should fail")
-			_, err := resolveServiceRef(cluster.Name, components, componentDef)
+			_, err := resolveServiceRef(nil, cluster.Name, components, componentDef)
 			Expect(err).NotTo(BeNil())
 			Expect(err.Error()).To(ContainSubstring("expect one component but got"))
 		})
@@ -156,7 +156,7 @@ var _ = Describe("ComponentRef Fields Tests", func() {
 			Expect(len(components)).To(Equal(1))
 
 			By("lookup service name, should fail")
-			value, err := resolveServiceRef(cluster.Name, components, componentDef)
+			value, err := resolveServiceRef(nil, cluster.Name, components, componentDef)
 			Expect(err).To(BeNil())
 			Expect(value).To(Equal(fmt.Sprintf("%s-%s", cluster.Name, referredCompName)))
 		})
@@ -184,7 +184,7 @@ var _ = Describe("ComponentRef Fields Tests", func() {
 				JoinWith: "",
 			}
 
-			value := resolveHeadlessServiceFieldRef(valueFrom, cluster, components)
+			value := resolveHeadlessServiceFieldRef(nil, valueFrom, cluster, components, componentDef.Name)
 			addrs := strings.Split(value, ",")
 			Expect(len(addrs)).To(Equal(int(replicas)))
 			for i, addr := range addrs {
@@ -30,12 +30,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -87,6 +89,7 @@ func ReconcileCompPostProvision(ctx context.Context,
 	}
 
 	err = CheckJobSucceed(ctx, cli, cluster, job.Name)
+	setActionJobCondition(comp, appsv1alpha1.ConditionTypePostProvisioned, job.Name, err)
 	if err != nil {
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorWaitCacheRefresh) {
 			return nil
@@ -177,6 +180,7 @@ func createPostProvisionJobIfNotExist(ctx context.Context,
 	}
 
 	// create the postProvisionJob if not exist
+	tracing.InjectJob(ctx, postProvisionJob)
 	if err := cli.Create(ctx, postProvisionJob); err != nil {
 		return postProvisionJob, err
 	}
@@ -203,6 +207,20 @@ func renderPostProvisionCmdJob(ctx context.Context,
 		return nil, errors.New("postProvision customHandler only support exec command by now, please check your customHandler spec.")
 	}
 
+	return renderActionCmdJob(ctx, cli, cluster, synthesizeComp, synthesizeComp.LifecycleActions.PostProvision,
+		genPostProvisionJobName(cluster.Name, synthesizeComp.Name), kbPostProvisionJobContainerName,
+		getPostProvisionCmdJobLabel(cluster.Name, synthesizeComp.Name))
+}
+
+// renderActionCmdJob renders the job to execute the exec command of a lifecycle action,
+// the job mounts the script volumes of the component pods and inherits the envs of them.
+func renderActionCmdJob(ctx context.Context,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster,
+	synthesizeComp *SynthesizedComponent,
+	action *appsv1alpha1.LifecycleActionHandler,
+	jobName, containerName string,
+	labels map[string]string) (*batchv1.Job, error) {
 	podList, err := GetComponentPodList(ctx, cli, *cluster, synthesizeComp.Name)
 	if err != nil {
 		return nil, err
@@ -246,34 +264,34 @@ func renderPostProvisionCmdJob(ctx context.Context,
 		return volumes, volumeMounts
 	}
 
-	renderJob := func(postProvisionSpec *appsv1alpha1.LifecycleActionHandler, envs []corev1.EnvVar, envFroms []corev1.EnvFromSource) (*batchv1.Job, error) {
+	renderJob := func(actionSpec *appsv1alpha1.LifecycleActionHandler, envs []corev1.EnvVar, envFroms []corev1.EnvFromSource) (*batchv1.Job, error) {
 		var (
-			postProvisionCustomHandler = postProvisionSpec.CustomHandler
+			customHandler = actionSpec.CustomHandler
 		)
 		volumes, volumeMounts := renderJobPodVolumes()
-		jobName := genPostProvisionJobName(cluster.Name, synthesizeComp.Name)
 		job := &batchv1.Job{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: cluster.Namespace,
 				Name:      jobName,
-				Labels:    getPostProvisionCmdJobLabel(cluster.Name, synthesizeComp.Name),
+				Labels:    labels,
 			},
 			Spec: batchv1.JobSpec{
 				Template: corev1.PodTemplateSpec{
 					ObjectMeta: metav1.ObjectMeta{
-						Namespace: cluster.Namespace,
-						Name:      jobName,
+						Namespace:   cluster.Namespace,
+						Name:        jobName,
+						Annotations: intctrlutil.BuildServiceMeshJobAnnotations(tplPod.Annotations[constant.ServiceMeshAnnotationKey]),
 					},
 					Spec: corev1.PodSpec{
 						Volumes:       volumes,
 						RestartPolicy: corev1.RestartPolicyNever,
 						Containers: []corev1.Container{
 							{
-								Name:            kbPostProvisionJobContainerName,
-								Image:           postProvisionCustomHandler.Image,
+								Name:            containerName,
+								Image:           customHandler.Image,
 								ImagePullPolicy: corev1.PullIfNotPresent,
-								Command:         postProvisionCustomHandler.Exec.Command,
-								Args:            postProvisionCustomHandler.Exec.Args,
+								Command:         customHandler.Exec.Command,
+								Args:            customHandler.Exec.Args,
 								Env:             envs,
 								EnvFrom:         envFroms,
 								VolumeMounts:    volumeMounts,
@@ -286,18 +304,25 @@ func renderPostProvisionCmdJob(ctx context.Context,
 		if len(cluster.Spec.Tolerations) > 0 {
 			job.Spec.Template.Spec.Tolerations = cluster.Spec.Tolerations
 		}
+		// the job is failed once the timeout is exceeded or all retries are used up
+		if customHandler.TimeoutSeconds > 0 {
+			job.Spec.ActiveDeadlineSeconds = pointer.Int64(int64(customHandler.TimeoutSeconds))
+		}
+		if customHandler.RetryPolicy != nil {
+			job.Spec.BackoffLimit = pointer.Int32(int32(customHandler.RetryPolicy.MaxRetries))
+		}
 		for i := range job.Spec.Template.Spec.Containers {
 			intctrlutil.InjectZeroResourcesLimitsIfEmpty(&job.Spec.Template.Spec.Containers[i])
 		}
 		return job, nil
 	}
 
-	envs, envFroms, err := buildPostProvisionEnvs(ctx, cli, cluster, synthesizeComp, pods, &tplPod)
+	envs, envFroms, err := buildActionEnvs(ctx, cli, cluster, action, pods, &tplPod)
 	if err != nil {
 		return nil, err
 	}
 
-	job, err := renderJob(synthesizeComp.LifecycleActions.PostProvision, envs, envFroms)
+	job, err := renderJob(action, envs, envFroms)
 	if err != nil {
 		return nil, err
 	}
@@ -305,19 +330,18 @@ func renderPostProvisionCmdJob(ctx context.Context,
 	return job, nil
 }
 
-// buildPostProvisionEnvs builds the postProvision command job envs.
-func buildPostProvisionEnvs(ctx context.Context,
+// buildActionEnvs builds the lifecycle action command job envs.
+func buildActionEnvs(ctx context.Context,
 	cli client.Client,
 	cluster *appsv1alpha1.Cluster,
-	synthesizeComp *SynthesizedComponent,
+	action *appsv1alpha1.LifecycleActionHandler,
 	pods []corev1.Pod,
 	tplPod *corev1.Pod) ([]corev1.EnvVar, []corev1.EnvFromSource, error) {
 	var workloadEnvs []corev1.EnvVar
 	var workloadEnvFroms []corev1.EnvFromSource
 
-	if synthesizeComp != nil && synthesizeComp.LifecycleActions != nil &&
-		synthesizeComp.LifecycleActions.PostProvision != nil && synthesizeComp.LifecycleActions.PostProvision.CustomHandler != nil {
-		workloadEnvs = append(workloadEnvs, synthesizeComp.LifecycleActions.PostProvision.CustomHandler.Env...)
+	if action != nil && action.CustomHandler != nil {
+		workloadEnvs = append(workloadEnvs, action.CustomHandler.Env...)
 	}
 
 	if tplPod != nil && len(tplPod.Spec.Containers) > 0 {
@@ -0,0 +1,165 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// pre-terminate constants
+const (
+	kbPreTerminateJobLabelKey      = "kubeblocks.io/pre-terminate-job"
+	kbPreTerminateJobLabelValue    = "kb-pre-terminate-job"
+	kbPreTerminateJobNamePrefix    = "kb-pre-terminate-job"
+	kbPreTerminateJobContainerName = "kb-pre-terminate-job-container"
+)
+
+// ReconcileCompPreTerminate reconciles the component-level preTerminate command, it should be called when the component is being deleted.
+// Returns:
+// - bool: whether the preTerminate action is done, the deletion of the component should proceed only after it's done
+// - error: any error that occurred during the handling, including the job failure
+func ReconcileCompPreTerminate(ctx context.Context,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster,
+	comp *appsv1alpha1.Component,
+	synthesizeComp *SynthesizedComponent) (bool, error) {
+	if !checkPreTerminateAction(synthesizeComp) {
+		return true, nil
+	}
+
+	// the component has never been provisioned or the pods have gone, nothing to do
+	podList, err := GetComponentPodList(ctx, cli, *cluster, synthesizeComp.Name)
+	if err != nil {
+		return false, err
+	}
+	jobName := genPreTerminateJobName(cluster.Name, synthesizeComp.Name)
+	if len(podList.Items) == 0 && !checkPreTerminateJobExist(ctx, cli, cluster, jobName) {
+		return true, nil
+	}
+
+	job, err := createPreTerminateJobIfNotExist(ctx, cli, cluster, comp, synthesizeComp)
+	if err != nil {
+		return false, err
+	}
+
+	err = CheckJobSucceed(ctx, cli, cluster, job.Name)
+	setActionJobCondition(comp, appsv1alpha1.ConditionTypePreTerminated, job.Name, err)
+	if err != nil {
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorWaitCacheRefresh) {
+			return false, nil
+		}
+		// the deletion proceeds if the failure is ignored, the error is still returned to be reported
+		return isPreTerminateFailureIgnored(synthesizeComp), err
+	}
+	return true, nil
+}
+
+func isPreTerminateFailureIgnored(synthesizeComp *SynthesizedComponent) bool {
+	return synthesizeComp.LifecycleActions.PreTerminate.FailurePolicy == appsv1alpha1.FailurePolicyIgnore
+}
+
+// createPreTerminateJobIfNotExist creates a job to execute component-level preTerminate command, each component only has a corresponding job.
+func createPreTerminateJobIfNotExist(ctx context.Context,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster,
+	comp *appsv1alpha1.Component,
+	synthesizeComp *SynthesizedComponent) (*batchv1.Job, error) {
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: genPreTerminateJobName(cluster.Name, synthesizeComp.Name)}
+	existJob := &batchv1.Job{}
+	exist, err := intctrlutil.CheckResourceExists(ctx, cli, key, existJob)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return existJob, nil
+	}
+
+	preTerminateJob, err := renderPreTerminateCmdJob(ctx, cli, cluster, synthesizeComp)
+	if err != nil {
+		return nil, err
+	}
+	// set the controller reference, the job will be garbage collected with the component
+	if err := intctrlutil.SetControllerReference(comp, preTerminateJob); err != nil {
+		return nil, err
+	}
+	tracing.InjectJob(ctx, preTerminateJob)
+	if err := cli.Create(ctx, preTerminateJob); err != nil {
+		return nil, err
+	}
+	return preTerminateJob, nil
+}
+
+func checkPreTerminateJobExist(ctx context.Context, cli client.Client, cluster *appsv1alpha1.Cluster, jobName string) bool {
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: jobName}
+	exist, _ := intctrlutil.CheckResourceExists(ctx, cli, key, &batchv1.Job{})
+	return exist
+}
+
+// renderPreTerminateCmdJob renders the preTerminate command job.
+func renderPreTerminateCmdJob(ctx context.Context,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster,
+	synthesizeComp *SynthesizedComponent) (*batchv1.Job, error) {
+	if !checkPreTerminateAction(synthesizeComp) {
+		return nil, errors.New("preTerminate CustomHandler spec not found")
+	}
+
+	if synthesizeComp.LifecycleActions.PreTerminate.CustomHandler.Exec == nil {
+		return nil, errors.New("preTerminate customHandler only support exec command by now, please check your customHandler spec.")
+	}
+
+	return renderActionCmdJob(ctx, cli, cluster, synthesizeComp, &synthesizeComp.LifecycleActions.PreTerminate.LifecycleActionHandler,
+		genPreTerminateJobName(cluster.Name, synthesizeComp.Name), kbPreTerminateJobContainerName,
+		getPreTerminateCmdJobLabel(cluster.Name, synthesizeComp.Name))
+}
+
+// genPreTerminateJobName generates the preTerminate job name.
+func genPreTerminateJobName(clusterName, componentName string) string {
+	return fmt.Sprintf("%s-%s-%s", kbPreTerminateJobNamePrefix, clusterName, componentName)
+}
+
+// getPreTerminateCmdJobLabel gets the labels for job that execute the preTerminate commands.
+func getPreTerminateCmdJobLabel(clusterName, componentName string) map[string]string {
+	return map[string]string{
+		constant.AppInstanceLabelKey:    clusterName,
+		constant.KBAppComponentLabelKey: componentName,
+		constant.AppManagedByLabelKey:   constant.AppName,
+		kbPreTerminateJobLabelKey:       kbPreTerminateJobLabelValue,
+	}
+}
+
+func checkPreTerminateAction(synthesizeComp *SynthesizedComponent) bool {
+	if synthesizeComp == nil || synthesizeComp.LifecycleActions == nil ||
+		synthesizeComp.LifecycleActions.PreTerminate == nil || synthesizeComp.LifecycleActions.PreTerminate.CustomHandler == nil {
+		return false
+	}
+	return true
+}
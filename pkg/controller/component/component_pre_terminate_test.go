@@ -0,0 +1,151 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("Component PreTerminate Test", func() {
+	const (
+		clusterDefName     = "test-clusterdef"
+		clusterVersionName = "test-clusterversion"
+		clusterName        = "test-cluster"
+		mysqlCompDefName   = "replicasets"
+		mysqlCompName      = "mysql"
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+
+		// namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("has the ReconcileCompPreTerminate function", func() {
+		newSynthesizedComp := func(cluster *appsv1alpha1.Cluster, failurePolicy appsv1alpha1.FailurePolicyType) *SynthesizedComponent {
+			return &SynthesizedComponent{
+				Namespace:   cluster.Namespace,
+				ClusterName: cluster.Name,
+				Name:        mysqlCompName,
+				LifecycleActions: &appsv1alpha1.ComponentLifecycleActions{
+					PreTerminate: &appsv1alpha1.PreTerminateActionHandler{
+						LifecycleActionHandler: appsv1alpha1.LifecycleActionHandler{
+							CustomHandler: &appsv1alpha1.Action{
+								Exec:           &appsv1alpha1.ExecAction{Command: []string{"echo"}},
+								TimeoutSeconds: 10,
+							},
+						},
+						FailurePolicy: failurePolicy,
+					},
+				},
+			}
+		}
+
+		createJob := func(cluster *appsv1alpha1.Cluster, conditions ...batchv1.JobCondition) *batchv1.Job {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      genPreTerminateJobName(cluster.Name, mysqlCompName),
+					Namespace: cluster.Namespace,
+					Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Containers: []corev1.Container{
+								{
+									Name:  "kubeblocks",
+									Image: "busybox",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, job)).Should(Succeed())
+			if len(conditions) > 0 {
+				Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(job), func(job *batchv1.Job) {
+					job.Status.Conditions = conditions
+				})()).Should(Succeed())
+			}
+			return job
+		}
+
+		It("should block or proceed the deletion by the result of the preTerminate job", func() {
+			// the job exceeding its deadline is marked as FailureTarget before Failed
+			timedOut := []batchv1.JobCondition{
+				{Type: "FailureTarget", Status: corev1.ConditionTrue, Reason: "DeadlineExceeded"},
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded"},
+			}
+			tests := []struct {
+				name          string
+				failurePolicy appsv1alpha1.FailurePolicyType
+				conditions    []batchv1.JobCondition
+				expectDone    bool
+				expectErr     bool
+			}{
+				{name: "running", expectDone: false, expectErr: false},
+				{name: "succeeded", conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}, expectDone: true},
+				{name: "timed out and blocked", failurePolicy: appsv1alpha1.FailurePolicyFail, conditions: timedOut, expectDone: false, expectErr: true},
+				{name: "timed out and blocked by default", conditions: timedOut, expectDone: false, expectErr: true},
+				{name: "timed out and ignored", failurePolicy: appsv1alpha1.FailurePolicyIgnore, conditions: timedOut, expectDone: true, expectErr: true},
+			}
+			for _, tt := range tests {
+				By("the preTerminate job is " + tt.name)
+				cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName, clusterVersionName).
+					WithRandomName().
+					AddComponent(mysqlCompName, mysqlCompDefName).
+					GetObject()
+				createJob(cluster, tt.conditions...)
+
+				comp := &appsv1alpha1.Component{}
+				done, err := ReconcileCompPreTerminate(ctx, k8sClient, cluster, comp, newSynthesizedComp(cluster, tt.failurePolicy))
+				Expect(done).Should(Equal(tt.expectDone))
+				if tt.expectErr {
+					Expect(err).Should(HaveOccurred())
+				} else {
+					Expect(err).ShouldNot(HaveOccurred())
+				}
+			}
+		})
+	})
+})
@@ -153,6 +153,17 @@ var _ = Describe("Component", func() {
 			Expect(synthesizeComp).ShouldNot(BeNil())
 			Expect(synthesizeComp.PodSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.TopologyKey).Should(Equal("topology.kubernetes.io/zone"))
 			Expect(synthesizeComp.PodSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey).Should(Equal("kubernetes.io/hostname"))
+			Expect(synthesizeComp.PodSpec.Affinity.NodeAffinity).Should(BeNil())
+			Expect(synthesizeComp.PodSpec.Tolerations).ShouldNot(ContainElement(HaveField("Key", constant.DedicatedNodeLabelKey)))
+
+			By("require the dedicated nodes")
+			compAffinity := &appsv1alpha1.Affinity{Tenancy: appsv1alpha1.DedicatedNode, DedicatedNodes: true}
+			podAffinity, err := BuildPodAffinity(cluster.Name, synthesizeComp.Name, compAffinity)
+			Expect(err).Should(Succeed())
+			Expect(podAffinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions).Should(
+				ContainElement(corev1.NodeSelectorRequirement{Key: constant.DedicatedNodeLabelKey, Operator: corev1.NodeSelectorOpExists}))
+			Expect(BuildDedicatedNodeTolerations(compAffinity)).Should(ContainElement(corev1.Toleration{
+				Key: constant.DedicatedNodeLabelKey, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}))
 		})
 
 		It("build monitor correctly", func() {
@@ -443,3 +454,91 @@ func TestGetConfigSpecByName(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSidecars(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	disabled := false
+	clusterCompDef := &appsv1alpha1.ClusterComponentDefinition{
+		Sidecars: []appsv1alpha1.SidecarSpec{
+			{
+				Name:      "exporter",
+				Container: corev1.Container{Name: "ignored", Image: "exporter:latest"},
+			},
+			{
+				Name:      "proxy",
+				Enabled:   &disabled,
+				Container: corev1.Container{Image: "proxy:latest"},
+				Volumes: []corev1.Volume{
+					{Name: "proxy-config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				},
+			},
+		},
+	}
+	newSynthesizedComp := func() *SynthesizedComponent {
+		return &SynthesizedComponent{
+			PodSpec: &corev1.PodSpec{Containers: []corev1.Container{{Name: "mysql"}}},
+		}
+	}
+
+	// the sidecars are injected by default
+	synthesizeComp := newSynthesizedComp()
+	buildSidecars(clusterCompDef, &appsv1alpha1.ClusterComponentSpec{}, synthesizeComp)
+	g.Expect(synthesizeComp.PodSpec.Containers).Should(HaveLen(2))
+	g.Expect(synthesizeComp.PodSpec.Containers[1].Name).Should(Equal("exporter"))
+	g.Expect(synthesizeComp.PodSpec.Volumes).Should(BeEmpty())
+
+	// the cluster component opts in and opts out
+	synthesizeComp = newSynthesizedComp()
+	buildSidecars(clusterCompDef, &appsv1alpha1.ClusterComponentSpec{
+		Sidecars: []appsv1alpha1.ClusterComponentSidecar{
+			{Name: "exporter", Enabled: false},
+			{Name: "proxy", Enabled: true},
+		},
+	}, synthesizeComp)
+	g.Expect(synthesizeComp.PodSpec.Containers).Should(HaveLen(2))
+	g.Expect(synthesizeComp.PodSpec.Containers[1].Name).Should(Equal("proxy"))
+	g.Expect(synthesizeComp.PodSpec.Volumes).Should(HaveLen(1))
+	g.Expect(synthesizeComp.PodSpec.Volumes[0].Name).Should(Equal("proxy-config"))
+
+	// the sidecar running the connection pooler is injected with the pool settings
+	poolSize := int32(20)
+	synthesizeComp = newSynthesizedComp()
+	buildSidecars(clusterCompDef, &appsv1alpha1.ClusterComponentSpec{
+		ConnectionPooler: &appsv1alpha1.ClusterComponentConnectionPooler{
+			Sidecar:         "proxy",
+			PoolMode:        appsv1alpha1.TransactionPoolMode,
+			DefaultPoolSize: &poolSize,
+		},
+	}, synthesizeComp)
+	g.Expect(synthesizeComp.PodSpec.Containers).Should(HaveLen(3))
+	g.Expect(synthesizeComp.PodSpec.Containers[2].Name).Should(Equal("proxy"))
+	g.Expect(synthesizeComp.PodSpec.Containers[2].Env).Should(ConsistOf(
+		corev1.EnvVar{Name: constant.KBEnvPoolerMode, Value: "transaction"},
+		corev1.EnvVar{Name: constant.KBEnvPoolerDefaultPoolSize, Value: "20"},
+	))
+}
+
+func TestAppendOrOverrideInitContainers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	initContainers := []corev1.Container{
+		{Name: "init-data", Image: "init:1.0"},
+		{Name: "init-config", Image: "init:1.0"},
+	}
+	for _, c := range []corev1.Container{
+		{Name: "migrate-schema", Image: "migrate:2.0"},
+		{Name: "init-config", Image: "init:2.0"},
+		{Name: "check-upgrade", Image: "check:2.0"},
+	} {
+		initContainers = appendOrOverrideContainerAttr(initContainers, c)
+	}
+
+	names := make([]string, 0, len(initContainers))
+	for _, c := range initContainers {
+		names = append(names, c.Name)
+	}
+	g.Expect(names).Should(Equal([]string{"init-data", "init-config", "migrate-schema", "check-upgrade"}))
+	g.Expect(initContainers[0].Image).Should(Equal("init:1.0"))
+	g.Expect(initContainers[1].Image).Should(Equal("init:2.0"))
+}
@@ -22,8 +22,12 @@ package component
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -33,6 +37,11 @@ import (
 
 const (
 	KBJobTTLSecondsAfterFinished = 5
+
+	// reasons of the lifecycle action conditions
+	reasonActionJobRunning   = "JobRunning"
+	reasonActionJobSucceeded = "JobSucceeded"
+	reasonActionJobFailed    = "JobFailed"
 )
 
 // GetJobWithLabels gets the job list with the specified labels.
@@ -103,16 +112,40 @@ func CheckJobSucceed(ctx context.Context,
 	if !exists {
 		return errors.New("job not exist, pls check")
 	}
-	jobStatusConditions := currentJob.Status.Conditions
-	if len(jobStatusConditions) > 0 {
-		switch jobStatusConditions[0].Type {
+	// the terminal condition may not be the first one, e.g., FailureTarget is added before Failed
+	for _, cond := range currentJob.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
 		case batchv1.JobComplete:
 			return nil
 		case batchv1.JobFailed:
-			return errors.New("job failed, pls check")
-		default:
-			return intctrlutil.NewErrorf(intctrlutil.ErrorWaitCacheRefresh, "requeue to waiting for job %s finished.", key.Name)
+			return fmt.Errorf("job failed, reason: %s, pls check", cond.Reason)
 		}
 	}
 	return intctrlutil.NewErrorf(intctrlutil.ErrorWaitCacheRefresh, "requeue to waiting for job %s finished.", key.Name)
 }
+
+// setActionJobCondition records the result of the lifecycle action job, which is checked by CheckJobSucceed, as a condition of the component.
+func setActionJobCondition(comp *appsv1alpha1.Component, conditionType, jobName string, jobErr error) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		ObservedGeneration: comp.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             reasonActionJobSucceeded,
+		Message:            fmt.Sprintf("job %s executed successfully", jobName),
+	}
+	switch {
+	case jobErr == nil:
+	case intctrlutil.IsTargetError(jobErr, intctrlutil.ErrorWaitCacheRefresh):
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = reasonActionJobRunning
+		condition.Message = fmt.Sprintf("job %s is running", jobName)
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reasonActionJobFailed
+		condition.Message = fmt.Sprintf("job %s failed: %s", jobName, jobErr.Error())
+	}
+	meta.SetStatusCondition(&comp.Status.Conditions, condition)
+}
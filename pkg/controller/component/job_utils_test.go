@@ -20,16 +20,20 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package component
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 )
 
@@ -124,5 +128,29 @@ var _ = Describe("Job Utils Test", func() {
 			By("delete job with label")
 			Expect(CleanJobWithLabels(ctx, k8sClient, cluster, map[string]string{labelKey: constant.AppName})).ShouldNot(HaveOccurred())
 		})
+
+		It("should record the result of the action job as a condition", func() {
+			comp := &appsv1alpha1.Component{}
+			jobName := "test-action-job"
+
+			By("job is running")
+			setActionJobCondition(comp, appsv1alpha1.ConditionTypePreTerminated, jobName,
+				intctrlutil.NewErrorf(intctrlutil.ErrorWaitCacheRefresh, "requeue to waiting for job %s finished.", jobName))
+			cond := meta.FindStatusCondition(comp.Status.Conditions, appsv1alpha1.ConditionTypePreTerminated)
+			Expect(cond).ShouldNot(BeNil())
+			Expect(cond.Status).Should(Equal(metav1.ConditionUnknown))
+
+			By("job failed")
+			setActionJobCondition(comp, appsv1alpha1.ConditionTypePreTerminated, jobName, errors.New("job failed, pls check"))
+			cond = meta.FindStatusCondition(comp.Status.Conditions, appsv1alpha1.ConditionTypePreTerminated)
+			Expect(cond.Status).Should(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).Should(Equal(reasonActionJobFailed))
+
+			By("job succeeded")
+			setActionJobCondition(comp, appsv1alpha1.ConditionTypePreTerminated, jobName, nil)
+			cond = meta.FindStatusCondition(comp.Status.Conditions, appsv1alpha1.ConditionTypePreTerminated)
+			Expect(cond.Status).Should(Equal(metav1.ConditionTrue))
+			Expect(comp.Status.Conditions).Should(HaveLen(1))
+		})
 	})
 })
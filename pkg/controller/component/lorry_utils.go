@@ -376,7 +376,7 @@ func getBuiltinActionHandler(synthesizeComp *SynthesizedComponent) appsv1alpha1.
 
 	actions := []*appsv1alpha1.LifecycleActionHandler{
 		synthesizeComp.LifecycleActions.PostProvision,
-		synthesizeComp.LifecycleActions.PreTerminate,
+		synthesizeComp.LifecycleActions.PreTerminate.GetLifecycleActionHandler(),
 		synthesizeComp.LifecycleActions.MemberJoin,
 		synthesizeComp.LifecycleActions.MemberLeave,
 		synthesizeComp.LifecycleActions.Readonly,
@@ -409,15 +409,17 @@ func getActionCommandsWithExecImageOrContainerName(synthesizeComp *SynthesizedCo
 
 	actions := map[string]*appsv1alpha1.LifecycleActionHandler{
 		constant.PostProvisionAction: synthesizeComp.LifecycleActions.PostProvision,
-		constant.PreTerminateAction:  synthesizeComp.LifecycleActions.PreTerminate,
+		constant.PreTerminateAction:  synthesizeComp.LifecycleActions.PreTerminate.GetLifecycleActionHandler(),
 		constant.MemberJoinAction:    synthesizeComp.LifecycleActions.MemberJoin,
 		constant.MemberLeaveAction:   synthesizeComp.LifecycleActions.MemberLeave,
 		constant.ReadonlyAction:      synthesizeComp.LifecycleActions.Readonly,
 		constant.ReadWriteAction:     synthesizeComp.LifecycleActions.Readwrite,
+
+		constant.AccountProvisionAction:        synthesizeComp.LifecycleActions.AccountProvision,
+		constant.AccountPasswordRotationAction: synthesizeComp.LifecycleActions.AccountPasswordRotation,
 		// "dataPopulate":     synthesizeComp.LifecycleActions.DataPopulate,
 		// "dataAssemble":     synthesizeComp.LifecycleActions.DataAssemble,
 		// "reconfigure":      synthesizeComp.LifecycleActions.Reconfigure,
-		// "accountProvision": synthesizeComp.LifecycleActions.AccountProvision,
 	}
 
 	if synthesizeComp.LifecycleActions.RoleProbe != nil {
@@ -20,12 +20,31 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package component
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 )
 
+// PrometheusRuleGVK is the GroupVersionKind of the PrometheusRule of the Prometheus Operator.
+var PrometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+// grafanaDashboardLabelKey is the label to select the ConfigMaps of the dashboards by the Grafana sidecar.
+const grafanaDashboardLabelKey = "grafana_dashboard"
+
 func buildMonitorConfigLegacy(
 	compDef *appsv1alpha1.ClusterComponentDefinition,
 	compSpec *appsv1alpha1.ClusterComponentSpec,
@@ -53,6 +72,7 @@ func buildMonitorConfig(
 			BuiltIn:    false,
 			ScrapePath: monitorConfig.Exporter.ScrapePath,
 			ScrapePort: monitorConfig.Exporter.ScrapePort.IntVal,
+			Templates:  monitorConfig.Templates,
 		}
 
 		if monitorConfig.Exporter.ScrapePort.Type == intstr.String {
@@ -70,8 +90,9 @@ func buildMonitorConfig(
 	}
 
 	synthesizeComp.Monitor = &MonitorConfig{
-		Enable:  true,
-		BuiltIn: true,
+		Enable:    true,
+		BuiltIn:   true,
+		Templates: monitorConfig.Templates,
 	}
 }
 
@@ -81,3 +102,63 @@ func disableMonitor(component *SynthesizedComponent) {
 		BuiltIn: false,
 	}
 }
+
+// GrafanaDashboardsName returns the name of the ConfigMap of the Grafana dashboards of the component.
+func GrafanaDashboardsName(synthesizeComp *SynthesizedComponent) string {
+	return fmt.Sprintf("%s-grafana-dashboards", synthesizeComp.FullCompName)
+}
+
+// PrometheusRuleName returns the name of the PrometheusRule of the component.
+func PrometheusRuleName(synthesizeComp *SynthesizedComponent) string {
+	return fmt.Sprintf("%s-alert-rules", synthesizeComp.FullCompName)
+}
+
+// renderMonitorTemplate replaces the placeholders in the template of the dashboards and alert rules.
+func renderMonitorTemplate(tpl string, synthesizeComp *SynthesizedComponent) string {
+	return strings.NewReplacer(
+		"$(NAMESPACE)", synthesizeComp.Namespace,
+		"$(CLUSTER_NAME)", synthesizeComp.ClusterName,
+		"$(COMP_NAME)", synthesizeComp.Name,
+	).Replace(tpl)
+}
+
+// BuildGrafanaDashboards builds the ConfigMap of the Grafana dashboards of the component from the template.
+func BuildGrafanaDashboards(synthesizeComp *SynthesizedComponent, tpl *corev1.ConfigMap) *corev1.ConfigMap {
+	data := make(map[string]string, len(tpl.Data))
+	for key, dashboard := range tpl.Data {
+		data[key] = renderMonitorTemplate(dashboard, synthesizeComp)
+	}
+	return builder.NewConfigMapBuilder(synthesizeComp.Namespace, GrafanaDashboardsName(synthesizeComp)).
+		AddLabelsInMap(constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name)).
+		AddLabels(grafanaDashboardLabelKey, "1").
+		SetData(data).
+		GetObject()
+}
+
+// BuildPrometheusRule builds the PrometheusRule of the component from the template of the alert rules,
+// the rule groups of all the keys are merged in the order of the keys.
+func BuildPrometheusRule(synthesizeComp *SynthesizedComponent, tpl *corev1.ConfigMap) (*unstructured.Unstructured, error) {
+	keys := make([]string, 0, len(tpl.Data))
+	for key := range tpl.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	groups := make([]interface{}, 0)
+	for _, key := range keys {
+		rules := struct {
+			Groups []interface{} `json:"groups"`
+		}{}
+		if err := yaml.Unmarshal([]byte(renderMonitorTemplate(tpl.Data[key], synthesizeComp)), &rules); err != nil {
+			return nil, fmt.Errorf("failed to parse the alert rules %s of template %s: %s", key, tpl.Name, err.Error())
+		}
+		groups = append(groups, rules.Groups...)
+	}
+
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(PrometheusRuleGVK)
+	rule.SetNamespace(synthesizeComp.Namespace)
+	rule.SetName(PrometheusRuleName(synthesizeComp))
+	rule.SetLabels(constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name))
+	rule.Object["spec"] = map[string]interface{}{"groups": groups}
+	return rule, nil
+}
@@ -22,9 +22,13 @@ package component
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
 var _ = Describe("monitor_utils", func() {
@@ -91,5 +95,84 @@ var _ = Describe("monitor_utils", func() {
 			Expect(monitorConfig.ScrapePort).To(BeEquivalentTo(0))
 			Expect(monitorConfig.ScrapePath).To(Equal(""))
 		})
+
+		It("should pass the templates of the dashboards and alert rules if monitor is enabled", func() {
+			clusterCompDef.Monitor.Templates = &appsv1alpha1.MonitorTemplates{
+				DashboardsTemplateRef: "mysql-dashboards",
+				AlertRulesTemplateRef: "mysql-alert-rules",
+			}
+			buildMonitorConfigLegacy(clusterCompDef, clusterCompSpec, component)
+			Expect(component.Monitor.Templates).Should(Equal(clusterCompDef.Monitor.Templates))
+
+			clusterCompSpec.Monitor = false
+			buildMonitorConfigLegacy(clusterCompDef, clusterCompSpec, component)
+			Expect(component.Monitor.Templates).Should(BeNil())
+		})
+	})
+
+	Context("builds the dashboards and alert rules from the templates", func() {
+		var synthesizedComp *SynthesizedComponent
+
+		BeforeEach(func() {
+			synthesizedComp = &SynthesizedComponent{
+				Namespace:    "default",
+				ClusterName:  "mycluster",
+				Name:         "mysql",
+				FullCompName: "mycluster-mysql",
+			}
+		})
+
+		It("should render the dashboards with the placeholders replaced", func() {
+			tpl := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql-dashboards"},
+				Data: map[string]string{
+					"mysql.json": `{"expr": "mysql_up{namespace=\"$(NAMESPACE)\",app_kubernetes_io_instance=\"$(CLUSTER_NAME)\",component=\"$(COMP_NAME)\"}"}`,
+				},
+			}
+			dashboards := BuildGrafanaDashboards(synthesizedComp, tpl)
+			Expect(dashboards.Name).Should(Equal("mycluster-mysql-grafana-dashboards"))
+			Expect(dashboards.Namespace).Should(Equal("default"))
+			Expect(dashboards.Labels).Should(HaveKeyWithValue(grafanaDashboardLabelKey, "1"))
+			Expect(dashboards.Labels).Should(HaveKeyWithValue(constant.AppInstanceLabelKey, "mycluster"))
+			Expect(dashboards.Data).Should(HaveKeyWithValue("mysql.json",
+				`{"expr": "mysql_up{namespace=\"default\",app_kubernetes_io_instance=\"mycluster\",component=\"mysql\"}"}`))
+		})
+
+		It("should merge the rule groups of the alert rules in the order of keys", func() {
+			tpl := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql-alert-rules"},
+				Data: map[string]string{
+					"replication.yaml": `
+groups:
+- name: $(CLUSTER_NAME)-replication
+  rules:
+  - alert: MySQLReplicationLag
+    expr: mysql_slave_status_seconds_behind_master{namespace="$(NAMESPACE)"} > 30
+    for: 1m
+`,
+					"availability.yaml": `
+groups:
+- name: $(CLUSTER_NAME)-availability
+  rules:
+  - alert: MySQLLeaderAbsent
+    expr: absent(kb_role{role="leader",namespace="$(NAMESPACE)"})
+`,
+				},
+			}
+			rule, err := BuildPrometheusRule(synthesizedComp, tpl)
+			Expect(err).Should(Succeed())
+			Expect(rule.GroupVersionKind()).Should(Equal(PrometheusRuleGVK))
+			Expect(rule.GetName()).Should(Equal("mycluster-mysql-alert-rules"))
+			groups, found, err := unstructured.NestedSlice(rule.Object, "spec", "groups")
+			Expect(err).Should(Succeed())
+			Expect(found).Should(BeTrue())
+			Expect(groups).Should(HaveLen(2))
+			Expect(groups[0]).Should(HaveKeyWithValue("name", "mycluster-availability"))
+			Expect(groups[1]).Should(HaveKeyWithValue("name", "mycluster-replication"))
+
+			tpl.Data["invalid.yaml"] = "groups: {"
+			_, err = BuildPrometheusRule(synthesizedComp, tpl)
+			Expect(err).Should(HaveOccurred())
+		})
 	})
 })
@@ -21,15 +21,20 @@ package component
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
+	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	rsmcore "github.com/apecloud/kubeblocks/pkg/controller/rsm"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 )
@@ -48,6 +53,96 @@ func GetComponentPodList(ctx context.Context, cli client.Reader, cluster appsv1a
 	return podList, nil
 }
 
+// GetComponentNodeHosts gets the hosts of the nodes where the pods of the component are running, which are used to
+// access the NodePort services of the component from outside the cluster.
+func GetComponentNodeHosts(ctx context.Context, cli client.Reader, cluster appsv1alpha1.Cluster, componentName string) ([]string, error) {
+	podList, err := GetComponentPodList(ctx, cli, cluster, componentName)
+	if err != nil {
+		return nil, err
+	}
+	nodeNames := make([]string, 0)
+	for _, pod := range podList.Items {
+		if len(pod.Spec.NodeName) > 0 && !slices.Contains(nodeNames, pod.Spec.NodeName) {
+			nodeNames = append(nodeNames, pod.Spec.NodeName)
+		}
+	}
+	slices.Sort(nodeNames)
+	hosts := make([]string, 0)
+	for _, nodeName := range nodeNames {
+		node := &corev1.Node{}
+		if err = cli.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if host := intctrlutil.GetNodeHost(node); len(host) > 0 && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// GetComponentRSM gets the RSM workload of the component, nil is returned if it is not created yet.
+func GetComponentRSM(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent) (*workloads.ReplicatedStateMachine, error) {
+	rsmObj := &workloads.ReplicatedStateMachine{}
+	rsmKey := types.NamespacedName{
+		Namespace: synthesizedComp.Namespace,
+		Name:      WorkloadName(synthesizedComp, synthesizedComp.Name),
+	}
+	if err := cli.Get(ctx, rsmKey, rsmObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rsmObj, nil
+}
+
+// PodNamesOfWorkload returns the names of the pods of the component, ordered by the pod ordinals.
+// The replicas and pod names are taken from the RSM @rsmObj once it is created, since the replicas of the component
+// may be changed by the autoscaler and the pods are only created after the RSM is updated.
+func PodNamesOfWorkload(synthesizedComp *SynthesizedComponent, rsmObj *workloads.ReplicatedStateMachine) []string {
+	if rsmObj == nil {
+		podNames := make([]string, 0, synthesizedComp.Replicas)
+		for i := 0; i < int(synthesizedComp.Replicas); i++ {
+			podNames = append(podNames, PodName(synthesizedComp, synthesizedComp.Name, i))
+		}
+		return podNames
+	}
+	replicas := int32(1)
+	if rsmObj.Spec.Replicas != nil {
+		replicas = *rsmObj.Spec.Replicas
+	}
+	podNames := make([]string, 0, replicas)
+	for i := 0; i < int(replicas); i++ {
+		podNames = append(podNames, rsmcore.GetPodName(rsmObj.Name, i))
+	}
+	return podNames
+}
+
+// BuildPodEndpoints builds the stable endpoints of the pods of the component, ordered by the pod ordinals.
+func BuildPodEndpoints(synthesizedComp *SynthesizedComponent, rsmObj *workloads.ReplicatedStateMachine) []string {
+	if synthesizedComp.PodEndpoints == nil {
+		return nil
+	}
+	headlessSvcName := HeadlessServiceName(synthesizedComp, synthesizedComp.Name)
+	if rsmObj != nil {
+		headlessSvcName = rsmcore.GetHeadlessSvcName(*rsmObj)
+	}
+	podNames := PodNamesOfWorkload(synthesizedComp, rsmObj)
+	endpoints := make([]string, 0, len(podNames))
+	for _, podName := range podNames {
+		if synthesizedComp.PodEndpoints.Type == appsv1alpha1.ServicePodEndpoints {
+			// the per-pod service is named after the pod
+			endpoints = append(endpoints, fmt.Sprintf("%s.%s.svc", podName, synthesizedComp.Namespace))
+		} else {
+			endpoints = append(endpoints, fmt.Sprintf("%s.%s.%s.svc", podName, headlessSvcName, synthesizedComp.Namespace))
+		}
+	}
+	return endpoints
+}
+
 // GetComponentPodListWithRole gets the pod list with target role by cluster and componentName
 func GetComponentPodListWithRole(ctx context.Context, cli client.Reader, cluster appsv1alpha1.Cluster, compSpecName, role string) (*corev1.PodList, error) {
 	podList := &corev1.PodList{}
@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+)
+
+func TestBuildPodEndpoints(t *testing.T) {
+	synthesizedComp := &SynthesizedComponent{
+		Namespace:          "default",
+		ClusterName:        "mycluster",
+		Name:               "kafka",
+		ClusterCompDefName: "kafka-broker",
+		Replicas:           2,
+	}
+	assert.Nil(t, BuildPodEndpoints(synthesizedComp, nil))
+
+	synthesizedComp.PodEndpoints = &appsv1alpha1.PodEndpoints{}
+	assert.Equal(t, []string{
+		"mycluster-kafka-0.mycluster-kafka-headless.default.svc",
+		"mycluster-kafka-1.mycluster-kafka-headless.default.svc",
+	}, BuildPodEndpoints(synthesizedComp, nil))
+
+	synthesizedComp.PodEndpoints = &appsv1alpha1.PodEndpoints{Type: appsv1alpha1.ServicePodEndpoints}
+	assert.Equal(t, []string{
+		"mycluster-kafka-0.default.svc",
+		"mycluster-kafka-1.default.svc",
+	}, BuildPodEndpoints(synthesizedComp, nil))
+
+	// the pods are named after the workload which follows the naming template
+	synthesizedComp.NamingTemplate = &appsv1alpha1.NamingTemplate{
+		Prefix:              "prod",
+		ComponentShortNames: map[string]string{"kafka-broker": "kb"},
+		Workloads:           true,
+	}
+	synthesizedComp.PodEndpoints = &appsv1alpha1.PodEndpoints{}
+	assert.Equal(t, []string{
+		"prod-mycluster-kb-0.prod-mycluster-kb-headless.default.svc",
+		"prod-mycluster-kb-1.prod-mycluster-kb-headless.default.svc",
+	}, BuildPodEndpoints(synthesizedComp, nil))
+
+	// the replicas and pod names are taken from the RSM once it is created
+	rsmObj := &workloads.ReplicatedStateMachine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "prod-mycluster-kb"},
+		Spec:       workloads.ReplicatedStateMachineSpec{Replicas: pointer.Int32(3)},
+	}
+	assert.Equal(t, []string{
+		"prod-mycluster-kb-0.prod-mycluster-kb-headless.default.svc",
+		"prod-mycluster-kb-1.prod-mycluster-kb-headless.default.svc",
+		"prod-mycluster-kb-2.prod-mycluster-kb-headless.default.svc",
+	}, BuildPodEndpoints(synthesizedComp, rsmObj))
+
+	synthesizedComp.PodEndpoints = &appsv1alpha1.PodEndpoints{Type: appsv1alpha1.ServicePodEndpoints}
+	rsmObj.Spec.Replicas = pointer.Int32(1)
+	assert.Equal(t, []string{
+		"prod-mycluster-kb-0.default.svc",
+	}, BuildPodEndpoints(synthesizedComp, rsmObj))
+}
@@ -21,15 +21,26 @@ package component
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
+const (
+	defaultRoleProbeSuccessThreshold = 1
+	defaultRoleProbeFailureThreshold = 2
+)
+
 // BuildRSMFrom builds a new Component object based on SynthesizedComponent.
 func BuildRSMFrom(synthesizeComp *SynthesizedComponent, protoRSM *workloads.ReplicatedStateMachine) (*workloads.ReplicatedStateMachine, error) {
 	if synthesizeComp == nil {
@@ -207,13 +218,21 @@ func (c *rsmRoleProbeConvertor) convert(args ...any) (any, error) {
 		return nil, nil
 	}
 
+	roleProbe := synthesizeComp.LifecycleActions.RoleProbe
 	rsmRoleProbe := &workloads.RoleProbe{
-		TimeoutSeconds:      synthesizeComp.LifecycleActions.RoleProbe.TimeoutSeconds,
-		PeriodSeconds:       synthesizeComp.LifecycleActions.RoleProbe.PeriodSeconds,
-		SuccessThreshold:    1,
-		FailureThreshold:    2,
+		InitialDelaySeconds: roleProbe.InitialDelaySeconds,
+		TimeoutSeconds:      roleProbe.TimeoutSeconds,
+		PeriodSeconds:       roleProbe.PeriodSeconds,
+		SuccessThreshold:    defaultRoleProbeSuccessThreshold,
+		FailureThreshold:    defaultRoleProbeFailureThreshold,
 		RoleUpdateMechanism: workloads.DirectAPIServerEventUpdate,
 	}
+	if roleProbe.SuccessThreshold > 0 {
+		rsmRoleProbe.SuccessThreshold = roleProbe.SuccessThreshold
+	}
+	if roleProbe.FailureThreshold > 0 {
+		rsmRoleProbe.FailureThreshold = roleProbe.FailureThreshold
+	}
 
 	if synthesizeComp.LifecycleActions.RoleProbe.BuiltinHandler != nil {
 		builtinHandler := string(*synthesizeComp.LifecycleActions.RoleProbe.BuiltinHandler)
@@ -222,18 +241,84 @@ func (c *rsmRoleProbeConvertor) convert(args ...any) (any, error) {
 	}
 
 	// TODO(xingran): RSM Action does not support args[] yet
-	if synthesizeComp.LifecycleActions.RoleProbe.CustomHandler != nil {
+	customHandler := roleProbe.CustomHandler
+	switch {
+	case customHandler == nil:
+	case customHandler.Exec != nil:
 		rsmRoleProbeCmdAction := workloads.Action{
-			Image:   synthesizeComp.LifecycleActions.RoleProbe.CustomHandler.Image,
-			Command: synthesizeComp.LifecycleActions.RoleProbe.CustomHandler.Exec.Command,
-			Args:    synthesizeComp.LifecycleActions.RoleProbe.CustomHandler.Exec.Args,
+			Image:   customHandler.Image,
+			Command: customHandler.Exec.Command,
+			Args:    customHandler.Exec.Args,
 		}
 		rsmRoleProbe.CustomHandler = []workloads.Action{rsmRoleProbeCmdAction}
+	case customHandler.HTTP != nil:
+		command, err := buildHTTPRoleProbeCommand(synthesizeComp, customHandler.HTTP, rsmRoleProbe.TimeoutSeconds)
+		if err != nil {
+			return nil, err
+		}
+		rsmRoleProbe.CustomHandler = []workloads.Action{{Image: customHandler.Image, Command: command}}
+	default:
+		return nil, errors.New("the custom handler of role probe should be either an exec or an HTTP action")
 	}
 
 	return rsmRoleProbe, nil
 }
 
+// buildHTTPRoleProbeCommand builds the command to request the HTTP endpoint of role probe,
+// the response body is taken as the role of the replica.
+func buildHTTPRoleProbeCommand(synthesizeComp *SynthesizedComponent, action *appsv1alpha1.HTTPAction, timeoutSeconds int32) ([]string, error) {
+	if action.Method != "" && !strings.EqualFold(action.Method, http.MethodGet) {
+		return nil, fmt.Errorf("the HTTP method %s of role probe is not supported, only GET is supported", action.Method)
+	}
+
+	port := action.Port.IntValue()
+	if action.Port.Type == intstr.String {
+		if synthesizeComp.PodSpec != nil {
+			for _, container := range synthesizeComp.PodSpec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == action.Port.StrVal {
+						port = int(containerPort.ContainerPort)
+					}
+				}
+			}
+		}
+	}
+	if port <= 0 {
+		return nil, fmt.Errorf("the port %s of role probe is not found", action.Port.String())
+	}
+
+	scheme := strings.ToLower(string(corev1.URISchemeHTTP))
+	if action.Scheme != "" {
+		scheme = strings.ToLower(string(action.Scheme))
+	}
+	// the IPv4 loopback address is kept in the pods of the IPv6 only clusters too, use it rather than localhost,
+	// which may be resolved to the IPv6 loopback address that the engines listening on 0.0.0.0 don't accept.
+	host := "127.0.0.1"
+	if action.Host != "" {
+		host = action.Host
+	}
+	path := action.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 1
+	}
+
+	command := []string{"wget", "-q", "-O", "-", "-T", strconv.Itoa(int(timeoutSeconds))}
+	for _, header := range action.HTTPHeaders {
+		command = append(command, "--header", shellQuote(fmt.Sprintf("%s: %s", header.Name, header.Value)))
+	}
+	command = append(command, shellQuote(fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(port)), path)))
+	return command, nil
+}
+
+// shellQuote quotes the string with single quotes to be passed to the shell literally,
+// the single quotes within it are closed, escaped and reopened.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 func (c *rsmCredentialConvertor) convert(args ...any) (any, error) {
 	synthesizeComp, err := parseRSMConvertorArgs(args...)
 	if err != nil {
@@ -23,6 +23,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloadsalpha1 "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 )
@@ -58,6 +61,47 @@ var _ = Describe("Test RSM Convertor", func() {
 			probe := res.(*workloadsalpha1.RoleProbe)
 			Expect(probe.CustomHandler[0].Command).Should(BeEquivalentTo(command))
 			Expect(probe.CustomHandler[0].Args).Should(BeEquivalentTo(args))
+			Expect(probe.SuccessThreshold).Should(BeEquivalentTo(defaultRoleProbeSuccessThreshold))
+			Expect(probe.FailureThreshold).Should(BeEquivalentTo(defaultRoleProbeFailureThreshold))
+		})
+
+		It("convert HTTP role probe", func() {
+			synComp.PodSpec = &corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "main",
+					Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+				}},
+			}
+			roleProbe := synComp.LifecycleActions.RoleProbe
+			roleProbe.FailureThreshold = 5
+			roleProbe.TimeoutSeconds = 3
+			roleProbe.CustomHandler = &appsv1alpha1.Action{
+				HTTP: &appsv1alpha1.HTTPAction{
+					Path: "role",
+					Port: intstr.FromString("http"),
+				},
+			}
+
+			convertor := &rsmRoleProbeConvertor{}
+			res, err := convertor.convert(synComp)
+			Expect(err).Should(Succeed())
+			probe := res.(*workloadsalpha1.RoleProbe)
+			Expect(probe.FailureThreshold).Should(BeEquivalentTo(5))
+			Expect(probe.CustomHandler).Should(HaveLen(1))
+			Expect(probe.CustomHandler[0].Command).Should(Equal([]string{"wget", "-q", "-O", "-", "-T", "3", "'http://127.0.0.1:8080/role'"}))
+
+			By("escape the single quotes in headers")
+			roleProbe.CustomHandler.HTTP.HTTPHeaders = []corev1.HTTPHeader{{Name: "X-Token", Value: "it's-a-secret"}}
+			res, err = convertor.convert(synComp)
+			Expect(err).Should(Succeed())
+			probe = res.(*workloadsalpha1.RoleProbe)
+			Expect(probe.CustomHandler[0].Command).Should(Equal([]string{"wget", "-q", "-O", "-", "-T", "3",
+				"--header", `'X-Token: it'\''s-a-secret'`, "'http://127.0.0.1:8080/role'"}))
+
+			By("unsupported HTTP method")
+			roleProbe.CustomHandler.HTTP.Method = "POST"
+			_, err = convertor.convert(synComp)
+			Expect(err).Should(HaveOccurred())
 		})
 	})
 })
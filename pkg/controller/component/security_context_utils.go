@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// buildSecurityContext resolves the default security settings of the component, the settings of the component
+// take precedence over the ones of the component definition, which take precedence over the data plane defaults.
+func buildSecurityContext(compDef *appsv1alpha1.ComponentDefinition, comp *appsv1alpha1.Component, synthesizeComp *SynthesizedComponent) error {
+	dpSecurityContext, err := intctrlutil.GetDataPlaneSecurityContext()
+	if err != nil {
+		return err
+	}
+	synthesizeComp.SecurityContext = mergeSecurityContextDefaults(comp.Spec.SecurityContext, compDef.Spec.SecurityContext, dpSecurityContext)
+	return nil
+}
+
+// mergeSecurityContextDefaults merges the security settings field by field, the former ones take precedence.
+func mergeSecurityContextDefaults(securityContexts ...*appsv1alpha1.SecurityContextDefaults) *appsv1alpha1.SecurityContextDefaults {
+	var merged *appsv1alpha1.SecurityContextDefaults
+	for _, sc := range securityContexts {
+		if sc == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &appsv1alpha1.SecurityContextDefaults{}
+		}
+		if merged.RunAsNonRoot == nil {
+			merged.RunAsNonRoot = sc.RunAsNonRoot
+		}
+		if merged.RunAsUser == nil {
+			merged.RunAsUser = sc.RunAsUser
+		}
+		if merged.RunAsGroup == nil {
+			merged.RunAsGroup = sc.RunAsGroup
+		}
+		if merged.FSGroup == nil {
+			merged.FSGroup = sc.FSGroup
+		}
+		if merged.SeccompProfile == nil {
+			merged.SeccompProfile = sc.SeccompProfile
+		}
+		if merged.AllowPrivilegeEscalation == nil {
+			merged.AllowPrivilegeEscalation = sc.AllowPrivilegeEscalation
+		}
+		if merged.DropAllCapabilities == nil {
+			merged.DropAllCapabilities = sc.DropAllCapabilities
+		}
+		if merged.ReadOnlyRootFilesystem == nil {
+			merged.ReadOnlyRootFilesystem = sc.ReadOnlyRootFilesystem
+		}
+	}
+	// the empty settings are treated as unset, so the pod spec is left unchanged.
+	if intctrlutil.IsEmptySecurityContextDefaults(merged) {
+		return nil
+	}
+	return merged
+}
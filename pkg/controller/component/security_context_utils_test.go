@@ -0,0 +1,54 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+var _ = Describe("security context utils", func() {
+	It("merges the security settings field by field", func() {
+		compSC := &appsv1alpha1.SecurityContextDefaults{
+			RunAsUser: pointer.Int64(1001),
+		}
+		compDefSC := &appsv1alpha1.SecurityContextDefaults{
+			RunAsUser:    pointer.Int64(999),
+			RunAsNonRoot: pointer.Bool(true),
+		}
+		dpSC := &appsv1alpha1.SecurityContextDefaults{
+			RunAsNonRoot:   pointer.Bool(false),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+		Expect(mergeSecurityContextDefaults(nil, nil)).Should(BeNil())
+		Expect(mergeSecurityContextDefaults(&appsv1alpha1.SecurityContextDefaults{}, nil)).Should(BeNil())
+
+		merged := mergeSecurityContextDefaults(compSC, compDefSC, dpSC)
+		Expect(*merged.RunAsUser).Should(Equal(int64(1001)))
+		Expect(*merged.RunAsNonRoot).Should(BeTrue())
+		Expect(merged.SeccompProfile.Type).Should(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+		Expect(merged.FSGroup).Should(BeNil())
+	})
+})
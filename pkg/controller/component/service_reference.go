@@ -43,6 +43,15 @@ func resolveServiceReferences(ctx context.Context, cli client.Reader, comp *Synt
 	return nil
 }
 
+// ResolveServiceDescriptorCredentialVars resolves the endpoint, port and auth of the ServiceDescriptor to the real values.
+func ResolveServiceDescriptorCredentialVars(ctx context.Context, cli client.Reader, sd *appsv1alpha1.ServiceDescriptor) error {
+	vars := []*appsv1alpha1.CredentialVar{sd.Spec.Endpoint, sd.Spec.Port}
+	if sd.Spec.Auth != nil {
+		vars = append(vars, sd.Spec.Auth.Username, sd.Spec.Auth.Password)
+	}
+	return resolveServiceRefCredentialVars(ctx, cli, sd.Namespace, vars...)
+}
+
 // resolveServiceRefCredentialVars resolves the credentialVar.ValueFrom to the real value
 // TODO: currently, we set the valueFrom to the value, which need to be refactored
 func resolveServiceRefCredentialVars(ctx context.Context, cli client.Reader,
@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -36,6 +37,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/apiconversion"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var (
@@ -144,33 +146,40 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 	}
 	compDefObj := compDef.DeepCopy()
 	synthesizeComp := &SynthesizedComponent{
-		Namespace:          comp.Namespace,
-		ClusterName:        clusterName,
-		ClusterUID:         clusterUID,
-		Comp2CompDefs:      buildComp2CompDefs(cluster, clusterCompSpec),
-		Name:               compName,
-		FullCompName:       comp.Name,
-		CompDefName:        compDef.Name,
-		ClusterGeneration:  clusterGeneration(cluster, comp),
-		PodSpec:            &compDef.Spec.Runtime,
-		HostNetwork:        compDefObj.Spec.HostNetwork,
-		LogConfigs:         compDefObj.Spec.LogConfigs,
-		ConfigTemplates:    compDefObj.Spec.Configs,
-		ScriptTemplates:    compDefObj.Spec.Scripts,
-		Roles:              compDefObj.Spec.Roles,
-		UpdateStrategy:     compDefObj.Spec.UpdateStrategy,
-		MinReadySeconds:    compDefObj.Spec.MinReadySeconds,
-		PolicyRules:        compDefObj.Spec.PolicyRules,
-		LifecycleActions:   compDefObj.Spec.LifecycleActions,
-		SystemAccounts:     compDefObj.Spec.SystemAccounts,
-		RoleArbitrator:     compDefObj.Spec.RoleArbitrator,
-		Replicas:           comp.Spec.Replicas,
-		Resources:          comp.Spec.Resources,
-		TLSConfig:          comp.Spec.TLSConfig,
-		ServiceAccountName: comp.Spec.ServiceAccountName,
-		Nodes:              comp.Spec.Nodes,
-		Instances:          comp.Spec.Instances,
-		RsmTransformPolicy: comp.Spec.RsmTransformPolicy,
+		Namespace:           comp.Namespace,
+		ClusterName:         clusterName,
+		ClusterUID:          clusterUID,
+		Comp2CompDefs:       buildComp2CompDefs(cluster, clusterCompSpec),
+		Name:                compName,
+		FullCompName:        comp.Name,
+		CompDefName:         compDef.Name,
+		ClusterGeneration:   clusterGeneration(cluster, comp),
+		PodSpec:             &compDef.Spec.Runtime,
+		HostNetwork:         compDefObj.Spec.HostNetwork,
+		LogConfigs:          compDefObj.Spec.LogConfigs,
+		ConfigTemplates:     compDefObj.Spec.Configs,
+		ScriptTemplates:     compDefObj.Spec.Scripts,
+		Roles:               compDefObj.Spec.Roles,
+		UpdateStrategy:      compDefObj.Spec.UpdateStrategy,
+		MinReadySeconds:     compDefObj.Spec.MinReadySeconds,
+		TLSCertReloadPolicy: tlsCertReloadPolicy(compDefObj),
+		PolicyRules:         compDefObj.Spec.PolicyRules,
+		LifecycleActions:    compDefObj.Spec.LifecycleActions,
+		SystemAccounts:      compDefObj.Spec.SystemAccounts,
+		RoleArbitrator:      compDefObj.Spec.RoleArbitrator,
+		Replicas:            comp.Spec.Replicas,
+		Resources:           comp.Spec.Resources,
+		TLSConfig:           comp.Spec.TLSConfig,
+		ServiceAccountName:  comp.Spec.ServiceAccountName,
+		Nodes:               comp.Spec.Nodes,
+		Instances:           comp.Spec.Instances,
+		RsmTransformPolicy:  comp.Spec.RsmTransformPolicy,
+		External:            comp.Spec.External,
+		NodeFailureRecovery: comp.Spec.NodeFailureRecovery,
+
+		VolumeClaimRetentionPolicy: comp.Spec.VolumeClaimRetentionPolicy,
+		Autoscaling:                comp.Spec.Autoscaling,
+		PodEndpoints:               comp.Spec.PodEndpoints,
 	}
 
 	// build backward compatible fields, including workload, services, componentRefEnvs, clusterDefName, clusterCompDefName, and clusterCompVer, etc.
@@ -212,6 +221,15 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 	// build serviceAccountName
 	buildServiceAccountName(synthesizeComp)
 
+	// build priorityClassName
+	buildPriorityClassName(synthesizeComp, comp)
+
+	// build the default security settings of pods and containers
+	if err := buildSecurityContext(compDefObj, comp, synthesizeComp); err != nil {
+		reqCtx.Log.Error(err, "build security context failed.")
+		return nil, err
+	}
+
 	// build lorryContainer
 	// TODO(xingran): buildLorryContainers relies on synthesizeComp.CharacterType and synthesizeComp.WorkloadType, which will be deprecated in the future.
 	if err := buildLorryContainers(reqCtx, synthesizeComp, clusterCompSpec); err != nil {
@@ -264,9 +282,9 @@ func buildComp2CompDefs(cluster *appsv1alpha1.Cluster, clusterCompSpec *appsv1al
 
 // buildLabelsAndAnnotations builds labels and annotations for synthesizedComponent.
 func buildLabelsAndAnnotations(compDef *appsv1alpha1.ComponentDefinition, comp *appsv1alpha1.Component, synthesizeComp *SynthesizedComponent) {
-	replaceEnvPlaceholderTokens := func(clusterName, uid, componentName string, kvMap map[string]string) map[string]string {
+	replaceEnvPlaceholderTokens := func(kvMap map[string]string) map[string]string {
 		replacedMap := make(map[string]string, len(kvMap))
-		builtInEnvMap := GetReplacementMapForBuiltInEnv(clusterName, uid, componentName)
+		builtInEnvMap := GetReplacementMapForBuiltInEnv(synthesizeComp)
 		for k, v := range kvMap {
 			replacedMap[ReplaceNamedVars(builtInEnvMap, k, -1, true)] = ReplaceNamedVars(builtInEnvMap, v, -1, true)
 		}
@@ -283,7 +301,7 @@ func buildLabelsAndAnnotations(compDef *appsv1alpha1.ComponentDefinition, comp *
 	if compDef.Spec.Labels != nil || comp.Labels != nil {
 		baseLabels := make(map[string]string)
 		if compDef.Spec.Labels != nil {
-			baseLabels = replaceEnvPlaceholderTokens(synthesizeComp.ClusterName, synthesizeComp.ClusterUID, synthesizeComp.Name, compDef.Spec.Labels)
+			baseLabels = replaceEnvPlaceholderTokens(compDef.Spec.Labels)
 		}
 		// override labels from component
 		synthesizeComp.Labels = mergeMaps(baseLabels, comp.Labels)
@@ -292,7 +310,7 @@ func buildLabelsAndAnnotations(compDef *appsv1alpha1.ComponentDefinition, comp *
 	if compDef.Spec.Annotations != nil || comp.Annotations != nil {
 		baseAnnotations := make(map[string]string)
 		if compDef.Spec.Annotations != nil {
-			baseAnnotations = replaceEnvPlaceholderTokens(synthesizeComp.ClusterName, synthesizeComp.ClusterUID, synthesizeComp.Name, compDef.Spec.Annotations)
+			baseAnnotations = replaceEnvPlaceholderTokens(compDef.Spec.Annotations)
 		}
 		// override annotations from component
 		synthesizeComp.Annotations = mergeMaps(baseAnnotations, comp.Annotations)
@@ -309,6 +327,11 @@ func buildAffinitiesAndTolerations(comp *appsv1alpha1.Component, synthesizeComp
 	synthesizeComp.PodSpec.TopologySpreadConstraints =
 		BuildPodTopologySpreadConstraints(synthesizeComp.ClusterName, synthesizeComp.Name, comp.Spec.Affinity)
 	synthesizeComp.PodSpec.Tolerations = comp.Spec.Tolerations
+	if dedicatedTolerations := BuildDedicatedNodeTolerations(comp.Spec.Affinity); len(dedicatedTolerations) > 0 {
+		tolerations := make([]corev1.Toleration, 0, len(comp.Spec.Tolerations)+len(dedicatedTolerations))
+		tolerations = append(tolerations, comp.Spec.Tolerations...)
+		synthesizeComp.PodSpec.Tolerations = append(tolerations, dedicatedTolerations...)
+	}
 	return nil
 }
 
@@ -394,6 +417,11 @@ func buildComponentServices(synthesizeComp *SynthesizedComponent, compDef *appsv
 	}
 }
 
+// IsLeastPrivilegeRBACEnabled checks whether a dedicated service account and role are generated for each component.
+func IsLeastPrivilegeRBACEnabled() bool {
+	return viper.GetBool(constant.EnableRBACManager) && viper.GetBool(constant.EnableLeastPrivilegeRBAC)
+}
+
 // buildServiceAccountName builds serviceAccountName for component and podSpec.
 func buildServiceAccountName(synthesizeComp *SynthesizedComponent) {
 	// lorry container requires a service account with adequate privileges.
@@ -405,11 +433,23 @@ func buildServiceAccountName(synthesizeComp *SynthesizedComponent) {
 	if synthesizeComp.LifecycleActions == nil || synthesizeComp.LifecycleActions.RoleProbe == nil {
 		return
 	}
-	synthesizeComp.ServiceAccountName = constant.GenerateDefaultServiceAccountName(synthesizeComp.ClusterName)
+	if IsLeastPrivilegeRBACEnabled() {
+		// each component has a dedicated service account, so the permissions of components are not accumulated on it.
+		synthesizeComp.ServiceAccountName = constant.GenerateComponentServiceAccountName(synthesizeComp.ClusterName, synthesizeComp.Name)
+	} else {
+		synthesizeComp.ServiceAccountName = constant.GenerateDefaultServiceAccountName(synthesizeComp.ClusterName)
+	}
 	// set component.PodSpec.ServiceAccountName
 	synthesizeComp.PodSpec.ServiceAccountName = synthesizeComp.ServiceAccountName
 }
 
+// buildPriorityClassName overrides the priorityClassName of podSpec if it is specified in the component.
+func buildPriorityClassName(synthesizeComp *SynthesizedComponent, comp *appsv1alpha1.Component) {
+	if comp.Spec.PriorityClassName != "" {
+		synthesizeComp.PodSpec.PriorityClassName = comp.Spec.PriorityClassName
+	}
+}
+
 // buildBackwardCompatibleFields builds backward compatible fields for component which referenced a clusterComponentDefinition and clusterComponentVersion before KubeBlocks Version 0.7.0
 // TODO(xingran): it will be removed in the future
 func buildBackwardCompatibleFields(reqCtx intctrlutil.RequestCtx,
@@ -431,6 +471,13 @@ func buildBackwardCompatibleFields(reqCtx intctrlutil.RequestCtx,
 	buildWorkload := func() {
 		synthesizeComp.ClusterDefName = clusterDef.Name
 		synthesizeComp.ClusterCompDefName = clusterCompDef.Name
+		synthesizeComp.NamingTemplate = clusterDef.Spec.NamingTemplate
+		if synthesizeComp.NamingTemplate != nil {
+			synthesizeComp.Comp2ClusterCompDefs = make(map[string]string)
+			for _, compSpec := range cluster.Spec.ComponentSpecs {
+				synthesizeComp.Comp2ClusterCompDefs[compSpec.Name] = compSpec.ComponentDefRef
+			}
+		}
 		synthesizeComp.WorkloadType = clusterCompDef.WorkloadType
 		synthesizeComp.CharacterType = clusterCompDef.CharacterType
 		synthesizeComp.HorizontalScalePolicy = clusterCompDef.HorizontalScalePolicy
@@ -453,11 +500,13 @@ func buildBackwardCompatibleFields(reqCtx intctrlutil.RequestCtx,
 				service = corev1.Service{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:        item.Name,
-						Annotations: item.Annotations,
+						Annotations: item.GetAnnotations(),
 					},
 					Spec: service.Spec,
 				}
 				service.Spec.Type = item.ServiceType
+				service.Spec.IPFamilyPolicy = item.IPFamilyPolicy
+				service.Spec.IPFamilies = item.IPFamilies
 				synthesizeComp.Services = append(synthesizeComp.Services, service)
 			}
 		}
@@ -498,6 +547,9 @@ func buildBackwardCompatibleFields(reqCtx intctrlutil.RequestCtx,
 	// merge clusterCompVersion
 	mergeClusterCompVersion()
 
+	// inject the sidecars enabled by the cluster component
+	buildSidecars(clusterCompDef, clusterCompSpec, synthesizeComp)
+
 	// build services
 	buildServices()
 
@@ -513,6 +565,52 @@ func buildBackwardCompatibleFields(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// buildSidecars injects the sidecars declared in the clusterComponentDefinition and enabled by the cluster component into the pod spec,
+// the volumes used by the sidecars are added too.
+func buildSidecars(clusterCompDef *appsv1alpha1.ClusterComponentDefinition,
+	clusterCompSpec *appsv1alpha1.ClusterComponentSpec,
+	synthesizeComp *SynthesizedComponent) {
+	if synthesizeComp.PodSpec == nil {
+		return
+	}
+	for _, sidecar := range clusterCompDef.Sidecars {
+		if !sidecar.IsEnabled(clusterCompSpec) {
+			continue
+		}
+		if _, c := intctrlutil.GetContainerByName(synthesizeComp.PodSpec.Containers, sidecar.Name); c != nil {
+			continue
+		}
+		container := sidecar.Container.DeepCopy()
+		container.Name = sidecar.Name
+		if clusterCompSpec.ConnectionPooler != nil && clusterCompSpec.ConnectionPooler.Sidecar == sidecar.Name {
+			container.Env = append(container.Env, buildConnectionPoolerEnvs(clusterCompSpec.ConnectionPooler)...)
+		}
+		synthesizeComp.PodSpec.Containers = append(synthesizeComp.PodSpec.Containers, *container)
+		for _, vol := range sidecar.Volumes {
+			if slices.ContainsFunc(synthesizeComp.PodSpec.Volumes, func(v corev1.Volume) bool { return v.Name == vol.Name }) {
+				continue
+			}
+			synthesizeComp.PodSpec.Volumes = append(synthesizeComp.PodSpec.Volumes, *vol.DeepCopy())
+		}
+	}
+}
+
+// buildConnectionPoolerEnvs builds the env vars of the pool settings for the connection pooler sidecar.
+func buildConnectionPoolerEnvs(pooler *appsv1alpha1.ClusterComponentConnectionPooler) []corev1.EnvVar {
+	poolMode := pooler.PoolMode
+	if len(poolMode) == 0 {
+		poolMode = appsv1alpha1.SessionPoolMode
+	}
+	envs := []corev1.EnvVar{{Name: constant.KBEnvPoolerMode, Value: strings.ToLower(string(poolMode))}}
+	if pooler.MaxClientConnections != nil {
+		envs = append(envs, corev1.EnvVar{Name: constant.KBEnvPoolerMaxClientConn, Value: strconv.Itoa(int(*pooler.MaxClientConnections))})
+	}
+	if pooler.DefaultPoolSize != nil {
+		envs = append(envs, corev1.EnvVar{Name: constant.KBEnvPoolerDefaultPoolSize, Value: strconv.Itoa(int(*pooler.DefaultPoolSize))})
+	}
+	return envs
+}
+
 // appendOrOverrideContainerAttr appends targetContainer to compContainers or overrides the attributes of compContainers with a given targetContainer,
 // if targetContainer does not exist in compContainers, it will be appended. otherwise it will be updated with the attributes of the target container.
 func appendOrOverrideContainerAttr(compContainers []corev1.Container, targetContainer corev1.Container) []corev1.Container {
@@ -604,8 +702,10 @@ func replaceContainerPlaceholderTokens(component *SynthesizedComponent, namedVal
 }
 
 // GetReplacementMapForBuiltInEnv gets the replacement map for KubeBlocks built-in environment variables.
-func GetReplacementMapForBuiltInEnv(clusterName, clusterUID, componentName string) map[string]string {
-	cc := constant.GenerateClusterComponentName(clusterName, componentName)
+func GetReplacementMapForBuiltInEnv(synthesizedComp *SynthesizedComponent) map[string]string {
+	clusterName, clusterUID, componentName := synthesizedComp.ClusterName, synthesizedComp.ClusterUID, synthesizedComp.Name
+	// the pods are named after the workload, which is named <cluster>-<component> unless the naming template applies to it
+	cc := WorkloadName(synthesizedComp, componentName)
 	replacementMap := map[string]string{
 		constant.EnvPlaceHolder(constant.KBEnvClusterName):     clusterName,
 		constant.EnvPlaceHolder(constant.KBEnvCompName):        componentName,
@@ -746,3 +846,12 @@ func updateResources(synthesizedComp *SynthesizedComponent, comp *appsv1alpha1.C
 	synthesizedComp.PodSpec.Containers[0].Resources = actualResources
 	return nil
 }
+
+// tlsCertReloadPolicy returns the TLS cert reload policy of the component definition, the component definitions
+// converted from the ClusterDefinition don't specify it and the default RollingRestart is used.
+func tlsCertReloadPolicy(compDef *appsv1alpha1.ComponentDefinition) appsv1alpha1.TLSCertReloadPolicy {
+	if len(compDef.Spec.TLSCertReloadPolicy) == 0 {
+		return appsv1alpha1.RollingRestartTLSCert
+	}
+	return compDef.Spec.TLSCertReloadPolicy
+}
@@ -34,6 +34,8 @@ type MonitorConfig struct {
 	BuiltIn    bool   `json:"builtIn"`
 	ScrapePort int32  `json:"scrapePort,omitempty"`
 	ScrapePath string `json:"scrapePath,omitempty"`
+	// Templates are the templates of the dashboards and alert rules provisioned for the component.
+	Templates *v1alpha1.MonitorTemplates `json:"templates,omitempty"`
 }
 
 type SynthesizedComponent struct {
@@ -54,6 +56,8 @@ type SynthesizedComponent struct {
 	ConfigTemplates      []v1alpha1.ComponentConfigSpec         `json:"configTemplates,omitempty"`
 	ScriptTemplates      []v1alpha1.ComponentTemplateSpec       `json:"scriptTemplates,omitempty"`
 	TLSConfig            *v1alpha1.TLSConfig                    `json:"tlsConfig"`
+	TLSCertHash          string                                 `json:"tlsCertHash,omitempty"`    // The hash of the TLS certificates in use, it's set when reconciling the TLS certificates.
+	TLSCertRotated       bool                                   `json:"tlsCertRotated,omitempty"` // The TLS certificates are rotated since the workload was updated last time.
 	ServiceAccountName   string                                 `json:"serviceAccountName,omitempty"`
 	// TODO: remove this later
 	ComponentRefEnvs  []corev1.EnvVar                        `json:"componentRefEnvs,omitempty"`
@@ -68,6 +72,19 @@ type SynthesizedComponent struct {
 
 	NodesAssignment []workloads.NodeAssignment `json:"nodesAssignment,omitempty"`
 
+	// External is set if the component stands for a database running outside of Kubernetes.
+	External *v1alpha1.ExternalComponent `json:"external,omitempty"`
+
+	NodeFailureRecovery *v1alpha1.NodeFailureRecovery `json:"nodeFailureRecovery,omitempty"`
+
+	VolumeClaimRetentionPolicy *v1alpha1.VolumeClaimRetentionPolicy `json:"volumeClaimRetentionPolicy,omitempty"`
+
+	Autoscaling *v1alpha1.ComponentAutoscaling `json:"autoscaling,omitempty"`
+
+	PodEndpoints *v1alpha1.PodEndpoints `json:"podEndpoints,omitempty"`
+
+	SecurityContext *v1alpha1.SecurityContextDefaults `json:"securityContext,omitempty"`
+
 	// The following fields were introduced with the ComponentDefinition and Component API in KubeBlocks version 0.8.0
 	Roles               []v1alpha1.ReplicaRole              `json:"roles,omitempty"`
 	Labels              map[string]string                   `json:"labels,omitempty"`
@@ -82,6 +99,7 @@ type SynthesizedComponent struct {
 	HostNetwork         *v1alpha1.HostNetwork               `json:"hostNetwork,omitempty"`
 	ComponentServices   []v1alpha1.ComponentService         `json:"componentServices,omitempty"`
 	MinReadySeconds     int32                               `json:"minReadySeconds,omitempty"`
+	TLSCertReloadPolicy v1alpha1.TLSCertReloadPolicy        `json:"tlsCertReloadPolicy,omitempty"`
 
 	// TODO(xingran): The following fields will be deprecated after version 0.8.0 and will be replaced with a new data structure.
 	Probes           *v1alpha1.ClusterDefinitionProbes `json:"probes,omitempty"`           // The Probes will be replaced with LifecycleActions.RoleProbe in the future.
@@ -96,4 +114,6 @@ type SynthesizedComponent struct {
 	CharacterType         string                          `json:"characterType,omitempty"`
 	WorkloadType          v1alpha1.WorkloadType           `json:"workloadType,omitempty"`
 	HorizontalScalePolicy *v1alpha1.HorizontalScalePolicy `json:"horizontalScalePolicy,omitempty"`
+	NamingTemplate        *v1alpha1.NamingTemplate        `json:"namingTemplate,omitempty"`
+	Comp2ClusterCompDefs  map[string]string               `json:"comp2ClusterCompDefs,omitempty"` // {compName: clusterCompDefName}, the components of the cluster which the naming template applies to
 }
@@ -38,6 +38,7 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 )
 
 var (
@@ -160,7 +161,7 @@ func builtinTemplateVars(synthesizedComp *SynthesizedComponent) []corev1.EnvVar
 			{Name: constant.KBEnvNamespace, Value: synthesizedComp.Namespace},
 			{Name: constant.KBEnvClusterName, Value: synthesizedComp.ClusterName},
 			{Name: constant.KBEnvClusterUID, Value: synthesizedComp.ClusterUID},
-			{Name: constant.KBEnvClusterCompName, Value: constant.GenerateClusterComponentName(synthesizedComp.ClusterName, synthesizedComp.Name)},
+			{Name: constant.KBEnvClusterCompName, Value: WorkloadName(synthesizedComp, synthesizedComp.Name)},
 			{Name: constant.KBEnvCompName, Value: synthesizedComp.Name},
 			{Name: constant.KBEnvCompReplicas, Value: strconv.Itoa(int(synthesizedComp.Replicas))},
 			{Name: constant.KBEnvClusterUIDPostfix8Deprecated, Value: clusterUIDPostfix(synthesizedComp)},
@@ -310,9 +311,8 @@ func buildDefaultEnvVars(synthesizedComp *SynthesizedComponent, legacy bool) []c
 			},
 		})
 	}
-	clusterCompName := func() string {
-		return constant.GenerateClusterComponentName(synthesizedComp.ClusterName, synthesizedComp.Name)
-	}()
+	// the pods are named after the workload, which is named <cluster>-<component> unless the naming template applies to it
+	clusterCompName := WorkloadName(synthesizedComp, synthesizedComp.Name)
 	if legacy {
 		vars = append(vars, []corev1.EnvVar{
 			{Name: constant.KBEnvClusterName, Value: synthesizedComp.ClusterName},
@@ -410,6 +410,8 @@ func resolveClusterObjectVarRef(ctx context.Context, cli client.Reader, synthesi
 		return resolveCredentialVarRef(ctx, cli, synthesizedComp, defineKey, *source.CredentialVarRef)
 	case source.ServiceRefVarRef != nil:
 		return resolveServiceRefVarRef(synthesizedComp, defineKey, *source.ServiceRefVarRef)
+	case source.ComponentVarRef != nil:
+		return resolveComponentVarRef(ctx, cli, synthesizedComp, defineKey, *source.ComponentVarRef)
 	}
 	return nil, nil, nil
 }
@@ -710,8 +712,9 @@ func resolveCredentialPasswordRef(ctx context.Context, cli client.Reader, synthe
 	defineKey string, selector appsv1alpha1.CredentialVarSelector) (*corev1.EnvVar, *corev1.EnvVar, error) {
 	resolvePassword := func(obj any) (*corev1.EnvVar, *corev1.EnvVar) {
 		secret := obj.(*corev1.Secret)
+		// the password is absent from the secret if it's kept in the external secret manager.
 		if secret.Data != nil {
-			if _, ok := secret.Data[constant.AccountPasswdForSecret]; ok {
+			if _, ok := secret.Data[constant.AccountPasswdForSecret]; ok || secretstore.IsSealed(secret) {
 				return nil, &corev1.EnvVar{
 					Name: defineKey,
 					ValueFrom: &corev1.EnvVarSource{
@@ -812,6 +815,73 @@ func resolveServiceRefPasswordRef(synthesizedComp *SynthesizedComponent, defineK
 	return resolveServiceRefVarRefLow(synthesizedComp, selector, selector.Password, resolvePassword)
 }
 
+func resolveComponentVarRef(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
+	defineKey string, selector appsv1alpha1.ComponentVarSelector) ([]corev1.EnvVar, []corev1.EnvVar, error) {
+	var resolveFunc func(context.Context, client.Reader, *SynthesizedComponent, string, appsv1alpha1.ComponentVarSelector) (*corev1.EnvVar, *corev1.EnvVar, error)
+	switch {
+	case selector.PodNamesForRole != nil:
+		resolveFunc = resolveComponentPodNamesForRoleRef
+	case selector.PodFQDNsForRole != nil:
+		resolveFunc = resolveComponentPodFQDNsForRoleRef
+	default:
+		return nil, nil, nil
+	}
+
+	var1, var2, err := resolveFunc(ctx, cli, synthesizedComp, defineKey, selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	return checkNBuildVars(var1, var2)
+}
+
+func resolveComponentPodNamesForRoleRef(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
+	defineKey string, selector appsv1alpha1.ComponentVarSelector) (*corev1.EnvVar, *corev1.EnvVar, error) {
+	resolvePodNames := func(obj any) (*corev1.EnvVar, *corev1.EnvVar) {
+		pods := podsWithRole(obj.(*corev1.PodList), selector.PodNamesForRole.Role)
+		if len(pods) == 0 {
+			return nil, nil
+		}
+		names := make([]string, 0, len(pods))
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		return &corev1.EnvVar{Name: defineKey, Value: strings.Join(names, ",")}, nil
+	}
+	return resolveComponentVarRefLow(ctx, cli, synthesizedComp, selector, selector.PodNamesForRole.Option, resolvePodNames)
+}
+
+func resolveComponentPodFQDNsForRoleRef(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
+	defineKey string, selector appsv1alpha1.ComponentVarSelector) (*corev1.EnvVar, *corev1.EnvVar, error) {
+	resolvePodFQDNs := func(obj any) (*corev1.EnvVar, *corev1.EnvVar) {
+		pods := podsWithRole(obj.(*corev1.PodList), selector.PodFQDNsForRole.Role)
+		if len(pods) == 0 {
+			return nil, nil
+		}
+		fqdns := make([]string, 0, len(pods))
+		for _, pod := range pods {
+			compName := pod.Labels[constant.KBAppComponentLabelKey]
+			fqdns = append(fqdns, fmt.Sprintf("%s.%s.%s.svc", pod.Name,
+				HeadlessServiceName(synthesizedComp, compName), pod.Namespace))
+		}
+		return &corev1.EnvVar{Name: defineKey, Value: strings.Join(fqdns, ",")}, nil
+	}
+	return resolveComponentVarRefLow(ctx, cli, synthesizedComp, selector, selector.PodFQDNsForRole.Option, resolvePodFQDNs)
+}
+
+// podsWithRole returns the pods with the specified role, sorted by name.
+func podsWithRole(podList *corev1.PodList, role string) []corev1.Pod {
+	pods := make([]corev1.Pod, 0)
+	for _, pod := range podList.Items {
+		if strings.EqualFold(pod.Labels[constant.RoleLabelKey], role) {
+			pods = append(pods, pod)
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	return pods
+}
+
 func resolvePodVarRefLow(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
 	selector appsv1alpha1.PodVarSelector, option *appsv1alpha1.VarOption, resolveVar func(any) (*corev1.EnvVar, *corev1.EnvVar)) (*corev1.EnvVar, *corev1.EnvVar, error) {
 	resolveObj := func() (any, error) {
@@ -828,7 +898,7 @@ func resolvePodVarRefLow(ctx context.Context, cli client.Reader, synthesizedComp
 		}
 
 		rsmName := func(compName string) string {
-			return constant.GenerateRSMNamePattern(synthesizedComp.ClusterName, compName)
+			return WorkloadName(synthesizedComp, compName)
 		}
 		obj, err := resolveReferentObject(ctx, cli, synthesizedComp, selector.ClusterObjectReference, rsmName, &workloads.ReplicatedStateMachine{})
 		if err != nil {
@@ -843,15 +913,34 @@ func resolvePodVarRefLow(ctx context.Context, cli client.Reader, synthesizedComp
 	return resolveClusterObjectVar("Pod", selector.ClusterObjectReference, option, resolveObj, resolveVar)
 }
 
+func resolveComponentVarRefLow(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
+	selector appsv1alpha1.ComponentVarSelector, option *appsv1alpha1.VarOption, resolveVar func(any) (*corev1.EnvVar, *corev1.EnvVar)) (*corev1.EnvVar, *corev1.EnvVar, error) {
+	resolveObj := func() (any, error) {
+		compName, err := resolveReferentComponent(synthesizedComp, selector.ClusterObjectReference)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		podList := &corev1.PodList{}
+		if err = cli.List(ctx, podList, client.InNamespace(synthesizedComp.Namespace),
+			client.MatchingLabels(constant.GetComponentWellKnownLabels(synthesizedComp.ClusterName, compName))); err != nil {
+			return nil, err
+		}
+		return podList, nil
+	}
+	return resolveClusterObjectVar("Component", selector.ClusterObjectReference, option, resolveObj, resolveVar)
+}
+
 func resolveServiceVarRefLow(ctx context.Context, cli client.Reader, synthesizedComp *SynthesizedComponent,
 	selector appsv1alpha1.ServiceVarSelector, option *appsv1alpha1.VarOption, resolveVar func(any) (*corev1.EnvVar, *corev1.EnvVar)) (*corev1.EnvVar, *corev1.EnvVar, error) {
 	resolveObj := func() (any, error) {
 		objName := func(compName string) string {
-			svcName := constant.GenerateComponentServiceName(synthesizedComp.ClusterName, compName, selector.Name)
 			if selector.Name == "headless" {
-				svcName = constant.GenerateDefaultComponentHeadlessServiceName(synthesizedComp.ClusterName, compName)
+				return HeadlessServiceName(synthesizedComp, compName)
 			}
-			return svcName
+			return ServiceName(synthesizedComp, compName, selector.Name)
 		}
 		return resolveReferentObject(ctx, cli, synthesizedComp, selector.ClusterObjectReference, objName, &corev1.Service{})
 	}
@@ -22,6 +22,7 @@ package component
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strconv"
 
@@ -54,6 +55,17 @@ func (r *mockReader) Get(ctx context.Context, key client.ObjectKey, obj client.O
 }
 
 func (r *mockReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	// ignore the list options
+	if podList, ok := list.(*corev1.PodList); ok {
+		for _, o := range r.objs {
+			if pod, ok := o.(*corev1.Pod); ok {
+				podList.Items = append(podList.Items, *pod)
+			}
+		}
+		if len(podList.Items) > 0 {
+			return nil
+		}
+	}
 	return r.cli.List(ctx, list, opts...)
 }
 
@@ -994,6 +1006,72 @@ var _ = Describe("vars", func() {
 			checkEnvVarWithValue(envVars, "service-host", svcName)
 		})
 
+		It("component vars", func() {
+			synthesizedComp.Comp2CompDefs = map[string]string{
+				"comp":  "compDef",
+				"comp2": "compDef2",
+			}
+			vars := []appsv1alpha1.EnvVar{
+				{
+					Name: "upstream-leader",
+					ValueFrom: &appsv1alpha1.VarSource{
+						ComponentVarRef: &appsv1alpha1.ComponentVarSelector{
+							ClusterObjectReference: appsv1alpha1.ClusterObjectReference{
+								CompDef: "compDef2",
+							},
+							ComponentVars: appsv1alpha1.ComponentVars{
+								PodFQDNsForRole: &appsv1alpha1.RoledVar{Role: "leader"},
+							},
+						},
+					},
+				},
+				{
+					Name: "upstream-followers",
+					ValueFrom: &appsv1alpha1.VarSource{
+						ComponentVarRef: &appsv1alpha1.ComponentVarSelector{
+							ClusterObjectReference: appsv1alpha1.ClusterObjectReference{
+								CompDef: "compDef2",
+							},
+							ComponentVars: appsv1alpha1.ComponentVars{
+								PodNamesForRole: &appsv1alpha1.RoledVar{Role: "follower"},
+							},
+						},
+					},
+				},
+			}
+			_, _, err := ResolveTemplateNEnvVars(testCtx.Ctx, testCtx.Cli, synthesizedComp, vars)
+			Expect(err).ShouldNot(Succeed())
+
+			newPod := func(name, role string) *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testCtx.DefaultNamespace,
+						Name:      name,
+						Labels: map[string]string{
+							constant.AppInstanceLabelKey:    synthesizedComp.ClusterName,
+							constant.KBAppComponentLabelKey: "comp2",
+							constant.RoleLabelKey:           role,
+						},
+					},
+				}
+			}
+			reader := &mockReader{
+				cli: testCtx.Cli,
+				objs: []client.Object{
+					newPod("test-cluster-comp2-2", "follower"),
+					newPod("test-cluster-comp2-0", "leader"),
+					newPod("test-cluster-comp2-1", "follower"),
+				},
+			}
+			templateVars, envVars, err := ResolveTemplateNEnvVars(testCtx.Ctx, reader, synthesizedComp, vars)
+			Expect(err).Should(Succeed())
+			leader := fmt.Sprintf("test-cluster-comp2-0.test-cluster-comp2-headless.%s.svc", testCtx.DefaultNamespace)
+			Expect(templateVars).Should(HaveKeyWithValue("upstream-leader", leader))
+			Expect(templateVars).Should(HaveKeyWithValue("upstream-followers", "test-cluster-comp2-1,test-cluster-comp2-2"))
+			checkEnvVarWithValue(envVars, "upstream-leader", leader)
+			checkEnvVarWithValue(envVars, "upstream-followers", "test-cluster-comp2-1,test-cluster-comp2-2")
+		})
+
 		It("vars reference and escaping", func() {
 			By("reference")
 			vars := []appsv1alpha1.EnvVar{
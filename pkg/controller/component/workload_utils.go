@@ -21,6 +21,7 @@ package component
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -104,3 +105,43 @@ func GetComponentWorkloadMinReadySeconds(ctx context.Context,
 		return GetComponentStsMinReadySeconds(ctx, cli, cluster, componentName)
 	}
 }
+
+// WorkloadName returns the name of the workload of the component @compName in the same cluster as @synthesizedComp,
+// following the naming template of the ClusterDefinition.
+func WorkloadName(synthesizedComp *SynthesizedComponent, compName string) string {
+	return synthesizedComp.NamingTemplate.GenerateComponentWorkloadName(synthesizedComp.ClusterName, compName,
+		clusterCompDefName(synthesizedComp, compName))
+}
+
+// HeadlessServiceName returns the name of the headless service of the component @compName in the same cluster as @synthesizedComp.
+func HeadlessServiceName(synthesizedComp *SynthesizedComponent, compName string) string {
+	return synthesizedComp.NamingTemplate.GenerateComponentHeadlessServiceName(synthesizedComp.ClusterName, compName,
+		clusterCompDefName(synthesizedComp, compName))
+}
+
+// ServiceName returns the name of the service @svcName of the component @compName in the same cluster as @synthesizedComp.
+func ServiceName(synthesizedComp *SynthesizedComponent, compName, svcName string) string {
+	return synthesizedComp.NamingTemplate.GenerateComponentServiceName(synthesizedComp.ClusterName, compName,
+		clusterCompDefName(synthesizedComp, compName), svcName)
+}
+
+// PodName returns the name of the pod with @ordinal of the component @compName in the same cluster as @synthesizedComp.
+func PodName(synthesizedComp *SynthesizedComponent, compName string, ordinal int) string {
+	return synthesizedComp.NamingTemplate.GenerateComponentPodName(synthesizedComp.ClusterName, compName,
+		clusterCompDefName(synthesizedComp, compName), ordinal)
+}
+
+// PodFQDN returns the FQDN of the pod with @ordinal of the component @compName in the same cluster as @synthesizedComp.
+func PodFQDN(synthesizedComp *SynthesizedComponent, compName string, ordinal int) string {
+	return fmt.Sprintf("%s.%s.%s.svc", PodName(synthesizedComp, compName, ordinal),
+		HeadlessServiceName(synthesizedComp, compName), synthesizedComp.Namespace)
+}
+
+// clusterCompDefName returns the name of the ClusterDefinition component definition referenced by the component @compName,
+// which the short name of the naming template is looked up with.
+func clusterCompDefName(synthesizedComp *SynthesizedComponent, compName string) string {
+	if compName == synthesizedComp.Name {
+		return synthesizedComp.ClusterCompDefName
+	}
+	return synthesizedComp.Comp2ClusterCompDefs[compName]
+}
@@ -32,38 +32,38 @@ import (
 
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 )
 
 type envBuildInFunc func(container interface{}, envName string) (string, error)
 
+type secretBuildInFunc func(secretName, key string) (string, error)
+
 type envWrapper struct {
 	// prevent circular references.
 	referenceCount int
 	*configTemplateBuilder
 
 	// configmap or secret not yet submitted.
-	localObjects  []coreclient.Object
-	clusterName   string
-	clusterUID    string
-	componentName string
+	localObjects []coreclient.Object
+	component    *component.SynthesizedComponent
 	// cache remoted configmap and secret.
 	cache map[schema.GroupVersionKind]map[coreclient.ObjectKey]coreclient.Object
 }
 
 const maxReferenceCount = 10
 
-func wrapGetEnvByName(templateBuilder *configTemplateBuilder, component *component.SynthesizedComponent, localObjs []coreclient.Object) envBuildInFunc {
+func wrapGetEnvByName(templateBuilder *configTemplateBuilder, synthesizedComp *component.SynthesizedComponent, localObjs []coreclient.Object) envBuildInFunc {
 	wrapper := &envWrapper{
 		configTemplateBuilder: templateBuilder,
 		localObjects:          localObjs,
+		component:             synthesizedComp,
 		cache:                 make(map[schema.GroupVersionKind]map[coreclient.ObjectKey]coreclient.Object),
 	}
 	// hack for test cases of cli update cmd...
-	if component != nil {
-		wrapper.clusterName = component.ClusterName
-		wrapper.clusterUID = component.ClusterUID
-		wrapper.componentName = component.Name
+	if synthesizedComp == nil {
+		wrapper.component = &component.SynthesizedComponent{}
 	}
 	return func(args interface{}, envName string) (string, error) {
 		container, err := fromJSONObject[corev1.Container](args)
@@ -74,6 +74,36 @@ func wrapGetEnvByName(templateBuilder *configTemplateBuilder, component *compone
 	}
 }
 
+// wrapGetSecretValue returns the function to get the value of the Secret key,
+// the template which calls it is required to be rendered as a Secret.
+func wrapGetSecretValue(templateBuilder *configTemplateBuilder, localObjs []coreclient.Object) secretBuildInFunc {
+	wrapper := &envWrapper{
+		configTemplateBuilder: templateBuilder,
+		localObjects:          localObjs,
+		cache:                 make(map[schema.GroupVersionKind]map[coreclient.ObjectKey]coreclient.Object),
+	}
+	return func(secretName, key string) (string, error) {
+		templateBuilder.secretReferenced = true
+		if wrapper.cli == nil {
+			return "", cfgcore.MakeError("not support secret[%s] value in local mode, cli is nil", secretName)
+		}
+		secret, err := getResourceObject(wrapper, &corev1.Secret{}, coreclient.ObjectKey{
+			Name:      secretName,
+			Namespace: wrapper.namespace,
+		})
+		if err != nil {
+			return "", err
+		}
+		if v, ok := secret.Data[key]; ok {
+			return string(v), nil
+		}
+		if v, ok := secret.StringData[key]; ok {
+			return v, nil
+		}
+		return "", cfgcore.MakeError("not found key[%s] in secret[%s]", key, secretName)
+	}
+}
+
 func (w *envWrapper) getEnvByName(container *corev1.Container, envName string) (string, error) {
 	for _, v := range container.Env {
 		if v.Name != envName {
@@ -163,6 +193,13 @@ func (w *envWrapper) secretValue(secretRef *corev1.SecretKeySelector, container
 	if secret.StringData != nil {
 		return secretPlaintext(secret.StringData)
 	}
+	if secretstore.IsSealed(secret) {
+		data, err := secretstore.Unseal(w.ctx, secret)
+		if err != nil {
+			return "", err
+		}
+		return secretCiphertext(data)
+	}
 	if secret.Data != nil {
 		return secretCiphertext(secret.Data)
 	}
@@ -226,10 +263,8 @@ func (w *envWrapper) checkAndReplaceEnv(value string, container *corev1.Containe
 
 func (w *envWrapper) doEnvReplace(replacedVars *set.LinkedHashSetString, oldValue string, container *corev1.Container) (string, error) {
 	var (
-		clusterName   = w.clusterName
-		clusterUID    = w.clusterUID
-		componentName = w.componentName
-		builtInEnvMap = component.GetReplacementMapForBuiltInEnv(clusterName, clusterUID, componentName)
+		clusterName   = w.component.ClusterName
+		builtInEnvMap = component.GetReplacementMapForBuiltInEnv(w.component)
 	)
 
 	maps.Copy(builtInEnvMap, component.GetEnvReplacementMapForConnCredential(clusterName))
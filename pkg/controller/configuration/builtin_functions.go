@@ -46,6 +46,7 @@ const (
 	builtInGetPVCSizeFunctionName                = "getPVCSize"
 	builtInGetContainerMemoryFunctionName        = "getContainerMemory"
 	builtInGetContainerRequestMemoryFunctionName = "getContainerRequestMemory"
+	builtInGetSecretValueFunctionName            = "getSecretValue"
 
 	// BuiltinMysqlCalBufferFunctionName Mysql Built-in
 	// TODO: This function migrate to configuration template
@@ -331,6 +332,7 @@ func BuiltInCustomFunctions(c *configTemplateBuilder, component *component.Synth
 		builtInGetPVCSizeFunctionName:                getPVCSize,
 		builtInGetContainerMemoryFunctionName:        getContainerMemory,
 		builtInGetContainerRequestMemoryFunctionName: getContainerRequestMemory,
+		builtInGetSecretValueFunctionName:            wrapGetSecretValue(c, localObjs),
 		builtInGetCAFile:                             getCAFile,
 		builtInGetCertFile:                           getCertFile,
 		builtInGetKeyFile:                            getKeyFile,
@@ -55,6 +55,7 @@ type builtInObjects struct {
 	component        *component.SynthesizedComponent
 	dynamicCompInfos *[]DynamicComponentInfo
 	componentValues  *componentTemplateValues
+	topology         *ComponentTopology
 }
 
 // General built-in objects
@@ -65,6 +66,7 @@ const (
 	builtinPodObject               = "podSpec"
 	builtinComponentResourceObject = "componentResource"
 	builtinClusterDomainObject     = "clusterDomain"
+	builtinTopologyObject          = "topology"
 )
 
 func buildInComponentObjects(cache []client.Object, podSpec *corev1.PodSpec, component *component.SynthesizedComponent, configSpecs []appsv1alpha1.ComponentConfigSpec, cluster *appsv1alpha1.Cluster) *builtInObjects {
@@ -117,5 +119,6 @@ func builtinCustomObjects(builtin *builtInObjects) map[string]any {
 		builtinPodObject:               builtin.podSpec,
 		builtinComponentResourceObject: builtin.componentValues.Resource,
 		builtinClusterDomainObject:     viper.GetString(constant.KubernetesClusterDomainEnv),
+		builtinTopologyObject:          builtin.topology,
 	}
 }
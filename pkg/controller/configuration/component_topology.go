@@ -0,0 +1,114 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// ComponentTopology describes the members and their roles of the component,
+// which is exposed as the built-in object "topology" in the configuration template rendering.
+type ComponentTopology struct {
+	Replicas  int32            `json:"replicas"`
+	Leader    *TopologyMember  `json:"leader"`
+	Followers []TopologyMember `json:"followers"`
+	Members   []TopologyMember `json:"members"`
+	// RoleEndpoints maps the role name to the addresses of the members with the role.
+	RoleEndpoints map[string][]string `json:"roleEndpoints"`
+}
+
+type TopologyMember struct {
+	Name    string `json:"name"`
+	Ordinal int32  `json:"ordinal"`
+	Role    string `json:"role,omitempty"`
+	Address string `json:"address"`
+}
+
+// BuildComponentTopology builds the topology of the component from the members status of the workload.
+// The members which have not been reported by the workload are built from the replicas, without the role.
+func BuildComponentTopology(synthesizedComp *component.SynthesizedComponent, membersStatus []workloads.MemberStatus) *ComponentTopology {
+	roles := make(map[string]workloads.ReplicaRole, len(membersStatus))
+	podNames := make(map[string]bool)
+	for _, status := range membersStatus {
+		roles[status.PodName] = status.ReplicaRole
+		podNames[status.PodName] = true
+	}
+	for i := 0; i < int(synthesizedComp.Replicas); i++ {
+		podNames[component.PodName(synthesizedComp, synthesizedComp.Name, i)] = true
+	}
+
+	topology := &ComponentTopology{
+		Replicas:      synthesizedComp.Replicas,
+		Followers:     make([]TopologyMember, 0),
+		Members:       make([]TopologyMember, 0, len(podNames)),
+		RoleEndpoints: make(map[string][]string),
+	}
+	for podName := range podNames {
+		_, ordinal := intctrlutil.ParseParentNameAndOrdinal(podName)
+		topology.Members = append(topology.Members, TopologyMember{
+			Name:    podName,
+			Ordinal: ordinal,
+			Role:    roles[podName].Name,
+			Address: fmt.Sprintf("%s.%s.%s.svc", podName,
+				component.HeadlessServiceName(synthesizedComp, synthesizedComp.Name), synthesizedComp.Namespace),
+		})
+	}
+	sort.Slice(topology.Members, func(i, j int) bool {
+		if topology.Members[i].Ordinal != topology.Members[j].Ordinal {
+			return topology.Members[i].Ordinal < topology.Members[j].Ordinal
+		}
+		return topology.Members[i].Name < topology.Members[j].Name
+	})
+	for i, member := range topology.Members {
+		switch {
+		case roles[member.Name].IsLeader && topology.Leader == nil:
+			topology.Leader = &topology.Members[i]
+		default:
+			topology.Followers = append(topology.Followers, member)
+		}
+		if member.Role != "" {
+			topology.RoleEndpoints[member.Role] = append(topology.RoleEndpoints[member.Role], member.Address)
+		}
+	}
+	return topology
+}
+
+// getComponentTopology gets the topology of the component from the running workload.
+// If the workload is not found, e.g. the component is creating, the topology is built from the replicas.
+func getComponentTopology(ctx context.Context, cli client.Reader, synthesizedComp *component.SynthesizedComponent) *ComponentTopology {
+	var membersStatus []workloads.MemberStatus
+	rsm := &workloads.ReplicatedStateMachine{}
+	rsmKey := client.ObjectKey{
+		Namespace: synthesizedComp.Namespace,
+		Name:      component.WorkloadName(synthesizedComp, synthesizedComp.Name),
+	}
+	if cli != nil && cli.Get(ctx, rsmKey, rsm) == nil {
+		membersStatus = rsm.Status.MembersStatus
+	}
+	return BuildComponentTopology(synthesizedComp, membersStatus)
+}
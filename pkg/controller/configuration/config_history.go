@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// configHistoryLimit is the max number of the rendered configuration versions kept for each config spec.
+const configHistoryLimit = 10
+
+// GetConfigHistoryName returns the name of the ConfigMap which keeps the rendered configuration of the revision.
+func GetConfigHistoryName(cmName, revision string) string {
+	return fmt.Sprintf("%s-rev-%s", cmName, revision)
+}
+
+// GetConfigHistory returns the rendered configuration of the revision.
+// The revision whose rendered configuration is the same as the previous one is not kept as a history version,
+// so it falls back to the latest history version before the revision.
+func GetConfigHistory(ctx context.Context, cli client.Client, cm *corev1.ConfigMap, revision string) (*corev1.ConfigMap, error) {
+	history := &corev1.ConfigMap{}
+	err := cli.Get(ctx, client.ObjectKey{Namespace: cm.Namespace, Name: GetConfigHistoryName(cm.Name, revision)}, history)
+	if !apierrors.IsNotFound(err) {
+		if err != nil {
+			return nil, err
+		}
+		return history, nil
+	}
+	target, parseErr := strconv.ParseInt(revision, 10, 64)
+	if parseErr != nil {
+		return nil, err
+	}
+	items, listErr := ListConfigHistory(ctx, cli, cm)
+	if listErr != nil {
+		return nil, listErr
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		if parseConfigHistoryRevision(&items[i]) <= target {
+			return &items[i], nil
+		}
+	}
+	return nil, err
+}
+
+// ListConfigHistory returns the history versions of the configuration ConfigMap, sorted by the revision in ascending order.
+func ListConfigHistory(ctx context.Context, cli client.Client, cm *corev1.ConfigMap) ([]corev1.ConfigMap, error) {
+	historyList := &corev1.ConfigMapList{}
+	if err := cli.List(ctx, historyList, client.InNamespace(cm.Namespace), configHistoryLabels(cm)); err != nil {
+		return nil, err
+	}
+	items := historyList.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return parseConfigHistoryRevision(&items[i]) < parseConfigHistoryRevision(&items[j])
+	})
+	return items, nil
+}
+
+// syncConfigHistory keeps the rendered configuration of the revision in a ConfigMap labeled as history,
+// and removes the oldest versions beyond the limit.
+// The revision is skipped if the rendered configuration is the same as the latest history version.
+func syncConfigHistory(ctx context.Context, cli client.Client, cm *corev1.ConfigMap, revision string) error {
+	items, err := ListConfigHistory(ctx, cli, cm)
+	if err != nil {
+		return err
+	}
+	if len(items) > 0 && reflect.DeepEqual(items[len(items)-1].Data, cm.Data) {
+		return nil
+	}
+
+	history := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetConfigHistoryName(cm.Name, revision),
+			Namespace: cm.Namespace,
+			Labels:    configHistoryLabels(cm),
+			Annotations: map[string]string{
+				constant.ConfigurationRevision:             revision,
+				constant.ConfigAppliedVersionAnnotationKey: cm.Annotations[constant.ConfigAppliedVersionAnnotationKey],
+			},
+			OwnerReferences: cm.OwnerReferences,
+		},
+		Data: cm.Data,
+	}
+	if err = cli.Create(ctx, history); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	if items, err = ListConfigHistory(ctx, cli, cm); err != nil {
+		return err
+	}
+	for i := 0; i < len(items)-configHistoryLimit; i++ {
+		if err := cli.Delete(ctx, &items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func configHistoryLabels(cm *corev1.ConfigMap) client.MatchingLabels {
+	return client.MatchingLabels{
+		constant.AppInstanceLabelKey:                 cm.Labels[constant.AppInstanceLabelKey],
+		constant.KBAppComponentLabelKey:              cm.Labels[constant.KBAppComponentLabelKey],
+		constant.CMConfigurationSpecProviderLabelKey: cm.Labels[constant.CMConfigurationSpecProviderLabelKey],
+		constant.CMConfigurationTypeLabelKey:         constant.ConfigHistoryType,
+	}
+}
+
+func parseConfigHistoryRevision(history *corev1.ConfigMap) int64 {
+	revision, err := strconv.ParseInt(history.Annotations[constant.ConfigurationRevision], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return revision
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("ConfigHistory test", func() {
+
+	const (
+		clusterName    = "test-cluster"
+		mysqlCompName  = "mysql"
+		configSpecName = "mysql-config"
+	)
+
+	var cm *corev1.ConfigMap
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		testapps.ClearResources(&testCtx, generics.ConfigMapSignature, inNS, configHistoryLabels(cm))
+	}
+
+	BeforeEach(func() {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s-%s", clusterName, mysqlCompName, configSpecName),
+				Namespace: testCtx.DefaultNamespace,
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:                 clusterName,
+					constant.KBAppComponentLabelKey:              mysqlCompName,
+					constant.CMConfigurationSpecProviderLabelKey: configSpecName,
+				},
+			},
+		}
+		cleanEnv()
+	})
+
+	AfterEach(cleanEnv)
+
+	expectRevisions := func(expected ...string) {
+		items, err := ListConfigHistory(ctx, k8sClient, cm)
+		Expect(err).Should(Succeed())
+		var revisions []string
+		for _, item := range items {
+			revisions = append(revisions, item.Annotations[constant.ConfigurationRevision])
+		}
+		Expect(revisions).Should(Equal(expected))
+	}
+
+	expectHistory := func(revision, expected string) {
+		history, err := GetConfigHistory(ctx, k8sClient, cm, revision)
+		Expect(err).Should(Succeed())
+		Expect(history.Annotations[constant.ConfigurationRevision]).Should(Equal(expected))
+	}
+
+	Context("test the history versions of the rendered configuration", func() {
+		It("should keep the changed configuration only", func() {
+			cm.Data = map[string]string{"my.cnf": "max_connections=100"}
+			Expect(syncConfigHistory(ctx, k8sClient, cm, "1")).Should(Succeed())
+			expectRevisions("1")
+
+			By("the rendered configuration is not changed, no new history version is kept")
+			Expect(syncConfigHistory(ctx, k8sClient, cm, "2")).Should(Succeed())
+			expectRevisions("1")
+
+			cm.Data = map[string]string{"my.cnf": "max_connections=200"}
+			Expect(syncConfigHistory(ctx, k8sClient, cm, "3")).Should(Succeed())
+			expectRevisions("1", "3")
+
+			By("the revision which is not kept falls back to the latest history version before it")
+			expectHistory("1", "1")
+			expectHistory("2", "1")
+			expectHistory("3", "3")
+			expectHistory("5", "3")
+			_, err := GetConfigHistory(ctx, k8sClient, cm, "0")
+			Expect(apierrors.IsNotFound(err)).Should(BeTrue())
+
+			By("the oldest versions beyond the limit are removed")
+			for i := 4; i < 4+configHistoryLimit; i++ {
+				cm.Data = map[string]string{"my.cnf": fmt.Sprintf("max_connections=%d", i*100)}
+				Expect(syncConfigHistory(ctx, k8sClient, cm, fmt.Sprint(i))).Should(Succeed())
+			}
+			expectRevisions("4", "5", "6", "7", "8", "9", "10", "11", "12", "13")
+		})
+	})
+})
@@ -22,6 +22,7 @@ package configuration
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +41,9 @@ type configTemplateBuilder struct {
 	builtInFunctions *gotemplate.BuiltInObjectsFunc
 	builtInObjects   *builtInObjects
 
+	// whether the values of Secrets are referenced by the last rendered template.
+	secretReferenced bool
+
 	podSpec *corev1.PodSpec
 	// cluster *appsv1alpha1.Cluster
 	ctx context.Context
@@ -70,11 +74,16 @@ func (c *configTemplateBuilder) formatError(file string, err error) error {
 }
 
 func (c *configTemplateBuilder) render(configs map[string]string) (map[string]string, error) {
+	// the topology is resolved from the running workload only if it is referenced by the templates.
+	if c.builtInObjects.topology == nil && c.builtInObjects.component != nil && referencesBuiltinObject(configs, builtinTopologyObject) {
+		c.builtInObjects.topology = getComponentTopology(c.ctx, c.cli, c.builtInObjects.component)
+	}
 	values, err := builtinObjectsAsValues(c.builtInObjects)
 	if err != nil {
 		return nil, err
 	}
 
+	c.secretReferenced = false
 	rendered := make(map[string]string, len(configs))
 	engine := gotemplate.NewTplEngine(values, c.builtInFunctions, c.templateName, c.cli, c.ctx)
 	for file, configContext := range configs {
@@ -97,3 +106,12 @@ func (c *configTemplateBuilder) injectBuiltInObjectsAndFunctions(
 	c.builtInFunctions = BuiltInCustomFunctions(c, component, localObjs)
 	c.builtInObjects = buildInComponentObjects(localObjs, podSpec, component, configs, cluster)
 }
+
+func referencesBuiltinObject(configs map[string]string, name string) bool {
+	for _, content := range configs {
+		if strings.Contains(content, "."+name) {
+			return true
+		}
+	}
+	return false
+}
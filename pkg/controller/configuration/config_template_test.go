@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	ctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -601,4 +602,50 @@ true
 		})
 	})
 
+	Context("component topology test", func() {
+		It("should build the topology from the members status", func() {
+			synthesizedComp := &ctrlcomp.SynthesizedComponent{
+				Namespace:   "default",
+				ClusterName: "my-test",
+				Name:        "mysql",
+				Replicas:    3,
+			}
+			topology := BuildComponentTopology(synthesizedComp, []workloads.MemberStatus{
+				{PodName: "my-test-mysql-0", ReplicaRole: workloads.ReplicaRole{Name: "follower"}},
+				{PodName: "my-test-mysql-1", ReplicaRole: workloads.ReplicaRole{Name: "leader", IsLeader: true}},
+			})
+			Expect(topology.Replicas).Should(BeEquivalentTo(3))
+			Expect(topology.Members).Should(HaveLen(3))
+			Expect(topology.Members[2].Name).Should(Equal("my-test-mysql-2"))
+			Expect(topology.Members[2].Ordinal).Should(BeEquivalentTo(2))
+			Expect(topology.Members[2].Role).Should(BeEmpty())
+			Expect(topology.Leader).ShouldNot(BeNil())
+			Expect(topology.Leader.Name).Should(Equal("my-test-mysql-1"))
+			Expect(topology.Leader.Address).Should(Equal("my-test-mysql-1.my-test-mysql-headless.default.svc"))
+			Expect(topology.Followers).Should(HaveLen(2))
+			Expect(topology.RoleEndpoints["follower"]).Should(Equal([]string{"my-test-mysql-0.my-test-mysql-headless.default.svc"}))
+		})
+
+		It("should render the template with the topology", func() {
+			cfgBuilder := newTemplateBuilder("my-test", "default", nil, nil)
+			cfgBuilder.injectBuiltInObjectsAndFunctions(podSpec, cfgTemplate, &ctrlcomp.SynthesizedComponent{
+				Namespace:   "default",
+				ClusterName: "my-test",
+				Name:        "mysql",
+				Replicas:    2,
+			}, nil, &appsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-test",
+					Namespace: "default",
+				},
+			})
+			rendered, err := cfgBuilder.render(map[string]string{
+				"my.cnf": `wsrep_cluster_address=gcomm://{{- range $i, $m := $.topology.members }}{{ if $i }},{{ end }}{{ $m.address }}{{- end }}`,
+			})
+			Expect(err).Should(Succeed())
+			Expect(rendered["my.cnf"]).Should(Equal("wsrep_cluster_address=gcomm://" +
+				"my-test-mysql-0.my-test-mysql-headless.default.svc,my-test-mysql-1.my-test-mysql-headless.default.svc"))
+		})
+	})
+
 })
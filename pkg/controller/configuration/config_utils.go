@@ -278,6 +278,33 @@ func UpdateConfigPayload(config *appsv1alpha1.ConfigurationSpec, component *comp
 	return updated, nil
 }
 
+// EnableTopologyTrigger checks if any config spec is re-rendered when the topology of the component changes.
+func EnableTopologyTrigger(config *appsv1alpha1.ConfigurationSpec) bool {
+	for _, item := range config.ConfigItemDetails {
+		if enableTopologyTrigger(item.ConfigSpec) {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateConfigTopologyPayload updates the topology payload of the config specs which are re-rendered when the topology changes.
+func UpdateConfigTopologyPayload(config *appsv1alpha1.ConfigurationSpec, topology *ComponentTopology) (bool, error) {
+	updated := false
+	for i := range config.ConfigItemDetails {
+		configSpec := &config.ConfigItemDetails[i]
+		if !enableTopologyTrigger(configSpec.ConfigSpec) {
+			continue
+		}
+		ret, err := intctrlutil.CheckAndPatchPayload(configSpec, constant.TopologyPayload, topology)
+		if err != nil {
+			return false, err
+		}
+		updated = updated || ret
+	}
+	return updated, nil
+}
+
 func validRerenderResources(configSpec *appsv1alpha1.ComponentConfigSpec) bool {
 	return configSpec != nil && len(configSpec.ReRenderResourceTypes) != 0
 }
@@ -286,6 +313,10 @@ func enableReplicasTrigger(configSpec *appsv1alpha1.ComponentConfigSpec) bool {
 	return validRerenderResources(configSpec) && slices.Contains(configSpec.ReRenderResourceTypes, appsv1alpha1.ComponentReplicasType)
 }
 
+func enableTopologyTrigger(configSpec *appsv1alpha1.ComponentConfigSpec) bool {
+	return validRerenderResources(configSpec) && slices.Contains(configSpec.ReRenderResourceTypes, appsv1alpha1.ComponentTopologyType)
+}
+
 func enableResourceTrigger(configSpec *appsv1alpha1.ComponentConfigSpec) bool {
 	return validRerenderResources(configSpec) && slices.Contains(configSpec.ReRenderResourceTypes, appsv1alpha1.ComponentResourceType)
 }
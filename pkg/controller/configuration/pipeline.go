@@ -336,6 +336,23 @@ func (p *updatePipeline) ApplyParameters() *updatePipeline {
 	})
 }
 
+// DiffParameters records the parameter-level diffs between the current and the new configuration in the item status.
+func (p *updatePipeline) DiffParameters() *updatePipeline {
+	return p.Wrap(func() error {
+		if p.isDone() || p.itemStatus == nil || p.ConfigMapObj == nil ||
+			p.ConfigConstraintObj == nil || p.ConfigConstraintObj.Spec.FormatterConfig == nil {
+			return nil
+		}
+		formatter := p.ConfigConstraintObj.Spec.FormatterConfig
+		configPatch, _, err := core.CreateConfigPatch(p.ConfigMapObj.Data, p.newCM.Data, formatter.Format, p.configSpec.Keys, false)
+		if err != nil {
+			return err
+		}
+		p.itemStatus.ParameterDiffs = core.GenerateParameterDiffs(configPatch, formatter, p.ConfigConstraintObj.Spec.SensitiveParameters)
+		return nil
+	})
+}
+
 func (p *updatePipeline) UpdateConfigVersion(revision string) *updatePipeline {
 	return p.Wrap(func() error {
 		if p.isDone() {
@@ -380,6 +397,16 @@ func (p *updatePipeline) Sync() *updatePipeline {
 	})
 }
 
+// SyncHistory keeps the rendered configuration as a history version, which can be rolled back to by the Reconfiguring OpsRequest.
+func (p *updatePipeline) SyncHistory(revision string) *updatePipeline {
+	return p.Wrap(func() error {
+		if p.isDone() || p.newCM == nil {
+			return nil
+		}
+		return syncConfigHistory(p.Context, p.Client, p.newCM, revision)
+	})
+}
+
 func (p *updatePipeline) SyncStatus() *updatePipeline {
 	return p.Wrap(func() (err error) {
 		if p.isDone() {
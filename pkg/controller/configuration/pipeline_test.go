@@ -198,6 +198,7 @@ max_connections = '1000'
 				PrepareForTemplate().
 				RerenderTemplate().
 				ApplyParameters().
+				DiffParameters().
 				UpdateConfigVersion(strconv.FormatInt(reconcileTask.ConfigurationObj.GetGeneration(), 10)).
 				Sync().
 				SyncStatus().
@@ -213,6 +214,7 @@ max_connections = '1000'
 				PrepareForTemplate().
 				RerenderTemplate().
 				ApplyParameters().
+				DiffParameters().
 				UpdateConfigVersion(strconv.FormatInt(reconcileTask.ConfigurationObj.GetGeneration(), 10)).
 				Sync().
 				SyncStatus().
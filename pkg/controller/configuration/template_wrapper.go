@@ -28,6 +28,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -100,6 +101,12 @@ func (wrapper *renderWrapper) renderConfigTemplate(cluster *appsv1alpha1.Cluster
 	revision := fromConfiguration(configuration)
 	for _, configSpec := range component.ConfigTemplates {
 		var item *appsv1alpha1.ConfigurationItemDetail
+		if configSpec.AsSecret {
+			if err := wrapper.renderConfigTemplateAsSecret(cluster, component, localObjs, configSpec, configuration); err != nil {
+				return err
+			}
+			continue
+		}
 		cmName := core.GetComponentCfgName(cluster.Name, component.Name, configSpec.Name)
 		origCMObj, err := wrapper.checkRerenderTemplateSpec(cmName, localObjs)
 		if err != nil {
@@ -129,6 +136,61 @@ func (wrapper *renderWrapper) renderConfigTemplate(cluster *appsv1alpha1.Cluster
 	return nil
 }
 
+// renderConfigTemplateAsSecret renders the config template into a Secret, which keeps the same metadata as the rendered ConfigMap.
+// The updated parameters of the Configuration are applied when it is rendered.
+func (wrapper *renderWrapper) renderConfigTemplateAsSecret(cluster *appsv1alpha1.Cluster, component *component.SynthesizedComponent,
+	localObjs []client.Object, configSpec appsv1alpha1.ComponentConfigSpec, configuration *appsv1alpha1.Configuration) error {
+	secretKey := client.ObjectKey{
+		Name:      core.GetComponentCfgName(cluster.Name, component.Name, configSpec.Name),
+		Namespace: wrapper.cluster.Namespace,
+	}
+	if object := findMatchedLocalObject(localObjs, secretKey, generics.ToGVK(&corev1.Secret{})); object != nil {
+		wrapper.addVolumeMountMeta(configSpec.ComponentTemplateSpec, object, false)
+		return nil
+	}
+	origSecret := &corev1.Secret{}
+	if err := wrapper.cli.Get(wrapper.ctx, secretKey, origSecret); err == nil {
+		wrapper.addVolumeMountMeta(configSpec.ComponentTemplateSpec, origSecret, false)
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	var item *appsv1alpha1.ConfigurationItemDetail
+	if configuration != nil {
+		item = configuration.Spec.GetConfigurationItem(configSpec.Name)
+	}
+	newCMObj, err := wrapper.rerenderConfigTemplate(cluster, component, configSpec, item)
+	if err != nil {
+		return err
+	}
+	if err = applyUpdatedParameters(item, newCMObj, configSpec, wrapper.cli, wrapper.ctx); err != nil {
+		return err
+	}
+	if err = updateConfigMetaForCM(newCMObj, item, fromConfiguration(configuration)); err != nil {
+		return err
+	}
+	core.SetParametersUpdateSource(newCMObj, constant.ReconfigureManagerSource)
+	return wrapper.addRenderedSecret(configSpec.ComponentTemplateSpec, convertToSecret(newCMObj))
+}
+
+// convertToSecret converts the rendered ConfigMap into a Secret with the same metadata and data.
+func convertToSecret(cm *corev1.ConfigMap) *corev1.Secret {
+	data := make(map[string][]byte, len(cm.Data))
+	for key, value := range cm.Data {
+		data[key] = []byte(value)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cm.Name,
+			Namespace:   cm.Namespace,
+			Labels:      cm.Labels,
+			Annotations: cm.Annotations,
+		},
+		Data: data,
+	}
+}
+
 func fromConfiguration(configuration *appsv1alpha1.Configuration) string {
 	if configuration == nil {
 		return ""
@@ -223,14 +285,29 @@ func (wrapper *renderWrapper) renderScriptTemplate(cluster *appsv1alpha1.Cluster
 	localObjs []client.Object) error {
 	for _, templateSpec := range component.ScriptTemplates {
 		cmName := core.GetComponentCfgName(cluster.Name, component.Name, templateSpec.Name)
+		var localObj client.Object = &corev1.ConfigMap{}
+		if templateSpec.AsSecret {
+			localObj = &corev1.Secret{}
+		}
 		object := findMatchedLocalObject(localObjs, client.ObjectKey{
 			Name:      cmName,
-			Namespace: wrapper.cluster.Namespace}, generics.ToGVK(&corev1.ConfigMap{}))
+			Namespace: wrapper.cluster.Namespace}, generics.ToGVK(localObj))
 		if object != nil {
 			wrapper.addVolumeMountMeta(templateSpec, object, false)
 			continue
 		}
 
+		if templateSpec.AsSecret {
+			secret, err := generateSecretFromTpl(cluster, component, wrapper.templateBuilder, cmName, templateSpec, wrapper.ctx, wrapper.cli)
+			if err != nil {
+				return err
+			}
+			if err := wrapper.addRenderedSecret(templateSpec, secret); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// Generate ConfigMap objects for config files
 		cm, err := generateConfigMapFromTpl(cluster, component, wrapper.templateBuilder, cmName, "", templateSpec, wrapper.ctx, wrapper.cli, nil)
 		if err != nil {
@@ -260,6 +337,15 @@ func (wrapper *renderWrapper) addRenderedObject(templateSpec appsv1alpha1.Compon
 	return nil
 }
 
+// addRenderedSecret adds the secret rendered from the template, the owner of the secret is the cluster.
+func (wrapper *renderWrapper) addRenderedSecret(templateSpec appsv1alpha1.ComponentTemplateSpec, secret *corev1.Secret) error {
+	if err := intctrlutil.SetOwnerReference(wrapper.cluster, secret); err != nil {
+		return err
+	}
+	wrapper.addVolumeMountMeta(templateSpec, secret, true)
+	return nil
+}
+
 func (wrapper *renderWrapper) addVolumeMountMeta(templateSpec appsv1alpha1.ComponentTemplateSpec, object client.Object, rendered bool) {
 	wrapper.volumes[object.GetName()] = templateSpec
 	if rendered {
@@ -344,6 +430,21 @@ func generateConfigMapFromTpl(cluster *appsv1alpha1.Cluster,
 	return factory.BuildConfigMapWithTemplate(cluster, component, configs, cmName, templateSpec), nil
 }
 
+// generateSecretFromTpl renders the template which references the values of secrets into a secret.
+func generateSecretFromTpl(cluster *appsv1alpha1.Cluster,
+	component *component.SynthesizedComponent,
+	tplBuilder *configTemplateBuilder,
+	secretName string,
+	templateSpec appsv1alpha1.ComponentTemplateSpec,
+	ctx context.Context,
+	cli client.Client) (*corev1.Secret, error) {
+	configs, err := renderConfigMapTemplate(tplBuilder, templateSpec, ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+	return factory.BuildSecretWithTemplate(cluster, component, configs, secretName, templateSpec), nil
+}
+
 // renderConfigMapTemplate renders config file using template engine
 func renderConfigMapTemplate(
 	templateBuilder *configTemplateBuilder,
@@ -368,6 +469,9 @@ func renderConfigMapTemplate(
 	if err != nil {
 		return nil, core.WrapError(err, "failed to render configmap")
 	}
+	if templateBuilder.secretReferenced && !templateSpec.AsSecret {
+		return nil, core.MakeError("the template[%s] references the values of secrets, it is required to be rendered as a secret", templateSpec.Name)
+	}
 	return renderedData, nil
 }
 
@@ -141,6 +141,51 @@ var _ = Describe("TemplateWrapperTest", func() {
 			Expect(tplWrapper.renderConfigTemplate(clusterObj, clusterComponent, nil, nil)).Should(Succeed())
 		})
 
+		It("TestConfigSpec rendered as a secret", func() {
+			mockK8sCli.MockGetMethod(testutil.WithGetReturned(testutil.WithConstructSimpleGetResult([]client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      configSpecName,
+						Namespace: testCtx.DefaultNamespace,
+					},
+					Data: map[string]string{
+						configSpecName: `[mysqld]
+password={{ getSecretValue "my-secret" "password" }}`,
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-secret",
+						Namespace: testCtx.DefaultNamespace,
+					},
+					Data: map[string][]byte{
+						"password": []byte("123456"),
+					},
+				},
+				&appsv1alpha1.ConfigConstraint{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: configSpecName,
+					},
+					Spec: appsv1alpha1.ConfigConstraintSpec{
+						FormatterConfig: &appsv1alpha1.FormatterConfig{
+							Format: appsv1alpha1.Ini,
+						},
+					},
+				},
+			}), testutil.WithAnyTimes()))
+
+			clusterComponent.ConfigTemplates[0].AsSecret = true
+			tplWrapper := mockTemplateWrapper()
+			Expect(tplWrapper.renderConfigTemplate(clusterObj, clusterComponent, nil, nil)).Should(Succeed())
+			Expect(tplWrapper.renderedObjs).Should(HaveLen(1))
+			secret, ok := tplWrapper.renderedObjs[0].(*corev1.Secret)
+			Expect(ok).Should(BeTrue())
+			Expect(secret.Name).Should(Equal(cfgcore.GetComponentCfgName(clusterName, clusterComponent.Name, clusterComponent.ConfigTemplates[0].Name)))
+			Expect(string(secret.Data[configSpecName])).Should(ContainSubstring("password=123456"))
+			Expect(secret.Labels[constant.CMConfigurationSpecProviderLabelKey]).Should(Equal(clusterComponent.ConfigTemplates[0].Name))
+			Expect(tplWrapper.volumes[secret.Name].AsSecret).Should(BeTrue())
+		})
+
 	})
 
 	Context("TestScriptsSpec", func() {
@@ -175,5 +220,42 @@ var _ = Describe("TemplateWrapperTest", func() {
 			tplWrapper := mockTemplateWrapper()
 			Expect(tplWrapper.renderScriptTemplate(clusterObj, clusterComponent, []client.Object{cmObj})).Should(Succeed())
 		})
+
+		It("TestScriptSpec referencing secrets", func() {
+			mockK8sCli.MockGetMethod(testutil.WithGetReturned(testutil.WithConstructSimpleGetResult([]client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      mysqlScriptsConfigName,
+						Namespace: testCtx.DefaultNamespace,
+					},
+					Data: map[string]string{
+						"setup.sh": `mysql -uroot -p{{ getSecretValue "my-secret" "password" }}`,
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-secret",
+						Namespace: testCtx.DefaultNamespace,
+					},
+					Data: map[string][]byte{
+						"password": []byte("123456"),
+					},
+				},
+			}), testutil.WithAnyTimes()))
+
+			By("rendering into a configmap is not allowed")
+			tplWrapper := mockTemplateWrapper()
+			Expect(tplWrapper.renderScriptTemplate(clusterObj, clusterComponent, nil)).ShouldNot(Succeed())
+
+			By("rendering into a secret")
+			clusterComponent.ScriptTemplates[0].AsSecret = true
+			tplWrapper = mockTemplateWrapper()
+			Expect(tplWrapper.renderScriptTemplate(clusterObj, clusterComponent, nil)).Should(Succeed())
+			Expect(tplWrapper.renderedObjs).Should(HaveLen(1))
+			secret, ok := tplWrapper.renderedObjs[0].(*corev1.Secret)
+			Expect(ok).Should(BeTrue())
+			Expect(string(secret.Data["setup.sh"])).Should(Equal("mysql -uroot -p123456"))
+			Expect(tplWrapper.volumes[secret.Name].AsSecret).Should(BeTrue())
+		})
 	})
 })
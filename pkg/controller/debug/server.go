@@ -0,0 +1,243 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package debug
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	clustersPath = "/debug/clusters/"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var log = ctrl.Log.WithName("debug")
+
+// Server serves the pprof profiles, the expvar variables, and the view of the clusters as the controllers see them,
+// to debug the memory growth and the stuck reconciliations. It is bound to localhost, or requires a bearer token
+// if bound to other addresses.
+type Server struct {
+	addr  string
+	token string
+	// cli reads the objects through the cache of the manager, as the controllers do.
+	cli client.Client
+}
+
+// NewServer creates the debug server listening on addr, the token is required if addr is not a loopback address.
+func NewServer(addr, token string, cli client.Client) (*Server, error) {
+	if len(token) == 0 && !isLoopback(addr) {
+		return nil, fmt.Errorf("the debug endpoint %s is not bound to localhost, the %s is required",
+			addr, constant.CfgKeyDebugAuthToken)
+	}
+	return &Server{addr: addr, token: token, cli: cli}, nil
+}
+
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Handler returns the handler of the debug endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc(clustersPath, s.serveCluster)
+	return s.authenticate(mux)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	if len(s.token) == 0 {
+		return next
+	}
+	expected := []byte("Bearer " + s.token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Start starts the server and blocks until ctx is done, it implements manager.Runnable.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("serving debug endpoints", "addr", s.addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, the endpoints are served by all the replicas.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// clusterView is the view of a cluster as the controllers see it.
+type clusterView struct {
+	Cluster                 *appsv1alpha1.Cluster              `json:"cluster"`
+	Components              []appsv1alpha1.Component           `json:"components"`
+	ReplicatedStateMachines []workloads.ReplicatedStateMachine `json:"replicatedStateMachines"`
+	Pods                    []corev1.Pod                       `json:"pods"`
+	PersistentVolumeClaims  []corev1.PersistentVolumeClaim     `json:"persistentVolumeClaims"`
+	// PodExpectations are the pod creations and deletions not observed yet, keyed by the replicated state machine.
+	PodExpectations map[string]podExpectations `json:"podExpectations,omitempty"`
+}
+
+type podExpectations struct {
+	Creations []string    `json:"creations,omitempty"`
+	Deletions []types.UID `json:"deletions,omitempty"`
+}
+
+// serveCluster serves /debug/clusters/<namespace>/<name>.
+func (s *Server) serveCluster(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, clustersPath), "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		http.Error(w, fmt.Sprintf("expected %s<namespace>/<name>", clustersPath), http.StatusBadRequest)
+		return
+	}
+	view, err := s.getClusterView(r.Context(), types.NamespacedName{Namespace: parts[0], Name: parts[1]})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if apierrors.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(view); err != nil {
+		log.Error(err, "failed to write the cluster view", "cluster", parts[0]+"/"+parts[1])
+	}
+}
+
+func (s *Server) getClusterView(ctx context.Context, key types.NamespacedName) (*clusterView, error) {
+	view := &clusterView{Cluster: &appsv1alpha1.Cluster{}}
+	if err := s.cli.Get(ctx, key, view.Cluster); err != nil {
+		return nil, err
+	}
+	opts := []client.ListOption{
+		client.InNamespace(key.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: key.Name},
+	}
+	compList := &appsv1alpha1.ComponentList{}
+	if err := s.cli.List(ctx, compList, opts...); err != nil {
+		return nil, err
+	}
+	rsmList := &workloads.ReplicatedStateMachineList{}
+	if err := s.cli.List(ctx, rsmList, opts...); err != nil {
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := s.cli.List(ctx, podList, opts...); err != nil {
+		return nil, err
+	}
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := s.cli.List(ctx, pvcList, opts...); err != nil {
+		return nil, err
+	}
+	view.Components = compList.Items
+	view.ReplicatedStateMachines = rsmList.Items
+	view.Pods = podList.Items
+	view.PersistentVolumeClaims = pvcList.Items
+
+	for _, rsm := range rsmList.Items {
+		expKey := client.ObjectKeyFromObject(&rsm).String()
+		creations, deletions := intctrlutil.PodExpectations.Pending(expKey)
+		if len(creations) == 0 && len(deletions) == 0 {
+			continue
+		}
+		if view.PodExpectations == nil {
+			view.PodExpectations = map[string]podExpectations{}
+		}
+		view.PodExpectations[expKey] = podExpectations{Creations: creations, Deletions: deletions}
+	}
+	stripManagedFields(view)
+	return view, nil
+}
+
+// stripManagedFields removes the managed fields, which are large and useless for debugging.
+func stripManagedFields(view *clusterView) {
+	view.Cluster.ManagedFields = nil
+	for i := range view.Components {
+		view.Components[i].ManagedFields = nil
+	}
+	for i := range view.ReplicatedStateMachines {
+		view.ReplicatedStateMachines[i].ManagedFields = nil
+	}
+	for i := range view.Pods {
+		view.Pods[i].ManagedFields = nil
+	}
+	for i := range view.PersistentVolumeClaims {
+		view.PersistentVolumeClaims[i].ManagedFields = nil
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("debug server", func() {
+	const (
+		clusterDefName     = "test-clusterdef"
+		clusterVersionName = "test-clusterversion"
+		clusterName        = "mycluster"
+		mysqlCompDefName   = "replicasets"
+		mysqlCompName      = "mysql"
+		token              = "secret"
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.RSMSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PodSignature, true, inNS, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName, clusterVersionName).
+			AddComponent(mysqlCompName, mysqlCompDefName).
+			Create(&testCtx)
+		testapps.NewRSMFactory(testCtx.DefaultNamespace, clusterName+"-"+mysqlCompName, clusterName, mysqlCompName).
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+			Create(&testCtx)
+		testapps.NewPodFactory(testCtx.DefaultNamespace, clusterName+"-"+mysqlCompName+"-0").
+			AddAppInstanceLabel(clusterName).
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+			Create(&testCtx)
+		testapps.NewPodFactory(testCtx.DefaultNamespace, "other-"+mysqlCompName+"-0").
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+			Create(&testCtx)
+	})
+
+	AfterEach(cleanEnv)
+
+	newServer := func(token string) *httptest.Server {
+		server, err := NewServer("127.0.0.1:0", token, k8sClient)
+		Expect(err).Should(Succeed())
+		return httptest.NewServer(server.Handler())
+	}
+
+	get := func(url, token string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		Expect(err).Should(Succeed())
+		if len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).Should(Succeed())
+		return resp
+	}
+
+	It("requires the token if not bound to localhost", func() {
+		_, err := NewServer(":6060", "", nil)
+		Expect(err).Should(HaveOccurred())
+		_, err = NewServer("0.0.0.0:6060", token, nil)
+		Expect(err).Should(Succeed())
+		for _, addr := range []string{"127.0.0.1:6060", "localhost:6060", "[::1]:6060"} {
+			_, err = NewServer(addr, "", nil)
+			Expect(err).Should(Succeed())
+		}
+	})
+
+	It("serves pprof and expvar", func() {
+		server := newServer("")
+		defer server.Close()
+		for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/vars"} {
+			resp := get(server.URL+path, "")
+			resp.Body.Close()
+			Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		}
+	})
+
+	It("authenticates the requests by the bearer token", func() {
+		server := newServer(token)
+		defer server.Close()
+		resp := get(server.URL+"/debug/vars", "")
+		resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		resp = get(server.URL+"/debug/vars", "wrong")
+		resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusUnauthorized))
+		resp = get(server.URL+"/debug/vars", token)
+		resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+	})
+
+	It("dumps the view of the cluster", func() {
+		server := newServer("")
+		defer server.Close()
+
+		expKey := testCtx.DefaultNamespace + "/" + clusterName + "-mysql"
+		intctrlutil.PodExpectations.ExpectCreations(expKey, clusterName+"-mysql-1")
+		defer intctrlutil.PodExpectations.Delete(expKey)
+
+		resp := get(server.URL+clustersPath+testCtx.DefaultNamespace+"/"+clusterName, "")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusOK))
+		view := &clusterView{}
+		Expect(json.NewDecoder(resp.Body).Decode(view)).Should(Succeed())
+		Expect(view.Cluster.Name).Should(Equal(clusterName))
+		Expect(view.Cluster.ManagedFields).Should(BeEmpty())
+		Expect(view.ReplicatedStateMachines).Should(HaveLen(1))
+		Expect(view.Pods).Should(HaveLen(1))
+		Expect(view.Pods[0].Name).Should(Equal(clusterName + "-mysql-0"))
+		Expect(view.PodExpectations).Should(HaveKeyWithValue(expKey, podExpectations{Creations: []string{clusterName + "-mysql-1"}}))
+	})
+
+	It("returns not found or bad request", func() {
+		server := newServer("")
+		defer server.Close()
+		resp := get(server.URL+clustersPath+testCtx.DefaultNamespace+"/not-exist", "")
+		resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusNotFound))
+		resp = get(server.URL+clustersPath+testCtx.DefaultNamespace, "")
+		resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+	})
+})
@@ -76,13 +76,23 @@ func BuildRSM(cluster *appsv1alpha1.Cluster, synthesizedComp *component.Synthesi
 	podBuilder := builder.NewPodBuilder("", "").
 		AddLabelsInMap(labels).
 		AddLabelsInMap(compDefLabel).
-		AddLabelsInMap(constant.GetAppVersionLabel(compDefName))
+		AddLabelsInMap(constant.GetAppVersionLabel(compDefName)).
+		AddAnnotationsInMap(intctrlutil.BuildServiceMeshPodAnnotations(cluster.Spec.ServiceMesh, synthesizedComp.PodSpec))
+	// record the hash of the certificates in use, which the rotation of the certificates is detected by.
+	if len(synthesizedComp.TLSCertHash) > 0 {
+		mergeAnnotations[constant.TLSCertHashAnnotationKey] = synthesizedComp.TLSCertHash
+	}
+	// the pods are restarted to load the rotated certificates if the engine can't reload them by itself.
+	if synthesizedComp.TLSCertRotated && synthesizedComp.TLSCertReloadPolicy == appsv1alpha1.RollingRestartTLSCert {
+		podBuilder.AddAnnotations(constant.TLSCertHashAnnotationKey, synthesizedComp.TLSCertHash)
+	}
 	template := corev1.PodTemplateSpec{
 		ObjectMeta: podBuilder.GetObject().ObjectMeta,
 		Spec:       *synthesizedComp.PodSpec.DeepCopy(),
 	}
+	intctrlutil.ApplySecurityContextDefaults(&template.Spec, synthesizedComp.SecurityContext)
 
-	rsmName := constant.GenerateRSMNamePattern(clusterName, compName)
+	rsmName := component.WorkloadName(synthesizedComp, compName)
 	rsmBuilder := builder.NewReplicatedStateMachineBuilder(namespace, rsmName).
 		AddLabelsInMap(mergeLabels).
 		AddAnnotationsInMap(mergeAnnotations).
@@ -194,6 +204,13 @@ func strongRandomString(length int) string {
 
 func BuildConnCredential(clusterDefinition *appsv1alpha1.ClusterDefinition, cluster *appsv1alpha1.Cluster,
 	synthesizedComp *component.SynthesizedComponent) *corev1.Secret {
+	return RebuildConnCredential(clusterDefinition, cluster, synthesizedComp, nil)
+}
+
+// RebuildConnCredential builds the connection credential secret, the constant values and the values generated randomly
+// (passwords, UUIDs) are taken from the existing secret if any, so that only the endpoint related values are regenerated.
+func RebuildConnCredential(clusterDefinition *appsv1alpha1.ClusterDefinition, cluster *appsv1alpha1.Cluster,
+	synthesizedComp *component.SynthesizedComponent, existing *corev1.Secret) *corev1.Secret {
 	wellKnownLabels := constant.GetKBWellKnownLabels(clusterDefinition.Name, cluster.Name, "")
 	delete(wellKnownLabels, constant.KBAppComponentLabelKey)
 	credentialBuilder := builder.NewSecretBuilder(cluster.Namespace, constant.GenerateDefaultConnCredential(cluster.Name)).
@@ -281,6 +298,8 @@ func BuildConnCredential(clusterDefinition *appsv1alpha1.ClusterDefinition, clus
 		randomPassword = restorePassword
 		strongRandomPasswd = restorePassword
 	}
+	svcName := synthesizedComp.NamingTemplate.GenerateComponentServiceName(cluster.Name, synthesizedComp.Name,
+		synthesizedComp.ClusterCompDefName, "")
 	m := map[string]string{
 		"$(RANDOM_PASSWD)":        randomPassword,
 		"$(STRONG_RANDOM_PASSWD)": strongRandomPasswd,
@@ -288,17 +307,40 @@ func BuildConnCredential(clusterDefinition *appsv1alpha1.ClusterDefinition, clus
 		"$(UUID_B64)":             uuidB64,
 		"$(UUID_STR_B64)":         uuidStrB64,
 		"$(UUID_HEX)":             uuidHex,
-		"$(SVC_FQDN)":             constant.GenerateDefaultComponentServiceName(cluster.Name, synthesizedComp.Name),
-		constant.EnvPlaceHolder(constant.KBEnvClusterCompName): constant.GenerateClusterComponentName(cluster.Name, synthesizedComp.Name),
-		"$(HEADLESS_SVC_FQDN)":                                 constant.GenerateDefaultComponentHeadlessServiceName(cluster.Name, synthesizedComp.Name),
+		"$(SVC_FQDN)":             svcName,
+		constant.EnvPlaceHolder(constant.KBEnvClusterCompName): synthesizedComp.NamingTemplate.GenerateComponentWorkloadName(cluster.Name,
+			synthesizedComp.Name, synthesizedComp.ClusterCompDefName),
+		"$(HEADLESS_SVC_FQDN)": synthesizedComp.NamingTemplate.GenerateComponentHeadlessServiceName(cluster.Name,
+			synthesizedComp.Name, synthesizedComp.ClusterCompDefName),
+		"$(NAMESPACE)": cluster.Namespace,
 	}
 	if len(synthesizedComp.Services) > 0 {
 		for _, p := range synthesizedComp.Services[0].Spec.Ports {
 			m[fmt.Sprintf("$(SVC_PORT_%s)", p.Name)] = strconv.Itoa(int(p.Port))
 		}
 	}
+	// the additional services of the component, e.g., a readonly service
+	for _, svc := range synthesizedComp.Services {
+		if len(svc.Name) == 0 {
+			continue
+		}
+		m[fmt.Sprintf("$(SVC_FQDN_%s)", svc.Name)] = synthesizedComp.NamingTemplate.GenerateComponentServiceName(cluster.Name,
+			synthesizedComp.Name, synthesizedComp.ClusterCompDefName, svc.Name)
+	}
 	replaceData(m)
 
+	// keep the random values generated before and the constant values which may have been changed by users
+	if existing != nil {
+		for k, v := range clusterDefinition.Spec.ConnectionCredential {
+			if strings.Contains(v, "$(") && !hasRandomConnCredentialVar(v) {
+				continue
+			}
+			if val, ok := existing.Data[k]; ok {
+				connCredential.StringData[k] = string(val)
+			}
+		}
+	}
+
 	// 2nd pass replace $(CONN_CREDENTIAL) variables
 	m = map[string]string{}
 	for k, v := range connCredential.StringData {
@@ -308,6 +350,16 @@ func BuildConnCredential(clusterDefinition *appsv1alpha1.ClusterDefinition, clus
 	return connCredential
 }
 
+// hasRandomConnCredentialVar checks whether the value refers to any variable generated randomly.
+func hasRandomConnCredentialVar(value string) bool {
+	for _, v := range []string{"$(RANDOM_PASSWD)", "$(STRONG_RANDOM_PASSWD)", "$(UUID)", "$(UUID_B64)", "$(UUID_STR_B64)", "$(UUID_HEX)"} {
+		if strings.Contains(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
 func BuildPVC(cluster *appsv1alpha1.Cluster,
 	component *component.SynthesizedComponent,
 	vct *corev1.PersistentVolumeClaimTemplate,
@@ -369,6 +421,26 @@ func BuildConfigMapWithTemplate(cluster *appsv1alpha1.Cluster,
 		GetObject()
 }
 
+// BuildSecretWithTemplate builds the Secret of the template which is rendered as a Secret.
+func BuildSecretWithTemplate(cluster *appsv1alpha1.Cluster,
+	component *component.SynthesizedComponent,
+	configs map[string]string,
+	secretName string,
+	configTemplateSpec appsv1alpha1.ComponentTemplateSpec) *corev1.Secret {
+	wellKnownLabels := constant.GetKBWellKnownLabels(component.ClusterDefName, cluster.Name, component.Name)
+	wellKnownLabels[constant.AppComponentLabelKey] = component.ClusterCompDefName
+	data := make(map[string][]byte, len(configs))
+	for key, value := range configs {
+		data[key] = []byte(value)
+	}
+	return builder.NewSecretBuilder(cluster.Namespace, secretName).
+		AddLabelsInMap(wellKnownLabels).
+		AddLabels(constant.CMConfigurationTypeLabelKey, constant.ConfigInstanceType).
+		AddLabels(constant.CMTemplateNameLabelKey, configTemplateSpec.TemplateRef).
+		SetData(data).
+		GetObject()
+}
+
 func BuildCfgManagerContainer(sidecarRenderedParam *cfgcm.CfgManagerBuildParams, component *component.SynthesizedComponent) (*corev1.Container, error) {
 	var env []corev1.EnvVar
 	env = append(env, corev1.EnvVar{
@@ -492,6 +564,31 @@ func BuildRoleBinding(cluster *appsv1alpha1.Cluster, saName string) *rbacv1.Role
 		GetObject()
 }
 
+func BuildComponentRole(synthesizedComp *component.SynthesizedComponent, rules []rbacv1.PolicyRule) *rbacv1.Role {
+	wellKnownLabels := constant.GetKBWellKnownLabels(synthesizedComp.ClusterDefName, synthesizedComp.ClusterName, synthesizedComp.Name)
+	return builder.NewRoleBuilder(synthesizedComp.Namespace, constant.GenerateComponentRoleName(synthesizedComp.ClusterName, synthesizedComp.Name)).
+		AddLabelsInMap(wellKnownLabels).
+		AddPolicyRules(rules...).
+		GetObject()
+}
+
+func BuildComponentRoleBinding(synthesizedComp *component.SynthesizedComponent, roleName, saName string) *rbacv1.RoleBinding {
+	wellKnownLabels := constant.GetKBWellKnownLabels(synthesizedComp.ClusterDefName, synthesizedComp.ClusterName, synthesizedComp.Name)
+	return builder.NewRoleBindingBuilder(synthesizedComp.Namespace, roleName).
+		AddLabelsInMap(wellKnownLabels).
+		SetRoleRef(rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     roleName,
+		}).
+		AddSubjects(rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: synthesizedComp.Namespace,
+			Name:      saName,
+		}).
+		GetObject()
+}
+
 func BuildClusterRoleBinding(cluster *appsv1alpha1.Cluster, saName string) *rbacv1.ClusterRoleBinding {
 	// TODO(component): compName
 	wellKnownLabels := constant.GetKBWellKnownLabels(cluster.Spec.ClusterDefRef, cluster.Name, "")
@@ -237,6 +237,39 @@ var _ = Describe("builder", func() {
 
 		})
 
+		It("rebuilds Conn. Credential with the existing one", func() {
+			var (
+				clusterDefObj                             = testapps.NewClusterDefFactoryWithConnCredential("conn-cred", mysqlCompDefName).GetObject()
+				clusterDef, cluster, synthesizedComponent = newClusterObjs(clusterDefObj)
+			)
+			clusterDef.Spec.ConnectionCredential["readonlyEndpoint"] = "$(SVC_FQDN_readonly).$(NAMESPACE).svc"
+			clusterDef.Spec.ConnectionCredential["dsn"] = "root:$(CONN_CREDENTIAL).RANDOM_PASSWD@tcp($(SVC_FQDN):$(SVC_PORT_mysql))/"
+			synthesizedComponent.Services = append(synthesizedComponent.Services, corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "readonly"},
+			})
+			credential := BuildConnCredential(clusterDef, cluster, synthesizedComponent)
+			Expect(credential.StringData["readonlyEndpoint"]).Should(Equal(
+				fmt.Sprintf("%s-%s-readonly.%s.svc", cluster.Name, synthesizedComponent.Name, cluster.Namespace)))
+
+			existing := &corev1.Secret{Data: map[string][]byte{}}
+			for k, v := range credential.StringData {
+				existing.Data[k] = []byte(v)
+			}
+			existing.Data["username"] = []byte("admin")
+
+			By("regenerating the endpoints with the random and constant values kept")
+			clusterDef.Spec.NamingTemplate = &appsv1alpha1.NamingTemplate{Prefix: "foo"}
+			synthesizedComponent.NamingTemplate = clusterDef.Spec.NamingTemplate
+			rebuilt := RebuildConnCredential(clusterDef, cluster, synthesizedComponent, existing)
+			Expect(rebuilt.StringData["RANDOM_PASSWD"]).Should(Equal(credential.StringData["RANDOM_PASSWD"]))
+			Expect(rebuilt.StringData["UUID"]).Should(Equal(credential.StringData["UUID"]))
+			Expect(rebuilt.StringData["username"]).Should(Equal("admin"))
+			Expect(rebuilt.StringData["SVC_FQDN"]).Should(Equal(fmt.Sprintf("foo-%s-%s", cluster.Name, synthesizedComponent.Name)))
+			Expect(rebuilt.StringData["readonlyEndpoint"]).Should(HavePrefix("foo-"))
+			Expect(rebuilt.StringData["dsn"]).Should(ContainSubstring(credential.StringData["RANDOM_PASSWD"]))
+			Expect(rebuilt.StringData["dsn"]).Should(ContainSubstring(rebuilt.StringData["SVC_FQDN"]))
+		})
+
 		It("builds Conn. Credential during restoring from backup", func() {
 			originalPassword := "test-passw0rd"
 			encryptionKey := "encryptionKey"
@@ -335,6 +368,17 @@ var _ = Describe("builder", func() {
 			Expect(*rsm.Spec.MemberUpdateStrategy).Should(BeEquivalentTo(workloads.BestEffortParallelUpdateStrategy))
 		})
 
+		It("builds RSM with the priorityClassName of the component", func() {
+			cluster, clusterDef, clusterVersion, _ := newAllFieldsClusterObj(nil, nil, false)
+			cluster.Spec.ComponentSpecs[0].PriorityClassName = "high-priority"
+			synthesizedComponent := newAllFieldsComponent(clusterDef, clusterVersion, cluster)
+			Expect(synthesizedComponent.PodSpec.PriorityClassName).Should(Equal("high-priority"))
+
+			rsm, err := BuildRSM(cluster, synthesizedComponent)
+			Expect(err).Should(BeNil())
+			Expect(rsm.Spec.Template.Spec.PriorityClassName).Should(Equal("high-priority"))
+		})
+
 		It("builds BackupJob correctly", func() {
 			_, cluster, synthesizedComponent := newClusterObjs(nil)
 			backupJobKey := types.NamespacedName{
@@ -56,7 +56,9 @@ func (r TransformerChain) ApplyTo(ctx TransformContext, dag *DAG) error {
 	for _, transformer := range r {
 		if err := transformer.Transform(ctx, dag); err != nil {
 			if intctrlutil.IsDelayedRequeueError(err) {
-				if delayedError == nil {
+				// requeue as early as the transformers require.
+				if delayedError == nil ||
+					err.(intctrlutil.DelayedRequeueError).RequeueAfter() < delayedError.(intctrlutil.DelayedRequeueError).RequeueAfter() {
 					delayedError = err
 				}
 				continue
@@ -0,0 +1,223 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/sink"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// the significant transitions to notify
+	EventClusterFailed      = "ClusterFailed"
+	EventFailoverExecuted   = "FailoverExecuted"
+	EventSwitchoverExecuted = "SwitchoverExecuted"
+	EventBackupFailed       = "BackupFailed"
+	EventOpsCompleted       = "OpsCompleted"
+
+	// the annotation keys of the Cluster to configure the notifications of the cluster,
+	// which are sent in addition to the ones configured at the operator level.
+	AnnotationKeyWebhookURL = "notification.kubeblocks.io/webhook-url"
+	AnnotationKeyTemplate   = "notification.kubeblocks.io/template"
+	AnnotationKeyEvents     = "notification.kubeblocks.io/events"
+
+	// DefaultTemplate is the default template of the payload, which is compatible with the incoming webhooks of Slack.
+	DefaultTemplate = `{"text": {{ printf "[%s] %s/%s: %s" .Event .Namespace .Cluster .Message | json }}}`
+
+	// maxSinks is the max number of webhooks to send to, the least recently used one is stopped for a new one.
+	maxSinks = 64
+)
+
+var (
+	log = logf.Log.WithName("notification")
+	// sinks post the notifications to the webhooks.
+	sinks = sink.NewPool("notification", maxSinks)
+)
+
+// Notification is the notification of a significant transition of a cluster, it's the data to render the payload template.
+type Notification struct {
+	Event     string    `json:"event"`
+	Namespace string    `json:"namespace"`
+	Cluster   string    `json:"cluster"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notify sends the notification of the event of obj to the webhooks configured at the operator level
+// and by the annotations of the cluster which obj belongs to. The cluster is read by cli if obj isn't a Cluster,
+// and only the operator level webhook is notified if it fails to be read.
+func Notify(ctx context.Context, cli client.Reader, obj client.Object, event, message string) {
+	cluster, ok := obj.(*appsv1alpha1.Cluster)
+	if !ok {
+		cluster = getCluster(ctx, cli, obj)
+	}
+	NotifyCluster(cluster, obj, event, message)
+}
+
+// NotifyCluster sends the notification of the event of obj which belongs to the cluster, the cluster can be nil.
+func NotifyCluster(cluster *appsv1alpha1.Cluster, obj client.Object, event, message string) {
+	n := Notification{
+		Event:     event,
+		Namespace: obj.GetNamespace(),
+		Cluster:   getClusterName(obj),
+		Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+		Name:      obj.GetName(),
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+	if len(n.Kind) == 0 {
+		// the type meta of the typed objects read from the server is empty
+		n.Kind = reflect.TypeOf(obj).Elem().Name()
+	}
+	if cluster != nil {
+		n.Cluster = cluster.Name
+	}
+
+	if url := viper.GetString(constant.CfgKeyNotificationWebhookURL); len(url) > 0 &&
+		subscribed(viper.GetString(constant.CfgKeyNotificationEvents), event) {
+		send(url, viper.GetString(constant.CfgKeyNotificationTemplate), n)
+	}
+	if cluster == nil || !viper.GetBool(constant.CfgKeyNotificationClusterWebhooksEnabled) {
+		return
+	}
+	if url := cluster.Annotations[AnnotationKeyWebhookURL]; len(url) > 0 &&
+		subscribed(cluster.Annotations[AnnotationKeyEvents], event) {
+		// the annotations are editable by the users of the cluster, only the hosts allowed by the operator are posted to.
+		if !hostAllowed(url, viper.GetString(constant.CfgKeyNotificationClusterWebhookHosts)) {
+			log.Info("the host of the cluster webhook is not allowed, drop the notification",
+				"cluster", n.Namespace+"/"+n.Cluster, "webhook", url)
+			return
+		}
+		tpl := cluster.Annotations[AnnotationKeyTemplate]
+		if len(tpl) == 0 {
+			tpl = viper.GetString(constant.CfgKeyNotificationTemplate)
+		}
+		send(url, tpl, n)
+	}
+}
+
+// hostAllowed returns true if the webhook is an HTTP(S) URL whose host is in the comma-separated hosts,
+// a host with the prefix "*." matches its subdomains. No host is allowed if the hosts are empty.
+func hostAllowed(webhook, hosts string) bool {
+	u, err := url.Parse(webhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range strings.Split(hosts, ",") {
+		h = strings.ToLower(strings.TrimSpace(h))
+		switch {
+		case len(h) == 0:
+			continue
+		case strings.HasPrefix(h, "*."):
+			if strings.HasSuffix(host, h[1:]) {
+				return true
+			}
+		case h == host || h == u.Host:
+			return true
+		}
+	}
+	return false
+}
+
+func getClusterName(obj client.Object) string {
+	switch o := obj.(type) {
+	case *appsv1alpha1.Cluster:
+		return o.Name
+	case *appsv1alpha1.OpsRequest:
+		return o.Spec.ClusterRef
+	default:
+		return obj.GetLabels()[constant.AppInstanceLabelKey]
+	}
+}
+
+func getCluster(ctx context.Context, cli client.Reader, obj client.Object) *appsv1alpha1.Cluster {
+	name := getClusterName(obj)
+	if cli == nil || len(name) == 0 {
+		return nil
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}, cluster); err != nil {
+		log.V(1).Info("failed to get the cluster to notify", "cluster", name, "error", err.Error())
+		return nil
+	}
+	return cluster
+}
+
+// subscribed returns true if the event is in the comma-separated events, or the events are empty.
+func subscribed(events, event string) bool {
+	if len(strings.TrimSpace(events)) == 0 {
+		return true
+	}
+	for _, e := range strings.Split(events, ",") {
+		if strings.TrimSpace(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Render renders the payload of the notification by the template, DefaultTemplate is used if the template is empty.
+// Besides the functions of text/template, the function json quotes a value as JSON.
+func Render(tpl string, n Notification) ([]byte, error) {
+	if len(tpl) == 0 {
+		tpl = DefaultTemplate
+	}
+	t, err := template.New("notification").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(tpl)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func send(url, tpl string, n Notification) {
+	payload, err := Render(tpl, n)
+	if err != nil {
+		log.Error(err, "failed to render the notification", "event", n.Event, "cluster", n.Namespace+"/"+n.Cluster)
+		return
+	}
+	sinks.Send(url, payload)
+}
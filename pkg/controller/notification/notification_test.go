@@ -0,0 +1,167 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package notification
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("notification", func() {
+	const (
+		clusterDefName     = "test-clusterdef"
+		clusterVersionName = "test-clusterversion"
+		clusterName        = "mycluster"
+		mysqlCompDefName   = "replicasets"
+		mysqlCompName      = "mysql"
+	)
+
+	var (
+		cluster  *appsv1alpha1.Cluster
+		pod      *corev1.Pod
+		received chan []byte
+		server   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		cluster = testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, clusterDefName, clusterVersionName).
+			AddComponent(mysqlCompName, mysqlCompDefName).
+			GetObject()
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      clusterName + "-" + mysqlCompName + "-1",
+				Labels:    map[string]string{constant.AppInstanceLabelKey: clusterName},
+			},
+		}
+		received = make(chan []byte, 4)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Method).Should(Equal(http.MethodPost))
+			body, err := io.ReadAll(r.Body)
+			Expect(err).Should(Succeed())
+			received <- body
+		}))
+		viper.Set(constant.CfgKeyNotificationClusterWebhooksEnabled, true)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+		viper.Set(constant.CfgKeyNotificationWebhookURL, "")
+		viper.Set(constant.CfgKeyNotificationTemplate, "")
+		viper.Set(constant.CfgKeyNotificationEvents, "")
+		viper.Set(constant.CfgKeyNotificationClusterWebhooksEnabled, false)
+		viper.Set(constant.CfgKeyNotificationClusterWebhookHosts, "")
+	})
+
+	It("renders the Slack-compatible payload by default", func() {
+		payload, err := Render("", Notification{
+			Event:     EventClusterFailed,
+			Namespace: "default",
+			Cluster:   "mycluster",
+			Message:   `the phase of cluster changes from Running to "Failed"`,
+		})
+		Expect(err).Should(Succeed())
+		body := map[string]string{}
+		Expect(json.Unmarshal(payload, &body)).Should(Succeed())
+		Expect(body).Should(HaveKeyWithValue("text",
+			`[ClusterFailed] default/mycluster: the phase of cluster changes from Running to "Failed"`))
+
+		_, err = Render("{{ .Unknown }}", Notification{})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("sends the notification to the operator webhook with the template", func() {
+		viper.Set(constant.CfgKeyNotificationWebhookURL, server.URL)
+		viper.Set(constant.CfgKeyNotificationTemplate, `{"event": {{ .Event | json }}, "kind": {{ .Kind | json }}, "name": {{ .Name | json }}}`)
+		NotifyCluster(nil, pod, EventFailoverExecuted, "the leader changes")
+
+		var body []byte
+		Eventually(received).Should(Receive(&body))
+		Expect(string(body)).Should(Equal(`{"event": "FailoverExecuted", "kind": "Pod", "name": "mycluster-mysql-1"}`))
+	})
+
+	It("filters the events not subscribed", func() {
+		viper.Set(constant.CfgKeyNotificationWebhookURL, server.URL)
+		viper.Set(constant.CfgKeyNotificationEvents, EventBackupFailed+", "+EventClusterFailed)
+		NotifyCluster(cluster, cluster, EventOpsCompleted, "ops completed")
+		Consistently(received, 200*time.Millisecond).ShouldNot(Receive())
+		NotifyCluster(cluster, cluster, EventClusterFailed, "cluster failed")
+		Eventually(received).Should(Receive())
+	})
+
+	It("sends the notification to the webhook of the cluster read by the client", func() {
+		cluster.Annotations = map[string]string{
+			AnnotationKeyWebhookURL: server.URL,
+			AnnotationKeyTemplate:   `{{ .Cluster }}/{{ .Event }}`,
+			AnnotationKeyEvents:     EventFailoverExecuted,
+		}
+		Expect(testCtx.CreateObj(ctx, cluster)).Should(Succeed())
+
+		By("not sending to the webhook of the cluster if the host is not allowed")
+		Notify(ctx, k8sClient, pod, EventFailoverExecuted, "the leader changes")
+		Consistently(received, 200*time.Millisecond).ShouldNot(Receive())
+
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).Should(Succeed())
+		viper.Set(constant.CfgKeyNotificationClusterWebhookHosts, "hooks.slack.com, "+serverURL.Host)
+		Notify(ctx, k8sClient, pod, EventFailoverExecuted, "the leader changes")
+		var body []byte
+		Eventually(received).Should(Receive(&body))
+		Expect(string(body)).Should(Equal("mycluster/FailoverExecuted"))
+
+		By("not sending to the webhook of the cluster if disabled")
+		viper.Set(constant.CfgKeyNotificationClusterWebhooksEnabled, false)
+		Notify(ctx, k8sClient, pod, EventFailoverExecuted, "the leader changes")
+		Consistently(received, 200*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("allows the hosts of the cluster webhooks", func() {
+		hosts := "hooks.slack.com, *.example.com, 10.0.0.1:8080"
+		Expect(hostAllowed("https://hooks.slack.com/services/T0/B0/X", hosts)).Should(BeTrue())
+		Expect(hostAllowed("https://HOOKS.slack.com/services", hosts)).Should(BeTrue())
+		Expect(hostAllowed("https://alerts.example.com/hook", hosts)).Should(BeTrue())
+		Expect(hostAllowed("http://10.0.0.1:8080/hook", hosts)).Should(BeTrue())
+		Expect(hostAllowed("https://example.com/hook", hosts)).Should(BeFalse())
+		Expect(hostAllowed("https://evilexample.com/hook", hosts)).Should(BeFalse())
+		Expect(hostAllowed("http://10.0.0.1/hook", hosts)).Should(BeFalse())
+		Expect(hostAllowed("http://169.254.169.254/latest/meta-data", hosts)).Should(BeFalse())
+		Expect(hostAllowed("file:///etc/passwd", "*")).Should(BeFalse())
+		Expect(hostAllowed("https://hooks.slack.com/services", "")).Should(BeFalse())
+	})
+})
@@ -26,6 +26,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -89,6 +90,9 @@ func (r *RestoreManager) DoRestore(comp *component.SynthesizedComponent, compObj
 	if backupObj.Status.BackupMethod == nil {
 		return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRestoreFailed, `status.backupMethod of backup "%s" can not be empty`, backupObj.Name)
 	}
+	if err = r.validateCompatibility(comp, backupObj); err != nil {
+		return err
+	}
 	if err = r.DoPrepareData(comp, compObj, backupObj); err != nil {
 		return err
 	}
@@ -137,7 +141,7 @@ func (r *RestoreManager) BuildPrepareDataRestore(comp *component.SynthesizedComp
 		}
 		pvc := &corev1.PersistentVolumeClaim{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:   fmt.Sprintf("%s-%s-%s", v.Name, r.Cluster.Name, comp.Name),
+				Name:   fmt.Sprintf("%s-%s", v.Name, component.WorkloadName(comp, comp.Name)),
 				Labels: pvcLabels,
 				Annotations: map[string]string{
 					// satisfy the detection of transformer_halt_recovering.
@@ -254,11 +258,83 @@ func (r *RestoreManager) GetRestoreObjectMeta(comp *component.SynthesizedCompone
 	if len(r.restoreLabels) == 0 {
 		r.restoreLabels = constant.GetKBWellKnownLabels(comp.ClusterDefName, r.Cluster.Name, comp.Name)
 	}
-	return metav1.ObjectMeta{
+	objMeta := metav1.ObjectMeta{
 		Name:      name,
 		Namespace: r.Cluster.Namespace,
 		Labels:    r.restoreLabels,
 	}
+	// the restore jobs run before the pods are created, mark the service mesh which the cluster works with.
+	if r.Cluster.Spec.ServiceMesh != nil {
+		objMeta.Annotations = map[string]string{constant.ServiceMeshAnnotationKey: string(r.Cluster.Spec.ServiceMesh.Provider)}
+	}
+	return objMeta
+}
+
+// validateCompatibility checks whether the backup can be restored to the component before the restore jobs run,
+// the cluster to restore can have a different topology from the backed up cluster, but the engine version
+// should be compatible and the volumes should be large enough to hold the backup data.
+func (r *RestoreManager) validateCompatibility(comp *component.SynthesizedComponent, backupObj *dpv1alpha1.Backup) error {
+	if err := r.validateClusterVersion(backupObj); err != nil {
+		return err
+	}
+	return r.validateVolumeSize(comp, backupObj)
+}
+
+// validateClusterVersion checks whether the ClusterVersion of the cluster is compatible with the one of the backed up cluster.
+func (r *RestoreManager) validateClusterVersion(backupObj *dpv1alpha1.Backup) error {
+	clusterString := backupObj.Annotations[constant.ClusterSnapshotAnnotationKey]
+	if len(clusterString) == 0 || len(r.Cluster.Spec.ClusterVersionRef) == 0 {
+		return nil
+	}
+	sourceCluster := &appsv1alpha1.Cluster{}
+	if err := json.Unmarshal([]byte(clusterString), sourceCluster); err != nil {
+		return err
+	}
+	sourceVersionName := sourceCluster.Spec.ClusterVersionRef
+	if len(sourceVersionName) == 0 || sourceVersionName == r.Cluster.Spec.ClusterVersionRef {
+		return nil
+	}
+	sourceVersion := &appsv1alpha1.ClusterVersion{}
+	if err := r.Client.Get(r.Ctx, client.ObjectKey{Name: sourceVersionName}, sourceVersion); err != nil {
+		// the ClusterVersion of the backed up cluster may have been removed, skip the validation.
+		return client.IgnoreNotFound(err)
+	}
+	targetVersion := &appsv1alpha1.ClusterVersion{}
+	if err := r.Client.Get(r.Ctx, client.ObjectKey{Name: r.Cluster.Spec.ClusterVersionRef}, targetVersion); err != nil {
+		return err
+	}
+	if err := targetVersion.ValidateRestoreFrom(sourceVersion); err != nil {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRestoreFailed, "failed to restore backup %s: %s", backupObj.Name, err.Error())
+	}
+	return nil
+}
+
+// validateVolumeSize checks whether the total size of the volumes to restore is not less than the backup size.
+func (r *RestoreManager) validateVolumeSize(comp *component.SynthesizedComponent, backupObj *dpv1alpha1.Backup) error {
+	targetVolumes := backupObj.Status.BackupMethod.TargetVolumes
+	if targetVolumes == nil || len(backupObj.Status.TotalSize) == 0 {
+		return nil
+	}
+	backupSize, err := resource.ParseQuantity(backupObj.Status.TotalSize)
+	if err != nil {
+		// the total size is reported by the backup tool, skip the validation if it is invalid.
+		return nil
+	}
+	volumeSize := resource.Quantity{}
+	for _, v := range comp.VolumeClaimTemplates {
+		if !r.existVolumeSource(targetVolumes, v.Name) {
+			continue
+		}
+		if storage, ok := v.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			volumeSize.Add(storage)
+		}
+	}
+	if volumeSize.IsZero() || volumeSize.Cmp(backupSize) >= 0 {
+		return nil
+	}
+	return intctrlutil.NewErrorf(intctrlutil.ErrorTypeRestoreFailed,
+		"failed to restore backup %s: the volume size %s of component %s is less than the backup size %s",
+		backupObj.Name, volumeSize.String(), comp.Name, backupSize.String())
 }
 
 // existVolumeSource checks if the backup.status.backupMethod.targetVolumes exists the target volume which should be restored.
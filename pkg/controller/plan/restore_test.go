@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package plan
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -289,6 +290,58 @@ var _ = Describe("Restore", func() {
 			err := restoreMGR.DoRestore(synthesizedComponent, compObj)
 			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRestoreFailed)).Should(BeTrue())
 		})
+
+		Context("validate compatibility", func() {
+			doRestore := func() error {
+				restoreFromBackup := fmt.Sprintf(`{"%s": {"name":"%s"}}`, mysqlCompName, backup.Name)
+				Expect(testapps.ChangeObj(&testCtx, cluster, func(tmpCluster *appsv1alpha1.Cluster) {
+					tmpCluster.Annotations = map[string]string{
+						constant.RestoreFromBackupAnnotationKey: restoreFromBackup,
+					}
+				})).Should(Succeed())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).Should(Succeed())
+				restoreMGR := NewRestoreManager(ctx, k8sClient, cluster, scheme.Scheme, nil, 3, 0)
+				return restoreMGR.DoRestore(synthesizedComponent, compObj)
+			}
+
+			It("should fail if the volume size is less than the backup size", func() {
+				backup.Status.TotalSize = "2Gi"
+				patchBackupStatus(backup.Status, client.ObjectKeyFromObject(backup))
+				err := doRestore()
+				Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRestoreFailed)).Should(BeTrue())
+				Expect(err.Error()).Should(ContainSubstring("less than the backup size"))
+			})
+
+			It("should fail if the major version of the ClusterVersion is changed", func() {
+				By("create a ClusterVersion with another major version for the backed up cluster")
+				sourceClusterVersion := testapps.NewClusterVersionFactory(clusterVersionName+"-source", clusterDefName).
+					AddComponentVersion(mysqlCompType).
+					AddContainerShort("mysql", testapps.ApeCloudMySQLImage).
+					Create(&testCtx).GetObject()
+				Expect(testapps.ChangeObj(&testCtx, sourceClusterVersion, func(cv *appsv1alpha1.ClusterVersion) {
+					cv.Spec.Version = "5.7.44"
+				})).Should(Succeed())
+				Expect(testapps.ChangeObj(&testCtx, clusterVersion, func(cv *appsv1alpha1.ClusterVersion) {
+					cv.Spec.Version = "8.0.30"
+				})).Should(Succeed())
+
+				By("mock the cluster snapshot of the backup")
+				sourceClusterObj := cluster.DeepCopy()
+				sourceClusterObj.Spec.ClusterVersionRef = sourceClusterVersion.Name
+				clusterJSON, err := json.Marshal(sourceClusterObj)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(testapps.ChangeObj(&testCtx, backup, func(tmpBackup *dpv1alpha1.Backup) {
+					if tmpBackup.Annotations == nil {
+						tmpBackup.Annotations = map[string]string{}
+					}
+					tmpBackup.Annotations[constant.ClusterSnapshotAnnotationKey] = string(clusterJSON)
+				})).Should(Succeed())
+
+				err = doRestore()
+				Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRestoreFailed)).Should(BeTrue())
+				Expect(err.Error()).Should(ContainSubstring("different major version"))
+			})
+		})
 	})
 })
 
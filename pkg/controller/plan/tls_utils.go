@@ -22,12 +22,19 @@ package plan
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"hash/fnv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -69,10 +76,104 @@ func ComposeTLSSecret(namespace, clusterName, componentName string) (*v1.Secret,
 	return secret, nil
 }
 
+// TLSCertRotationWindow is the period before the expiry that the certificates are rotated in.
+const TLSCertRotationWindow = 30 * 24 * time.Hour
+
+// GetTLSCertNotAfter returns the expiry time of the first certificate in PEM format.
+func GetTLSCertNotAfter(certPEM []byte) (time.Time, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return time.Time{}, errors.New("failed to decode the tls cert in PEM format")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
+
+// NeedRotateTLSCert checks whether the certificate expires in the rotation window.
+func NeedRotateTLSCert(notAfter time.Time) bool {
+	return time.Until(notAfter) < TLSCertRotationWindow
+}
+
+// ComputeTLSCertHash computes the hash of the certificate, it changes once the certificate is rotated.
+func ComputeTLSCertHash(certPEM []byte) string {
+	h := fnv.New64a()
+	_, _ = h.Write(certPEM)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func GenerateTLSSecretName(clusterName, componentName string) string {
 	return clusterName + "-" + componentName + "-tls-certs"
 }
 
+func GenerateTLSClientSecretName(clusterName, componentName string) string {
+	return clusterName + "-" + componentName + "-tls-client-certs"
+}
+
+// CertManagerCertificateGVK is the GroupVersionKind of the cert-manager Certificate.
+var CertManagerCertificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// ComposeCertManagerCertificate composes a cert-manager Certificate object, the issued certificates
+// are stored in the secret @secretName with the keys ca.crt, tls.crt and tls.key.
+func ComposeCertManagerCertificate(namespace, clusterName, name, secretName string,
+	issuerRef *dbaasv1alpha1.CertManagerIssuerRef, dnsNames []string, usages []string) *unstructured.Unstructured {
+	kind := issuerRef.Kind
+	if len(kind) == 0 {
+		kind = "Issuer"
+	}
+	group := issuerRef.Group
+	if len(group) == 0 {
+		group = CertManagerCertificateGVK.Group
+	}
+	toSlice := func(items []string) []interface{} {
+		s := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			s = append(s, item)
+		}
+		return s
+	}
+	spec := map[string]interface{}{
+		"secretName": secretName,
+		"issuerRef": map[string]interface{}{
+			"name":  issuerRef.Name,
+			"kind":  kind,
+			"group": group,
+		},
+		"usages": toSlice(usages),
+	}
+	if len(dnsNames) > 0 {
+		spec["commonName"] = dnsNames[0]
+		spec["dnsNames"] = toSlice(dnsNames)
+	}
+
+	labels := map[string]string{
+		constant.AppInstanceLabelKey: clusterName,
+		constant.KBManagedByKey:      constant.AppName,
+	}
+	// label the issued secret as the ones generated by KubeBlocks, so that it is found and cleaned up with the cluster.
+	secretLabels := make(map[string]interface{}, len(labels))
+	for k, v := range labels {
+		secretLabels[k] = v
+	}
+	spec["secretTemplate"] = map[string]interface{}{
+		"labels": secretLabels,
+	}
+
+	cert := &unstructured.Unstructured{}
+	cert.SetGroupVersionKind(CertManagerCertificateGVK)
+	cert.SetNamespace(namespace)
+	cert.SetName(name)
+	cert.SetLabels(labels)
+	cert.Object["spec"] = spec
+	return cert
+}
+
 func buildFromTemplate(tpl string, vars interface{}) (string, error) {
 	fmap := sprig.TxtFuncMap()
 	t := template.Must(template.New("tls").Funcs(fmap).Parse(tpl))
@@ -22,6 +22,7 @@ package plan
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -58,6 +59,56 @@ var _ = Describe("TLSUtilsTest", func() {
 		})
 	})
 
+	Context("TLS cert expiry functions", func() {
+		It("should work well", func() {
+			secret, err := ComposeTLSSecret(namespace, "bar", "test")
+			Expect(err).Should(BeNil())
+			cert := []byte(secret.StringData[constant.CertName])
+
+			notAfter, err := GetTLSCertNotAfter(cert)
+			Expect(err).Should(BeNil())
+			Expect(notAfter.After(time.Now().Add(TLSCertRotationWindow))).Should(BeTrue())
+			Expect(NeedRotateTLSCert(notAfter)).Should(BeFalse())
+			Expect(NeedRotateTLSCert(time.Now().Add(time.Hour))).Should(BeTrue())
+
+			_, err = GetTLSCertNotAfter([]byte("invalid"))
+			Expect(err).ShouldNot(BeNil())
+
+			Expect(ComputeTLSCertHash(cert)).Should(Equal(ComputeTLSCertHash(cert)))
+			Expect(ComputeTLSCertHash(cert)).ShouldNot(Equal(ComputeTLSCertHash([]byte("another"))))
+		})
+	})
+
+	Context("ComposeCertManagerCertificate function", func() {
+		It("should work well", func() {
+			clusterName := "bar"
+			secretName := GenerateTLSSecretName(clusterName, "test")
+			issuerRef := &appsv1alpha1.CertManagerIssuerRef{Name: "ca-issuer"}
+			dnsNames := []string{"bar-test", "bar-test.foo"}
+			cert := ComposeCertManagerCertificate(namespace, clusterName, "bar-test", secretName, issuerRef, dnsNames, []string{"server auth"})
+			Expect(cert.GroupVersionKind()).Should(Equal(CertManagerCertificateGVK))
+			Expect(cert.GetNamespace()).Should(Equal(namespace))
+			Expect(cert.GetName()).Should(Equal("bar-test"))
+			Expect(cert.GetLabels()[constant.AppInstanceLabelKey]).Should(Equal(clusterName))
+
+			spec := cert.Object["spec"].(map[string]interface{})
+			Expect(spec["secretName"]).Should(Equal(secretName))
+			Expect(spec["commonName"]).Should(Equal("bar-test"))
+			Expect(spec["dnsNames"]).Should(Equal([]interface{}{"bar-test", "bar-test.foo"}))
+			Expect(spec["issuerRef"]).Should(Equal(map[string]interface{}{
+				"name":  "ca-issuer",
+				"kind":  "Issuer",
+				"group": "cert-manager.io",
+			}))
+			Expect(spec["secretTemplate"]).Should(Equal(map[string]interface{}{
+				"labels": map[string]interface{}{
+					constant.AppInstanceLabelKey: clusterName,
+					constant.KBManagedByKey:      constant.AppName,
+				},
+			}))
+		})
+	})
+
 	Context("CheckTLSSecretRef function", func() {
 		It("should work well", func() {
 			ctx := context.Background()
@@ -0,0 +1,202 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rsm
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// the minimum Kubernetes version which supports resizing the resources of pods in place.
+var minInPlacePodResizeVersion = utilversion.MajorMinor(1, 27)
+
+// supportsInPlacePodResize checks whether the resources of pods can be resized in place,
+// that is the feature is enabled and the Kubernetes cluster is new enough.
+func supportsInPlacePodResize() bool {
+	if !viper.GetBool(FeatureGateRSMInPlacePodVerticalScaling) {
+		return false
+	}
+	ver, ok := viper.Get(constant.CfgKeyServerInfo).(version.Info)
+	if !ok {
+		return false
+	}
+	serverVersion, err := utilversion.ParseGeneric(ver.GitVersion)
+	if err != nil {
+		return false
+	}
+	return serverVersion.AtLeast(minInPlacePodResizeVersion)
+}
+
+// getRevisionTemplate gets the pod template recorded in the ControllerRevision of the StatefulSet.
+func getRevisionTemplate(ctx context.Context, cli client.Reader, namespace, revision string) (*corev1.PodTemplateSpec, error) {
+	cr := &appsv1.ControllerRevision{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: revision}, cr); err != nil {
+		return nil, err
+	}
+	// the revision data of StatefulSet is a patch of the spec.template
+	patch := struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(cr.Data.Raw, &patch); err != nil {
+		return nil, err
+	}
+	return &patch.Spec.Template, nil
+}
+
+// isResourcesOnlyChanged checks whether the two pod templates differ only in the resources of the containers,
+// and the QoS class of the pods is kept, since it can't be changed by resizing in place.
+func isResourcesOnlyChanged(oldTemplate, newTemplate *corev1.PodTemplateSpec) bool {
+	if len(oldTemplate.Spec.Containers) != len(newTemplate.Spec.Containers) {
+		return false
+	}
+	if getPodQOS(&oldTemplate.Spec) != getPodQOS(&newTemplate.Spec) {
+		return false
+	}
+	oldCopy, newCopy := oldTemplate.DeepCopy(), newTemplate.DeepCopy()
+	resourcesChanged := false
+	for i := range oldCopy.Spec.Containers {
+		if !equality.Semantic.DeepEqual(oldCopy.Spec.Containers[i].Resources, newCopy.Spec.Containers[i].Resources) {
+			resourcesChanged = true
+		}
+		oldCopy.Spec.Containers[i].Resources = corev1.ResourceRequirements{}
+		newCopy.Spec.Containers[i].Resources = corev1.ResourceRequirements{}
+	}
+	return resourcesChanged && equality.Semantic.DeepEqual(oldCopy, newCopy)
+}
+
+// getPodQOS gets the QoS class of the pods created by the pod spec, it follows the qos.GetPodQOS of Kubernetes,
+// and the requests are defaulted to the limits as the pods are.
+func getPodQOS(podSpec *corev1.PodSpec) corev1.PodQOSClass {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	isGuaranteed := true
+	isQOSComputeResource := func(name corev1.ResourceName) bool {
+		return name == corev1.ResourceCPU || name == corev1.ResourceMemory
+	}
+	addQuantity := func(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+		sum := quantity.DeepCopy()
+		if q, ok := list[name]; ok {
+			sum.Add(q)
+		}
+		list[name] = sum
+	}
+	containers := make([]corev1.Container, 0, len(podSpec.Containers)+len(podSpec.InitContainers))
+	containers = append(containers, podSpec.Containers...)
+	containers = append(containers, podSpec.InitContainers...)
+	for _, c := range containers {
+		containerRequests := c.Resources.Requests.DeepCopy()
+		if containerRequests == nil {
+			containerRequests = corev1.ResourceList{}
+		}
+		for name, quantity := range c.Resources.Limits {
+			if _, ok := containerRequests[name]; !ok {
+				containerRequests[name] = quantity
+			}
+		}
+		for name, quantity := range containerRequests {
+			if isQOSComputeResource(name) && quantity.Sign() > 0 {
+				addQuantity(requests, name, quantity)
+			}
+		}
+		limitsFound := map[corev1.ResourceName]bool{}
+		for name, quantity := range c.Resources.Limits {
+			if isQOSComputeResource(name) && quantity.Sign() > 0 {
+				limitsFound[name] = true
+				addQuantity(limits, name, quantity)
+			}
+		}
+		if !limitsFound[corev1.ResourceCPU] || !limitsFound[corev1.ResourceMemory] {
+			isGuaranteed = false
+		}
+	}
+	if len(requests) == 0 && len(limits) == 0 {
+		return corev1.PodQOSBestEffort
+	}
+	if isGuaranteed {
+		for name, req := range requests {
+			if lim, ok := limits[name]; !ok || lim.Cmp(req) != 0 {
+				isGuaranteed = false
+				break
+			}
+		}
+	}
+	if isGuaranteed && len(requests) == len(limits) {
+		return corev1.PodQOSGuaranteed
+	}
+	return corev1.PodQOSBurstable
+}
+
+// canResizePodInPlace checks whether the pod can be updated to the update revision by resizing its resources in place.
+func canResizePodInPlace(ctx context.Context, cli client.Reader, pod *corev1.Pod, updateRevision string) (*corev1.PodTemplateSpec, bool, error) {
+	podRevision := intctrlutil.GetPodRevision(pod)
+	if len(podRevision) == 0 || len(updateRevision) == 0 {
+		return nil, false, nil
+	}
+	oldTemplate, err := getRevisionTemplate(ctx, cli, pod.Namespace, podRevision)
+	if err != nil {
+		return nil, false, client.IgnoreNotFound(err)
+	}
+	newTemplate, err := getRevisionTemplate(ctx, cli, pod.Namespace, updateRevision)
+	if err != nil {
+		return nil, false, client.IgnoreNotFound(err)
+	}
+	if !isResourcesOnlyChanged(oldTemplate, newTemplate) {
+		return nil, false, nil
+	}
+	return newTemplate, true, nil
+}
+
+// buildResizedPod builds the pod with the resources of the new template and marks it as the update revision.
+func buildResizedPod(pod *corev1.Pod, template *corev1.PodTemplateSpec, updateRevision string) *corev1.Pod {
+	podCopy := pod.DeepCopy()
+	resources := make(map[string]corev1.ResourceRequirements, len(template.Spec.Containers))
+	for _, c := range template.Spec.Containers {
+		resources[c.Name] = c.Resources
+	}
+	for i, c := range podCopy.Spec.Containers {
+		if r, ok := resources[c.Name]; ok {
+			podCopy.Spec.Containers[i].Resources = r
+		}
+	}
+	if podCopy.Labels == nil {
+		podCopy.Labels = map[string]string{}
+	}
+	podCopy.Labels[appsv1.StatefulSetRevisionLabel] = updateRevision
+	if podCopy.Annotations == nil {
+		podCopy.Annotations = map[string]string{}
+	}
+	podCopy.Annotations[constant.PodResizedInPlaceAnnotationKey] = time.Now().UTC().Format(time.RFC3339)
+	return podCopy
+}
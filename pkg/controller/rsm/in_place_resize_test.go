@@ -0,0 +1,140 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rsm
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+var _ = Describe("in-place resize test", func() {
+	buildTemplate := func(cpu string) *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"foo": "bar"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  "foo",
+						Image: "foo:latest",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	Context("isResourcesOnlyChanged function", func() {
+		It("should work well", func() {
+			By("only the resources are changed")
+			Expect(isResourcesOnlyChanged(buildTemplate("1"), buildTemplate("2"))).Should(BeTrue())
+
+			By("nothing is changed")
+			Expect(isResourcesOnlyChanged(buildTemplate("1"), buildTemplate("1000m"))).Should(BeFalse())
+
+			By("the image is changed as well")
+			newTemplate := buildTemplate("2")
+			newTemplate.Spec.Containers[0].Image = "foo:v2"
+			Expect(isResourcesOnlyChanged(buildTemplate("1"), newTemplate)).Should(BeFalse())
+
+			By("a container is added")
+			newTemplate = buildTemplate("2")
+			newTemplate.Spec.Containers = append(newTemplate.Spec.Containers, corev1.Container{Name: "bar"})
+			Expect(isResourcesOnlyChanged(buildTemplate("1"), newTemplate)).Should(BeFalse())
+
+			By("the QoS class is changed from Burstable to Guaranteed")
+			oldTemplate := buildTemplate("1")
+			oldTemplate.Spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+			oldTemplate.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory] = resource.MustParse("2Gi")
+			Expect(getPodQOS(&oldTemplate.Spec)).Should(Equal(corev1.PodQOSBurstable))
+			newTemplate = buildTemplate("2")
+			newTemplate.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory] = resource.MustParse("2Gi")
+			Expect(getPodQOS(&newTemplate.Spec)).Should(Equal(corev1.PodQOSGuaranteed))
+			Expect(isResourcesOnlyChanged(oldTemplate, newTemplate)).Should(BeFalse())
+
+			By("the QoS class is changed from BestEffort to Burstable")
+			oldTemplate = buildTemplate("1")
+			oldTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+			Expect(getPodQOS(&oldTemplate.Spec)).Should(Equal(corev1.PodQOSBestEffort))
+			Expect(isResourcesOnlyChanged(oldTemplate, buildTemplate("2"))).Should(BeFalse())
+		})
+	})
+
+	Context("canResizePodInPlace function", func() {
+		It("should work well", func() {
+			buildRevision := func(name string, template *corev1.PodTemplateSpec) *appsv1.ControllerRevision {
+				patch := map[string]any{
+					"spec": map[string]any{
+						"template": template,
+					},
+				}
+				data, err := json.Marshal(patch)
+				Expect(err).Should(BeNil())
+				return &appsv1.ControllerRevision{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+					Data:       runtime.RawExtension{Raw: data},
+				}
+			}
+			revisions := map[string]*appsv1.ControllerRevision{
+				"rev-1": buildRevision("rev-1", buildTemplate("1")),
+				"rev-2": buildRevision("rev-2", buildTemplate("2")),
+			}
+			k8sMock.EXPECT().
+				Get(gomock.Any(), gomock.Any(), &appsv1.ControllerRevision{}, gomock.Any()).
+				DoAndReturn(func(_ context.Context, key client.ObjectKey, obj *appsv1.ControllerRevision, _ ...client.GetOption) error {
+					*obj = *revisions[key.Name]
+					return nil
+				}).Times(2)
+
+			pod := builder.NewPodBuilder(namespace, GetPodName(name, 0)).
+				AddLabels(appsv1.StatefulSetRevisionLabel, "rev-1").
+				AddContainer(buildTemplate("1").Spec.Containers[0]).
+				GetObject()
+			template, ok, err := canResizePodInPlace(ctx, k8sMock, pod, "rev-2")
+			Expect(err).Should(BeNil())
+			Expect(ok).Should(BeTrue())
+			Expect(template.Spec.Containers[0].Resources.Limits.Cpu().String()).Should(Equal("2"))
+
+			By("build the resized pod")
+			resized := buildResizedPod(pod, template, "rev-2")
+			Expect(resized.Labels[appsv1.StatefulSetRevisionLabel]).Should(Equal("rev-2"))
+			Expect(resized.Annotations).Should(HaveKey(constant.PodResizedInPlaceAnnotationKey))
+			Expect(resized.Spec.Containers[0].Resources.Limits.Cpu().String()).Should(Equal("2"))
+			Expect(pod.Spec.Containers[0].Resources.Limits.Cpu().String()).Should(Equal("1"))
+		})
+	})
+})
@@ -24,6 +24,8 @@ import (
 	"errors"
 	"fmt"
 
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,7 +34,9 @@ import (
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 )
 
 type PlanBuilder struct {
@@ -81,7 +85,9 @@ func (b *PlanBuilder) Build() (graph.Plan, error) {
 	var err error
 	// new a DAG and apply chain on it, after that we should get the final Plan
 	dag := graph.NewDAG()
+	_, span := tracing.StartSpan(b.transCtx.Context, "rsm.Build")
 	err = b.transformers.ApplyTo(b.transCtx, dag)
+	tracing.EndSpan(span, err)
 	// log for debug
 	b.transCtx.Logger.Info(fmt.Sprintf("DAG: %s", dag))
 
@@ -97,7 +103,10 @@ func (b *PlanBuilder) Build() (graph.Plan, error) {
 // Plan implementation
 
 func (p *Plan) Execute() error {
-	return p.dag.WalkReverseTopoOrder(p.walkFunc, nil)
+	_, span := tracing.StartSpan(p.transCtx.Context, "rsm.Execute")
+	err := p.dag.WalkReverseTopoOrder(p.walkFunc, nil)
+	tracing.EndSpan(span, err)
+	return err
 }
 
 // Do the real works
@@ -125,8 +134,18 @@ func (b *PlanBuilder) rsmWalkFunc(v graph.Vertex) error {
 }
 
 func (b *PlanBuilder) createObject(ctx context.Context, vertex *model.ObjectVertex) error {
+	if job, ok := vertex.Obj.(*batchv1.Job); ok {
+		tracing.InjectJob(ctx, job)
+	}
+	pod, isPod := vertex.Obj.(*corev1.Pod)
+	if isPod && b.trackPods() {
+		intctrlutil.PodExpectations.ExpectCreations(podExpectationKey(b.transCtx.rsm), pod.Name)
+	}
 	err := b.cli.Create(ctx, vertex.Obj, clientOption(vertex))
 	if err != nil && !apierrors.IsAlreadyExists(err) {
+		if isPod {
+			intctrlutil.PodExpectations.CreationObserved(podExpectationKey(b.transCtx.rsm), pod.Name)
+		}
 		return err
 	}
 	return nil
@@ -137,6 +156,9 @@ func (b *PlanBuilder) updateObject(ctx context.Context, vertex *model.ObjectVert
 	if err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
+	if err == nil {
+		b.recordPodUpdated(vertex.Obj, false)
+	}
 	return nil
 }
 
@@ -150,16 +172,57 @@ func (b *PlanBuilder) deleteObject(ctx context.Context, vertex *model.ObjectVert
 		}
 	}
 	if !model.IsObjectDeleting(vertex.Obj) {
+		pod, isPod := vertex.Obj.(*corev1.Pod)
+		if isPod && b.trackPods() {
+			intctrlutil.PodExpectations.ExpectDeletions(podExpectationKey(b.transCtx.rsm), pod.UID)
+		}
 		err := b.cli.Delete(ctx, vertex.Obj, clientOption(vertex))
 		if err != nil && !apierrors.IsNotFound(err) {
+			if isPod {
+				intctrlutil.PodExpectations.DeletionObserved(podExpectationKey(b.transCtx.rsm), pod.UID)
+			}
 			return err
 		}
+		if err == nil {
+			b.recordPodUpdated(vertex.Obj, true)
+		}
 	}
 	return nil
 }
 
+// recordPodUpdated records the update metrics if the object is a pod updated by the update strategy,
+// the step of the update plan completes when the pod is resized in place or deleted to re-create.
+func (b *PlanBuilder) recordPodUpdated(obj client.Object, deleted bool) {
+	if _, ok := obj.(*corev1.Pod); !ok {
+		return
+	}
+	recreate, ok := b.transCtx.podsToBeUpdated[obj.GetName()]
+	if !ok || recreate != deleted {
+		return
+	}
+	rsm := b.transCtx.rsm
+	metrics.UpdatePlanStepsTotal.WithLabelValues(metrics.ComponentLabelValues(rsm)...).Inc()
+	if deleted {
+		strategy := "None"
+		if rsm.Spec.MemberUpdateStrategy != nil {
+			strategy = string(*rsm.Spec.MemberUpdateStrategy)
+		}
+		metrics.PodsDeletedTotal.WithLabelValues(append(metrics.ComponentLabelValues(rsm), strategy)...).Inc()
+	}
+}
+
+// trackPods returns true if the pods created and deleted should be recorded in intctrlutil.PodExpectations,
+// there is no need to when the rsm is being deleted.
+func (b *PlanBuilder) trackPods() bool {
+	return b.transCtx.rsm != nil && !model.IsObjectDeleting(b.transCtx.rsm)
+}
+
 func (b *PlanBuilder) statusObject(ctx context.Context, vertex *model.ObjectVertex) error {
-	if err := b.cli.Status().Update(ctx, vertex.Obj, clientOption(vertex)); err != nil {
+	ctx, span := tracing.StartSpan(ctx, "rsm.WriteStatus")
+	err := b.cli.Status().Update(ctx, vertex.Obj, clientOption(vertex))
+	tracing.EndSpan(span, err)
+	if err != nil {
+		metrics.RecordStatusPatchFailure("rsm", vertex.Obj)
 		return err
 	}
 	return nil
@@ -21,11 +21,13 @@ package rsm
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -33,9 +35,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	mockclient "github.com/apecloud/kubeblocks/pkg/testutil/k8s/mocks"
 )
 
@@ -169,6 +173,52 @@ var _ = Describe("plan builder test", func() {
 			Expect(rsmBuilder.rsmWalkFunc(v)).Should(Succeed())
 		})
 
+		It("should record the update metrics when the pods are actually updated", func() {
+			strategy := workloads.SerialUpdateStrategy
+			rsm = builder.NewReplicatedStateMachineBuilder(namespace, name).
+				AddLabels(constant.AppInstanceLabelKey, "metrics-cluster").
+				AddLabels(constant.KBAppComponentLabelKey, "metrics-comp").
+				SetMemberUpdateStrategy(&strategy).
+				GetObject()
+			rsmBuilder.transCtx.rsm = rsm
+			defer intctrlutil.PodExpectations.Delete(podExpectationKey(rsm))
+			rsmBuilder.transCtx.podsToBeUpdated = map[string]bool{
+				GetPodName(name, 0): true,
+				GetPodName(name, 1): false,
+			}
+			stepLabels := metrics.ComponentLabelValues(rsm)
+			deletedLabels := append(metrics.ComponentLabelValues(rsm), string(strategy))
+			steps := testutil.ToFloat64(metrics.UpdatePlanStepsTotal.WithLabelValues(stepLabels...))
+			deleted := testutil.ToFloat64(metrics.PodsDeletedTotal.WithLabelValues(deletedLabels...))
+
+			By("fail to delete the pod")
+			pod0 := builder.NewPodBuilder(namespace, GetPodName(name, 0)).GetObject()
+			k8sMock.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("mock error")).Times(1)
+			Expect(rsmBuilder.rsmWalkFunc(&model.ObjectVertex{Obj: pod0, Action: model.ActionDeletePtr()})).ShouldNot(Succeed())
+			Expect(testutil.ToFloat64(metrics.UpdatePlanStepsTotal.WithLabelValues(stepLabels...))).Should(Equal(steps))
+			Expect(testutil.ToFloat64(metrics.PodsDeletedTotal.WithLabelValues(deletedLabels...))).Should(Equal(deleted))
+
+			By("delete the pod")
+			k8sMock.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			Expect(rsmBuilder.rsmWalkFunc(&model.ObjectVertex{Obj: pod0, Action: model.ActionDeletePtr()})).Should(Succeed())
+			Expect(testutil.ToFloat64(metrics.UpdatePlanStepsTotal.WithLabelValues(stepLabels...))).Should(Equal(steps + 1))
+			Expect(testutil.ToFloat64(metrics.PodsDeletedTotal.WithLabelValues(deletedLabels...))).Should(Equal(deleted + 1))
+
+			By("resize the pod in place")
+			pod1 := builder.NewPodBuilder(namespace, GetPodName(name, 1)).GetObject()
+			k8sMock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			Expect(rsmBuilder.rsmWalkFunc(&model.ObjectVertex{Obj: pod1, Action: model.ActionUpdatePtr()})).Should(Succeed())
+			Expect(testutil.ToFloat64(metrics.UpdatePlanStepsTotal.WithLabelValues(stepLabels...))).Should(Equal(steps + 2))
+			Expect(testutil.ToFloat64(metrics.PodsDeletedTotal.WithLabelValues(deletedLabels...))).Should(Equal(deleted + 1))
+
+			By("delete the pod not updated by the update strategy")
+			pod2 := builder.NewPodBuilder(namespace, GetPodName(name, 2)).GetObject()
+			k8sMock.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			Expect(rsmBuilder.rsmWalkFunc(&model.ObjectVertex{Obj: pod2, Action: model.ActionDeletePtr()})).Should(Succeed())
+			Expect(testutil.ToFloat64(metrics.UpdatePlanStepsTotal.WithLabelValues(stepLabels...))).Should(Equal(steps + 2))
+			Expect(testutil.ToFloat64(metrics.PodsDeletedTotal.WithLabelValues(deletedLabels...))).Should(Equal(deleted + 1))
+		})
+
 		It("should update object status", func() {
 			rsm.Generation = 2
 			rsm.Status.ObservedGeneration = 2
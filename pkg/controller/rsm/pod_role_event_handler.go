@@ -141,7 +141,7 @@ func handleRoleChangedEvent(cli client.Client, reqCtx intctrlutil.RequestCtx, re
 		}
 		reqCtx.Log.V(1).Info("handle role change event", "pod", pod.Name, "role", role, "originalRole", message.OriginalRole)
 
-		if err := updatePodRoleLabel(cli, reqCtx, *rsm, pod, pair.RoleName, snapshot.Version); err != nil {
+		if err := updatePodRoleLabel(cli, reqCtx, recorder, *rsm, pod, pair.RoleName, snapshot.Version); err != nil {
 			return "", err
 		}
 	}
@@ -45,7 +45,7 @@ var _ = Describe("pod role label event handler test", func() {
 				Ctx: ctx,
 				Log: logger,
 			}
-			pod := builder.NewPodBuilder(namespace, getPodName(name, 0)).SetUID(uid).GetObject()
+			pod := builder.NewPodBuilder(namespace, GetPodName(name, 0)).SetUID(uid).GetObject()
 			objectRef := corev1.ObjectReference{
 				APIVersion: "v1",
 				Kind:       "Pod",
@@ -92,7 +92,7 @@ var _ = Describe("pod role label event handler test", func() {
 					Expect(pd).ShouldNot(BeNil())
 					Expect(pd.Labels).ShouldNot(BeNil())
 					Expect(pd.Labels[roleLabelKey]).Should(Equal(role.Name))
-					Expect(pd.Labels[rsmAccessModeLabelKey]).Should(BeEquivalentTo(role.AccessMode))
+					Expect(pd.Labels[RSMAccessModeLabelKey]).Should(BeEquivalentTo(role.AccessMode))
 					return nil
 				}).Times(1)
 			k8sMock.EXPECT().
@@ -138,7 +138,7 @@ var (
 		Password: workloads.CredentialVar{Value: "bar"},
 	}
 
-	pod = builder.NewPodBuilder(namespace, getPodName(name, 0)).
+	pod = builder.NewPodBuilder(namespace, GetPodName(name, 0)).
 		AddContainer(corev1.Container{
 			Name:  "foo",
 			Image: "bar",
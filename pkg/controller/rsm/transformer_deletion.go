@@ -22,6 +22,7 @@ package rsm
 import (
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 // ObjectDeletionTransformer handles object and its secondary resources' deletion
@@ -53,6 +54,7 @@ func (t *ObjectDeletionTransformer) Transform(ctx graph.TransformContext, dag *g
 		}
 	}
 	graphCli.Delete(dag, obj)
+	intctrlutil.PodExpectations.Delete(podExpectationKey(obj))
 
 	// fast return, that is stopping the plan.Build() stage and jump to plan.Execute() directly
 	return graph.ErrPrematureStop
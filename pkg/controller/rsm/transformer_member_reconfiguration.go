@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/audit"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
@@ -126,6 +127,7 @@ func (t *MemberReconfigurationTransformer) Transform(ctx graph.TransformContext,
 		if !isActionDone(rsm, action) {
 			return nil
 		}
+		recordSwitchoverResult(transCtx, dag, action)
 		// mark it as 'handled'
 		deleteAction(transCtx, dag, action)
 		return createNextAction(transCtx, dag, rsm, action)
@@ -135,7 +137,10 @@ func (t *MemberReconfigurationTransformer) Transform(ctx graph.TransformContext,
 			// need manual handling
 			return nil
 		}
-		return createNextAction(transCtx, dag, rsm, action)
+		err := createNextAction(transCtx, dag, rsm, action)
+		// record it after the next action is created, which may be the same one.
+		recordSwitchoverResult(transCtx, dag, action)
+		return err
 	default:
 		// action in progress
 		return nil
@@ -165,16 +170,49 @@ func cleanAction(transCtx *rsmTransformContext, dag *graph.DAG) error {
 	action := actionList[0]
 	switch {
 	case action.Status.Succeeded > 0:
+		recordSwitchoverResult(transCtx, dag, action)
 		deleteAction(transCtx, dag, action)
 	case action.Status.Failed > 0:
 		emitEvent(transCtx, action)
+		recordSwitchoverResult(transCtx, dag, action)
 	}
 	return nil
 }
 
+// recordSwitchoverResult records the audit entry of the result of the switchover action once.
+// The failed actions are kept and handled again by the following reconciliations, they are marked as audited
+// in the annotations rather than in memory, so that the new leader doesn't record them again after a takeover.
+func recordSwitchoverResult(transCtx *rsmTransformContext, dag *graph.DAG, action *batchv1.Job) {
+	if !isSwitchoverAction(action) || action.Annotations[jobAuditedAnnotationKey] == "true" {
+		return
+	}
+	result := audit.ResultSucceeded
+	if action.Status.Failed > 0 {
+		result = audit.ResultFailed
+	}
+	audit.Record(transCtx.EventRecorder, transCtx.rsm, audit.Event{
+		Actor:   audit.ControllerActor(),
+		Action:  audit.ActionSwitchover,
+		Kind:    "ReplicatedStateMachine",
+		Target:  getLeaderPodName(transCtx.rsm.Status.MembersStatus),
+		Result:  result,
+		Message: fmt.Sprintf("job name: %s", action.Name),
+	})
+	// the succeeded ones are marked as handled and never listed again.
+	if action.Status.Failed > 0 {
+		graphCli, _ := transCtx.Client.(model.GraphClient)
+		actionNew := action.DeepCopy()
+		if actionNew.Annotations == nil {
+			actionNew.Annotations = map[string]string{}
+		}
+		actionNew.Annotations[jobAuditedAnnotationKey] = "true"
+		graphCli.Update(dag, action, actionNew, &model.ReplaceIfExistingOption{})
+	}
+}
+
 func isActionDone(rsm *workloads.ReplicatedStateMachine, action *batchv1.Job) bool {
 	ordinal, _ := getActionOrdinal(action.Name)
-	podName := getPodName(rsm.Name, ordinal)
+	podName := GetPodName(rsm.Name, ordinal)
 	membersStatus := rsm.Status.MembersStatus
 	switch action.Labels[jobTypeLabel] {
 	case jobTypeSwitchover:
@@ -212,7 +250,7 @@ func createNextAction(transCtx *rsmTransformContext, dag *graph.DAG, rsm *worklo
 	if nextActionInfo.actionType == jobTypeSwitchover {
 		ordinal = 0
 	}
-	target := getPodName(rsm.Name, ordinal)
+	target := GetPodName(rsm.Name, ordinal)
 	actionName := getActionName(rsm.Name, int(rsm.Generation), nextActionInfo.ordinal, nextActionInfo.actionType)
 	nextAction := buildAction(rsm, actionName, nextActionInfo.actionType, jobScenarioMembership, leader, target)
 
@@ -222,7 +260,20 @@ func createNextAction(transCtx *rsmTransformContext, dag *graph.DAG, rsm *worklo
 	}
 
 	cli, _ := transCtx.Client.(model.GraphClient)
-	return createAction(dag, cli, rsm, nextAction)
+	if err := createAction(dag, cli, rsm, nextAction); err != nil {
+		return err
+	}
+	if nextActionInfo.actionType == jobTypeSwitchover {
+		audit.Record(transCtx.EventRecorder, rsm, audit.Event{
+			Actor:   audit.ControllerActor(),
+			Action:  audit.ActionSwitchover,
+			Kind:    "ReplicatedStateMachine",
+			Target:  target,
+			Result:  audit.ResultStarted,
+			Message: fmt.Sprintf("switchover from leader %s by action %s", leader, actionName),
+		})
+	}
+	return nil
 }
 
 func generateActionInfoList(rsm *workloads.ReplicatedStateMachine) []*actionInfo {
@@ -325,7 +376,7 @@ func abnormalAnalysis(rsm *workloads.ReplicatedStateMachine, action *batchv1.Job
 	}
 	var abnormalPodList, leaderPodList []string
 	for i := 0; i < currentMembers; i++ {
-		podName := getPodName(rsm.Name, i)
+		podName := GetPodName(rsm.Name, i)
 		status, ok := statusMap[podName]
 		if !ok {
 			abnormalPodList = append(abnormalPodList, podName)
@@ -356,7 +407,7 @@ func abnormalAnalysis(rsm *workloads.ReplicatedStateMachine, action *batchv1.Job
 func generateActionInfos(rsm *workloads.ReplicatedStateMachine, ordinal int, actionTypeList []string) []*actionInfo {
 	var actionInfos []*actionInfo
 	leaderPodName := getLeaderPodName(rsm.Status.MembersStatus)
-	podName := getPodName(rsm.Name, ordinal)
+	podName := GetPodName(rsm.Name, ordinal)
 	for _, actionType := range actionTypeList {
 		checker := func() bool {
 			return podName == leaderPodName
@@ -28,6 +28,7 @@ import (
 	"github.com/golang/mock/gomock"
 	apps "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
@@ -42,7 +43,7 @@ var _ = Describe("member reconfiguration transformer test.", func() {
 		var membersStatus []workloads.MemberStatus
 		for i := 0; i < replicas; i++ {
 			status := workloads.MemberStatus{
-				PodName:     getPodName(rsm.Name, i),
+				PodName:     GetPodName(rsm.Name, i),
 				ReplicaRole: workloads.ReplicaRole{Name: "follower"},
 			}
 			membersStatus = append(membersStatus, status)
@@ -127,6 +128,34 @@ var _ = Describe("member reconfiguration transformer test.", func() {
 		transformer = &MemberReconfigurationTransformer{}
 	})
 
+	Context("switchover result audit", func() {
+		It("should record the failed switchover once even if the operator leadership moves", func() {
+			recorder := record.NewFakeRecorder(10)
+			transCtx.EventRecorder = recorder
+			action := builder.NewJobBuilder(name, getActionName(rsm.Name, int(rsm.Generation), 1, jobTypeSwitchover)).
+				AddLabels(jobTypeLabel, jobTypeSwitchover).
+				GetObject()
+			action.Status.Failed = 1
+
+			By("record the failed action and mark it as audited")
+			recordSwitchoverResult(transCtx, dag, action)
+			Expect(recorder.Events).Should(HaveLen(1))
+			jobs := graphCli.FindAll(dag, &batchv1.Job{})
+			Expect(jobs).Should(HaveLen(1))
+			Expect(jobs[0].GetAnnotations()).Should(HaveKeyWithValue(jobAuditedAnnotationKey, "true"))
+			Expect(graphCli.IsAction(dag, jobs[0], model.ActionUpdatePtr())).Should(BeTrue())
+
+			By("the new leader doesn't record the action marked as audited again")
+			recorder = record.NewFakeRecorder(10)
+			transCtx.EventRecorder = recorder
+			dag = graph.NewDAG()
+			graphCli.Root(dag, transCtx.rsmOrig, transCtx.rsm, model.ActionStatusPtr())
+			recordSwitchoverResult(transCtx, dag, jobs[0].(*batchv1.Job))
+			Expect(recorder.Events).Should(BeEmpty())
+			Expect(graphCli.FindAll(dag, &batchv1.Job{})).Should(BeEmpty())
+		})
+	})
+
 	Context("roleful cluster initialization", func() {
 		It("should initialize well", func() {
 			By("initialReplicas=0")
@@ -316,11 +345,11 @@ var _ = Describe("member reconfiguration transformer test.", func() {
 			By("make member 1 switchover successfully and prepare member 1 leaving")
 			membersStatus := []workloads.MemberStatus{
 				{
-					PodName:     getPodName(rsm.Name, 0),
+					PodName:     GetPodName(rsm.Name, 0),
 					ReplicaRole: workloads.ReplicaRole{Name: "leader", IsLeader: true},
 				},
 				{
-					PodName:     getPodName(rsm.Name, 1),
+					PodName:     GetPodName(rsm.Name, 1),
 					ReplicaRole: workloads.ReplicaRole{Name: "follower"},
 				},
 			}
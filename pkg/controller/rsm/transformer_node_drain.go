@@ -0,0 +1,161 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rsm
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// NodeDrainTransformer switches the leader over to another member proactively
+// if the node hosting the leader is cordoned, which is usually the first step of a node drain,
+// so that the write downtime is minimized during the planned node maintenance.
+type NodeDrainTransformer struct{}
+
+var _ graph.Transformer = &NodeDrainTransformer{}
+
+func (t *NodeDrainTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*rsmTransformContext)
+	rsm := transCtx.rsm
+	if !model.IsObjectStatusUpdating(transCtx.rsmOrig) {
+		return nil
+	}
+	if rsm.Spec.MembershipReconfiguration == nil || rsm.Spec.MembershipReconfiguration.SwitchoverAction == nil {
+		return nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	actionList, err := getActionList(transCtx, jobScenarioNodeDrain)
+	if err != nil {
+		return err
+	}
+	if len(actionList) > 0 {
+		action := actionList[0]
+		switch {
+		case action.Status.Succeeded == 0 && action.Status.Failed == 0:
+			// action in progress, wait
+			return nil
+		case action.Status.Failed > 0:
+			emitActionFailedEvent(transCtx, jobTypeSwitchover, action.Name)
+			fallthrough
+		case action.Status.Succeeded > 0:
+			doActionCleanup(dag, graphCli, action)
+		}
+		return nil
+	}
+
+	leader := getLeaderPodName(rsm.Status.MembersStatus)
+	if leader == "" {
+		return nil
+	}
+	podList := &corev1.PodList{}
+	if err = transCtx.Client.List(transCtx.Context, podList, client.InNamespace(rsm.Namespace), client.MatchingLabels(getLabels(rsm))); err != nil {
+		return err
+	}
+	pods := podList.Items
+	if len(pods) < 2 {
+		return nil
+	}
+	var leaderPod *corev1.Pod
+	for i := range pods {
+		if pods[i].Name == leader {
+			leaderPod = &pods[i]
+			break
+		}
+	}
+	if leaderPod == nil {
+		return nil
+	}
+	cordoned, err := isNodeCordoned(transCtx, leaderPod.Spec.NodeName)
+	if err != nil || !cordoned {
+		return err
+	}
+
+	target, err := selectNodeDrainSwitchoverTarget(transCtx, pods, leader)
+	if err != nil || target == "" {
+		return err
+	}
+	ordinal, _ := getPodOrdinal(leader)
+	actionName := getActionName(rsm.Name, int(rsm.Generation), ordinal, jobScenarioNodeDrain)
+	// the handled action is kept as the terminal state of the switchover: a failed one is not retried
+	// until the leader or the generation changes, otherwise it would be re-created forever.
+	handled, err := isActionHandled(transCtx, actionName)
+	if err != nil || handled {
+		return err
+	}
+	action := buildAction(rsm, actionName, jobTypeSwitchover, jobScenarioNodeDrain, leader, target)
+	emitActionEvent(transCtx, corev1.EventTypeNormal, jobTypeSwitchover,
+		fmt.Sprintf("node %s hosting the leader %s is cordoned, switchover to %s", leaderPod.Spec.NodeName, leader, target))
+	return createAction(dag, graphCli, rsm, action)
+}
+
+// selectNodeDrainSwitchoverTarget selects a member with role label on a schedulable node as the new leader.
+func selectNodeDrainSwitchoverTarget(transCtx *rsmTransformContext, pods []corev1.Pod, leader string) (string, error) {
+	for _, pod := range pods {
+		if pod.Name == leader || !intctrlutil.IsAvailable(&pod, 0) {
+			continue
+		}
+		if _, ok := pod.Labels[roleLabelKey]; !ok {
+			continue
+		}
+		cordoned, err := isNodeCordoned(transCtx, pod.Spec.NodeName)
+		if err != nil {
+			return "", err
+		}
+		if !cordoned {
+			return pod.Name, nil
+		}
+	}
+	return "", nil
+}
+
+func isActionHandled(transCtx *rsmTransformContext, actionName string) (bool, error) {
+	action := &batchv1.Job{}
+	if err := transCtx.Client.Get(transCtx.Context, types.NamespacedName{Namespace: transCtx.rsm.Namespace, Name: actionName}, action); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func isNodeCordoned(transCtx *rsmTransformContext, nodeName string) (bool, error) {
+	if nodeName == "" {
+		return false, nil
+	}
+	node := &corev1.Node{}
+	if err := transCtx.Client.Get(transCtx.Context, types.NamespacedName{Name: nodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return node.Spec.Unschedulable, nil
+}
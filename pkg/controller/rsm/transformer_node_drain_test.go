@@ -0,0 +1,193 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package rsm
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/golang/mock/gomock"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+var _ = Describe("node drain transformer test.", func() {
+	var (
+		pod0, pod1, pod2 *corev1.Pod
+		cordonedNodes    map[string]bool
+		handledActions   map[string]bool
+	)
+
+	mockPodsAndNodes := func() {
+		k8sMock.EXPECT().
+			List(gomock.Any(), &batchv1.JobList{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, list *batchv1.JobList, _ ...client.ListOption) error {
+				return nil
+			}).Times(1)
+		k8sMock.EXPECT().
+			List(gomock.Any(), &corev1.PodList{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, list *corev1.PodList, _ ...client.ListOption) error {
+				list.Items = []corev1.Pod{*pod0, *pod1, *pod2}
+				return nil
+			}).Times(1)
+		k8sMock.EXPECT().
+			Get(gomock.Any(), gomock.Any(), &corev1.Node{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, objKey client.ObjectKey, obj *corev1.Node, _ ...client.GetOption) error {
+				obj.Name = objKey.Name
+				obj.Spec.Unschedulable = cordonedNodes[objKey.Name]
+				return nil
+			}).AnyTimes()
+		k8sMock.EXPECT().
+			Get(gomock.Any(), gomock.Any(), &batchv1.Job{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, objKey client.ObjectKey, obj *batchv1.Job, _ ...client.GetOption) error {
+				if !handledActions[objKey.Name] {
+					return apierrors.NewNotFound(batchv1.Resource("jobs"), objKey.Name)
+				}
+				obj.Name = objKey.Name
+				obj.Status.Failed = 1
+				return nil
+			}).AnyTimes()
+	}
+
+	BeforeEach(func() {
+		rsm = builder.NewReplicatedStateMachineBuilder(namespace, name).
+			SetUID(uid).
+			SetReplicas(3).
+			SetRoles(roles).
+			SetMembershipReconfiguration(&reconfiguration).
+			SetService(service).
+			GetObject()
+		rsm.Generation = 1
+		rsm.Status.ObservedGeneration = 1
+		rsm.Status.MembersStatus = []workloads.MemberStatus{
+			{
+				PodName:     GetPodName(rsm.Name, 0),
+				ReplicaRole: workloads.ReplicaRole{Name: "follower"},
+			},
+			{
+				PodName:     GetPodName(rsm.Name, 1),
+				ReplicaRole: workloads.ReplicaRole{Name: "leader", IsLeader: true},
+			},
+			{
+				PodName:     GetPodName(rsm.Name, 2),
+				ReplicaRole: workloads.ReplicaRole{Name: "follower"},
+			},
+		}
+
+		pod0 = builder.NewPodBuilder(namespace, GetPodName(rsm.Name, 0)).
+			AddLabels(roleLabelKey, "follower").
+			SetNodeName("node-0").
+			GetObject()
+		pod1 = builder.NewPodBuilder(namespace, GetPodName(rsm.Name, 1)).
+			AddLabels(roleLabelKey, "leader").
+			SetNodeName("node-1").
+			GetObject()
+		pod2 = builder.NewPodBuilder(namespace, GetPodName(rsm.Name, 2)).
+			AddLabels(roleLabelKey, "follower").
+			SetNodeName("node-2").
+			GetObject()
+		makePodUpdateReady(newRevision, pod0, pod1, pod2)
+		for _, pod := range []*corev1.Pod{pod0, pod1, pod2} {
+			pod.Status.Phase = corev1.PodRunning
+		}
+		cordonedNodes = map[string]bool{}
+		handledActions = map[string]bool{}
+
+		transCtx = &rsmTransformContext{
+			Context:       ctx,
+			Client:        graphCli,
+			EventRecorder: record.NewFakeRecorder(10),
+			Logger:        logger,
+			rsmOrig:       rsm.DeepCopy(),
+			rsm:           rsm,
+		}
+
+		dag = mockDAG()
+		transformer = &NodeDrainTransformer{}
+	})
+
+	Context("RSM is not in status updating", func() {
+		It("should return directly", func() {
+			transCtx.rsmOrig.Generation = 2
+			dagExpected := mockDAG()
+
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(dag.Equals(dagExpected, less)).Should(BeTrue())
+		})
+	})
+
+	Context("the leader's node is schedulable", func() {
+		It("should do nothing", func() {
+			mockPodsAndNodes()
+			dagExpected := mockDAG()
+
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(dag.Equals(dagExpected, less)).Should(BeTrue())
+		})
+	})
+
+	Context("the leader's node is cordoned", func() {
+		It("should switchover to a member on a schedulable node", func() {
+			cordonedNodes["node-1"] = true
+			cordonedNodes["node-0"] = true
+			mockPodsAndNodes()
+			dagExpected := mockDAG()
+			actionName := getActionName(rsm.Name, int(rsm.Generation), 1, jobScenarioNodeDrain)
+			graphCli.Create(dagExpected, builder.NewJobBuilder(name, actionName).GetObject())
+
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(dag.Equals(dagExpected, less)).Should(BeTrue())
+			var action *batchv1.Job
+			for _, v := range dag.Vertices() {
+				if job, ok := v.(*model.ObjectVertex).Obj.(*batchv1.Job); ok {
+					action = job
+				}
+			}
+			Expect(action).ShouldNot(BeNil())
+			Expect(action.Labels[jobScenarioLabel]).Should(Equal(jobScenarioNodeDrain))
+			Expect(action.Spec.Template.Spec.Containers[0].Env).Should(ContainElement(corev1.EnvVar{
+				Name:  targetHostVarName,
+				Value: fmt.Sprintf("%s.%s", pod2.Name, GetHeadlessSvcName(*rsm)),
+			}))
+		})
+	})
+
+	Context("the switchover of the cordoned node has failed", func() {
+		It("should not create the action again", func() {
+			cordonedNodes["node-1"] = true
+			handledActions[getActionName(rsm.Name, int(rsm.Generation), 1, jobScenarioNodeDrain)] = true
+			mockPodsAndNodes()
+			dagExpected := mockDAG()
+
+			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
+			Expect(dag.Equals(dagExpected, less)).Should(BeTrue())
+		})
+	})
+})
@@ -39,6 +39,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	"github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -63,6 +64,9 @@ func (t *ObjectGenerationTransformer) Transform(ctx graph.TransformContext, dag
 		pods := buildPods(*rsm)
 		for idx := range pods {
 			pod := pods[idx]
+			if err := secretstore.InjectPodDelivery(pod.Namespace, &pod.Spec); err != nil {
+				return err
+			}
 			objects = append(objects, pod)
 		}
 	} else {
@@ -71,6 +75,10 @@ func (t *ObjectGenerationTransformer) Transform(ctx graph.TransformContext, dag
 		headLessSvc := buildHeadlessSvc(*rsm)
 		envConfig := buildEnvConfigMap(*rsm)
 		sts := buildSts(*rsm, headLessSvc.Name, *envConfig)
+		// the sealed credentials are delivered from the external secret manager.
+		if err := secretstore.InjectPodDelivery(sts.Namespace, &sts.Spec.Template.Spec); err != nil {
+			return err
+		}
 		objects = append(objects, sts)
 		objects = append(objects, headLessSvc, envConfig)
 		if svc != nil {
@@ -112,6 +120,19 @@ func (t *ObjectGenerationTransformer) Transform(ctx graph.TransformContext, dag
 	updateSet := newNameSet.Intersection(oldNameSet)
 	deleteSet := oldNameSet.Difference(newNameSet)
 
+	// hold back the pods creation and deletion until the previous ones are observed by the cache
+	if rsm.Spec.RsmTransformPolicy == workloads.ToPod {
+		var pods []corev1.Pod
+		for _, object := range oldSnapshot {
+			if pod, ok := object.(*corev1.Pod); ok {
+				pods = append(pods, *pod)
+			}
+		}
+		if !podExpectationsSatisfied(rsm, pods) {
+			createSet, deleteSet = sets.New[model.GVKNObjKey](), sets.New[model.GVKNObjKey]()
+		}
+	}
+
 	createNewObjects := func() {
 		for name := range createSet {
 			cli.Create(dag, newSnapshot[name])
@@ -323,7 +344,7 @@ func buildHeadlessSvc(rsm workloads.ReplicatedStateMachine) *corev1.Service {
 	annotations := ParseAnnotationsOfScope(HeadlessServiceScope, rsm.Annotations)
 	labels := getLabels(&rsm)
 	selectors := getSvcSelector(&rsm, true)
-	hdlBuilder := builder.NewHeadlessServiceBuilder(rsm.Namespace, getHeadlessSvcName(rsm)).
+	hdlBuilder := builder.NewHeadlessServiceBuilder(rsm.Namespace, GetHeadlessSvcName(rsm)).
 		AddLabelsInMap(labels).
 		AddSelectorsInMap(selectors).
 		AddAnnotationsInMap(annotations).
@@ -346,7 +367,12 @@ func buildHeadlessSvc(rsm workloads.ReplicatedStateMachine) *corev1.Service {
 			hdlBuilder.AddPorts(servicePort)
 		}
 	}
-	return hdlBuilder.GetObject()
+	hdlSvc := hdlBuilder.GetObject()
+	// resolve the pod FQDNs to the addresses of all the IP families in the dual-stack clusters,
+	// it falls back to the single IP family of the cluster otherwise.
+	ipFamilyPolicy := corev1.IPFamilyPolicyPreferDualStack
+	hdlSvc.Spec.IPFamilyPolicy = &ipFamilyPolicy
+	return hdlSvc
 }
 
 func buildSts(rsm workloads.ReplicatedStateMachine, headlessSvcName string, envConfig corev1.ConfigMap) *apps.StatefulSet {
@@ -737,7 +763,7 @@ func injectCustomRoleProbeContainer(rsm workloads.ReplicatedStateMachine, templa
 
 func buildEnvConfigData(set workloads.ReplicatedStateMachine) map[string]string {
 	envData := map[string]string{}
-	svcName := getHeadlessSvcName(set)
+	svcName := GetHeadlessSvcName(set)
 	uid := string(set.UID)
 	strReplicas := strconv.Itoa(int(*set.Spec.Replicas))
 	generateReplicaEnv := func(prefix string) {
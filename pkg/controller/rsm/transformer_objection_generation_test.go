@@ -98,7 +98,7 @@ var _ = Describe("object generation transformer test.", func() {
 	Context("Transform function", func() {
 		It("should work well", func() {
 			sts := builder.NewStatefulSetBuilder(namespace, name).GetObject()
-			headlessSvc := builder.NewHeadlessServiceBuilder(name, getHeadlessSvcName(*rsm)).GetObject()
+			headlessSvc := builder.NewHeadlessServiceBuilder(name, GetHeadlessSvcName(*rsm)).GetObject()
 			svc := builder.NewServiceBuilder(name, name).GetObject()
 			env := builder.NewConfigMapBuilder(name, name+"-rsm-env").GetObject()
 			k8sMock.EXPECT().
@@ -192,15 +192,15 @@ var _ = Describe("object generation transformer test.", func() {
 			By("build env config data")
 			rsm.Status.MembersStatus = []workloads.MemberStatus{
 				{
-					PodName:     getPodName(rsm.Name, 1),
+					PodName:     GetPodName(rsm.Name, 1),
 					ReplicaRole: workloads.ReplicaRole{Name: "leader", IsLeader: true},
 				},
 				{
-					PodName:     getPodName(rsm.Name, 0),
+					PodName:     GetPodName(rsm.Name, 0),
 					ReplicaRole: workloads.ReplicaRole{Name: "follower", CanVote: true},
 				},
 				{
-					PodName:     getPodName(rsm.Name, 2),
+					PodName:     GetPodName(rsm.Name, 2),
 					ReplicaRole: workloads.ReplicaRole{Name: "follower", CanVote: true},
 				},
 			}
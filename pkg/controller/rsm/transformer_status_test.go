@@ -153,13 +153,13 @@ var _ = Describe("object status transformer test.", func() {
 					*obj = *sts
 					return nil
 				}).Times(1)
-			pod0 := builder.NewPodBuilder(namespace, getPodName(rsm.Name, 0)).
+			pod0 := builder.NewPodBuilder(namespace, GetPodName(rsm.Name, 0)).
 				AddLabels(roleLabelKey, "follower").
 				GetObject()
-			pod1 := builder.NewPodBuilder(namespace, getPodName(name, 1)).
+			pod1 := builder.NewPodBuilder(namespace, GetPodName(name, 1)).
 				AddLabels(roleLabelKey, "leader").
 				GetObject()
-			pod2 := builder.NewPodBuilder(namespace, getPodName(name, 2)).
+			pod2 := builder.NewPodBuilder(namespace, GetPodName(name, 2)).
 				AddLabels(roleLabelKey, "follower").
 				GetObject()
 			makePodUpdateReady(newRevision, pod0, pod1, pod2)
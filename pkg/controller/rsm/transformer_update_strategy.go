@@ -79,6 +79,11 @@ func (t *UpdateStrategyTransformer) Transform(ctx graph.TransformContext, dag *g
 		}
 	}
 
+	// the pods deleted last time may not be observed by the cache yet, wait for them rather than deleting again
+	if !podExpectationsSatisfied(rsm, pods) {
+		return nil
+	}
+
 	// we don't check whether pod role label present: prefer stateful_set's Update done than role probing ready
 	// TODO(free6om): maybe should wait rsm ready for high availability:
 	// 1. after some pods updated
@@ -92,6 +97,18 @@ func (t *UpdateStrategyTransformer) Transform(ctx graph.TransformContext, dag *g
 		return err
 	}
 
+	// resize the pods in place if only the resources are changed, and the others will be re-created
+	podsToBeResized, podsToBeUpdated, err := splitPodsToBeResized(transCtx, podsToBeUpdated)
+	if err != nil {
+		return err
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	transCtx.podsToBeUpdated = make(map[string]bool, len(podsToBeResized)+len(podsToBeUpdated))
+	for _, resize := range podsToBeResized {
+		graphCli.Update(dag, resize.pod, buildResizedPod(resize.pod, resize.template, rsm.Status.UpdateRevision))
+		transCtx.podsToBeUpdated[resize.pod.Name] = false
+	}
+
 	// do switchover if leader in pods to be updated
 	switch shouldWaitNextLoop, err := doSwitchoverIfNeeded(transCtx, dag, pods, podsToBeUpdated); {
 	case err != nil:
@@ -100,14 +117,41 @@ func (t *UpdateStrategyTransformer) Transform(ctx graph.TransformContext, dag *g
 		return nil
 	}
 
-	graphCli, _ := transCtx.Client.(model.GraphClient)
 	for _, pod := range podsToBeUpdated {
 		graphCli.Delete(dag, pod)
+		transCtx.podsToBeUpdated[pod.Name] = true
 	}
 
 	return nil
 }
 
+type podToBeResized struct {
+	pod      *corev1.Pod
+	template *corev1.PodTemplateSpec
+}
+
+// splitPodsToBeResized picks out the pods which can be updated by resizing the resources in place.
+func splitPodsToBeResized(transCtx *rsmTransformContext, pods []*corev1.Pod) ([]podToBeResized, []*corev1.Pod, error) {
+	rsm := transCtx.rsm
+	if rsm.Spec.RsmTransformPolicy == workloads.ToPod || !supportsInPlacePodResize() {
+		return nil, pods, nil
+	}
+	var podsToBeResized []podToBeResized
+	var podsToBeRecreated []*corev1.Pod
+	for _, pod := range pods {
+		template, ok, err := canResizePodInPlace(transCtx.Context, transCtx.Client, pod, rsm.Status.UpdateRevision)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			podsToBeResized = append(podsToBeResized, podToBeResized{pod: pod, template: template})
+		} else {
+			podsToBeRecreated = append(podsToBeRecreated, pod)
+		}
+	}
+	return podsToBeResized, podsToBeRecreated, nil
+}
+
 // return true means action created or in progress, should wait it to the termination state
 func doSwitchoverIfNeeded(transCtx *rsmTransformContext, dag *graph.DAG, pods []corev1.Pod, podsToBeUpdated []*corev1.Pod) (bool, error) {
 	if len(podsToBeUpdated) == 0 {
@@ -157,7 +201,7 @@ func doSwitchoverIfNeeded(transCtx *rsmTransformContext, dag *graph.DAG, pods []
 func createSwitchoverAction(dag *graph.DAG, cli model.GraphClient, rsm *workloads.ReplicatedStateMachine, pods []corev1.Pod) error {
 	leader := getLeaderPodName(rsm.Status.MembersStatus)
 	targetOrdinal := selectSwitchoverTarget(rsm, pods)
-	target := getPodName(rsm.Name, targetOrdinal)
+	target := GetPodName(rsm.Name, targetOrdinal)
 	actionType := jobTypeSwitchover
 	ordinal, _ := getPodOrdinal(leader)
 	actionName := getActionName(rsm.Name, int(rsm.Generation), ordinal, actionType)
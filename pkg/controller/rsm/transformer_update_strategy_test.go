@@ -48,15 +48,15 @@ var _ = Describe("update strategy transformer test.", func() {
 		rsm.Status.UpdateRevision = newRevision
 		membersStatus := []workloads.MemberStatus{
 			{
-				PodName:     getPodName(rsm.Name, 1),
+				PodName:     GetPodName(rsm.Name, 1),
 				ReplicaRole: workloads.ReplicaRole{Name: "leader", IsLeader: true},
 			},
 			{
-				PodName:     getPodName(rsm.Name, 0),
+				PodName:     GetPodName(rsm.Name, 0),
 				ReplicaRole: workloads.ReplicaRole{Name: "follower"},
 			},
 			{
-				PodName:     getPodName(rsm.Name, 2),
+				PodName:     GetPodName(rsm.Name, 2),
 				ReplicaRole: workloads.ReplicaRole{Name: "follower"},
 			},
 		}
@@ -156,15 +156,15 @@ var _ = Describe("update strategy transformer test.", func() {
 					obj.Spec.Replicas = rsm.Spec.Replicas
 					return nil
 				}).Times(4)
-			pod0 := builder.NewPodBuilder(namespace, getPodName(rsm.Name, 0)).
+			pod0 := builder.NewPodBuilder(namespace, GetPodName(rsm.Name, 0)).
 				AddLabels(roleLabelKey, "follower").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
-			pod1 := builder.NewPodBuilder(namespace, getPodName(name, 1)).
+			pod1 := builder.NewPodBuilder(namespace, GetPodName(name, 1)).
 				AddLabels(roleLabelKey, "leader").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
-			pod2 := builder.NewPodBuilder(namespace, getPodName(name, 2)).
+			pod2 := builder.NewPodBuilder(namespace, GetPodName(name, 2)).
 				AddLabels(roleLabelKey, "follower").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
@@ -181,6 +181,7 @@ var _ = Describe("update strategy transformer test.", func() {
 			graphCli.Delete(dagExpected, pod0)
 			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
 			Expect(dag.Equals(dagExpected, less)).Should(BeTrue())
+			Expect(transCtx.podsToBeUpdated).Should(Equal(map[string]bool{pod0.Name: true}))
 
 			By("update the second pod")
 			makePodUpdateReady(newRevision, pod0)
@@ -218,6 +219,8 @@ var _ = Describe("update strategy transformer test.", func() {
 			dag = mockDAG()
 			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
 			Expect(dag.Equals(dagExpected, less)).Should(BeTrue())
+			// the leader is not counted as updated until the switchover is done
+			Expect(transCtx.podsToBeUpdated).Should(BeEmpty())
 
 			By("update the last(leader) pod")
 			dagExpected = mockDAG()
@@ -251,6 +254,7 @@ var _ = Describe("update strategy transformer test.", func() {
 			dag = mockDAG()
 			Expect(transformer.Transform(transCtx, dag)).Should(Succeed())
 			Expect(dag.Equals(dagExpected, less)).Should(BeTrue())
+			Expect(transCtx.podsToBeUpdated).Should(Equal(map[string]bool{pod1.Name: true}))
 		})
 	})
 })
@@ -21,6 +21,7 @@ package rsm
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/tools/record"
@@ -45,13 +46,18 @@ const (
 
 	FeatureGateRSMToPod = "RSM_TO_POD"
 
+	// FeatureGateRSMInPlacePodVerticalScaling whether to resize the resources of pods in place if possible,
+	// it requires the InPlacePodVerticalScaling feature gate to be enabled in the Kubernetes cluster.
+	FeatureGateRSMInPlacePodVerticalScaling = "IN_PLACE_POD_VERTICAL_SCALING"
+
 	workloadsManagedByLabelKey = "workloads.kubeblocks.io/managed-by"
 	workloadsInstanceLabelKey  = "workloads.kubeblocks.io/instance"
 
 	kindReplicatedStateMachine = "ReplicatedStateMachine"
 
-	roleLabelKey          = "kubeblocks.io/role"
-	rsmAccessModeLabelKey = "rsm.workloads.kubeblocks.io/access-mode"
+	roleLabelKey = "kubeblocks.io/role"
+	// RSMAccessModeLabelKey is the label of the access mode of the role, which is set to the pods by the rsm.
+	RSMAccessModeLabelKey = "rsm.workloads.kubeblocks.io/access-mode"
 	rsmGenerationLabelKey = "rsm.workloads.kubeblocks.io/controller-generation"
 
 	defaultPodName = "Unknown"
@@ -61,6 +67,7 @@ const (
 	jobHandledLabel             = "rsm.workloads.kubeblocks.io/job-handled"
 	jobTypeLabel                = "rsm.workloads.kubeblocks.io/job-type"
 	jobScenarioLabel            = "rsm.workloads.kubeblocks.io/job-scenario"
+	jobAuditedAnnotationKey     = "rsm.workloads.kubeblocks.io/job-audited"
 	jobHandledTrue              = "true"
 	jobHandledFalse             = "false"
 	jobTypeSwitchover           = "switchover"
@@ -70,6 +77,7 @@ const (
 	jobTypePromote              = "promote"
 	jobScenarioMembership       = "membership-reconfiguration"
 	jobScenarioUpdate           = "pod-update"
+	jobScenarioNodeDrain        = "node-drain"
 
 	roleProbeContainerName       = "kb-role-probe"
 	roleProbeBinaryName          = "lorry"
@@ -101,6 +109,10 @@ const (
 	checkRoleEventReason         = "checkRole"
 
 	actionSvcPortBase = int32(36500)
+
+	// switchoverCompletionWindow is the period after a switchover completes, during which the leader changes
+	// are considered to be caused by the switchover.
+	switchoverCompletionWindow = time.Minute
 )
 
 type rsmTransformContext struct {
@@ -110,6 +122,11 @@ type rsmTransformContext struct {
 	logr.Logger
 	rsm     *workloads.ReplicatedStateMachine
 	rsmOrig *workloads.ReplicatedStateMachine
+
+	// podsToBeUpdated are the pods updated by the update plan in this round, keyed by the pod name,
+	// true means the pod is deleted to re-create and false means it is resized in place.
+	// the update metrics are recorded when the pods are actually updated by the plan builder.
+	podsToBeUpdated map[string]bool
 }
 
 func (c *rsmTransformContext) GetContext() context.Context {
@@ -72,6 +72,14 @@ func (p *realUpdatePlan) planWalkFunc(vertex graph.Vertex) error {
 
 	// if pod is the latest version, we do nothing
 	if intctrlutil.GetPodRevision(pod) == p.rsm.Status.UpdateRevision {
+		switch pod.Status.Resize {
+		case corev1.PodResizeStatusInfeasible:
+			// the pod can not be resized in place, fall back to re-create it
+			p.podsToBeUpdated = append(p.podsToBeUpdated, pod)
+			return ErrStop
+		case corev1.PodResizeStatusProposed, corev1.PodResizeStatusInProgress, corev1.PodResizeStatusDeferred:
+			return ErrWait
+		}
 		if intctrlutil.PodIsReadyWithLabel(*pod) {
 			return ErrContinue
 		} else {
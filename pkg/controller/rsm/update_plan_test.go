@@ -41,36 +41,36 @@ var _ = Describe("update plan test.", func() {
 		var pod0, pod1, pod2, pod3, pod4, pod5, pod6 *corev1.Pod
 
 		resetPods := func() {
-			pod0 = builder.NewPodBuilder(namespace, getPodName(name, 0)).
+			pod0 = builder.NewPodBuilder(namespace, GetPodName(name, 0)).
 				AddLabels(roleLabelKey, "follower").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
 
-			pod1 = builder.NewPodBuilder(namespace, getPodName(name, 1)).
+			pod1 = builder.NewPodBuilder(namespace, GetPodName(name, 1)).
 				AddLabels(roleLabelKey, "logger").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
 
-			pod2 = builder.NewPodBuilder(namespace, getPodName(name, 2)).
+			pod2 = builder.NewPodBuilder(namespace, GetPodName(name, 2)).
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
 
-			pod3 = builder.NewPodBuilder(namespace, getPodName(name, 3)).
+			pod3 = builder.NewPodBuilder(namespace, GetPodName(name, 3)).
 				AddLabels(roleLabelKey, "learner").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
 
-			pod4 = builder.NewPodBuilder(namespace, getPodName(name, 4)).
+			pod4 = builder.NewPodBuilder(namespace, GetPodName(name, 4)).
 				AddLabels(roleLabelKey, "candidate").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
 
-			pod5 = builder.NewPodBuilder(namespace, getPodName(name, 5)).
+			pod5 = builder.NewPodBuilder(namespace, GetPodName(name, 5)).
 				AddLabels(roleLabelKey, "leader").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
 
-			pod6 = builder.NewPodBuilder(namespace, getPodName(name, 6)).
+			pod6 = builder.NewPodBuilder(namespace, GetPodName(name, 6)).
 				AddLabels(roleLabelKey, "learner").
 				AddLabels(apps.StatefulSetRevisionLabel, oldRevision).
 				GetObject()
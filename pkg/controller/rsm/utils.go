@@ -26,6 +26,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -35,16 +36,20 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/audit"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	"github.com/apecloud/kubeblocks/pkg/controller/notification"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -135,7 +140,7 @@ func ComposeRolePriorityMap(roles []workloads.ReplicaRole) map[string]int {
 }
 
 // updatePodRoleLabel updates pod role label when internal container role changed
-func updatePodRoleLabel(cli client.Client, reqCtx intctrlutil.RequestCtx,
+func updatePodRoleLabel(cli client.Client, reqCtx intctrlutil.RequestCtx, recorder record.EventRecorder,
 	rsm workloads.ReplicatedStateMachine, pod *corev1.Pod, roleName string, version string) error {
 	ctx := reqCtx.Ctx
 	roleMap := composeRoleMap(rsm)
@@ -144,21 +149,86 @@ func updatePodRoleLabel(cli client.Client, reqCtx intctrlutil.RequestCtx,
 
 	// update pod role label
 	patch := client.MergeFrom(pod.DeepCopy())
+	lastRoleName := pod.Labels[roleLabelKey]
 	role, ok := roleMap[roleName]
 	switch ok {
 	case true:
 		pod.Labels[roleLabelKey] = role.Name
-		pod.Labels[rsmAccessModeLabelKey] = string(role.AccessMode)
+		pod.Labels[RSMAccessModeLabelKey] = string(role.AccessMode)
 	case false:
 		delete(pod.Labels, roleLabelKey)
-		delete(pod.Labels, rsmAccessModeLabelKey)
+		delete(pod.Labels, RSMAccessModeLabelKey)
+	}
+	if lastRoleName != pod.Labels[roleLabelKey] {
+		metrics.RoleChangesTotal.WithLabelValues(append(metrics.ComponentLabelValues(pod), pod.Labels[roleLabelKey])...).Inc()
 	}
 
 	if pod.Annotations == nil {
 		pod.Annotations = map[string]string{}
 	}
 	pod.Annotations[constant.LastRoleSnapshotVersionAnnotationKey] = version
-	return cli.Patch(ctx, pod, patch)
+	if err := cli.Patch(ctx, pod, patch); err != nil {
+		return err
+	}
+
+	// the pod takes over the leadership from another one, by failover or switchover
+	if ok && role.IsLeader && lastRoleName != role.Name {
+		if oldLeader := getLeaderPodName(rsm.Status.MembersStatus); len(oldLeader) > 0 && oldLeader != pod.Name {
+			message := fmt.Sprintf("the leader of %s changes from %s to %s", rsm.Name, oldLeader, pod.Name)
+			switchover, err := isSwitchoverInProgress(ctx, cli, &rsm)
+			if err != nil {
+				reqCtx.Log.Error(err, "failed to check the switchover in progress", "rsm", rsm.Name)
+			}
+			if switchover {
+				notification.Notify(ctx, cli, pod, notification.EventSwitchoverExecuted, message)
+			} else {
+				notification.Notify(ctx, cli, pod, notification.EventFailoverExecuted, message)
+			}
+			// the switchovers are audited by the initiators, only the failovers are audited here.
+			if err == nil && !switchover {
+				audit.Record(recorder, &rsm, audit.Event{
+					Actor:   audit.ControllerActor(),
+					Action:  audit.ActionFailover,
+					Kind:    "ReplicatedStateMachine",
+					Target:  pod.Name,
+					Result:  audit.ResultSucceeded,
+					Message: message,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// isSwitchoverInProgress tells whether there is a switchover of the rsm in progress or just completed, which is
+// executed by the switchover action of the rsm, or by the switchover job of an OpsRequest.
+func isSwitchoverInProgress(ctx context.Context, cli client.Client, rsm *workloads.ReplicatedStateMachine) (bool, error) {
+	actionLabels := getLabels(rsm)
+	actionLabels[jobScenarioLabel] = jobScenarioMembership
+	actionLabels[jobTypeLabel] = jobTypeSwitchover
+	opsJobLabels := client.MatchingLabels{
+		constant.AppInstanceLabelKey:    rsm.Labels[constant.AppInstanceLabelKey],
+		constant.KBAppComponentLabelKey: rsm.Labels[constant.KBAppComponentLabelKey],
+	}
+	for _, opts := range [][]client.ListOption{
+		{client.MatchingLabels(actionLabels)},
+		{opsJobLabels, client.HasLabels{constant.SwitchoverJobLabelKey}},
+	} {
+		jobList := &batchv1.JobList{}
+		if err := cli.List(ctx, jobList, append(opts, client.InNamespace(rsm.Namespace))...); err != nil {
+			return false, err
+		}
+		for _, job := range jobList.Items {
+			if job.Status.Failed > 0 {
+				continue
+			}
+			// the role changes are reported a while after the switchover completes.
+			if job.Status.CompletionTime == nil || time.Since(job.Status.CompletionTime.Time) < switchoverCompletionWindow {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
 }
 
 func composeRoleMap(rsm workloads.ReplicatedStateMachine) map[string]workloads.ReplicaRole {
@@ -230,9 +300,8 @@ func getPodsOfStatefulSet(ctx context.Context, cli client.Reader, stsObj *appsv1
 	if err != nil {
 		return nil, err
 	}
-	if err := cli.List(ctx, podList,
-		&client.ListOptions{Namespace: stsObj.Namespace},
-		client.MatchingLabels(selector)); err != nil {
+	if err := intctrlutil.ListByIndex(ctx, cli, podList, intctrlutil.PodOwnerStatefulSetField, stsObj.Name,
+		selector, client.InNamespace(stsObj.Namespace)); err != nil {
 		return nil, err
 	}
 	isMemberOf := func(stsName string, pod *corev1.Pod) bool {
@@ -248,7 +317,22 @@ func getPodsOfStatefulSet(ctx context.Context, cli client.Reader, stsObj *appsv1
 	return pods, nil
 }
 
-func getHeadlessSvcName(rsm workloads.ReplicatedStateMachine) string {
+// podExpectationKey returns the key of the rsm in intctrlutil.PodExpectations,
+// which is the same as the key of the component it belongs to.
+func podExpectationKey(rsm *workloads.ReplicatedStateMachine) string {
+	return client.ObjectKeyFromObject(rsm).String()
+}
+
+// podExpectationsSatisfied observes the pods read from the cache, and returns true if the pods created
+// and deleted previously are all observed.
+func podExpectationsSatisfied(rsm *workloads.ReplicatedStateMachine, pods []corev1.Pod) bool {
+	key := podExpectationKey(rsm)
+	intctrlutil.PodExpectations.ObservePods(key, pods)
+	return intctrlutil.PodExpectations.Satisfied(key)
+}
+
+// GetHeadlessSvcName returns the name of the headless service which the pods managed by the rsm are addressed by.
+func GetHeadlessSvcName(rsm workloads.ReplicatedStateMachine) string {
 	return strings.Join([]string{rsm.Name, "headless"}, "-")
 }
 
@@ -295,7 +379,8 @@ func printActionList(logger logr.Logger, actionList []*batchv1.Job) {
 	logger.Info(fmt.Sprintf("action list: %v\n", actionNameList))
 }
 
-func getPodName(parent string, ordinal int) string {
+// GetPodName returns the name of the pod with the ordinal managed by the rsm.
+func GetPodName(parent string, ordinal int) string {
 	return fmt.Sprintf("%s-%d", parent, ordinal)
 }
 
@@ -382,7 +467,7 @@ func buildActionPodTemplate(rsm *workloads.ReplicatedStateMachine, env []corev1.
 }
 
 func buildActionEnv(rsm *workloads.ReplicatedStateMachine, leader, target string) []corev1.EnvVar {
-	svcName := getHeadlessSvcName(*rsm)
+	svcName := GetHeadlessSvcName(*rsm)
 	leaderHost := fmt.Sprintf("%s.%s", leader, svcName)
 	targetHost := fmt.Sprintf("%s.%s", target, svcName)
 	svcPort := findSvcPort(*rsm)
@@ -707,7 +792,7 @@ func IsRSMReady(rsm *workloads.ReplicatedStateMachine) bool {
 		return false
 	}
 	for i := 0; i < int(*rsm.Spec.Replicas); i++ {
-		podName := getPodName(rsm.Name, i)
+		podName := GetPodName(rsm.Name, i)
 		if !isMemberReady(podName, membersStatus) {
 			return false
 		}
@@ -23,11 +23,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/golang/mock/gomock"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -174,7 +176,7 @@ var _ = Describe("utils test", func() {
 				AddMatchLabels(constant.KBManagedByKey, kindReplicatedStateMachine).
 				AddMatchLabels(constant.AppInstanceLabelKey, name).
 				GetObject()
-			pod := builder.NewPodBuilder(namespace, getPodName(name, 0)).
+			pod := builder.NewPodBuilder(namespace, GetPodName(name, 0)).
 				AddLabels(constant.KBManagedByKey, kindReplicatedStateMachine).
 				AddLabels(constant.AppInstanceLabelKey, name).
 				GetObject()
@@ -194,9 +196,9 @@ var _ = Describe("utils test", func() {
 		})
 	})
 
-	Context("getHeadlessSvcName function", func() {
+	Context("GetHeadlessSvcName function", func() {
 		It("should work well", func() {
-			Expect(getHeadlessSvcName(*rsm)).Should(Equal("bar-headless"))
+			Expect(GetHeadlessSvcName(*rsm)).Should(Equal("bar-headless"))
 		})
 	})
 
@@ -222,7 +224,7 @@ var _ = Describe("utils test", func() {
 					},
 				},
 			}
-			pod := builder.NewPodBuilder(namespace, getPodName(name, 0)).
+			pod := builder.NewPodBuilder(namespace, GetPodName(name, 0)).
 				SetContainers([]corev1.Container{container}).
 				GetObject()
 			rsm.Spec.Template = corev1.PodTemplateSpec{
@@ -257,7 +259,7 @@ var _ = Describe("utils test", func() {
 
 	Context("getPodName function", func() {
 		It("should work well", func() {
-			Expect(getPodName(name, 1)).Should(Equal("bar-1"))
+			Expect(GetPodName(name, 1)).Should(Equal("bar-1"))
 		})
 	})
 
@@ -435,4 +437,48 @@ var _ = Describe("utils test", func() {
 			Expect(IsOwnedByRsm(rsm)).Should(BeFalse())
 		})
 	})
+
+	Context("isSwitchoverInProgress function", func() {
+		It("should work well", func() {
+			mockJobs := func(actionJobs, opsJobs []batchv1.Job) {
+				k8sMock.EXPECT().
+					List(gomock.Any(), &batchv1.JobList{}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, list *batchv1.JobList, _ ...client.ListOption) error {
+						list.Items = actionJobs
+						return nil
+					}).Times(1)
+				if opsJobs == nil {
+					return
+				}
+				k8sMock.EXPECT().
+					List(gomock.Any(), &batchv1.JobList{}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, list *batchv1.JobList, _ ...client.ListOption) error {
+						list.Items = opsJobs
+						return nil
+					}).Times(1)
+			}
+
+			By("no switchover jobs")
+			mockJobs([]batchv1.Job{}, []batchv1.Job{})
+			Expect(isSwitchoverInProgress(ctx, k8sMock, rsm)).Should(BeFalse())
+
+			By("a switchover action in progress")
+			mockJobs([]batchv1.Job{{}}, nil)
+			Expect(isSwitchoverInProgress(ctx, k8sMock, rsm)).Should(BeTrue())
+
+			By("a failed switchover job of OpsRequest")
+			mockJobs([]batchv1.Job{}, []batchv1.Job{{Status: batchv1.JobStatus{Failed: 1}}})
+			Expect(isSwitchoverInProgress(ctx, k8sMock, rsm)).Should(BeFalse())
+
+			By("a switchover job of OpsRequest completed just now")
+			completed := metav1.Now()
+			mockJobs([]batchv1.Job{}, []batchv1.Job{{Status: batchv1.JobStatus{Succeeded: 1, CompletionTime: &completed}}})
+			Expect(isSwitchoverInProgress(ctx, k8sMock, rsm)).Should(BeTrue())
+
+			By("a switchover job of OpsRequest completed long ago")
+			completed = metav1.NewTime(time.Now().Add(-2 * switchoverCompletionWindow))
+			mockJobs([]batchv1.Job{}, []batchv1.Job{{Status: batchv1.JobStatus{Succeeded: 1, CompletionTime: &completed}}})
+			Expect(isSwitchoverInProgress(ctx, k8sMock, rsm)).Should(BeFalse())
+		})
+	})
 })
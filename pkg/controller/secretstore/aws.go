@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const AWSSecretsManagerProviderName = "aws-secrets-manager"
+
+func init() {
+	RegisterProvider(AWSSecretsManagerProviderName, newAWSSecretsManagerProvider)
+}
+
+// awsSecretsManagerProvider stores the secrets in AWS Secrets Manager, the credentials are taken from
+// the default credential chain, e.g., the IAM role of the service account.
+type awsSecretsManagerProvider struct {
+	prefix string
+	client *secretsmanager.SecretsManager
+}
+
+var _ Provider = &awsSecretsManagerProvider{}
+
+func newAWSSecretsManagerProvider() (Provider, error) {
+	config := aws.NewConfig()
+	if region := viper.GetString(constant.CfgKeySecretStoreAWSRegion); len(region) > 0 {
+		config = config.WithRegion(region)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &awsSecretsManagerProvider{
+		prefix: viper.GetString(constant.CfgKeySecretStorePathPrefix),
+		client: secretsmanager.New(sess),
+	}, nil
+}
+
+func (p *awsSecretsManagerProvider) Name() string {
+	return AWSSecretsManagerProviderName
+}
+
+// Ref returns the name of the secret in AWS Secrets Manager, which is accepted as the secret id as well as the ARN.
+func (p *awsSecretsManagerProvider) Ref(key string) string {
+	if len(p.prefix) > 0 {
+		return fmt.Sprintf("%s/%s", p.prefix, key)
+	}
+	return key
+}
+
+func (p *awsSecretsManagerProvider) Put(ctx context.Context, key string, values map[string][]byte) (string, error) {
+	name := p.Ref(key)
+	data := make(map[string]string, len(values))
+	for k, v := range values {
+		data[k] = string(v)
+	}
+	secretString, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	putOutput, err := p.client.PutSecretValueWithContext(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(name),
+		SecretString: aws.String(string(secretString)),
+	})
+	if err == nil {
+		return aws.StringValue(putOutput.ARN), nil
+	}
+	if !isAWSResourceNotFound(err) {
+		return "", err
+	}
+	createOutput, err := p.client.CreateSecretWithContext(ctx, &secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		SecretString: aws.String(string(secretString)),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(createOutput.ARN), nil
+}
+
+func (p *awsSecretsManagerProvider) Get(ctx context.Context, ref string) (map[string][]byte, error) {
+	output, err := p.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(ref),
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]string{}
+	if err = json.Unmarshal([]byte(aws.StringValue(output.SecretString)), &data); err != nil {
+		return nil, err
+	}
+	values := make(map[string][]byte, len(data))
+	for k, v := range data {
+		values[k] = []byte(v)
+	}
+	return values, nil
+}
+
+func (p *awsSecretsManagerProvider) Delete(ctx context.Context, ref string) error {
+	_, err := p.client.DeleteSecretWithContext(ctx, &secretsmanager.DeleteSecretInput{
+		SecretId:                   aws.String(ref),
+		ForceDeleteWithoutRecovery: aws.Bool(true),
+	})
+	if err != nil && !isAWSResourceNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func isAWSResourceNotFound(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == secretsmanager.ErrCodeResourceNotFoundException
+}
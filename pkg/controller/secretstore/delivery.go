@@ -0,0 +1,259 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// FileEnvSuffix is the suffix of the environment variables which give the paths of the files holding the sealed values,
+	// e.g., KB_SERVICE_PASSWORD_FILE for KB_SERVICE_PASSWORD.
+	FileEnvSuffix = "_FILE"
+
+	fetchContainerName = "init-secrets"
+	secretsVolumeName  = "kb-secrets"
+	secretsMountPath   = "/kb-secrets"
+)
+
+// sealedKeys are the keys of the secrets which are sealed by the callers of Seal.
+var sealedKeys = map[string]bool{
+	constant.AccountPasswdForSecret: true,
+}
+
+// providerEnvKeys are the settings passed to the init container to access the secret manager, the token of vault
+// is excluded, the pods log in with their service accounts instead.
+var providerEnvKeys = []string{
+	constant.CfgKeySecretStoreProvider,
+	constant.CfgKeySecretStoreVaultAddr,
+	constant.CfgKeySecretStoreVaultMount,
+	constant.CfgKeySecretStoreVaultRole,
+	constant.CfgKeySecretStoreAWSRegion,
+}
+
+// InjectPodDelivery delivers the sealed values referred to by the environment variables of the pod from the external
+// secret manager, since they are absent from the secrets. The references to the secrets are made optional, an init
+// container fetches the values into a memory-backed volume, and the paths of the files are given by the environment
+// variables with the FileEnvSuffix. It does nothing if there is no provider configured.
+func InjectPodDelivery(namespace string, podSpec *corev1.PodSpec) error {
+	provider, err := GetProvider()
+	if err != nil || provider == nil {
+		return err
+	}
+	refs := map[string]string{}
+	injectContainer := func(container *corev1.Container) {
+		env := make([]corev1.EnvVar, 0, len(container.Env))
+		fileEnv := make([]corev1.EnvVar, 0)
+		for _, e := range container.Env {
+			if e.ValueFrom == nil || e.ValueFrom.SecretKeyRef == nil || !sealedKeys[e.ValueFrom.SecretKeyRef.Key] {
+				env = append(env, e)
+				continue
+			}
+			selector := e.ValueFrom.SecretKeyRef.DeepCopy()
+			selector.Optional = pointer.Bool(true)
+			env = append(env, corev1.EnvVar{Name: e.Name, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: selector}})
+			fileEnv = append(fileEnv, corev1.EnvVar{
+				Name:  e.Name + FileEnvSuffix,
+				Value: filepath.Join(secretsMountPath, selector.Name, selector.Key),
+			})
+			refs[selector.Name] = provider.Ref(secretStoreKey(namespace, selector.Name))
+		}
+		if len(fileEnv) == 0 {
+			return
+		}
+		for _, e := range fileEnv {
+			if !hasEnv(env, e.Name) {
+				env = append(env, e)
+			}
+		}
+		container.Env = env
+		if !hasVolumeMount(container.VolumeMounts, secretsVolumeName) {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      secretsVolumeName,
+				MountPath: secretsMountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+	// copy the containers, they may be shared with the object which the pod spec is built from.
+	podSpec.InitContainers = append([]corev1.Container{}, podSpec.InitContainers...)
+	podSpec.Containers = append([]corev1.Container{}, podSpec.Containers...)
+	for i := range podSpec.InitContainers {
+		if podSpec.InitContainers[i].Name != fetchContainerName {
+			injectContainer(&podSpec.InitContainers[i])
+		}
+	}
+	for i := range podSpec.Containers {
+		injectContainer(&podSpec.Containers[i])
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	if !hasVolume(podSpec.Volumes, secretsVolumeName) {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: secretsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+			},
+		})
+	}
+	fetchContainer := buildFetchContainer(refs)
+	for i, c := range podSpec.InitContainers {
+		if c.Name == fetchContainerName {
+			podSpec.InitContainers[i] = fetchContainer
+			return nil
+		}
+	}
+	// the values should be fetched before the other init containers run.
+	podSpec.InitContainers = append([]corev1.Container{fetchContainer}, podSpec.InitContainers...)
+	return nil
+}
+
+func buildFetchContainer(refs map[string]string) corev1.Container {
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	command := []string{"lorryctl", "fetch-secrets", "--output-dir", secretsMountPath}
+	for _, name := range names {
+		command = append(command, "--ref", fmt.Sprintf("%s=%s", name, refs[name]))
+	}
+	env := make([]corev1.EnvVar, 0, len(providerEnvKeys))
+	for _, key := range providerEnvKeys {
+		if value := viper.GetString(key); len(value) > 0 {
+			env = append(env, corev1.EnvVar{Name: key, Value: value})
+		}
+	}
+	return corev1.Container{
+		Name:            fetchContainerName,
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		Command:         command,
+		Env:             env,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      secretsVolumeName,
+				MountPath: secretsMountPath,
+			},
+		},
+	}
+}
+
+// FetchSecrets fetches the values referenced by the refs, which map the names of the secrets to the references,
+// and writes them into the files named by the keys under the directories named by the secrets.
+func FetchSecrets(ctx context.Context, refs map[string]string, outputDir string) error {
+	provider, err := GetProvider()
+	if err != nil {
+		return err
+	}
+	if provider == nil {
+		return fmt.Errorf("no secret store provider configured")
+	}
+	for name, ref := range refs {
+		values, err := provider.Get(ctx, ref)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Join(outputDir, name)
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		for key, value := range values {
+			// the containers may run as other users than the init container.
+			if err = os.WriteFile(filepath.Join(dir, key), value, 0444); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LoadProviderEnvs loads the settings of the secret manager passed to the init container by InjectPodDelivery.
+func LoadProviderEnvs() {
+	for _, key := range providerEnvKeys {
+		viper.Set(key, os.Getenv(key))
+	}
+}
+
+// LoadFileEnvs sets the value of an environment variable from the file given by the one with the FileEnvSuffix,
+// if the variable itself is not set, since the sealed values are delivered by the files.
+func LoadFileEnvs() error {
+	for _, kv := range os.Environ() {
+		name, path, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasSuffix(name, FileEnvSuffix) || len(path) == 0 {
+			continue
+		}
+		key := strings.TrimSuffix(name, FileEnvSuffix)
+		if len(os.Getenv(key)) > 0 {
+			continue
+		}
+		value, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if err = os.Setenv(key, string(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasEnv(env []corev1.EnvVar, name string) bool {
+	for _, e := range env {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMount(mounts []corev1.VolumeMount, name string) bool {
+	for _, m := range mounts {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, v := range volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("secret delivery", func() {
+	const secretName = "mycluster-mysql-account-root"
+
+	provider := &fakeProvider{store: map[string]map[string][]byte{}}
+
+	RegisterProvider(fakeProviderName+"-delivery", func() (Provider, error) {
+		return provider, nil
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "")
+	})
+
+	newPodSpec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "mysql",
+					Env: []corev1.EnvVar{
+						{
+							Name: "MYSQL_ROOT_PASSWORD",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+									Key:                  constant.AccountPasswdForSecret,
+								},
+							},
+						},
+						{
+							Name: "MYSQL_ROOT_USER",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+									Key:                  constant.AccountNameForSecret,
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "exporter",
+				},
+			},
+		}
+	}
+
+	It("keeps the pod as is if no provider configured", func() {
+		podSpec := newPodSpec()
+		Expect(InjectPodDelivery("default", podSpec)).Should(Succeed())
+		Expect(podSpec).Should(Equal(newPodSpec()))
+	})
+
+	It("delivers the sealed values by the init container", func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, fakeProviderName+"-delivery")
+		podSpec := newPodSpec()
+		origin := podSpec.Containers[0].Env[0].ValueFrom
+		Expect(InjectPodDelivery("default", podSpec)).Should(Succeed())
+
+		By("check the init container")
+		Expect(podSpec.InitContainers).Should(HaveLen(1))
+		fetchContainer := podSpec.InitContainers[0]
+		Expect(fetchContainer.Name).Should(Equal(fetchContainerName))
+		Expect(fetchContainer.Command).Should(ContainElements("fetch-secrets", secretName+"=fake://default/"+secretName))
+		Expect(fetchContainer.Env).Should(ContainElement(corev1.EnvVar{
+			Name:  constant.CfgKeySecretStoreProvider,
+			Value: fakeProviderName + "-delivery",
+		}))
+		Expect(podSpec.Volumes).Should(HaveLen(1))
+		Expect(podSpec.Volumes[0].EmptyDir.Medium).Should(Equal(corev1.StorageMediumMemory))
+
+		By("check the containers referring to the sealed values")
+		container := podSpec.Containers[0]
+		Expect(*container.Env[0].ValueFrom.SecretKeyRef.Optional).Should(BeTrue())
+		Expect(container.Env[1].ValueFrom.SecretKeyRef.Optional).Should(BeNil())
+		Expect(container.Env).Should(ContainElement(corev1.EnvVar{
+			Name:  "MYSQL_ROOT_PASSWORD" + FileEnvSuffix,
+			Value: filepath.Join(secretsMountPath, secretName, constant.AccountPasswdForSecret),
+		}))
+		Expect(container.VolumeMounts).Should(HaveLen(1))
+		Expect(podSpec.Containers[1].VolumeMounts).Should(BeEmpty())
+		Expect(origin.SecretKeyRef.Optional).Should(BeNil())
+
+		By("inject again")
+		expected := podSpec.DeepCopy()
+		Expect(InjectPodDelivery("default", podSpec)).Should(Succeed())
+		Expect(podSpec).Should(Equal(expected))
+	})
+
+	It("fetches the values and loads them into the environment variables", func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, fakeProviderName+"-delivery")
+		provider.store["default/"+secretName] = map[string][]byte{constant.AccountPasswdForSecret: []byte("passwd")}
+
+		dir := GinkgoT().TempDir()
+		Expect(FetchSecrets(context.Background(), map[string]string{secretName: "fake://default/" + secretName}, dir)).Should(Succeed())
+		path := filepath.Join(dir, secretName, constant.AccountPasswdForSecret)
+		Expect(os.ReadFile(path)).Should(Equal([]byte("passwd")))
+
+		GinkgoT().Setenv("KB_TEST_PASSWORD"+FileEnvSuffix, path)
+		GinkgoT().Setenv("KB_TEST_PASSWORD", "")
+		Expect(LoadFileEnvs()).Should(Succeed())
+		Expect(os.Getenv("KB_TEST_PASSWORD")).Should(Equal("passwd"))
+	})
+})
@@ -0,0 +1,212 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// Provider is the interface of the external secret managers, which keep the sensitive values of the secrets
+// generated by KubeBlocks as the source of truth, and the references to them are stored in the Kubernetes Secrets.
+type Provider interface {
+	// Name returns the name of the provider.
+	Name() string
+
+	// Ref returns the reference to the values stored with the key.
+	Ref(key string) string
+
+	// Put stores the values with the key, and returns the reference to them.
+	// It overwrites the values if the key already exists.
+	Put(ctx context.Context, key string, values map[string][]byte) (string, error)
+
+	// Get returns the values referenced by the ref.
+	Get(ctx context.Context, ref string) (map[string][]byte, error)
+
+	// Delete deletes the values referenced by the ref, it's not an error if they don't exist.
+	Delete(ctx context.Context, ref string) error
+}
+
+// ProviderFactory creates a provider from the settings of the operator.
+type ProviderFactory func() (Provider, error)
+
+var (
+	factories = map[string]ProviderFactory{}
+
+	providerMutex sync.Mutex
+	providers     = map[string]Provider{}
+)
+
+// RegisterProvider registers a provider factory with the name, which is referred to by the operator setting
+// constant.CfgKeySecretStoreProvider.
+func RegisterProvider(name string, factory ProviderFactory) {
+	factories[name] = factory
+}
+
+// Enabled tells whether the generated secrets are stored in an external secret manager.
+func Enabled() bool {
+	return len(viper.GetString(constant.CfgKeySecretStoreProvider)) > 0
+}
+
+// GetProvider returns the provider configured for the operator, or nil if there is none.
+func GetProvider() (Provider, error) {
+	name := viper.GetString(constant.CfgKeySecretStoreProvider)
+	if len(name) == 0 {
+		return nil, nil
+	}
+	return getProvider(name)
+}
+
+func getProvider(name string) (Provider, error) {
+	providerMutex.Lock()
+	defer providerMutex.Unlock()
+	if provider, ok := providers[name]; ok {
+		return provider, nil
+	}
+	factory, ok := factories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown secret store provider: %s", name)
+	}
+	provider, err := factory()
+	if err != nil {
+		return nil, err
+	}
+	providers[name] = provider
+	return provider, nil
+}
+
+// IsSealed tells whether the sensitive values of the secret are stored in an external secret manager.
+func IsSealed(secret *corev1.Secret) bool {
+	if secret == nil || secret.Annotations == nil {
+		return false
+	}
+	_, ok := secret.Annotations[constant.SecretStoreProviderAnnotationKey]
+	return ok
+}
+
+// Seal moves the values of the keys from the secret to the external secret manager configured, and puts
+// the reference to them into the secret. It does nothing if there is no provider configured.
+//
+// The pods get the values from the secret manager by the init container injected by InjectPodDelivery.
+func Seal(ctx context.Context, secret *corev1.Secret, keys ...string) error {
+	provider, err := GetProvider()
+	if err != nil || provider == nil {
+		return err
+	}
+	values := map[string][]byte{}
+	for _, key := range keys {
+		if v, ok := secret.Data[key]; ok {
+			values[key] = v
+			delete(secret.Data, key)
+		}
+		if v, ok := secret.StringData[key]; ok {
+			values[key] = []byte(v)
+			delete(secret.StringData, key)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	ref, err := provider.Put(ctx, storeKey(secret), values)
+	if err != nil {
+		return err
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constant.SecretStoreProviderAnnotationKey] = provider.Name()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[constant.SecretStoreRefKey] = []byte(ref)
+	return nil
+}
+
+// Unseal returns the data of the secret, with the sensitive values fetched from the external secret manager.
+func Unseal(ctx context.Context, secret *corev1.Secret) (map[string][]byte, error) {
+	data := make(map[string][]byte, len(secret.Data))
+	for k, v := range secret.Data {
+		data[k] = v
+	}
+	if !IsSealed(secret) {
+		return data, nil
+	}
+	provider, err := getProvider(secret.Annotations[constant.SecretStoreProviderAnnotationKey])
+	if err != nil {
+		return nil, err
+	}
+	values, err := provider.Get(ctx, string(secret.Data[constant.SecretStoreRefKey]))
+	if err != nil {
+		return nil, err
+	}
+	delete(data, constant.SecretStoreRefKey)
+	for k, v := range values {
+		data[k] = v
+	}
+	return data, nil
+}
+
+// UpdateSealed updates the sensitive values of a sealed secret in the external secret manager,
+// the keys with empty values are deleted. The secret itself is kept unchanged.
+func UpdateSealed(ctx context.Context, secret *corev1.Secret, values map[string][]byte) error {
+	provider, err := getProvider(secret.Annotations[constant.SecretStoreProviderAnnotationKey])
+	if err != nil {
+		return err
+	}
+	existing, err := provider.Get(ctx, string(secret.Data[constant.SecretStoreRefKey]))
+	if err != nil {
+		return err
+	}
+	for k, v := range values {
+		if len(v) == 0 {
+			delete(existing, k)
+		} else {
+			existing[k] = v
+		}
+	}
+	_, err = provider.Put(ctx, storeKey(secret), existing)
+	return err
+}
+
+// Purge deletes the sensitive values of a sealed secret from the external secret manager.
+func Purge(ctx context.Context, secret *corev1.Secret) error {
+	if !IsSealed(secret) {
+		return nil
+	}
+	provider, err := getProvider(secret.Annotations[constant.SecretStoreProviderAnnotationKey])
+	if err != nil {
+		return err
+	}
+	return provider.Delete(ctx, string(secret.Data[constant.SecretStoreRefKey]))
+}
+
+func storeKey(secret *corev1.Secret) string {
+	return secretStoreKey(secret.Namespace, secret.Name)
+}
+
+func secretStoreKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const fakeProviderName = "fake"
+
+type fakeProvider struct {
+	store map[string]map[string][]byte
+}
+
+func (p *fakeProvider) Name() string {
+	return fakeProviderName
+}
+
+func (p *fakeProvider) Ref(key string) string {
+	return "fake://" + key
+}
+
+func (p *fakeProvider) Put(_ context.Context, key string, values map[string][]byte) (string, error) {
+	p.store[key] = values
+	return p.Ref(key), nil
+}
+
+func (p *fakeProvider) Get(_ context.Context, ref string) (map[string][]byte, error) {
+	values, ok := p.store[strings.TrimPrefix(ref, "fake://")]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", ref)
+	}
+	result := map[string][]byte{}
+	for k, v := range values {
+		result[k] = v
+	}
+	return result, nil
+}
+
+func (p *fakeProvider) Delete(_ context.Context, ref string) error {
+	delete(p.store, strings.TrimPrefix(ref, "fake://"))
+	return nil
+}
+
+var _ = Describe("secret store", func() {
+	var (
+		ctx      = context.Background()
+		provider = &fakeProvider{store: map[string]map[string][]byte{}}
+	)
+
+	RegisterProvider(fakeProviderName, func() (Provider, error) {
+		return provider, nil
+	})
+
+	newSecret := func() *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "mycluster-mysql-account-root",
+			},
+			Data: map[string][]byte{
+				constant.AccountNameForSecret:   []byte("root"),
+				constant.AccountPasswdForSecret: []byte("passwd"),
+			},
+		}
+	}
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "")
+	})
+
+	It("keeps the secret as is if no provider configured", func() {
+		secret := newSecret()
+		Expect(Enabled()).Should(BeFalse())
+		Expect(Seal(ctx, secret, constant.AccountPasswdForSecret)).Should(Succeed())
+		Expect(IsSealed(secret)).Should(BeFalse())
+		Expect(secret.Data).Should(HaveKey(constant.AccountPasswdForSecret))
+
+		data, err := Unseal(ctx, secret)
+		Expect(err).Should(Succeed())
+		Expect(data).Should(Equal(secret.Data))
+		Expect(Purge(ctx, secret)).Should(Succeed())
+	})
+
+	It("seals and unseals the secret", func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, fakeProviderName)
+		Expect(Enabled()).Should(BeTrue())
+
+		secret := newSecret()
+		Expect(Seal(ctx, secret, constant.AccountPasswdForSecret)).Should(Succeed())
+		Expect(IsSealed(secret)).Should(BeTrue())
+		Expect(secret.Annotations[constant.SecretStoreProviderAnnotationKey]).Should(Equal(fakeProviderName))
+		Expect(secret.Data).ShouldNot(HaveKey(constant.AccountPasswdForSecret))
+		Expect(string(secret.Data[constant.SecretStoreRefKey])).Should(Equal("fake://default/mycluster-mysql-account-root"))
+
+		data, err := Unseal(ctx, secret)
+		Expect(err).Should(Succeed())
+		Expect(data).Should(HaveKeyWithValue(constant.AccountNameForSecret, []byte("root")))
+		Expect(data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte("passwd")))
+		Expect(data).ShouldNot(HaveKey(constant.SecretStoreRefKey))
+
+		By("update the sealed password")
+		Expect(UpdateSealed(ctx, secret, map[string][]byte{constant.AccountPasswdForSecret: []byte("new")})).Should(Succeed())
+		data, err = Unseal(ctx, secret)
+		Expect(err).Should(Succeed())
+		Expect(data).Should(HaveKeyWithValue(constant.AccountPasswdForSecret, []byte("new")))
+
+		By("delete the sealed key with empty value")
+		Expect(UpdateSealed(ctx, secret, map[string][]byte{
+			constant.AccountNextPasswdForSecret: []byte("next"),
+		})).Should(Succeed())
+		Expect(UpdateSealed(ctx, secret, map[string][]byte{
+			constant.AccountNextPasswdForSecret: nil,
+		})).Should(Succeed())
+		Expect(provider.store["default/mycluster-mysql-account-root"]).ShouldNot(HaveKey(constant.AccountNextPasswdForSecret))
+
+		By("purge the sealed password")
+		Expect(Purge(ctx, secret)).Should(Succeed())
+		_, err = Unseal(ctx, secret)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("seals the string data", func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, fakeProviderName)
+		secret := newSecret()
+		secret.Data = nil
+		secret.StringData = map[string]string{
+			constant.AccountNameForSecret:   "root",
+			constant.AccountPasswdForSecret: "passwd",
+		}
+		Expect(Seal(ctx, secret, constant.AccountPasswdForSecret)).Should(Succeed())
+		Expect(secret.StringData).ShouldNot(HaveKey(constant.AccountPasswdForSecret))
+		Expect(secret.Data).Should(HaveKey(constant.SecretStoreRefKey))
+	})
+
+	It("returns error for unknown provider", func() {
+		viper.Set(constant.CfgKeySecretStoreProvider, "unknown")
+		_, err := GetProvider()
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("works with vault", func() {
+		var (
+			mutex sync.Mutex
+			kv    = map[string]map[string]string{}
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if r.Header.Get("X-Vault-Token") != "token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			path := strings.TrimPrefix(r.URL.Path, "/v1/secret/")
+			switch r.Method {
+			case http.MethodPost:
+				body, _ := io.ReadAll(r.Body)
+				req := struct {
+					Data map[string]string `json:"data"`
+				}{}
+				_ = json.Unmarshal(body, &req)
+				kv[strings.TrimPrefix(path, "data/")] = req.Data
+				w.WriteHeader(http.StatusOK)
+			case http.MethodGet:
+				data, ok := kv[strings.TrimPrefix(path, "data/")]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+			case http.MethodDelete:
+				delete(kv, strings.TrimPrefix(path, "metadata/"))
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}))
+		defer server.Close()
+
+		viper.Set(constant.CfgKeySecretStoreVaultAddr, server.URL)
+		viper.Set(constant.CfgKeySecretStoreVaultToken, "token")
+		viper.Set(constant.CfgKeySecretStorePathPrefix, "kubeblocks")
+		p, err := newVaultProvider()
+		Expect(err).Should(Succeed())
+
+		ref, err := p.Put(ctx, "default/secret", map[string][]byte{"password": []byte("passwd")})
+		Expect(err).Should(Succeed())
+		Expect(ref).Should(Equal("kubeblocks/default/secret"))
+
+		values, err := p.Get(ctx, ref)
+		Expect(err).Should(Succeed())
+		Expect(values).Should(HaveKeyWithValue("password", []byte("passwd")))
+
+		Expect(p.Delete(ctx, ref)).Should(Succeed())
+		_, err = p.Get(ctx, ref)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("logs in vault with the service account of the pod", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/auth/kubernetes/login" {
+				body, _ := io.ReadAll(r.Body)
+				req := map[string]string{}
+				_ = json.Unmarshal(body, &req)
+				if req["role"] != "kubeblocks" || req["jwt"] != "jwt" {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": "pod-token"}})
+				return
+			}
+			if r.Header.Get("X-Vault-Token") != "pod-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": map[string]string{"password": "passwd"}}})
+		}))
+		defer server.Close()
+
+		tokenPath := filepath.Join(GinkgoT().TempDir(), "token")
+		Expect(os.WriteFile(tokenPath, []byte("jwt"), 0600)).Should(Succeed())
+		defaultTokenPath := serviceAccountTokenPath
+		serviceAccountTokenPath = tokenPath
+		defer func() { serviceAccountTokenPath = defaultTokenPath }()
+
+		viper.Set(constant.CfgKeySecretStoreVaultAddr, server.URL)
+		viper.Set(constant.CfgKeySecretStoreVaultToken, "")
+		viper.Set(constant.CfgKeySecretStoreVaultRole, "kubeblocks")
+		defer viper.Set(constant.CfgKeySecretStoreVaultRole, "")
+		p, err := newVaultProvider()
+		Expect(err).Should(Succeed())
+
+		values, err := p.Get(ctx, "kubeblocks/default/secret")
+		Expect(err).Should(Succeed())
+		Expect(values).Should(HaveKeyWithValue("password", []byte("passwd")))
+	})
+})
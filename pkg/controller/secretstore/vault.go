@@ -0,0 +1,209 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const VaultProviderName = "vault"
+
+// serviceAccountTokenPath is the path of the service account token of the pod to log in vault.
+var serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+func init() {
+	RegisterProvider(VaultProviderName, newVaultProvider)
+}
+
+// vaultProvider stores the secrets in the KV version 2 secrets engine of HashiCorp Vault.
+// It logs in with the Kubernetes auth method by the role if no token is given, e.g., in the pods of the clusters.
+type vaultProvider struct {
+	addr   string
+	mount  string
+	prefix string
+	role   string
+	client *http.Client
+
+	tokenMutex sync.Mutex
+	token      string
+}
+
+var _ Provider = &vaultProvider{}
+
+func newVaultProvider() (Provider, error) {
+	addr := viper.GetString(constant.CfgKeySecretStoreVaultAddr)
+	if len(addr) == 0 {
+		return nil, fmt.Errorf("the address of vault is required")
+	}
+	mount := viper.GetString(constant.CfgKeySecretStoreVaultMount)
+	if len(mount) == 0 {
+		mount = "secret"
+	}
+	return &vaultProvider{
+		addr:   strings.TrimSuffix(addr, "/"),
+		token:  viper.GetString(constant.CfgKeySecretStoreVaultToken),
+		mount:  strings.Trim(mount, "/"),
+		prefix: strings.Trim(viper.GetString(constant.CfgKeySecretStorePathPrefix), "/"),
+		role:   viper.GetString(constant.CfgKeySecretStoreVaultRole),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (p *vaultProvider) Name() string {
+	return VaultProviderName
+}
+
+func (p *vaultProvider) Ref(key string) string {
+	if len(p.prefix) > 0 {
+		return fmt.Sprintf("%s/%s", p.prefix, key)
+	}
+	return key
+}
+
+func (p *vaultProvider) Put(ctx context.Context, key string, values map[string][]byte) (string, error) {
+	path := p.Ref(key)
+	data := make(map[string]string, len(values))
+	for k, v := range values {
+		data[k] = string(v)
+	}
+	body, err := json.Marshal(map[string]any{"data": data})
+	if err != nil {
+		return "", err
+	}
+	if _, err = p.do(ctx, http.MethodPost, fmt.Sprintf("%s/data/%s", p.mount, path), body); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (p *vaultProvider) Get(ctx context.Context, ref string) (map[string][]byte, error) {
+	body, err := p.do(ctx, http.MethodGet, fmt.Sprintf("%s/data/%s", p.mount, ref), nil)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, fmt.Errorf("the secret %s is not found in vault", ref)
+	}
+	resp := struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}{}
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	values := make(map[string][]byte, len(resp.Data.Data))
+	for k, v := range resp.Data.Data {
+		values[k] = []byte(v)
+	}
+	return values, nil
+}
+
+func (p *vaultProvider) Delete(ctx context.Context, ref string) error {
+	// delete all the versions and the metadata of the secret
+	_, err := p.do(ctx, http.MethodDelete, fmt.Sprintf("%s/metadata/%s", p.mount, ref), nil)
+	return err
+}
+
+// getToken returns the token to access vault, it logs in with the service account token of the pod
+// by the Kubernetes auth method if no token is given.
+func (p *vaultProvider) getToken(ctx context.Context) (string, error) {
+	p.tokenMutex.Lock()
+	defer p.tokenMutex.Unlock()
+	if len(p.token) > 0 || len(p.role) == 0 {
+		return p.token, nil
+	}
+	jwt, err := os.ReadFile(serviceAccountTokenPath)
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(map[string]string{"role": p.role, "jwt": string(jwt)})
+	if err != nil {
+		return "", err
+	}
+	respBody, err := p.request(ctx, http.MethodPost, "auth/kubernetes/login", body, "")
+	if err != nil {
+		return "", err
+	}
+	resp := struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}{}
+	if err = json.Unmarshal(respBody, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Auth.ClientToken) == 0 {
+		return "", fmt.Errorf("failed to login vault with the role %s", p.role)
+	}
+	p.token = resp.Auth.ClientToken
+	return p.token, nil
+}
+
+// do sends the request to the vault API, it returns nil body if the path is not found.
+func (p *vaultProvider) do(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.request(ctx, method, path, body, token)
+}
+
+func (p *vaultProvider) request(ctx context.Context, method, path string, body []byte, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/%s", p.addr, path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if len(token) > 0 {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode >= http.StatusBadRequest:
+		return nil, fmt.Errorf("vault request %s %s failed with status %d: %s", method, path, resp.StatusCode, string(respBody))
+	default:
+		return respBody, nil
+	}
+}
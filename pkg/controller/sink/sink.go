@@ -0,0 +1,156 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	queueSize = 1024
+	timeout   = 5 * time.Second
+)
+
+// Pool keeps the sinks of the webhooks which the JSON payloads are posted to, at most maxSinks of them if positive,
+// the least recently used one is stopped for a new one.
+type Pool struct {
+	// name is the name of the webhooks in the logs and the errors, e.g., audit.
+	name     string
+	maxSinks int
+	log      logr.Logger
+
+	mutex sync.Mutex
+	sinks map[string]*webhookSink
+	seq   uint64
+}
+
+// NewPool creates a pool of the webhook sinks, which are named by name in the logs and the errors.
+func NewPool(name string, maxSinks int) *Pool {
+	return &Pool{
+		name:     name,
+		maxSinks: maxSinks,
+		log:      logf.Log.WithName(name),
+		sinks:    map[string]*webhookSink{},
+	}
+}
+
+// Send queues the payload to post to the webhook asynchronously, the payload is dropped if the webhook is not able
+// to keep up with the payloads.
+func (p *Pool) Send(url string, payload []byte) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	// the payload is queued with the lock held, the sink is not stopped in the meantime
+	p.getSink(url).send(payload)
+}
+
+// getSink returns the sink of the webhook, it must be called with the mutex held.
+func (p *Pool) getSink(url string) *webhookSink {
+	p.seq++
+	if sink, ok := p.sinks[url]; ok {
+		sink.lastUsed = p.seq
+		return sink
+	}
+	if p.maxSinks > 0 && len(p.sinks) >= p.maxSinks {
+		p.evictSink()
+	}
+	sink := &webhookSink{
+		pool:  p,
+		url:   url,
+		queue: make(chan []byte, queueSize),
+		client: &http.Client{
+			Timeout: timeout,
+			// the webhook is checked before posted to, it must not redirect to the hosts not checked.
+			CheckRedirect: func(req *http.Request, _ []*http.Request) error {
+				return fmt.Errorf("the %s webhook redirects to %s, which is refused", p.name, req.URL.Redacted())
+			},
+		},
+		lastUsed: p.seq,
+	}
+	go sink.run()
+	p.sinks[url] = sink
+	return sink
+}
+
+// evictSink stops the least recently used sink, the payloads queued are still posted before it exits.
+func (p *Pool) evictSink() {
+	var lru *webhookSink
+	for _, sink := range p.sinks {
+		if lru == nil || sink.lastUsed < lru.lastUsed {
+			lru = sink
+		}
+	}
+	if lru != nil {
+		delete(p.sinks, lru.url)
+		close(lru.queue)
+	}
+}
+
+// webhookSink posts the payloads to the webhook one by one.
+type webhookSink struct {
+	pool   *Pool
+	url    string
+	queue  chan []byte
+	client *http.Client
+	// lastUsed is the sequence number of the last payload sent to the sink
+	lastUsed uint64
+}
+
+func (s *webhookSink) send(payload []byte) {
+	select {
+	case s.queue <- payload:
+	default:
+		s.pool.log.Info(fmt.Sprintf("the %s webhook queue is full, drop the payload", s.pool.name), "webhook", s.url)
+	}
+}
+
+func (s *webhookSink) run() {
+	for payload := range s.queue {
+		if err := s.post(payload); err != nil {
+			s.pool.log.Error(err, fmt.Sprintf("failed to send the payload to the %s webhook", s.pool.name), "webhook", s.url)
+		}
+	}
+}
+
+func (s *webhookSink) post(payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("the %s webhook responds with status %d", s.pool.name, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package sink
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("webhook sink", func() {
+	It("posts the payloads to the webhook", func() {
+		received := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			Expect(r.Header.Get("Content-Type")).Should(Equal("application/json"))
+			received <- string(body)
+		}))
+		defer server.Close()
+
+		NewPool("test", 0).Send(server.URL, []byte(`{"text": "hello"}`))
+		Eventually(received).Should(Receive(Equal(`{"text": "hello"}`)))
+	})
+
+	It("refuses the redirects of the webhook", func() {
+		redirected := make(chan struct{}, 1)
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			redirected <- struct{}{}
+		}))
+		defer target.Close()
+		posted := make(chan struct{}, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			posted <- struct{}{}
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer server.Close()
+
+		NewPool("test", 0).Send(server.URL, []byte(`{}`))
+		Eventually(posted).Should(Receive())
+		Consistently(redirected, 500*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("bounds the number of the webhooks", func() {
+		maxSinks := 4
+		pool := NewPool("test", maxSinks)
+		pool.mutex.Lock()
+		defer pool.mutex.Unlock()
+		for i := 0; i < 2*maxSinks; i++ {
+			pool.getSink(fmt.Sprintf("http://127.0.0.1:1/hook-%d", i))
+		}
+		Expect(pool.sinks).Should(HaveLen(maxSinks))
+		Expect(pool.sinks).ShouldNot(HaveKey("http://127.0.0.1:1/hook-0"))
+		Expect(pool.sinks).Should(HaveKey(fmt.Sprintf("http://127.0.0.1:1/hook-%d", 2*maxSinks-1)))
+	})
+})
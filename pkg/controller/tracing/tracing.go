@@ -0,0 +1,135 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	tracerName  = "github.com/apecloud/kubeblocks"
+	serviceName = "kubeblocks"
+
+	// annotationKeyPrefix is the prefix of the annotation keys to propagate the trace context into the spawned objects,
+	// e.g., tracing.kubeblocks.io/traceparent, following the W3C trace context.
+	annotationKeyPrefix = "tracing.kubeblocks.io/"
+)
+
+// Setup enables the tracing if the OTLP endpoint is configured, and the spans are exported to it by gRPC.
+// It returns a func to flush the spans and shutdown the exporter, which is a no-op if the tracing is disabled.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	endpoint := viper.GetString(constant.CfgKeyTracingOTLPEndpoint)
+	if len(endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if viper.GetBool(constant.CfgKeyTracingOTLPInsecure) {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ratio := 1.0
+	if viper.IsSet(constant.CfgKeyTracingSampleRatio) {
+		ratio = viper.GetFloat64(constant.CfgKeyTracingSampleRatio)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span as the child of the one in ctx, it's a no-op span if the tracing is disabled.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartReconcileSpan starts the span of a reconciliation, the spans started in the reconciliation are its children.
+func StartReconcileSpan(ctx context.Context, controller string, req ctrl.Request) (context.Context, trace.Span) {
+	return StartSpan(ctx, controller+".Reconcile",
+		attribute.String("kubeblocks.controller", controller),
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("k8s.object.name", req.Name))
+}
+
+// EndSpan records the err if any, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// InjectAnnotations propagates the trace context in ctx into the annotations of obj,
+// so the spans of the spawned objects can be joined into the trace of the reconciliation.
+func InjectAnnotations(ctx context.Context, obj metav1.Object) {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range carrier {
+		annotations[annotationKeyPrefix+key] = value
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// InjectJob propagates the trace context in ctx into the annotations of the job and its pods.
+func InjectJob(ctx context.Context, job *batchv1.Job) {
+	InjectAnnotations(ctx, job)
+	InjectAnnotations(ctx, &job.Spec.Template)
+}
+
+// ExtractAnnotations returns a copy of ctx carrying the trace context propagated in the annotations of obj.
+func ExtractAnnotations(ctx context.Context, obj metav1.Object) context.Context {
+	carrier := propagation.MapCarrier{}
+	for key, value := range obj.GetAnnotations() {
+		if strings.HasPrefix(key, annotationKeyPrefix) {
+			carrier[strings.TrimPrefix(key, annotationKeyPrefix)] = value
+		}
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("tracing", func() {
+	var (
+		recorder *tracetest.SpanRecorder
+	)
+
+	BeforeEach(func() {
+		recorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+
+	It("disables the tracing if no endpoint configured", func() {
+		viper.Set(constant.CfgKeyTracingOTLPEndpoint, "")
+		shutdown, err := Setup(context.Background())
+		Expect(err).Should(Succeed())
+		Expect(shutdown(context.Background())).Should(Succeed())
+	})
+
+	It("records the reconciliation spans", func() {
+		req := ctrl.Request{}
+		req.Namespace, req.Name = "default", "test"
+		ctx, span := StartReconcileSpan(context.Background(), "cluster", req)
+		_, child := StartSpan(ctx, "cluster.Build")
+		EndSpan(child, errors.New("build failed"))
+		EndSpan(span, nil)
+
+		spans := recorder.Ended()
+		Expect(spans).Should(HaveLen(2))
+		Expect(spans[0].Name()).Should(Equal("cluster.Build"))
+		Expect(spans[0].Status().Code).Should(Equal(codes.Error))
+		Expect(spans[0].Parent().SpanID()).Should(Equal(spans[1].SpanContext().SpanID()))
+		Expect(spans[1].Name()).Should(Equal("cluster.Reconcile"))
+		Expect(spans[1].Status().Code).Should(Equal(codes.Unset))
+	})
+
+	It("propagates the trace context into jobs", func() {
+		ctx, span := StartSpan(context.Background(), "cluster.Reconcile")
+		defer span.End()
+
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-job",
+			},
+		}
+		InjectJob(ctx, job)
+		Expect(job.Annotations).Should(HaveKey(annotationKeyPrefix + "traceparent"))
+		Expect(job.Spec.Template.Annotations).Should(HaveKey(annotationKeyPrefix + "traceparent"))
+
+		extracted := trace.SpanContextFromContext(ExtractAnnotations(context.Background(), &job.Spec.Template))
+		Expect(extracted.TraceID()).Should(Equal(span.SpanContext().TraceID()))
+		Expect(extracted.SpanID()).Should(Equal(span.SpanContext().SpanID()))
+	})
+
+	It("doesn't inject anything without the trace context", func() {
+		job := &batchv1.Job{}
+		InjectJob(context.Background(), job)
+		Expect(job.Annotations).Should(BeEmpty())
+	})
+})
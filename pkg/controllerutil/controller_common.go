@@ -180,11 +180,25 @@ func HandleCRDeletion(reqCtx RequestCtx,
 // ValidateReferenceCR validates existing referencing CRs, if exists, requeue reconcile after 30 seconds
 func ValidateReferenceCR(reqCtx RequestCtx, cli client.Client, obj client.Object,
 	labelKey string, recordEvent func(), objLists ...client.ObjectList) (*ctrl.Result, error) {
+	return validateReferenceCR(reqCtx, recordEvent, func(objList client.ObjectList) error {
+		return cli.List(reqCtx.Ctx, objList, client.MatchingLabels{labelKey: obj.GetName()}, client.Limit(1))
+	}, objLists...)
+}
+
+// ValidateReferenceCRByIndex is the same as ValidateReferenceCR, except that it looks up the referencing CRs
+// by the field index, and falls back to the labels if the index is not available.
+func ValidateReferenceCRByIndex(reqCtx RequestCtx, cli client.Client, obj client.Object,
+	field, labelKey string, recordEvent func(), objLists ...client.ObjectList) (*ctrl.Result, error) {
+	return validateReferenceCR(reqCtx, recordEvent, func(objList client.ObjectList) error {
+		return ListByIndex(reqCtx.Ctx, cli, objList, field, obj.GetName(), client.MatchingLabels{labelKey: obj.GetName()})
+	}, objLists...)
+}
+
+func validateReferenceCR(reqCtx RequestCtx, recordEvent func(),
+	list func(client.ObjectList) error, objLists ...client.ObjectList) (*ctrl.Result, error) {
 	for _, objList := range objLists {
 		// get referencing cr list
-		if err := cli.List(reqCtx.Ctx, objList,
-			client.MatchingLabels{labelKey: obj.GetName()}, client.Limit(1),
-		); err != nil {
+		if err := list(objList); err != nil {
 			return nil, err
 		}
 		if v, err := conversion.EnforcePtr(objList); err != nil {
@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// defaultReconcileBaseBackoff and defaultReconcileMaxBackoff are the per-item backoff of controller-runtime by default.
+	defaultReconcileBaseBackoff = 5 * time.Millisecond
+	defaultReconcileMaxBackoff  = 1000 * time.Second
+)
+
+// ReconcileWorkers returns the max concurrent reconciles of a controller, which is the value of the key if set,
+// otherwise the share of the global reconcile workers, and at least 1.
+func ReconcileWorkers(key, globalKey string, share float64) int {
+	workers := viper.GetInt(key)
+	if workers <= 0 {
+		workers = int(math.Ceil(viper.GetFloat64(globalKey) * share))
+	}
+	return max(workers, 1)
+}
+
+// NewReconcileRateLimiter returns the rate limiter of the controllers, the failed requests are requeued with the
+// exponential backoff between RECONCILE_BASE_BACKOFF and RECONCILE_MAX_BACKOFF, and the overall requeue rate is
+// limited as controller-runtime does by default.
+func NewReconcileRateLimiter() ratelimiter.RateLimiter {
+	baseBackoff := viper.GetDuration(constant.CfgKeyReconcileBaseBackoff)
+	if baseBackoff <= 0 {
+		baseBackoff = defaultReconcileBaseBackoff
+	}
+	maxBackoff := viper.GetDuration(constant.CfgKeyReconcileMaxBackoff)
+	if maxBackoff < baseBackoff {
+		maxBackoff = max(defaultReconcileMaxBackoff, baseBackoff)
+	}
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(baseBackoff, maxBackoff),
+		// the same overall limit as workqueue.DefaultControllerRateLimiter
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
+
+// NewControllerOptions returns the controller options with the max concurrent reconciles of the key
+// and the rate limiter configured.
+func NewControllerOptions(key, globalKey string, share float64) controller.Options {
+	return controller.Options{
+		MaxConcurrentReconciles: ReconcileWorkers(key, globalKey, share),
+		RateLimiter:             NewReconcileRateLimiter(),
+	}
+}
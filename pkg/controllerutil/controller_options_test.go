@@ -0,0 +1,65 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestReconcileWorkers(t *testing.T) {
+	viper.Set(constant.CfgKBReconcileWorkers, 10)
+	defer viper.Set(constant.CfgKBReconcileWorkers, nil)
+	if workers := ReconcileWorkers(constant.CfgKeyClusterReconcileWorkers, constant.CfgKBReconcileWorkers, 0.25); workers != 3 {
+		t.Fatalf("expected a quarter of the global workers rounded up, got %d", workers)
+	}
+
+	viper.Set(constant.CfgKeyClusterReconcileWorkers, 6)
+	defer viper.Set(constant.CfgKeyClusterReconcileWorkers, nil)
+	if workers := ReconcileWorkers(constant.CfgKeyClusterReconcileWorkers, constant.CfgKBReconcileWorkers, 0.25); workers != 6 {
+		t.Fatalf("expected the workers of the controller, got %d", workers)
+	}
+
+	if workers := ReconcileWorkers(constant.CfgKeyOpsRequestReconcileWorkers, "NOT_SET_RECONCILE_WORKERS", 0.5); workers != 1 {
+		t.Fatalf("expected at least 1 worker, got %d", workers)
+	}
+}
+
+func TestNewReconcileRateLimiter(t *testing.T) {
+	viper.Set(constant.CfgKeyReconcileBaseBackoff, "1s")
+	viper.Set(constant.CfgKeyReconcileMaxBackoff, "4s")
+	defer func() {
+		viper.Set(constant.CfgKeyReconcileBaseBackoff, nil)
+		viper.Set(constant.CfgKeyReconcileMaxBackoff, nil)
+	}()
+	limiter := NewReconcileRateLimiter()
+	for i, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		if backoff := limiter.When("item"); backoff != expected {
+			t.Fatalf("expected backoff %s of retry %d, got %s", expected, i, backoff)
+		}
+	}
+	limiter.Forget("item")
+	if backoff := limiter.When("item"); backoff != time.Second {
+		t.Fatalf("expected the backoff reset to %s, got %s", time.Second, backoff)
+	}
+}
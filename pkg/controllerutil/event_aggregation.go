@@ -0,0 +1,251 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// DefaultEventAggregationWindow is the default window to aggregate the repeated events in.
+const DefaultEventAggregationWindow = 10 * time.Second
+
+// aggregatingEventSink wraps the event sink to aggregate the repeated events.
+//
+// The event correlator of client-go has counted the repeated events already, but each repeat is still
+// patched to the API server with the count increased. The sink writes the first patch of an event in a window
+// as-is, and holds the following ones till the window ends, then only the latest one is written, which carries
+// the count of all the repeats in the window.
+//
+// Only the count patches of the same Event object are throttled here. The events of different objects, e.g. the
+// role changes and deletions of each pod in a rollout, are combined by the aggregator of the event correlator
+// into one Event, see eventAggregatorByReasonFunc, then the count patches of it are throttled as well.
+type aggregatingEventSink struct {
+	sink   record.EventSink
+	window time.Duration
+
+	mu sync.Mutex
+	// lastWrites are the last time the events were written to the sink, keyed by the namespace/name of the event.
+	lastWrites map[string]time.Time
+	// pending are the latest patches held in the window, keyed by the namespace/name of the event.
+	pending   map[string]*pendingEventPatch
+	lastPrune time.Time
+}
+
+type pendingEventPatch struct {
+	event *corev1.Event
+	data  []byte
+}
+
+var _ record.EventSink = &aggregatingEventSink{}
+
+// NewAggregatingEventSink returns the sink aggregating the repeated events within the window into the sink,
+// the sink is returned as-is if the window is not positive.
+func NewAggregatingEventSink(sink record.EventSink, window time.Duration) record.EventSink {
+	if window <= 0 {
+		return sink
+	}
+	return &aggregatingEventSink{
+		sink:       sink,
+		window:     window,
+		lastWrites: map[string]time.Time{},
+		pending:    map[string]*pendingEventPatch{},
+		lastPrune:  time.Now(),
+	}
+}
+
+func eventKey(event *corev1.Event) string {
+	return event.Namespace + "/" + event.Name
+}
+
+func (s *aggregatingEventSink) Create(event *corev1.Event) (*corev1.Event, error) {
+	newEvent, err := s.sink.Create(event)
+	if err == nil {
+		s.written(eventKey(newEvent))
+	}
+	return newEvent, err
+}
+
+func (s *aggregatingEventSink) Update(event *corev1.Event) (*corev1.Event, error) {
+	newEvent, err := s.sink.Update(event)
+	if err == nil {
+		s.written(eventKey(newEvent))
+	}
+	return newEvent, err
+}
+
+func (s *aggregatingEventSink) Patch(event *corev1.Event, data []byte) (*corev1.Event, error) {
+	key := eventKey(event)
+	s.mu.Lock()
+	if _, held := s.pending[key]; held {
+		// replace the held one, the latest patch is written when the window ends
+		s.pending[key] = &pendingEventPatch{event: event, data: data}
+		s.mu.Unlock()
+		return event, nil
+	}
+	lastWrite, ok := s.lastWrites[key]
+	elapsed := time.Since(lastWrite)
+	if !ok || elapsed >= s.window {
+		s.mu.Unlock()
+		newEvent, err := s.sink.Patch(event, data)
+		if err == nil {
+			s.written(key)
+		}
+		return newEvent, err
+	}
+	s.pending[key] = &pendingEventPatch{event: event, data: data}
+	time.AfterFunc(s.window-elapsed, func() { s.flush(key) })
+	s.mu.Unlock()
+	// the event correlator caches the returned event, which is the same as written to the server later.
+	return event, nil
+}
+
+// flush writes the latest patch of the event held in the window.
+func (s *aggregatingEventSink) flush(key string) {
+	s.mu.Lock()
+	patch, ok := s.pending[key]
+	delete(s.pending, key)
+	// start the next window before writing, the following repeats are held rather than racing with this one
+	s.lastWrites[key] = time.Now()
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	if _, err := s.sink.Patch(patch.event, patch.data); err != nil {
+		ctrl.Log.WithName("events").Error(err, "failed to write the aggregated event", "event", key,
+			"reason", patch.event.Reason, "count", patch.event.Count)
+	}
+}
+
+// flushAll writes the latest patches of all the events held, no repeats are aggregated for them any more.
+func (s *aggregatingEventSink) flushAll() {
+	s.mu.Lock()
+	keys := make([]string, 0, len(s.pending))
+	for key := range s.pending {
+		keys = append(keys, key)
+	}
+	s.mu.Unlock()
+	for _, key := range keys {
+		s.flush(key)
+	}
+}
+
+func (s *aggregatingEventSink) written(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.lastWrites[key] = now
+	// prune the events not written in the last window, no more repeats to aggregate for them.
+	if now.Sub(s.lastPrune) < s.window {
+		return
+	}
+	for k, t := range s.lastWrites {
+		if _, held := s.pending[k]; !held && now.Sub(t) >= s.window {
+			delete(s.lastWrites, k)
+		}
+	}
+	s.lastPrune = now
+}
+
+// aggregatingEventBroadcaster records the events to the sinks aggregating the repeated events.
+type aggregatingEventBroadcaster struct {
+	record.EventBroadcaster
+	window time.Duration
+
+	mu    sync.Mutex
+	sinks []*aggregatingEventSink
+}
+
+func (b *aggregatingEventBroadcaster) StartRecordingToSink(sink record.EventSink) watch.Interface {
+	aggregatingSink := NewAggregatingEventSink(sink, b.window)
+	if s, ok := aggregatingSink.(*aggregatingEventSink); ok {
+		b.mu.Lock()
+		b.sinks = append(b.sinks, s)
+		b.mu.Unlock()
+	}
+	return b.EventBroadcaster.StartRecordingToSink(aggregatingSink)
+}
+
+// Shutdown stops the broadcaster and writes the repeats held in the window, which are lost otherwise.
+func (b *aggregatingEventBroadcaster) Shutdown() {
+	b.EventBroadcaster.Shutdown()
+	b.mu.Lock()
+	sinks := b.sinks
+	b.mu.Unlock()
+	for _, s := range sinks {
+		s.flushAll()
+	}
+}
+
+// NewEventBroadcaster returns the event broadcaster for the manager, which aggregates the repeated events
+// within EVENT_AGGREGATION_WINDOW to prevent flooding the API server and etcd with the events in big rollouts,
+// it returns nil to use the default one of the manager if the aggregation is disabled. The manager doesn't stop
+// the broadcaster given, call Shutdown after the manager stops to write the repeats held.
+func NewEventBroadcaster() record.EventBroadcaster {
+	window := DefaultEventAggregationWindow
+	if viper.IsSet(constant.CfgKeyEventAggregationWindow) {
+		window = viper.GetDuration(constant.CfgKeyEventAggregationWindow)
+	}
+	if window <= 0 {
+		return nil
+	}
+	return &aggregatingEventBroadcaster{
+		EventBroadcaster: record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{
+			KeyFunc:              eventAggregatorByReasonFunc,
+			MessageFunc:          eventAggregatorByReasonMessageFunc,
+			MaxIntervalInSeconds: int(math.Ceil(window.Seconds())),
+		}),
+		window: window,
+	}
+}
+
+// eventAggregatorByReasonFunc groups the events by the source, the kind and namespace of the involved objects
+// and the reason, rather than by each involved object as record.EventAggregatorByReasonFunc does. The similar
+// events of different objects within the window, e.g. the role changes of all the pods in a rollout, are
+// combined into one Event once there are too many of them.
+func eventAggregatorByReasonFunc(event *corev1.Event) (string, string) {
+	return strings.Join([]string{
+		event.Source.Component,
+		event.Source.Host,
+		event.InvolvedObject.Kind,
+		event.InvolvedObject.Namespace,
+		event.InvolvedObject.APIVersion,
+		event.Type,
+		event.Reason,
+		event.ReportingController,
+		event.ReportingInstance,
+	}, ""), event.InvolvedObject.Name + "/" + event.Message
+}
+
+// eventAggregatorByReasonMessageFunc returns the message of the combined event, which is recorded on the latest
+// involved object, so the message tells the events are combined from the objects of the kind.
+func eventAggregatorByReasonMessageFunc(event *corev1.Event) string {
+	return "(combined from similar events of " + event.InvolvedObject.Kind + "s): " + event.Message
+}
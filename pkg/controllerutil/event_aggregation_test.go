@@ -0,0 +1,197 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+type fakeEventSink struct {
+	mu      sync.Mutex
+	creates int
+	patches []int32
+}
+
+func (s *fakeEventSink) Create(event *corev1.Event) (*corev1.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creates++
+	return event, nil
+}
+
+func (s *fakeEventSink) Update(event *corev1.Event) (*corev1.Event, error) {
+	return event, nil
+}
+
+func (s *fakeEventSink) Patch(event *corev1.Event, _ []byte) (*corev1.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.patches = append(s.patches, event.Count)
+	return event, nil
+}
+
+func (s *fakeEventSink) patched() []int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int32{}, s.patches...)
+}
+
+func TestAggregatingEventSink(t *testing.T) {
+	const window = 100 * time.Millisecond
+	fake := &fakeEventSink{}
+	sink := NewAggregatingEventSink(fake, window)
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-mysql-0.17a1"},
+		Reason:     "RoleChanged",
+		Count:      1,
+	}
+	if _, err := sink.Create(event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the repeats within the window are held
+	for i := int32(2); i <= 5; i++ {
+		repeat := event.DeepCopy()
+		repeat.Count = i
+		if _, err := sink.Patch(repeat, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if patches := fake.patched(); len(patches) != 0 {
+		t.Fatalf("expected the repeats held within the window, got patches %v", patches)
+	}
+
+	// only the latest is written when the window ends
+	time.Sleep(2 * window)
+	if patches := fake.patched(); len(patches) != 1 || patches[0] != 5 {
+		t.Fatalf("expected the latest repeat written once, got patches %v", patches)
+	}
+
+	// the repeat after the window is written directly
+	time.Sleep(window)
+	repeat := event.DeepCopy()
+	repeat.Count = 6
+	if _, err := sink.Patch(repeat, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patches := fake.patched(); len(patches) != 2 || patches[1] != 6 {
+		t.Fatalf("expected the repeat after the window written directly, got patches %v", patches)
+	}
+	if fake.creates != 1 {
+		t.Fatalf("expected the event created once, got %d", fake.creates)
+	}
+
+	if NewAggregatingEventSink(fake, 0) != record.EventSink(fake) {
+		t.Fatalf("expected the sink as-is if the aggregation disabled")
+	}
+}
+
+func TestNewEventBroadcaster(t *testing.T) {
+	if NewEventBroadcaster() == nil {
+		t.Fatalf("expected the aggregation enabled by default")
+	}
+	viper.Set(constant.CfgKeyEventAggregationWindow, "0s")
+	defer viper.Set(constant.CfgKeyEventAggregationWindow, nil)
+	if NewEventBroadcaster() != nil {
+		t.Fatalf("expected the default broadcaster if the aggregation disabled")
+	}
+}
+
+func TestEventBroadcasterShutdown(t *testing.T) {
+	viper.Set(constant.CfgKeyEventAggregationWindow, "1h")
+	defer viper.Set(constant.CfgKeyEventAggregationWindow, nil)
+	broadcaster := NewEventBroadcaster().(*aggregatingEventBroadcaster)
+	fake := &fakeEventSink{}
+	broadcaster.StartRecordingToSink(fake)
+	sink := broadcaster.sinks[0]
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-mysql-0.17a1"},
+		Reason:     "RoleChanged",
+		Count:      1,
+	}
+	if _, err := sink.Create(event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repeat := event.DeepCopy()
+	repeat.Count = 2
+	if _, err := sink.Patch(repeat, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patches := fake.patched(); len(patches) != 0 {
+		t.Fatalf("expected the repeat held within the window, got patches %v", patches)
+	}
+
+	// the repeats held are written when the broadcaster is stopped
+	broadcaster.Shutdown()
+	if patches := fake.patched(); len(patches) != 1 || patches[0] != 2 {
+		t.Fatalf("expected the repeat held written on shutdown, got patches %v", patches)
+	}
+}
+
+func TestEventBroadcasterAggregateObjects(t *testing.T) {
+	viper.Set(constant.CfgKeyEventAggregationWindow, "1h")
+	defer viper.Set(constant.CfgKeyEventAggregationWindow, nil)
+	broadcaster := NewEventBroadcaster()
+	fake := &fakeEventSink{}
+	broadcaster.StartRecordingToSink(fake)
+	defer broadcaster.Shutdown()
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "kubeblocks"})
+
+	// the role changes of the pods in a rollout
+	for i := 0; i < 20; i++ {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("mycluster-mysql-%d", i)}}
+		recorder.Event(pod, corev1.EventTypeNormal, "RoleChanged", "role changed to secondary")
+	}
+
+	// the events following the first ones are combined into one Event, whose count patches are held in the window
+	const expectedCreates = 10
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		fake.mu.Lock()
+		creates := fake.creates
+		fake.mu.Unlock()
+		if creates == expectedCreates {
+			break
+		}
+		if creates > expectedCreates || time.Now().After(deadline) {
+			t.Fatalf("expected %d events created, got %d", expectedCreates, creates)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if fake.creates != expectedCreates || len(fake.patches) != 0 {
+		t.Fatalf("expected %d events created without patches, got %d created and patches %v",
+			expectedCreates, fake.creates, fake.patches)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ExpectationsTimeout is how long the expectations are waited for, after that they are considered as satisfied,
+// in case of the events are missed by the informer, the same as the built-in controllers.
+const ExpectationsTimeout = 5 * time.Minute
+
+// PodExpectations records the pods created and deleted by the controllers, keyed by component.
+var PodExpectations = NewExpectations(ExpectationsTimeout)
+
+// Expectations records the creations and deletions of objects issued by the controllers, keyed by the owner,
+// so the next reconciliation can hold back the actions until the informer cache has observed them,
+// rather than acting on the stale cache and issuing them again.
+// The creations are tracked by the object names and the deletions by the object UIDs.
+type Expectations struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	items map[string]*expectation
+}
+
+type expectation struct {
+	creations sets.Set[string]
+	deletions sets.Set[types.UID]
+	timestamp time.Time
+}
+
+// NewExpectations creates an Expectations whose items expire after ttl.
+func NewExpectations(ttl time.Duration) *Expectations {
+	return &Expectations{
+		ttl:   ttl,
+		items: map[string]*expectation{},
+	}
+}
+
+// ComponentExpectationKey returns the expectation key of a component.
+func ComponentExpectationKey(namespace, clusterName, compName string) string {
+	return types.NamespacedName{Namespace: namespace, Name: clusterName + "-" + compName}.String()
+}
+
+func (e *Expectations) getOrCreate(key string) *expectation {
+	item, ok := e.items[key]
+	if !ok {
+		item = &expectation{
+			creations: sets.New[string](),
+			deletions: sets.New[types.UID](),
+		}
+		e.items[key] = item
+	}
+	item.timestamp = time.Now()
+	return item
+}
+
+// ExpectCreations records the objects to be created, it should be called before the creations are issued.
+func (e *Expectations) ExpectCreations(key string, names ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.getOrCreate(key).creations.Insert(names...)
+}
+
+// ExpectDeletions records the objects to be deleted, it should be called before the deletions are issued.
+func (e *Expectations) ExpectDeletions(key string, uids ...types.UID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.getOrCreate(key).deletions.Insert(uids...)
+}
+
+// CreationObserved lowers the expected creations, it's also used when the creations failed.
+func (e *Expectations) CreationObserved(key string, names ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if item, ok := e.items[key]; ok {
+		item.creations.Delete(names...)
+	}
+}
+
+// DeletionObserved lowers the expected deletions, it's also used when the deletions failed.
+func (e *Expectations) DeletionObserved(key string, uids ...types.UID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if item, ok := e.items[key]; ok {
+		item.deletions.Delete(uids...)
+	}
+}
+
+// ObservePods lowers the expectations with the pods read from the cache:
+// the expected creations are observed if the pods are present,
+// and the expected deletions are observed if the pods are absent or being deleted.
+func (e *Expectations) ObservePods(key string, pods []corev1.Pod) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	item, ok := e.items[key]
+	if !ok {
+		return
+	}
+	present := sets.New[types.UID]()
+	for _, pod := range pods {
+		item.creations.Delete(pod.Name)
+		if pod.DeletionTimestamp == nil {
+			present.Insert(pod.UID)
+		}
+	}
+	item.deletions = item.deletions.Intersection(present)
+}
+
+// Satisfied returns true if all the expectations of key are observed or expired.
+func (e *Expectations) Satisfied(key string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	item, ok := e.items[key]
+	if !ok {
+		return true
+	}
+	if (item.creations.Len() == 0 && item.deletions.Len() == 0) || time.Since(item.timestamp) > e.ttl {
+		delete(e.items, key)
+		return true
+	}
+	return false
+}
+
+// Pending returns the names of the pods expected to be created and the uids of the pods expected to be deleted
+// but not observed yet, without expiring them.
+func (e *Expectations) Pending(key string) ([]string, []types.UID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	item, ok := e.items[key]
+	if !ok {
+		return nil, nil
+	}
+	return sets.List(item.creations), sets.List(item.deletions)
+}
+
+// Delete removes the expectations of key, it should be called when the owner is deleted.
+func (e *Expectations) Delete(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.items, key)
+}
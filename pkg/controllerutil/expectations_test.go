@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestExpectations(t *testing.T) {
+	newPod := func(name string, uid types.UID, deleting bool) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				UID:  uid,
+			},
+		}
+		if deleting {
+			now := metav1.Now()
+			pod.DeletionTimestamp = &now
+		}
+		return pod
+	}
+	key := ComponentExpectationKey("default", "test", "mysql")
+	if key != "default/test-mysql" {
+		t.Fatalf("unexpected expectation key: %s", key)
+	}
+
+	expectations := NewExpectations(ExpectationsTimeout)
+	if !expectations.Satisfied(key) {
+		t.Fatalf("expected satisfied without any expectation")
+	}
+
+	expectations.ExpectDeletions(key, "uid-0", "uid-1")
+	expectations.ExpectCreations(key, "pod-2")
+	if expectations.Satisfied(key) {
+		t.Fatalf("expected not satisfied before observed")
+	}
+
+	// the stale cache: pod-0 is being deleted, pod-1 is still present and pod-2 is absent
+	expectations.ObservePods(key, []corev1.Pod{newPod("pod-0", "uid-0", true), newPod("pod-1", "uid-1", false)})
+	if expectations.Satisfied(key) {
+		t.Fatalf("expected not satisfied with the stale cache")
+	}
+	if creations, deletions := expectations.Pending(key); len(creations) != 1 || len(deletions) != 1 || deletions[0] != "uid-1" {
+		t.Fatalf("unexpected pending expectations: %v, %v", creations, deletions)
+	}
+
+	// the deletion of pod-1 failed
+	expectations.DeletionObserved(key, "uid-1")
+	expectations.ObservePods(key, []corev1.Pod{newPod("pod-1", "uid-1", false), newPod("pod-2", "uid-2", false)})
+	if !expectations.Satisfied(key) {
+		t.Fatalf("expected satisfied after all observed")
+	}
+
+	// the expectations expire
+	expectations = NewExpectations(time.Millisecond)
+	expectations.ExpectCreations(key, "pod-3")
+	time.Sleep(2 * time.Millisecond)
+	if !expectations.Satisfied(key) {
+		t.Fatalf("expected satisfied after expired")
+	}
+
+	expectations.ExpectCreations(key, "pod-3")
+	expectations.Delete(key)
+	if !expectations.Satisfied(key) {
+		t.Fatalf("expected satisfied after deleted")
+	}
+}
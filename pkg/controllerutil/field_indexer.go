@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	// PodOwnerStatefulSetField is the field index of the pods by the name of the StatefulSet which controls them.
+	PodOwnerStatefulSetField = "metadata.ownerReferences.statefulSet"
+
+	// PodNodeNameField is the field index of the pods by the name of the node which they are scheduled to.
+	PodNodeNameField = "spec.nodeName"
+
+	// ClusterDefRefField is the field index of the ClusterVersions by the referenced ClusterDefinition.
+	// The Clusters are not indexed since they are not cached, see GetUncachedObjects.
+	ClusterDefRefField = "spec.clusterDefinitionRef"
+)
+
+// RegisterFieldIndexes registers the field indexes to the cache of the manager, which are used to look up
+// the objects without listing and filtering all of them by labels.
+func RegisterFieldIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &corev1.Pod{}, PodOwnerStatefulSetField, func(obj client.Object) []string {
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil || owner.Kind != "StatefulSet" || owner.APIVersion != appsv1.SchemeGroupVersion.String() {
+			return nil
+		}
+		return []string{owner.Name}
+	}); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &corev1.Pod{}, PodNodeNameField, func(obj client.Object) []string {
+		if nodeName := obj.(*corev1.Pod).Spec.NodeName; len(nodeName) > 0 {
+			return []string{nodeName}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return indexer.IndexField(ctx, &appsv1alpha1.ClusterVersion{}, ClusterDefRefField, func(obj client.Object) []string {
+		return []string{obj.(*appsv1alpha1.ClusterVersion).Spec.ClusterDefinitionRef}
+	})
+}
+
+// ListByIndex lists the objects by the field index, and falls back to list them by labels if the index is not
+// available, e.g., the client is not backed by the cache of the manager.
+func ListByIndex(ctx context.Context, cli client.Reader, list client.ObjectList, field, value string,
+	labels client.MatchingLabels, opts ...client.ListOption) error {
+	if err := cli.List(ctx, list, append([]client.ListOption{client.MatchingFields{field: value}}, opts...)...); err == nil {
+		return nil
+	}
+	return cli.List(ctx, list, append([]client.ListOption{labels}, opts...)...)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("field indexer test", func() {
+	var (
+		prefix    string
+		labels    client.MatchingLabels
+		cachedCli client.Client
+		stopCache context.CancelFunc
+	)
+
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+	}
+
+	createPod := func(name, owner, app string) {
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: prefix + owner, UID: types.UID(prefix + owner)},
+		}
+		testapps.NewPodFactory(testCtx.DefaultNamespace, prefix+name).
+			AddLabels("app", prefix+app).
+			SetOwnerReferences(appsv1.SchemeGroupVersion.String(), "StatefulSet", sts).
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+			AddNodeName(prefix + "node-" + owner).
+			Create(&testCtx)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		prefix = testCtx.GetRandomStr() + "-"
+		labels = client.MatchingLabels{"app": prefix + "test"}
+		createPod("sts1-0", "sts1", "test")
+		createPod("sts1-1", "sts1", "test")
+		createPod("sts2-0", "sts2", "test")
+		createPod("sts3-0", "sts3", "other")
+
+		By("start a cache with the field indexes registered")
+		informerCache, err := cache.New(cfg, cache.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).Should(Succeed())
+		Expect(RegisterFieldIndexes(ctx, informerCache)).Should(Succeed())
+		var cacheCtx context.Context
+		cacheCtx, stopCache = context.WithCancel(ctx)
+		go func() {
+			defer GinkgoRecover()
+			Expect(informerCache.Start(cacheCtx)).Should(Succeed())
+		}()
+		Expect(informerCache.WaitForCacheSync(cacheCtx)).Should(BeTrue())
+		cachedCli, err = client.New(cfg, client.Options{
+			Scheme: k8sClient.Scheme(),
+			Cache:  &client.CacheOptions{Reader: informerCache},
+		})
+		Expect(err).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		stopCache()
+		cleanEnv()
+	})
+
+	It("lists the pods by the owner index", func() {
+		Eventually(func(g Gomega) {
+			podList := &corev1.PodList{}
+			g.Expect(ListByIndex(ctx, cachedCli, podList, PodOwnerStatefulSetField, prefix+"sts1", labels)).Should(Succeed())
+			g.Expect(podList.Items).Should(HaveLen(2))
+		}).Should(Succeed())
+	})
+
+	It("lists the pods by the node name index", func() {
+		Eventually(func(g Gomega) {
+			podList := &corev1.PodList{}
+			g.Expect(ListByIndex(ctx, cachedCli, podList, PodNodeNameField, prefix+"node-sts2", labels)).Should(Succeed())
+			g.Expect(podList.Items).Should(HaveLen(1))
+			g.Expect(podList.Items[0].Name).Should(Equal(prefix + "sts2-0"))
+		}).Should(Succeed())
+	})
+
+	It("falls back to the labels if the index is not available", func() {
+		podList := &corev1.PodList{}
+		Expect(ListByIndex(ctx, k8sClient, podList, PodOwnerStatefulSetField, prefix+"sts1", labels)).Should(Succeed())
+		Expect(podList.Items).Should(HaveLen(3))
+	})
+})
@@ -22,10 +22,13 @@ package controllerutil
 import (
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -39,7 +42,8 @@ var (
 
 func NewNamespacedControllerManagedBy(mgr manager.Manager) *builder.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
-		WithEventFilter(predicate.NewPredicateFuncs(namespacePredicateFilter))
+		WithEventFilter(predicate.NewPredicateFuncs(namespacePredicateFilter)).
+		WithEventFilter(predicate.NewPredicateFuncs(shardPredicateFilter))
 }
 
 func namespacePredicateFilter(object client.Object) bool {
@@ -56,3 +60,145 @@ func namespacePredicateFilter(object client.Object) bool {
 	}
 	return managedNamespaces.Has(object.GetNamespace())
 }
+
+// NewPodChangedPredicate filters out the pod updates which make no difference to the reconciliation, such as the status
+// heartbeats, the resourceVersion-only updates and the changes of the irrelevant labels and annotations. Only the deletion,
+// the changes of spec, phase, readiness and container states, and the changes of the given labels and annotations are passed.
+func NewPodChangedPredicate(keys ...string) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return true
+			}
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+			if !ok {
+				return true
+			}
+			return isPodChanged(oldPod, newPod, keys)
+		},
+	}
+}
+
+// NodeSchedulableChangedPredicate passes the node events only if the node is cordoned or uncordoned.
+var NodeSchedulableChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return true
+		}
+		newNode, ok := e.ObjectNew.(*corev1.Node)
+		if !ok {
+			return true
+		}
+		return oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable
+	},
+}
+
+// NodeReadyChangedPredicate passes the node events only if the Ready condition of the node is changed.
+var NodeReadyChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldNode, ok := e.ObjectOld.(*corev1.Node)
+		if !ok {
+			return true
+		}
+		newNode, ok := e.ObjectNew.(*corev1.Node)
+		if !ok {
+			return true
+		}
+		return nodeReadyStatus(oldNode) != nodeReadyStatus(newNode)
+	},
+}
+
+// PodReadyOrRoleChangedPredicate passes the pod events only if the readiness or the role of the pod is changed,
+// or the pod is deleted. The creation of a pod is filtered since it is neither ready nor assigned a role yet.
+var PodReadyOrRoleChangedPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, ok := e.ObjectOld.(*corev1.Pod)
+		if !ok {
+			return true
+		}
+		newPod, ok := e.ObjectNew.(*corev1.Pod)
+		if !ok {
+			return true
+		}
+		return podReadyStatus(oldPod) != podReadyStatus(newPod) ||
+			oldPod.Labels[constant.RoleLabelKey] != newPod.Labels[constant.RoleLabelKey]
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+func podReadyStatus(pod *corev1.Pod) corev1.ConditionStatus {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+func nodeReadyStatus(node *corev1.Node) corev1.ConditionStatus {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+func isPodChanged(oldPod, newPod *corev1.Pod, keys []string) bool {
+	if oldPod.DeletionTimestamp.IsZero() != newPod.DeletionTimestamp.IsZero() || oldPod.Generation != newPod.Generation {
+		return true
+	}
+	for _, key := range keys {
+		if oldPod.Labels[key] != newPod.Labels[key] || oldPod.Annotations[key] != newPod.Annotations[key] {
+			return true
+		}
+	}
+	if !equality.Semantic.DeepEqual(oldPod.Spec, newPod.Spec) {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(digestPodStatus(&oldPod.Status), digestPodStatus(&newPod.Status))
+}
+
+// digestPodStatus strips the timestamps and messages from the pod status, which change without state transitions.
+func digestPodStatus(status *corev1.PodStatus) *corev1.PodStatus {
+	digest := &corev1.PodStatus{
+		Phase:  status.Phase,
+		PodIP:  status.PodIP,
+		Resize: status.Resize,
+	}
+	for _, cond := range status.Conditions {
+		digest.Conditions = append(digest.Conditions, corev1.PodCondition{Type: cond.Type, Status: cond.Status})
+	}
+	digestContainerStatuses := func(statuses []corev1.ContainerStatus) []corev1.ContainerStatus {
+		var digests []corev1.ContainerStatus
+		for _, s := range statuses {
+			d := corev1.ContainerStatus{
+				Name:               s.Name,
+				Ready:              s.Ready,
+				RestartCount:       s.RestartCount,
+				AllocatedResources: s.AllocatedResources,
+				Resources:          s.Resources,
+			}
+			switch {
+			case s.State.Waiting != nil:
+				d.State.Waiting = &corev1.ContainerStateWaiting{Reason: s.State.Waiting.Reason}
+			case s.State.Running != nil:
+				d.State.Running = &corev1.ContainerStateRunning{}
+			case s.State.Terminated != nil:
+				d.State.Terminated = &corev1.ContainerStateTerminated{Reason: s.State.Terminated.Reason, ExitCode: s.State.Terminated.ExitCode}
+			}
+			digests = append(digests, d)
+		}
+		return digests
+	}
+	digest.InitContainerStatuses = digestContainerStatuses(status.InitContainerStatuses)
+	digest.ContainerStatuses = digestContainerStatuses(status.ContainerStatuses)
+	return digest
+}
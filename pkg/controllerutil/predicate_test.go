@@ -0,0 +1,227 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestPodChangedPredicate(t *testing.T) {
+	oldPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-pod",
+			ResourceVersion: "1",
+			Labels:          map[string]string{constant.RoleLabelKey: "leader"},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{
+				Type:          corev1.PodReady,
+				Status:        corev1.ConditionTrue,
+				LastProbeTime: metav1.Now(),
+			}},
+		},
+	}
+	p := NewPodChangedPredicate(constant.RoleLabelKey)
+
+	tests := []struct {
+		name   string
+		mutate func(pod *corev1.Pod)
+		expect bool
+	}{
+		{
+			name: "resourceVersion only",
+			mutate: func(pod *corev1.Pod) {
+				pod.ResourceVersion = "2"
+			},
+			expect: false,
+		},
+		{
+			name: "status heartbeat",
+			mutate: func(pod *corev1.Pod) {
+				pod.Status.Conditions[0].LastProbeTime = metav1.NewTime(time.Now().Add(time.Minute))
+			},
+			expect: false,
+		},
+		{
+			name: "irrelevant label",
+			mutate: func(pod *corev1.Pod) {
+				pod.Labels["foo"] = "bar"
+			},
+			expect: false,
+		},
+		{
+			name: "role label",
+			mutate: func(pod *corev1.Pod) {
+				pod.Labels[constant.RoleLabelKey] = "follower"
+			},
+			expect: true,
+		},
+		{
+			name: "readiness",
+			mutate: func(pod *corev1.Pod) {
+				pod.Status.Conditions[0].Status = corev1.ConditionFalse
+			},
+			expect: true,
+		},
+		{
+			name: "deletion",
+			mutate: func(pod *corev1.Pod) {
+				now := metav1.Now()
+				pod.DeletionTimestamp = &now
+			},
+			expect: true,
+		},
+	}
+	for _, tt := range tests {
+		newPod := oldPod.DeepCopy()
+		tt.mutate(newPod)
+		if got := p.Update(event.UpdateEvent{ObjectOld: oldPod, ObjectNew: newPod}); got != tt.expect {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestNodeReadyChangedPredicate(t *testing.T) {
+	oldNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node", ResourceVersion: "1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{
+				Type:              corev1.NodeReady,
+				Status:            corev1.ConditionTrue,
+				LastHeartbeatTime: metav1.Now(),
+			}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(node *corev1.Node)
+		expect bool
+	}{
+		{
+			name: "heartbeat",
+			mutate: func(node *corev1.Node) {
+				node.ResourceVersion = "2"
+				node.Status.Conditions[0].LastHeartbeatTime = metav1.NewTime(time.Now().Add(time.Minute))
+			},
+			expect: false,
+		},
+		{
+			name: "not ready",
+			mutate: func(node *corev1.Node) {
+				node.Status.Conditions[0].Status = corev1.ConditionFalse
+			},
+			expect: true,
+		},
+		{
+			name: "unknown",
+			mutate: func(node *corev1.Node) {
+				node.Status.Conditions[0].Status = corev1.ConditionUnknown
+			},
+			expect: true,
+		},
+	}
+	for _, tt := range tests {
+		newNode := oldNode.DeepCopy()
+		tt.mutate(newNode)
+		if got := NodeReadyChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: newNode}); got != tt.expect {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
+
+func TestNodeSchedulableChangedPredicate(t *testing.T) {
+	oldNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node", ResourceVersion: "1"}}
+
+	heartbeat := oldNode.DeepCopy()
+	heartbeat.ResourceVersion = "2"
+	if NodeSchedulableChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: heartbeat}) {
+		t.Errorf("expected the heartbeat of the node to be filtered")
+	}
+	cordoned := oldNode.DeepCopy()
+	cordoned.Spec.Unschedulable = true
+	if !NodeSchedulableChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldNode, ObjectNew: cordoned}) {
+		t.Errorf("expected the cordon of the node to pass")
+	}
+}
+
+func TestPodReadyOrRoleChangedPredicate(t *testing.T) {
+	oldPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-pod",
+			ResourceVersion: "1",
+			Labels:          map[string]string{constant.RoleLabelKey: "leader"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(pod *corev1.Pod)
+		expect bool
+	}{
+		{
+			name: "restart",
+			mutate: func(pod *corev1.Pod) {
+				pod.ResourceVersion = "2"
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "test", RestartCount: 1}}
+			},
+			expect: false,
+		},
+		{
+			name: "not ready",
+			mutate: func(pod *corev1.Pod) {
+				pod.Status.Conditions[0].Status = corev1.ConditionFalse
+			},
+			expect: true,
+		},
+		{
+			name: "switchover",
+			mutate: func(pod *corev1.Pod) {
+				pod.Labels[constant.RoleLabelKey] = "follower"
+			},
+			expect: true,
+		},
+	}
+	for _, tt := range tests {
+		newPod := oldPod.DeepCopy()
+		tt.mutate(newPod)
+		if got := PodReadyOrRoleChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldPod, ObjectNew: newPod}); got != tt.expect {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+	if PodReadyOrRoleChangedPredicate.Create(event.CreateEvent{Object: oldPod}) {
+		t.Errorf("expected the creation of the pod to be filtered")
+	}
+	if !PodReadyOrRoleChangedPredicate.Delete(event.DeleteEvent{Object: oldPod}) {
+		t.Errorf("expected the deletion of the pod to pass")
+	}
+}
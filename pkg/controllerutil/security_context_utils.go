@@ -0,0 +1,152 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"encoding/json"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// GetDataPlaneSecurityContext gets the default security settings of the data plane workloads configured for the operator,
+// nil is returned if none is configured, e.g. the value is empty or '{}'.
+func GetDataPlaneSecurityContext() (*appsv1alpha1.SecurityContextDefaults, error) {
+	val := viper.GetString(constant.CfgKeyDataPlaneSecurityContext)
+	if val == "" {
+		return nil, nil
+	}
+	securityContext := &appsv1alpha1.SecurityContextDefaults{}
+	if err := json.Unmarshal([]byte(val), securityContext); err != nil {
+		return nil, err
+	}
+	if IsEmptySecurityContextDefaults(securityContext) {
+		return nil, nil
+	}
+	return securityContext, nil
+}
+
+// IsEmptySecurityContextDefaults checks whether none of the security settings is specified.
+func IsEmptySecurityContextDefaults(defaults *appsv1alpha1.SecurityContextDefaults) bool {
+	return defaults == nil || reflect.DeepEqual(*defaults, appsv1alpha1.SecurityContextDefaults{})
+}
+
+// ApplyDataPlaneSecurityContext applies the default security settings of the data plane to the pod of a job,
+// e.g. the backup, restore and ops jobs.
+func ApplyDataPlaneSecurityContext(podSpec *corev1.PodSpec) error {
+	defaults, err := GetDataPlaneSecurityContext()
+	if err != nil {
+		return err
+	}
+	ApplySecurityContextDefaults(podSpec, defaults)
+	return nil
+}
+
+// ApplySecurityContextDefaults applies the default security settings to the pod and all its containers,
+// the settings specified in the pod spec explicitly are retained. The pod spec is left unchanged if no setting is specified.
+func ApplySecurityContextDefaults(podSpec *corev1.PodSpec, defaults *appsv1alpha1.SecurityContextDefaults) {
+	if podSpec == nil || IsEmptySecurityContextDefaults(defaults) {
+		return
+	}
+	defaults = defaults.DeepCopy()
+	// the pod or containers which explicitly run as root, e.g. the backup and restore jobs, can't run as non-root.
+	if runAsRoot(podSpec) {
+		defaults.RunAsNonRoot = nil
+	}
+	if defaults.RunAsNonRoot != nil || defaults.RunAsUser != nil || defaults.RunAsGroup != nil ||
+		defaults.FSGroup != nil || defaults.SeccompProfile != nil {
+		if podSpec.SecurityContext == nil {
+			podSpec.SecurityContext = &corev1.PodSecurityContext{}
+		}
+		psc := podSpec.SecurityContext
+		if psc.RunAsNonRoot == nil {
+			psc.RunAsNonRoot = defaults.RunAsNonRoot
+		}
+		if psc.RunAsUser == nil {
+			psc.RunAsUser = defaults.RunAsUser
+		}
+		if psc.RunAsGroup == nil {
+			psc.RunAsGroup = defaults.RunAsGroup
+		}
+		if psc.FSGroup == nil {
+			psc.FSGroup = defaults.FSGroup
+		}
+		if psc.SeccompProfile == nil {
+			psc.SeccompProfile = defaults.SeccompProfile
+		}
+	}
+	if defaults.ReadOnlyRootFilesystem == nil && defaults.AllowPrivilegeEscalation == nil &&
+		(defaults.DropAllCapabilities == nil || !*defaults.DropAllCapabilities) {
+		return
+	}
+	for i := range podSpec.InitContainers {
+		applyContainerSecurityContextDefaults(&podSpec.InitContainers[i], defaults)
+	}
+	for i := range podSpec.Containers {
+		applyContainerSecurityContextDefaults(&podSpec.Containers[i], defaults)
+	}
+}
+
+func runAsRoot(podSpec *corev1.PodSpec) bool {
+	isRoot := func(uid *int64) bool {
+		return uid != nil && *uid == 0
+	}
+	if podSpec.SecurityContext != nil && isRoot(podSpec.SecurityContext.RunAsUser) {
+		return true
+	}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for _, c := range containers {
+			if c.SecurityContext != nil && isRoot(c.SecurityContext.RunAsUser) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func applyContainerSecurityContextDefaults(container *corev1.Container, defaults *appsv1alpha1.SecurityContextDefaults) {
+	// the pointers are not shared between containers
+	defaults = defaults.DeepCopy()
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	sc := container.SecurityContext
+	if sc.ReadOnlyRootFilesystem == nil {
+		sc.ReadOnlyRootFilesystem = defaults.ReadOnlyRootFilesystem
+	}
+	if sc.Privileged != nil && *sc.Privileged {
+		return
+	}
+	if sc.AllowPrivilegeEscalation == nil {
+		sc.AllowPrivilegeEscalation = defaults.AllowPrivilegeEscalation
+	}
+	if defaults.DropAllCapabilities != nil && *defaults.DropAllCapabilities {
+		if sc.Capabilities == nil {
+			sc.Capabilities = &corev1.Capabilities{}
+		}
+		if len(sc.Capabilities.Drop) == 0 {
+			sc.Capabilities.Drop = []corev1.Capability{"ALL"}
+		}
+	}
+}
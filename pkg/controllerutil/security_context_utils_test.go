@@ -0,0 +1,153 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestGetDataPlaneSecurityContext(t *testing.T) {
+	defer viper.Set(constant.CfgKeyDataPlaneSecurityContext, "")
+	for _, val := range []string{"", "{}", `{"runAsUser":null}`} {
+		viper.Set(constant.CfgKeyDataPlaneSecurityContext, val)
+		securityContext, err := GetDataPlaneSecurityContext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if securityContext != nil {
+			t.Errorf("expect %q treated as unset, got: %v", val, securityContext)
+		}
+	}
+
+	viper.Set(constant.CfgKeyDataPlaneSecurityContext, `{"runAsNonRoot":true}`)
+	securityContext, err := GetDataPlaneSecurityContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if securityContext == nil || !*securityContext.RunAsNonRoot {
+		t.Errorf("expect runAsNonRoot, got: %v", securityContext)
+	}
+
+	viper.Set(constant.CfgKeyDataPlaneSecurityContext, "invalid")
+	if _, err = GetDataPlaneSecurityContext(); err == nil {
+		t.Error("expect an error of the invalid settings")
+	}
+}
+
+func TestApplySecurityContextDefaults(t *testing.T) {
+	newPodSpec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers: []corev1.Container{
+				{Name: "main"},
+				{
+					Name: "privileged",
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: pointer.Bool(true),
+					},
+				},
+			},
+		}
+	}
+
+	// the pod spec is left unchanged without any setting, so the existing workloads are not restarted.
+	for _, defaults := range []*appsv1alpha1.SecurityContextDefaults{nil, {}} {
+		podSpec := newPodSpec()
+		ApplySecurityContextDefaults(podSpec, defaults)
+		if !reflect.DeepEqual(newPodSpec(), podSpec) {
+			t.Errorf("expect the pod spec unchanged, got: %v", podSpec)
+		}
+	}
+
+	// only the container settings are applied.
+	podSpec := newPodSpec()
+	ApplySecurityContextDefaults(podSpec, &appsv1alpha1.SecurityContextDefaults{AllowPrivilegeEscalation: pointer.Bool(false)})
+	if podSpec.SecurityContext != nil {
+		t.Errorf("expect no pod security context, got: %v", podSpec.SecurityContext)
+	}
+	if !reflect.DeepEqual(pointer.Bool(false), podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation) {
+		t.Errorf("expect the privilege escalation disallowed, got: %v", podSpec.Containers[0].SecurityContext)
+	}
+
+	defaults := &appsv1alpha1.SecurityContextDefaults{
+		RunAsNonRoot:             pointer.Bool(true),
+		RunAsUser:                pointer.Int64(1001),
+		FSGroup:                  pointer.Int64(1001),
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		AllowPrivilegeEscalation: pointer.Bool(false),
+		DropAllCapabilities:      pointer.Bool(true),
+	}
+	podSpec = newPodSpec()
+	podSpec.SecurityContext = &corev1.PodSecurityContext{FSGroup: pointer.Int64(0)}
+	ApplySecurityContextDefaults(podSpec, defaults)
+
+	// the settings of the pod spec are retained.
+	psc := podSpec.SecurityContext
+	if *psc.FSGroup != 0 || *psc.RunAsUser != 1001 || !*psc.RunAsNonRoot || psc.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+		t.Errorf("unexpected pod security context: %v", psc)
+	}
+	// the settings are applied to the unprivileged containers.
+	for _, c := range []corev1.Container{podSpec.InitContainers[0], podSpec.Containers[0]} {
+		if *c.SecurityContext.AllowPrivilegeEscalation || !reflect.DeepEqual([]corev1.Capability{"ALL"}, c.SecurityContext.Capabilities.Drop) ||
+			c.SecurityContext.ReadOnlyRootFilesystem != nil {
+			t.Errorf("unexpected security context of container %s: %v", c.Name, c.SecurityContext)
+		}
+	}
+	if sc := podSpec.Containers[1].SecurityContext; sc.AllowPrivilegeEscalation != nil || sc.Capabilities != nil {
+		t.Errorf("expect the privileged container unchanged, got: %v", sc)
+	}
+
+	// the jobs running as root, e.g. the backup and restore jobs, are not forced to run as non-root.
+	podSpec = newPodSpec()
+	podSpec.Containers[0].SecurityContext = &corev1.SecurityContext{RunAsUser: pointer.Int64(0)}
+	ApplySecurityContextDefaults(podSpec, defaults)
+	if podSpec.SecurityContext.RunAsNonRoot != nil {
+		t.Errorf("expect runAsNonRoot unset for the pod running as root, got: %v", podSpec.SecurityContext)
+	}
+}
+
+func TestApplyDataPlaneSecurityContext(t *testing.T) {
+	defer viper.Set(constant.CfgKeyDataPlaneSecurityContext, "")
+
+	viper.Set(constant.CfgKeyDataPlaneSecurityContext, "{}")
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backup"}}}
+	if err := ApplyDataPlaneSecurityContext(podSpec); err != nil {
+		t.Fatal(err)
+	}
+	if podSpec.SecurityContext != nil || podSpec.Containers[0].SecurityContext != nil {
+		t.Errorf("expect the job pod unchanged, got: %v", podSpec)
+	}
+
+	viper.Set(constant.CfgKeyDataPlaneSecurityContext, `{"seccompProfile":{"type":"RuntimeDefault"}}`)
+	if err := ApplyDataPlaneSecurityContext(podSpec); err != nil {
+		t.Fatal(err)
+	}
+	if podSpec.SecurityContext == nil || podSpec.SecurityContext.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+		t.Errorf("expect the seccomp profile applied, got: %v", podSpec.SecurityContext)
+	}
+}
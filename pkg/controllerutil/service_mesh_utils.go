@@ -0,0 +1,116 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+const (
+	istioSidecarInjectAnnotationKey        = "sidecar.istio.io/inject"
+	istioExcludeInboundPortsAnnotationKey  = "traffic.sidecar.istio.io/excludeInboundPorts"
+	istioExcludeOutboundPortsAnnotationKey = "traffic.sidecar.istio.io/excludeOutboundPorts"
+	istioProxyConfigAnnotationKey          = "proxy.istio.io/config"
+
+	linkerdInjectAnnotationKey            = "linkerd.io/inject"
+	linkerdSkipInboundPortsAnnotationKey  = "config.linkerd.io/skip-inbound-ports"
+	linkerdSkipOutboundPortsAnnotationKey = "config.linkerd.io/skip-outbound-ports"
+	linkerdProxyAwaitAnnotationKey        = "config.linkerd.io/proxy-await"
+)
+
+// BuildServiceMeshPodAnnotations builds the annotations of the pods working with the sidecar proxy of the service mesh.
+// The container ports are excluded from the interception of the sidecar proxy, and the containers are held until the
+// sidecar proxy is ready.
+func BuildServiceMeshPodAnnotations(serviceMesh *appsv1alpha1.ServiceMeshCompatibility, podSpec *corev1.PodSpec) map[string]string {
+	if serviceMesh == nil || podSpec == nil {
+		return nil
+	}
+	ports := joinContainerPorts(podSpec)
+	annotations := map[string]string{
+		constant.ServiceMeshAnnotationKey: string(serviceMesh.Provider),
+	}
+	switch serviceMesh.Provider {
+	case appsv1alpha1.IstioServiceMesh:
+		annotations[istioProxyConfigAnnotationKey] = `{"holdApplicationUntilProxyStarts": true}`
+		if len(ports) > 0 {
+			annotations[istioExcludeInboundPortsAnnotationKey] = ports
+			if serviceMesh.DisableMTLS {
+				annotations[istioExcludeOutboundPortsAnnotationKey] = ports
+			}
+		}
+	case appsv1alpha1.LinkerdServiceMesh:
+		annotations[linkerdProxyAwaitAnnotationKey] = "enabled"
+		if len(ports) > 0 {
+			annotations[linkerdSkipInboundPortsAnnotationKey] = ports
+			if serviceMesh.DisableMTLS {
+				annotations[linkerdSkipOutboundPortsAnnotationKey] = ports
+			}
+		}
+	}
+	return annotations
+}
+
+// BuildServiceMeshJobAnnotations builds the annotations of the job pods to disable the injection of the sidecar proxy,
+// which never exits and blocks the job from completing. The provider is the value of the annotation
+// constant.ServiceMeshAnnotationKey on the pods of the cluster.
+func BuildServiceMeshJobAnnotations(provider string) map[string]string {
+	switch appsv1alpha1.ServiceMeshProvider(provider) {
+	case appsv1alpha1.IstioServiceMesh:
+		return map[string]string{istioSidecarInjectAnnotationKey: "false"}
+	case appsv1alpha1.LinkerdServiceMesh:
+		return map[string]string{linkerdInjectAnnotationKey: "disabled"}
+	}
+	return nil
+}
+
+// BuildClusterServiceMeshJobAnnotations builds the annotations of the job pods running against the cluster to disable
+// the injection of the sidecar proxy, if the cluster works with the service mesh.
+func BuildClusterServiceMeshJobAnnotations(cluster *appsv1alpha1.Cluster) map[string]string {
+	if cluster == nil || cluster.Spec.ServiceMesh == nil {
+		return nil
+	}
+	return BuildServiceMeshJobAnnotations(string(cluster.Spec.ServiceMesh.Provider))
+}
+
+func joinContainerPorts(podSpec *corev1.PodSpec) string {
+	ports := make([]int, 0)
+	exists := make(map[int32]bool)
+	for _, container := range podSpec.Containers {
+		for _, port := range container.Ports {
+			if !exists[port.ContainerPort] {
+				exists[port.ContainerPort] = true
+				ports = append(ports, int(port.ContainerPort))
+			}
+		}
+	}
+	sort.Ints(ports)
+	portStrs := make([]string, 0, len(ports))
+	for _, port := range ports {
+		portStrs = append(portStrs, strconv.Itoa(port))
+	}
+	return strings.Join(portStrs, ",")
+}
@@ -0,0 +1,89 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestBuildServiceMeshPodAnnotations(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: "mysql", Ports: []corev1.ContainerPort{{ContainerPort: 3306}, {ContainerPort: 13306}}},
+			{Name: "lorry", Ports: []corev1.ContainerPort{{ContainerPort: 3501}, {ContainerPort: 3306}}},
+		},
+	}
+	if annotations := BuildServiceMeshPodAnnotations(nil, podSpec); annotations != nil {
+		t.Errorf("expect no annotations without service mesh, got: %v", annotations)
+	}
+
+	annotations := BuildServiceMeshPodAnnotations(&appsv1alpha1.ServiceMeshCompatibility{Provider: appsv1alpha1.IstioServiceMesh}, podSpec)
+	expected := map[string]string{
+		constant.ServiceMeshAnnotationKey:     "Istio",
+		istioProxyConfigAnnotationKey:         `{"holdApplicationUntilProxyStarts": true}`,
+		istioExcludeInboundPortsAnnotationKey: "3306,3501,13306",
+	}
+	if !reflect.DeepEqual(expected, annotations) {
+		t.Errorf("expect annotations %v, got: %v", expected, annotations)
+	}
+
+	annotations = BuildServiceMeshPodAnnotations(&appsv1alpha1.ServiceMeshCompatibility{
+		Provider:    appsv1alpha1.LinkerdServiceMesh,
+		DisableMTLS: true,
+	}, podSpec)
+	expected = map[string]string{
+		constant.ServiceMeshAnnotationKey:     "Linkerd",
+		linkerdProxyAwaitAnnotationKey:        "enabled",
+		linkerdSkipInboundPortsAnnotationKey:  "3306,3501,13306",
+		linkerdSkipOutboundPortsAnnotationKey: "3306,3501,13306",
+	}
+	if !reflect.DeepEqual(expected, annotations) {
+		t.Errorf("expect annotations %v, got: %v", expected, annotations)
+	}
+}
+
+func TestBuildServiceMeshJobAnnotations(t *testing.T) {
+	if annotations := BuildServiceMeshJobAnnotations(""); annotations != nil {
+		t.Errorf("expect no annotations without service mesh, got: %v", annotations)
+	}
+	if annotations := BuildServiceMeshJobAnnotations("Istio"); annotations[istioSidecarInjectAnnotationKey] != "false" {
+		t.Errorf("expect the istio sidecar is not injected, got: %v", annotations)
+	}
+	if annotations := BuildServiceMeshJobAnnotations("Linkerd"); annotations[linkerdInjectAnnotationKey] != "disabled" {
+		t.Errorf("expect the linkerd proxy is not injected, got: %v", annotations)
+	}
+}
+
+func TestBuildClusterServiceMeshJobAnnotations(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{}
+	if annotations := BuildClusterServiceMeshJobAnnotations(cluster); annotations != nil {
+		t.Errorf("expect no annotations without service mesh, got: %v", annotations)
+	}
+	cluster.Spec.ServiceMesh = &appsv1alpha1.ServiceMeshCompatibility{Provider: appsv1alpha1.IstioServiceMesh}
+	if annotations := BuildClusterServiceMeshJobAnnotations(cluster); annotations[istioSidecarInjectAnnotationKey] != "false" {
+		t.Errorf("expect the istio sidecar is not injected, got: %v", annotations)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// the default node port range of Kubernetes
+const defaultNodePortRange = "30000-32767"
+
+// IsExposedService checks whether the service is accessible from outside the cluster.
+func IsExposedService(svcType corev1.ServiceType) bool {
+	return svcType == corev1.ServiceTypeLoadBalancer || svcType == corev1.ServiceTypeNodePort
+}
+
+// BuildExposedEndpoint builds the external endpoint of a LoadBalancer or NodePort service.
+// The nodeHosts are the hosts of the nodes to access the NodePort service.
+// The addresses are empty if they have not been allocated yet.
+func BuildExposedEndpoint(svc *corev1.Service, nodeHosts []string) appsv1alpha1.ExposedEndpoint {
+	endpoint := appsv1alpha1.ExposedEndpoint{
+		Name:        svc.Name,
+		ServiceType: svc.Spec.Type,
+	}
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeLoadBalancer:
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			host := ingress.IP
+			if host == "" {
+				host = ingress.Hostname
+			}
+			if host == "" {
+				continue
+			}
+			for _, port := range svc.Spec.Ports {
+				endpoint.Addresses = append(endpoint.Addresses, net.JoinHostPort(host, strconv.Itoa(int(port.Port))))
+			}
+		}
+	case corev1.ServiceTypeNodePort:
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort == 0 {
+				continue
+			}
+			if len(nodeHosts) == 0 {
+				endpoint.Addresses = append(endpoint.Addresses, fmt.Sprintf(":%d", port.NodePort))
+				continue
+			}
+			for _, host := range nodeHosts {
+				endpoint.Addresses = append(endpoint.Addresses, net.JoinHostPort(host, strconv.Itoa(int(port.NodePort))))
+			}
+		}
+	}
+	return endpoint
+}
+
+// GetNodeHost returns the external IP of the node, or the internal IP if the node has no external IP.
+func GetNodeHost(node *corev1.Node) string {
+	var host string
+	for _, addr := range node.Status.Addresses {
+		switch addr.Type {
+		case corev1.NodeExternalIP:
+			return addr.Address
+		case corev1.NodeInternalIP:
+			if host == "" {
+				host = addr.Address
+			}
+		}
+	}
+	return host
+}
+
+// AllocateDeterministicNodePorts sets the node ports which are not specified for the ports of the NodePort or
+// LoadBalancer service. The node ports already allocated to the service are kept, and the new ones are derived from
+// the hash of the namespace, the service name and the port name, or the next free port in the node port range
+// if the derived one is allocated to the other services.
+func AllocateDeterministicNodePorts(ctx context.Context, cli client.Reader, svc *corev1.Service) error {
+	if !IsExposedService(svc.Spec.Type) {
+		return nil
+	}
+	base, size, err := parseNodePortRange(viper.GetString(constant.CfgKeyServiceNodePortRange))
+	if err != nil {
+		return err
+	}
+	svcList := &corev1.ServiceList{}
+	if err = cli.List(ctx, svcList); err != nil {
+		return err
+	}
+	allocated := map[string]int32{}
+	used := map[int32]bool{}
+	for _, item := range svcList.Items {
+		for _, port := range item.Spec.Ports {
+			if port.NodePort == 0 {
+				continue
+			}
+			if item.Namespace == svc.Namespace && item.Name == svc.Name {
+				allocated[port.Name] = port.NodePort
+			} else {
+				used[port.NodePort] = true
+			}
+		}
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.NodePort != 0 {
+			used[port.NodePort] = true
+		}
+	}
+	for i, port := range svc.Spec.Ports {
+		if port.NodePort != 0 {
+			continue
+		}
+		if nodePort, ok := allocated[port.Name]; ok && !used[nodePort] {
+			svc.Spec.Ports[i].NodePort = nodePort
+			used[nodePort] = true
+			continue
+		}
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(fmt.Sprintf("%s/%s/%s/%d", svc.Namespace, svc.Name, port.Name, port.Port)))
+		offset := int32(h.Sum32() % uint32(size))
+		nodePort := int32(0)
+		for j := int32(0); j < size; j++ {
+			if candidate := base + (offset+j)%size; !used[candidate] {
+				nodePort = candidate
+				break
+			}
+		}
+		if nodePort == 0 {
+			return fmt.Errorf("no free node port in range %d-%d for the port %s of service %s", base, base+size-1, port.Name, svc.Name)
+		}
+		svc.Spec.Ports[i].NodePort = nodePort
+		used[nodePort] = true
+	}
+	return nil
+}
+
+// parseNodePortRange parses the node port range in the form of "30000-32767", it's the default range of Kubernetes if empty.
+func parseNodePortRange(portRange string) (int32, int32, error) {
+	if len(portRange) == 0 {
+		portRange = defaultNodePortRange
+	}
+	from, to, ok := strings.Cut(strings.TrimSpace(portRange), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid node port range %s", portRange)
+	}
+	base, err := strconv.ParseInt(strings.TrimSpace(from), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid node port range %s: %w", portRange, err)
+	}
+	last, err := strconv.ParseInt(strings.TrimSpace(to), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid node port range %s: %w", portRange, err)
+	}
+	if base <= 0 || base > last {
+		return 0, 0, fmt.Errorf("invalid node port range %s", portRange)
+	}
+	return int32(base), int32(last - base + 1), nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestBuildExposedEndpoint(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mysql-vpc"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{Port: 3306, NodePort: 30306}},
+		},
+	}
+	if endpoint := BuildExposedEndpoint(svc, nil); len(endpoint.Addresses) != 0 {
+		t.Errorf("expect no addresses before the load balancer is allocated, got: %v", endpoint.Addresses)
+	}
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "172.16.0.1"}, {Hostname: "lb.example.com"}}
+	endpoint := BuildExposedEndpoint(svc, nil)
+	if expected := []string{"172.16.0.1:3306", "lb.example.com:3306"}; !reflect.DeepEqual(endpoint.Addresses, expected) {
+		t.Errorf("expect addresses %v, got: %v", expected, endpoint.Addresses)
+	}
+	if endpoint.Name != svc.Name || endpoint.ServiceType != corev1.ServiceTypeLoadBalancer {
+		t.Errorf("unexpected endpoint: %v", endpoint)
+	}
+
+	svc.Spec.Type = corev1.ServiceTypeNodePort
+	endpoint = BuildExposedEndpoint(svc, nil)
+	if expected := []string{":30306"}; !reflect.DeepEqual(endpoint.Addresses, expected) {
+		t.Errorf("expect addresses %v, got: %v", expected, endpoint.Addresses)
+	}
+
+	endpoint = BuildExposedEndpoint(svc, []string{"10.0.0.1", "10.0.0.2"})
+	if expected := []string{"10.0.0.1:30306", "10.0.0.2:30306"}; !reflect.DeepEqual(endpoint.Addresses, expected) {
+		t.Errorf("expect addresses %v, got: %v", expected, endpoint.Addresses)
+	}
+	endpoint = BuildExposedEndpoint(svc, []string{"fd00::1"})
+	if expected := []string{"[fd00::1]:30306"}; !reflect.DeepEqual(endpoint.Addresses, expected) {
+		t.Errorf("expect addresses %v, got: %v", expected, endpoint.Addresses)
+	}
+}
+
+func TestGetNodeHost(t *testing.T) {
+	node := &corev1.Node{}
+	if host := GetNodeHost(node); host != "" {
+		t.Errorf("expect empty host, got: %s", host)
+	}
+	node.Status.Addresses = []corev1.NodeAddress{
+		{Type: corev1.NodeHostName, Address: "node-1"},
+		{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+	}
+	if host := GetNodeHost(node); host != "10.0.0.1" {
+		t.Errorf("expect the internal IP, got: %s", host)
+	}
+	node.Status.Addresses = append(node.Status.Addresses, corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "1.2.3.4"})
+	if host := GetNodeHost(node); host != "1.2.3.4" {
+		t.Errorf("expect the external IP, got: %s", host)
+	}
+}
+
+var _ = Describe("service utils test", func() {
+	var svcName string
+
+	cleanEnv := func() {
+		By("clean resources")
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResources(&testCtx, generics.ServiceSignature, inNS, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		svcName = "test-mysql-vpc-" + testCtx.GetRandomStr()
+	})
+
+	AfterEach(func() {
+		cleanEnv()
+		viper.Set(constant.CfgKeyServiceNodePortRange, "")
+	})
+
+	buildSvc := func() *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: svcName},
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeNodePort,
+				Ports: []corev1.ServicePort{{Name: "mysql", Port: 3306}, {Name: "admin", Port: 33062, NodePort: 31000}},
+			},
+		}
+	}
+
+	Context("allocate deterministic node ports", func() {
+		It("derives the same node port for the same service port", func() {
+			svc := buildSvc()
+			Expect(AllocateDeterministicNodePorts(ctx, k8sClient, svc)).Should(Succeed())
+			nodePort := svc.Spec.Ports[0].NodePort
+			Expect(nodePort).Should(BeNumerically(">=", 30000))
+			Expect(nodePort).Should(BeNumerically("<=", 32767))
+			By("the specified node port is kept")
+			Expect(svc.Spec.Ports[1].NodePort).Should(Equal(int32(31000)))
+
+			svc = buildSvc()
+			Expect(AllocateDeterministicNodePorts(ctx, k8sClient, svc)).Should(Succeed())
+			Expect(svc.Spec.Ports[0].NodePort).Should(Equal(nodePort))
+		})
+
+		It("takes the next free node port and keeps the allocated one", func() {
+			svc := buildSvc()
+			svc.Spec.Ports[1].NodePort = 0
+			Expect(AllocateDeterministicNodePorts(ctx, k8sClient, svc)).Should(Succeed())
+			nodePort := svc.Spec.Ports[0].NodePort
+
+			By("the node port derived is allocated to another service, the next free one is taken")
+			other := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testCtx.DefaultNamespace, Name: "other-" + testCtx.GetRandomStr()},
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80, NodePort: nodePort}},
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, other)).Should(Succeed())
+			svc = buildSvc()
+			svc.Spec.Ports[1].NodePort = 0
+			Expect(AllocateDeterministicNodePorts(ctx, k8sClient, svc)).Should(Succeed())
+			next := svc.Spec.Ports[0].NodePort
+			Expect(next).ShouldNot(Equal(nodePort))
+			Expect(next).Should(BeNumerically(">=", 30000))
+			Expect(next).Should(BeNumerically("<=", 32767))
+
+			By("the node port allocated to the service is kept even if it's not the derived one")
+			Expect(testCtx.CreateObj(ctx, svc)).Should(Succeed())
+			testapps.DeleteObject(&testCtx, client.ObjectKeyFromObject(other), &corev1.Service{})
+			Eventually(func(g Gomega) {
+				svc = buildSvc()
+				svc.Spec.Ports[1].NodePort = 0
+				g.Expect(AllocateDeterministicNodePorts(ctx, k8sClient, svc)).Should(Succeed())
+				g.Expect(svc.Spec.Ports[0].NodePort).Should(Equal(next))
+			}).Should(Succeed())
+		})
+
+		It("allocates the node ports in the configured range", func() {
+			viper.Set(constant.CfgKeyServiceNodePortRange, "40000-40001")
+			svc := buildSvc()
+			svc.Spec.Ports[1].NodePort = 0
+			Expect(AllocateDeterministicNodePorts(ctx, k8sClient, svc)).Should(Succeed())
+			ports := []int32{svc.Spec.Ports[0].NodePort, svc.Spec.Ports[1].NodePort}
+			Expect(ports).Should(ConsistOf(int32(40000), int32(40001)))
+
+			By("the node port range is exhausted")
+			svc = buildSvc()
+			svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{Name: "extra", Port: 3307})
+			svc.Spec.Ports[1].NodePort = 0
+			Expect(AllocateDeterministicNodePorts(ctx, k8sClient, svc)).ShouldNot(Succeed())
+		})
+
+		It("allocates no node port for the ClusterIP service", func() {
+			svc := buildSvc()
+			svc.Spec.Type = corev1.ServiceTypeClusterIP
+			Expect(AllocateDeterministicNodePorts(ctx, k8sClient, svc)).Should(Succeed())
+			Expect(svc.Spec.Ports[0].NodePort).Should(BeZero())
+		})
+	})
+})
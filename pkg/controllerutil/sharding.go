@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var (
+	// shardingReader reads the namespaces to look up the shard label, set by SetupSharding.
+	shardingReader client.Reader
+	// currentShard is the shard of this operator replica, -1 means the sharding is not set up for this process.
+	currentShard = -1
+)
+
+// SetupSharding sets up the sharding mode with the reader to read the namespaces, it's a no-op if the sharding is disabled.
+// Only the processes which have set up the sharding filter the objects by shard.
+func SetupSharding(reader client.Reader) error {
+	if !ShardingEnabled() {
+		return nil
+	}
+	shardID, err := ShardID()
+	if err != nil {
+		return err
+	}
+	if total := viper.GetInt(constant.CfgKeyShardingTotal); shardID < 0 || shardID >= total {
+		return fmt.Errorf("the shard id %d is out of range [0, %d)", shardID, total)
+	}
+	shardingReader = reader
+	currentShard = shardID
+	return nil
+}
+
+// ShardingEnabled returns true if the clusters are spread over more than one operator replicas.
+func ShardingEnabled() bool {
+	return viper.GetInt(constant.CfgKeyShardingTotal) > 1
+}
+
+// ShardID returns the shard of this operator replica, which is SHARD_ID if set, otherwise the ordinal of the hostname
+// modulo the number of the shards, e.g., 1 for kubeblocks-3 of 2 shards, when the replicas are run as a StatefulSet.
+// The replicas of the same shard elect the leader among them, so the extra replicas are the standbys of the shards.
+func ShardID() (int, error) {
+	if viper.IsSet(constant.CfgKeyShardID) {
+		return viper.GetInt(constant.CfgKeyShardID), nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return 0, err
+	}
+	return shardIDOfHostname(hostname, viper.GetInt(constant.CfgKeyShardingTotal))
+}
+
+func shardIDOfHostname(hostname string, total int) (int, error) {
+	ordinal, err := strconv.Atoi(hostname[strings.LastIndex(hostname, "-")+1:])
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the shard id from hostname %s, set %s explicitly", hostname, constant.CfgKeyShardID)
+	}
+	if total > 1 {
+		return ordinal % total, nil
+	}
+	return ordinal, nil
+}
+
+// ShardOf returns the shard which the object belongs to. All the objects of a cluster belong to the same shard, which is
+// the value of the shard label of the namespace if labeled, otherwise the hash of the cluster's namespace/name.
+// The cluster-scoped objects and the objects not belonging to any cluster belong to the shard 0.
+//
+// The namespace is read for every event, from the informer cache of the manager rather than the API server.
+// Relabeling a namespace doesn't requeue its clusters, neither in the old shard nor in the new one, the clusters are
+// picked up by the new shard on their next events, so restart the operator replicas after relabeling the namespaces.
+func ShardOf(ctx context.Context, obj client.Object, total int) int {
+	clusterName := getShardingClusterName(obj)
+	if len(obj.GetNamespace()) == 0 || len(clusterName) == 0 {
+		return 0
+	}
+	if shardingReader != nil {
+		ns := &corev1.Namespace{}
+		if err := shardingReader.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, ns); err == nil {
+			if shard, err := strconv.Atoi(ns.Labels[constant.ShardLabelKey]); err == nil && shard >= 0 {
+				return shard % total
+			}
+		}
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(obj.GetNamespace() + "/" + clusterName))
+	return int(h.Sum32() % uint32(total))
+}
+
+// getShardingClusterName returns the name of the cluster which the object belongs to.
+func getShardingClusterName(obj client.Object) string {
+	switch o := obj.(type) {
+	case *appsv1alpha1.Cluster:
+		return o.Name
+	case *appsv1alpha1.OpsRequest:
+		// the cluster label is added to the OpsRequest by the controller, which may be not there yet
+		return o.Spec.ClusterRef
+	default:
+		return obj.GetLabels()[constant.AppInstanceLabelKey]
+	}
+}
+
+// shardPredicateFilter filters out the objects not belonging to the shard of this operator replica in the sharding mode.
+// The nodes are shared by the clusters of all the shards, so they are let through, and the handlers mapping them to
+// the namespaced objects should filter the results by InCurrentShard.
+func shardPredicateFilter(object client.Object) bool {
+	if _, ok := object.(*corev1.Node); ok {
+		return true
+	}
+	return InCurrentShard(object)
+}
+
+// InCurrentShard returns true if the object belongs to the shard of this operator replica, or the sharding is not set up.
+func InCurrentShard(object client.Object) bool {
+	if currentShard < 0 || !ShardingEnabled() {
+		return true
+	}
+	return ShardOf(context.Background(), object, viper.GetInt(constant.CfgKeyShardingTotal)) == currentShard
+}
@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+var _ = Describe("sharding test", func() {
+	const total = 4
+
+	var (
+		namespace string
+		cluster   *appsv1alpha1.Cluster
+		ops       *appsv1alpha1.OpsRequest
+		pod       *corev1.Pod
+	)
+
+	BeforeEach(func() {
+		namespace = "test-sharding-" + testCtx.GetRandomStr()
+		cluster = &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "mycluster"},
+		}
+		ops = &appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "myops"},
+			Spec:       appsv1alpha1.OpsRequestSpec{ClusterRef: "mycluster"},
+		}
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "mycluster-mysql-0",
+				Labels:    map[string]string{constant.AppInstanceLabelKey: "mycluster"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		shardingReader = nil
+		testapps.DeleteObject(&testCtx, client.ObjectKey{Name: namespace}, &corev1.Namespace{})
+	})
+
+	It("assigns the objects of a cluster to the same shard", func() {
+		shard := ShardOf(ctx, cluster, total)
+		Expect(shard).Should(BeNumerically(">=", 0))
+		Expect(shard).Should(BeNumerically("<", total))
+		for _, obj := range []client.Object{cluster, ops, pod} {
+			Expect(ShardOf(ctx, obj, total)).Should(Equal(shard), obj.GetName())
+		}
+		By("the cluster-scoped object is assigned to the shard 0")
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}}
+		Expect(ShardOf(ctx, node, total)).Should(Equal(0))
+	})
+
+	It("prefers the shard label of the namespace to the hash", func() {
+		labeled := (ShardOf(ctx, cluster, total) + 1) % total
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   namespace,
+				Labels: map[string]string{constant.ShardLabelKey: fmt.Sprint(labeled)},
+			},
+		}
+		Expect(testCtx.CreateObj(ctx, ns)).Should(Succeed())
+		shardingReader = k8sClient
+		for _, obj := range []client.Object{cluster, ops, pod} {
+			Expect(ShardOf(ctx, obj, total)).Should(Equal(labeled), obj.GetName())
+		}
+	})
+})
+
+func TestShardPredicateFilter(t *testing.T) {
+	viper.Set(constant.CfgKeyShardingTotal, 2)
+	defer viper.Set(constant.CfgKeyShardingTotal, 0)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "mycluster-mysql-0",
+			Labels:    map[string]string{constant.AppInstanceLabelKey: "mycluster"},
+		},
+	}
+	// not filtered if the sharding is not set up by this process
+	if !shardPredicateFilter(pod) {
+		t.Fatalf("expected not filtered without sharding set up")
+	}
+
+	shard := ShardOf(context.Background(), pod, 2)
+	defer func() { currentShard = -1 }()
+	currentShard = shard
+	if !shardPredicateFilter(pod) {
+		t.Fatalf("expected the object of shard %d accepted", shard)
+	}
+	currentShard = 1 - shard
+	if shardPredicateFilter(pod) {
+		t.Fatalf("expected the object of shard %d filtered out by shard %d", shard, currentShard)
+	}
+	// the nodes are shared by all the shards
+	if !shardPredicateFilter(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}}) {
+		t.Fatalf("expected the node accepted by shard %d", currentShard)
+	}
+}
+
+func TestShardIDOfHostname(t *testing.T) {
+	for hostname, expected := range map[string]int{
+		"kubeblocks-0": 0,
+		"kubeblocks-1": 1,
+		"kubeblocks-2": 0,
+		"kubeblocks-3": 1,
+	} {
+		if shard, err := shardIDOfHostname(hostname, 2); err != nil || shard != expected {
+			t.Fatalf("expected shard %d for %s, got %d, %v", expected, hostname, shard, err)
+		}
+	}
+	// the replicas of a Deployment have no ordinal
+	if _, err := shardIDOfHostname("kubeblocks-7d9f8c6b5-x2k4p", 2); err == nil {
+		t.Fatal("expected error for the hostname without ordinal")
+	}
+}
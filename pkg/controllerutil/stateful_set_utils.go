@@ -73,9 +73,8 @@ func GetPodListByStatefulSet(ctx context.Context, cli client.Client, stsObj *app
 // getPodListByStatefulSetWithSelector gets statefulSet pod list.
 func getPodListByStatefulSetWithSelector(ctx context.Context, cli client.Client, stsObj *appsv1.StatefulSet, selector client.MatchingLabels) ([]corev1.Pod, error) {
 	podList := &corev1.PodList{}
-	if err := cli.List(ctx, podList,
-		&client.ListOptions{Namespace: stsObj.Namespace},
-		selector); err != nil {
+	if err := ListByIndex(ctx, cli, podList, PodOwnerStatefulSetField, stsObj.Name, selector,
+		client.InNamespace(stsObj.Namespace)); err != nil {
 		return nil, err
 	}
 	var pods []corev1.Pod
@@ -0,0 +1,94 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StatusChange mutates the status of the object it's applied on.
+type StatusChange[T client.Object] func(obj T)
+
+// StatusWriter accumulates the status changes of an object made by the different code paths of a reconciliation,
+// and writes them back in a single status patch guarded by optimistic lock.
+// If the patch conflicts, the latest object is fetched, all the changes are re-applied on it and the patch is retried,
+// so the status fields not touched by the changes, which may be updated concurrently by others, are kept.
+type StatusWriter[T client.Object] struct {
+	cli     client.Client
+	obj     T
+	changes []StatusChange[T]
+}
+
+// NewStatusWriter creates a StatusWriter for obj, which is the object read at the beginning of the reconciliation.
+func NewStatusWriter[T client.Object](cli client.Client, obj T) *StatusWriter[T] {
+	return &StatusWriter[T]{
+		cli: cli,
+		obj: obj,
+	}
+}
+
+// Add queues a status change, the changes are applied in the order they are added.
+func (w *StatusWriter[T]) Add(change StatusChange[T]) {
+	w.changes = append(w.changes, change)
+}
+
+// Pending returns the number of the changes not written yet.
+func (w *StatusWriter[T]) Pending() int {
+	return len(w.changes)
+}
+
+// Write applies the pending changes and patches the object status once, it's a no-op if the status doesn't change.
+// It returns the object written, or the latest one read if nothing changes.
+func (w *StatusWriter[T]) Write(ctx context.Context, opts ...client.SubResourcePatchOption) (T, error) {
+	obj := w.obj.DeepCopyObject().(T)
+	if len(w.changes) == 0 {
+		return obj, nil
+	}
+	refresh := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refresh {
+			// get into an empty object, the stale maps and slices may be merged with the latest ones otherwise
+			obj = reflect.New(reflect.TypeOf(w.obj).Elem()).Interface().(T)
+			if err := w.cli.Get(ctx, client.ObjectKeyFromObject(w.obj), obj); err != nil {
+				return err
+			}
+		}
+		refresh = true
+		orig := obj.DeepCopyObject().(T)
+		for _, change := range w.changes {
+			change(obj)
+		}
+		if equality.Semantic.DeepEqual(orig, obj) {
+			return nil
+		}
+		patch := client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})
+		return w.cli.Status().Patch(ctx, obj, patch, opts...)
+	})
+	if err != nil {
+		return obj, err
+	}
+	w.changes = nil
+	return obj, nil
+}
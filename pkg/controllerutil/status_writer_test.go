@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("status writer test", func() {
+	const (
+		clusterDefName     = "test-clusterdef"
+		clusterVersionName = "test-clusterversion"
+		mysqlCompName      = "mysql"
+		mysqlCompDefName   = "mysql"
+	)
+
+	var cluster *appsv1alpha1.Cluster
+
+	cleanEnv := func() {
+		By("clean resources")
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		cluster = testapps.NewClusterFactory(testCtx.DefaultNamespace, "test-cluster-"+testCtx.GetRandomStr(),
+			clusterDefName, clusterVersionName).
+			AddComponent(mysqlCompName, mysqlCompDefName).
+			Create(&testCtx).GetObject()
+	})
+
+	AfterEach(cleanEnv)
+
+	It("writes the pending status changes in a single patch", func() {
+		stale := &appsv1alpha1.Cluster{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), stale)).Should(Succeed())
+
+		By("the status is updated by others after the object is read")
+		latest := stale.DeepCopy()
+		meta.SetStatusCondition(&latest.Status.Conditions, metav1.Condition{Type: "Other", Status: metav1.ConditionTrue, Reason: "Other"})
+		Expect(k8sClient.Status().Update(ctx, latest)).Should(Succeed())
+
+		writer := NewStatusWriter(k8sClient, stale)
+		_, err := writer.Write(ctx)
+		Expect(err).Should(Succeed())
+		writer.Add(func(cluster *appsv1alpha1.Cluster) {
+			cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+		})
+		writer.Add(func(cluster *appsv1alpha1.Cluster) {
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"})
+		})
+		Expect(writer.Pending()).Should(Equal(2))
+		written, err := writer.Write(ctx)
+		Expect(err).Should(Succeed())
+		Expect(writer.Pending()).Should(BeZero())
+		Expect(written.Status.Phase).Should(Equal(appsv1alpha1.RunningClusterPhase))
+
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(cluster), func(g Gomega, result *appsv1alpha1.Cluster) {
+			g.Expect(result.Status.Phase).Should(Equal(appsv1alpha1.RunningClusterPhase))
+			for _, condType := range []string{"Other", "Ready"} {
+				g.Expect(meta.FindStatusCondition(result.Status.Conditions, condType)).ShouldNot(BeNil(), condType)
+			}
+		})).Should(Succeed())
+	})
+})
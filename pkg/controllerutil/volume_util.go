@@ -71,6 +71,12 @@ func CreateOrUpdatePodVolumes(podSpec *corev1.PodSpec, volumes map[string]appsv1
 		if templateSpec.VolumeName == "" {
 			continue
 		}
+		if templateSpec.AsSecret {
+			if podVolumes, err = createOrUpdateSecretVolume(podVolumes, cmName, templateSpec); err != nil {
+				return err
+			}
+			continue
+		}
 		if podVolumes, err = CreateOrUpdateVolume(podVolumes, templateSpec.VolumeName, func(volumeName string) corev1.Volume {
 			return corev1.Volume{
 				Name: volumeName,
@@ -95,3 +101,24 @@ func CreateOrUpdatePodVolumes(podSpec *corev1.PodSpec, volumes map[string]appsv1
 	podSpec.Volumes = podVolumes
 	return nil
 }
+
+func createOrUpdateSecretVolume(volumes []corev1.Volume, secretName string, templateSpec appsv1alpha1.ComponentTemplateSpec) ([]corev1.Volume, error) {
+	return CreateOrUpdateVolume(volumes, templateSpec.VolumeName, func(volumeName string) corev1.Volume {
+		return corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  secretName,
+					DefaultMode: templateSpec.DefaultMode,
+				},
+			},
+		}
+	}, func(volume *corev1.Volume) error {
+		secret := volume.Secret
+		if secret == nil {
+			return fmt.Errorf("mount volume[%s] requires a Secret: [%+v]", volume.Name, volume)
+		}
+		secret.SecretName = secretName
+		return nil
+	})
+}
@@ -104,6 +104,21 @@ var _ = Describe("lifecycle_utils", func() {
 			Expect(len(ps.Volumes)).To(Equal(3))
 		})
 
+		It("should succeed in normal test case, where a secret volume is added", func() {
+			volumes["my_secret"] = appsv1alpha1.ComponentTemplateSpec{
+				Name:        "mySecret",
+				TemplateRef: "mySecret",
+				VolumeName:  "mySecretVolume",
+				AsSecret:    true,
+			}
+			ps := &sts.Spec.Template.Spec
+			err := CreateOrUpdatePodVolumes(ps, volumes)
+			Expect(err).Should(BeNil())
+			Expect(len(ps.Volumes)).To(Equal(2))
+			Expect(ps.Volumes[1].Secret).ShouldNot(BeNil())
+			Expect(ps.Volumes[1].Secret.SecretName).To(Equal("my_secret"))
+		})
+
 		It("should fail if updated volume doesn't contain ConfigMap", func() {
 			const (
 				cmName            = "my_config_for_test"
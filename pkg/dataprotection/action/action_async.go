@@ -0,0 +1,44 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package action
+
+import (
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+// AsyncAction is an action that starts the wrapped action and does not wait for
+// it to complete, the following actions can be executed while it is running.
+// It still fails if the wrapped action fails.
+type AsyncAction struct {
+	Action
+}
+
+func (a *AsyncAction) Execute(ctx ActionContext) (*dpv1alpha1.ActionStatus, error) {
+	status, err := a.Action.Execute(ctx)
+	if err != nil || status == nil {
+		return status, err
+	}
+	if status.Phase == dpv1alpha1.ActionPhaseRunning {
+		status.Phase = dpv1alpha1.ActionPhaseCompleted
+	}
+	return status, nil
+}
+
+var _ Action = &AsyncAction{}
@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package action
 
 import (
+	"math"
+
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -59,6 +62,14 @@ func (e *ExecAction) Execute(ctx ActionContext) (*dpv1alpha1.ActionStatus, error
 		return nil, err
 	}
 	e.JobAction.PodSpec = e.buildPodSpec()
+	if err := intctrlutil.ApplyDataPlaneSecurityContext(e.JobAction.PodSpec); err != nil {
+		return nil, err
+	}
+	if e.Timeout.Duration > 0 {
+		// terminate the job and mark it as failed if the command does not
+		// complete within the timeout.
+		e.JobAction.ActiveDeadlineSeconds = pointer.Int64(int64(math.Ceil(e.Timeout.Seconds())))
+	}
 	return e.JobAction.Execute(ctx)
 }
 
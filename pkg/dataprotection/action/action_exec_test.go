@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package action_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -123,5 +125,32 @@ var _ = Describe("ExecAction Test", func() {
 			key := client.ObjectKey{Name: actionName, Namespace: testCtx.DefaultNamespace}
 			Eventually(testapps.CheckObjExists(&testCtx, key, job, true)).Should(Succeed())
 		})
+
+		It("should set the active deadline of the job by the timeout", func() {
+			act := &action.ExecAction{
+				JobAction: action.JobAction{
+					Name: actionName,
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      actionName,
+						Namespace: testCtx.DefaultNamespace,
+					},
+					Owner: testdp.NewFakeBackup(&testCtx, nil),
+				},
+				PodName:            podName,
+				Namespace:          testCtx.DefaultNamespace,
+				Command:            command,
+				Container:          container,
+				ServiceAccountName: serviceAccountName,
+				Timeout:            metav1.Duration{Duration: 90 * time.Second},
+			}
+			_, err := act.Execute(buildActionCtx())
+			Expect(err).Should(Succeed())
+
+			key := client.ObjectKey{Name: actionName, Namespace: testCtx.DefaultNamespace}
+			Eventually(testapps.CheckObj(&testCtx, key, func(g Gomega, fetched *batchv1.Job) {
+				g.Expect(fetched.Spec.ActiveDeadlineSeconds).ShouldNot(BeNil())
+				g.Expect(*fetched.Spec.ActiveDeadlineSeconds).Should(BeEquivalentTo(90))
+			})).Should(Succeed())
+		})
 	})
 })
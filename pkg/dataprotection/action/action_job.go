@@ -50,6 +50,10 @@ type JobAction struct {
 
 	// BackOffLimit is the number of retries before considering a JobAction as failed.
 	BackOffLimit *int32
+
+	// ActiveDeadlineSeconds is the duration in seconds that the job may be active
+	// before the system tries to terminate it and considers it as failed.
+	ActiveDeadlineSeconds *int64
 }
 
 func (j *JobAction) GetName() string {
@@ -108,7 +112,8 @@ func (j *JobAction) Execute(actCtx ActionContext) (*dpv1alpha1.ActionStatus, err
 				ObjectMeta: j.ObjectMeta,
 				Spec:       *j.PodSpec,
 			},
-			BackoffLimit: j.BackOffLimit,
+			BackoffLimit:          j.BackOffLimit,
+			ActiveDeadlineSeconds: j.ActiveDeadlineSeconds,
 		},
 	}
 
@@ -92,6 +92,7 @@ func (s *StatefulSetAction) Execute(ctx ActionContext) (actionStatus *dpv1alpha1
 		}, nil
 	}
 	sts.Spec.Template.Spec = *s.PodSpec
+	sts.Spec.Template.Annotations = s.ObjectMeta.Annotations
 	// update the statefulSet
 
 	if err = ctx.Client.Update(ctx.Ctx, sts); err != nil {
@@ -122,7 +123,8 @@ func (s *StatefulSetAction) createStatefulSet(ctx ActionContext, podSpec *corev1
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: s.ObjectMeta.Labels,
+					Labels:      s.ObjectMeta.Labels,
+					Annotations: s.ObjectMeta.Annotations,
 				},
 				Spec: *podSpec,
 			},
@@ -256,6 +256,9 @@ func (d *Deleter) createDeleteJob(container corev1.Container,
 	if err := utils.AddTolerations(&podSpec); err != nil {
 		return err
 	}
+	if err := ctrlutil.ApplyDataPlaneSecurityContext(&podSpec); err != nil {
+		return err
+	}
 	kopiaRepoPath := backup.Status.KopiaRepoPath
 	encryptionConfig := backup.Status.EncryptionConfig
 	if backupRepo != nil {
@@ -276,8 +279,9 @@ func (d *Deleter) createDeleteJob(container corev1.Container,
 		Spec: batchv1.JobSpec{
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Namespace: jobKey.Namespace,
-					Name:      jobKey.Name,
+					Namespace:   jobKey.Namespace,
+					Name:        jobKey.Name,
+					Annotations: ctrlutil.BuildServiceMeshJobAnnotations(backup.Annotations[constant.ServiceMeshAnnotationKey]),
 				},
 				Spec: podSpec,
 			},
@@ -28,9 +28,11 @@ import (
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
@@ -43,6 +45,10 @@ const (
 	BackupDataJobNamePrefix      = "dp-backup"
 	prebackupJobNamePrefix       = "dp-prebackup"
 	postbackupJobNamePrefix      = "dp-postbackup"
+	SnapshotHookJobNamePrefix    = "dp-snapshothook"
+	SnapshotResumeJobNamePrefix  = "dp-snapshotresume"
+	snapshotQuiescedJobPrefix    = "dp-snapshotquiesced"
+	snapshotHookWaitActionPrefix = "dp-snapshothookwait"
 	BackupDataContainerName      = "backupdata"
 	SyncProgressContainerName    = "sync-progress"
 	SyncProgressSharedVolumeName = "sync-progress-shared-volume"
@@ -58,11 +64,15 @@ type Request struct {
 	BackupPolicy         *dpv1alpha1.BackupPolicy
 	BackupMethod         *dpv1alpha1.BackupMethod
 	ActionSet            *dpv1alpha1.ActionSet
+	ParentBackup         *dpv1alpha1.Backup
 	TargetPods           []*corev1.Pod
 	BackupRepoPVC        *corev1.PersistentVolumeClaim
 	BackupRepo           *dpv1alpha1.BackupRepo
 	ToolConfigSecret     *corev1.Secret
 	WorkerServiceAccount string
+	// SnapshotHook is the hook declared in the ComponentDefinition of the target
+	// component, which keeps the component quiesced while taking the volume snapshots.
+	SnapshotHook *appsv1alpha1.SnapshotHook
 }
 
 func (r *Request) GetBackupType() string {
@@ -104,6 +114,10 @@ func (r *Request) BuildActions() ([]action.Action, error) {
 		appendIgnoreNil(backupDataAction)
 	}
 
+	// start the snapshot hooks and wait for the target pods to be quiesced
+	appendIgnoreNil(r.buildSnapshotHookActions(SnapshotHookJobNamePrefix)...)
+	appendIgnoreNil(r.buildSnapshotHookActions(snapshotQuiescedJobPrefix)...)
+
 	// build create volume snapshot action
 	for i := range r.TargetPods {
 		createVolumeSnapshotAction, err := r.buildCreateVolumeSnapshotAction(r.TargetPods[i], fmt.Sprintf("createVolumeSnapshot-%d", i))
@@ -113,6 +127,10 @@ func (r *Request) BuildActions() ([]action.Action, error) {
 		appendIgnoreNil(createVolumeSnapshotAction)
 	}
 
+	// resume the target pods and wait for the snapshot hooks to exit
+	appendIgnoreNil(r.buildSnapshotHookActions(SnapshotResumeJobNamePrefix)...)
+	appendIgnoreNil(r.buildSnapshotHookActions(snapshotHookWaitActionPrefix)...)
+
 	// build backup kubernetes resources action
 	backupKubeResourcesAction, err := r.buildBackupKubeResourcesAction()
 	if err != nil {
@@ -176,7 +194,7 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 
 	backupDataAct := r.ActionSet.Spec.Backup.BackupData
 	switch r.ActionSet.Spec.BackupType {
-	case dpv1alpha1.BackupTypeFull:
+	case dpv1alpha1.BackupTypeFull, dpv1alpha1.BackupTypeIncremental:
 		podSpec, err := r.BuildJobActionPodSpec(targetPod, BackupDataContainerName, &backupDataAct.JobActionSpec)
 		if err != nil {
 			return nil, fmt.Errorf("failed to build job action pod spec: %w", err)
@@ -186,7 +204,7 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 		}
 		return &action.JobAction{
 			Name:         name,
-			ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
+			ObjectMeta:   *buildBackupJobObjMetaForPod(r.Backup, name, targetPod),
 			Owner:        r.Backup,
 			PodSpec:      podSpec,
 			BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
@@ -202,9 +220,10 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 		return &action.StatefulSetAction{
 			Name: r.Name,
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: r.Namespace,
-				Name:      r.Name,
-				Labels:    BuildBackupWorkloadLabels(r.Backup),
+				Namespace:   r.Namespace,
+				Name:        r.Name,
+				Labels:      BuildBackupWorkloadLabels(r.Backup),
+				Annotations: intctrlutil.BuildServiceMeshJobAnnotations(r.TargetPods[0].Annotations[constant.ServiceMeshAnnotationKey]),
 			},
 			Replicas:  pointer.Int32(int32(1)),
 			Backup:    r.Backup,
@@ -252,6 +271,62 @@ func (r *Request) buildCreateVolumeSnapshotAction(targetPod *corev1.Pod, name st
 	}, nil
 }
 
+// buildSnapshotHookActions builds the actions of the snapshot hook, the prefix
+// determines which step to build:
+//   - SnapshotHookJobNamePrefix: starts the hook in the target pods without waiting
+//     for it, the hook quiesces the component and holds the session until resumed.
+//   - snapshotQuiescedJobPrefix: waits for the hook to report the component is quiesced.
+//   - SnapshotResumeJobNamePrefix: signals the hook to resume the component.
+//   - snapshotHookWaitActionPrefix: waits for the hook to exit.
+func (r *Request) buildSnapshotHookActions(prefix string) []action.Action {
+	if r.BackupMethod == nil ||
+		!boolptr.IsSetToTrue(r.BackupMethod.SnapshotVolumes) ||
+		r.SnapshotHook == nil {
+		return nil
+	}
+
+	hook := r.SnapshotHook
+	quiescedFile := fmt.Sprintf("/tmp/kb-snapshot-%s-quiesced", r.Backup.Name)
+	resumeFile := fmt.Sprintf("/tmp/kb-snapshot-%s-resume", r.Backup.Name)
+	buildHookAction := func(targetPod *corev1.Pod, name string, command ...string) *action.ExecAction {
+		return r.buildExecAction(targetPod, name, &dpv1alpha1.ExecActionSpec{
+			Container: hook.Container,
+			Command:   command,
+			Timeout:   hook.Timeout,
+		}).(*action.ExecAction)
+	}
+
+	buildHookJobAction := func(targetPod *corev1.Pod, name string) *action.ExecAction {
+		script := fmt.Sprintf(`export KB_SNAPSHOT_QUIESCED_FILE=%[1]s KB_SNAPSHOT_RESUME_FILE=%[2]s; `+
+			`rm -f %[1]s %[2]s; "$@"; rc=$?; rm -f %[1]s %[2]s; exit $rc`, quiescedFile, resumeFile)
+		hookAction := buildHookAction(targetPod, name, append([]string{"sh", "-c", script, "sh"}, hook.Command...)...)
+		// the hook must not be retried, a new session does not hold the locks of the former one.
+		hookAction.BackOffLimit = pointer.Int32(0)
+		return hookAction
+	}
+
+	var actions []action.Action
+	for i := range r.TargetPods {
+		pod := r.TargetPods[i]
+		name := fmt.Sprintf("%s-%d", prefix, i)
+		switch prefix {
+		case SnapshotHookJobNamePrefix:
+			actions = append(actions, &action.AsyncAction{Action: buildHookJobAction(pod, name)})
+		case snapshotQuiescedJobPrefix:
+			actions = append(actions, buildHookAction(pod, name, "sh", "-c",
+				fmt.Sprintf("until [ -f %s ]; do sleep 1; done", quiescedFile)))
+		case SnapshotResumeJobNamePrefix:
+			actions = append(actions, buildHookAction(pod, name, "touch", resumeFile))
+		case snapshotHookWaitActionPrefix:
+			// waits for the job of the hook started before.
+			waitAction := buildHookJobAction(pod, fmt.Sprintf("%s-%d", SnapshotHookJobNamePrefix, i))
+			waitAction.Name = name
+			actions = append(actions, waitAction)
+		}
+	}
+	return actions
+}
+
 // TODO(ldm): implement this
 func (r *Request) buildBackupKubeResourcesAction() (action.Action, error) {
 	return nil, nil
@@ -278,7 +353,7 @@ func (r *Request) buildAction(targetPod *corev1.Pod,
 func (r *Request) buildExecAction(targetPod *corev1.Pod,
 	name string,
 	exec *dpv1alpha1.ExecActionSpec) action.Action {
-	objectMeta := *buildBackupJobObjMeta(r.Backup, name)
+	objectMeta := *buildBackupJobObjMetaForPod(r.Backup, name, targetPod)
 	objectMeta.Labels[dptypes.BackupNamespaceLabelKey] = r.Namespace
 	// create exec job in kubeblocks namespace for security
 	objectMeta.Namespace = viper.GetString(constant.CfgKeyCtrlrMgrNS)
@@ -310,7 +385,7 @@ func (r *Request) buildJobAction(targetPod *corev1.Pod,
 	}
 	return &action.JobAction{
 		Name:         name,
-		ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
+		ObjectMeta:   *buildBackupJobObjMetaForPod(r.Backup, name, targetPod),
 		Owner:        r.Backup,
 		PodSpec:      podSpec,
 		BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
@@ -355,6 +430,14 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 				Value: r.Spec.RetentionPeriod.String(),
 			},
 		}
+		if r.ParentBackup != nil {
+			envVars = append(envVars, corev1.EnvVar{
+				Name:  dptypes.DPParentBackupBasePath,
+				Value: r.ParentBackup.Status.Path,
+			})
+		}
+		envVars = append(envVars, utils.BuildEnvByCompressionConfig(r.Status.CompressionConfig)...)
+		envVars = append(envVars, utils.BuildEnvByBandwidthLimit(r.Status.BandwidthLimit)...)
 		envVars = append(envVars, utils.BuildEnvByCredential(targetPod, r.BackupPolicy.Spec.Target.ConnectionCredential)...)
 		if r.ActionSet != nil {
 			envVars = append(envVars, r.ActionSet.Spec.Env...)
@@ -424,6 +507,8 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 
 	if r.BackupMethod.RuntimeSettings != nil {
 		container.Resources = r.BackupMethod.RuntimeSettings.Resources
+	} else if r.Status.JobPodTemplate != nil && r.Status.JobPodTemplate.Resources != nil {
+		container.Resources = *r.Status.JobPodTemplate.Resources
 	}
 
 	if r.ActionSet != nil {
@@ -452,6 +537,14 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 			return nil, err
 		}
 	}
+	utils.ApplyJobPodTemplate(podSpec, r.Status.JobPodTemplate, !runOnTargetPodNode())
+	if err := intctrlutil.ApplyDataPlaneSecurityContext(podSpec); err != nil {
+		return nil, err
+	}
+	// deliver the password of the connection credential from the external secret manager if it's sealed
+	if err := secretstore.InjectPodDelivery(r.Backup.Namespace, podSpec); err != nil {
+		return nil, err
+	}
 
 	utils.InjectDatasafed(podSpec, r.BackupRepo, RepoVolumeMountPath,
 		r.Status.EncryptionConfig, r.Status.KopiaRepoPath)
@@ -20,15 +20,19 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package backup
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
@@ -143,6 +147,39 @@ var _ = Describe("Request Test", func() {
 				Expect(err).Should(HaveOccurred())
 			})
 
+			It("build snapshot hook actions around the volume snapshot", func() {
+				request.Backup = backup
+				request.TargetPods = []*corev1.Pod{targetPod}
+				request.BackupMethod = &dpv1alpha1.BackupMethod{
+					Name:            testdp.VSBackupMethodName,
+					SnapshotVolumes: boolptr.True(),
+				}
+				request.SnapshotHook = &appsv1alpha1.SnapshotHook{
+					Command: []string{"/scripts/flush-tables-with-read-lock.sh"},
+				}
+				hookActions := request.buildSnapshotHookActions(SnapshotHookJobNamePrefix)
+				Expect(hookActions).Should(HaveLen(1))
+				hookAction, ok := hookActions[0].(*action.AsyncAction)
+				Expect(ok).Should(BeTrue())
+				hookJobAction := hookAction.Action.(*action.ExecAction)
+				Expect(*hookJobAction.BackOffLimit).Should(BeEquivalentTo(0))
+				Expect(hookJobAction.Command).Should(ContainElement("/scripts/flush-tables-with-read-lock.sh"))
+
+				By("the hook job is waited by the same job")
+				waitActions := request.buildSnapshotHookActions(snapshotHookWaitActionPrefix)
+				Expect(waitActions).Should(HaveLen(1))
+				waitAction := waitActions[0].(*action.ExecAction)
+				Expect(waitAction.GetName()).ShouldNot(Equal(hookJobAction.GetName()))
+				Expect(waitAction.ObjectMeta.Name).Should(Equal(hookJobAction.ObjectMeta.Name))
+				Expect(waitAction.Command).Should(Equal(hookJobAction.Command))
+
+				By("the resume action signals the hook in the same pod")
+				resumeActions := request.buildSnapshotHookActions(SnapshotResumeJobNamePrefix)
+				Expect(resumeActions).Should(HaveLen(1))
+				Expect(resumeActions[0].(*action.ExecAction).Command).Should(Equal(
+					[]string{"touch", fmt.Sprintf("/tmp/kb-snapshot-%s-resume", backup.Name)}))
+			})
+
 		})
 	})
 })
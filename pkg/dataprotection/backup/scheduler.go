@@ -204,6 +204,9 @@ EOF
 	if err := dputils.AddTolerations(podSpec); err != nil {
 		return nil, err
 	}
+	if err := intctrlutil.ApplyDataPlaneSecurityContext(podSpec); err != nil {
+		return nil, err
+	}
 	return podSpec, nil
 }
 
@@ -24,14 +24,17 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/rogpeppe/go-internal/semver"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/action"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
@@ -146,6 +149,14 @@ func buildBackupJobObjMeta(backup *dpv1alpha1.Backup, prefix string) *metav1.Obj
 	}
 }
 
+// buildBackupJobObjMetaForPod builds the object meta of the backup job for the target pod. If the target pod works with
+// the sidecar proxy of the service mesh, the sidecar proxy is not injected into the job, otherwise it will never complete.
+func buildBackupJobObjMetaForPod(backup *dpv1alpha1.Backup, prefix string, targetPod *corev1.Pod) *metav1.ObjectMeta {
+	objMeta := buildBackupJobObjMeta(backup, prefix)
+	objMeta.Annotations = intctrlutil.BuildServiceMeshJobAnnotations(targetPod.Annotations[constant.ServiceMeshAnnotationKey])
+	return objMeta
+}
+
 func GenerateBackupJobName(backup *dpv1alpha1.Backup, prefix string) string {
 	name := fmt.Sprintf("%s-%s-%s", prefix, backup.Name, backup.UID[:8])
 	// job name cannot exceed 63 characters for label name limit.
@@ -230,6 +241,22 @@ func SetExpirationByCreationTime(backup *dpv1alpha1.Backup) error {
 	return nil
 }
 
+// GetDependentBackups gets the backups in the same namespace that are directly
+// based on the specified backup, such as incremental backups whose parent is it.
+func GetDependentBackups(ctx context.Context, cli client.Client, backup *dpv1alpha1.Backup) ([]*dpv1alpha1.Backup, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := cli.List(ctx, backupList, client.InNamespace(backup.Namespace)); err != nil {
+		return nil, err
+	}
+	var dependents []*dpv1alpha1.Backup
+	for i := range backupList.Items {
+		if backupList.Items[i].Spec.ParentBackupName == backup.Name {
+			dependents = append(dependents, &backupList.Items[i])
+		}
+	}
+	return dependents, nil
+}
+
 // BuildCronJobSchedule build cron job schedule info based on kubernetes version.
 // For kubernetes version >= 1.25, the timeZone field is supported, return timezone.
 // Ref https://kubernetes.io/docs/concepts/workloads/controllers/cron-jobs/#time-zones
@@ -254,3 +281,42 @@ func BuildCronJobSchedule(cronExpression string) (*string, string) {
 	}
 	return nil, fmt.Sprintf("CRON_TZ=%s %s", timeZone, cronExpression)
 }
+
+// CalculateThroughput calculates the average throughput of the backup by the total
+// size and the duration, the result is in the format of "10Mi/s". If the total size
+// can not be parsed or the duration is less than one second, returns empty string.
+func CalculateThroughput(totalSize string, duration time.Duration) string {
+	if totalSize == "" || duration < time.Second {
+		return ""
+	}
+	size, err := resource.ParseQuantity(totalSize)
+	if err != nil || size.Sign() <= 0 {
+		return ""
+	}
+	bytesPerSecond := int64(float64(size.Value()) / duration.Seconds())
+	// round down to a multiple of 1Ki to make the result readable.
+	if bytesPerSecond >= 1024 {
+		bytesPerSecond = bytesPerSecond / 1024 * 1024
+	}
+	return resource.NewQuantity(bytesPerSecond, resource.BinarySI).String() + "/s"
+}
+
+// GetBandwidthLimit returns the effective bandwidth limit of the backup, which is
+// the smaller one of the bandwidth limits of the backup and the backup repository.
+// Returns nil if neither of them is set.
+func GetBandwidthLimit(backup *dpv1alpha1.Backup, backupRepo *dpv1alpha1.BackupRepo) *resource.Quantity {
+	var limit *resource.Quantity
+	if backup != nil && backup.Spec.BandwidthLimit != nil && backup.Spec.BandwidthLimit.Sign() > 0 {
+		limit = backup.Spec.BandwidthLimit
+	}
+	if backupRepo != nil && backupRepo.Spec.BandwidthLimit != nil && backupRepo.Spec.BandwidthLimit.Sign() > 0 {
+		if limit == nil || backupRepo.Spec.BandwidthLimit.Cmp(*limit) < 0 {
+			limit = backupRepo.Spec.BandwidthLimit
+		}
+	}
+	if limit == nil {
+		return nil
+	}
+	res := limit.DeepCopy()
+	return &res
+}
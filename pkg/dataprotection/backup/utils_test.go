@@ -21,11 +21,16 @@ package backup
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/utils/pointer"
 
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -87,3 +92,138 @@ func TestBuildCronJobSchedule(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateThroughput(t *testing.T) {
+	tests := []struct {
+		name       string
+		totalSize  string
+		duration   time.Duration
+		throughput string
+	}{
+		{
+			name:       "empty total size",
+			totalSize:  "",
+			duration:   time.Minute,
+			throughput: "",
+		},
+		{
+			name:       "invalid total size",
+			totalSize:  "1xx",
+			duration:   time.Minute,
+			throughput: "",
+		},
+		{
+			name:       "duration less than one second",
+			totalSize:  "1Gi",
+			duration:   500 * time.Millisecond,
+			throughput: "",
+		},
+		{
+			name:       "total size with unit",
+			totalSize:  "1Gi",
+			duration:   16 * time.Second,
+			throughput: "64Mi/s",
+		},
+		{
+			name:       "total size in bytes",
+			totalSize:  "1234567",
+			duration:   time.Second,
+			throughput: "1205Ki/s",
+		},
+		{
+			name:       "throughput less than 1Ki",
+			totalSize:  "1000",
+			duration:   10 * time.Second,
+			throughput: "100/s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.throughput, CalculateThroughput(tt.totalSize, tt.duration))
+		})
+	}
+}
+
+func TestGetBandwidthLimit(t *testing.T) {
+	newBackup := func(limit string) *dpv1alpha1.Backup {
+		backup := &dpv1alpha1.Backup{}
+		if limit != "" {
+			quantity := resource.MustParse(limit)
+			backup.Spec.BandwidthLimit = &quantity
+		}
+		return backup
+	}
+	newBackupRepo := func(limit string) *dpv1alpha1.BackupRepo {
+		repo := &dpv1alpha1.BackupRepo{}
+		if limit != "" {
+			quantity := resource.MustParse(limit)
+			repo.Spec.BandwidthLimit = &quantity
+		}
+		return repo
+	}
+	tests := []struct {
+		name        string
+		backupLimit string
+		repoLimit   string
+		expected    string
+	}{
+		{
+			name:     "no limit",
+			expected: "",
+		},
+		{
+			name:        "only backup limit",
+			backupLimit: "100Mi",
+			expected:    "100Mi",
+		},
+		{
+			name:      "only repo limit",
+			repoLimit: "50Mi",
+			expected:  "50Mi",
+		},
+		{
+			name:        "backup limit is smaller",
+			backupLimit: "10Mi",
+			repoLimit:   "50Mi",
+			expected:    "10Mi",
+		},
+		{
+			name:        "repo limit is smaller",
+			backupLimit: "100Mi",
+			repoLimit:   "50Mi",
+			expected:    "50Mi",
+		},
+		{
+			name:        "zero limit is ignored",
+			backupLimit: "0",
+			repoLimit:   "50Mi",
+			expected:    "50Mi",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := GetBandwidthLimit(newBackup(tt.backupLimit), newBackupRepo(tt.repoLimit))
+			if tt.expected == "" {
+				assert.Nil(t, limit)
+				return
+			}
+			assert.NotNil(t, limit)
+			expected := resource.MustParse(tt.expected)
+			assert.Equal(t, expected.Value(), limit.Value())
+		})
+	}
+}
+
+func TestBuildBackupJobObjMetaForPod(t *testing.T) {
+	backup := &dpv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mybackup", UID: "backup-uid"}}
+	targetPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-mysql-0"}}
+	assert.Empty(t, buildBackupJobObjMetaForPod(backup, "dp-backup", targetPod).Annotations)
+
+	targetPod.Annotations = map[string]string{constant.ServiceMeshAnnotationKey: "Istio"}
+	assert.Equal(t, map[string]string{"sidecar.istio.io/inject": "false"},
+		buildBackupJobObjMetaForPod(backup, "dp-backup", targetPod).Annotations)
+
+	targetPod.Annotations = map[string]string{constant.ServiceMeshAnnotationKey: "Linkerd"}
+	assert.Equal(t, map[string]string{"linkerd.io/inject": "disabled"},
+		buildBackupJobObjMetaForPod(backup, "dp-backup", targetPod).Annotations)
+}
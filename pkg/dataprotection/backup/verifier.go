@@ -0,0 +1,173 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	verifyBackupJobNamePrefix = "verify-"
+)
+
+type Verifier struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// VerifyBackup builds a job to verify the backup data by the verify action of
+// the actionSet. It returns true if the verification job is finished, and an
+// error if the verification job failed.
+func (v *Verifier) VerifyBackup(backup *dpv1alpha1.Backup, actionSet *dpv1alpha1.ActionSet) (bool, error) {
+	jobKey := BuildVerifyBackupJobKey(backup)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(v.Ctx, v.Client, jobKey, job)
+	if err != nil {
+		return false, err
+	}
+
+	// if verification job exists, check its status
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return true, fmt.Errorf("verification backup job \"%s\" failed, %s", job.Name, msg)
+		}
+		return false, nil
+	}
+
+	if actionSet.Spec.Backup == nil || actionSet.Spec.Backup.VerifyBackup == nil {
+		return true, fmt.Errorf("actionSet %s does not define the verify action", actionSet.Name)
+	}
+	backupRepo := &dpv1alpha1.BackupRepo{}
+	if err = v.Client.Get(v.Ctx, client.ObjectKey{Name: backup.Status.BackupRepoName}, backupRepo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, fmt.Errorf("backup repo %s not found", backup.Status.BackupRepoName)
+		}
+		return false, err
+	}
+	return false, v.createVerifyBackupJob(jobKey, backup, backupRepo, actionSet)
+}
+
+// DeleteVerifyBackupJob deletes the verification job of the backup.
+func (v *Verifier) DeleteVerifyBackupJob(backup *dpv1alpha1.Backup) error {
+	job := &batchv1.Job{}
+	if err := v.Client.Get(v.Ctx, BuildVerifyBackupJobKey(backup), job); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return ctrlutil.BackgroundDeleteObject(v.Client, v.Ctx, job)
+}
+
+func (v *Verifier) createVerifyBackupJob(jobKey client.ObjectKey,
+	backup *dpv1alpha1.Backup,
+	backupRepo *dpv1alpha1.BackupRepo,
+	actionSet *dpv1alpha1.ActionSet) error {
+	verifyAction := actionSet.Spec.Backup.VerifyBackup
+	runAsUser := int64(0)
+	envVars := []corev1.EnvVar{
+		{Name: dptypes.DPBackupBasePath, Value: backup.Status.Path},
+		{Name: dptypes.DPBackupName, Value: backup.Name},
+		{Name: dptypes.DPParentBackupName, Value: backup.Spec.ParentBackupName},
+	}
+	envVars = append(envVars, actionSet.Spec.Env...)
+	container := corev1.Container{
+		Name:            backup.Name,
+		Command:         verifyAction.Command,
+		Image:           common.Expand(verifyAction.Image, common.MappingFuncFor(utils.CovertEnvToMap(envVars))),
+		Env:             envVars,
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	// build pod
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: v.WorkerServiceAccount,
+	}
+	if err := utils.AddTolerations(&podSpec); err != nil {
+		return err
+	}
+	if err := ctrlutil.ApplyDataPlaneSecurityContext(&podSpec); err != nil {
+		return err
+	}
+	utils.InjectDatasafed(&podSpec, backupRepo, RepoVolumeMountPath,
+		backup.Status.EncryptionConfig, backup.Status.KopiaRepoPath)
+
+	// build job
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels: map[string]string{
+				constant.AppManagedByLabelKey: dptypes.AppName,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   jobKey.Namespace,
+					Name:        jobKey.Name,
+					Annotations: ctrlutil.BuildServiceMeshJobAnnotations(backup.Annotations[constant.ServiceMeshAnnotationKey]),
+				},
+				Spec: podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	if err := utils.SetControllerReference(backup, job, v.Scheme); err != nil {
+		return err
+	}
+	v.Log.V(1).Info("create a job to verify backup", "job", job)
+	return client.IgnoreAlreadyExists(v.Client.Create(v.Ctx, job))
+}
+
+func BuildVerifyBackupJobKey(backup *dpv1alpha1.Backup) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s%s", backup.UID[:8], verifyBackupJobNamePrefix, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
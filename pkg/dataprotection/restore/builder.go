@@ -33,6 +33,8 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
@@ -60,17 +62,20 @@ type restoreJobBuilder struct {
 	jobName              string
 	labels               map[string]string
 	serviceAccount       string
+	// serviceMeshProvider is the provider of the service mesh which the restored cluster works with.
+	serviceMeshProvider string
 }
 
 func newRestoreJobBuilder(restore *dpv1alpha1.Restore, backupSet BackupActionSet, backupRepo *dpv1alpha1.BackupRepo, stage dpv1alpha1.RestoreStage) *restoreJobBuilder {
 	return &restoreJobBuilder{
-		restore:            restore,
-		backupSet:          backupSet,
-		backupRepo:         backupRepo,
-		stage:              stage,
-		commonVolumes:      []corev1.Volume{},
-		commonVolumeMounts: []corev1.VolumeMount{},
-		labels:             BuildRestoreLabels(restore.Name),
+		restore:             restore,
+		backupSet:           backupSet,
+		backupRepo:          backupRepo,
+		stage:               stage,
+		commonVolumes:       []corev1.Volume{},
+		commonVolumeMounts:  []corev1.VolumeMount{},
+		labels:              BuildRestoreLabels(restore.Name),
+		serviceMeshProvider: restore.Annotations[constant.ServiceMeshAnnotationKey],
 	}
 }
 
@@ -172,6 +177,14 @@ func (r *restoreJobBuilder) addLabel(key, value string) *restoreJobBuilder {
 	return r
 }
 
+// setServiceMeshProvider sets the provider of the service mesh from the target pod if it is not specified by the restore.
+func (r *restoreJobBuilder) setServiceMeshProvider(pod *corev1.Pod) *restoreJobBuilder {
+	if r.serviceMeshProvider == "" && pod != nil {
+		r.serviceMeshProvider = pod.Annotations[constant.ServiceMeshAnnotationKey]
+	}
+	return r
+}
+
 func (r *restoreJobBuilder) setServiceAccount(serviceAccount string) *restoreJobBuilder {
 	r.serviceAccount = serviceAccount
 	return r
@@ -194,6 +207,8 @@ func (r *restoreJobBuilder) addCommonEnv() *restoreJobBuilder {
 		r.env = append(r.env, corev1.EnvVar{Name: dptypes.DPBackupBasePath, Value: filePath})
 		// TODO: add continuous file path env
 	}
+	// add compression envs, the restore tool should decompress the data with the same algorithm
+	r.env = append(r.env, utils.BuildEnvByCompressionConfig(backup.Status.CompressionConfig)...)
 	// add time env
 	actionSetEnv := r.backupSet.ActionSet.Spec.Env
 	timeFormat := getTimeFormat(actionSetEnv)
@@ -294,9 +309,14 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 		RunAsUser: &runUser,
 	}
 	podSpec.RestartPolicy = corev1.RestartPolicyNever
+	// the scheduling settings of the job pod template only take effect for the
+	// prepareData stage when the restore does not specify them.
+	overrideScheduling := false
 	if r.stage == dpv1alpha1.PrepareData {
 		// set scheduling spec
 		schedulingSpec := r.restore.Spec.PrepareDataConfig.SchedulingSpec
+		overrideScheduling = len(schedulingSpec.Tolerations) == 0 && len(schedulingSpec.NodeSelector) == 0 &&
+			schedulingSpec.Affinity == nil && schedulingSpec.NodeName == ""
 		podSpec.Tolerations = schedulingSpec.Tolerations
 		podSpec.Affinity = schedulingSpec.Affinity
 		podSpec.NodeSelector = schedulingSpec.NodeSelector
@@ -310,10 +330,17 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 	r.specificVolumes = append(r.specificVolumes, r.commonVolumes...)
 	podSpec.Volumes = r.specificVolumes
 	podSpec.ServiceAccountName = r.serviceAccount
+	jobPodTemplate := r.backupSet.Backup.Status.JobPodTemplate
+	utils.ApplyJobPodTemplate(&podSpec, jobPodTemplate, overrideScheduling)
+	if r.restore.Spec.ServiceAccountName != "" {
+		// the service account specified by the restore takes precedence.
+		podSpec.ServiceAccountName = r.serviceAccount
+	}
 
 	job.Spec.Template.Spec = podSpec
 	job.Spec.Template.ObjectMeta = metav1.ObjectMeta{
-		Labels: r.labels,
+		Labels:      r.labels,
+		Annotations: intctrlutil.BuildServiceMeshJobAnnotations(r.serviceMeshProvider),
 	}
 	if r.restore.Spec.BackoffLimit != nil {
 		job.Spec.BackoffLimit = r.restore.Spec.BackoffLimit
@@ -323,9 +350,14 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 
 	// 2. set restore container
 	r.specificVolumeMounts = append(r.specificVolumeMounts, r.commonVolumeMounts...)
+	resources := r.restore.Spec.ContainerResources
+	if resources.Limits == nil && resources.Requests == nil &&
+		jobPodTemplate != nil && jobPodTemplate.Resources != nil {
+		resources = *jobPodTemplate.Resources
+	}
 	container := corev1.Container{
 		Name:         Restore,
-		Resources:    r.restore.Spec.ContainerResources,
+		Resources:    resources,
 		Env:          r.env,
 		EnvFrom:      r.envFrom,
 		VolumeMounts: r.specificVolumeMounts,
@@ -372,6 +404,9 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 
 	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
 	job.Spec.Template.Spec.Containers = []corev1.Container{container}
+	// the settings are validated when the controller starts, so the error is ignored here.
+	_ = intctrlutil.ApplyDataPlaneSecurityContext(&job.Spec.Template.Spec)
+	_ = secretstore.InjectPodDelivery(job.Namespace, &job.Spec.Template.Spec)
 	controllerutil.AddFinalizer(job, dptypes.DataProtectionFinalizerName)
 
 	// 3. inject datasafed if needed
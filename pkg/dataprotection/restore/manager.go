@@ -563,6 +563,7 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 			setCommand(actionSpec.Job.Command).
 			setToleration(targetPod.Spec.Tolerations).
 			addTargetPodAndCredentialEnv(targetPod, r.Restore.Spec.ReadyConfig.ConnectionCredential).
+			setServiceMeshProvider(targetPod).
 			setServiceAccount(r.WorkerServiceAccount).
 			build()
 		return []*batchv1.Job{job}, nil
@@ -586,7 +587,8 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 			args := append([]string{"-n", targetPodList.Items[i].Namespace, "exec", targetPodList.Items[i].Name, "-c", containerName, "--"}, actionSpec.Exec.Command...)
 			jobBuilder.setImage(viper.GetString(constant.KBToolsImage)).setCommand([]string{"kubectl"}).setArgs(args).
 				setJobName(buildJobName(i)).
-				setToleration(targetPodList.Items[i].Spec.Tolerations)
+				setToleration(targetPodList.Items[i].Spec.Tolerations).
+				setServiceMeshProvider(&targetPodList.Items[i])
 			job := jobBuilder.build()
 			// create exec job in kubeblocks namespace for security
 			kbInstalledNamespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
@@ -35,6 +35,8 @@ const (
 	CfgKeyWorkerClusterRoleName = "WORKER_CLUSTER_ROLE_NAME"
 	// CfgDataProtectionReconcileWorkers the max reconcile workers for MaxConcurrentReconciles
 	CfgDataProtectionReconcileWorkers = "DATAPROTECTION_RECONCILE_WORKERS"
+	// CfgBackupReconcileWorkers the max reconcile workers of the backup controller, default to DATAPROTECTION_RECONCILE_WORKERS
+	CfgBackupReconcileWorkers = "BACKUP_RECONCILE_WORKERS"
 )
 
 // config default values
@@ -108,8 +110,16 @@ const (
 	DPBackupName = "DP_BACKUP_NAME"
 	// DPParentBackupName backup CR name
 	DPParentBackupName = "DP_PARENT_BACKUP_NAME"
+	// DPParentBackupBasePath the base path for the parent backup data in the storage
+	DPParentBackupBasePath = "DP_PARENT_BACKUP_BASE_PATH"
 	// DPTTL backup time to live, reference the backup.spec.retentionPeriod
 	DPTTL = "DP_TTL"
+	// DPCompressionAlgorithm the algorithm for compressing backup data
+	DPCompressionAlgorithm = "DP_COMPRESSION_ALGORITHM"
+	// DPCompressionLevel the level for compressing backup data
+	DPCompressionLevel = "DP_COMPRESSION_LEVEL"
+	// DPBandwidthLimit the maximum bandwidth in bytes per second for transferring backup data
+	DPBandwidthLimit = "DP_BANDWIDTH_LIMIT"
 	// DPCheckInterval check interval for sync backup progress
 	DPCheckInterval = "DP_CHECK_INTERVAL"
 	// DPBackupInfoFile the file name which retains the backup.status info
@@ -23,6 +23,7 @@ import (
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -69,3 +70,22 @@ func buildEnvBySecretKey(name, secretName, key string) corev1.EnvVar {
 		},
 	}
 }
+
+// BuildEnvByCompressionConfig builds the envs which pass the compression config to the backup tool.
+func BuildEnvByCompressionConfig(config *dpv1alpha1.CompressionConfig) []corev1.EnvVar {
+	if config == nil {
+		return nil
+	}
+	envVars := []corev1.EnvVar{{Name: dptypes.DPCompressionAlgorithm, Value: string(config.Algorithm)}}
+	if config.Level != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: dptypes.DPCompressionLevel, Value: strconv.Itoa(int(*config.Level))})
+	}
+	return envVars
+}
+
+func BuildEnvByBandwidthLimit(limit *resource.Quantity) []corev1.EnvVar {
+	if limit == nil || limit.Sign() <= 0 {
+		return nil
+	}
+	return []corev1.EnvVar{{Name: dptypes.DPBandwidthLimit, Value: strconv.FormatInt(limit.Value(), 10)}}
+}
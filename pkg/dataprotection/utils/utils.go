@@ -64,6 +64,32 @@ func AddTolerations(podSpec *corev1.PodSpec) (err error) {
 	return nil
 }
 
+// ApplyJobPodTemplate overrides the pod settings of the backup or restore job
+// by the job pod template. The scheduling settings are overridden only if
+// overrideScheduling is true.
+func ApplyJobPodTemplate(podSpec *corev1.PodSpec, template *dpv1alpha1.JobPodTemplate, overrideScheduling bool) {
+	if template == nil {
+		return
+	}
+	if overrideScheduling {
+		if len(template.NodeSelector) > 0 {
+			podSpec.NodeSelector = template.NodeSelector
+		}
+		if len(template.Tolerations) > 0 {
+			podSpec.Tolerations = template.Tolerations
+		}
+	}
+	if template.ServiceAccountName != "" {
+		podSpec.ServiceAccountName = template.ServiceAccountName
+	}
+	if template.SecurityContext != nil {
+		podSpec.SecurityContext = template.SecurityContext
+	}
+	if len(template.ImagePullSecrets) > 0 {
+		podSpec.ImagePullSecrets = template.ImagePullSecrets
+	}
+}
+
 // IsJobFinished if the job is completed or failed, return true.
 // if the job is failed, return the failed message.
 func IsJobFinished(job *batchv1.Job) (bool, batchv1.JobConditionType, string) {
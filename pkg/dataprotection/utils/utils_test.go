@@ -24,8 +24,10 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/version"
 
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 )
 
@@ -81,3 +83,39 @@ func TestGetKubeVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyJobPodTemplate(t *testing.T) {
+	runAsNonRoot := true
+	template := &dpv1alpha1.JobPodTemplate{
+		NodeSelector:       map[string]string{"node-role": "backup"},
+		Tolerations:        []corev1.Toleration{{Key: "backup", Operator: corev1.TolerationOpExists}},
+		ServiceAccountName: "backup-sa",
+		SecurityContext:    &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+		ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry"}},
+	}
+	newPodSpec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			NodeSelector:       map[string]string{corev1.LabelHostname: "node-1"},
+			Tolerations:        []corev1.Toleration{{Key: "default", Operator: corev1.TolerationOpExists}},
+			ServiceAccountName: "worker-sa",
+		}
+	}
+
+	podSpec := newPodSpec()
+	ApplyJobPodTemplate(podSpec, nil, true)
+	assert.Equal(t, newPodSpec(), podSpec)
+
+	podSpec = newPodSpec()
+	ApplyJobPodTemplate(podSpec, template, true)
+	assert.Equal(t, template.NodeSelector, podSpec.NodeSelector)
+	assert.Equal(t, template.Tolerations, podSpec.Tolerations)
+	assert.Equal(t, template.ServiceAccountName, podSpec.ServiceAccountName)
+	assert.Equal(t, template.SecurityContext, podSpec.SecurityContext)
+	assert.Equal(t, template.ImagePullSecrets, podSpec.ImagePullSecrets)
+
+	podSpec = newPodSpec()
+	ApplyJobPodTemplate(podSpec, template, false)
+	assert.Equal(t, newPodSpec().NodeSelector, podSpec.NodeSelector)
+	assert.Equal(t, newPodSpec().Tolerations, podSpec.Tolerations)
+	assert.Equal(t, template.ServiceAccountName, podSpec.ServiceAccountName)
+}
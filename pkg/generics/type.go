@@ -102,6 +102,8 @@ var ComponentDefinitionSignature = func(appsv1alpha1.ComponentDefinition, *appsv
 }
 var OpsDefinitionSignature = func(_ appsv1alpha1.OpsDefinition, _ *appsv1alpha1.OpsDefinition, _ appsv1alpha1.OpsDefinitionList, _ *appsv1alpha1.OpsDefinitionList) {
 }
+var DatabaseUserSignature = func(_ appsv1alpha1.DatabaseUser, _ *appsv1alpha1.DatabaseUser, _ appsv1alpha1.DatabaseUserList, _ *appsv1alpha1.DatabaseUserList) {
+}
 var OpsRequestSignature = func(_ appsv1alpha1.OpsRequest, _ *appsv1alpha1.OpsRequest, _ appsv1alpha1.OpsRequestList, _ *appsv1alpha1.OpsRequestList) {
 }
 var ConfigConstraintSignature = func(_ appsv1alpha1.ConfigConstraint, _ *appsv1alpha1.ConfigConstraint, _ appsv1alpha1.ConfigConstraintList, _ *appsv1alpha1.ConfigConstraintList) {
@@ -134,12 +134,12 @@ func (cli *lorryClient) DescribeUser(ctx context.Context, userName string) (map[
 	if err != nil {
 		return nil, err
 	}
-	user, ok := resp["user"]
+	user, ok := resp["user"].(map[string]any)
 	if !ok {
 		return nil, nil
 	}
 
-	return user.(map[string]any), nil
+	return user, nil
 }
 
 func (cli *lorryClient) GrantUserRole(ctx context.Context, userName, roleName string) error {
@@ -162,6 +162,18 @@ func (cli *lorryClient) RevokeUserRole(ctx context.Context, userName, roleName s
 	return err
 }
 
+func (cli *lorryClient) RotateAccountPassword(ctx context.Context, userName, password, oldPassword string, retainOldPassword bool) error {
+	parameters := map[string]any{
+		"userName":          userName,
+		"password":          password,
+		"oldPassword":       oldPassword,
+		"retainOldPassword": retainOldPassword,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(AccountPasswordRotationOp), http.MethodPost, req)
+	return err
+}
+
 func (cli *lorryClient) Switchover(ctx context.Context, primary, candidate string, force bool) error {
 	parameters := map[string]any{
 		"primary":   primary,
@@ -241,6 +241,20 @@ func (mr *MockClientMockRecorder) RevokeUserRole(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRole", reflect.TypeOf((*MockClient)(nil).RevokeUserRole), arg0, arg1, arg2)
 }
 
+// RotateAccountPassword mocks base method.
+func (m *MockClient) RotateAccountPassword(arg0 context.Context, arg1, arg2, arg3 string, arg4 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateAccountPassword", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateAccountPassword indicates an expected call of RotateAccountPassword.
+func (mr *MockClientMockRecorder) RotateAccountPassword(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateAccountPassword", reflect.TypeOf((*MockClient)(nil).RotateAccountPassword), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Switchover mocks base method.
 func (m *MockClient) Switchover(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
 	m.ctrl.T.Helper()
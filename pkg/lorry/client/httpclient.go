@@ -28,6 +28,7 @@ import (
 	"net"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -36,11 +37,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/apecloud/kubeblocks/pkg/controller/tracing"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
 const (
-	urlTemplate = "http://%s:%d/v1.0/"
+	urlTemplate = "http://%s/v1.0/"
 )
 
 var NotImplemented = errors.New("NotImplemented")
@@ -92,7 +94,7 @@ func NewHTTPClientWithPod(pod *corev1.Pod) (*HTTPClient, error) {
 
 	operationClient := &HTTPClient{
 		Client:           client,
-		URL:              fmt.Sprintf(urlTemplate, ip, port),
+		URL:              fmt.Sprintf(urlTemplate, net.JoinHostPort(ip, strconv.Itoa(int(port)))),
 		CacheTTL:         60 * time.Second,
 		RequestTimeout:   30 * time.Second,
 		ReconcileTimeout: 500 * time.Millisecond,
@@ -134,6 +136,13 @@ func NewHTTPClientWithURL(url string) (*HTTPClient, error) {
 }
 
 func (cli *HTTPClient) Request(ctx context.Context, operation, method string, req map[string]any) (map[string]any, error) {
+	ctx, span := tracing.StartSpan(ctx, "lorry."+strings.ToLower(operation))
+	resp, err := cli.request(ctx, operation, method, req)
+	tracing.EndSpan(span, err)
+	return resp, err
+}
+
+func (cli *HTTPClient) request(ctx context.Context, operation, method string, req map[string]any) (map[string]any, error) {
 	ctxWithReconcileTimeout, cancel := context.WithTimeout(ctx, cli.ReconcileTimeout)
 	defer cancel()
 
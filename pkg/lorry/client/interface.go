@@ -34,6 +34,10 @@ type Client interface {
 	ListUsers(ctx context.Context) ([]map[string]any, error)
 	ListSystemAccounts(ctx context.Context) ([]map[string]any, error)
 
+	// RotateAccountPassword changes the password of the account to the new one by the accountPasswordRotation action,
+	// the old password is still accepted until the action is called again with retainOldPassword false.
+	RotateAccountPassword(ctx context.Context, userName, password, oldPassword string, retainOldPassword bool) error
+
 	// JoinMember sends a join member operation request to Lorry, located on the target pod that is about to join.
 	JoinMember(ctx context.Context) error
 
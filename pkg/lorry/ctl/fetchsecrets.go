@@ -0,0 +1,72 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package ctl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/secretstore"
+)
+
+type FetchSecretsOptions struct {
+	refs      []string
+	outputDir string
+}
+
+var fetchSecretsOptions = &FetchSecretsOptions{}
+var FetchSecretsCmd = &cobra.Command{
+	Use:   "fetch-secrets",
+	Short: "fetch the sealed secrets from the external secret manager.",
+	Example: `
+lorryctl fetch-secrets --ref mycluster-mysql-account-root=kubeblocks/default/mycluster-mysql-account-root --output-dir /kb-secrets
+  `,
+	Args: cobra.MinimumNArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		refs := map[string]string{}
+		for _, ref := range fetchSecretsOptions.refs {
+			name, value, ok := strings.Cut(ref, "=")
+			if !ok || name == "" || value == "" {
+				fmt.Printf("invalid secret reference: %s\n", ref)
+				os.Exit(1)
+			}
+			refs[name] = value
+		}
+		// the settings of the secret manager are passed by the environment variables without the prefix of lorryctl.
+		secretstore.LoadProviderEnvs()
+		if err := secretstore.FetchSecrets(context.Background(), refs, fetchSecretsOptions.outputDir); err != nil {
+			fmt.Printf("fetch secrets failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("fetch secrets success\n")
+	},
+}
+
+func init() {
+	FetchSecretsCmd.Flags().StringArrayVarP(&fetchSecretsOptions.refs, "ref", "r", nil, "The reference to the sealed values of a secret, in the form of <secret name>=<reference>")
+	FetchSecretsCmd.Flags().StringVarP(&fetchSecretsOptions.outputDir, "output-dir", "o", "/kb-secrets", "The directory to write the values into")
+	FetchSecretsCmd.Flags().BoolP("help", "h", false, "Print this help message")
+
+	RootCmd.AddCommand(FetchSecretsCmd)
+}
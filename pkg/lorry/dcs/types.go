@@ -21,6 +21,7 @@ package dcs
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -91,7 +92,7 @@ func (c *Cluster) IsLocked() bool {
 
 func (c *Cluster) GetMemberAddrWithPort(member Member) string {
 	addr := c.GetMemberAddr(member)
-	return fmt.Sprintf("%s:%s", addr, member.DBPort)
+	return net.JoinHostPort(addr, member.DBPort)
 }
 
 func (c *Cluster) GetMemberAddr(member Member) string {
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,9 +35,19 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/lorry/dcs"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
 	"github.com/apecloud/kubeblocks/pkg/lorry/util"
 )
 
+// the operations of the accountProvision action
+const (
+	accountOperationCreate     = "create"
+	accountOperationDelete     = "delete"
+	accountOperationDescribe   = "describe"
+	accountOperationGrantRole  = "grantRole"
+	accountOperationRevokeRole = "revokeRole"
+)
+
 type Manager struct {
 	engines.DBManagerBase
 
@@ -264,6 +275,102 @@ func (mgr *Manager) PostProvision(ctx context.Context, componentNames, podNames,
 	return err
 }
 
+// RotateAccountPassword provides the following dedicated environment variables for the action:
+//
+// - KB_ACCOUNT_NAME: The name of the account to rotate the password.
+// - KB_ACCOUNT_PASSWORD: The new password of the account.
+// - KB_ACCOUNT_OLD_PASSWORD: The old password of the account, it's empty when discarding the old password.
+// - KB_ACCOUNT_RETAIN_OLD_PASSWORD: Whether the old password should still be accepted, "true" or "false".
+func (mgr *Manager) RotateAccountPassword(ctx context.Context, userName, password, oldPassword string, retainOldPassword bool) error {
+	rotationCmd, ok := mgr.actionCommands[constant.AccountPasswordRotationAction]
+	if !ok || len(rotationCmd) == 0 {
+		return errors.New("account password rotation command is empty!")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return err
+	}
+
+	envs = append(envs, "KB_ACCOUNT_NAME"+"="+userName)
+	envs = append(envs, "KB_ACCOUNT_PASSWORD"+"="+password)
+	envs = append(envs, "KB_ACCOUNT_OLD_PASSWORD"+"="+oldPassword)
+	envs = append(envs, "KB_ACCOUNT_RETAIN_OLD_PASSWORD"+"="+strconv.FormatBool(retainOldPassword))
+	output, err := util.ExecCommand(ctx, rotationCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("account password rotation", "output", output)
+	}
+	return err
+}
+
+// CreateUser creates the account by the accountProvision action with the operation "create".
+func (mgr *Manager) CreateUser(ctx context.Context, userName, password string) error {
+	_, err := mgr.provisionAccount(ctx, accountOperationCreate, userName, "KB_ACCOUNT_PASSWORD="+password)
+	return err
+}
+
+// DeleteUser deletes the account by the accountProvision action with the operation "delete".
+func (mgr *Manager) DeleteUser(ctx context.Context, userName string) error {
+	_, err := mgr.provisionAccount(ctx, accountOperationDelete, userName)
+	return err
+}
+
+// DescribeUser describes the account by the accountProvision action with the operation "describe",
+// it returns nil if the action prints nothing, which means the account does not exist.
+func (mgr *Manager) DescribeUser(ctx context.Context, userName string) (*models.UserInfo, error) {
+	output, err := mgr.provisionAccount(ctx, accountOperationDescribe, userName)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(output)) == 0 {
+		return nil, nil
+	}
+	user := &models.UserInfo{}
+	if err = json.Unmarshal([]byte(output), user); err != nil {
+		return nil, errors.Wrapf(err, "invalid output of the account provision action: %s", output)
+	}
+	return user, nil
+}
+
+// GrantUserRole grants the role to the account by the accountProvision action with the operation "grantRole".
+func (mgr *Manager) GrantUserRole(ctx context.Context, userName, roleName string) error {
+	_, err := mgr.provisionAccount(ctx, accountOperationGrantRole, userName, "KB_ACCOUNT_ROLE="+roleName)
+	return err
+}
+
+// RevokeUserRole revokes the role from the account by the accountProvision action with the operation "revokeRole".
+func (mgr *Manager) RevokeUserRole(ctx context.Context, userName, roleName string) error {
+	_, err := mgr.provisionAccount(ctx, accountOperationRevokeRole, userName, "KB_ACCOUNT_ROLE="+roleName)
+	return err
+}
+
+// provisionAccount provides the following dedicated environment variables for the accountProvision action:
+//
+// - KB_ACCOUNT_OPERATION: The operation to perform, one of "create", "delete", "describe", "grantRole" and "revokeRole".
+// - KB_ACCOUNT_NAME: The name of the account.
+// - KB_ACCOUNT_PASSWORD: The password of the account, for the "create" operation only.
+// - KB_ACCOUNT_ROLE: The role to grant or revoke, for the "grantRole" and "revokeRole" operations only.
+func (mgr *Manager) provisionAccount(ctx context.Context, operation, userName string, extraEnvs ...string) (string, error) {
+	provisionCmd, ok := mgr.actionCommands[constant.AccountProvisionAction]
+	if !ok || len(provisionCmd) == 0 {
+		return "", errors.New("account provision command is empty!")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return "", err
+	}
+
+	envs = append(envs, "KB_ACCOUNT_OPERATION"+"="+operation)
+	envs = append(envs, "KB_ACCOUNT_NAME"+"="+userName)
+	envs = append(envs, extraEnvs...)
+	output, err := util.ExecCommand(ctx, provisionCmd, envs)
+
+	if output != "" && operation != accountOperationDescribe {
+		mgr.Logger.Info("account provision", "operation", operation, "output", output)
+	}
+	return output, err
+}
+
 // PreTerminate provides the following dedicated environment variables for the action:
 //
 // - KB_POD_FQDN: The FQDN of the replica pod to check the role.
@@ -32,6 +32,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -69,6 +70,44 @@ var _ = Describe("ETCD DBManager", func() {
 			Expect(snapshot.Version).Should(Equal("1"))
 		})
 	})
+
+	Context("account provision", func() {
+		It("manages the users by the action", func() {
+			for _, env := range []string{constant.KBEnvPodFQDN, constant.KBEnvServicePort, constant.KBEnvServiceUser, constant.KBEnvServicePassword} {
+				GinkgoT().Setenv(env, "test")
+			}
+			script := `case "$KB_ACCOUNT_OPERATION" in
+describe) [ "$KB_ACCOUNT_NAME" = "foo" ] && echo '{"userName": "foo", "roleName": "readonly"}'; exit 0;;
+create) [ -n "$KB_ACCOUNT_PASSWORD" ];;
+grantRole|revokeRole) [ "$KB_ACCOUNT_ROLE" = "readonly" ];;
+delete) [ "$KB_ACCOUNT_NAME" = "foo" ];;
+*) exit 1;;
+esac`
+			manager, err := NewManager(nil)
+			Expect(err).Should(Succeed())
+			mgr := manager.(*Manager)
+			mgr.actionCommands = map[string][]string{constant.AccountProvisionAction: {"/bin/sh", "-c", script}}
+
+			user, err := mgr.DescribeUser(context.TODO(), "foo")
+			Expect(err).Should(Succeed())
+			Expect(user).ShouldNot(BeNil())
+			Expect(user.RoleName).Should(Equal("readonly"))
+			user, err = mgr.DescribeUser(context.TODO(), "bar")
+			Expect(err).Should(Succeed())
+			Expect(user).Should(BeNil())
+
+			Expect(mgr.CreateUser(context.TODO(), "foo", "passwd")).Should(Succeed())
+			Expect(mgr.CreateUser(context.TODO(), "foo", "")).ShouldNot(Succeed())
+			Expect(mgr.GrantUserRole(context.TODO(), "foo", "readonly")).Should(Succeed())
+			Expect(mgr.RevokeUserRole(context.TODO(), "foo", "readonly")).Should(Succeed())
+			Expect(mgr.DeleteUser(context.TODO(), "foo")).Should(Succeed())
+			Expect(mgr.DeleteUser(context.TODO(), "bar")).ShouldNot(Succeed())
+
+			By("no action declared")
+			mgr.actionCommands = nil
+			Expect(mgr.CreateUser(context.TODO(), "foo", "passwd")).ShouldNot(Succeed())
+		})
+	})
 })
 
 func setUpHost() *httptest.Server {
@@ -23,6 +23,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/pkg/errors"
@@ -53,7 +54,7 @@ func (mgr *Manager) Failover(ctx context.Context, cluster *dcs.Cluster, candidat
 
 	candidateMember := candidateCluster.Members[0]
 
-	candidateAddr := fmt.Sprintf("%s:%s", candidateMember.PodIP, candidateMember.DBPort)
+	candidateAddr := net.JoinHostPort(candidateMember.PodIP, candidateMember.DBPort)
 	candidateDB, err := config.GetDBConnWithAddr(candidateAddr)
 	if err != nil {
 		mgr.Logger.Info("new candidatedb connection failed", "error", err)
@@ -23,6 +23,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -61,14 +62,14 @@ func (mgr *Manager) Switchover(ctx context.Context, cluster *dcs.Cluster, primar
 		return errors.Wrapf(err, "get candidate %s failed", candidate)
 	}
 
-	primaryAddr := fmt.Sprintf("%s:%s", primaryMember.PodIP, primaryMember.DBPort)
+	primaryAddr := net.JoinHostPort(primaryMember.PodIP, primaryMember.DBPort)
 	primaryDB, err := config.GetDBConnWithAddr(primaryAddr)
 	if err != nil {
 		mgr.Logger.Info("new primarydb connection failed", "error", err)
 		return err
 	}
 
-	candidateAddr := fmt.Sprintf("%s:%s", candidateMember.PodIP, candidateMember.DBPort)
+	candidateAddr := net.JoinHostPort(candidateMember.PodIP, candidateMember.DBPort)
 	candidateDB, err := config.GetDBConnWithAddr(candidateAddr)
 	if err != nil {
 		mgr.Logger.Info("new candidatedb connection failed", "error", err)
@@ -297,10 +298,10 @@ func (mgr *Manager) getConnWithMode(member *dcs.Member, compatibilityMode string
 	case MYSQL:
 		user = "root"
 		// "root@alice@tcp(10.1.0.47:2881)/oceanbase?multiStatements=true"
-		dsn = fmt.Sprintf("%s@%s@tcp(%s:%s)/oceanbase?multiStatements=true", user, mgr.ReplicaTenant, member.PodIP, member.DBPort)
+		dsn = fmt.Sprintf("%s@%s@tcp(%s)/oceanbase?multiStatements=true", user, mgr.ReplicaTenant, net.JoinHostPort(member.PodIP, member.DBPort))
 	case ORACLE:
 		user = "SYS"
-		dsn = fmt.Sprintf("%s@%s@tcp(%s:%s)/SYS?multiStatements=true", user, mgr.ReplicaTenant, member.PodIP, member.DBPort)
+		dsn = fmt.Sprintf("%s@%s@tcp(%s)/SYS?multiStatements=true", user, mgr.ReplicaTenant, net.JoinHostPort(member.PodIP, member.DBPort))
 	default:
 		err := errors.Errorf("the compatibility mode is invalid: %s", compatibilityMode)
 		return nil, err
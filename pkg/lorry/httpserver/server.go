@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 	"time"
 
 	fasthttprouter "github.com/fasthttp/router"
@@ -75,7 +76,7 @@ func (s *server) StartNonBlocking() error {
 		listeners = append(listeners, l)
 	} else {
 		apiListenAddress := s.config.Address
-		l, err := net.Listen("tcp", fmt.Sprintf("%s:%v", apiListenAddress, s.config.Port))
+		l, err := net.Listen("tcp", net.JoinHostPort(apiListenAddress, strconv.Itoa(s.config.Port)))
 		if err != nil {
 			logger.Error(err, "listen address", apiListenAddress, "port", s.config.Port)
 		} else {
@@ -27,7 +27,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
-	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
 	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
 	"github.com/apecloud/kubeblocks/pkg/lorry/util"
 )
@@ -48,7 +47,7 @@ func init() {
 }
 
 func (s *DeleteUser) Init(ctx context.Context) error {
-	dbManager, err := register.GetDBManager(nil)
+	dbManager, err := getUserManager()
 	if err != nil {
 		return errors.Wrap(err, "get manager failed")
 	}
@@ -26,8 +26,10 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
@@ -51,7 +53,7 @@ func init() {
 }
 
 func (s *DescribeUser) Init(ctx context.Context) error {
-	dbManager, err := register.GetDBManager(nil)
+	dbManager, err := getUserManager()
 	if err != nil {
 		return errors.Wrap(err, "get manager failed")
 	}
@@ -83,10 +85,35 @@ func (s *DescribeUser) Do(ctx context.Context, req *operations.OpsRequest) (*ope
 		return resp, err
 	}
 
-	resp.Data["user"] = result
+	if result != nil {
+		resp.Data["user"] = result
+	}
 	return resp.WithSuccess("")
 }
 
+// getActionCommand returns the command of the action declared in the ComponentDefinition, or nil if it's not declared.
+func getActionCommand(action string) ([]string, error) {
+	actionJSON := viper.GetString(constant.KBEnvActionCommands)
+	if actionJSON == "" {
+		return nil, nil
+	}
+	actionCommands := map[string][]string{}
+	if err := json.Unmarshal([]byte(actionJSON), &actionCommands); err != nil {
+		return nil, err
+	}
+	return actionCommands[action], nil
+}
+
+// getUserManager returns the custom manager if the accountProvision action is declared, which manages the users
+// by the action, otherwise the manager of the builtin engine.
+func getUserManager() (engines.DBManager, error) {
+	provisionCmd, err := getActionCommand(constant.AccountProvisionAction)
+	if err != nil {
+		return nil, err
+	}
+	return register.GetDBManager(provisionCmd)
+}
+
 func UserInfoParser(req *operations.OpsRequest) (*models.UserInfo, error) {
 	user := &models.UserInfo{}
 	if req == nil || req.Parameters == nil {
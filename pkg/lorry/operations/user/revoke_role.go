@@ -28,7 +28,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
-	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
 	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
 	"github.com/apecloud/kubeblocks/pkg/lorry/util"
 )
@@ -49,7 +48,7 @@ func init() {
 }
 
 func (s *RevokeRole) Init(ctx context.Context) error {
-	dbManager, err := register.GetDBManager(nil)
+	dbManager, err := getUserManager()
 	if err != nil {
 		return errors.Wrap(err, "get manager failed")
 	}
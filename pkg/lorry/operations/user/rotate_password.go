@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+type RotateAccountPassword struct {
+	operations.Base
+	logger  logr.Logger
+	Command []string
+}
+
+type AccountPasswordRotationManager interface {
+	RotateAccountPassword(ctx context.Context, userName, password, oldPassword string, retainOldPassword bool) error
+}
+
+var rotateAccountPassword operations.Operation = &RotateAccountPassword{}
+
+func init() {
+	err := operations.Register(strings.ToLower(string(util.AccountPasswordRotationOp)), rotateAccountPassword)
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func (s *RotateAccountPassword) Init(_ context.Context) error {
+	s.logger = ctrl.Log.WithName("RotateAccountPassword")
+	rotationCmd, err := getActionCommand(constant.AccountPasswordRotationAction)
+	if err != nil {
+		s.logger.Info("get action commands failed", "error", err.Error())
+		return err
+	}
+	s.Command = rotationCmd
+	return nil
+}
+
+func (s *RotateAccountPassword) IsReadonly(ctx context.Context) bool {
+	return false
+}
+
+func (s *RotateAccountPassword) PreCheck(ctx context.Context, req *operations.OpsRequest) error {
+	userInfo, err := UserInfoParser(req)
+	if err != nil {
+		return err
+	}
+	return userInfo.UserNameAndPasswdValidator()
+}
+
+func (s *RotateAccountPassword) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
+	userInfo, _ := UserInfoParser(req)
+	resp := operations.NewOpsResponse(util.AccountPasswordRotationOp)
+
+	manager, err := register.GetDBManager(s.Command)
+	if err != nil {
+		return nil, errors.Wrap(err, "get manager failed")
+	}
+	rotationManager, ok := manager.(AccountPasswordRotationManager)
+	if !ok {
+		return nil, models.ErrNoImplemented
+	}
+	err = rotationManager.RotateAccountPassword(ctx, userInfo.UserName, userInfo.Password,
+		req.GetString("oldPassword"), req.GetBool("retainOldPassword"))
+	if err != nil {
+		s.logger.Info("executing RotateAccountPassword error", "error", err)
+		return resp, err
+	}
+	return resp.WithSuccess("")
+}
@@ -64,6 +64,8 @@ const (
 	RevokeUserRoleOp     OperationKind = "revokeUserRole"
 	ListSystemAccountsOp OperationKind = "listSystemAccounts"
 
+	AccountPasswordRotationOp OperationKind = "accountPasswordRotation"
+
 	JoinMemberOperation  OperationKind = "joinMember"
 	LeaveMemberOperation OperationKind = "leaveMember"
 
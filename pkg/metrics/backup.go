@@ -0,0 +1,60 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var backupLabels = []string{"namespace", "cluster", "component", "backup_type"}
+
+var (
+	// BackupTotalBytes records the total size of the latest completed backup.
+	BackupTotalBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubeblocks_backup_total_bytes",
+		Help: "The total size of the latest completed backup, in bytes.",
+	}, backupLabels)
+
+	// BackupDurationSeconds records the duration of the latest completed backup.
+	BackupDurationSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubeblocks_backup_duration_seconds",
+		Help: "The duration of the latest completed backup, in seconds.",
+	}, backupLabels)
+
+	// BackupThroughputBytes records the average throughput of the latest completed backup.
+	BackupThroughputBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubeblocks_backup_throughput_bytes_per_second",
+		Help: "The average throughput of the latest completed backup, in bytes per second.",
+	}, backupLabels)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(BackupTotalBytes, BackupDurationSeconds, BackupThroughputBytes)
+}
+
+// DeleteBackupMetrics deletes the series of the backups of the component with the backup type, it's called
+// when the last backup of them is deleted.
+func DeleteBackupMetrics(namespace, cluster, component, backupType string) {
+	labels := prometheus.Labels{"namespace": namespace, "cluster": cluster, "component": component, "backup_type": backupType}
+	BackupTotalBytes.DeletePartialMatch(labels)
+	BackupDurationSeconds.DeletePartialMatch(labels)
+	BackupThroughputBytes.DeletePartialMatch(labels)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// BackupVerificationPassed records whether the latest backup verification of
+	// a backup policy passed, 1 for passed and 0 for failed.
+	BackupVerificationPassed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubeblocks_backup_verification_passed",
+		Help: "Whether the latest backup verification of the backup policy passed, 1 for passed and 0 for failed.",
+	}, []string{"namespace", "backup_policy", "backup_method"})
+
+	// BackupVerificationTimestamp records the completion time of the latest backup
+	// verification of a backup policy.
+	BackupVerificationTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubeblocks_backup_verification_timestamp_seconds",
+		Help: "The completion time of the latest backup verification of the backup policy, in unix seconds.",
+	}, []string{"namespace", "backup_policy", "backup_method"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(BackupVerificationPassed, BackupVerificationTimestamp)
+}
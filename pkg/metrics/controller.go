@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var componentLabels = []string{"namespace", "cluster", "component"}
+
+var (
+	// UpdatePlanStepsTotal records the number of pods selected to update by the update plans of the workloads.
+	UpdatePlanStepsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubeblocks_update_plan_steps_total",
+		Help: "The total number of pods selected to update by the update plans.",
+	}, componentLabels)
+
+	// PodsDeletedTotal records the number of pods deleted to update, by the member update strategy.
+	PodsDeletedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubeblocks_pods_deleted_total",
+		Help: "The total number of pods deleted to update, by the member update strategy.",
+	}, append(componentLabels, "strategy"))
+
+	// RoleChangesTotal records the number of role changes of pods observed, by the new role.
+	RoleChangesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubeblocks_role_changes_total",
+		Help: "The total number of role changes of pods observed, by the new role.",
+	}, append(componentLabels, "role"))
+
+	// OpsRequestDurationSeconds records the duration of the completed OpsRequests, by type and phase.
+	OpsRequestDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubeblocks_opsrequest_duration_seconds",
+		Help:    "The duration of the completed OpsRequests from the start to the completion, in seconds.",
+		Buckets: []float64{10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200, 21600},
+	}, []string{"namespace", "cluster", "type", "phase"})
+
+	// StatusPatchFailuresTotal records the number of failed status updates, by the controller.
+	StatusPatchFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubeblocks_status_patch_failures_total",
+		Help: "The total number of failed status updates of the objects, by the controller.",
+	}, append([]string{"controller"}, componentLabels...))
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(UpdatePlanStepsTotal, PodsDeletedTotal, RoleChangesTotal,
+		OpsRequestDurationSeconds, StatusPatchFailuresTotal)
+}
+
+// ComponentLabelValues returns the values of the namespace, cluster and component labels of the object.
+func ComponentLabelValues(obj client.Object) []string {
+	labels := obj.GetLabels()
+	return []string{obj.GetNamespace(), labels[constant.AppInstanceLabelKey], labels[constant.KBAppComponentLabelKey]}
+}
+
+// RecordStatusPatchFailure increases the counter of the failed status updates of the object.
+func RecordStatusPatchFailure(controller string, obj client.Object) {
+	StatusPatchFailuresTotal.WithLabelValues(append([]string{controller}, ComponentLabelValues(obj)...)...).Inc()
+}
+
+// DeleteClusterMetrics deletes the series of the cluster, it's called when the cluster is deleted,
+// otherwise the series of the deleted clusters are kept forever.
+func DeleteClusterMetrics(namespace, cluster string) {
+	labels := prometheus.Labels{"namespace": namespace, "cluster": cluster}
+	UpdatePlanStepsTotal.DeletePartialMatch(labels)
+	PodsDeletedTotal.DeletePartialMatch(labels)
+	RoleChangesTotal.DeletePartialMatch(labels)
+	OpsRequestDurationSeconds.DeletePartialMatch(labels)
+	StatusPatchFailuresTotal.DeletePartialMatch(labels)
+}
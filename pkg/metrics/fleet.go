@@ -0,0 +1,246 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// fleetCollectTimeout bounds the time spent listing the objects on a single scrape.
+const fleetCollectTimeout = 10 * time.Second
+
+var (
+	clustersDesc = prometheus.NewDesc("kubeblocks_clusters",
+		"The number of clusters, by phase.",
+		[]string{"namespace", "phase"}, nil)
+
+	componentsDesc = prometheus.NewDesc("kubeblocks_components",
+		"The number of cluster components, by workload type, definition and phase. The workload type is empty for the components of ComponentDefinition.",
+		[]string{"namespace", "workload_type", "definition", "phase"}, nil)
+
+	podsDesc = prometheus.NewDesc("kubeblocks_pods",
+		"The number of pods managed by KubeBlocks, by role.",
+		[]string{"namespace", "role"}, nil)
+
+	pendingOpsRequestsDesc = prometheus.NewDesc("kubeblocks_opsrequests_pending",
+		"The number of OpsRequests not completed yet, by type and phase.",
+		[]string{"namespace", "type", "phase"}, nil)
+)
+
+// pendingOpsPhases are the phases of the OpsRequests which are not completed yet.
+var pendingOpsPhases = map[appsv1alpha1.OpsPhase]bool{
+	appsv1alpha1.OpsPendingPhase:    true,
+	appsv1alpha1.OpsCreatingPhase:   true,
+	appsv1alpha1.OpsRunningPhase:    true,
+	appsv1alpha1.OpsCancellingPhase: true,
+}
+
+// FleetCollector exports the gauges of the clusters, components, pods and OpsRequests managed by KubeBlocks.
+// The gauges are computed from the objects on each scrape, so no state is kept between scrapes.
+//
+// Only the leader exports the gauges, and only of the objects in its shard, so that the gauges summed over
+// the operator replicas count each object once.
+type FleetCollector struct {
+	reader  client.Reader
+	elected <-chan struct{}
+}
+
+var _ prometheus.Collector = &FleetCollector{}
+
+// NewFleetCollector creates a FleetCollector listing the objects with the reader, it exports the gauges once
+// the elected channel is closed, or always if the channel is nil.
+func NewFleetCollector(reader client.Reader, elected <-chan struct{}) *FleetCollector {
+	return &FleetCollector{reader: reader, elected: elected}
+}
+
+// RegisterFleetMetric registers the fleet-level gauges to the metrics registry of the controller-runtime.
+func RegisterFleetMetric(mgr manager.Manager) {
+	if !constant.EnabledFleetMetrics() {
+		return
+	}
+	ctrlmetrics.Registry.MustRegister(NewFleetCollector(mgr.GetClient(), mgr.Elected()))
+}
+
+func (c *FleetCollector) isLeader() bool {
+	if c.elected == nil {
+		return true
+	}
+	select {
+	case <-c.elected:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *FleetCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clustersDesc
+	ch <- componentsDesc
+	ch <- podsDesc
+	ch <- pendingOpsRequestsDesc
+}
+
+func (c *FleetCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.isLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), fleetCollectTimeout)
+	defer cancel()
+
+	logger := log.FromContext(ctx).WithName("fleet-metrics")
+	if err := c.collectClusters(ctx, ch); err != nil {
+		logger.Error(err, "failed to collect the cluster metrics")
+	}
+	if err := c.collectPods(ctx, ch); err != nil {
+		logger.Error(err, "failed to collect the pod metrics")
+	}
+	if err := c.collectOpsRequests(ctx, ch); err != nil {
+		logger.Error(err, "failed to collect the OpsRequest metrics")
+	}
+}
+
+func (c *FleetCollector) collectClusters(ctx context.Context, ch chan<- prometheus.Metric) error {
+	clusterList := &appsv1alpha1.ClusterList{}
+	if err := c.reader.List(ctx, clusterList); err != nil {
+		return err
+	}
+	clusters := newCounter()
+	clusterMap := make(map[string]*appsv1alpha1.Cluster, len(clusterList.Items))
+	for i, cluster := range clusterList.Items {
+		if !intctrlutil.InCurrentShard(&clusterList.Items[i]) {
+			continue
+		}
+		clusters.inc(cluster.Namespace, string(cluster.Status.Phase))
+		clusterMap[cluster.Namespace+"/"+cluster.Name] = &clusterList.Items[i]
+	}
+	clusters.collect(ch, clustersDesc)
+
+	clusterDefList := &appsv1alpha1.ClusterDefinitionList{}
+	if err := c.reader.List(ctx, clusterDefList); err != nil {
+		return err
+	}
+	clusterDefs := make(map[string]*appsv1alpha1.ClusterDefinition, len(clusterDefList.Items))
+	for i := range clusterDefList.Items {
+		clusterDefs[clusterDefList.Items[i].Name] = &clusterDefList.Items[i]
+	}
+	compList := &appsv1alpha1.ComponentList{}
+	if err := c.reader.List(ctx, compList); err != nil {
+		return err
+	}
+	components := newCounter()
+	for _, comp := range compList.Items {
+		cluster := clusterMap[comp.Namespace+"/"+comp.Labels[constant.AppInstanceLabelKey]]
+		if cluster == nil {
+			// the cluster is not in this shard, or is deleted
+			continue
+		}
+		workloadType, definition := "", comp.Spec.CompDef
+		if len(definition) == 0 {
+			// the legacy component defined by the ClusterDefinition
+			workloadType, definition = legacyComponentType(cluster, clusterDefs[cluster.Spec.ClusterDefRef],
+				comp.Labels[constant.KBAppComponentLabelKey])
+		}
+		components.inc(comp.Namespace, workloadType, definition, string(comp.Status.Phase))
+	}
+	components.collect(ch, componentsDesc)
+	return nil
+}
+
+// legacyComponentType returns the workload type and the name of the component definition in the ClusterDefinition
+// of the legacy component.
+func legacyComponentType(cluster *appsv1alpha1.Cluster, clusterDef *appsv1alpha1.ClusterDefinition, compName string) (string, string) {
+	compSpec := cluster.Spec.GetComponentByName(compName)
+	if compSpec == nil {
+		return "", ""
+	}
+	if clusterDef != nil {
+		if compDef := clusterDef.GetComponentDefByName(compSpec.ComponentDefRef); compDef != nil {
+			return string(compDef.WorkloadType), compSpec.ComponentDefRef
+		}
+	}
+	return "", compSpec.ComponentDefRef
+}
+
+func (c *FleetCollector) collectPods(ctx context.Context, ch chan<- prometheus.Metric) error {
+	podList := &corev1.PodList{}
+	if err := c.reader.List(ctx, podList, client.MatchingLabels{constant.AppManagedByLabelKey: constant.AppName}); err != nil {
+		return err
+	}
+	pods := newCounter()
+	for i, pod := range podList.Items {
+		if !intctrlutil.InCurrentShard(&podList.Items[i]) {
+			continue
+		}
+		pods.inc(pod.Namespace, pod.Labels[constant.RoleLabelKey])
+	}
+	pods.collect(ch, podsDesc)
+	return nil
+}
+
+func (c *FleetCollector) collectOpsRequests(ctx context.Context, ch chan<- prometheus.Metric) error {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := c.reader.List(ctx, opsList); err != nil {
+		return err
+	}
+	opsRequests := newCounter()
+	for i, ops := range opsList.Items {
+		if !pendingOpsPhases[ops.Status.Phase] || !intctrlutil.InCurrentShard(&opsList.Items[i]) {
+			continue
+		}
+		opsRequests.inc(ops.Namespace, string(ops.Spec.Type), string(ops.Status.Phase))
+	}
+	opsRequests.collect(ch, pendingOpsRequestsDesc)
+	return nil
+}
+
+// counter counts the objects by the label values.
+type counter struct {
+	labelValues map[string][]string
+	counts      map[string]int
+}
+
+func newCounter() *counter {
+	return &counter{labelValues: map[string][]string{}, counts: map[string]int{}}
+}
+
+func (c *counter) inc(labelValues ...string) {
+	key := strings.Join(labelValues, "\x00")
+	c.labelValues[key] = labelValues
+	c.counts[key]++
+}
+
+func (c *counter) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc) {
+	for key, count := range c.counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count), c.labelValues[key]...)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (C) 2022-2023 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("fleet metrics", func() {
+	const (
+		clusterDefName     = "apecloud-mysql"
+		clusterVersionName = "apecloud-mysql-8.0"
+		mysqlCompDefName   = "mysql"
+		mysqlCompName      = "mysql"
+		legacyClusterName  = "legacy"
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PodSignature, true, inNS, ml)
+	}
+
+	createCluster := func(phase appsv1alpha1.ClusterPhase, factory *testapps.MockClusterFactory) {
+		cluster := factory.Create(&testCtx).GetObject()
+		Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
+			cluster.Status.Phase = phase
+		})).Should(Succeed())
+	}
+
+	createComponent := func(clusterName, compName, compDef string, phase appsv1alpha1.ClusterComponentPhase) {
+		comp := testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, compName), compDef).
+			AddLabelsInMap(constant.GetComponentWellKnownLabels(clusterName, compName)).
+			SetReplicas(1).
+			Create(&testCtx).
+			GetObject()
+		Expect(testapps.ChangeObjStatus(&testCtx, comp, func() {
+			comp.Status.Phase = phase
+		})).Should(Succeed())
+	}
+
+	createPod := func(name string, labels map[string]string) {
+		testapps.NewPodFactory(testCtx.DefaultNamespace, name).
+			AddLabelsInMap(labels).
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+			Create(&testCtx)
+	}
+
+	createOps := func(name string, opsType appsv1alpha1.OpsType, phase appsv1alpha1.OpsPhase) {
+		ops := testapps.NewOpsRequestObj(name, testCtx.DefaultNamespace, legacyClusterName, opsType)
+		switch opsType {
+		case appsv1alpha1.RestartType:
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: mysqlCompName}}
+		case appsv1alpha1.UpgradeType:
+			ops.Spec.Upgrade = &appsv1alpha1.Upgrade{ClusterVersionRef: clusterVersionName}
+		}
+		ops = testapps.CreateOpsRequest(ctx, testCtx, ops)
+		Expect(testapps.ChangeObjStatus(&testCtx, ops, func() {
+			ops.Status.Phase = phase
+		})).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+
+		testapps.NewClusterDefFactory(clusterDefName).
+			AddComponentDef(testapps.ConsensusMySQLComponent, mysqlCompDefName).
+			Create(&testCtx)
+		createCluster(appsv1alpha1.RunningClusterPhase,
+			testapps.NewClusterFactory(testCtx.DefaultNamespace, legacyClusterName, clusterDefName, clusterVersionName).
+				AddComponent(mysqlCompName, mysqlCompDefName))
+		createComponent(legacyClusterName, mysqlCompName, "", appsv1alpha1.RunningClusterCompPhase)
+		createCluster(appsv1alpha1.CreatingClusterPhase,
+			testapps.NewClusterFactory(testCtx.DefaultNamespace, "pg", "", "").
+				AddComponentV2("postgresql", "postgresql-14"))
+		createComponent("pg", "postgresql", "postgresql-14", appsv1alpha1.CreatingClusterCompPhase)
+		createCluster(appsv1alpha1.CreatingClusterPhase,
+			testapps.NewClusterFactory(testCtx.DefaultNamespace, "redis", "", "").
+				AddComponentV2("redis", "redis-7"))
+		createComponent("redis", "redis", "redis-7", appsv1alpha1.CreatingClusterCompPhase)
+
+		podLabels := func(role string) map[string]string {
+			labels := constant.GetComponentWellKnownLabels(legacyClusterName, mysqlCompName)
+			labels[constant.RoleLabelKey] = role
+			return labels
+		}
+		createPod("legacy-mysql-0", podLabels("leader"))
+		createPod("legacy-mysql-1", podLabels("follower"))
+		createPod("legacy-mysql-2", podLabels("follower"))
+		createPod("other", map[string]string{"app": "other"})
+
+		createOps("legacy-restart", appsv1alpha1.RestartType, appsv1alpha1.OpsRunningPhase)
+		createOps("legacy-upgrade", appsv1alpha1.UpgradeType, appsv1alpha1.OpsSucceedPhase)
+	})
+
+	AfterEach(cleanEnv)
+
+	It("exports the gauges of the objects", func() {
+		collector := NewFleetCollector(k8sClient, nil)
+
+		expected := fmt.Sprintf(`
+# HELP kubeblocks_clusters The number of clusters, by phase.
+# TYPE kubeblocks_clusters gauge
+kubeblocks_clusters{namespace="%[1]s",phase="Creating"} 2
+kubeblocks_clusters{namespace="%[1]s",phase="Running"} 1
+# HELP kubeblocks_components The number of cluster components, by workload type, definition and phase. The workload type is empty for the components of ComponentDefinition.
+# TYPE kubeblocks_components gauge
+kubeblocks_components{definition="mysql",namespace="%[1]s",phase="Running",workload_type="Consensus"} 1
+kubeblocks_components{definition="postgresql-14",namespace="%[1]s",phase="Creating",workload_type=""} 1
+kubeblocks_components{definition="redis-7",namespace="%[1]s",phase="Creating",workload_type=""} 1
+# HELP kubeblocks_pods The number of pods managed by KubeBlocks, by role.
+# TYPE kubeblocks_pods gauge
+kubeblocks_pods{namespace="%[1]s",role="follower"} 2
+kubeblocks_pods{namespace="%[1]s",role="leader"} 1
+# HELP kubeblocks_opsrequests_pending The number of OpsRequests not completed yet, by type and phase.
+# TYPE kubeblocks_opsrequests_pending gauge
+kubeblocks_opsrequests_pending{namespace="%[1]s",phase="Running",type="Restart"} 1
+`, testCtx.DefaultNamespace)
+		Expect(testutil.CollectAndCompare(collector, strings.NewReader(expected))).Should(Succeed())
+	})
+
+	It("exports no gauges until elected as the leader", func() {
+		elected := make(chan struct{})
+		collector := NewFleetCollector(k8sClient, elected)
+		Expect(testutil.CollectAndCount(collector)).Should(BeZero())
+
+		close(elected)
+		Expect(testutil.CollectAndCount(collector)).ShouldNot(BeZero())
+	})
+})
@@ -85,6 +85,21 @@ func (factory *MockClusterVersionFactory) AddContainerShort(name string, image s
 	})
 }
 
+func (factory *MockClusterVersionFactory) AddUpgradePreCheck(name string, image string, command ...string) *MockClusterVersionFactory {
+	comps := factory.Get().Spec.ComponentVersions
+	if len(comps) > 0 {
+		comp := comps[len(comps)-1]
+		comp.UpgradePreChecks = append(comp.UpgradePreChecks, appsv1alpha1.UpgradePreCheck{
+			Name:    name,
+			Image:   image,
+			Command: command,
+		})
+		comps[len(comps)-1] = comp
+	}
+	factory.Get().Spec.ComponentVersions = comps
+	return factory
+}
+
 func (factory *MockClusterVersionFactory) AddConfigTemplate(name string,
 	configTemplateRef string, configConstraintRef string, volumeName string) *MockClusterVersionFactory {
 	comps := factory.Get().Spec.ComponentVersions
@@ -264,6 +264,11 @@ func (f *MockComponentDefinitionFactory) SetUpdateStrategy(strategy *appsv1alpha
 	return f
 }
 
+func (f *MockComponentDefinitionFactory) SetSnapshotHook(hook *appsv1alpha1.SnapshotHook) *MockComponentDefinitionFactory {
+	f.Get().Spec.SnapshotHook = hook
+	return f
+}
+
 func (f *MockComponentDefinitionFactory) AddRole(name string, serviceable, writable bool) *MockComponentDefinitionFactory {
 	role := appsv1alpha1.ReplicaRole{
 		Name:        name,
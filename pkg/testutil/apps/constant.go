@@ -338,7 +338,9 @@ var (
 		},
 		LifecycleActions: &appsv1alpha1.ComponentLifecycleActions{
 			PostProvision: defaultLifecycleActionHandler,
-			PreTerminate:  defaultLifecycleActionHandler,
+			PreTerminate: &appsv1alpha1.PreTerminateActionHandler{
+				LifecycleActionHandler: *defaultLifecycleActionHandler,
+			},
 			RoleProbe: &appsv1alpha1.RoleProbe{
 				LifecycleActionHandler: *defaultLifecycleActionHandler,
 				PeriodSeconds:          1,
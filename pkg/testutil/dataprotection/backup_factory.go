@@ -51,6 +51,11 @@ func (f *MockBackupFactory) SetBackupMethod(backupMethod string) *MockBackupFact
 	return f
 }
 
+func (f *MockBackupFactory) SetParentBackupName(parentBackupName string) *MockBackupFactory {
+	f.Get().Spec.ParentBackupName = parentBackupName
+	return f
+}
+
 func (f *MockBackupFactory) SetLabels(labels map[string]string) *MockBackupFactory {
 	f.Get().SetLabels(labels)
 	return f
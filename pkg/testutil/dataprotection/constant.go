@@ -24,14 +24,16 @@ const (
 	ComponentName = "test-comp"
 	ContainerName = "test-container"
 
-	BackupName         = "test-backup"
-	BackupRepoName     = "test-repo"
-	BackupPolicyName   = "test-backup-policy"
-	BackupMethodName   = "xtrabackup"
-	VSBackupMethodName = "volume-snapshot"
-	BackupPathPrefix   = "/backup"
-	ActionSetName      = "xtrabackup"
-	VSActionSetName    = "volume-snapshot"
+	BackupName                  = "test-backup"
+	BackupRepoName              = "test-repo"
+	BackupPolicyName            = "test-backup-policy"
+	BackupMethodName            = "xtrabackup"
+	VSBackupMethodName          = "volume-snapshot"
+	IncrementalBackupMethodName = "xtrabackup-inc"
+	BackupPathPrefix            = "/backup"
+	ActionSetName               = "xtrabackup"
+	VSActionSetName             = "volume-snapshot"
+	IncrementalActionSetName    = "xtrabackup-inc"
 
 	DataVolumeName      = "data"
 	DataVolumeMountPath = "/data"
@@ -0,0 +1,150 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package testutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrLeadershipLost is returned by the writes of the LeaderClient once the leadership is lost.
+var ErrLeadershipLost = errors.New("the operator leadership is lost")
+
+// LeaderClient wraps the client of the operator leader, and fails all the writes once the given number of writes
+// are done, to simulate that the leadership moves in the middle of a reconciliation.
+type LeaderClient struct {
+	client.Client
+	mu         sync.Mutex
+	writesLeft int
+	lost       bool
+}
+
+var _ client.Client = &LeaderClient{}
+
+// NewLeaderClient returns a client which loses the leadership after @writes writes are done.
+func NewLeaderClient(cli client.Client, writes int) *LeaderClient {
+	return &LeaderClient{Client: cli, writesLeft: writes}
+}
+
+// Lost returns true if the leadership has been lost, that is, some writes are refused.
+func (c *LeaderClient) Lost() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lost
+}
+
+func (c *LeaderClient) write() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.writesLeft <= 0 {
+		c.lost = true
+		return ErrLeadershipLost
+	}
+	c.writesLeft--
+	return nil
+}
+
+func (c *LeaderClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.write(); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *LeaderClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.write(); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *LeaderClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.write(); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *LeaderClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.write(); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *LeaderClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if err := c.write(); err != nil {
+		return err
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *LeaderClient) Status() client.SubResourceWriter {
+	return &leaderSubResourceClient{SubResourceWriter: c.Client.Status(), leader: c}
+}
+
+func (c *LeaderClient) SubResource(subResource string) client.SubResourceClient {
+	return &leaderSubResourceClient{SubResourceWriter: c.Client.SubResource(subResource), leader: c,
+		reader: c.Client.SubResource(subResource)}
+}
+
+type leaderSubResourceClient struct {
+	client.SubResourceWriter
+	reader client.SubResourceReader
+	leader *LeaderClient
+}
+
+func (c *leaderSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return c.reader.Get(ctx, obj, subResource, opts...)
+}
+
+func (c *leaderSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if err := c.leader.write(); err != nil {
+		return err
+	}
+	return c.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *leaderSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if err := c.leader.write(); err != nil {
+		return err
+	}
+	return c.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (c *leaderSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if err := c.leader.write(); err != nil {
+		return err
+	}
+	return c.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// RunTakeover runs @reconcile by the leader which loses the leadership after @writes writes are done,
+// and then runs it again by the new leader on the persisted state. @reconcile should build all the in-memory
+// state, e.g. the handlers and the objects read, from the given client, as a new operator replica does.
+// It returns whether the leadership is lost during the first run, and the error of the new leader.
+func RunTakeover(cli client.Client, writes int, reconcile func(cli client.Client) error) (bool, error) {
+	leader := NewLeaderClient(cli, writes)
+	_ = reconcile(leader)
+	return leader.Lost(), reconcile(cli)
+}